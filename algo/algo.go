@@ -0,0 +1,51 @@
+// Package algo 提供与容器配合使用的泛型算法：排序、划分、第 k 小元素选择以及有序序列的合并
+// 排序、划分和选择直接在切片上原地进行；Array 和 List 把同样的算法应用到动态数组和链表上，
+// 合并操作基于 iter.Seq，可以直接合并任意容器的 All 迭代器
+package algo
+
+import (
+	dynamicarray "godatastructure/array"
+	"godatastructure/list"
+)
+
+// Array 把 f 应用到动态数组的元素上
+// 元素先按下标顺序复制到切片中，f 原地修改切片后再逐个写回数组，例如：
+//
+//	algo.Array(a, func(s []int) { algo.QuickSort(s, cmp) })
+//
+// 时间复杂度: O(n) 加上 f 的开销
+func Array[T any](a dynamicarray.DynamicArray[T], f func([]T)) {
+	values := make([]T, 0, a.Len())
+	for v := range a.All() {
+		values = append(values, v)
+	}
+	f(values)
+	for i, v := range values {
+		a.Set(i, v)
+	}
+}
+
+// List 把 f 应用到链表的元素上
+// 链表按下标访问需要 O(n)，因此元素先复制到切片中，f 原地修改切片后按新的顺序重建链表。
+// 重建后原有的节点不再属于链表，之前通过 Find 得到的节点指针会失效
+// 时间复杂度: O(n) 加上 f 的开销
+func List[T comparable](l list.LinkedList[T], f func([]T)) {
+	values := l.ToSlice()
+	f(values)
+	l.Clear()
+	for _, v := range values {
+		l.Append(v)
+	}
+}
+
+// SortArray 使用归并排序对动态数组进行稳定排序
+// 时间复杂度: O(n log n)
+func SortArray[T any](a dynamicarray.DynamicArray[T], cmp func(a, b T) int) {
+	Array(a, func(s []T) { MergeSort(s, cmp) })
+}
+
+// SortList 使用归并排序对链表进行稳定排序
+// 时间复杂度: O(n log n)
+func SortList[T comparable](l list.LinkedList[T], cmp func(a, b T) int) {
+	List(l, func(s []T) { MergeSort(s, cmp) })
+}
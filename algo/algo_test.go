@@ -0,0 +1,71 @@
+package algo
+
+import (
+	"slices"
+	"testing"
+
+	dynamicarray "godatastructure/array"
+	"godatastructure/list"
+)
+
+// TestArray 测试在动态数组上应用算法
+func TestArray(t *testing.T) {
+	t.Run("SortArray", func(t *testing.T) {
+		a := dynamicarray.New[int]()
+		for _, v := range []int{5, 3, 9, 1, 7} {
+			a.Append(v)
+		}
+		SortArray(a, intCmp)
+		if got := slices.Collect(a.All()); !slices.Equal(got, []int{1, 3, 5, 7, 9}) {
+			t.Errorf("got %v", got)
+		}
+	})
+
+	t.Run("Select", func(t *testing.T) {
+		a := dynamicarray.New[int]()
+		for _, v := range []int{5, 3, 9, 1, 7} {
+			a.Append(v)
+		}
+		var median int
+		Array(a, func(s []int) { median = Select(s, len(s)/2, intCmp) })
+		if median != 5 || a.Len() != 5 {
+			t.Errorf("median = %d, Len() = %d", median, a.Len())
+		}
+	})
+
+	t.Run("空数组", func(t *testing.T) {
+		a := dynamicarray.New[int]()
+		SortArray(a, intCmp)
+		if !a.IsEmpty() {
+			t.Error("IsEmpty() = false")
+		}
+	})
+}
+
+// TestList 测试在链表上应用算法
+func TestList(t *testing.T) {
+	t.Run("SortList", func(t *testing.T) {
+		l := list.New[int]()
+		for _, v := range []int{5, 3, 9, 1, 7} {
+			l.Append(v)
+		}
+		SortList(l, intCmp)
+		if got := l.ToSlice(); !slices.Equal(got, []int{1, 3, 5, 7, 9}) {
+			t.Errorf("got %v", got)
+		}
+		if l.Size() != 5 {
+			t.Errorf("Size() = %d", l.Size())
+		}
+	})
+
+	t.Run("Partition", func(t *testing.T) {
+		l := list.New[int](list.WithArena(0))
+		for i := range 6 {
+			l.Append(i)
+		}
+		List(l, func(s []int) { StablePartition(s, func(v int) bool { return v%2 == 1 }) })
+		if got := l.ToSlice(); !slices.Equal(got, []int{1, 3, 5, 0, 2, 4}) {
+			t.Errorf("got %v", got)
+		}
+	})
+}
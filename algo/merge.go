@@ -0,0 +1,72 @@
+package algo
+
+import "iter"
+
+// Merge 惰性合并两个有序序列，返回按 cmp 升序产出所有元素的迭代器
+// 相等的元素先产出 a 中的，因此合并是稳定的；a 和 b 可以是任意容器的 All 迭代器
+// 时间复杂度: O(n+m)
+func Merge[T any](a, b iter.Seq[T], cmp func(a, b T) int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		nextA, stopA := iter.Pull(a)
+		defer stopA()
+		nextB, stopB := iter.Pull(b)
+		defer stopB()
+		va, okA := nextA()
+		vb, okB := nextB()
+		for okA && okB {
+			if cmp(vb, va) < 0 {
+				if !yield(vb) {
+					return
+				}
+				vb, okB = nextB()
+			} else {
+				if !yield(va) {
+					return
+				}
+				va, okA = nextA()
+			}
+		}
+		for ; okA; va, okA = nextA() {
+			if !yield(va) {
+				return
+			}
+		}
+		for ; okB; vb, okB = nextB() {
+			if !yield(vb) {
+				return
+			}
+		}
+	}
+}
+
+// MergeAll 惰性合并任意数量的有序序列
+// 两两分治合并，每个元素经过 O(log k) 次比较；相等的元素按序列的先后顺序产出
+// 时间复杂度: O(n log k)，k 为序列数量
+func MergeAll[T any](cmp func(a, b T) int, seqs ...iter.Seq[T]) iter.Seq[T] {
+	switch len(seqs) {
+	case 0:
+		return func(yield func(T) bool) {}
+	case 1:
+		return seqs[0]
+	}
+	mid := len(seqs) / 2
+	return Merge(MergeAll(cmp, seqs[:mid]...), MergeAll(cmp, seqs[mid:]...), cmp)
+}
+
+// MergeSlices 把两个有序切片合并为一个新的有序切片，相等的元素先取 a 中的
+// 时间复杂度: O(n+m)
+func MergeSlices[T any](a, b []T, cmp func(a, b T) int) []T {
+	result := make([]T, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		if cmp(b[j], a[i]) < 0 {
+			result = append(result, b[j])
+			j++
+		} else {
+			result = append(result, a[i])
+			i++
+		}
+	}
+	result = append(result, a[i:]...)
+	return append(result, b[j:]...)
+}
@@ -0,0 +1,130 @@
+package algo
+
+import (
+	"iter"
+	"slices"
+	"testing"
+
+	"godatastructure/list"
+)
+
+// TestMerge 测试合并两个有序序列
+func TestMerge(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b []int
+		want []int
+	}{
+		{"都为空", nil, nil, []int{}},
+		{"一侧为空", []int{1, 2}, nil, []int{1, 2}},
+		{"交错", []int{1, 3, 5}, []int{2, 4, 6, 8}, []int{1, 2, 3, 4, 5, 6, 8}},
+		{"含相等元素", []int{1, 2, 2}, []int{2, 3}, []int{1, 2, 2, 2, 3}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := slices.AppendSeq([]int{}, Merge(slices.Values(tt.a), slices.Values(tt.b), intCmp))
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Merge = %v, want %v", got, tt.want)
+			}
+			if got := MergeSlices(tt.a, tt.b, intCmp); !slices.Equal(got, tt.want) {
+				t.Errorf("MergeSlices = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("稳定", func(t *testing.T) {
+		type pair struct{ k, src int }
+		cmp := func(a, b pair) int { return a.k - b.k }
+		a := []pair{{1, 0}, {2, 0}}
+		b := []pair{{1, 1}, {2, 1}}
+		want := []pair{{1, 0}, {1, 1}, {2, 0}, {2, 1}}
+		if got := slices.Collect(Merge(slices.Values(a), slices.Values(b), cmp)); !slices.Equal(got, want) {
+			t.Errorf("Merge = %v", got)
+		}
+		if got := MergeSlices(a, b, cmp); !slices.Equal(got, want) {
+			t.Errorf("MergeSlices = %v", got)
+		}
+	})
+
+	t.Run("合并容器的迭代器", func(t *testing.T) {
+		l := list.New[int]()
+		for _, v := range []int{1, 4, 9} {
+			l.Append(v)
+		}
+		got := slices.Collect(Merge(l.All(), slices.Values([]int{2, 3, 10}), intCmp))
+		if !slices.Equal(got, []int{1, 2, 3, 4, 9, 10}) {
+			t.Errorf("Merge = %v", got)
+		}
+	})
+
+	t.Run("提前停止", func(t *testing.T) {
+		pulled := 0
+		counting := func(yield func(int) bool) {
+			for i := 0; ; i += 2 {
+				pulled++
+				if !yield(i) {
+					return
+				}
+			}
+		}
+		for v := range Merge(counting, slices.Values([]int{1, 3}), intCmp) {
+			if v == 2 {
+				break
+			}
+		}
+		if pulled != 2 {
+			t.Errorf("pulled = %d, want 2", pulled)
+		}
+	})
+}
+
+// TestMergeAll 测试合并多个有序序列
+func TestMergeAll(t *testing.T) {
+	t.Run("没有序列", func(t *testing.T) {
+		if got := slices.Collect(MergeAll(intCmp)); len(got) != 0 {
+			t.Errorf("MergeAll() = %v", got)
+		}
+	})
+
+	t.Run("多个序列", func(t *testing.T) {
+		var seqs []iter.Seq[int]
+		var want []int
+		for i := range 7 {
+			var s []int
+			for j := i; j < 50; j += i + 1 {
+				s = append(s, j)
+			}
+			want = append(want, s...)
+			seqs = append(seqs, slices.Values(s))
+		}
+		slices.Sort(want)
+		if got := slices.Collect(MergeAll(intCmp, seqs...)); !slices.Equal(got, want) {
+			t.Errorf("MergeAll = %v, want %v", got, want)
+		}
+	})
+}
+
+// BenchmarkMerge 测试合并两个有序切片与拼接后排序的性能
+func BenchmarkMerge(b *testing.B) {
+	a := benchData(5000)
+	c := benchData(5000)
+	slices.Sort(a)
+	slices.Sort(c)
+	b.Run("MergeSlices", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			MergeSlices(a, c, intCmp)
+		}
+	})
+	b.Run("Merge", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for range Merge(slices.Values(a), slices.Values(c), intCmp) {
+			}
+		}
+	})
+	b.Run("slices.Sort", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			s := append(slices.Clone(a), c...)
+			slices.Sort(s)
+		}
+	})
+}
@@ -0,0 +1,63 @@
+package algo
+
+// Partition 把 s 中满足 pred 的元素移动到前部，返回满足 pred 的元素数量 k
+// 返回后 s[:k] 都满足 pred，s[k:] 都不满足；不保持元素的相对顺序
+// 时间复杂度: O(n)
+func Partition[T any](s []T, pred func(T) bool) int {
+	k := 0
+	for i, v := range s {
+		if pred(v) {
+			s[k], s[i] = s[i], s[k]
+			k++
+		}
+	}
+	return k
+}
+
+// StablePartition 与 Partition 相同，但保持两部分内元素的相对顺序，需要 O(n) 的额外空间
+// 时间复杂度: O(n)
+func StablePartition[T any](s []T, pred func(T) bool) int {
+	rest := make([]T, 0, len(s))
+	k := 0
+	for _, v := range s {
+		if pred(v) {
+			s[k] = v
+			k++
+		} else {
+			rest = append(rest, v)
+		}
+	}
+	copy(s[k:], rest)
+	return k
+}
+
+// Select 返回 s 中第 k 小的元素，k 从0开始
+// 使用快速选择原地重排 s，返回后 s[k] 即为结果，s[:k] 中的元素都不大于它，s[k+1:] 中的元素都不小于它
+// 参数：
+//   - s: 要选择的切片，会被重排
+//   - k: 元素的名次，必须满足 0 <= k < len(s)，否则 panic
+//   - cmp: 比较函数
+//
+// 返回：
+//   - T: 第 k 小的元素
+//
+// 时间复杂度: 平均 O(n)
+func Select[T any](s []T, k int, cmp func(a, b T) int) T {
+	if k < 0 || k >= len(s) {
+		panic("索引越界")
+	}
+	lo, hi := 0, len(s)
+	for hi-lo > insertionThreshold {
+		lt, gt := partition3(s[lo:hi], cmp)
+		switch {
+		case k < lo+lt:
+			hi = lo + lt
+		case k >= lo+gt:
+			lo += gt
+		default:
+			return s[k]
+		}
+	}
+	insertionSort(s[lo:hi], cmp)
+	return s[k]
+}
@@ -0,0 +1,101 @@
+package algo
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+// TestPartition 测试划分
+func TestPartition(t *testing.T) {
+	even := func(v int) bool { return v%2 == 0 }
+
+	t.Run("Partition", func(t *testing.T) {
+		s := []int{1, 2, 3, 4, 5, 6, 7}
+		k := Partition(s, even)
+		if k != 3 {
+			t.Fatalf("k = %d, want 3", k)
+		}
+		for i, v := range s {
+			if even(v) != (i < k) {
+				t.Errorf("s = %v 划分错误", s)
+				break
+			}
+		}
+		slices.Sort(s)
+		if !slices.Equal(s, []int{1, 2, 3, 4, 5, 6, 7}) {
+			t.Errorf("元素丢失: %v", s)
+		}
+	})
+
+	t.Run("StablePartition", func(t *testing.T) {
+		s := []int{1, 2, 3, 4, 5, 6, 7}
+		k := StablePartition(s, even)
+		if k != 3 || !slices.Equal(s, []int{2, 4, 6, 1, 3, 5, 7}) {
+			t.Errorf("k = %d, s = %v", k, s)
+		}
+	})
+
+	t.Run("空切片", func(t *testing.T) {
+		if k := Partition(nil, even); k != 0 {
+			t.Errorf("k = %d", k)
+		}
+		if k := StablePartition(nil, even); k != 0 {
+			t.Errorf("k = %d", k)
+		}
+	})
+}
+
+// TestSelect 测试第 k 小元素选择
+func TestSelect(t *testing.T) {
+	r := rand.New(rand.NewPCG(5, 6))
+	for _, n := range []int{1, 5, 13, 100, 1000} {
+		s := make([]int, n)
+		for i := range s {
+			s[i] = r.IntN(n/2 + 1)
+		}
+		sorted := slices.Sorted(slices.Values(s))
+		for _, k := range []int{0, n / 3, n / 2, n - 1} {
+			work := slices.Clone(s)
+			if got := Select(work, k, intCmp); got != sorted[k] {
+				t.Errorf("n=%d: Select(%d) = %d, want %d", n, k, got, sorted[k])
+			}
+			if work[k] != sorted[k] {
+				t.Errorf("n=%d: s[%d] = %d, want %d", n, k, work[k], sorted[k])
+			}
+			for i, v := range work {
+				if (i < k && v > work[k]) || (i > k && v < work[k]) {
+					t.Errorf("n=%d k=%d: 下标 %d 处的元素 %d 位置错误", n, k, i, v)
+					break
+				}
+			}
+		}
+	}
+
+	t.Run("越界时panic", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("期望 panic")
+			}
+		}()
+		Select([]int{1}, 1, intCmp)
+	})
+}
+
+// BenchmarkSelect 测试选择中位数与完整排序的性能
+func BenchmarkSelect(b *testing.B) {
+	data := benchData(10000)
+	s := make([]int, len(data))
+	b.Run("Select", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			copy(s, data)
+			Select(s, len(s)/2, intCmp)
+		}
+	})
+	b.Run("slices.Sort", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			copy(s, data)
+			slices.Sort(s)
+		}
+	})
+}
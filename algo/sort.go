@@ -0,0 +1,204 @@
+package algo
+
+import (
+	"math/bits"
+
+	"golang.org/x/exp/constraints"
+)
+
+// insertionThreshold 长度不超过该值的区间使用插入排序
+const insertionThreshold = 12
+
+// QuickSort 使用快速排序对 s 原地排序，不稳定
+// 枢轴取首、中、尾三个元素的中位数，递归过深时改用堆排序，因此最坏情况也是 O(n log n)
+// 时间复杂度: O(n log n)
+func QuickSort[T any](s []T, cmp func(a, b T) int) {
+	quickSort(s, cmp, 2*bits.Len(uint(len(s))))
+}
+
+// quickSort 对 s 进行快速排序，depth 为剩余允许的递归深度
+// 只对较短的一侧递归，较长的一侧在循环中处理，使栈深度不超过 O(log n)
+func quickSort[T any](s []T, cmp func(a, b T) int, depth int) {
+	for len(s) > insertionThreshold {
+		if depth == 0 {
+			HeapSort(s, cmp)
+			return
+		}
+		depth--
+		lt, gt := partition3(s, cmp)
+		if lt < len(s)-gt {
+			quickSort(s[:lt], cmp, depth)
+			s = s[gt:]
+		} else {
+			quickSort(s[gt:], cmp, depth)
+			s = s[:lt]
+		}
+	}
+	insertionSort(s, cmp)
+}
+
+// medianOfThree 把首、中、尾三个元素的中位数交换到 s[0] 作为枢轴
+func medianOfThree[T any](s []T, cmp func(a, b T) int) {
+	a, b, c := 0, len(s)/2, len(s)-1
+	if cmp(s[b], s[a]) < 0 {
+		a, b = b, a
+	}
+	if cmp(s[c], s[b]) < 0 {
+		b = c
+		if cmp(s[b], s[a]) < 0 {
+			b = a
+		}
+	}
+	s[0], s[b] = s[b], s[0]
+}
+
+// partition3 以三数取中得到的元素为枢轴进行三路划分
+// 返回后 s[:lt] 小于枢轴，s[lt:gt] 等于枢轴，s[gt:] 大于枢轴；大量重复元素时不会退化
+func partition3[T any](s []T, cmp func(a, b T) int) (lt, gt int) {
+	medianOfThree(s, cmp)
+	pivot := s[0]
+	lt, i, gt := 0, 1, len(s)
+	for i < gt {
+		switch c := cmp(s[i], pivot); {
+		case c < 0:
+			s[lt], s[i] = s[i], s[lt]
+			lt++
+			i++
+		case c > 0:
+			gt--
+			s[i], s[gt] = s[gt], s[i]
+		default:
+			i++
+		}
+	}
+	return lt, gt
+}
+
+// insertionSort 使用插入排序对 s 原地稳定排序
+func insertionSort[T any](s []T, cmp func(a, b T) int) {
+	for i := 1; i < len(s); i++ {
+		v := s[i]
+		j := i
+		for j > 0 && cmp(v, s[j-1]) < 0 {
+			s[j] = s[j-1]
+			j--
+		}
+		s[j] = v
+	}
+}
+
+// MergeSort 使用归并排序对 s 原地稳定排序，需要 O(n) 的额外空间
+// 时间复杂度: O(n log n)
+func MergeSort[T any](s []T, cmp func(a, b T) int) {
+	if len(s) <= insertionThreshold {
+		insertionSort(s, cmp)
+		return
+	}
+	buf := make([]T, len(s))
+	mergeSort(s, buf, cmp)
+}
+
+// mergeSort 对 s 进行归并排序，buf 是长度与 s 相同的辅助空间
+func mergeSort[T any](s, buf []T, cmp func(a, b T) int) {
+	if len(s) <= insertionThreshold {
+		insertionSort(s, cmp)
+		return
+	}
+	mid := len(s) / 2
+	mergeSort(s[:mid], buf[:mid], cmp)
+	mergeSort(s[mid:], buf[mid:], cmp)
+	if cmp(s[mid], s[mid-1]) >= 0 {
+		return
+	}
+	copy(buf, s)
+	i, j, k := 0, mid, 0
+	for i < mid && j < len(s) {
+		// 相等时取左半部分的元素以保证稳定
+		if cmp(buf[j], buf[i]) < 0 {
+			s[k] = buf[j]
+			j++
+		} else {
+			s[k] = buf[i]
+			i++
+		}
+		k++
+	}
+	k += copy(s[k:], buf[i:mid])
+	copy(s[k:], buf[j:])
+}
+
+// HeapSort 使用堆排序对 s 原地排序，不稳定，不需要额外空间
+// 时间复杂度: O(n log n)
+func HeapSort[T any](s []T, cmp func(a, b T) int) {
+	n := len(s)
+	for i := n/2 - 1; i >= 0; i-- {
+		siftDown(s, i, n, cmp)
+	}
+	for end := n - 1; end > 0; end-- {
+		s[0], s[end] = s[end], s[0]
+		siftDown(s, 0, end, cmp)
+	}
+}
+
+// siftDown 在 s[:n] 构成的最大堆中将下标 i 处的元素下沉到正确位置
+func siftDown[T any](s []T, i, n int, cmp func(a, b T) int) {
+	for {
+		largest := i
+		left, right := 2*i+1, 2*i+2
+		if left < n && cmp(s[left], s[largest]) > 0 {
+			largest = left
+		}
+		if right < n && cmp(s[right], s[largest]) > 0 {
+			largest = right
+		}
+		if largest == i {
+			return
+		}
+		s[i], s[largest] = s[largest], s[i]
+		i = largest
+	}
+}
+
+// RadixSort 使用最低位优先的基数排序对整数切片原地升序排序，稳定，需要 O(n) 的额外空间
+// 每趟按一个字节分桶，有符号整数通过翻转符号位转换为无符号顺序；
+// 所有元素在某个字节上相同时跳过该趟
+// 时间复杂度: O(w·n)，w 为整数类型的字节数
+func RadixSort[T constraints.Integer](s []T) {
+	if len(s) < 2 {
+		return
+	}
+	width := 0
+	for x := T(1); x != 0; x <<= 1 {
+		width++
+	}
+	var signBit uint64
+	if ^T(0) < 0 {
+		signBit = 1 << (width - 1)
+	}
+	key := func(v T) uint64 { return uint64(v) ^ signBit }
+
+	src, dst := s, make([]T, len(s))
+	for shift := 0; shift < width; shift += 8 {
+		var count [256]int
+		for _, v := range src {
+			count[byte(key(v)>>shift)]++
+		}
+		if count[byte(key(src[0])>>shift)] == len(src) {
+			continue
+		}
+		pos := 0
+		for i, c := range count {
+			count[i] = pos
+			pos += c
+		}
+		for _, v := range src {
+			b := byte(key(v) >> shift)
+			dst[count[b]] = v
+			count[b]++
+		}
+		src, dst = dst, src
+	}
+	if &src[0] != &s[0] {
+		copy(s, src)
+	}
+}
@@ -0,0 +1,171 @@
+package algo
+
+import (
+	"math"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func intCmp(a, b int) int {
+	return a - b
+}
+
+// sorters 所有基于比较的排序函数
+var sorters = []struct {
+	name string
+	sort func(s []int, cmp func(a, b int) int)
+}{
+	{"QuickSort", QuickSort[int]},
+	{"MergeSort", MergeSort[int]},
+	{"HeapSort", HeapSort[int]},
+	{"RadixSort", func(s []int, _ func(a, b int) int) { RadixSort(s) }},
+}
+
+// inputs 返回用于测试排序的各种输入
+func inputs() map[string][]int {
+	r := rand.New(rand.NewPCG(1, 2))
+	random := make([]int, 1000)
+	dups := make([]int, 1000)
+	negative := make([]int, 1000)
+	for i := range random {
+		random[i] = r.IntN(100000)
+		dups[i] = r.IntN(5)
+		negative[i] = r.IntN(2000) - 1000
+	}
+	ascending := make([]int, 500)
+	descending := make([]int, 500)
+	for i := range ascending {
+		ascending[i] = i
+		descending[i] = 500 - i
+	}
+	return map[string][]int{
+		"空切片":  {},
+		"单个元素": {1},
+		"短切片":  {3, 1, 2},
+		"随机":   random,
+		"大量重复": dups,
+		"含负数":  negative,
+		"升序":   ascending,
+		"降序":   descending,
+		"极值":   {math.MaxInt, math.MinInt, 0, -1, 1, math.MinInt, math.MaxInt},
+	}
+}
+
+// TestSort 测试各排序函数的结果与 slices.Sort 一致
+func TestSort(t *testing.T) {
+	for _, sorter := range sorters {
+		for name, input := range inputs() {
+			t.Run(sorter.name+"/"+name, func(t *testing.T) {
+				got := slices.Clone(input)
+				want := slices.Clone(input)
+				slices.Sort(want)
+				sorter.sort(got, func(a, b int) int {
+					// 避免 a-b 在极值时溢出
+					switch {
+					case a < b:
+						return -1
+					case a > b:
+						return 1
+					}
+					return 0
+				})
+				if !slices.Equal(got, want) {
+					t.Errorf("排序结果错误: %v", got)
+				}
+			})
+		}
+	}
+}
+
+// TestMergeSortStable 测试归并排序的稳定性
+func TestMergeSortStable(t *testing.T) {
+	type pair struct{ k, v int }
+	s := make([]pair, 200)
+	for i := range s {
+		s[i] = pair{k: (i * 7) % 5, v: i}
+	}
+	MergeSort(s, func(a, b pair) int { return a.k - b.k })
+	for i := 1; i < len(s); i++ {
+		if s[i-1].k == s[i].k && s[i-1].v > s[i].v {
+			t.Fatalf("相等元素的相对顺序改变: %v, %v", s[i-1], s[i])
+		}
+	}
+}
+
+// TestRadixSortTypes 测试不同宽度和符号的整数类型
+func TestRadixSortTypes(t *testing.T) {
+	t.Run("int8", func(t *testing.T) {
+		s := []int8{127, -128, 0, -1, 5, -5}
+		RadixSort(s)
+		if !slices.Equal(s, []int8{-128, -5, -1, 0, 5, 127}) {
+			t.Errorf("got %v", s)
+		}
+	})
+
+	t.Run("uint16", func(t *testing.T) {
+		s := []uint16{65535, 0, 256, 255, 1}
+		RadixSort(s)
+		if !slices.Equal(s, []uint16{0, 1, 255, 256, 65535}) {
+			t.Errorf("got %v", s)
+		}
+	})
+
+	t.Run("uint64", func(t *testing.T) {
+		s := []uint64{math.MaxUint64, 1 << 63, 0, 1<<63 - 1}
+		RadixSort(s)
+		if !slices.Equal(s, []uint64{0, 1<<63 - 1, 1 << 63, math.MaxUint64}) {
+			t.Errorf("got %v", s)
+		}
+	})
+}
+
+// TestQuickSortAdversarial 测试快速排序在深度超限后改用堆排序
+func TestQuickSortAdversarial(t *testing.T) {
+	s := make([]int, 10000)
+	for i := range s {
+		s[i] = i % 2
+	}
+	calls := 0
+	QuickSort(s, func(a, b int) int {
+		calls++
+		return a - b
+	})
+	if !slices.IsSorted(s) {
+		t.Error("排序结果错误")
+	}
+	if calls > 20*len(s)*14 {
+		t.Errorf("比较次数过多: %d", calls)
+	}
+}
+
+// benchData 返回 n 个随机整数
+func benchData(n int) []int {
+	r := rand.New(rand.NewPCG(3, 4))
+	s := make([]int, n)
+	for i := range s {
+		s[i] = r.IntN(1 << 30)
+	}
+	return s
+}
+
+// BenchmarkSort 测试各排序函数与标准库的性能
+func BenchmarkSort(b *testing.B) {
+	data := benchData(10000)
+	run := func(name string, sort func([]int)) {
+		b.Run(name, func(b *testing.B) {
+			s := make([]int, len(data))
+			for i := 0; i < b.N; i++ {
+				copy(s, data)
+				sort(s)
+			}
+		})
+	}
+	run("QuickSort", func(s []int) { QuickSort(s, intCmp) })
+	run("MergeSort", func(s []int) { MergeSort(s, intCmp) })
+	run("HeapSort", func(s []int) { HeapSort(s, intCmp) })
+	run("RadixSort", RadixSort[int])
+	run("slices.SortFunc", func(s []int) { slices.SortFunc(s, intCmp) })
+	run("slices.SortStableFunc", func(s []int) { slices.SortStableFunc(s, intCmp) })
+	run("slices.Sort", slices.Sort[[]int])
+}
@@ -2,6 +2,10 @@ package dynamicarray
 
 import (
 	"errors"
+	"iter"
+
+	"godatastructure/codec"
+	"godatastructure/metrics"
 )
 
 // 常量定义
@@ -14,29 +18,67 @@ const (
 // 支持泛型类型 T
 // 实现了自动扩容和缩容的动态数组数据结构
 type DynamicArray[T any] interface {
-	Append(value T)                  // 在数组末尾添加元素
-	Insert(index int, value T) error // 在指定位置插入元素
-	Remove(index int) (T, error)     // 删除指定位置的元素并返回
-	Get(index int) (T, error)        // 获取指定位置的元素
-	Set(index int, value T) error    // 设置指定位置的元素
-	Len() int                        // 获取数组当前长度
-	Cap() int                        // 获取数组当前容量
+	Append(value T)                         // 在数组末尾添加元素
+	Insert(index int, value T) error        // 在指定位置插入元素
+	Remove(index int) (T, error)            // 删除指定位置的元素并返回
+	Get(index int) (T, error)               // 获取指定位置的元素
+	Set(index int, value T) error           // 设置指定位置的元素
+	Len() int                               // 获取数组当前长度
+	Cap() int                               // 获取数组当前容量
+	Size() int                              // 获取数组当前长度，与 Len 相同
+	IsEmpty() bool                          // 检查数组是否为空
+	Clear()                                 // 清空数组并恢复初始容量
+	All() iter.Seq[T]                       // 返回按下标顺序遍历的迭代器
+	codec.Marshaler                         // 二进制与 JSON 序列化，按下标顺序编码
+	Clone(copier func(T) T) DynamicArray[T] // 返回容量相同的深拷贝，copier 为 nil 时直接复制元素
 }
 
 // dynamicArray 动态数组实现
 type dynamicArray[T any] struct {
-	data     []T // 底层切片
-	size     int // 当前元素数量
-	capacity int // 当前容量
+	data     []T              // 底层切片
+	size     int              // 当前元素数量
+	capacity int              // 当前容量
+	recorder metrics.Recorder // 指标记录器，为 nil 时不记录
+}
+
+// Option 动态数组的可选配置
+type Option func(*options)
+
+// options 动态数组的配置项
+type options struct {
+	recorder metrics.Recorder
+}
+
+// WithRecorder 使动态数组把插入、删除、查找、更新和扩缩容事件报告给 r
+// Clone 得到的副本不会继承记录器
+func WithRecorder(r metrics.Recorder) Option {
+	return func(o *options) {
+		o.recorder = r
+	}
 }
 
 // New 创建新的动态数组
+// 参数：
+//   - opts: 可选配置，例如 WithRecorder
+//
 // 时间复杂度: O(1)
-func New[T any]() DynamicArray[T] {
+func New[T any](opts ...Option) DynamicArray[T] {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
 	return &dynamicArray[T]{
 		data:     make([]T, initialCapacity),
 		size:     0,
 		capacity: initialCapacity,
+		recorder: o.recorder,
+	}
+}
+
+// record 向记录器报告事件
+func (da *dynamicArray[T]) record(e metrics.Event) {
+	if da.recorder != nil {
+		da.recorder.Record(e, da.size)
 	}
 }
 
@@ -49,6 +91,7 @@ func (da *dynamicArray[T]) Append(value T) {
 	}
 	da.data[da.size] = value
 	da.size++
+	da.record(metrics.Insert)
 }
 
 // Insert 在指定索引位置插入元素
@@ -72,6 +115,7 @@ func (da *dynamicArray[T]) Insert(index int, value T) error {
 	copy(da.data[index+1:], da.data[index:da.size])
 	da.data[index] = value
 	da.size++
+	da.record(metrics.Insert)
 	return nil
 }
 
@@ -95,6 +139,7 @@ func (da *dynamicArray[T]) Remove(index int) (T, error) {
 	da.size--
 	var zero T
 	da.data[da.size] = zero // 清理最后一个元素
+	da.record(metrics.Delete)
 
 	// 缩容检查
 	if da.size > 0 && float64(da.size)/float64(da.capacity) <= shrinkFactor {
@@ -114,6 +159,7 @@ func (da *dynamicArray[T]) resize(newCapacity int) {
 	copy(newData, da.data[:da.size])
 	da.data = newData
 	da.capacity = newCapacity
+	da.record(metrics.Resize)
 }
 
 // Get 获取指定索引位置的元素
@@ -123,6 +169,7 @@ func (da *dynamicArray[T]) Get(index int) (T, error) {
 		var zero T
 		return zero, errors.New("索引越界")
 	}
+	da.record(metrics.Lookup)
 	return da.data[index], nil
 }
 
@@ -133,6 +180,7 @@ func (da *dynamicArray[T]) Set(index int, value T) error {
 		return errors.New("索引越界")
 	}
 	da.data[index] = value
+	da.record(metrics.Update)
 	return nil
 }
 
@@ -147,3 +195,91 @@ func (da *dynamicArray[T]) Len() int {
 func (da *dynamicArray[T]) Cap() int {
 	return da.capacity
 }
+
+// Size 返回数组中元素的个数，与 Len 相同
+// 时间复杂度: O(1)
+func (da *dynamicArray[T]) Size() int {
+	return da.size
+}
+
+// IsEmpty 检查数组是否为空
+// 时间复杂度: O(1)
+func (da *dynamicArray[T]) IsEmpty() bool {
+	return da.size == 0
+}
+
+// Clear 清空数组并恢复初始容量
+// 时间复杂度: O(1)
+func (da *dynamicArray[T]) Clear() {
+	da.data = make([]T, initialCapacity)
+	da.size = 0
+	da.capacity = initialCapacity
+}
+
+// All 返回按下标顺序遍历的迭代器
+// 时间复杂度: O(n)
+func (da *dynamicArray[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := 0; i < da.size; i++ {
+			if !yield(da.data[i]) {
+				return
+			}
+		}
+	}
+}
+
+// MarshalBinary 使用 codec.For[T] 返回的元素编解码器把数组编码为二进制，按下标顺序编码
+// 实现 encoding.BinaryMarshaler 接口
+func (da *dynamicArray[T]) MarshalBinary() ([]byte, error) {
+	return codec.EncodeSeq(da.All(), codec.For[T]())
+}
+
+// UnmarshalBinary 从 MarshalBinary 生成的数据中解码，替换数组中的所有元素
+// 解码失败时数组保持不变，实现 encoding.BinaryUnmarshaler 接口
+func (da *dynamicArray[T]) UnmarshalBinary(data []byte) error {
+	values, err := codec.DecodeSeq(data, codec.For[T]())
+	if err != nil {
+		return err
+	}
+	da.load(values)
+	return nil
+}
+
+// MarshalJSON 把数组编码为 JSON 数组，实现 json.Marshaler 接口
+func (da *dynamicArray[T]) MarshalJSON() ([]byte, error) {
+	return codec.MarshalJSONSeq(da.All())
+}
+
+// UnmarshalJSON 从 JSON 数组中解码，替换数组中的所有元素
+// 解码失败时数组保持不变，实现 json.Unmarshaler 接口
+func (da *dynamicArray[T]) UnmarshalJSON(data []byte) error {
+	values, err := codec.UnmarshalJSONSeq[T](data)
+	if err != nil {
+		return err
+	}
+	da.load(values)
+	return nil
+}
+
+// load 清空数组后按顺序放入 values 中的元素
+func (da *dynamicArray[T]) load(values []T) {
+	da.Clear()
+	for _, v := range values {
+		da.Append(v)
+	}
+}
+
+// Clone 返回容量相同的深拷贝
+// copier 用于复制每个元素，为 nil 时直接赋值
+// 时间复杂度: O(n)
+func (da *dynamicArray[T]) Clone(copier func(T) T) DynamicArray[T] {
+	data := make([]T, da.capacity)
+	for i := 0; i < da.size; i++ {
+		v := da.data[i]
+		if copier != nil {
+			v = copier(v)
+		}
+		data[i] = v
+	}
+	return &dynamicArray[T]{data: data, size: da.size, capacity: da.capacity}
+}
@@ -1,7 +1,11 @@
 package dynamicarray
 
 import (
+	"encoding/json"
+	"slices"
 	"testing"
+
+	"godatastructure/metrics"
 )
 
 // TestNew 测试创建新的动态数组
@@ -166,3 +170,117 @@ func TestShrink(t *testing.T) {
 		t.Errorf("期望容量减小, 原容量: %d, 现容量: %d", originalCap, arr.Cap())
 	}
 }
+
+// TestAll 测试按下标顺序遍历
+func TestAll(t *testing.T) {
+	da := New[int]()
+	for i := 0; i < 10; i++ {
+		da.Append(i)
+	}
+	da.Remove(0)
+	var got []int
+	for v := range da.All() {
+		got = append(got, v)
+	}
+	if !slices.Equal(got, []int{1, 2, 3, 4, 5, 6, 7, 8, 9}) {
+		t.Errorf("迭代结果错误: %v", got)
+	}
+}
+
+// TestMarshal 测试动态数组的二进制与 JSON 序列化
+func TestMarshal(t *testing.T) {
+	src := New[int]()
+	for _, v := range []int{1, 2, 3, 4, 5} {
+		src.Append(v)
+	}
+	want := slices.Collect(src.All())
+
+	t.Run("二进制", func(t *testing.T) {
+		data, err := src.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		dst := New[int]()
+		if err := dst.UnmarshalBinary(data); err != nil {
+			t.Fatal(err)
+		}
+		if got := slices.Collect(dst.All()); !slices.Equal(got, want) {
+			t.Errorf("期望%v，实际为%v", want, got)
+		}
+		if err := dst.UnmarshalBinary(data[:len(data)-1]); err == nil {
+			t.Error("截断的数据应返回错误")
+		}
+		if dst.Size() != len(want) {
+			t.Error("解码失败时应保持不变")
+		}
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		data, err := json.Marshal(src)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != `[1,2,3,4,5]` {
+			t.Errorf("JSON 为%s", data)
+		}
+		dst := New[int]()
+		if err := json.Unmarshal(data, dst); err != nil {
+			t.Fatal(err)
+		}
+		if got := slices.Collect(dst.All()); !slices.Equal(got, want) {
+			t.Errorf("期望%v，实际为%v", want, got)
+		}
+	})
+}
+
+// TestClone 测试动态数组的深拷贝
+func TestClone(t *testing.T) {
+	da := New[[]int]()
+	for i := 0; i < 5; i++ {
+		da.Append([]int{i})
+	}
+	clone := da.Clone(slices.Clone)
+	if clone.Len() != 5 || clone.Cap() != da.Cap() {
+		t.Fatalf("拷贝的长度和容量应与原数组相同")
+	}
+	v, _ := da.Get(0)
+	v[0] = 100
+	da.Set(1, nil)
+	if v, _ := clone.Get(0); v[0] != 0 {
+		t.Errorf("深拷贝不应受原元素修改的影响，实际为%v", v)
+	}
+	if v, _ := clone.Get(1); v == nil {
+		t.Error("修改原数组不应影响拷贝")
+	}
+}
+
+// TestWithRecorder 测试动态数组向记录器报告事件
+func TestWithRecorder(t *testing.T) {
+	var c metrics.Counters
+	da := New[int](WithRecorder(&c))
+	for i := 0; i < 8; i++ {
+		da.Append(i)
+	}
+	da.Insert(0, -1)
+	da.Get(0)
+	da.Set(0, 1)
+	for i := 0; i < 7; i++ {
+		da.Remove(0)
+	}
+	if c.Count(metrics.Insert) != 9 || c.Count(metrics.Delete) != 7 ||
+		c.Count(metrics.Lookup) != 1 || c.Count(metrics.Update) != 1 {
+		t.Errorf("事件计数错误: %v", c.Snapshot())
+	}
+	// 容量从4扩容到8、16，再缩容到8、4
+	if c.Count(metrics.Resize) != 4 {
+		t.Errorf("期望扩缩容4次，实际为%d", c.Count(metrics.Resize))
+	}
+	if c.Peak() != 9 {
+		t.Errorf("期望峰值为9，实际为%d", c.Peak())
+	}
+
+	da.Clone(nil).Append(0)
+	if c.Count(metrics.Insert) != 9 {
+		t.Error("拷贝不应继承记录器")
+	}
+}
@@ -0,0 +1,205 @@
+package binarytree
+
+import (
+	"fmt"
+
+	"godatastructure/codec"
+)
+
+// avlTree AVL 树，实现了 BinaryTree 接口
+// 每个节点左右子树的高度差不超过1，插入和删除后沿路径旋转恢复平衡，
+// 树高不超过 1.44·log2(n+2)，有序插入也不会退化成链表
+type avlTree[T any] struct {
+	binaryTree[T] // 复用查找与遍历方法
+}
+
+// NewAVL 创建一个新的 AVL 树，需要传入一个比较函数
+// 可选配置 opts 例如 WithArena
+func NewAVL[T any](cmp func(a, b T) int, opts ...Option) BinaryTree[T] {
+	return &avlTree[T]{newBinaryTree(cmp, opts)}
+}
+
+// Insert 插入元素，返回元素数量是否增加
+// 与已有元素相等时按 WithDuplicates 的设置处理，默认插入到其右子树
+// 时间复杂度: O(log n)
+func (t *avlTree[T]) Insert(value T) bool {
+	if t.dup != AllowDuplicates {
+		if n := t.find(t.root, value); n != nil {
+			return t.absorb(n, value)
+		}
+	}
+	t.root = t.insert(t.root, value)
+	t.size++
+	return true
+}
+
+// Remove 删除一个值等于 value 的元素，返回元素是否存在
+// CountDuplicates 模式下值出现多次时只减少一次计数
+// 时间复杂度: O(log n)
+func (t *avlTree[T]) Remove(value T) bool {
+	if n := t.find(t.root, value); n != nil && t.release(n) {
+		return true
+	}
+	var removed bool
+	t.root, removed = t.remove(t.root, value)
+	if removed {
+		t.size--
+	}
+	return removed
+}
+
+// RemoveAll 删除值等于 value 的所有元素，返回删除的元素数量
+// 时间复杂度: O(k log n)，k 为删除的元素数量
+func (t *avlTree[T]) RemoveAll(value T) int {
+	return removeAll(t, value)
+}
+
+// RemoveAndGet 删除一个值等于 value 的元素，返回树中存储的值，元素不存在时第二个返回值为 false
+// 时间复杂度: O(log n)
+func (t *avlTree[T]) RemoveAndGet(value T) (T, bool) {
+	return removeAndGet(t, value)
+}
+
+// Height 返回树高，即根节点记录的高度，空树为0
+// 时间复杂度: O(1)
+func (t *avlTree[T]) Height() int {
+	return heightOf(t.root)
+}
+
+// Clone 返回结构相同的 AVL 树深拷贝
+// copier 用于复制每个元素，为 nil 时直接赋值
+// 时间复杂度: O(n)
+func (t *avlTree[T]) Clone(copier func(T) T) BinaryTree[T] {
+	return &avlTree[T]{binaryTree[T]{root: cloneNode(t.root, copier), cmp: t.cmp, size: t.size, dup: t.dup}}
+}
+
+// Deserialize 从 Serialize 生成的数据中还原树的结构，替换树中的所有元素
+// 节点高度根据结构重新计算，还原出的树不满足 AVL 树的平衡条件时返回错误，树保持不变
+// 时间复杂度: O(n)
+func (t *avlTree[T]) Deserialize(data []byte) error {
+	return t.decode(data, nil, func(n *TreeNode[T]) error {
+		updateHeight(n)
+		if bf := balanceFactor(n); bf > 1 || bf < -1 {
+			return fmt.Errorf("%w: 元素 %v 的左右子树高度差为%d，不满足 AVL 树的平衡条件", codec.ErrFormat, n.Value, bf)
+		}
+		return nil
+	})
+}
+
+// insert 把 value 插入以 node 为根的子树，返回平衡后的子树根节点
+// 递归深度不超过树高，即 O(log n)
+func (t *avlTree[T]) insert(node *TreeNode[T], value T) *TreeNode[T] {
+	if node == nil {
+		n := t.newNode(value)
+		n.height = 1
+		return n
+	}
+	if t.cmp(value, node.Value) < 0 {
+		node.Left = t.insert(node.Left, value)
+	} else {
+		node.Right = t.insert(node.Right, value)
+	}
+	return rebalance(node)
+}
+
+// remove 从以 node 为根的子树中删除一个值等于 value 的节点，返回平衡后的子树根节点以及是否删除
+func (t *avlTree[T]) remove(node *TreeNode[T], value T) (*TreeNode[T], bool) {
+	if node == nil {
+		return nil, false
+	}
+	var removed bool
+	c := t.cmp(value, node.Value)
+	switch {
+	case c < 0:
+		node.Left, removed = t.remove(node.Left, value)
+	case c > 0:
+		node.Right, removed = t.remove(node.Right, value)
+	default:
+		removed = true
+		if node.Left == nil || node.Right == nil {
+			child := node.Left
+			if child == nil {
+				child = node.Right
+			}
+			t.nodes.Free(node)
+			return child, true
+		}
+		// 摘下右子树中最小的节点替换当前节点的值
+		var minNode *TreeNode[T]
+		node.Right, minNode = t.removeMin(node.Right)
+		node.Value, node.dups = minNode.Value, minNode.dups
+		t.nodes.Free(minNode)
+	}
+	if !removed {
+		return node, false
+	}
+	return rebalance(node), true
+}
+
+// removeMin 从以 node 为根的子树中摘下最小的节点，返回平衡后的子树根节点和被摘下的节点
+func (t *avlTree[T]) removeMin(node *TreeNode[T]) (*TreeNode[T], *TreeNode[T]) {
+	if node.Left == nil {
+		return node.Right, node
+	}
+	var minNode *TreeNode[T]
+	node.Left, minNode = t.removeMin(node.Left)
+	return rebalance(node), minNode
+}
+
+// heightOf 返回子树高度，空子树为0
+func heightOf[T any](n *TreeNode[T]) int {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
+
+// updateHeight 根据子节点重新计算节点高度
+func updateHeight[T any](n *TreeNode[T]) {
+	n.height = max(heightOf(n.Left), heightOf(n.Right)) + 1
+}
+
+// balanceFactor 返回左子树高度减去右子树高度
+func balanceFactor[T any](n *TreeNode[T]) int {
+	return heightOf(n.Left) - heightOf(n.Right)
+}
+
+// rebalance 更新节点高度，左右子树高度差为2时旋转，返回子树新的根节点
+func rebalance[T any](n *TreeNode[T]) *TreeNode[T] {
+	updateHeight(n)
+	switch bf := balanceFactor(n); {
+	case bf > 1:
+		if balanceFactor(n.Left) < 0 {
+			// 左右情形：先对左子节点左旋
+			n.Left = rotateLeft(n.Left)
+		}
+		return rotateRight(n)
+	case bf < -1:
+		if balanceFactor(n.Right) > 0 {
+			// 右左情形：先对右子节点右旋
+			n.Right = rotateRight(n.Right)
+		}
+		return rotateLeft(n)
+	}
+	return n
+}
+
+// rotateLeft 左旋，右子节点成为子树的根节点
+func rotateLeft[T any](n *TreeNode[T]) *TreeNode[T] {
+	r := n.Right
+	n.Right = r.Left
+	r.Left = n
+	updateHeight(n)
+	updateHeight(r)
+	return r
+}
+
+// rotateRight 右旋，左子节点成为子树的根节点
+func rotateRight[T any](n *TreeNode[T]) *TreeNode[T] {
+	l := n.Left
+	n.Left = l.Right
+	l.Right = n
+	updateHeight(n)
+	updateHeight(l)
+	return l
+}
@@ -0,0 +1,121 @@
+package binarytree
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// checkAVL 检查 AVL 树的有序性、平衡性和每个节点记录的高度，返回子树高度
+func checkAVL(t *testing.T, n *TreeNode[int]) int {
+	t.Helper()
+	if n == nil {
+		return 0
+	}
+	if n.Left != nil && n.Left.Value > n.Value {
+		t.Fatalf("节点%d的左子节点%d更大", n.Value, n.Left.Value)
+	}
+	if n.Right != nil && n.Right.Value < n.Value {
+		t.Fatalf("节点%d的右子节点%d更小", n.Value, n.Right.Value)
+	}
+	lh, rh := checkAVL(t, n.Left), checkAVL(t, n.Right)
+	if lh-rh > 1 || rh-lh > 1 {
+		t.Fatalf("节点%d左右子树高度分别为%d和%d，不平衡", n.Value, lh, rh)
+	}
+	if h := max(lh, rh) + 1; n.height != h {
+		t.Fatalf("节点%d记录的高度为%d，实际为%d", n.Value, n.height, h)
+	}
+	return n.height
+}
+
+// TestAVLTree 测试 AVL 树的基本操作
+func TestAVLTree(t *testing.T) {
+	tree := NewAVL(intCmp)
+	for i := range 1000 {
+		tree.Insert(i) // 有序插入
+	}
+	root := tree.(*avlTree[int]).root
+	checkAVL(t, root)
+	if h := tree.Height(); h > 14 {
+		t.Errorf("有序插入1000个元素后树高为%d，AVL 树应不超过14", h)
+	}
+
+	t.Run("Search", func(t *testing.T) {
+		for _, v := range []int{0, 500, 999} {
+			if node := tree.Search(v); node == nil || node.Value != v {
+				t.Errorf("未找到已插入的值: %d", v)
+			}
+		}
+		if tree.Search(1000) != nil {
+			t.Error("找到了不应存在的值1000")
+		}
+	})
+
+	t.Run("Remove", func(t *testing.T) {
+		for i := 0; i < 1000; i += 2 {
+			if !tree.Remove(i) {
+				t.Fatalf("删除%d失败", i)
+			}
+		}
+		if tree.Remove(0) {
+			t.Error("重复删除应该返回false")
+		}
+		checkAVL(t, tree.(*avlTree[int]).root)
+		if tree.Size() != 500 {
+			t.Errorf("Size() = %d，期望 500", tree.Size())
+		}
+		if result := inOrderValues(tree); len(result) != 500 || result[0] != 1 || !slices.IsSorted(result) {
+			t.Errorf("删除后中序遍历结果错误: %v", result)
+		}
+	})
+
+	t.Run("Clone", func(t *testing.T) {
+		clone := tree.Clone(nil)
+		checkAVL(t, clone.(*avlTree[int]).root)
+		clone.Insert(-1)
+		if tree.Search(-1) != nil {
+			t.Error("修改副本不应影响原树")
+		}
+	})
+
+	t.Run("Duplicate Insert", func(t *testing.T) {
+		tree := NewAVL(intCmp)
+		for range 10 {
+			tree.Insert(1)
+		}
+		checkAVL(t, tree.(*avlTree[int]).root)
+		if !tree.Remove(1) || tree.Size() != 9 || tree.Search(1) == nil {
+			t.Error("删除一个重复值后其余的应仍然存在")
+		}
+	})
+}
+
+// TestAVLTreeRandom 随机插入删除并与排序切片比较
+func TestAVLTreeRandom(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	tree := NewAVL(intCmp, WithArena(16))
+	var want []int
+	for i := range 3000 {
+		v := rng.Intn(200)
+		if rng.Intn(3) == 0 {
+			j, found := slices.BinarySearch(want, v)
+			if tree.Remove(v) != found {
+				t.Fatalf("Remove(%d) 的返回值应为 %v", v, found)
+			}
+			if found {
+				want = slices.Delete(want, j, j+1)
+			}
+		} else {
+			tree.Insert(v)
+			j, _ := slices.BinarySearch(want, v)
+			want = slices.Insert(want, j, v)
+		}
+		if i%100 == 0 {
+			checkAVL(t, tree.(*avlTree[int]).root)
+		}
+	}
+	checkAVL(t, tree.(*avlTree[int]).root)
+	if got := inOrderValues(tree); !slices.Equal(got, want) {
+		t.Errorf("中序遍历结果错误，期望 %v，得到 %v", want, got)
+	}
+}
@@ -1,132 +1,841 @@
 package binarytree
 
+import (
+	"iter"
+	"slices"
+
+	"godatastructure/internal/arena"
+)
+
 // TreeNode 定义了二叉树的节点
 type TreeNode[T any] struct {
-	Value T
-	Left  *TreeNode[T]
-	Right *TreeNode[T]
+	Value    T
+	Left     *TreeNode[T]
+	Right    *TreeNode[T]
+	height   int    // 以该节点为根的子树高度，仅 AVL 树维护
+	size     int    // 以该节点为根的子树中的元素数量（计入重复次数），仅树堆维护
+	priority uint32 // 随机优先级，仅树堆使用
+	dups     int    // 值额外出现的次数，只有 CountDuplicates 模式下会大于0
 }
 
+// DuplicatePolicy 插入与已有元素相等的值时的处理方式
+// 前三个取值的顺序与 rbtree.DuplicatePolicy 相同，OverwriteDuplicates 为本包新增
+type DuplicatePolicy uint8
+
+const (
+	AllowDuplicates     DuplicatePolicy = iota // 默认：每次插入都创建新节点，相等的值插入到已有节点的右子树
+	CountDuplicates                            // 多重集合：每个值只有一个节点，节点记录值出现的次数
+	RejectDuplicates                           // 严格集合：插入已存在的值时不做任何修改
+	OverwriteDuplicates                        // 覆盖：用新值替换已有节点的值，适合比较函数只比较键的场景
+)
+
 // BinaryTree 定义了二叉树的接口
 type BinaryTree[T any] interface {
-	Insert(value T)
+	Insert(value T) bool // 插入元素，按重复元素策略处理已存在的值，返回元素数量是否增加
 	Search(value T) *TreeNode[T]
-	Remove(value T) bool
-	PreOrderTraversal(func(T))
-	InOrderTraversal(func(T))
-	PostOrderTraversal(func(T))
+	Remove(value T) bool                  // 删除一个值等于 value 的元素，CountDuplicates 模式下只减少一次计数
+	RemoveAll(value T) int                // 删除值等于 value 的所有元素，返回删除的元素数量
+	RemoveAndGet(value T) (T, bool)       // 删除一个值等于 value 的元素，返回树中存储的值
+	RemoveFunc(pred func(T) bool) int     // 删除所有满足 pred 的元素，返回删除的元素数量
+	Count(value T) int                    // 返回值等于 value 的元素数量
+	PreOrderTraversal(func(T) bool)       // 前序遍历，回调返回 false 时停止
+	InOrderTraversal(func(T) bool)        // 中序遍历，回调返回 false 时停止
+	MorrisInOrderTraversal(func(T) bool)  // 不使用栈和递归的中序遍历，额外空间为 O(1)
+	PostOrderTraversal(func(T) bool)      // 后序遍历，回调返回 false 时停止
+	PreOrder() iter.Seq[T]                // 返回前序遍历的迭代器
+	InOrder() iter.Seq[T]                 // 返回中序遍历（升序）的迭代器，与 All 相同
+	PostOrder() iter.Seq[T]               // 返回后序遍历的迭代器
+	All() iter.Seq[T]                     // 返回中序遍历（升序）的迭代器
+	Leaves() []T                          // 按从左到右的顺序返回所有叶子节点的元素
+	Paths(fn func(path []T))              // 按从左到右的顺序把每条从根节点到叶子节点的路径传给 fn
+	Size() int                            // 返回元素数量，CountDuplicates 模式下计入重复次数
+	Height() int                          // 返回树高，即从根节点到最深节点经过的节点数量，空树为0
+	Depth(value T) int                    // 返回值等于 value 的节点的深度，根节点为0，不存在时返回-1
+	Min() (T, bool)                       // 返回最小元素，空树时第二个返回值为 false
+	Max() (T, bool)                       // 返回最大元素，空树时第二个返回值为 false
+	Kth(k int) (T, bool)                  // 返回第 k 小的元素（k 从0开始），k 越界时第二个返回值为 false
+	LCA(a, b T) (*TreeNode[T], bool)      // 返回值为 a 和 b 的两个节点的最近公共祖先，任一值不存在时第二个返回值为 false
+	Serialize() ([]byte, error)           // 按前序把树的结构和元素编码为二进制
+	Deserialize(data []byte) error        // 从 Serialize 生成的数据中还原完全相同的树结构
+	Invert()                              // 交换所有节点的左右子树，同时反转比较函数
+	IsSymmetric() bool                    // 检查树是否与其镜像相同
+	Validate() error                      // 检查二叉搜索树性质及各实现维护的附加信息，发现损坏时返回错误
+	IsBalanced() bool                     // 检查每个节点左右子树的高度差是否不超过1
+	IsEmpty() bool                        // 检查树是否为空
+	Clear()                               // 清空树
+	Compare(a, b T) int                   // 使用比较函数比较两个元素
+	Clone(copier func(T) T) BinaryTree[T] // 返回结构相同的深拷贝，copier 为 nil 时直接复制元素
+	String() string                       // 返回逐层缩进的树结构，用于调试
+	ToDOT() string                        // 返回 Graphviz DOT 格式的树结构
 }
 
 // binaryTree 实现了 BinaryTree 接口
 type binaryTree[T any] struct {
-	root *TreeNode[T]
-	cmp  func(a, b T) int // 比较函数，用于比较节点值
+	root     *TreeNode[T]
+	cmp      func(a, b T) int          // 比较函数，用于比较节点值
+	reversed func(a, b T) int          // 与 cmp 方向相反的比较函数，Invert 时与 cmp 交换
+	size     int                       // 元素数量（计入重复次数）
+	nodes    *arena.Arena[TreeNode[T]] // 节点分配器，为 nil 时直接分配
+	dup      DuplicatePolicy           // 插入重复值时的处理方式
+}
+
+// Option 二叉搜索树、伸展树、AVL 树和树堆的可选配置
+type Option func(*options)
+
+// options 二叉搜索树、伸展树、AVL 树和树堆的配置项
+type options struct {
+	arena    bool            // 是否使用节点分配器
+	slabSize int             // 分配器每块包含的节点数量
+	dup      DuplicatePolicy // 插入重复值时的处理方式
+}
+
+// WithArena 使树从按块分配的对象池中分配节点，并在删除节点和 Clear 时回收复用，
+// 以减少频繁插入删除时的垃圾回收压力。slabSize 为每块包含的节点数量，小于1时使用默认值。
+// 启用后，Search 返回的节点在被删除后可能被复用，不应再访问；Clone 得到的副本不会继承分配器
+func WithArena(slabSize int) Option {
+	return func(o *options) {
+		o.arena = true
+		o.slabSize = slabSize
+	}
+}
+
+// WithDuplicates 设置插入与已有元素相等的值时的处理方式，默认为 AllowDuplicates
+// 除 AllowDuplicates 外，树中不会有两个相等的节点：Remove 在 CountDuplicates 模式下只减少一次计数，
+// RemoveAll 删除该值的全部元素；Size、遍历和 Kth 都计入重复次数
+func WithDuplicates(p DuplicatePolicy) Option {
+	return func(o *options) {
+		o.dup = p
+	}
+}
+
+// newBinaryTree 按配置创建二叉搜索树，供 New、NewSplay、NewAVL 与 NewTreap 使用
+func newBinaryTree[T any](cmp func(a, b T) int, opts []Option) binaryTree[T] {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	t := binaryTree[T]{cmp: cmp, dup: o.dup}
+	if o.arena {
+		t.nodes = arena.New[TreeNode[T]](o.slabSize)
+	}
+	return t
 }
 
 // New 创建一个新的二叉树，需要传入一个比较函数
-func New[T any](cmp func(a, b T) int) BinaryTree[T] {
-	return &binaryTree[T]{cmp: cmp}
+// 可选配置 opts 例如 WithArena
+func New[T any](cmp func(a, b T) int, opts ...Option) BinaryTree[T] {
+	t := newBinaryTree(cmp, opts)
+	return &t
 }
 
-func (t *binaryTree[T]) Insert(value T) {
-	t.root = t.insertRec(t.root, value)
+// NewFromSorted 由按比较函数升序排列的元素直接构建高度平衡的二叉搜索树
+// 每次取中间的元素作为子树的根节点，各节点左右子树的大小相差不超过1，避免逐个插入有序数据时退化成链表
+// 参数：
+//   - cmp: 比较函数
+//   - values: 按升序排列的元素，可以包含重复的元素，按 WithDuplicates 的设置处理；未排序时会 panic
+//   - opts: 可选配置，例如 WithArena、WithDuplicates
+//
+// 时间复杂度: O(n)
+func NewFromSorted[T any](cmp func(a, b T) int, values []T, opts ...Option) BinaryTree[T] {
+	if !slices.IsSortedFunc(values, cmp) {
+		panic("元素必须按升序排列")
+	}
+	t := newBinaryTree(cmp, opts)
+	var counts []int // 每个值出现的次数，为 nil 时都是1
+	if t.dup != AllowDuplicates {
+		var unique []T
+		for i, v := range values {
+			if i > 0 && cmp(v, values[i-1]) == 0 {
+				switch t.dup {
+				case OverwriteDuplicates:
+					unique[len(unique)-1] = v
+				case CountDuplicates:
+					counts[len(counts)-1]++
+				}
+				continue
+			}
+			unique = append(unique, v)
+			if t.dup == CountDuplicates {
+				counts = append(counts, 1)
+			}
+		}
+		values = unique
+	}
+	t.root = t.build(values, counts)
+	t.size = len(values)
+	for _, c := range counts {
+		t.size += c - 1
+	}
+	return &t
 }
 
-func (t *binaryTree[T]) insertRec(node *TreeNode[T], value T) *TreeNode[T] {
-	if node == nil {
-		return &TreeNode[T]{Value: value}
+// build 以 values 中间的元素为根构建子树，counts 不为 nil 时为各元素出现的次数，递归深度为 O(log n)
+func (t *binaryTree[T]) build(values []T, counts []int) *TreeNode[T] {
+	if len(values) == 0 {
+		return nil
 	}
-	if t.cmp(value, node.Value) < 0 {
-		node.Left = t.insertRec(node.Left, value)
-	} else {
-		node.Right = t.insertRec(node.Right, value)
+	mid := len(values) / 2
+	n := t.newNode(values[mid])
+	var left, right []int
+	if counts != nil {
+		n.dups = counts[mid] - 1
+		left, right = counts[:mid], counts[mid+1:]
 	}
-	return node
+	n.Left = t.build(values[:mid], left)
+	n.Right = t.build(values[mid+1:], right)
+	return n
 }
 
-func (t *binaryTree[T]) Search(value T) *TreeNode[T] {
-	return t.searchRec(t.root, value)
+// newNode 分配值为 value 的节点
+func (t *binaryTree[T]) newNode(value T) *TreeNode[T] {
+	n := t.nodes.Alloc()
+	n.Value = value
+	return n
+}
+
+// absorb 按重复元素策略处理与 value 相等的已有节点 n，返回元素数量是否增加
+// 只在 AllowDuplicates 以外的模式下调用
+func (t *binaryTree[T]) absorb(n *TreeNode[T], value T) bool {
+	switch t.dup {
+	case OverwriteDuplicates:
+		n.Value = value
+	case CountDuplicates:
+		n.dups++
+		t.size++
+		return true
+	}
+	return false
+}
+
+// release 节点 n 的值出现多次时减少一次计数并返回 true，此时不需要摘除节点
+func (t *binaryTree[T]) release(n *TreeNode[T]) bool {
+	if n.dups > 0 {
+		n.dups--
+		t.size--
+		return true
+	}
+	return false
+}
+
+// count 返回节点的值出现的次数
+func (n *TreeNode[T]) count() int {
+	return n.dups + 1
+}
+
+// freeNodes 把以 n 为根的子树中的节点全部交还给分配器
+// 使用显式栈实现，退化成链表的树也不会耗尽调用栈
+func (t *binaryTree[T]) freeNodes(n *TreeNode[T]) {
+	if n == nil {
+		return
+	}
+	stack := []*TreeNode[T]{n}
+	for len(stack) > 0 {
+		n = stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if n.Left != nil {
+			stack = append(stack, n.Left)
+		}
+		if n.Right != nil {
+			stack = append(stack, n.Right)
+		}
+		t.nodes.Free(n)
+	}
 }
 
-func (t *binaryTree[T]) searchRec(node *TreeNode[T], value T) *TreeNode[T] {
-	if node == nil || t.cmp(value, node.Value) == 0 {
-		return node
+// Insert 插入元素，返回元素数量是否增加
+// 与已有元素相等时按 WithDuplicates 的设置处理，默认插入到其右子树
+// 时间复杂度: O(h)，h 为树高
+func (t *binaryTree[T]) Insert(value T) bool {
+	link := &t.root
+	for *link != nil {
+		c := t.cmp(value, (*link).Value)
+		if c == 0 && t.dup != AllowDuplicates {
+			return t.absorb(*link, value)
+		}
+		if c < 0 {
+			link = &(*link).Left
+		} else {
+			link = &(*link).Right
+		}
 	}
-	if t.cmp(value, node.Value) < 0 {
-		return t.searchRec(node.Left, value)
+	*link = t.newNode(value)
+	t.size++
+	return true
+}
+
+// Search 查找值等于 value 的节点，不存在时返回 nil
+// 时间复杂度: O(h)
+func (t *binaryTree[T]) Search(value T) *TreeNode[T] {
+	return t.find(t.root, value)
+}
+
+// find 在以 node 为根的子树中查找值等于 value 的节点，不存在时返回 nil
+func (t *binaryTree[T]) find(node *TreeNode[T], value T) *TreeNode[T] {
+	for node != nil {
+		c := t.cmp(value, node.Value)
+		if c == 0 {
+			return node
+		}
+		if c < 0 {
+			node = node.Left
+		} else {
+			node = node.Right
+		}
 	}
-	return t.searchRec(node.Right, value)
+	return nil
 }
 
+// Remove 删除一个值等于 value 的元素，返回元素是否存在
+// CountDuplicates 模式下值出现多次时只减少一次计数
+// 时间复杂度: O(h)
 func (t *binaryTree[T]) Remove(value T) bool {
-	var removed bool
-	t.root, removed = t.removeRec(t.root, value)
+	link := t.findLink(value)
+	if *link == nil {
+		return false
+	}
+	if !t.release(*link) {
+		t.unlink(link)
+		t.size--
+	}
+	return true
+}
+
+// RemoveAll 删除值等于 value 的所有元素，返回删除的元素数量
+// 时间复杂度: O(k·h)，k 为删除的元素数量
+func (t *binaryTree[T]) RemoveAll(value T) int {
+	return removeAll(t, value)
+}
+
+// removeAll 反复调用 tree.Remove 删除值等于 value 的所有元素，供各实现的 RemoveAll 使用
+func removeAll[T any](tree BinaryTree[T], value T) int {
+	removed := 0
+	for tree.Remove(value) {
+		removed++
+	}
 	return removed
 }
 
-func (t *binaryTree[T]) removeRec(node *TreeNode[T], value T) (*TreeNode[T], bool) {
-	if node == nil {
-		return nil, false
+// RemoveAndGet 删除一个值等于 value 的元素，返回树中存储的值，元素不存在时第二个返回值为 false
+// 比较函数只比较键时，可以用只填写键的 value 取出完整的元素
+// 时间复杂度: O(h)
+func (t *binaryTree[T]) RemoveAndGet(value T) (T, bool) {
+	return removeAndGet(t, value)
+}
+
+// removeAndGet 先查找再删除同一个元素，供各实现的 RemoveAndGet 使用
+// 各实现需要各自定义 RemoveAndGet，使 tree.Remove 调用到自己的 Remove
+// 各实现的 Remove 与 Search 沿相同的路径找到第一个相等的节点，因此删除的正是查找到的元素
+func removeAndGet[T any](tree BinaryTree[T], value T) (T, bool) {
+	n := tree.Search(value)
+	if n == nil {
+		var zero T
+		return zero, false
 	}
-	var removed bool
-	if t.cmp(value, node.Value) < 0 {
-		node.Left, removed = t.removeRec(node.Left, value)
-	} else if t.cmp(value, node.Value) > 0 {
-		node.Right, removed = t.removeRec(node.Right, value)
-	} else {
-		removed = true
-		if node.Left == nil {
-			return node.Right, true
-		} else if node.Right == nil {
-			return node.Left, true
+	v := n.Value
+	tree.Remove(value)
+	return v, true
+}
+
+// RemoveFunc 删除所有满足 pred 的元素，返回删除的元素数量
+// pred 对每个节点调用一次，CountDuplicates 模式下删除该值的全部出现；
+// 有元素被删除时剩余的节点被重新链接为高度平衡的树，否则树的结构保持不变
+// 时间复杂度: O(n)
+func (t *binaryTree[T]) RemoveFunc(pred func(T) bool) int {
+	kept, removed := t.prune(pred)
+	if removed > 0 {
+		t.root = relink(kept)
+	}
+	return removed
+}
+
+// prune 按中序找出所有满足 pred 的节点并交还给分配器，返回按中序排列的剩余节点和删除的元素数量
+// 所有节点都检查完后才修改树，pred 发生 panic 时树保持不变
+func (t *binaryTree[T]) prune(pred func(T) bool) ([]*TreeNode[T], int) {
+	var kept, dropped, stack []*TreeNode[T]
+	removed := 0
+	node := t.root
+	for node != nil || len(stack) > 0 {
+		for node != nil {
+			stack = append(stack, node)
+			node = node.Left
+		}
+		node = stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if pred(node.Value) {
+			dropped = append(dropped, node)
+			removed += node.count()
+		} else {
+			kept = append(kept, node)
+		}
+		node = node.Right
+	}
+	for _, n := range dropped {
+		t.nodes.Free(n)
+	}
+	t.size -= removed
+	return kept, removed
+}
+
+// relink 把按中序排列的节点重新链接为高度平衡的树并更新节点记录的高度，返回根节点
+// 各节点左右子树的大小相差不超过1，因此结果同样满足 AVL 树的平衡条件
+func relink[T any](nodes []*TreeNode[T]) *TreeNode[T] {
+	if len(nodes) == 0 {
+		return nil
+	}
+	mid := len(nodes) / 2
+	n := nodes[mid]
+	n.Left = relink(nodes[:mid])
+	n.Right = relink(nodes[mid+1:])
+	updateHeight(n)
+	return n
+}
+
+// Count 返回值等于 value 的元素数量
+// AllowDuplicates 模式下相等的节点经过旋转后可能分布在某个相等节点的两侧，因此在相等节点处继续搜索两棵子树
+// 时间复杂度: O(h + k)，k 为值等于 value 的节点数量
+func (t *binaryTree[T]) Count(value T) int {
+	count := 0
+	var stack []*TreeNode[T]
+	if t.root != nil {
+		stack = append(stack, t.root)
+	}
+	for len(stack) > 0 {
+		n := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		c := t.cmp(value, n.Value)
+		if c == 0 {
+			count += n.count()
+		}
+		if c <= 0 && n.Left != nil {
+			stack = append(stack, n.Left)
+		}
+		if c >= 0 && n.Right != nil {
+			stack = append(stack, n.Right)
+		}
+	}
+	return count
+}
+
+// findLink 返回指向值等于 value 的节点的指针（根指针或父节点的子节点指针）
+// 不存在时返回的指针指向 nil，即 value 应插入的位置
+func (t *binaryTree[T]) findLink(value T) **TreeNode[T] {
+	link := &t.root
+	for *link != nil {
+		c := t.cmp(value, (*link).Value)
+		if c == 0 {
+			break
+		}
+		if c < 0 {
+			link = &(*link).Left
 		} else {
-			// 找到右子树中最小的节点替换当前节点
-			minNode := t.findMin(node.Right)
-			node.Value = minNode.Value
-			node.Right, _ = t.removeRec(node.Right, minNode.Value)
+			link = &(*link).Right
 		}
 	}
-	return node, removed
+	return link
 }
 
-func (t *binaryTree[T]) findMin(node *TreeNode[T]) *TreeNode[T] {
-	current := node
-	for current.Left != nil {
-		current = current.Left
+// unlink 从树中摘除 *link 指向的节点并交还给分配器
+func (t *binaryTree[T]) unlink(link **TreeNode[T]) {
+	node := *link
+	switch {
+	case node.Left == nil:
+		*link = node.Right
+	case node.Right == nil:
+		*link = node.Left
+	default:
+		// 找到右子树中最小的节点替换当前节点
+		minLink := &node.Right
+		for (*minLink).Left != nil {
+			minLink = &(*minLink).Left
+		}
+		minNode := *minLink
+		node.Value, node.dups = minNode.Value, minNode.dups
+		*minLink = minNode.Right
+		node = minNode
+	}
+	t.nodes.Free(node)
+}
+
+// Size 返回元素数量，CountDuplicates 模式下计入重复次数
+// 时间复杂度: O(1)
+func (t *binaryTree[T]) Size() int {
+	return t.size
+}
+
+// Height 返回树高，即从根节点到最深节点经过的节点数量，空树为0
+// 按层遍历计数，退化成链表的树也不会耗尽调用栈
+// 时间复杂度: O(n)
+func (t *binaryTree[T]) Height() int {
+	height := 0
+	var level []*TreeNode[T]
+	if t.root != nil {
+		level = append(level, t.root)
+	}
+	for len(level) > 0 {
+		height++
+		var next []*TreeNode[T]
+		for _, n := range level {
+			if n.Left != nil {
+				next = append(next, n.Left)
+			}
+			if n.Right != nil {
+				next = append(next, n.Right)
+			}
+		}
+		level = next
+	}
+	return height
+}
+
+// Depth 返回值等于 value 的节点的深度，即从根节点到该节点经过的边数，根节点为0
+// 存在多个相等的元素时返回最浅的一个，不存在时返回-1；不会调整树的结构
+// 时间复杂度: O(h)
+func (t *binaryTree[T]) Depth(value T) int {
+	depth := 0
+	for node := t.root; node != nil; depth++ {
+		c := t.cmp(value, node.Value)
+		if c == 0 {
+			return depth
+		}
+		if c < 0 {
+			node = node.Left
+		} else {
+			node = node.Right
+		}
+	}
+	return -1
+}
+
+// Min 返回最小元素，即最左侧的节点，空树时第二个返回值为 false
+// 时间复杂度: O(h)
+func (t *binaryTree[T]) Min() (T, bool) {
+	if t.root == nil {
+		var zero T
+		return zero, false
+	}
+	node := t.root
+	for node.Left != nil {
+		node = node.Left
+	}
+	return node.Value, true
+}
+
+// Max 返回最大元素，即最右侧的节点，空树时第二个返回值为 false
+// 时间复杂度: O(h)
+func (t *binaryTree[T]) Max() (T, bool) {
+	if t.root == nil {
+		var zero T
+		return zero, false
+	}
+	node := t.root
+	for node.Right != nil {
+		node = node.Right
+	}
+	return node.Value, true
+}
+
+// Kth 返回第 k 小的元素（k 从0开始），k 越界时第二个返回值为 false
+// 节点不记录子树大小，按中序遍历到第 k 个元素即停止
+// 时间复杂度: O(h + k)
+func (t *binaryTree[T]) Kth(k int) (T, bool) {
+	if k >= 0 && k < t.size {
+		for v := range t.All() {
+			if k == 0 {
+				return v, true
+			}
+			k--
+		}
+	}
+	var zero T
+	return zero, false
+}
+
+// LCA 返回值为 a 和 b 的两个节点的最近公共祖先，节点可以是其自身的祖先
+// 从根节点向下，a 和 b 第一次分到不同子树（或其中之一等于当前节点）的位置即为最近公共祖先；
+// 任一值不存在时第二个返回值为 false。不会调整树的结构
+// 时间复杂度: O(h)
+func (t *binaryTree[T]) LCA(a, b T) (*TreeNode[T], bool) {
+	if t.cmp(a, b) > 0 {
+		a, b = b, a
+	}
+	node := t.root
+	for node != nil {
+		if t.cmp(b, node.Value) < 0 {
+			node = node.Left
+		} else if t.cmp(a, node.Value) > 0 {
+			node = node.Right
+		} else {
+			break
+		}
+	}
+	if node == nil || t.find(node, a) == nil || t.find(node, b) == nil {
+		return nil, false
+	}
+	return node, true
+}
+
+// Invert 交换所有节点的左右子树，得到原树的镜像
+// 镜像按原比较函数是降序的，因此同时反转树的比较函数，使查找、插入等操作仍然有效，
+// 之后中序遍历按原顺序的降序访问，Compare 也返回相反的结果；再次调用可以恢复原状
+// AVL 树的高度和树堆的优先级、子树大小在镜像中保持不变，无需重新计算
+// 时间复杂度: O(n)
+func (t *binaryTree[T]) Invert() {
+	if t.reversed == nil {
+		cmp := t.cmp
+		t.reversed = func(a, b T) int { return cmp(b, a) }
+	}
+	t.cmp, t.reversed = t.reversed, t.cmp
+	if t.root == nil {
+		return
+	}
+	stack := []*TreeNode[T]{t.root}
+	for len(stack) > 0 {
+		n := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		n.Left, n.Right = n.Right, n.Left
+		if n.Left != nil {
+			stack = append(stack, n.Left)
+		}
+		if n.Right != nil {
+			stack = append(stack, n.Right)
+		}
 	}
-	return current
 }
-func (t *binaryTree[T]) PreOrderTraversal(f func(T)) {
-	t.preOrderRec(t.root, f)
+
+// IsSymmetric 检查树是否与其镜像相同，即左子树与右子树互为镜像且对应位置的元素相等
+// 二叉搜索树中只有全部元素相等时才可能对称
+// 时间复杂度: O(n)
+func (t *binaryTree[T]) IsSymmetric() bool {
+	if t.root == nil {
+		return true
+	}
+	stack := [][2]*TreeNode[T]{{t.root.Left, t.root.Right}}
+	for len(stack) > 0 {
+		pair := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		a, b := pair[0], pair[1]
+		if a == nil || b == nil {
+			if a != b {
+				return false
+			}
+			continue
+		}
+		if a.dups != b.dups || t.cmp(a.Value, b.Value) != 0 {
+			return false
+		}
+		stack = append(stack, [2]*TreeNode[T]{a.Left, b.Right}, [2]*TreeNode[T]{a.Right, b.Left})
+	}
+	return true
 }
 
-func (t *binaryTree[T]) preOrderRec(node *TreeNode[T], f func(T)) {
-	if node != nil {
-		f(node.Value)
-		t.preOrderRec(node.Left, f)
-		t.preOrderRec(node.Right, f)
+// IsEmpty 检查树是否为空
+// 时间复杂度: O(1)
+func (t *binaryTree[T]) IsEmpty() bool {
+	return t.root == nil
+}
+
+// Clear 清空树
+// 时间复杂度: O(1)，启用分配器时为 O(n)
+func (t *binaryTree[T]) Clear() {
+	if t.nodes != nil {
+		t.freeNodes(t.root)
 	}
+	t.root = nil
+	t.size = 0
 }
-func (t *binaryTree[T]) InOrderTraversal(f func(T)) {
-	t.inOrderRec(t.root, f)
+
+// Compare 使用树的比较函数比较两个元素
+func (t *binaryTree[T]) Compare(a, b T) int {
+	return t.cmp(a, b)
 }
 
-func (t *binaryTree[T]) inOrderRec(node *TreeNode[T], f func(T)) {
-	if node != nil {
-		t.inOrderRec(node.Left, f)
-		f(node.Value)
-		t.inOrderRec(node.Right, f)
+// PreOrderTraversal 前序遍历（根、左、右），f 返回 false 时停止遍历
+// 时间复杂度: O(n)
+func (t *binaryTree[T]) PreOrderTraversal(f func(T) bool) {
+	t.PreOrder()(f)
+}
+
+// InOrderTraversal 中序遍历（左、根、右），即按升序访问，f 返回 false 时停止遍历
+// 时间复杂度: O(n)
+func (t *binaryTree[T]) InOrderTraversal(f func(T) bool) {
+	t.InOrder()(f)
+}
+
+// MorrisInOrderTraversal 使用 Morris 线索化进行中序遍历，不使用栈和递归，额外空间为 O(1)
+// 遍历时临时把每个节点前驱的空右指针指向该节点，回到该节点时再恢复，遍历结束后树的结构不变；
+// 因此遍历期间 f 不应访问或修改树，并发场景下也不能与其他读操作同时进行。
+// f 返回 false 或 panic 时会先跑完剩余的遍历以拆除所有线索，但不再调用 f
+// 时间复杂度: O(n)，每条边最多经过三次
+func (t *binaryTree[T]) MorrisInOrderTraversal(f func(T) bool) {
+	cur := t.root
+	defer func() {
+		if r := recover(); r != nil {
+			// 从正在访问的节点继续遍历即可拆除剩余的线索
+			morris(&cur, func(T) bool { return true })
+			panic(r)
+		}
+	}()
+	stopped := false
+	morris(&cur, func(v T) bool {
+		if !stopped && !f(v) {
+			stopped = true
+		}
+		return true
+	})
+}
+
+// morris 从 *cur 开始进行 Morris 中序遍历，遍历过程中 *cur 始终为当前节点
+// visit 的返回值仅为与遍历回调保持一致，线索必须全部拆除，因此遍历不会提前结束
+func morris[T any](cur **TreeNode[T], visit func(T) bool) {
+	for *cur != nil {
+		node := *cur
+		if node.Left == nil {
+			for range node.count() {
+				visit(node.Value)
+			}
+			*cur = node.Right // 右指针可能是指回后继的线索
+			continue
+		}
+		// 找到左子树中的前驱
+		pred := node.Left
+		for pred.Right != nil && pred.Right != node {
+			pred = pred.Right
+		}
+		if pred.Right == nil {
+			// 第一次到达：建立线索后进入左子树
+			pred.Right = node
+			*cur = node.Left
+			continue
+		}
+		// 第二次到达：左子树已访问完，拆除线索
+		pred.Right = nil
+		for range node.count() {
+			visit(node.Value)
+		}
+		*cur = node.Right
 	}
 }
 
-func (t *binaryTree[T]) PostOrderTraversal(f func(T)) {
-	t.postOrderRec(t.root, f)
+// PostOrderTraversal 后序遍历（左、右、根），f 返回 false 时停止遍历
+// 时间复杂度: O(n)
+func (t *binaryTree[T]) PostOrderTraversal(f func(T) bool) {
+	t.PostOrder()(f)
 }
 
-func (t *binaryTree[T]) postOrderRec(node *TreeNode[T], f func(T)) {
-	if node != nil {
-		t.postOrderRec(node.Left, f)
-		t.postOrderRec(node.Right, f)
-		f(node.Value)
+// PreOrder 返回前序遍历（根、左、右）的迭代器
+// 使用显式栈实现，退化成链表的树也不会耗尽调用栈，可以随时提前终止
+func (t *binaryTree[T]) PreOrder() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if t.root == nil {
+			return
+		}
+		stack := []*TreeNode[T]{t.root}
+		for len(stack) > 0 {
+			node := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			for range node.count() {
+				if !yield(node.Value) {
+					return
+				}
+			}
+			// 右子节点先入栈，保证左子树先被访问
+			if node.Right != nil {
+				stack = append(stack, node.Right)
+			}
+			if node.Left != nil {
+				stack = append(stack, node.Left)
+			}
+		}
+	}
+}
+
+// InOrder 返回中序遍历（升序）的迭代器，与 All 相同
+func (t *binaryTree[T]) InOrder() iter.Seq[T] {
+	return t.All()
+}
+
+// PostOrder 返回后序遍历（左、右、根）的迭代器
+// 使用显式栈实现，栈中保存尚未访问的祖先节点，可以随时提前终止
+func (t *binaryTree[T]) PostOrder() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		var stack []*TreeNode[T]
+		var last *TreeNode[T] // 上一个访问的节点
+		node := t.root
+		for node != nil || len(stack) > 0 {
+			for node != nil {
+				stack = append(stack, node)
+				node = node.Left
+			}
+			top := stack[len(stack)-1]
+			if top.Right != nil && top.Right != last {
+				// 右子树尚未访问
+				node = top.Right
+				continue
+			}
+			stack = stack[:len(stack)-1]
+			for range top.count() {
+				if !yield(top.Value) {
+					return
+				}
+			}
+			last = top
+		}
+	}
+}
+
+// All 返回中序遍历（升序）的迭代器
+// 使用显式栈实现，可以随时提前终止
+func (t *binaryTree[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		var stack []*TreeNode[T]
+		node := t.root
+		for node != nil || len(stack) > 0 {
+			for node != nil {
+				stack = append(stack, node)
+				node = node.Left
+			}
+			node = stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			for range node.count() {
+				if !yield(node.Value) {
+					return
+				}
+			}
+			node = node.Right
+		}
+	}
+}
+
+// Clone 返回结构相同的深拷贝
+// copier 用于复制每个元素，为 nil 时直接赋值；复制后的元素应与原元素保持相同的顺序
+// 时间复杂度: O(n)
+func (t *binaryTree[T]) Clone(copier func(T) T) BinaryTree[T] {
+	return &binaryTree[T]{root: cloneNode(t.root, copier), cmp: t.cmp, size: t.size, dup: t.dup}
+}
+
+// cloneNode 复制以 node 为根的子树
+// 使用显式栈实现，退化成链表的树也不会耗尽调用栈
+func cloneNode[T any](node *TreeNode[T], copier func(T) T) *TreeNode[T] {
+	var root *TreeNode[T]
+	type pending struct {
+		src  *TreeNode[T]
+		link **TreeNode[T] // 副本应挂接的位置
+	}
+	stack := []pending{{node, &root}}
+	for len(stack) > 0 {
+		p := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if p.src == nil {
+			continue
+		}
+		value := p.src.Value
+		if copier != nil {
+			value = copier(value)
+		}
+		// 复制平衡树维护的附加信息
+		n := &TreeNode[T]{Value: value, height: p.src.height, size: p.src.size, priority: p.src.priority, dups: p.src.dups}
+		*p.link = n
+		stack = append(stack, pending{p.src.Left, &n.Left}, pending{p.src.Right, &n.Right})
 	}
+	return root
 }
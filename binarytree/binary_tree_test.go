@@ -1,6 +1,10 @@
 package binarytree
 
 import (
+	"fmt"
+	"iter"
+	"runtime/debug"
+	"slices"
 	"testing"
 )
 
@@ -152,8 +156,9 @@ func TestTraversals(t *testing.T) {
 	t.Run("PreOrder Traversal", func(t *testing.T) {
 		expected := []int{5, 3, 1, 4, 7, 6, 8}
 		result := make([]int, 0)
-		tree.PreOrderTraversal(func(v int) {
+		tree.PreOrderTraversal(func(v int) bool {
 			result = append(result, v)
+			return true
 		})
 
 		if !sliceEqual(result, expected) {
@@ -165,8 +170,9 @@ func TestTraversals(t *testing.T) {
 	t.Run("InOrder Traversal", func(t *testing.T) {
 		expected := []int{1, 3, 4, 5, 6, 7, 8}
 		result := make([]int, 0)
-		tree.InOrderTraversal(func(v int) {
+		tree.InOrderTraversal(func(v int) bool {
 			result = append(result, v)
+			return true
 		})
 
 		if !sliceEqual(result, expected) {
@@ -178,8 +184,9 @@ func TestTraversals(t *testing.T) {
 	t.Run("PostOrder Traversal", func(t *testing.T) {
 		expected := []int{1, 4, 3, 6, 8, 7, 5}
 		result := make([]int, 0)
-		tree.PostOrderTraversal(func(v int) {
+		tree.PostOrderTraversal(func(v int) bool {
 			result = append(result, v)
+			return true
 		})
 
 		if !sliceEqual(result, expected) {
@@ -259,8 +266,9 @@ func TestEmptyTree(t *testing.T) {
 
 	// 测试空树遍历
 	count := 0
-	tree.InOrderTraversal(func(v int) {
+	tree.InOrderTraversal(func(v int) bool {
 		count++
+		return true
 	})
 	if count != 0 {
 		t.Error("空树遍历不应该有任何回调")
@@ -279,3 +287,804 @@ func sliceEqual(a, b []int) bool {
 	}
 	return true
 }
+
+// TestAll 测试中序迭代器
+func TestAll(t *testing.T) {
+	for name, tree := range map[string]BinaryTree[int]{"二叉搜索树": New(intCmp), "伸展树": NewSplay(intCmp), "AVL树": NewAVL(intCmp), "树堆": NewTreap(intCmp)} {
+		t.Run(name, func(t *testing.T) {
+			for _, v := range []int{5, 3, 7, 1, 4, 6, 8} {
+				tree.Insert(v)
+			}
+			result := make([]int, 0)
+			for v := range tree.All() {
+				result = append(result, v)
+				if v == 6 {
+					break
+				}
+			}
+			if !sliceEqual(result, []int{1, 3, 4, 5, 6}) {
+				t.Errorf("迭代结果错误: %v", result)
+			}
+		})
+	}
+}
+
+// TestWithArena 测试使用节点分配器的二叉搜索树与伸展树在删除和清空时回收节点
+func TestWithArena(t *testing.T) {
+	trees := map[string]BinaryTree[int]{
+		"二叉搜索树": New(intCmp, WithArena(8)),
+		"伸展树":   NewSplay(intCmp, WithArena(8)),
+		"AVL树":  NewAVL(intCmp, WithArena(8)),
+		"树堆":    NewTreap(intCmp, WithArena(8)),
+	}
+	for name, tree := range trees {
+		t.Run(name, func(t *testing.T) {
+			var nodes interface{ Stats() (int, int) }
+			switch tr := tree.(type) {
+			case *binaryTree[int]:
+				nodes = tr.nodes
+			case *splayTree[int]:
+				nodes = tr.nodes
+			case *avlTree[int]:
+				nodes = tr.nodes
+			case *Treap[int]:
+				nodes = tr.nodes
+			}
+			for i := 0; i < 50; i++ {
+				tree.Insert((i * 17) % 50)
+			}
+			for i := 0; i < 50; i += 2 {
+				tree.Remove(i)
+			}
+			if _, free := nodes.Stats(); free != 25 {
+				t.Errorf("期望回收25个节点，实际为%d", free)
+			}
+			for i := 0; i < 50; i += 2 {
+				tree.Insert(i)
+			}
+			var got []int
+			tree.InOrderTraversal(func(v int) bool {
+				got = append(got, v)
+				return true
+			})
+			for i, v := range got {
+				if v != i {
+					t.Fatalf("复用节点后中序遍历错误: %v", got)
+				}
+			}
+			tree.Clear()
+			if _, free := nodes.Stats(); free != 50 {
+				t.Errorf("清空后应回收全部节点，实际为%d", free)
+			}
+		})
+	}
+}
+
+// TestDegenerateTree 测试退化成链表的深树上的操作不会耗尽调用栈
+func TestDegenerateTree(t *testing.T) {
+	const n = 100000
+	// 直接链接节点构造退化树，避免 O(n²) 的逐个插入
+	tree := &binaryTree[int]{cmp: intCmp, size: n}
+	link := &tree.root
+	for i := range n {
+		*link = &TreeNode[int]{Value: i}
+		link = &(*link).Right
+	}
+	// 递归实现每层至少占用几十字节的栈，限制在 1MB 内即可发现递归
+	defer debug.SetMaxStack(debug.SetMaxStack(1 << 20))
+
+	count, last := 0, -1
+	tree.InOrderTraversal(func(v int) bool {
+		if v != last+1 {
+			t.Fatalf("中序遍历顺序错误: %d 之后为 %d", last, v)
+		}
+		last = v
+		count++
+		return true
+	})
+	if count != n {
+		t.Errorf("中序遍历访问了%d个节点，期望 %d", count, n)
+	}
+	count = 0
+	tree.MorrisInOrderTraversal(func(int) bool {
+		count++
+		return true
+	})
+	if count != n {
+		t.Errorf("Morris 遍历访问了%d个节点，期望 %d", count, n)
+	}
+	count = 0
+	tree.PreOrderTraversal(func(int) bool {
+		count++
+		return true
+	})
+	tree.PostOrderTraversal(func(int) bool {
+		count++
+		return true
+	})
+	if count != 2*n {
+		t.Errorf("前序与后序遍历共访问了%d个节点，期望 %d", count, 2*n)
+	}
+	clone := tree.Clone(nil)
+	if clone.Size() != n || clone.Search(n-1) == nil {
+		t.Error("克隆的深树内容不完整")
+	}
+	tree.Insert(n)
+	if !tree.Remove(n-1) || tree.Search(n) == nil {
+		t.Error("在深树末端插入和删除失败")
+	}
+	tree.Clear()
+}
+
+// TestTraversalsRandom 测试随机插入删除后三种遍历的结果与递归定义一致
+func TestTraversalsRandom(t *testing.T) {
+	tree := New(intCmp)
+	for i := range 200 {
+		tree.Insert((i * 73) % 101)
+	}
+	for i := 0; i < 101; i += 3 {
+		tree.Remove(i)
+	}
+	root := tree.(*binaryTree[int]).root
+	var pre, in, post []int
+	var walk func(n *TreeNode[int])
+	walk = func(n *TreeNode[int]) {
+		if n == nil {
+			return
+		}
+		pre = append(pre, n.Value)
+		walk(n.Left)
+		in = append(in, n.Value)
+		walk(n.Right)
+		post = append(post, n.Value)
+	}
+	walk(root)
+	for name, tc := range map[string]struct {
+		traverse func(func(int) bool)
+		seq      iter.Seq[int]
+		want     []int
+	}{
+		"前序": {tree.PreOrderTraversal, tree.PreOrder(), pre},
+		"中序": {tree.InOrderTraversal, tree.InOrder(), in},
+		"后序": {tree.PostOrderTraversal, tree.PostOrder(), post},
+	} {
+		var got []int
+		tc.traverse(func(v int) bool {
+			got = append(got, v)
+			return true
+		})
+		if !slices.Equal(got, tc.want) {
+			t.Errorf("%s遍历结果错误，期望 %v，得到 %v", name, tc.want, got)
+		}
+		if got := slices.Collect(tc.seq); !slices.Equal(got, tc.want) {
+			t.Errorf("%s迭代器结果错误，期望 %v，得到 %v", name, tc.want, got)
+		}
+	}
+	if !slices.IsSorted(in) || len(in) != tree.Size() {
+		t.Errorf("删除后中序遍历应有序且包含%d个元素: %v", tree.Size(), in)
+	}
+}
+
+// TestHeightAndDepth 测试树高与节点深度
+func TestHeightAndDepth(t *testing.T) {
+	tree := New(intCmp)
+	if tree.Height() != 0 || tree.Depth(1) != -1 {
+		t.Error("空树的高度应为0，且不包含任何节点")
+	}
+	for _, v := range []int{5, 3, 7, 1, 4, 6, 8, 2} {
+		tree.Insert(v)
+	}
+	if h := tree.Height(); h != 4 {
+		t.Errorf("Height() = %d，期望 4", h)
+	}
+	for v, want := range map[int]int{5: 0, 3: 1, 7: 1, 1: 2, 8: 2, 2: 3, 9: -1} {
+		if got := tree.Depth(v); got != want {
+			t.Errorf("Depth(%d) = %d，期望 %d", v, got, want)
+		}
+	}
+
+	t.Run("伸展树", func(t *testing.T) {
+		tree := NewSplay(intCmp)
+		for i := range 10 {
+			tree.Insert(i) // 顺序插入后新节点总在根部，树退化为左链
+		}
+		if h := tree.Height(); h != 10 {
+			t.Errorf("Height() = %d，期望 10", h)
+		}
+		if d := tree.Depth(0); d != 9 {
+			t.Errorf("Depth(0) = %d，期望 9", d)
+		}
+		if root := tree.(*splayTree[int]).root; root.Value != 9 {
+			t.Errorf("Depth 不应调整树的结构，根节点变为%d", root.Value)
+		}
+	})
+}
+
+// TestMinMax 测试各实现的最小与最大元素查询
+func TestMinMax(t *testing.T) {
+	trees := map[string]BinaryTree[int]{
+		"二叉搜索树": New(intCmp),
+		"伸展树":   NewSplay(intCmp),
+		"AVL树":  NewAVL(intCmp),
+		"树堆":    NewTreap(intCmp),
+	}
+	for name, tree := range trees {
+		t.Run(name, func(t *testing.T) {
+			if _, ok := tree.Min(); ok {
+				t.Error("空树的 Min 应返回 false")
+			}
+			if _, ok := tree.Max(); ok {
+				t.Error("空树的 Max 应返回 false")
+			}
+			for _, v := range []int{5, 3, 7, 1, 4, 6, 8} {
+				tree.Insert(v)
+			}
+			if v, ok := tree.Min(); !ok || v != 1 {
+				t.Errorf("Min() = %d, %v，期望 1", v, ok)
+			}
+			if v, ok := tree.Max(); !ok || v != 8 {
+				t.Errorf("Max() = %d, %v，期望 8", v, ok)
+			}
+			tree.Remove(1)
+			tree.Remove(8)
+			if v, _ := tree.Min(); v != 3 {
+				t.Errorf("删除后 Min() = %d，期望 3", v)
+			}
+			if v, _ := tree.Max(); v != 7 {
+				t.Errorf("删除后 Max() = %d，期望 7", v)
+			}
+			if got := inOrderValues(tree); !sliceEqual(got, []int{3, 4, 5, 6, 7}) {
+				t.Errorf("查询后中序遍历结果错误: %v", got)
+			}
+		})
+	}
+}
+
+// TestKth 测试各实现的第 k 小元素查询
+func TestKth(t *testing.T) {
+	trees := map[string]BinaryTree[int]{
+		"二叉搜索树": New(intCmp),
+		"伸展树":   NewSplay(intCmp),
+		"AVL树":  NewAVL(intCmp),
+		"树堆":    NewTreap(intCmp),
+	}
+	for name, tree := range trees {
+		t.Run(name, func(t *testing.T) {
+			if _, ok := tree.Kth(0); ok {
+				t.Error("空树的 Kth 应返回 false")
+			}
+			for i := range 100 {
+				tree.Insert((i * 37) % 100 * 2) // 0, 2, ..., 198 乱序插入
+			}
+			tree.Insert(50) // 重复元素
+			want := 0
+			for k := range 101 {
+				v, ok := tree.Kth(k)
+				if !ok || v != want {
+					t.Fatalf("Kth(%d) = %d, %v，期望 %d", k, v, ok, want)
+				}
+				if k != 25 { // 第25和26个元素都是50
+					want += 2
+				}
+			}
+			for _, k := range []int{-1, 101} {
+				if _, ok := tree.Kth(k); ok {
+					t.Errorf("Kth(%d) 越界时应返回 false", k)
+				}
+			}
+		})
+	}
+}
+
+// TestLCA 测试最近公共祖先查询
+func TestLCA(t *testing.T) {
+	tree := New(intCmp)
+	if _, ok := tree.LCA(1, 2); ok {
+		t.Error("空树的 LCA 应返回 false")
+	}
+	//         5
+	//       /   \
+	//      3     7
+	//     / \   / \
+	//    1   4 6   8
+	//     \
+	//      2
+	for _, v := range []int{5, 3, 7, 1, 4, 6, 8, 2} {
+		tree.Insert(v)
+	}
+	for _, tc := range []struct{ a, b, want int }{
+		{1, 4, 3},
+		{4, 1, 3},
+		{2, 4, 3},
+		{1, 2, 1},
+		{6, 8, 7},
+		{2, 8, 5},
+		{5, 6, 5},
+		{4, 4, 4},
+	} {
+		if n, ok := tree.LCA(tc.a, tc.b); !ok || n.Value != tc.want {
+			t.Errorf("LCA(%d, %d) 期望 %d", tc.a, tc.b, tc.want)
+		}
+	}
+	for _, pair := range [][2]int{{1, 9}, {0, 8}, {9, 10}} {
+		if _, ok := tree.LCA(pair[0], pair[1]); ok {
+			t.Errorf("LCA(%d, %d) 中有不存在的值，应返回 false", pair[0], pair[1])
+		}
+	}
+}
+
+// TestInvert 测试镜像翻转后树仍可正常使用，且再次翻转恢复原状
+func TestInvert(t *testing.T) {
+	trees := map[string]BinaryTree[int]{
+		"二叉搜索树": New(intCmp),
+		"伸展树":   NewSplay(intCmp),
+		"AVL树":  NewAVL(intCmp),
+		"树堆":    NewTreap(intCmp),
+	}
+	for name, tree := range trees {
+		t.Run(name, func(t *testing.T) {
+			tree.Invert()
+			if !tree.IsEmpty() || !tree.IsSymmetric() {
+				t.Error("空树翻转后应仍为空且对称")
+			}
+			tree.Invert()
+			for _, v := range []int{5, 3, 7, 1, 4, 6, 8} {
+				tree.Insert(v)
+			}
+			tree.Invert()
+			if got := inOrderValues(tree); !sliceEqual(got, []int{8, 7, 6, 5, 4, 3, 1}) {
+				t.Errorf("翻转后中序遍历应为降序，实际为%v", got)
+			}
+			if tree.Compare(1, 2) <= 0 {
+				t.Error("翻转后比较函数应反转")
+			}
+			if v, _ := tree.Min(); v != 8 {
+				t.Errorf("翻转后 Min() 应返回原最大值8，实际为%d", v)
+			}
+			tree.Insert(2)
+			if tree.Search(2) == nil || !tree.Remove(7) || tree.Search(7) != nil {
+				t.Error("翻转后查找、插入和删除应仍然有效")
+			}
+			tree.Invert()
+			if got := inOrderValues(tree); !sliceEqual(got, []int{1, 2, 3, 4, 5, 6, 8}) {
+				t.Errorf("再次翻转后中序遍历应为升序，实际为%v", got)
+			}
+		})
+	}
+	checkAVL(t, trees["AVL树"].(*avlTree[int]).root)
+	checkTreap(t, trees["树堆"].(*Treap[int]).root)
+
+	t.Run("两次翻转恢复结构", func(t *testing.T) {
+		tree := New(intCmp)
+		for _, v := range []int{5, 3, 7, 1, 4} {
+			tree.Insert(v)
+		}
+		tree.Invert()
+		if got := preOrderValues(tree); !sliceEqual(got, []int{5, 7, 3, 4, 1}) {
+			t.Errorf("翻转后前序遍历为%v，期望 [5 7 3 4 1]", got)
+		}
+		tree.Invert()
+		if got := preOrderValues(tree); !sliceEqual(got, []int{5, 3, 1, 4, 7}) {
+			t.Errorf("两次翻转后前序遍历为%v，期望 [5 3 1 4 7]", got)
+		}
+	})
+}
+
+// TestIsSymmetric 测试对称性检查
+func TestIsSymmetric(t *testing.T) {
+	tree := New(intCmp)
+	tree.Insert(1)
+	if !tree.IsSymmetric() {
+		t.Error("单个节点的树应对称")
+	}
+	tree.Insert(2)
+	if tree.IsSymmetric() {
+		t.Error("只有右子树的树不应对称")
+	}
+	// 手工构造形状对称但元素不同的树
+	root := &TreeNode[int]{Value: 2, Left: &TreeNode[int]{Value: 1}, Right: &TreeNode[int]{Value: 3}}
+	shaped := &binaryTree[int]{root: root, cmp: intCmp, size: 3}
+	if shaped.IsSymmetric() {
+		t.Error("对应位置元素不同的树不应对称")
+	}
+	root.Right.Value = 1
+	if !shaped.IsSymmetric() {
+		t.Error("形状和元素都对称的树应对称")
+	}
+}
+
+// TestNewFromSorted 测试由有序元素构建平衡的二叉搜索树
+func TestNewFromSorted(t *testing.T) {
+	values := make([]int, 1000)
+	for i := range values {
+		values[i] = i / 2 // 包含重复元素
+	}
+	tree := NewFromSorted(intCmp, values)
+	if err := tree.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if !tree.IsBalanced() || tree.Height() != 10 {
+		t.Errorf("1000个元素构建的树应平衡且高度为10，实际高度为%d", tree.Height())
+	}
+	if got := inOrderValues(tree); !slices.Equal(got, values) {
+		t.Error("中序遍历结果应与输入相同")
+	}
+	for _, v := range []int{0, 250, 499} {
+		if tree.Search(v) == nil {
+			t.Errorf("未找到已存在的值: %d", v)
+		}
+	}
+	tree.Insert(1000)
+	if !tree.Remove(250) || tree.Size() != 1000 {
+		t.Error("构建后的树应能继续插入和删除")
+	}
+
+	if empty := NewFromSorted(intCmp, nil); !empty.IsEmpty() {
+		t.Error("由空切片构建的树应为空")
+	}
+
+	t.Run("未排序", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("元素未排序时应 panic")
+			}
+		}()
+		NewFromSorted(intCmp, []int{1, 3, 2})
+	})
+
+	t.Run("重复元素策略", func(t *testing.T) {
+		unique := NewFromSorted(intCmp, values, WithDuplicates(RejectDuplicates))
+		if unique.Size() != 500 || unique.Count(250) != 1 || unique.Validate() != nil {
+			t.Errorf("拒绝重复时应去重，实际大小为%d", unique.Size())
+		}
+		counted := NewFromSorted(intCmp, values, WithDuplicates(CountDuplicates))
+		if counted.Size() != 1000 || counted.Count(250) != 2 || counted.Validate() != nil {
+			t.Errorf("计数模式下大小为%d，Count(250) = %d", counted.Size(), counted.Count(250))
+		}
+		if !slices.Equal(inOrderValues(counted), values) {
+			t.Error("计数模式下中序遍历结果应与输入相同")
+		}
+	})
+}
+
+// TestMorrisInOrderTraversal 测试 Morris 中序遍历的结果，以及遍历后树的结构保持不变
+func TestMorrisInOrderTraversal(t *testing.T) {
+	trees := map[string]BinaryTree[int]{
+		"二叉搜索树": New(intCmp),
+		"伸展树":   NewSplay(intCmp),
+		"AVL树":  NewAVL(intCmp),
+		"树堆":    NewTreap(intCmp),
+	}
+	for name, tree := range trees {
+		t.Run(name, func(t *testing.T) {
+			tree.MorrisInOrderTraversal(func(int) bool {
+				t.Error("空树遍历不应该有任何回调")
+				return true
+			})
+			for i := range 200 {
+				tree.Insert((i * 37) % 101)
+			}
+			pre, in := preOrderValues(tree), inOrderValues(tree)
+			var got []int
+			tree.MorrisInOrderTraversal(func(v int) bool {
+				got = append(got, v)
+				return true
+			})
+			if !slices.Equal(got, in) {
+				t.Errorf("Morris 遍历结果错误，期望 %v，得到 %v", in, got)
+			}
+			if !slices.Equal(preOrderValues(tree), pre) {
+				t.Error("遍历后树的结构应保持不变")
+			}
+			if err := tree.Validate(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+
+	t.Run("回调 panic", func(t *testing.T) {
+		tree := New(intCmp)
+		for _, v := range []int{5, 3, 7, 1, 4, 6, 8, 2} {
+			tree.Insert(v)
+		}
+		pre := preOrderValues(tree)
+		for stop := 1; stop <= 8; stop++ {
+			func() {
+				defer func() {
+					if recover() == nil {
+						t.Error("回调的 panic 应继续传播")
+					}
+				}()
+				tree.MorrisInOrderTraversal(func(v int) bool {
+					if v == stop {
+						panic("stop")
+					}
+					return true
+				})
+			}()
+			if got := preOrderValues(tree); !sliceEqual(got, pre) {
+				t.Fatalf("在%d处 panic 后树的结构被破坏: %v", stop, got)
+			}
+		}
+	})
+}
+
+// TestTraversalStop 测试回调返回 false 时各种遍历提前终止
+func TestTraversalStop(t *testing.T) {
+	tree := New(intCmp)
+	for _, v := range []int{5, 3, 7, 1, 4, 6, 8} {
+		tree.Insert(v)
+	}
+	pre := preOrderValues(tree)
+	for name, tc := range map[string]struct {
+		traverse func(func(int) bool)
+		want     []int
+	}{
+		"前序":     {tree.PreOrderTraversal, []int{5, 3, 1}},
+		"中序":     {tree.InOrderTraversal, []int{1, 3, 4}},
+		"Morris": {tree.MorrisInOrderTraversal, []int{1, 3, 4}},
+		"后序":     {tree.PostOrderTraversal, []int{1, 4, 3}},
+	} {
+		var got []int
+		tc.traverse(func(v int) bool {
+			got = append(got, v)
+			return len(got) < 3
+		})
+		if !sliceEqual(got, tc.want) {
+			t.Errorf("%s遍历应在第3个元素后停止，期望 %v，得到 %v", name, tc.want, got)
+		}
+	}
+	if got := preOrderValues(tree); !sliceEqual(got, pre) {
+		t.Errorf("提前终止的 Morris 遍历后树的结构被破坏: %v", got)
+	}
+
+	var got []int
+	for v := range tree.PostOrder() {
+		if v == 7 {
+			break
+		}
+		got = append(got, v)
+	}
+	if !sliceEqual(got, []int{1, 4, 3, 6, 8}) {
+		t.Errorf("后序迭代器应在7处停止，实际为%v", got)
+	}
+}
+
+// TestDuplicatePolicy 测试各实现在不同重复元素策略下的插入、计数与删除
+func TestDuplicatePolicy(t *testing.T) {
+	constructors := map[string]func(opts ...Option) BinaryTree[int]{
+		"二叉搜索树": func(opts ...Option) BinaryTree[int] { return New(intCmp, opts...) },
+		"伸展树":   func(opts ...Option) BinaryTree[int] { return NewSplay(intCmp, opts...) },
+		"AVL树":  func(opts ...Option) BinaryTree[int] { return NewAVL(intCmp, opts...) },
+		"树堆":    func(opts ...Option) BinaryTree[int] { return NewTreap(intCmp, opts...) },
+	}
+	for name, newTree := range constructors {
+		t.Run(name, func(t *testing.T) {
+			t.Run("允许重复", func(t *testing.T) {
+				tree := newTree()
+				for _, v := range []int{5, 3, 5, 8, 5} {
+					if !tree.Insert(v) {
+						t.Fatalf("默认策略下插入 %d 应增加元素数量", v)
+					}
+				}
+				if tree.Count(5) != 3 || tree.Count(4) != 0 || tree.Size() != 5 {
+					t.Errorf("Count(5) = %d, Size = %d，期望 3 和 5", tree.Count(5), tree.Size())
+				}
+				if n := tree.RemoveAll(5); n != 3 || tree.Count(5) != 0 || tree.Size() != 2 {
+					t.Errorf("RemoveAll(5) = %d，删除后 Size = %d", n, tree.Size())
+				}
+			})
+
+			t.Run("拒绝重复", func(t *testing.T) {
+				tree := newTree(WithDuplicates(RejectDuplicates))
+				for _, v := range []int{5, 3, 8} {
+					if !tree.Insert(v) {
+						t.Fatalf("插入新元素 %d 应返回 true", v)
+					}
+				}
+				if tree.Insert(5) || tree.Size() != 3 || tree.Count(5) != 1 {
+					t.Error("插入已存在的元素应返回 false 且不改变树")
+				}
+				if err := tree.Validate(); err != nil {
+					t.Error(err)
+				}
+				if n := tree.RemoveAll(5); n != 1 || tree.Search(5) != nil {
+					t.Errorf("RemoveAll(5) = %d，期望 1", n)
+				}
+			})
+
+			t.Run("计数", func(t *testing.T) {
+				tree := newTree(WithDuplicates(CountDuplicates))
+				for _, v := range []int{5, 3, 5, 8, 5, 3} {
+					if !tree.Insert(v) {
+						t.Fatalf("计数模式下插入 %d 应增加元素数量", v)
+					}
+				}
+				if tree.Size() != 6 || tree.Count(5) != 3 || tree.Count(3) != 2 {
+					t.Errorf("Size = %d, Count(5) = %d, Count(3) = %d", tree.Size(), tree.Count(5), tree.Count(3))
+				}
+				if got := inOrderValues(tree); !slices.Equal(got, []int{3, 3, 5, 5, 5, 8}) {
+					t.Errorf("中序遍历结果为 %v", got)
+				}
+				if got := slices.Collect(tree.PreOrder()); len(got) != 6 {
+					t.Errorf("前序遍历应按出现次数访问，实际为 %v", got)
+				}
+				if v, ok := tree.Kth(4); !ok || v != 5 {
+					t.Errorf("Kth(4) = %d, %v，期望 5", v, ok)
+				}
+				if err := tree.Validate(); err != nil {
+					t.Fatal(err)
+				}
+				if !tree.Remove(5) || tree.Count(5) != 2 || tree.Size() != 5 {
+					t.Error("Remove 应只减少一次计数")
+				}
+				if n := tree.RemoveAll(5); n != 2 || tree.Search(5) != nil || tree.Size() != 3 {
+					t.Errorf("RemoveAll(5) = %d，删除后 Size = %d", n, tree.Size())
+				}
+				if err := tree.Validate(); err != nil {
+					t.Error(err)
+				}
+				clone := tree.Clone(nil)
+				if clone.Count(3) != 2 || !slices.Equal(preOrderValues(clone), preOrderValues(tree)) {
+					t.Error("克隆应保留出现次数")
+				}
+				if !clone.Insert(3) || clone.Count(3) != 3 || tree.Count(3) != 2 {
+					t.Error("克隆应继承重复元素策略且与原树互不影响")
+				}
+			})
+		})
+	}
+}
+
+// TestOverwriteDuplicates 测试覆盖策略用新值替换键相等的已有元素
+func TestOverwriteDuplicates(t *testing.T) {
+	type entry struct {
+		key, val int
+	}
+	byKey := func(a, b entry) int { return intCmp(a.key, b.key) }
+	for name, tree := range map[string]BinaryTree[entry]{
+		"二叉搜索树": New(byKey, WithDuplicates(OverwriteDuplicates)),
+		"伸展树":   NewSplay(byKey, WithDuplicates(OverwriteDuplicates)),
+		"AVL树":  NewAVL(byKey, WithDuplicates(OverwriteDuplicates)),
+		"树堆":    NewTreap(byKey, WithDuplicates(OverwriteDuplicates)),
+	} {
+		t.Run(name, func(t *testing.T) {
+			for i := range 10 {
+				tree.Insert(entry{i, i})
+			}
+			if tree.Insert(entry{4, 40}) || tree.Size() != 10 {
+				t.Error("覆盖已有元素不应增加元素数量")
+			}
+			if n := tree.Search(entry{key: 4}); n == nil || n.Value.val != 40 {
+				t.Error("已有元素应被新值覆盖")
+			}
+		})
+	}
+}
+
+// TestDuplicatePolicyRandom 随机插入删除，计数模式与拒绝模式的结果与多重集合、集合模型一致
+func TestDuplicatePolicyRandom(t *testing.T) {
+	for _, policy := range []DuplicatePolicy{RejectDuplicates, CountDuplicates} {
+		for name, tree := range map[string]BinaryTree[int]{
+			"二叉搜索树": New(intCmp, WithDuplicates(policy)),
+			"伸展树":   NewSplay(intCmp, WithDuplicates(policy)),
+			"AVL树":  NewAVL(intCmp, WithDuplicates(policy)),
+			"树堆":    NewTreap(intCmp, WithDuplicates(policy)),
+		} {
+			model := map[int]int{}
+			size := 0
+			for i := range 3000 {
+				v := (i * 7919) % 50
+				switch i % 5 {
+				case 0, 1, 2:
+					grew := tree.Insert(v)
+					if policy == CountDuplicates || model[v] == 0 {
+						model[v]++
+						size++
+						if !grew {
+							t.Fatalf("%s: 插入 %d 应增加元素数量", name, v)
+						}
+					} else if grew {
+						t.Fatalf("%s: 拒绝模式下插入已存在的 %d 不应增加元素数量", name, v)
+					}
+				case 3:
+					if tree.Remove(v) != (model[v] > 0) {
+						t.Fatalf("%s: Remove(%d) 的返回值错误", name, v)
+					}
+					if model[v] > 0 {
+						model[v]--
+						size--
+					}
+				case 4:
+					if n := tree.RemoveAll(v); n != model[v] {
+						t.Fatalf("%s: RemoveAll(%d) = %d，期望 %d", name, v, n, model[v])
+					}
+					size -= model[v]
+					model[v] = 0
+				}
+				if tree.Count(v) != model[v] || tree.Size() != size {
+					t.Fatalf("%s: Count(%d) = %d，期望 %d；Size = %d，期望 %d", name, v, tree.Count(v), model[v], tree.Size(), size)
+				}
+			}
+			if err := tree.Validate(); err != nil {
+				t.Errorf("%s: %v", name, err)
+			}
+		}
+	}
+}
+
+// TestRemoveAndGet 测试删除元素时取回树中存储的值
+func TestRemoveAndGet(t *testing.T) {
+	type entry struct {
+		key int
+		val string
+	}
+	byKey := func(a, b entry) int { return intCmp(a.key, b.key) }
+	for name, tree := range map[string]BinaryTree[entry]{
+		"二叉搜索树": New(byKey),
+		"伸展树":   NewSplay(byKey),
+		"AVL树":  NewAVL(byKey),
+		"树堆":    NewTreap(byKey),
+	} {
+		t.Run(name, func(t *testing.T) {
+			for i := range 20 {
+				tree.Insert(entry{(i * 7) % 20, fmt.Sprint("v", (i*7)%20)})
+			}
+			got, ok := tree.RemoveAndGet(entry{key: 13})
+			if !ok || got.val != "v13" {
+				t.Errorf("RemoveAndGet(13) = %v, %v，期望 v13", got, ok)
+			}
+			if tree.Search(entry{key: 13}) != nil || tree.Size() != 19 {
+				t.Error("取回的元素应已被删除")
+			}
+			if _, ok := tree.RemoveAndGet(entry{key: 13}); ok {
+				t.Error("删除不存在的元素应返回 false")
+			}
+			if err := tree.Validate(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+// TestRemoveFunc 测试按条件批量删除后树的内容与结构
+func TestRemoveFunc(t *testing.T) {
+	for _, policy := range []DuplicatePolicy{AllowDuplicates, CountDuplicates} {
+		for name, tree := range map[string]BinaryTree[int]{
+			"二叉搜索树": New(intCmp, WithDuplicates(policy), WithArena(0)),
+			"伸展树":   NewSplay(intCmp, WithDuplicates(policy)),
+			"AVL树":  NewAVL(intCmp, WithDuplicates(policy)),
+			"树堆":    NewTreap(intCmp, WithDuplicates(policy)),
+		} {
+			var want []int
+			for i := range 1000 {
+				v := (i * 37) % 500
+				tree.Insert(v)
+				if v%3 != 0 {
+					want = append(want, v)
+				}
+			}
+			slices.Sort(want)
+			if n := tree.RemoveFunc(func(int) bool { return false }); n != 0 || tree.Size() != 1000 {
+				t.Errorf("%s: 没有满足条件的元素时应返回0，实际为%d", name, n)
+			}
+			if n := tree.RemoveFunc(func(v int) bool { return v%3 == 0 }); n != 1000-len(want) {
+				t.Errorf("%s: RemoveFunc 返回%d，期望 %d", name, n, 1000-len(want))
+			}
+			if got := inOrderValues(tree); !slices.Equal(got, want) || tree.Size() != len(want) {
+				t.Errorf("%s: 删除后剩余%d个元素，期望 %d", name, tree.Size(), len(want))
+			}
+			if err := tree.Validate(); err != nil {
+				t.Errorf("%s: %v", name, err)
+			}
+			// 删除后的树可以继续正常使用
+			tree.Insert(3)
+			if !tree.Remove(3) || !tree.Remove(1) || tree.Validate() != nil {
+				t.Errorf("%s: 批量删除后无法继续插入和删除", name)
+			}
+			if n := tree.RemoveFunc(func(int) bool { return true }); n != len(want)-1 || !tree.IsEmpty() {
+				t.Errorf("%s: 删除全部元素后应为空，返回%d", name, n)
+			}
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package binarytree
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// String 返回树结构的字符串表示，用于调试
+// 每行一个节点，子节点按 L（左）、R（右）标出并逐层缩进，
+// CountDuplicates 模式下值出现多次时标注次数，例如：
+//
+//	20
+//	├── L: 10
+//	└── R: 30×2
+//	    └── R: 40
+//
+// 使用显式栈实现，退化成链表的树也不会耗尽调用栈
+// 时间复杂度: O(n·h)，每行的缩进长度与节点深度成正比
+func (t *binaryTree[T]) String() string {
+	if t.root == nil {
+		return "空树"
+	}
+	type line struct {
+		node   *TreeNode[T]
+		prefix string // 该行连接线之前的缩进
+		branch string // 连接线和左右标记，根节点为空
+		indent string // 子节点在该行缩进的基础上追加的部分
+	}
+	var sb strings.Builder
+	stack := []line{{node: t.root}}
+	for len(stack) > 0 {
+		l := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		sb.WriteString(l.prefix + l.branch + valueLabel(l.node) + "\n")
+		prefix := l.prefix + l.indent
+		// 右子节点先入栈，保证左子节点先输出
+		if n := l.node; n.Right != nil {
+			stack = append(stack, line{n.Right, prefix, "└── R: ", "    "})
+			if n.Left != nil {
+				stack = append(stack, line{n.Left, prefix, "├── L: ", "│   "})
+			}
+		} else if n.Left != nil {
+			stack = append(stack, line{n.Left, prefix, "└── L: ", "    "})
+		}
+	}
+	return sb.String()
+}
+
+// ToDOT 返回 Graphviz DOT 格式的树结构，边上标注左右子节点，可以用 dot -Tsvg 等命令渲染
+// 时间复杂度: O(n)
+func (t *binaryTree[T]) ToDOT() string {
+	var sb strings.Builder
+	sb.WriteString("digraph binarytree {\n")
+	// 按前序编号，父节点的编号总是小于子节点
+	type entry struct {
+		node   *TreeNode[T]
+		parent int
+		side   string
+	}
+	id := 0
+	var stack []entry
+	if t.root != nil {
+		stack = append(stack, entry{node: t.root, parent: -1})
+	}
+	for len(stack) > 0 {
+		e := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		fmt.Fprintf(&sb, "\tn%d [label=%s];\n", id, strconv.Quote(valueLabel(e.node)))
+		if e.parent >= 0 {
+			fmt.Fprintf(&sb, "\tn%d -> n%d [label=%q];\n", e.parent, id, e.side)
+		}
+		if e.node.Right != nil {
+			stack = append(stack, entry{e.node.Right, id, "R"})
+		}
+		if e.node.Left != nil {
+			stack = append(stack, entry{e.node.Left, id, "L"})
+		}
+		id++
+	}
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
+// valueLabel 返回节点的值，出现多次时附加次数
+func valueLabel[T any](n *TreeNode[T]) string {
+	if n.dups > 0 {
+		return fmt.Sprintf("%v×%d", n.Value, n.count())
+	}
+	return fmt.Sprint(n.Value)
+}
@@ -0,0 +1,59 @@
+package binarytree
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestString 测试树结构的字符串表示
+func TestString(t *testing.T) {
+	tree := New(intCmp, WithDuplicates(CountDuplicates))
+	if tree.String() != "空树" {
+		t.Errorf("空树的字符串表示为%q", tree.String())
+	}
+	for _, v := range []int{20, 10, 30, 30, 40, 5, 25} {
+		tree.Insert(v)
+	}
+	want := `20
+├── L: 10
+│   └── L: 5
+└── R: 30×2
+    ├── L: 25
+    └── R: 40
+`
+	if got := tree.String(); got != want {
+		t.Errorf("字符串表示为\n%s期望\n%s", got, want)
+	}
+
+	avl := NewAVL(intCmp)
+	for i := range 3 {
+		avl.Insert(i)
+	}
+	if got, want := avl.String(), "1\n├── L: 0\n└── R: 2\n"; got != want {
+		t.Errorf("AVL 树的字符串表示为\n%s期望\n%s", got, want)
+	}
+}
+
+// TestToDOT 测试以 DOT 格式输出树结构
+func TestToDOT(t *testing.T) {
+	tree := NewSplay(func(a, b string) int { return strings.Compare(a, b) })
+	for _, v := range []string{"c", "a", "b", `"q"`} {
+		tree.Insert(v)
+	}
+	got := tree.ToDOT()
+	for _, line := range []string{
+		"digraph binarytree {",
+		`n0 [label="\"q\""];`,
+		`n0 -> n1 [label="R"];`,
+	} {
+		if !strings.Contains(got, line) {
+			t.Errorf("DOT 输出中缺少 %s:\n%s", line, got)
+		}
+	}
+	if strings.Count(got, "->") != 3 || !strings.HasSuffix(got, "}\n") {
+		t.Errorf("DOT 输出不正确:\n%s", got)
+	}
+	if empty := New(intCmp).ToDOT(); empty != "digraph binarytree {\n}\n" {
+		t.Errorf("空树的 DOT 输出为%q", empty)
+	}
+}
@@ -0,0 +1,184 @@
+package binarytree
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+
+	"godatastructure/codec"
+)
+
+// serialVersion 当前的树结构序列化格式版本
+const serialVersion byte = 1
+
+// 每个节点的标志位，记录子节点是否存在
+const (
+	hasLeft  byte = 1 << iota // 存在左子节点
+	hasRight                  // 存在右子节点
+)
+
+// Serialize 按前序把树的结构和元素编码为二进制，Deserialize 可以据此还原完全相同的形状
+// 格式为「版本号、节点数量、按前序依次编码的节点」，每个节点为「子节点标志、元素」，
+// CountDuplicates 模式下在子节点标志之后追加该值出现的次数，因此只能由同样使用该模式的树还原；
+// 元素使用 codec.For[T] 返回的编解码器，自定义类型可以通过 codec.Register 注册
+// 时间复杂度: O(n)
+func (t *binaryTree[T]) Serialize() ([]byte, error) {
+	return t.encode(nil)
+}
+
+// Deserialize 从 Serialize 生成的数据中还原树的结构，替换树中的所有元素
+// 数据有误或还原出的树不满足二叉搜索树性质时返回错误，树保持不变
+// 时间复杂度: O(n)
+func (t *binaryTree[T]) Deserialize(data []byte) error {
+	return t.decode(data, nil, nil)
+}
+
+// encode 按前序编码整棵树，extra 不为 nil 时在元素之前追加节点的附加信息
+// 使用显式栈实现，退化成链表的树也不会耗尽调用栈
+func (t *binaryTree[T]) encode(extra func(buf []byte, n *TreeNode[T]) []byte) ([]byte, error) {
+	c := codec.For[T]()
+	var buf []byte
+	var err error
+	nodes := 0
+	stack := []*TreeNode[T]{}
+	if t.root != nil {
+		stack = append(stack, t.root)
+	}
+	for len(stack) > 0 {
+		n := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		var flags byte
+		if n.Left != nil {
+			flags |= hasLeft
+		}
+		if n.Right != nil {
+			flags |= hasRight
+			stack = append(stack, n.Right)
+		}
+		if n.Left != nil {
+			stack = append(stack, n.Left)
+		}
+		buf = append(buf, flags)
+		if t.dup == CountDuplicates {
+			buf = binary.AppendUvarint(buf, uint64(n.count()))
+		}
+		if extra != nil {
+			buf = extra(buf, n)
+		}
+		if buf, err = c.Append(buf, n.Value); err != nil {
+			return nil, err
+		}
+		nodes++
+	}
+	// CountDuplicates 模式下节点数量小于元素数量，编码完成后再写入头部
+	header := binary.AppendUvarint([]byte{serialVersion}, uint64(nodes))
+	return append(header, buf...), nil
+}
+
+// decode 解码 encode 生成的数据并替换整棵树
+// extra 不为 nil 时在元素之前读取节点的附加信息，返回消耗的字节数；
+// fix 不为 nil 时在所有子节点之后对每个节点调用，用于重新计算并检查平衡树维护的附加信息
+func (t *binaryTree[T]) decode(data []byte, extra func(data []byte, n *TreeNode[T]) (int, error), fix func(n *TreeNode[T]) error) error {
+	if len(data) == 0 {
+		return codec.ErrTruncated
+	}
+	if data[0] != serialVersion {
+		return codec.ErrFormat
+	}
+	count, size := binary.Uvarint(data[1:])
+	switch {
+	case size == 0:
+		return codec.ErrTruncated
+	case size < 0:
+		return codec.ErrFormat
+	}
+	data = data[1+size:]
+	// 每个节点至少占两个字节，避免按错误的数量分配内存
+	if count > uint64(len(data))/2 {
+		return codec.ErrTruncated
+	}
+
+	c := codec.For[T]()
+	elements := 0 // 计入重复次数的元素数量
+	var root *TreeNode[T]
+	nodes := make([]*TreeNode[T], 0, count) // 按前序排列的节点
+	stack := []**TreeNode[T]{}              // 等待挂接子树的位置
+	if count > 0 {
+		stack = append(stack, &root)
+	}
+	err := func() error {
+		for len(stack) > 0 {
+			link := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if len(data) == 0 {
+				return codec.ErrTruncated
+			}
+			flags := data[0]
+			data = data[1:]
+			if flags&^(hasLeft|hasRight) != 0 || len(nodes) == int(count) {
+				return codec.ErrFormat
+			}
+			n := t.newNode(*new(T))
+			*link = n
+			nodes = append(nodes, n)
+			if t.dup == CountDuplicates {
+				cnt, size := binary.Uvarint(data)
+				switch {
+				case size == 0:
+					return codec.ErrTruncated
+				case size < 0 || cnt == 0 || cnt > uint64(math.MaxInt-elements):
+					return codec.ErrFormat
+				}
+				n.dups = int(cnt - 1)
+				data = data[size:]
+			}
+			elements += n.count()
+			if extra != nil {
+				size, err := extra(data, n)
+				if err != nil {
+					return err
+				}
+				data = data[size:]
+			}
+			v, size, err := c.Decode(data)
+			if err != nil {
+				return err
+			}
+			n.Value = v
+			data = data[size:]
+			if flags&hasRight != 0 {
+				stack = append(stack, &n.Right)
+			}
+			if flags&hasLeft != 0 {
+				stack = append(stack, &n.Left)
+			}
+		}
+		if len(nodes) != int(count) || len(data) != 0 {
+			return codec.ErrFormat
+		}
+		// 前序的逆序中每个节点都排在其所有子孙之后
+		if fix != nil {
+			for i := len(nodes) - 1; i >= 0; i-- {
+				if err := fix(nodes[i]); err != nil {
+					return err
+				}
+			}
+		}
+		// 借用临时的树检查有序性和重复元素策略，解码出的节点数量已经核对过
+		tmp := binaryTree[T]{root: root, cmp: t.cmp, size: elements, dup: t.dup}
+		if err := tmp.validate(nil); err != nil {
+			return fmt.Errorf("%w: %v", codec.ErrFormat, err)
+		}
+		return nil
+	}()
+	if err != nil {
+		if t.nodes != nil {
+			t.freeNodes(root)
+		}
+		return err
+	}
+	t.Clear()
+	t.root = root
+	t.size = elements
+	return nil
+}
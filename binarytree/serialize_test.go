@@ -0,0 +1,171 @@
+package binarytree
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"godatastructure/codec"
+)
+
+// preOrderValues 返回前序遍历结果，前序与中序结果相同说明两棵树的形状相同
+func preOrderValues[T any](tree BinaryTree[T]) []T {
+	result := make([]T, 0)
+	tree.PreOrderTraversal(func(v T) bool {
+		result = append(result, v)
+		return true
+	})
+	return result
+}
+
+// TestSerialize 测试各实现序列化后还原出完全相同的树结构
+func TestSerialize(t *testing.T) {
+	constructors := map[string]func() BinaryTree[int]{
+		"二叉搜索树": func() BinaryTree[int] { return New(intCmp) },
+		"伸展树":   func() BinaryTree[int] { return NewSplay(intCmp) },
+		"AVL树":  func() BinaryTree[int] { return NewAVL(intCmp) },
+		"树堆":    func() BinaryTree[int] { return NewTreap(intCmp) },
+	}
+	for name, newTree := range constructors {
+		t.Run(name, func(t *testing.T) {
+			tree := newTree()
+			for i := range 200 {
+				tree.Insert((i * 37) % 101)
+			}
+			data, err := tree.Serialize()
+			if err != nil {
+				t.Fatal(err)
+			}
+			restored := newTree()
+			restored.Insert(-1)
+			if err := restored.Deserialize(data); err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(preOrderValues(restored), preOrderValues(tree)) ||
+				!slices.Equal(inOrderValues(restored), inOrderValues(tree)) {
+				t.Error("还原后的树结构与原树不同")
+			}
+			if restored.Size() != tree.Size() || restored.Height() != tree.Height() {
+				t.Errorf("还原后大小为%d、高度为%d，期望 %d 和 %d",
+					restored.Size(), restored.Height(), tree.Size(), tree.Height())
+			}
+			// 还原后的树可以继续正常使用
+			restored.Insert(1000)
+			if !restored.Remove(50) || restored.Search(1000) == nil {
+				t.Error("还原后的树无法继续插入和删除")
+			}
+
+			empty, err := newTree().Serialize()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := restored.Deserialize(empty); err != nil || !restored.IsEmpty() {
+				t.Errorf("还原空树后应为空，错误为%v", err)
+			}
+		})
+	}
+
+	t.Run("平衡树的附加信息", func(t *testing.T) {
+		avl := NewAVL(intCmp)
+		treap := NewTreap(intCmp)
+		for i := range 100 {
+			avl.Insert(i)
+			treap.Insert(i)
+		}
+		data, _ := avl.Serialize()
+		restoredAVL := NewAVL(intCmp)
+		if err := restoredAVL.Deserialize(data); err != nil {
+			t.Fatal(err)
+		}
+		checkAVL(t, restoredAVL.(*avlTree[int]).root)
+
+		data, _ = treap.Serialize()
+		restoredTreap := NewTreap(intCmp)
+		if err := restoredTreap.Deserialize(data); err != nil {
+			t.Fatal(err)
+		}
+		checkTreap(t, restoredTreap.root)
+		if v, ok := restoredTreap.Kth(42); !ok || v != 42 {
+			t.Errorf("还原后 Kth(42) = %d，期望 42", v)
+		}
+	})
+
+	t.Run("出现次数", func(t *testing.T) {
+		tree := NewTreap(intCmp, WithDuplicates(CountDuplicates))
+		for i := range 100 {
+			tree.Insert(i % 10)
+		}
+		data, err := tree.Serialize()
+		if err != nil {
+			t.Fatal(err)
+		}
+		restored := NewTreap(intCmp, WithDuplicates(CountDuplicates))
+		if err := restored.Deserialize(data); err != nil {
+			t.Fatal(err)
+		}
+		if restored.Size() != 100 || restored.Count(3) != 10 || !slices.Equal(inOrderValues(restored), inOrderValues(tree)) {
+			t.Errorf("还原后大小为%d，Count(3) = %d", restored.Size(), restored.Count(3))
+		}
+		if err := restored.Validate(); err != nil {
+			t.Error(err)
+		}
+		if err := NewTreap(intCmp).Deserialize(data); err == nil {
+			t.Error("不使用计数模式的树不能还原带有出现次数的数据")
+		}
+	})
+}
+
+// TestDeserializeInvalid 测试数据有误时返回错误且树保持不变
+func TestDeserializeInvalid(t *testing.T) {
+	source := New(intCmp)
+	for _, v := range []int{2, 1, 3} {
+		source.Insert(v)
+	}
+	valid, _ := source.Serialize()
+
+	// 把根节点的值改为比右子节点更大，破坏有序性
+	unordered := slices.Clone(valid)
+	unordered[3] = 100
+
+	degenerate := New(intCmp)
+	for i := range 5 {
+		degenerate.Insert(i)
+	}
+	unbalanced, _ := degenerate.Serialize()
+
+	cases := []struct {
+		name string
+		tree BinaryTree[int]
+		data []byte
+		want error
+	}{
+		{"空数据", New(intCmp), nil, codec.ErrTruncated},
+		{"版本号错误", New(intCmp), append([]byte{99}, valid[1:]...), codec.ErrFormat},
+		{"数据不完整", New(intCmp), valid[:len(valid)-1], codec.ErrTruncated},
+		{"多余的数据", New(intCmp), append(slices.Clone(valid), 0), codec.ErrFormat},
+		{"标志位错误", New(intCmp), append([]byte{valid[0], valid[1], 0xff}, valid[3:]...), codec.ErrFormat},
+		{"违反有序性", New(intCmp), unordered, codec.ErrFormat},
+		{"AVL树不平衡", NewAVL(intCmp), unbalanced, codec.ErrFormat},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.tree.Insert(7)
+			if err := tc.tree.Deserialize(tc.data); !errors.Is(err, tc.want) {
+				t.Errorf("期望错误 %v，实际为 %v", tc.want, err)
+			}
+			if got := inOrderValues(tc.tree); !sliceEqual(got, []int{7}) {
+				t.Errorf("解码失败后树应保持不变，实际为%v", got)
+			}
+		})
+	}
+
+	t.Run("释放节点", func(t *testing.T) {
+		tree := New(intCmp, WithArena(8)).(*binaryTree[int])
+		if err := tree.Deserialize(unordered); err == nil {
+			t.Fatal("应返回错误")
+		}
+		if _, free := tree.nodes.Stats(); free != 3 {
+			t.Errorf("解码失败后应回收已分配的3个节点，实际为%d", free)
+		}
+	})
+}
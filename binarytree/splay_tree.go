@@ -0,0 +1,182 @@
+package binarytree
+
+// splayTree 伸展树，实现了 BinaryTree 接口
+// 每次插入、查找、删除后都会把访问到的节点旋转到根部，
+// 最近访问过的元素再次访问时路径很短，适合访问局部性强的场景
+// 任意 m 次操作的均摊时间复杂度为 O(m log n)
+type splayTree[T any] struct {
+	binaryTree[T] // 复用遍历方法
+}
+
+// NewSplay 创建一个新的伸展树，需要传入一个比较函数
+// 可选配置 opts 例如 WithArena
+func NewSplay[T any](cmp func(a, b T) int, opts ...Option) BinaryTree[T] {
+	return &splayTree[T]{newBinaryTree(cmp, opts)}
+}
+
+// Insert 插入元素，新节点成为根节点，返回元素数量是否增加
+// 与已有元素相等时按 WithDuplicates 的设置处理，已有节点被旋转到根部
+// 时间复杂度: 均摊 O(log n)
+func (t *splayTree[T]) Insert(value T) bool {
+	if t.root == nil {
+		t.root = t.newNode(value)
+		t.size++
+		return true
+	}
+	root := t.splay(t.root, value)
+	c := t.cmp(value, root.Value)
+	if c == 0 && t.dup != AllowDuplicates {
+		t.root = root
+		return t.absorb(root, value)
+	}
+	node := t.newNode(value)
+	t.size++
+	if c < 0 {
+		node.Left = root.Left
+		node.Right = root
+		root.Left = nil
+	} else {
+		node.Right = root.Right
+		node.Left = root
+		root.Right = nil
+	}
+	t.root = node
+	return true
+}
+
+// Search 查找元素，找到时将其旋转到根部
+// 未找到时最后访问的节点成为根节点
+// 时间复杂度: 均摊 O(log n)
+func (t *splayTree[T]) Search(value T) *TreeNode[T] {
+	if t.root == nil {
+		return nil
+	}
+	t.root = t.splay(t.root, value)
+	if t.cmp(value, t.root.Value) == 0 {
+		return t.root
+	}
+	return nil
+}
+
+// Remove 删除一个值等于 value 的元素，返回元素是否存在
+// CountDuplicates 模式下值出现多次时只减少一次计数
+// 时间复杂度: 均摊 O(log n)
+func (t *splayTree[T]) Remove(value T) bool {
+	if t.Search(value) == nil {
+		return false
+	}
+	if t.release(t.root) {
+		return true
+	}
+	t.size--
+	left, right := t.root.Left, t.root.Right
+	t.nodes.Free(t.root)
+	if left == nil {
+		t.root = right
+		return true
+	}
+	// 左子树的最大节点旋转到根后没有右子节点，直接接上原右子树
+	t.root = splayBy(left, func(T) int { return 1 })
+	t.root.Right = right
+	return true
+}
+
+// RemoveAll 删除值等于 value 的所有元素，返回删除的元素数量
+// 时间复杂度: 均摊 O(k log n)，k 为删除的元素数量
+func (t *splayTree[T]) RemoveAll(value T) int {
+	return removeAll(t, value)
+}
+
+// RemoveAndGet 删除一个值等于 value 的元素，返回树中存储的值，元素不存在时第二个返回值为 false
+// 时间复杂度: 均摊 O(log n)
+func (t *splayTree[T]) RemoveAndGet(value T) (T, bool) {
+	return removeAndGet(t, value)
+}
+
+// Min 返回最小元素，并将其旋转到根部，空树时第二个返回值为 false
+// 时间复杂度: 均摊 O(log n)
+func (t *splayTree[T]) Min() (T, bool) {
+	if t.root == nil {
+		var zero T
+		return zero, false
+	}
+	t.root = splayBy(t.root, func(T) int { return -1 })
+	return t.root.Value, true
+}
+
+// Max 返回最大元素，并将其旋转到根部，空树时第二个返回值为 false
+// 时间复杂度: 均摊 O(log n)
+func (t *splayTree[T]) Max() (T, bool) {
+	if t.root == nil {
+		var zero T
+		return zero, false
+	}
+	t.root = splayBy(t.root, func(T) int { return 1 })
+	return t.root.Value, true
+}
+
+// Clone 返回结构相同的伸展树深拷贝
+// copier 用于复制每个元素，为 nil 时直接赋值
+// 时间复杂度: O(n)
+func (t *splayTree[T]) Clone(copier func(T) T) BinaryTree[T] {
+	return &splayTree[T]{binaryTree[T]{root: cloneNode(t.root, copier), cmp: t.cmp, size: t.size, dup: t.dup}}
+}
+
+// splay 自顶向下伸展，把值等于 value 的节点（不存在时为最后访问的节点）旋转到根部
+func (t *splayTree[T]) splay(root *TreeNode[T], value T) *TreeNode[T] {
+	return splayBy(root, func(v T) int { return t.cmp(value, v) })
+}
+
+// splayBy 自顶向下伸展，dir 返回目标相对于节点值的方向：小于0向左，大于0向右，等于0停止
+func splayBy[T any](root *TreeNode[T], dir func(T) int) *TreeNode[T] {
+	var header TreeNode[T]
+	leftMax, rightMin := &header, &header // 左树的最大节点、右树的最小节点
+	for {
+		c := dir(root.Value)
+		if c < 0 {
+			if root.Left == nil {
+				break
+			}
+			if dir(root.Left.Value) < 0 {
+				// zig-zig：先右旋
+				child := root.Left
+				root.Left = child.Right
+				child.Right = root
+				root = child
+				if root.Left == nil {
+					break
+				}
+			}
+			// 挂到右树
+			rightMin.Left = root
+			rightMin = root
+			root = root.Left
+		} else if c > 0 {
+			if root.Right == nil {
+				break
+			}
+			if dir(root.Right.Value) > 0 {
+				// zag-zag：先左旋
+				child := root.Right
+				root.Right = child.Left
+				child.Left = root
+				root = child
+				if root.Right == nil {
+					break
+				}
+			}
+			// 挂到左树
+			leftMax.Right = root
+			leftMax = root
+			root = root.Right
+		} else {
+			break
+		}
+	}
+	// 重新组装
+	leftMax.Right = root.Left
+	rightMin.Left = root.Right
+	root.Left = header.Right
+	root.Right = header.Left
+	return root
+}
@@ -0,0 +1,224 @@
+package binarytree
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// inOrderValues 返回中序遍历结果
+func inOrderValues[T any](tree BinaryTree[T]) []T {
+	result := make([]T, 0)
+	tree.InOrderTraversal(func(v T) bool {
+		result = append(result, v)
+		return true
+	})
+	return result
+}
+
+// TestSplayTree 测试伸展树的基本操作
+func TestSplayTree(t *testing.T) {
+	tree := NewSplay(intCmp)
+	values := []int{5, 3, 7, 1, 4, 6, 8}
+	for _, v := range values {
+		tree.Insert(v)
+	}
+
+	t.Run("InOrder", func(t *testing.T) {
+		expected := []int{1, 3, 4, 5, 6, 7, 8}
+		if result := inOrderValues(tree); !sliceEqual(result, expected) {
+			t.Errorf("中序遍历结果错误，期望 %v，得到 %v", expected, result)
+		}
+	})
+
+	t.Run("Search Splays To Root", func(t *testing.T) {
+		node := tree.Search(4)
+		if node == nil || node.Value != 4 {
+			t.Fatal("未找到已存在的值4")
+		}
+		if root := tree.(*splayTree[int]).root; root != node {
+			t.Errorf("查找后根节点应为4，实际为%d", root.Value)
+		}
+		if tree.Search(2) != nil {
+			t.Error("找到了不应存在的值2")
+		}
+		// 查找后树的内容不变
+		if result := inOrderValues(tree); !sliceEqual(result, []int{1, 3, 4, 5, 6, 7, 8}) {
+			t.Errorf("查找后中序遍历结果错误: %v", result)
+		}
+	})
+
+	t.Run("Min and Max Splay To Root", func(t *testing.T) {
+		if v, _ := tree.Min(); tree.(*splayTree[int]).root.Value != v {
+			t.Error("Min 后最小元素应位于根部")
+		}
+		if v, _ := tree.Max(); tree.(*splayTree[int]).root.Value != v {
+			t.Error("Max 后最大元素应位于根部")
+		}
+	})
+
+	t.Run("Remove", func(t *testing.T) {
+		if !tree.Remove(5) {
+			t.Error("删除节点5失败")
+		}
+		if tree.Remove(5) {
+			t.Error("重复删除应该返回false")
+		}
+		if tree.Search(5) != nil {
+			t.Error("删除后仍能找到节点5")
+		}
+		if result := inOrderValues(tree); !sliceEqual(result, []int{1, 3, 4, 6, 7, 8}) {
+			t.Errorf("删除后中序遍历结果错误: %v", result)
+		}
+	})
+
+	t.Run("Empty Tree", func(t *testing.T) {
+		empty := NewSplay(intCmp)
+		if empty.Search(1) != nil || empty.Remove(1) {
+			t.Error("空树不应包含任何元素")
+		}
+	})
+
+	t.Run("Duplicate Insert", func(t *testing.T) {
+		tree := NewSplay(intCmp)
+		tree.Insert(1)
+		tree.Insert(1)
+		if !tree.Remove(1) || tree.Search(1) == nil {
+			t.Error("删除一个重复值后另一个应仍然存在")
+		}
+	})
+}
+
+// TestSplayTreeRandom 与有序切片对比随机操作
+func TestSplayTreeRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	tree := NewSplay(intCmp)
+	var want []int
+	for i := 0; i < 3000; i++ {
+		v := r.Intn(300)
+		idx, found := slices.BinarySearch(want, v)
+		switch r.Intn(3) {
+		case 0:
+			if tree.Remove(v) != found {
+				t.Fatalf("Remove(%d)返回值错误", v)
+			}
+			if found {
+				want = slices.Delete(want, idx, idx+1)
+			}
+		case 1:
+			if (tree.Search(v) != nil) != found {
+				t.Fatalf("Search(%d)结果错误", v)
+			}
+		default:
+			tree.Insert(v)
+			want = slices.Insert(want, idx, v)
+		}
+	}
+	if result := inOrderValues(tree); !sliceEqual(result, want) {
+		t.Fatal("随机操作后元素不一致")
+	}
+}
+
+// TestSplayAccessLocality 测试伸展树的访问局部性：查找后元素位于根部，反复访问的少量热点元素始终位于树的顶部
+func TestSplayAccessLocality(t *testing.T) {
+	tree := NewSplay(intCmp)
+	rng := rand.New(rand.NewSource(1))
+	for _, v := range rng.Perm(10000) {
+		tree.Insert(v)
+	}
+	hot := []int{17, 4242, 9001, 123, 5555, 7777, 31, 8080}
+	for range 10 {
+		for _, k := range hot {
+			if tree.Search(k) == nil {
+				t.Fatalf("未找到已插入的值%d", k)
+			}
+			if d := tree.Depth(k); d != 0 {
+				t.Fatalf("查找%d后其深度应为0，实际为%d", k, d)
+			}
+		}
+		for _, k := range hot {
+			// 热点元素依次被旋转到根部，彼此之间只相隔最近访问过的其他热点元素
+			if d := tree.Depth(k); d >= len(hot) {
+				t.Errorf("反复访问的热点元素%d的深度为%d，期望小于%d", k, d, len(hot))
+			}
+		}
+	}
+	if h := tree.Height(); h <= len(hot) {
+		t.Errorf("树高为%d，无法体现热点元素比其余元素更浅", h)
+	}
+}
+
+// 性能测试
+func BenchmarkSplayTree(b *testing.B) {
+	tree := NewSplay(intCmp)
+	for i := 0; i < 10000; i++ {
+		tree.Insert(i)
+	}
+	b.Run("顺序查找", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			tree.Search(i % 10000)
+		}
+	})
+	b.Run("热点查找", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			tree.Search(i % 16)
+		}
+	})
+}
+
+// TestSplaySizeClear 测试伸展树的节点计数与清空
+func TestSplaySizeClear(t *testing.T) {
+	for name, tree := range map[string]BinaryTree[int]{
+		"二叉搜索树": New(intCmp),
+		"伸展树":   NewSplay(intCmp),
+	} {
+		t.Run(name, func(t *testing.T) {
+			for _, v := range []int{5, 3, 8, 1, 4} {
+				tree.Insert(v)
+			}
+			if tree.Size() != 5 || tree.IsEmpty() {
+				t.Errorf("期望大小为5，实际为%d", tree.Size())
+			}
+			tree.Remove(3)
+			tree.Remove(100)
+			if tree.Size() != 4 {
+				t.Errorf("删除后期望大小为4，实际为%d", tree.Size())
+			}
+			tree.Clear()
+			if !tree.IsEmpty() || tree.Size() != 0 || tree.Search(5) != nil {
+				t.Error("清空后树应为空")
+			}
+		})
+	}
+}
+
+// TestClone 测试二叉搜索树和伸展树的深拷贝
+func TestClone(t *testing.T) {
+	for name, tree := range map[string]BinaryTree[int]{
+		"二叉搜索树": New(intCmp),
+		"伸展树":   NewSplay(intCmp),
+	} {
+		t.Run(name, func(t *testing.T) {
+			for _, v := range []int{5, 3, 8, 1, 4} {
+				tree.Insert(v)
+			}
+			clone := tree.Clone(nil)
+			if !slices.Equal(inOrderValues(clone), []int{1, 3, 4, 5, 8}) || clone.Size() != 5 {
+				t.Fatalf("拷贝的元素不正确: %v", inOrderValues(clone))
+			}
+			tree.Remove(3)
+			clone.Insert(9)
+			if clone.Search(3) == nil || tree.Search(9) != nil {
+				t.Error("拷贝与原树应互不影响")
+			}
+			// 伸展树的拷贝应保持伸展树的行为
+			if clone.Search(1); name == "伸展树" && inOrderValues(clone)[0] != 1 {
+				t.Error("拷贝后的伸展树顺序不正确")
+			}
+			doubled := tree.Clone(func(v int) int { return v * 2 })
+			if !slices.Equal(inOrderValues(doubled), []int{2, 8, 10, 16}) {
+				t.Errorf("copier 应作用于每个元素，实际为%v", inOrderValues(doubled))
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package binarytree
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+)
+
+var (
+	ErrTraversalMismatch = errors.New("前序与中序遍历序列不一致")
+)
+
+// NewFromTraversals 由前序与中序遍历序列还原二叉搜索树的原始结构
+// 依次取前序中的元素：中序中的下一个元素尚未出现在当前路径上时作为左子节点，
+// 否则沿路径回溯到中序对应的祖先后作为其右子节点。元素的相等关系由 cmp 判断
+// 参数：
+//   - cmp: 比较函数
+//   - preorder: 前序遍历序列
+//   - inorder: 中序遍历序列
+//   - opts: 可选配置，例如 WithArena
+//
+// 返回：
+//   - BinaryTree[T]: 还原出的树
+//   - error: 两个序列长度不同、无法构成同一棵树，或还原出的树不满足二叉搜索树性质时返回包装了 ErrTraversalMismatch 的错误
+//
+// 时间复杂度: O(n)
+func NewFromTraversals[T any](cmp func(a, b T) int, preorder, inorder []T, opts ...Option) (BinaryTree[T], error) {
+	if len(preorder) != len(inorder) {
+		return nil, fmt.Errorf("%w: 前序有%d个元素，中序有%d个元素", ErrTraversalMismatch, len(preorder), len(inorder))
+	}
+	t := newBinaryTree(cmp, opts)
+	if len(preorder) == 0 {
+		return &t, nil
+	}
+	t.root = t.newNode(preorder[0])
+	t.size = len(preorder)
+	stack := []*TreeNode[T]{t.root} // 当前路径上尚未确定右子树的节点
+	j := 0                          // 中序中下一个待匹配的位置
+	for _, v := range preorder[1:] {
+		if j == len(inorder) {
+			// 中序已全部匹配，前序中却还有元素
+			t.Clear()
+			return nil, fmt.Errorf("%w: 前序中的 %v 在中序中没有对应的位置", ErrTraversalMismatch, v)
+		}
+		node := t.newNode(v)
+		parent := stack[len(stack)-1]
+		if cmp(parent.Value, inorder[j]) != 0 {
+			parent.Left = node
+		} else {
+			for len(stack) > 0 && j < len(inorder) && cmp(stack[len(stack)-1].Value, inorder[j]) == 0 {
+				parent = stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				j++
+			}
+			parent.Right = node
+		}
+		stack = append(stack, node)
+	}
+	// 序列不一致时上面的过程仍会得到一棵树，需要核对其遍历结果
+	if err := t.matches(inorder, t.InOrder()); err != nil {
+		t.Clear()
+		return nil, fmt.Errorf("%w: 中序%v", ErrTraversalMismatch, err)
+	}
+	if err := t.matches(preorder, t.PreOrder()); err != nil {
+		t.Clear()
+		return nil, fmt.Errorf("%w: 前序%v", ErrTraversalMismatch, err)
+	}
+	if err := t.Validate(); err != nil {
+		t.Clear()
+		return nil, fmt.Errorf("%w: %v", ErrTraversalMismatch, err)
+	}
+	return &t, nil
+}
+
+// matches 检查 seq 的遍历结果是否与 want 逐个相等
+func (t *binaryTree[T]) matches(want []T, seq iter.Seq[T]) error {
+	i := 0
+	for v := range seq {
+		if t.cmp(v, want[i]) != 0 {
+			return fmt.Errorf("第%d个元素应为 %v，还原出的树为 %v", i, want[i], v)
+		}
+		i++
+	}
+	return nil
+}
+
+// Leaves 按从左到右的顺序返回所有叶子节点（没有子节点的节点）的元素
+// 时间复杂度: O(n)
+func (t *binaryTree[T]) Leaves() []T {
+	var leaves []T
+	t.walkPaths(func(path []*TreeNode[T]) {
+		leaves = append(leaves, path[len(path)-1].Value)
+	})
+	return leaves
+}
+
+// Paths 按从左到右的顺序把每条从根节点到叶子节点的路径传给 fn，路径的第一个元素为根节点
+// 各次调用共用同一个切片，fn 需要保留路径时应自行复制
+// 时间复杂度: O(n + 所有路径的总长度)
+func (t *binaryTree[T]) Paths(fn func(path []T)) {
+	var values []T
+	t.walkPaths(func(path []*TreeNode[T]) {
+		values = values[:0]
+		for _, n := range path {
+			values = append(values, n.Value)
+		}
+		fn(values)
+	})
+}
+
+// walkPaths 按前序遍历，每到达一个叶子节点就把从根节点到该叶子的节点路径传给 fn
+// 使用显式栈实现，退化成链表的树也不会耗尽调用栈
+func (t *binaryTree[T]) walkPaths(fn func(path []*TreeNode[T])) {
+	if t.root == nil {
+		return
+	}
+	type frame struct {
+		node  *TreeNode[T]
+		depth int // 节点在路径中的位置
+	}
+	var path []*TreeNode[T]
+	stack := []frame{{t.root, 0}}
+	for len(stack) > 0 {
+		f := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		path = append(path[:f.depth], f.node)
+		if f.node.Left == nil && f.node.Right == nil {
+			fn(path)
+			continue
+		}
+		if f.node.Right != nil {
+			stack = append(stack, frame{f.node.Right, f.depth + 1})
+		}
+		if f.node.Left != nil {
+			stack = append(stack, frame{f.node.Left, f.depth + 1})
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package binarytree
+
+import (
+	"errors"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// TestNewFromTraversals 测试由前序与中序遍历序列还原树结构
+func TestNewFromTraversals(t *testing.T) {
+	t.Run("还原结构", func(t *testing.T) {
+		rng := rand.New(rand.NewSource(1))
+		for range 50 {
+			source := New(intCmp)
+			for range rng.Intn(100) {
+				source.Insert(rng.Intn(1000))
+			}
+			pre, in := preOrderValues(source), inOrderValues(source)
+			tree, err := NewFromTraversals(intCmp, pre, in)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !slices.Equal(preOrderValues(tree), pre) || !slices.Equal(inOrderValues(tree), in) {
+				t.Fatal("还原后的树结构与原树不同")
+			}
+			if tree.Size() != source.Size() || tree.Height() != source.Height() {
+				t.Fatalf("还原后大小为%d、高度为%d，期望 %d 和 %d",
+					tree.Size(), tree.Height(), source.Size(), source.Height())
+			}
+		}
+	})
+
+	t.Run("空序列", func(t *testing.T) {
+		tree, err := NewFromTraversals(intCmp, nil, nil)
+		if err != nil || !tree.IsEmpty() {
+			t.Errorf("空序列应还原出空树，错误为%v", err)
+		}
+	})
+
+	t.Run("随机序列", func(t *testing.T) {
+		// 任意排列组合都不应 panic，成功时还原出的树与两个序列一致
+		rng := rand.New(rand.NewSource(2))
+		for range 2000 {
+			n := rng.Intn(6)
+			pre, in := rng.Perm(n), rng.Perm(n)
+			for i := range n {
+				pre[i] %= 3 // 引入重复元素
+				in[i] %= 3
+			}
+			slices.Sort(in)
+			tree, err := NewFromTraversals(intCmp, pre, in)
+			if err != nil {
+				continue
+			}
+			if !slices.Equal(preOrderValues(tree), pre) || !slices.Equal(inOrderValues(tree), in) {
+				t.Fatalf("还原出的树与序列 %v、%v 不一致", pre, in)
+			}
+		}
+	})
+
+	t.Run("序列不一致", func(t *testing.T) {
+		for name, tc := range map[string][2][]int{
+			"长度不同":  {{2, 1}, {1, 2, 3}},
+			"元素不同":  {{2, 1, 3}, {1, 2, 4}},
+			"结构不一致": {{2, 1, 3}, {3, 1, 2}},
+			"违反有序性": {{1, 2, 3}, {2, 1, 3}},
+		} {
+			if _, err := NewFromTraversals(intCmp, tc[0], tc[1]); !errors.Is(err, ErrTraversalMismatch) {
+				t.Errorf("%s: 期望 ErrTraversalMismatch，实际为%v", name, err)
+			}
+		}
+	})
+}
+
+// TestLeavesAndPaths 测试叶子节点与根到叶子路径的枚举
+func TestLeavesAndPaths(t *testing.T) {
+	tree := New(intCmp)
+	if leaves := tree.Leaves(); len(leaves) != 0 {
+		t.Errorf("空树不应有叶子节点，实际为%v", leaves)
+	}
+	tree.Paths(func([]int) { t.Error("空树不应有路径") })
+
+	//         5
+	//       /   \
+	//      3     7
+	//     / \     \
+	//    1   4     8
+	//     \
+	//      2
+	for _, v := range []int{5, 3, 7, 1, 4, 8, 2} {
+		tree.Insert(v)
+	}
+	if leaves := tree.Leaves(); !slices.Equal(leaves, []int{2, 4, 8}) {
+		t.Errorf("Leaves() = %v，期望 [2 4 8]", leaves)
+	}
+	var paths [][]int
+	tree.Paths(func(path []int) {
+		paths = append(paths, slices.Clone(path))
+	})
+	want := [][]int{{5, 3, 1, 2}, {5, 3, 4}, {5, 7, 8}}
+	if !slices.EqualFunc(paths, want, slices.Equal[[]int]) {
+		t.Errorf("Paths 结果为%v，期望 %v", paths, want)
+	}
+
+	single := New(intCmp)
+	single.Insert(1)
+	if leaves := single.Leaves(); !slices.Equal(leaves, []int{1}) {
+		t.Errorf("只有根节点时根节点即为叶子，实际为%v", leaves)
+	}
+}
@@ -0,0 +1,330 @@
+package binarytree
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand/v2"
+
+	"godatastructure/codec"
+)
+
+var (
+	ErrMergeOrder = errors.New("合并的树中存在小于当前树最大值的元素")
+)
+
+// Treap 树堆，实现了 BinaryTree 接口
+// 按值满足二叉搜索树性质，按随机优先级满足大根堆性质，期望高度为 O(log n)
+// 以按值分裂（Split）与合并（Merge）作为基本操作，适合有序序列的切分与拼接
+// 与 BinaryTree 的其他实现一样默认允许重复元素，相等的元素排在已有元素之后；
+// 节点记录的子树大小计入重复次数，Kth、Rank 等顺序统计在 CountDuplicates 模式下同样按元素计数，
+// treap 包的 Treap 与 Multiset 都包装本实现
+type Treap[T any] struct {
+	binaryTree[T] // 复用查找与遍历方法
+}
+
+// NewTreap 创建一个新的树堆，需要传入一个比较函数
+// 可选配置 opts 例如 WithArena
+func NewTreap[T any](cmp func(a, b T) int, opts ...Option) *Treap[T] {
+	return &Treap[T]{newBinaryTree(cmp, opts)}
+}
+
+// Insert 插入元素，返回元素数量是否增加
+// 与已有元素相等时按 WithDuplicates 的设置处理
+// 时间复杂度: 期望 O(log n)
+func (t *Treap[T]) Insert(value T) bool {
+	if t.dup != AllowDuplicates {
+		if n := t.find(t.root, value); n != nil {
+			if t.dup == CountDuplicates {
+				// 沿查找路径更新子树大小
+				for p := t.root; p != n; {
+					p.size++
+					if t.cmp(value, p.Value) < 0 {
+						p = p.Left
+					} else {
+						p = p.Right
+					}
+				}
+				n.size++
+			}
+			return t.absorb(n, value)
+		}
+	}
+	left, right := t.split(t.root, value, true)
+	n := t.newNode(value)
+	n.priority = rand.Uint32()
+	n.size = 1
+	t.root = mergeTreap(mergeTreap(left, n), right)
+	t.size++
+	return true
+}
+
+// Remove 删除一个值等于 value 的元素，返回元素是否存在
+// 被删除节点的左右子树合并后接替它的位置，CountDuplicates 模式下值出现多次时只减少一次计数
+// 时间复杂度: 期望 O(log n)
+func (t *Treap[T]) Remove(value T) bool {
+	if t.Search(value) == nil {
+		return false
+	}
+	// 沿查找路径更新子树大小
+	link := &t.root
+	for {
+		node := *link
+		node.size--
+		c := t.cmp(value, node.Value)
+		if c == 0 {
+			break
+		}
+		if c < 0 {
+			link = &node.Left
+		} else {
+			link = &node.Right
+		}
+	}
+	node := *link
+	if t.release(node) {
+		return true
+	}
+	*link = mergeTreap(node.Left, node.Right)
+	t.nodes.Free(node)
+	t.size--
+	return true
+}
+
+// RemoveAll 删除值等于 value 的所有元素，返回删除的元素数量
+// 时间复杂度: 期望 O(k log n)，k 为删除的元素数量
+func (t *Treap[T]) RemoveAll(value T) int {
+	return removeAll(t, value)
+}
+
+// RemoveAndGet 删除一个值等于 value 的元素，返回树堆中存储的值，元素不存在时第二个返回值为 false
+// 时间复杂度: 期望 O(log n)
+func (t *Treap[T]) RemoveAndGet(value T) (T, bool) {
+	return removeAndGet(t, value)
+}
+
+// RemoveFunc 删除所有满足 pred 的元素，返回删除的元素数量
+// pred 对每个节点调用一次；剩余节点保留原有的优先级，按中序用单调栈重新链接
+// 时间复杂度: O(n)
+func (t *Treap[T]) RemoveFunc(pred func(T) bool) int {
+	kept, removed := t.prune(pred)
+	if removed > 0 {
+		t.root = relinkTreap(kept)
+	}
+	return removed
+}
+
+// Kth 返回第 k 小的元素（k 从0开始），k 越界时第二个返回值为 false
+// 利用节点记录的子树大小直接向下查找
+// 时间复杂度: 期望 O(log n)
+func (t *Treap[T]) Kth(k int) (T, bool) {
+	if k < 0 || k >= t.size {
+		var zero T
+		return zero, false
+	}
+	n := t.root
+	for {
+		ls := sizeOf(n.Left)
+		switch {
+		case k < ls:
+			n = n.Left
+		case k < ls+n.count():
+			return n.Value, true
+		default:
+			k -= ls + n.count()
+			n = n.Right
+		}
+	}
+}
+
+// Rank 返回严格小于 value 的元素数量，即 value 第一次出现时的名次
+// 时间复杂度: 期望 O(log n)
+func (t *Treap[T]) Rank(value T) int {
+	return t.rank(value, false)
+}
+
+// Count 返回值等于 value 的元素数量，利用子树大小计算，不需要逐个访问相等的元素
+// 时间复杂度: 期望 O(log n)
+func (t *Treap[T]) Count(value T) int {
+	return t.rank(value, true) - t.rank(value, false)
+}
+
+// rank 返回小于 value 的元素数量，inclusive 为 true 时同时计入等于 value 的元素
+// 相等的元素可能位于节点的任意一侧，但左子树中的元素都不大于节点，右子树中的元素都不小于节点
+func (t *Treap[T]) rank(value T, inclusive bool) int {
+	rank := 0
+	for n := t.root; n != nil; {
+		if c := t.cmp(value, n.Value); c < 0 || (c == 0 && !inclusive) {
+			n = n.Left
+		} else {
+			rank += sizeOf(n.Left) + n.count()
+			n = n.Right
+		}
+	}
+	return rank
+}
+
+// Split 按 key 将树堆分裂为两棵：左树包含所有小于 key 的元素，右树包含其余元素
+// 两棵树继承比较函数、节点分配器和重复元素策略，分裂后原树堆变为空树
+// 时间复杂度: 期望 O(log n)
+func (t *Treap[T]) Split(key T) (*Treap[T], *Treap[T]) {
+	left, right := t.split(t.root, key, false)
+	t.root, t.size = nil, 0
+	return t.with(left), t.with(right)
+}
+
+// Merge 将 other 中的所有元素并入当前树堆，合并后 other 变为空树
+// 要求 other 中的所有元素都不小于当前树堆的最大元素；不允许重复元素时（见 WithDuplicates）要求严格大于
+// 参数：
+//   - other: 待合并的树堆
+//
+// 返回值：
+//   - error: 不满足顺序要求时返回 ErrMergeOrder，两棵树均保持不变
+//
+// 时间复杂度: 期望 O(log n)
+func (t *Treap[T]) Merge(other *Treap[T]) error {
+	if t == other || other.root == nil {
+		return nil
+	}
+	if t.root != nil {
+		last := t.root
+		for last.Right != nil {
+			last = last.Right
+		}
+		first := other.root
+		for first.Left != nil {
+			first = first.Left
+		}
+		if c := t.cmp(first.Value, last.Value); c < 0 || (c == 0 && t.dup != AllowDuplicates) {
+			return ErrMergeOrder
+		}
+	}
+	t.root = mergeTreap(t.root, other.root)
+	t.size += other.size
+	other.root, other.size = nil, 0
+	return nil
+}
+
+// Clone 返回结构和优先级都相同的树堆深拷贝
+// copier 用于复制每个元素，为 nil 时直接赋值；复制后的元素应与原元素保持相同的顺序
+// 时间复杂度: O(n)
+func (t *Treap[T]) Clone(copier func(T) T) BinaryTree[T] {
+	return &Treap[T]{binaryTree[T]{root: cloneNode(t.root, copier), cmp: t.cmp, size: t.size, dup: t.dup}}
+}
+
+// Serialize 按前序把树堆的结构、元素和每个节点的优先级编码为二进制
+// 时间复杂度: O(n)
+func (t *Treap[T]) Serialize() ([]byte, error) {
+	return t.encode(func(buf []byte, n *TreeNode[T]) []byte {
+		return binary.AppendUvarint(buf, uint64(n.priority))
+	})
+}
+
+// Deserialize 从 Serialize 生成的数据中还原树堆的结构和优先级，替换树堆中的所有元素
+// 子树大小根据结构重新计算，优先级不满足堆性质时返回错误，树堆保持不变
+// 时间复杂度: O(n)
+func (t *Treap[T]) Deserialize(data []byte) error {
+	return t.decode(data, func(data []byte, n *TreeNode[T]) (int, error) {
+		p, size := binary.Uvarint(data)
+		switch {
+		case size == 0:
+			return 0, codec.ErrTruncated
+		case size < 0 || p > math.MaxUint32:
+			return 0, codec.ErrFormat
+		}
+		n.priority = uint32(p)
+		return size, nil
+	}, func(n *TreeNode[T]) error {
+		updateSize(n)
+		for _, child := range []*TreeNode[T]{n.Left, n.Right} {
+			if child != nil && child.priority > n.priority {
+				return fmt.Errorf("%w: 元素 %v 的优先级大于父节点 %v 的优先级", codec.ErrFormat, child.Value, n.Value)
+			}
+		}
+		return nil
+	})
+}
+
+// with 返回以 root 为根、与 t 共享比较函数、节点分配器和重复元素策略的树堆
+func (t *Treap[T]) with(root *TreeNode[T]) *Treap[T] {
+	return &Treap[T]{binaryTree[T]{root: root, cmp: t.cmp, size: sizeOf(root), nodes: t.nodes, dup: t.dup}}
+}
+
+// split 将子树分裂为两部分
+// inclusive 为 false 时左部分为小于 key 的元素，为 true 时左部分为小于等于 key 的元素
+// 递归深度不超过树高，期望为 O(log n)
+func (t *Treap[T]) split(n *TreeNode[T], key T, inclusive bool) (*TreeNode[T], *TreeNode[T]) {
+	if n == nil {
+		return nil, nil
+	}
+	c := t.cmp(n.Value, key)
+	if c < 0 || (inclusive && c == 0) {
+		left, right := t.split(n.Right, key, inclusive)
+		n.Right = left
+		updateSize(n)
+		return n, right
+	}
+	left, right := t.split(n.Left, key, inclusive)
+	n.Left = right
+	updateSize(n)
+	return left, n
+}
+
+// mergeTreap 合并两棵树堆子树，要求 a 中所有元素都不大于 b 中的元素
+func mergeTreap[T any](a, b *TreeNode[T]) *TreeNode[T] {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	if a.priority >= b.priority {
+		a.Right = mergeTreap(a.Right, b)
+		updateSize(a)
+		return a
+	}
+	b.Left = mergeTreap(a, b.Left)
+	updateSize(b)
+	return b
+}
+
+// relinkTreap 按中序排列的节点和各自的优先级重新链接为树堆并更新子树大小，返回根节点
+// 栈中保存当前的右链，弹出的节点不会再获得子节点，弹出时即可计算子树大小
+func relinkTreap[T any](nodes []*TreeNode[T]) *TreeNode[T] {
+	var stack []*TreeNode[T]
+	for _, n := range nodes {
+		var last *TreeNode[T]
+		// 优先级相同时先出现的节点作为祖先，与 mergeTreap 一致
+		for len(stack) > 0 && stack[len(stack)-1].priority < n.priority {
+			last = stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			updateSize(last)
+		}
+		n.Left, n.Right = last, nil
+		if len(stack) > 0 {
+			stack[len(stack)-1].Right = n
+		}
+		stack = append(stack, n)
+	}
+	if len(stack) == 0 {
+		return nil
+	}
+	for i := len(stack) - 1; i >= 0; i-- {
+		updateSize(stack[i])
+	}
+	return stack[0]
+}
+
+// sizeOf 返回树堆子树的大小，空子树为0
+func sizeOf[T any](n *TreeNode[T]) int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+// updateSize 根据子节点和节点的计数重新计算子树大小
+func updateSize[T any](n *TreeNode[T]) {
+	n.size = sizeOf(n.Left) + sizeOf(n.Right) + n.count()
+}
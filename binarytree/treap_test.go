@@ -0,0 +1,232 @@
+package binarytree
+
+import (
+	"errors"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// checkTreap 检查树堆的有序性、堆性质和每个节点记录的子树大小，返回子树大小
+func checkTreap(t *testing.T, n *TreeNode[int]) int {
+	t.Helper()
+	if n == nil {
+		return 0
+	}
+	for _, child := range []*TreeNode[int]{n.Left, n.Right} {
+		if child != nil && child.priority > n.priority {
+			t.Fatalf("节点%d的优先级小于子节点%d", n.Value, child.Value)
+		}
+	}
+	if n.Left != nil && n.Left.Value > n.Value {
+		t.Fatalf("节点%d的左子节点%d更大", n.Value, n.Left.Value)
+	}
+	if n.Right != nil && n.Right.Value < n.Value {
+		t.Fatalf("节点%d的右子节点%d更小", n.Value, n.Right.Value)
+	}
+	if size := checkTreap(t, n.Left) + checkTreap(t, n.Right) + 1; n.size != size {
+		t.Fatalf("节点%d记录的子树大小为%d，实际为%d", n.Value, n.size, size)
+	}
+	return n.size
+}
+
+// TestTreap 测试树堆的插入、查找、删除以及分裂与合并
+func TestTreap(t *testing.T) {
+	tree := NewTreap(intCmp)
+	for i := range 1000 {
+		tree.Insert(i) // 有序插入
+	}
+	checkTreap(t, tree.root)
+	if h := tree.Height(); h > 60 {
+		t.Errorf("有序插入1000个元素后树高为%d，期望接近 O(log n)", h)
+	}
+
+	t.Run("Search and Remove", func(t *testing.T) {
+		if node := tree.Search(500); node == nil || node.Value != 500 {
+			t.Error("未找到已插入的值500")
+		}
+		for i := 0; i < 1000; i += 2 {
+			if !tree.Remove(i) {
+				t.Fatalf("删除%d失败", i)
+			}
+		}
+		if tree.Remove(0) || tree.Search(0) != nil {
+			t.Error("删除后不应再找到0")
+		}
+		checkTreap(t, tree.root)
+		if tree.Size() != 500 {
+			t.Errorf("Size() = %d，期望 500", tree.Size())
+		}
+	})
+
+	t.Run("Split and Merge", func(t *testing.T) {
+		left, right := tree.Split(501)
+		if !tree.IsEmpty() {
+			t.Error("分裂后原树应为空")
+		}
+		checkTreap(t, left.root)
+		checkTreap(t, right.root)
+		if left.Size() != 250 || right.Size() != 250 {
+			t.Fatalf("分裂后大小分别为%d和%d，期望均为250", left.Size(), right.Size())
+		}
+		if v := inOrderValues[int](left); v[len(v)-1] != 499 {
+			t.Errorf("左树的最大元素应为499，实际为%d", v[len(v)-1])
+		}
+		if !errors.Is(right.Merge(left), ErrMergeOrder) {
+			t.Error("元素范围重叠时应返回 ErrMergeOrder")
+		}
+		if right.Size() != 250 || left.Size() != 250 {
+			t.Error("合并失败时两棵树应保持不变")
+		}
+		if err := left.Merge(right); err != nil {
+			t.Fatal(err)
+		}
+		checkTreap(t, left.root)
+		if !right.IsEmpty() || left.Size() != 500 {
+			t.Error("合并后右树应为空，左树包含全部元素")
+		}
+		if v := inOrderValues[int](left); !slices.IsSorted(v) || len(v) != 500 {
+			t.Error("合并后中序遍历应为升序的500个元素")
+		}
+	})
+
+	t.Run("Duplicates", func(t *testing.T) {
+		tree := NewTreap(intCmp)
+		for range 5 {
+			tree.Insert(1)
+		}
+		tree.Insert(2)
+		if tree.Count(1) != 5 || tree.Count(3) != 0 {
+			t.Errorf("Count(1) = %d，期望 5", tree.Count(1))
+		}
+		if tree.Rank(1) != 0 || tree.Rank(2) != 5 || tree.Rank(3) != 6 {
+			t.Error("Rank 应返回严格小于给定值的元素数量")
+		}
+		if v, ok := tree.Kth(4); !ok || v != 1 {
+			t.Errorf("Kth(4) = (%d, %v)，期望 (1, true)", v, ok)
+		}
+		if v, ok := tree.Kth(5); !ok || v != 2 {
+			t.Errorf("Kth(5) = (%d, %v)，期望 (2, true)", v, ok)
+		}
+		if _, ok := tree.Kth(6); ok {
+			t.Error("越界的k应返回false")
+		}
+		left, right := tree.Split(1)
+		if !left.IsEmpty() || right.Size() != 6 {
+			t.Error("按1分裂时所有元素都应在右树")
+		}
+		left, right = right.Split(2)
+		if left.Size() != 5 || right.Size() != 1 {
+			t.Errorf("按2分裂后大小分别为%d和%d，期望 5 和 1", left.Size(), right.Size())
+		}
+		if err := left.Merge(right); err != nil {
+			t.Error(err)
+		}
+		if !left.Remove(1) || left.Size() != 5 || left.Search(1) == nil {
+			t.Error("删除一个重复值后其余的应仍然存在")
+		}
+	})
+
+	t.Run("Validate", func(t *testing.T) {
+		tree := NewTreap(intCmp)
+		for i := range 50 {
+			tree.Insert(i % 10)
+		}
+		if err := tree.Validate(); err != nil {
+			t.Fatalf("正常使用后应通过检查: %v", err)
+		}
+		tree.root.size++
+		if tree.Validate() == nil {
+			t.Error("应发现错误的子树大小")
+		}
+		tree.root.size--
+		child := tree.root.Left
+		if child == nil {
+			child = tree.root.Right
+		}
+		child.priority = tree.root.priority + 1
+		if tree.Validate() == nil {
+			t.Error("应发现违反堆性质")
+		}
+	})
+
+	t.Run("CountDuplicates", func(t *testing.T) {
+		tree := NewTreap(intCmp, WithDuplicates(CountDuplicates))
+		for i := range 30 {
+			tree.Insert(i % 3)
+		}
+		left, right := tree.Split(1)
+		if left.Size() != 10 || right.Size() != 20 || right.Count(1) != 10 {
+			t.Fatalf("分裂后大小分别为%d和%d，期望 10 和 20", left.Size(), right.Size())
+		}
+		if !right.Insert(1) || right.Count(1) != 11 {
+			t.Error("分裂出的树应继承重复元素策略")
+		}
+		if r := right.Rank(2); r != 11 {
+			t.Errorf("Rank(2) = %d，期望 11", r)
+		}
+		if r := right.Rank(1); r != 0 {
+			t.Errorf("Rank(1) = %d，期望 0", r)
+		}
+		if err := left.Merge(NewTreap(intCmp, WithDuplicates(CountDuplicates))); err != nil {
+			t.Error("与空树合并应成功")
+		}
+		equal := NewTreap(intCmp, WithDuplicates(CountDuplicates))
+		equal.Insert(0)
+		if !errors.Is(left.Merge(equal), ErrMergeOrder) {
+			t.Error("不允许重复元素时合并相等的元素应返回 ErrMergeOrder")
+		}
+		if err := left.Merge(right); err != nil || left.Size() != 31 || left.Validate() != nil {
+			t.Errorf("合并后大小为%d，错误为%v", left.Size(), err)
+		}
+	})
+
+	t.Run("Clone", func(t *testing.T) {
+		tree := NewTreap(intCmp)
+		for i := range 10 {
+			tree.Insert(i)
+		}
+		clone := tree.Clone(nil).(*Treap[int])
+		checkTreap(t, clone.root)
+		clone.Remove(3)
+		if tree.Search(3) == nil || tree.Size() != 10 {
+			t.Error("修改副本不应影响原树")
+		}
+	})
+}
+
+// TestTreapRandom 随机插入、删除、分裂与合并，并与排序切片比较
+func TestTreapRandom(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	tree := NewTreap(intCmp, WithArena(16))
+	var want []int
+	for i := range 3000 {
+		v := rng.Intn(200)
+		switch rng.Intn(10) {
+		case 0:
+			left, right := tree.Split(v)
+			if err := left.Merge(right); err != nil {
+				t.Fatal(err)
+			}
+			tree = left
+		case 1, 2, 3:
+			j, found := slices.BinarySearch(want, v)
+			if tree.Remove(v) != found {
+				t.Fatalf("Remove(%d) 的返回值应为 %v", v, found)
+			}
+			if found {
+				want = slices.Delete(want, j, j+1)
+			}
+		default:
+			tree.Insert(v)
+			j, _ := slices.BinarySearch(want, v)
+			want = slices.Insert(want, j, v)
+		}
+		if i%100 == 0 {
+			checkTreap(t, tree.root)
+		}
+	}
+	if got := inOrderValues[int](tree); !slices.Equal(got, want) {
+		t.Errorf("中序遍历结果错误，期望 %v，得到 %v", want, got)
+	}
+}
@@ -0,0 +1,119 @@
+package binarytree
+
+import (
+	"fmt"
+)
+
+// Validate 检查树的结构不变量，发现损坏时返回描述问题的错误
+// 检查内容：按比较函数中序遍历非递减（即满足二叉搜索树性质），不允许重复元素时严格递增，
+// 节点的计数符合重复元素策略，且元素数量等于 Size；
+// 适合直接修改过节点或大量使用后确认树的完整性
+// 时间复杂度: O(n)
+func (t *binaryTree[T]) Validate() error {
+	return t.validate(nil)
+}
+
+// validate 按中序检查有序性、节点计数和元素数量，check 不为 nil 时对每个节点额外检查平衡树维护的附加信息
+// 使用显式栈实现，退化成链表的树也不会耗尽调用栈
+func (t *binaryTree[T]) validate(check func(n *TreeNode[T]) error) error {
+	var stack []*TreeNode[T]
+	var prev *TreeNode[T]
+	count := 0
+	node := t.root
+	for node != nil || len(stack) > 0 {
+		for node != nil {
+			stack = append(stack, node)
+			node = node.Left
+		}
+		node = stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if prev != nil {
+			c := t.cmp(prev.Value, node.Value)
+			if c > 0 {
+				return fmt.Errorf("元素 %v 排在 %v 之后，违反二叉搜索树性质", node.Value, prev.Value)
+			}
+			if c == 0 && t.dup != AllowDuplicates {
+				return fmt.Errorf("元素 %v 存在多个节点，违反重复元素策略", node.Value)
+			}
+		}
+		if node.dups < 0 || (node.dups > 0 && t.dup != CountDuplicates) {
+			return fmt.Errorf("元素 %v 记录的出现次数%d不符合重复元素策略", node.Value, node.count())
+		}
+		if check != nil {
+			if err := check(node); err != nil {
+				return err
+			}
+		}
+		count += node.count()
+		prev = node
+		node = node.Right
+	}
+	if count != t.size {
+		return fmt.Errorf("记录的元素数量为%d，实际为%d", t.size, count)
+	}
+	return nil
+}
+
+// IsBalanced 检查树是否高度平衡，即每个节点左右子树的高度差不超过1
+// 时间复杂度: O(n)
+func (t *binaryTree[T]) IsBalanced() bool {
+	if t.root == nil {
+		return true
+	}
+	// 前序的逆序中每个节点都排在其所有子孙之后，可以自底向上计算高度
+	var order []*TreeNode[T]
+	stack := []*TreeNode[T]{t.root}
+	for len(stack) > 0 {
+		n := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		order = append(order, n)
+		if n.Right != nil {
+			stack = append(stack, n.Right)
+		}
+		if n.Left != nil {
+			stack = append(stack, n.Left)
+		}
+	}
+	heights := make(map[*TreeNode[T]]int, len(order))
+	for i := len(order) - 1; i >= 0; i-- {
+		n := order[i]
+		lh, rh := heights[n.Left], heights[n.Right]
+		if lh-rh > 1 || rh-lh > 1 {
+			return false
+		}
+		heights[n] = max(lh, rh) + 1
+	}
+	return true
+}
+
+// Validate 检查 AVL 树的结构不变量，发现损坏时返回描述问题的错误
+// 除二叉搜索树性质和节点数量外，还检查每个节点记录的高度以及左右子树的高度差不超过1
+// 时间复杂度: O(n)
+func (t *avlTree[T]) Validate() error {
+	return t.validate(func(n *TreeNode[T]) error {
+		if h := max(heightOf(n.Left), heightOf(n.Right)) + 1; n.height != h {
+			return fmt.Errorf("元素 %v 记录的高度为%d，实际为%d", n.Value, n.height, h)
+		}
+		if bf := balanceFactor(n); bf > 1 || bf < -1 {
+			return fmt.Errorf("元素 %v 的左右子树高度差为%d，不满足 AVL 树的平衡条件", n.Value, bf)
+		}
+		return nil
+	})
+}
+
+// Validate 检查树堆的结构不变量，发现损坏时返回描述问题的错误
+// 除二叉搜索树性质和元素数量外，还检查父节点的优先级不小于子节点，以及每个节点记录的子树大小
+// 时间复杂度: O(n)
+func (t *Treap[T]) Validate() error {
+	return t.validate(func(n *TreeNode[T]) error {
+		for _, child := range []*TreeNode[T]{n.Left, n.Right} {
+			if child != nil && child.priority > n.priority {
+				return fmt.Errorf("元素 %v 的优先级大于父节点 %v 的优先级", child.Value, n.Value)
+			}
+		}
+		if size := sizeOf(n.Left) + sizeOf(n.Right) + n.count(); n.size != size {
+			return fmt.Errorf("元素 %v 记录的子树大小为%d，实际为%d", n.Value, n.size, size)
+		}
+		return nil
+	})
+}
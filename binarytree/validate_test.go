@@ -0,0 +1,131 @@
+package binarytree
+
+import (
+	"math"
+	"testing"
+)
+
+// baseOf 返回各实现内嵌的二叉搜索树，用于在测试中直接修改节点
+func baseOf(tree BinaryTree[int]) *binaryTree[int] {
+	switch tr := tree.(type) {
+	case *binaryTree[int]:
+		return tr
+	case *splayTree[int]:
+		return &tr.binaryTree
+	case *avlTree[int]:
+		return &tr.binaryTree
+	case *Treap[int]:
+		return &tr.binaryTree
+	}
+	return nil
+}
+
+// TestValidate 测试各实现在正常使用后通过结构检查，且能发现结构损坏
+func TestValidate(t *testing.T) {
+	trees := map[string]BinaryTree[int]{
+		"二叉搜索树": New(intCmp),
+		"伸展树":   NewSplay(intCmp),
+		"AVL树":  NewAVL(intCmp),
+		"树堆":    NewTreap(intCmp),
+	}
+	for name, tree := range trees {
+		t.Run(name, func(t *testing.T) {
+			if err := tree.Validate(); err != nil {
+				t.Errorf("空树应通过检查: %v", err)
+			}
+			for i := range 200 {
+				tree.Insert((i * 37) % 101)
+			}
+			for i := 0; i < 101; i += 3 {
+				tree.Remove(i)
+			}
+			if err := tree.Validate(); err != nil {
+				t.Fatalf("正常使用后应通过检查: %v", err)
+			}
+
+			base := baseOf(tree)
+			root := base.root
+			for root.Left == nil {
+				root = root.Right
+			}
+			t.Run("违反有序性", func(t *testing.T) {
+				old := root.Left.Value
+				root.Left.Value = root.Value + 1000
+				defer func() { root.Left.Value = old }()
+				if tree.Validate() == nil {
+					t.Error("应发现违反二叉搜索树性质")
+				}
+			})
+
+			t.Run("节点数量错误", func(t *testing.T) {
+				base.size++
+				defer func() { base.size-- }()
+				if tree.Validate() == nil {
+					t.Error("应发现节点数量错误")
+				}
+			})
+
+			if err := tree.Validate(); err != nil {
+				t.Errorf("恢复后应通过检查: %v", err)
+			}
+		})
+	}
+
+	t.Run("AVL树高度错误", func(t *testing.T) {
+		root := baseOf(trees["AVL树"]).root
+		root.height++
+		defer func() { root.height-- }()
+		if trees["AVL树"].Validate() == nil {
+			t.Error("应发现记录的高度错误")
+		}
+	})
+
+	t.Run("树堆优先级错误", func(t *testing.T) {
+		root := baseOf(trees["树堆"]).root
+		old, oldRoot := root.Left.priority, root.priority
+		root.priority, root.Left.priority = 0, math.MaxUint32
+		defer func() { root.Left.priority, root.priority = old, oldRoot }()
+		if trees["树堆"].Validate() == nil {
+			t.Error("应发现违反堆性质")
+		}
+	})
+
+	t.Run("树堆子树大小错误", func(t *testing.T) {
+		root := baseOf(trees["树堆"]).root
+		root.size++
+		defer func() { root.size-- }()
+		if trees["树堆"].Validate() == nil {
+			t.Error("应发现记录的子树大小错误")
+		}
+	})
+}
+
+// TestIsBalanced 测试高度平衡检查
+func TestIsBalanced(t *testing.T) {
+	tree := New(intCmp)
+	if !tree.IsBalanced() {
+		t.Error("空树应平衡")
+	}
+	for _, v := range []int{4, 2, 6, 1, 3, 5, 7} {
+		tree.Insert(v)
+	}
+	if !tree.IsBalanced() {
+		t.Error("满二叉树应平衡")
+	}
+	tree.Insert(8)
+	if !tree.IsBalanced() {
+		t.Error("高度差为1时应平衡")
+	}
+	tree.Insert(9)
+	if tree.IsBalanced() {
+		t.Error("节点7的左右子树高度差为2，不应平衡")
+	}
+
+	avl := NewAVL(intCmp)
+	for i := range 1000 {
+		avl.Insert(i)
+	}
+	if !avl.IsBalanced() {
+		t.Error("AVL 树应始终平衡")
+	}
+}
@@ -0,0 +1,148 @@
+package bloomfilter
+
+import (
+	"hash/fnv"
+	"math"
+)
+
+// maxCount 计数器的上限，达到上限后计数器不再变化，以免删除时产生假阴性
+const maxCount = math.MaxUint8
+
+// CountingBloomFilter 计数布隆过滤器
+// 将普通布隆过滤器的每个位替换为计数器，从而支持删除元素
+// 查询结果可能存在假阳性（误判存在），但不会存在假阴性（只要不删除未添加过的元素）
+type CountingBloomFilter struct {
+	counters []uint8 // 计数器数组
+	k        uint64  // 哈希函数个数
+	count    int     // 当前元素数量（近似值，重复添加会被重复计数）
+}
+
+// NewCounting 根据预期元素数量和期望的假阳性率创建计数布隆过滤器
+// 参数：
+//   - expectedItems: 预期元素数量，必须大于0
+//   - falsePositiveRate: 期望的假阳性率，取值范围 (0, 1)
+//
+// 返回：
+//   - *CountingBloomFilter: 计数器数量和哈希函数个数按最优公式计算
+func NewCounting(expectedItems int, falsePositiveRate float64) *CountingBloomFilter {
+	if expectedItems <= 0 {
+		panic("预期元素数量必须大于0")
+	}
+	if falsePositiveRate <= 0 || falsePositiveRate >= 1 {
+		panic("假阳性率必须在0和1之间")
+	}
+	n := float64(expectedItems)
+	m := math.Ceil(-n * math.Log(falsePositiveRate) / (math.Ln2 * math.Ln2))
+	k := math.Max(1, math.Round(m/n*math.Ln2))
+	return NewCountingWithSize(int(m), int(k))
+}
+
+// NewCountingWithSize 使用指定的计数器数量和哈希函数个数创建计数布隆过滤器
+// 时间复杂度: O(m)
+func NewCountingWithSize(m, k int) *CountingBloomFilter {
+	if m <= 0 || k <= 0 {
+		panic("计数器数量和哈希函数个数必须大于0")
+	}
+	return &CountingBloomFilter{
+		counters: make([]uint8, m),
+		k:        uint64(k),
+	}
+}
+
+// Add 添加元素
+// 时间复杂度: O(k)
+func (f *CountingBloomFilter) Add(data []byte) {
+	h1, h2 := baseHashes(data)
+	for i := uint64(0); i < f.k; i++ {
+		idx := f.location(h1, h2, i)
+		if f.counters[idx] < maxCount {
+			f.counters[idx]++
+		}
+	}
+	f.count++
+}
+
+// Remove 删除元素
+// 返回 false 表示元素一定不存在，此时不做任何修改
+// 注意：删除从未添加过的元素（但被误判为存在）会导致其他元素产生假阴性
+// 时间复杂度: O(k)
+func (f *CountingBloomFilter) Remove(data []byte) bool {
+	if !f.Contains(data) {
+		return false
+	}
+	h1, h2 := baseHashes(data)
+	for i := uint64(0); i < f.k; i++ {
+		idx := f.location(h1, h2, i)
+		// 已饱和的计数器无法确定真实计数，保持不变
+		if f.counters[idx] < maxCount {
+			f.counters[idx]--
+		}
+	}
+	f.count--
+	return true
+}
+
+// Contains 检查元素是否可能存在
+// 返回 false 表示元素一定不存在，返回 true 表示元素可能存在
+// 时间复杂度: O(k)
+func (f *CountingBloomFilter) Contains(data []byte) bool {
+	h1, h2 := baseHashes(data)
+	for i := uint64(0); i < f.k; i++ {
+		if f.counters[f.location(h1, h2, i)] == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// AddString 添加字符串元素
+func (f *CountingBloomFilter) AddString(s string) {
+	f.Add([]byte(s))
+}
+
+// RemoveString 删除字符串元素
+func (f *CountingBloomFilter) RemoveString(s string) bool {
+	return f.Remove([]byte(s))
+}
+
+// ContainsString 检查字符串元素是否可能存在
+func (f *CountingBloomFilter) ContainsString(s string) bool {
+	return f.Contains([]byte(s))
+}
+
+// Count 返回添加的元素数量减去删除的元素数量
+// 时间复杂度: O(1)
+func (f *CountingBloomFilter) Count() int {
+	return f.count
+}
+
+// Clear 清空过滤器
+// 时间复杂度: O(m)
+func (f *CountingBloomFilter) Clear() {
+	clear(f.counters)
+	f.count = 0
+}
+
+// EstimatedFalsePositiveRate 根据当前元素数量估算假阳性率
+// 时间复杂度: O(1)
+func (f *CountingBloomFilter) EstimatedFalsePositiveRate() float64 {
+	m := float64(len(f.counters))
+	k := float64(f.k)
+	return math.Pow(1-math.Exp(-k*float64(f.count)/m), k)
+}
+
+// location 使用双重哈希计算第 i 个哈希函数对应的计数器下标
+func (f *CountingBloomFilter) location(h1, h2, i uint64) uint64 {
+	return (h1 + i*h2) % uint64(len(f.counters))
+}
+
+// baseHashes 计算双重哈希所需的两个基础哈希值
+func baseHashes(data []byte) (uint64, uint64) {
+	h := fnv.New64a()
+	h.Write(data)
+	sum := h.Sum64()
+	h1 := sum & 0xffffffff
+	h2 := sum >> 32
+	// 保证 h2 为奇数，避免步长为0
+	return h1, h2 | 1
+}
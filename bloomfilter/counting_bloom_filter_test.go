@@ -0,0 +1,110 @@
+package bloomfilter
+
+import (
+	"fmt"
+	"testing"
+)
+
+// TestCountingBloomFilterBasic 测试添加、查询和删除
+func TestCountingBloomFilterBasic(t *testing.T) {
+	f := NewCounting(1000, 0.01)
+
+	f.AddString("apple")
+	f.AddString("banana")
+	if !f.ContainsString("apple") || !f.ContainsString("banana") {
+		t.Error("已添加的元素应该存在")
+	}
+	if f.Count() != 2 {
+		t.Errorf("期望数量为2，实际为%d", f.Count())
+	}
+
+	if !f.RemoveString("apple") {
+		t.Error("删除已添加的元素应返回true")
+	}
+	if f.ContainsString("apple") {
+		t.Error("删除后元素不应存在")
+	}
+	if !f.ContainsString("banana") {
+		t.Error("删除apple不应影响banana")
+	}
+	if f.RemoveString("cherry") {
+		t.Error("删除一定不存在的元素应返回false")
+	}
+
+	f.Clear()
+	if f.ContainsString("banana") || f.Count() != 0 {
+		t.Error("清空后不应存在任何元素")
+	}
+}
+
+// TestCountingBloomFilterDuplicates 测试重复添加同一元素
+func TestCountingBloomFilterDuplicates(t *testing.T) {
+	f := NewCountingWithSize(64, 3)
+	f.AddString("x")
+	f.AddString("x")
+	f.RemoveString("x")
+	if !f.ContainsString("x") {
+		t.Error("添加两次删除一次后元素应仍然存在")
+	}
+	f.RemoveString("x")
+	if f.ContainsString("x") {
+		t.Error("添加两次删除两次后元素不应存在")
+	}
+}
+
+// TestCountingBloomFilterFalsePositiveRate 测试假阳性率
+func TestCountingBloomFilterFalsePositiveRate(t *testing.T) {
+	const n = 10000
+	f := NewCounting(n, 0.01)
+	for i := 0; i < n; i++ {
+		f.AddString(fmt.Sprintf("item-%d", i))
+	}
+
+	// 不应存在假阴性
+	for i := 0; i < n; i++ {
+		if !f.ContainsString(fmt.Sprintf("item-%d", i)) {
+			t.Fatalf("已添加的元素item-%d不存在", i)
+		}
+	}
+
+	falsePositives := 0
+	for i := 0; i < n; i++ {
+		if f.ContainsString(fmt.Sprintf("other-%d", i)) {
+			falsePositives++
+		}
+	}
+	rate := float64(falsePositives) / n
+	if rate > 0.03 {
+		t.Errorf("假阳性率%.4f明显高于预期0.01", rate)
+	}
+	t.Logf("实际假阳性率: %.4f，估算值: %.4f", rate, f.EstimatedFalsePositiveRate())
+
+	// 删除一半元素后，剩余元素依然存在
+	for i := 0; i < n/2; i++ {
+		f.RemoveString(fmt.Sprintf("item-%d", i))
+	}
+	for i := n / 2; i < n; i++ {
+		if !f.ContainsString(fmt.Sprintf("item-%d", i)) {
+			t.Fatalf("删除其他元素后item-%d不存在", i)
+		}
+	}
+}
+
+// TestCountingBloomFilterInvalidArgs 测试非法参数
+func TestCountingBloomFilterInvalidArgs(t *testing.T) {
+	cases := map[string]func(){
+		"元素数量为0":  func() { NewCounting(0, 0.01) },
+		"假阳性率为1":  func() { NewCounting(10, 1) },
+		"计数器数量为0": func() { NewCountingWithSize(0, 1) },
+	}
+	for name, fn := range cases {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Error("非法参数应该导致panic")
+				}
+			}()
+			fn()
+		})
+	}
+}
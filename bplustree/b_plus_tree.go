@@ -1,77 +1,230 @@
 package bplustree
 
 import (
+	"cmp"
+	"errors"
 	"fmt"
-	"golang.org/x/exp/constraints"
+	"iter"
+	"reflect"
+	"slices"
 	"strings"
+
+	"godatastructure/codec"
+	"godatastructure/internal/arena"
+	"godatastructure/metrics"
+	"godatastructure/tuple"
+	"golang.org/x/exp/constraints"
 )
 
 // TreeNode B+ 树节点结构
-// K: 键类型，必须是可比较的
+// K: 键类型，通过树的比较函数排序
 // V: 值类型，可以是任意类型
-type TreeNode[K constraints.Ordered, V any] struct {
+type TreeNode[K any, V any] struct {
 	isLeaf   bool              // 是否为叶子节点
 	keys     []K               // 键数组
 	children []*TreeNode[K, V] // 子节点指针数组（仅对非叶子节点有效）
 	values   []V               // 值数组（仅对叶子节点有效）
 	next     *TreeNode[K, V]   // 指向下一个叶子节点的指针（用于范围查询）
+	prev     *TreeNode[K, V]   // 指向上一个叶子节点的指针（用于逆序遍历）
 	parent   *TreeNode[K, V]   // 父节点指针
+	count    int               // 子树中键值对的数量（仅对非叶子节点有效，用于 Rank 与 SelectKth）
+	prefix   string            // 叶子节点中所有键的公共前缀（仅在启用前缀压缩时使用），此时 keys 只保存去掉前缀后的部分
+}
+
+// subtreeSize 返回以 n 为根的子树中键值对的数量
+func (n *TreeNode[K, V]) subtreeSize() int {
+	if n.isLeaf {
+		return len(n.keys)
+	}
+	return n.count
+}
+
+// addCount 把 n 的所有祖先节点记录的子树大小加上 delta
+func (n *TreeNode[K, V]) addCount(delta int) {
+	for p := n.parent; p != nil; p = p.parent {
+		p.count += delta
+	}
 }
 
 // BPlusTree B+ 树结构
-type BPlusTree[K constraints.Ordered, V any] struct {
-	root  *TreeNode[K, V] // 根节点
-	order int             // 树的阶数（每个节点最多可以有order个子节点）
+// 键的顺序由比较函数决定：NewBPlusTree 使用键的自然顺序，NewBPlusTreeFunc 可以为任意类型的键指定顺序
+type BPlusTree[K any, V any] struct {
+	root     *TreeNode[K, V]              // 根节点
+	order    int                          // 树的阶数（每个节点最多可以有order个子节点）
+	cmp      func(a, b K) int             // 键的比较函数
+	size     int                          // 键值对数量
+	recorder metrics.Recorder             // 指标记录器，为 nil 时不记录
+	nodes    *arena.Arena[TreeNode[K, V]] // 节点分配器，为 nil 时直接分配
+	dup      bool                         // 是否允许重复的键
+	compress bool                         // 是否对叶子节点中的键进行前缀压缩
 }
 
-// NewBPlusTree 创建新的 B+ 树
+// Option B+ 树的可选配置
+type Option func(*options)
+
+// options B+ 树的配置项
+type options struct {
+	recorder metrics.Recorder
+	arena    bool // 是否使用节点分配器
+	slabSize int  // 分配器每块包含的节点数量
+	dup      bool // 是否允许重复的键
+	compress bool // 是否对叶子节点中的键进行前缀压缩
+}
+
+// WithRecorder 使 B+ 树把插入、更新、删除、查找以及节点分裂与合并事件报告给 r
+// Clone 得到的副本不会继承记录器
+func WithRecorder(r metrics.Recorder) Option {
+	return func(o *options) {
+		o.recorder = r
+	}
+}
+
+// WithArena 使 B+ 树从按块分配的对象池中分配节点，并在删除导致节点合并以及 Clear 时回收复用，
+// 以减少频繁重建索引时的垃圾回收压力。slabSize 为每块包含的节点数量，小于1时使用默认值。
+// Clone 得到的副本不会继承分配器
+func WithArena(slabSize int) Option {
+	return func(o *options) {
+		o.arena = true
+		o.slabSize = slabSize
+	}
+}
+
+// WithDuplicates 使 B+ 树允许重复的键，用作多重映射（例如二级索引）
+// 此时 Insert 总是添加新的键值对而不覆盖已有的值，相同键的键值对按插入顺序排列；
+// Search 返回最早插入的值，SearchAll 返回所有的值，Delete 删除最早插入的一个，DeleteAll 删除全部；
+// 区间遍历与迭代器会产出每一个键值对，Size 为键值对的总数。Clone 得到的副本保留该模式
+func WithDuplicates() Option {
+	return func(o *options) {
+		o.dup = true
+	}
+}
+
+// NewBPlusTree 创建按键的自然顺序排列的 B+ 树
+// 参数：
+//   - order: 树的阶数，必须大于等于3
+//   - opts: 可选配置，例如 WithRecorder、WithArena、WithDuplicates
+//
+// 返回：
+//   - *BPlusTree[K, V]: 新创建的 B+ 树指针
+func NewBPlusTree[K constraints.Ordered, V any](order int, opts ...Option) *BPlusTree[K, V] {
+	return NewBPlusTreeFunc[K, V](order, cmp.Compare[K], opts...)
+}
+
+// NewBPlusTreeFunc 创建使用自定义比较函数的 B+ 树，可以用结构体等任意类型作为键，例如组合键
 // 参数：
 //   - order: 树的阶数，必须大于等于3
+//   - cmp: 键的比较函数，a < b 时返回负数，a == b 时返回0，a > b 时返回正数；比较结果为0的键视为同一个键
+//   - opts: 可选配置，例如 WithRecorder、WithArena、WithDuplicates
 //
 // 返回：
 //   - *BPlusTree[K, V]: 新创建的 B+ 树指针
-func NewBPlusTree[K constraints.Ordered, V any](order int) *BPlusTree[K, V] {
+func NewBPlusTreeFunc[K any, V any](order int, cmp func(a, b K) int, opts ...Option) *BPlusTree[K, V] {
 	if order < 3 {
 		panic("阶数必须至少为3")
 	}
-	return &BPlusTree[K, V]{
-		root: &TreeNode[K, V]{
-			isLeaf: true,
-			keys:   make([]K, 0),
-			values: make([]V, 0),
-		},
-		order: order,
+	if cmp == nil {
+		panic("比较函数不能为 nil")
+	}
+	var o options
+	for _, opt := range opts {
+		opt(&o)
 	}
+	if o.compress && reflect.TypeFor[K]().Kind() != reflect.String {
+		panic("前缀压缩只能用于字符串键")
+	}
+	tree := &BPlusTree[K, V]{
+		order:    order,
+		cmp:      cmp,
+		recorder: o.recorder,
+		dup:      o.dup,
+		compress: o.compress,
+	}
+	if o.arena {
+		tree.nodes = arena.New[TreeNode[K, V]](o.slabSize)
+	}
+	tree.root = tree.newNode(TreeNode[K, V]{
+		isLeaf: true,
+		keys:   make([]K, 0),
+		values: make([]V, 0),
+	})
+	return tree
 }
 
-// Insert 向 B+ 树中插入键值对
+// newNode 从分配器中分配节点并初始化为 n
+func (tree *BPlusTree[K, V]) newNode(n TreeNode[K, V]) *TreeNode[K, V] {
+	p := tree.nodes.Alloc()
+	*p = n
+	return p
+}
+
+// freeNodes 把以 n 为根的子树中的节点全部交还给分配器
+func (tree *BPlusTree[K, V]) freeNodes(n *TreeNode[K, V]) {
+	for _, child := range n.children {
+		tree.freeNodes(child)
+	}
+	tree.nodes.Free(n)
+}
+
+// record 向记录器报告事件
+func (tree *BPlusTree[K, V]) record(e metrics.Event) {
+	if tree.recorder != nil {
+		tree.recorder.Record(e, tree.size)
+	}
+}
+
+// Insert 向 B+ 树中插入键值对，键已存在时更新值；允许重复的键时总是添加新的键值对
 // 参数：
 //   - key: 要插入的键
 //   - value: 要插入的值
 func (tree *BPlusTree[K, V]) Insert(key K, value V) {
+	tree.Upsert(key, value, nil)
+}
+
+// Upsert 插入键值对，键已存在时用 merge(旧值, 新值) 的结果替换旧值，只需要一次查找
+// 可用于计数器累加、向列表追加元素等需要合并而不是覆盖的场景；允许重复的键时与 Insert 相同，总是添加新的键值对
+// 参数：
+//   - key: 要插入的键
+//   - value: 要插入的值
+//   - merge: 合并旧值与新值的函数，为 nil 时直接用新值覆盖，与 Insert 相同
+//
+// 时间复杂度: O(order·log n)
+func (tree *BPlusTree[K, V]) Upsert(key K, value V, merge func(old, new V) V) {
 	// 处理空树的情况
 	if len(tree.root.keys) == 0 {
+		tree.expand(tree.root)
 		tree.root.keys = append(tree.root.keys, key)
 		tree.root.values = append(tree.root.values, value)
+		tree.compressLeaf(tree.root)
+		tree.size++
+		tree.record(metrics.Insert)
 		return
 	}
 
 	// 查找要插入的叶子节点
 	targetLeaf := tree.findLeaf(key)
 
-	// 在叶子节点中查找插入位置
+	// 在叶子节点中查找插入位置，允许重复的键时插入到相同键的最后面
 	insertPos := 0
-	for insertPos < len(targetLeaf.keys) && targetLeaf.keys[insertPos] < key {
+	for insertPos < len(targetLeaf.keys) && tree.cmp(tree.keyAt(targetLeaf, insertPos), key) < 0 {
+		insertPos++
+	}
+	for tree.dup && insertPos < len(targetLeaf.keys) && tree.cmp(tree.keyAt(targetLeaf, insertPos), key) == 0 {
 		insertPos++
 	}
 
 	// 如果键已存在，更新值
-	if insertPos < len(targetLeaf.keys) && targetLeaf.keys[insertPos] == key {
+	if !tree.dup && insertPos < len(targetLeaf.keys) && tree.cmp(tree.keyAt(targetLeaf, insertPos), key) == 0 {
+		if merge != nil {
+			value = merge(targetLeaf.values[insertPos], value)
+		}
 		targetLeaf.values[insertPos] = value
+		tree.record(metrics.Update)
 		return
 	}
 
-	// 插入新的键值对
+	// 插入新的键值对，启用前缀压缩时先还原完整的键
+	tree.expand(targetLeaf)
+	tree.size++
 	targetLeaf.keys = append(targetLeaf.keys, key)
 	targetLeaf.values = append(targetLeaf.values, value)
 
@@ -82,14 +235,18 @@ func (tree *BPlusTree[K, V]) Insert(key K, value V) {
 	}
 	targetLeaf.keys[insertPos] = key
 	targetLeaf.values[insertPos] = value
+	targetLeaf.addCount(1)
+	tree.record(metrics.Insert)
 
 	// 检查是否需要分裂
 	if len(targetLeaf.keys) >= tree.order {
 		tree.splitLeafNode(targetLeaf)
+	} else {
+		tree.compressLeaf(targetLeaf)
 	}
 }
 
-// findLeaf 查找要插入的叶子节点
+// findLeaf 查找要插入的叶子节点，即最后一个可能包含给定键的叶子节点
 // 参数：
 //   - key: 要查找的键
 //
@@ -100,7 +257,7 @@ func (tree *BPlusTree[K, V]) findLeaf(key K) *TreeNode[K, V] {
 	for !currentNode.isLeaf {
 		pos := 0
 		// 找到第一个大于或等于key的位置
-		for pos < len(currentNode.keys) && currentNode.keys[pos] <= key {
+		for pos < len(currentNode.keys) && tree.cmp(currentNode.keys[pos], key) <= 0 {
 			pos++
 		}
 		currentNode = currentNode.children[pos]
@@ -108,19 +265,24 @@ func (tree *BPlusTree[K, V]) findLeaf(key K) *TreeNode[K, V] {
 	return currentNode
 }
 
-// splitLeafNode 分裂叶子节点
+// splitLeafNode 分裂叶子节点，启用前缀压缩时 leafNode 中的键必须是完整的键，分裂后两个节点分别重新压缩
 // 参数：
 //   - leafNode: 需要分裂的叶子节点
 func (tree *BPlusTree[K, V]) splitLeafNode(leafNode *TreeNode[K, V]) {
+	tree.record(metrics.Split)
 	midIndex := (len(leafNode.keys) + 1) / 2
 
 	// 创建新的右侧节点
-	newRightNode := &TreeNode[K, V]{
+	newRightNode := tree.newNode(TreeNode[K, V]{
 		isLeaf: true,
 		keys:   make([]K, len(leafNode.keys[midIndex:])),
 		values: make([]V, len(leafNode.values[midIndex:])),
 		next:   leafNode.next,
+		prev:   leafNode,
 		parent: leafNode.parent,
+	})
+	if newRightNode.next != nil {
+		newRightNode.next.prev = newRightNode
 	}
 
 	// 复制数据到新节点
@@ -134,15 +296,18 @@ func (tree *BPlusTree[K, V]) splitLeafNode(leafNode *TreeNode[K, V]) {
 
 	// 获取用于父节点的键
 	separatorKey := newRightNode.keys[0]
+	tree.compressLeaf(leafNode)
+	tree.compressLeaf(newRightNode)
 
 	// 处理父节点
 	if leafNode == tree.root {
 		// 创建新的根节点
-		newRoot := &TreeNode[K, V]{
+		newRoot := tree.newNode(TreeNode[K, V]{
 			isLeaf:   false,
 			keys:     []K{separatorKey},
 			children: []*TreeNode[K, V]{leafNode, newRightNode},
-		}
+			count:    tree.size,
+		})
 		tree.root = newRoot
 		leafNode.parent = newRoot
 		newRightNode.parent = newRoot
@@ -154,12 +319,9 @@ func (tree *BPlusTree[K, V]) splitLeafNode(leafNode *TreeNode[K, V]) {
 // insertIntoParent 将分裂后的节点插入到父节点
 func (tree *BPlusTree[K, V]) insertIntoParent(leftNode *TreeNode[K, V], key K, rightNode *TreeNode[K, V]) {
 	parent := leftNode.parent
-	insertPos := 0
 
-	// 查找插入位置
-	for insertPos < len(parent.keys) && parent.keys[insertPos] < key {
-		insertPos++
-	}
+	// 分隔键插入到左侧节点之后；允许重复的键时父节点中可能有多个相同的分隔键，因此按子节点的位置而不是按键查找
+	insertPos := slices.Index(parent.children, leftNode)
 
 	// 插入键和子节点
 	parent.keys = append(parent.keys, key)
@@ -182,24 +344,27 @@ func (tree *BPlusTree[K, V]) insertIntoParent(leftNode *TreeNode[K, V], key K, r
 
 // splitInternalNode 分裂内部节点
 func (tree *BPlusTree[K, V]) splitInternalNode(internalNode *TreeNode[K, V]) {
+	tree.record(metrics.Split)
 	midIndex := len(internalNode.keys) / 2
 	promoteKey := internalNode.keys[midIndex]
 
 	// 创建新的右侧节点
-	newRightNode := &TreeNode[K, V]{
+	newRightNode := tree.newNode(TreeNode[K, V]{
 		isLeaf:   false,
 		keys:     make([]K, len(internalNode.keys[midIndex+1:])),
 		children: make([]*TreeNode[K, V], len(internalNode.children[midIndex+1:])),
-	}
+	})
 
 	// 复制键和子节点到新节点
 	copy(newRightNode.keys, internalNode.keys[midIndex+1:])
 	copy(newRightNode.children, internalNode.children[midIndex+1:])
 
-	// 更新子节点的父指针
+	// 更新子节点的父指针与两侧的子树大小
 	for _, child := range newRightNode.children {
 		child.parent = newRightNode
+		newRightNode.count += child.subtreeSize()
 	}
+	internalNode.count -= newRightNode.count
 
 	// 更新原节点
 	internalNode.keys = internalNode.keys[:midIndex]
@@ -207,11 +372,12 @@ func (tree *BPlusTree[K, V]) splitInternalNode(internalNode *TreeNode[K, V]) {
 
 	// 处理父节点
 	if internalNode == tree.root {
-		newRoot := &TreeNode[K, V]{
+		newRoot := tree.newNode(TreeNode[K, V]{
 			isLeaf:   false,
 			keys:     []K{promoteKey},
 			children: []*TreeNode[K, V]{internalNode, newRightNode},
-		}
+			count:    tree.size,
+		})
 		tree.root = newRoot
 		internalNode.parent = newRoot
 		newRightNode.parent = newRoot
@@ -229,26 +395,481 @@ func (tree *BPlusTree[K, V]) splitInternalNode(internalNode *TreeNode[K, V]) {
 //   - V: 找到的值
 //   - bool: 是否找到该键
 func (tree *BPlusTree[K, V]) Search(key K) (V, bool) {
-	currentNode := tree.root
+	tree.record(metrics.Lookup)
+	leaf, pos := tree.find(key)
+	if leaf == nil {
+		var zero V
+		return zero, false
+	}
+	return leaf.values[pos], true
+}
 
-	// 找到包含目标键的叶子节点
-	for !currentNode.isLeaf {
-		pos := 0
-		for pos < len(currentNode.keys) && key >= currentNode.keys[pos] {
-			pos++
+// SearchAll 按插入顺序返回键对应的所有值，键不存在时返回空切片
+// 不允许重复的键时最多返回一个值
+// 时间复杂度: O(log n + m)，m 为该键的值的数量
+func (tree *BPlusTree[K, V]) SearchAll(key K) []V {
+	tree.record(metrics.Lookup)
+	result := make([]V, 0)
+	leaf, pos := tree.find(key)
+	for ; leaf != nil; leaf, pos = leaf.next, 0 {
+		for ; pos < len(leaf.keys); pos++ {
+			if tree.cmp(tree.keyAt(leaf, pos), key) != 0 {
+				return result
+			}
+			result = append(result, leaf.values[pos])
 		}
-		currentNode = currentNode.children[pos]
 	}
+	return result
+}
 
-	// 在叶子节点中查找键
-	for i := 0; i < len(currentNode.keys); i++ {
-		if currentNode.keys[i] == key {
-			return currentNode.values[i], true
+// seek 返回第一个大于等于 key 的键所在的叶子节点及其在节点中的下标
+// 下标可能等于叶子节点的键数量，表示该键位于后续的叶子节点中（或不存在）
+func (tree *BPlusTree[K, V]) seek(key K) (*TreeNode[K, V], int) {
+	node := tree.root
+	for !node.isLeaf {
+		node = node.children[tree.seekChild(node, key)]
+	}
+	return node, tree.lowerBound(node, key)
+}
+
+// seekChild 返回内部节点中可能包含第一个大于等于 key 的键的子节点下标
+// 不允许重复的键时等于分隔键的键只会位于右侧子树；允许重复的键时也可能位于左侧子树，需要进入最左侧的候选子树
+func (tree *BPlusTree[K, V]) seekChild(node *TreeNode[K, V], key K) int {
+	pos := 0
+	for pos < len(node.keys) {
+		c := tree.cmp(node.keys[pos], key)
+		if c > 0 || (c == 0 && tree.dup) {
+			break
+		}
+		pos++
+	}
+	return pos
+}
+
+// lowerBound 返回叶子节点中第一个大于等于 key 的键的下标
+func (tree *BPlusTree[K, V]) lowerBound(leaf *TreeNode[K, V], key K) int {
+	pos := 0
+	for pos < len(leaf.keys) && tree.cmp(tree.keyAt(leaf, pos), key) < 0 {
+		pos++
+	}
+	return pos
+}
+
+// find 返回第一个等于 key 的键所在的叶子节点及其下标，键不存在时返回 nil
+func (tree *BPlusTree[K, V]) find(key K) (*TreeNode[K, V], int) {
+	leaf, pos := tree.seek(key)
+	if pos == len(leaf.keys) {
+		leaf, pos = leaf.next, 0
+	}
+	if leaf == nil || tree.cmp(tree.keyAt(leaf, pos), key) != 0 {
+		return nil, 0
+	}
+	return leaf, pos
+}
+
+// Delete 从 B+ 树中删除指定键及其对应的值，允许重复的键时只删除最早插入的一个
+// 删除后叶子节点的键少于下限时，先尝试从相邻的兄弟节点借一个键，兄弟节点也不富余时与其合并，
+// 合并会使父节点减少一个键，必要时向上继续调整；根节点只剩一个子节点时由该子节点成为新的根
+// 参数：
+//   - key: 要删除的键
+//
+// 返回：
+//   - bool: 键是否存在
+//
+// 时间复杂度: O(order·log n)
+func (tree *BPlusTree[K, V]) Delete(key K) bool {
+	leaf, pos := tree.find(key)
+	if leaf == nil {
+		return false
+	}
+
+	leaf.keys = slices.Delete(leaf.keys, pos, pos+1)
+	leaf.values = slices.Delete(leaf.values, pos, pos+1)
+	leaf.addCount(-1)
+	tree.size--
+	tree.record(metrics.Delete)
+	tree.rebalance(leaf)
+	return true
+}
+
+// Rank 返回严格小于 key 的键值对数量
+// 下降时累加所选子节点左侧各子树记录的大小，不需要扫描叶子节点
+// 时间复杂度: O(order·log n)
+func (tree *BPlusTree[K, V]) Rank(key K) int {
+	rank := 0
+	node := tree.root
+	for !node.isLeaf {
+		pos := tree.seekChild(node, key)
+		for _, child := range node.children[:pos] {
+			rank += child.subtreeSize()
+		}
+		node = node.children[pos]
+	}
+	return rank + tree.lowerBound(node, key)
+}
+
+// SelectKth 返回第 k 小的键值对（k 从0开始），k 越界时第三个返回值为 false
+// 允许重复的键时相同的键按插入顺序各占一个位置
+// 时间复杂度: O(order·log n)
+func (tree *BPlusTree[K, V]) SelectKth(k int) (K, V, bool) {
+	if k < 0 || k >= tree.size {
+		var key K
+		var value V
+		return key, value, false
+	}
+	node := tree.root
+	for !node.isLeaf {
+		i := 0
+		for ; k >= node.children[i].subtreeSize(); i++ {
+			k -= node.children[i].subtreeSize()
+		}
+		node = node.children[i]
+	}
+	return tree.keyAt(node, k), node.values[k], true
+}
+
+// DeleteAll 删除键对应的所有值，返回删除的数量
+// 时间复杂度: O(m·order·log n)，m 为该键的值的数量
+func (tree *BPlusTree[K, V]) DeleteAll(key K) int {
+	count := 0
+	for tree.Delete(key) {
+		count++
+	}
+	return count
+}
+
+// minKeys 返回非根节点至少包含的键数量
+// 分裂得到的节点都不少于该数量，两个不足的相邻节点合并后也不会超过上限
+func (tree *BPlusTree[K, V]) minKeys() int {
+	return (tree.order - 1) / 2
+}
+
+// rebalance 在 node 的键少于下限时通过借键或合并恢复平衡
+func (tree *BPlusTree[K, V]) rebalance(node *TreeNode[K, V]) {
+	if node == tree.root {
+		// 根节点可以少于下限，但内部根节点没有键时只剩一个子节点，树的高度减一
+		if !node.isLeaf && len(node.keys) == 0 {
+			tree.root = node.children[0]
+			tree.root.parent = nil
+			tree.nodes.Free(node)
 		}
+		return
+	}
+	if len(node.keys) >= tree.minKeys() {
+		return
+	}
+
+	parent := node.parent
+	idx := slices.Index(parent.children, node)
+	if idx > 0 && len(parent.children[idx-1].keys) > tree.minKeys() {
+		tree.borrowFromLeft(parent, idx)
+		return
+	}
+	if idx < len(parent.children)-1 && len(parent.children[idx+1].keys) > tree.minKeys() {
+		tree.borrowFromRight(parent, idx)
+		return
+	}
+	if idx > 0 {
+		tree.mergeChildren(parent, idx-1)
+	} else {
+		tree.mergeChildren(parent, idx)
+	}
+	tree.rebalance(parent)
+}
+
+// borrowFromLeft 把 parent 第 idx-1 个子节点的最后一个键移动到第 idx 个子节点，并更新分隔键
+func (tree *BPlusTree[K, V]) borrowFromLeft(parent *TreeNode[K, V], idx int) {
+	node, left := parent.children[idx], parent.children[idx-1]
+	last := len(left.keys) - 1
+	if node.isLeaf {
+		tree.expand(node)
+		tree.expand(left)
+		node.keys = slices.Insert(node.keys, 0, left.keys[last])
+		node.values = slices.Insert(node.values, 0, left.values[last])
+		left.keys = slices.Delete(left.keys, last, last+1)
+		left.values = slices.Delete(left.values, last, last+1)
+		parent.keys[idx-1] = node.keys[0]
+		tree.compressLeaf(node)
+		tree.compressLeaf(left)
+		return
+	}
+	// 内部节点借键时，父节点的分隔键下移，左兄弟的最后一个键上移
+	child := left.children[last+1]
+	node.keys = slices.Insert(node.keys, 0, parent.keys[idx-1])
+	node.children = slices.Insert(node.children, 0, child)
+	child.parent = node
+	node.count += child.subtreeSize()
+	left.count -= child.subtreeSize()
+	parent.keys[idx-1] = left.keys[last]
+	left.keys = slices.Delete(left.keys, last, last+1)
+	left.children = slices.Delete(left.children, last+1, last+2)
+}
+
+// borrowFromRight 把 parent 第 idx+1 个子节点的第一个键移动到第 idx 个子节点，并更新分隔键
+func (tree *BPlusTree[K, V]) borrowFromRight(parent *TreeNode[K, V], idx int) {
+	node, right := parent.children[idx], parent.children[idx+1]
+	if node.isLeaf {
+		tree.expand(node)
+		tree.expand(right)
+		node.keys = append(node.keys, right.keys[0])
+		node.values = append(node.values, right.values[0])
+		right.keys = slices.Delete(right.keys, 0, 1)
+		right.values = slices.Delete(right.values, 0, 1)
+		parent.keys[idx] = right.keys[0]
+		tree.compressLeaf(node)
+		tree.compressLeaf(right)
+		return
 	}
+	// 内部节点借键时，父节点的分隔键下移，右兄弟的第一个键上移
+	child := right.children[0]
+	node.keys = append(node.keys, parent.keys[idx])
+	node.children = append(node.children, child)
+	child.parent = node
+	node.count += child.subtreeSize()
+	right.count -= child.subtreeSize()
+	parent.keys[idx] = right.keys[0]
+	right.keys = slices.Delete(right.keys, 0, 1)
+	right.children = slices.Delete(right.children, 0, 1)
+}
 
-	var zero V
-	return zero, false
+// mergeChildren 把 parent 的第 idx+1 个子节点合并到第 idx 个子节点中，并从父节点删除两者之间的分隔键
+// 被合并的节点交还给分配器
+func (tree *BPlusTree[K, V]) mergeChildren(parent *TreeNode[K, V], idx int) {
+	tree.record(metrics.Merge)
+	left, right := parent.children[idx], parent.children[idx+1]
+	if left.isLeaf {
+		tree.expand(left)
+		tree.expand(right)
+		left.keys = append(left.keys, right.keys...)
+		left.values = append(left.values, right.values...)
+		left.next = right.next
+		if right.next != nil {
+			right.next.prev = left
+		}
+		tree.compressLeaf(left)
+	} else {
+		left.keys = append(left.keys, parent.keys[idx])
+		left.keys = append(left.keys, right.keys...)
+		for _, child := range right.children {
+			child.parent = left
+		}
+		left.children = append(left.children, right.children...)
+		left.count += right.count
+	}
+	parent.keys = slices.Delete(parent.keys, idx, idx+1)
+	parent.children = slices.Delete(parent.children, idx+1, idx+2)
+	tree.nodes.Free(right)
+}
+
+// RangeSearch 按键升序返回区间 [start, end) 内的所有键值对
+// 参数：
+//   - start: 区间下界（包含）
+//   - end: 区间上界（不包含），不大于 start 时返回空切片
+//
+// 返回：
+//   - []tuple.Pair[K, V]: 按键升序排列的键值对，First 为键，Second 为值
+//
+// 时间复杂度: O(log n + m)，m 为区间内的键值对数量
+func (tree *BPlusTree[K, V]) RangeSearch(start, end K) []tuple.Pair[K, V] {
+	result := make([]tuple.Pair[K, V], 0)
+	tree.AscendRange(start, end, func(key K, value V) bool {
+		result = append(result, tuple.NewPair(key, value))
+		return true
+	})
+	return result
+}
+
+// AscendRange 按键升序遍历区间 [start, end) 内的键值对，fn 返回 false 时停止遍历
+// 先从根节点下降到 start 所在的叶子节点，再沿叶子链表向右扫描，直到遇到不小于 end 的键
+// 时间复杂度: O(log n + m)，m 为区间内的键值对数量
+func (tree *BPlusTree[K, V]) AscendRange(start, end K, fn func(key K, value V) bool) {
+	if tree.cmp(start, end) >= 0 {
+		return
+	}
+	leaf, pos := tree.seek(start)
+	for ; leaf != nil; leaf, pos = leaf.next, 0 {
+		for ; pos < len(leaf.keys); pos++ {
+			key := tree.keyAt(leaf, pos)
+			if tree.cmp(key, end) >= 0 || !fn(key, leaf.values[pos]) {
+				return
+			}
+		}
+	}
+}
+
+// PrefixScan 按键升序遍历所有以 prefix 开头的键值对，fn 返回 false 时停止遍历
+// 只能用于底层类型为 string 的键，否则 panic；树需要按字符串的自然顺序（逐字节比较）排列键。
+// 以 prefix 开头的键在树中是连续的一段，
+// 因此先定位到 prefix 所在的叶子节点，再沿叶子链表扫描到第一个不以 prefix 开头的键为止
+// 时间复杂度: O(log n + m)，m 为以 prefix 开头的键值对数量
+func (tree *BPlusTree[K, V]) PrefixScan(prefix string, fn func(key K, value V) bool) {
+	keyType := reflect.TypeFor[K]()
+	if keyType.Kind() != reflect.String {
+		panic("PrefixScan 只能用于字符串键")
+	}
+	toKey := func(s string) K {
+		return reflect.ValueOf(s).Convert(keyType).Interface().(K)
+	}
+	start := toKey(prefix)
+	if end, ok := prefixSuccessor(prefix); ok {
+		tree.AscendRange(start, toKey(end), fn)
+		return
+	}
+	// prefix 为空或全部由 0xff 组成时没有上界，扫描到最后一个键
+	c := tree.Cursor()
+	c.Seek(start)
+	for c.Next() && fn(c.Key(), c.Value()) {
+	}
+}
+
+// prefixSuccessor 返回大于所有以 prefix 开头的字符串的最小字符串，不存在时第二个返回值为 false
+// 去掉末尾的 0xff 字节后把最后一个字节加一即可
+func prefixSuccessor(prefix string) (string, bool) {
+	for i := len(prefix) - 1; i >= 0; i-- {
+		if prefix[i] != 0xff {
+			end := []byte(prefix[:i+1])
+			end[i]++
+			return string(end), true
+		}
+	}
+	return "", false
+}
+
+// DescendRange 按键降序遍历区间 (greaterThan, lessOrEqual] 内的键值对，fn 返回 false 时停止遍历
+// 先从根节点下降到 lessOrEqual 所在的叶子节点，再沿叶子链表的 prev 指针向左扫描
+// 时间复杂度: O(log n + m)，m 为区间内的键值对数量
+func (tree *BPlusTree[K, V]) DescendRange(lessOrEqual, greaterThan K, fn func(key K, value V) bool) {
+	if tree.cmp(lessOrEqual, greaterThan) <= 0 {
+		return
+	}
+	leaf := tree.findLeaf(lessOrEqual)
+	pos := len(leaf.keys) - 1
+	for pos >= 0 && tree.cmp(tree.keyAt(leaf, pos), lessOrEqual) > 0 {
+		pos--
+	}
+	tree.descend(leaf, pos, func(key K, value V) bool {
+		return tree.cmp(key, greaterThan) > 0 && fn(key, value)
+	})
+}
+
+// Backward 返回按键降序遍历所有键值对的迭代器
+// 从最右侧的叶子节点开始沿 prev 指针遍历叶子链表，适合取最大的若干个键
+// 遍历过程中不应修改 B+ 树
+func (tree *BPlusTree[K, V]) Backward() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		leaf := tree.lastLeaf()
+		tree.descend(leaf, len(leaf.keys)-1, yield)
+	}
+}
+
+// descend 从 leaf 的第 pos 个键开始按键降序遍历，fn 返回 false 时停止
+func (tree *BPlusTree[K, V]) descend(leaf *TreeNode[K, V], pos int, fn func(key K, value V) bool) {
+	for leaf != nil {
+		for ; pos >= 0; pos-- {
+			if !fn(tree.keyAt(leaf, pos), leaf.values[pos]) {
+				return
+			}
+		}
+		leaf = leaf.prev
+		if leaf != nil {
+			pos = len(leaf.keys) - 1
+		}
+	}
+}
+
+// Min 返回最小的键值对，树为空时第三个返回值为 false
+// 时间复杂度: O(log n)
+func (tree *BPlusTree[K, V]) Min() (K, V, bool) {
+	leaf := tree.firstLeaf()
+	if len(leaf.keys) == 0 {
+		var key K
+		var value V
+		return key, value, false
+	}
+	return tree.keyAt(leaf, 0), leaf.values[0], true
+}
+
+// Max 返回最大的键值对，树为空时第三个返回值为 false
+// 时间复杂度: O(log n)
+func (tree *BPlusTree[K, V]) Max() (K, V, bool) {
+	leaf := tree.lastLeaf()
+	if len(leaf.keys) == 0 {
+		var key K
+		var value V
+		return key, value, false
+	}
+	last := len(leaf.keys) - 1
+	return tree.keyAt(leaf, last), leaf.values[last], true
+}
+
+// firstLeaf 返回最左侧的叶子节点
+// 只有树为空时该叶子节点才没有键，删除过程中的合并保证非根叶子节点不为空
+func (tree *BPlusTree[K, V]) firstLeaf() *TreeNode[K, V] {
+	node := tree.root
+	for !node.isLeaf {
+		node = node.children[0]
+	}
+	return node
+}
+
+// lastLeaf 返回最右侧的叶子节点
+func (tree *BPlusTree[K, V]) lastLeaf() *TreeNode[K, V] {
+	node := tree.root
+	for !node.isLeaf {
+		node = node.children[len(node.children)-1]
+	}
+	return node
+}
+
+// Len 返回键值对数量
+// 数量在插入新键和删除时维护，不需要扫描叶子节点
+// 时间复杂度: O(1)
+func (tree *BPlusTree[K, V]) Len() int {
+	return tree.size
+}
+
+// Size 返回键值对数量，与 Len 相同
+// 时间复杂度: O(1)
+func (tree *BPlusTree[K, V]) Size() int {
+	return tree.size
+}
+
+// Height 返回树的层数，即从根节点到叶子节点经过的节点数，空树为0
+// 时间复杂度: O(log n)
+func (tree *BPlusTree[K, V]) Height() int {
+	if tree.size == 0 {
+		return 0
+	}
+	height := 1
+	for n := tree.root; !n.isLeaf; n = n.children[0] {
+		height++
+	}
+	return height
+}
+
+// IsEmpty 检查树是否为空
+// 时间复杂度: O(1)
+func (tree *BPlusTree[K, V]) IsEmpty() bool {
+	return tree.size == 0
+}
+
+// Clear 清空树，保留阶数
+// 时间复杂度: O(1)，启用分配器时为 O(n)
+func (tree *BPlusTree[K, V]) Clear() {
+	if tree.nodes != nil {
+		tree.freeNodes(tree.root)
+	}
+	tree.root = tree.newNode(TreeNode[K, V]{
+		isLeaf: true,
+		keys:   make([]K, 0),
+		values: make([]V, 0),
+	})
+	tree.size = 0
+}
+
+// Compare 使用树的比较函数比较两个键
+func (tree *BPlusTree[K, V]) Compare(a, b K) int {
+	return tree.cmp(a, b)
 }
 
 // String 返回树的字符串表示，用于调试
@@ -264,7 +885,10 @@ func (tree *BPlusTree[K, V]) printTree(node *TreeNode[K, V], level int) string {
 	var sb strings.Builder
 	indent := strings.Repeat("  ", level)
 
-	if node.isLeaf {
+	if node.isLeaf && node.prefix != "" {
+		sb.WriteString(fmt.Sprintf("%s叶子节点: prefix=%q keys=%v values=%v\n",
+			indent, node.prefix, node.keys, node.values))
+	} else if node.isLeaf {
 		sb.WriteString(fmt.Sprintf("%s叶子节点: keys=%v values=%v\n",
 			indent, node.keys, node.values))
 	} else {
@@ -275,3 +899,234 @@ func (tree *BPlusTree[K, V]) printTree(node *TreeNode[K, V], level int) string {
 	}
 	return sb.String()
 }
+
+// ForEach 按键升序对每个键值对调用 fn，fn 返回 false 时停止遍历
+// 从最左侧的叶子节点开始沿 next 指针遍历叶子链表，不分配额外的内存，适合全量导出等场景
+// 遍历过程中不应修改 B+ 树
+// 时间复杂度: O(log n + m)，m 为遍历的键值对数量
+func (tree *BPlusTree[K, V]) ForEach(fn func(key K, value V) bool) {
+	for node := tree.firstLeaf(); node != nil; node = node.next {
+		for i := range node.keys {
+			if !fn(tree.keyAt(node, i), node.values[i]) {
+				return
+			}
+		}
+	}
+}
+
+// All 返回按键升序遍历所有键值对的迭代器，遍历方式与 ForEach 相同
+// 遍历过程中不应修改 B+ 树
+func (tree *BPlusTree[K, V]) All() iter.Seq2[K, V] {
+	return tree.ForEach
+}
+
+// MarshalBinary 使用 codec.For 返回的键、值编解码器把 B+ 树编码为二进制，按键升序编码
+// 实现 encoding.BinaryMarshaler 接口
+func (tree *BPlusTree[K, V]) MarshalBinary() ([]byte, error) {
+	return codec.EncodeSeq2(tree.All(), codec.For[K](), codec.For[V]())
+}
+
+// UnmarshalBinary 从 MarshalBinary 生成的数据中解码，替换 B+ 树中的所有键值对
+// 解码失败时 B+ 树保持不变，实现 encoding.BinaryUnmarshaler 接口
+func (tree *BPlusTree[K, V]) UnmarshalBinary(data []byte) error {
+	var keys []K
+	var values []V
+	err := codec.DecodeSeq2(data, codec.For[K](), codec.For[V](), func(key K, value V) {
+		keys = append(keys, key)
+		values = append(values, value)
+	})
+	if err != nil {
+		return err
+	}
+	tree.load(keys, values)
+	return nil
+}
+
+// MarshalJSON 把 B+ 树编码为 {"key": ..., "value": ...} 对象组成的 JSON 数组，实现 json.Marshaler 接口
+func (tree *BPlusTree[K, V]) MarshalJSON() ([]byte, error) {
+	return codec.MarshalJSONSeq2(tree.All())
+}
+
+// UnmarshalJSON 从 MarshalJSON 生成的 JSON 中解码，替换 B+ 树中的所有键值对
+// 解码失败时 B+ 树保持不变，实现 json.Unmarshaler 接口
+func (tree *BPlusTree[K, V]) UnmarshalJSON(data []byte) error {
+	var keys []K
+	var values []V
+	err := codec.UnmarshalJSONSeq2(data, func(key K, value V) {
+		keys = append(keys, key)
+		values = append(values, value)
+	})
+	if err != nil {
+		return err
+	}
+	tree.load(keys, values)
+	return nil
+}
+
+// load 清空 B+ 树后放入所有键值对
+func (tree *BPlusTree[K, V]) load(keys []K, values []V) {
+	tree.Clear()
+	for i, key := range keys {
+		tree.Insert(key, values[i])
+	}
+}
+
+// Clone 返回结构相同的深拷贝，包括父节点指针和叶子链表
+// copier 用于复制每个值，为 nil 时直接赋值；键直接赋值
+// 时间复杂度: O(n)
+func (tree *BPlusTree[K, V]) Clone(copier func(V) V) *BPlusTree[K, V] {
+	var prevLeaf *TreeNode[K, V]
+	root := tree.cloneNode(tree.root, nil, &prevLeaf, copier)
+	return &BPlusTree[K, V]{root: root, order: tree.order, cmp: tree.cmp, size: tree.size, dup: tree.dup, compress: tree.compress}
+}
+
+// cloneNode 按从左到右的顺序递归复制子树，prevLeaf 记录上一个复制的叶子节点，用于重建叶子链表
+func (tree *BPlusTree[K, V]) cloneNode(n, parent *TreeNode[K, V], prevLeaf **TreeNode[K, V], copier func(V) V) *TreeNode[K, V] {
+	clone := &TreeNode[K, V]{
+		isLeaf: n.isLeaf,
+		keys:   append(make([]K, 0, len(n.keys)), n.keys...),
+		parent: parent,
+		count:  n.count,
+		prefix: n.prefix,
+	}
+	if n.isLeaf {
+		clone.values = make([]V, len(n.values))
+		for i, v := range n.values {
+			if copier != nil {
+				v = copier(v)
+			}
+			clone.values[i] = v
+		}
+		if *prevLeaf != nil {
+			(*prevLeaf).next = clone
+			clone.prev = *prevLeaf
+		}
+		*prevLeaf = clone
+		return clone
+	}
+	clone.children = make([]*TreeNode[K, V], len(n.children))
+	for i, child := range n.children {
+		clone.children[i] = tree.cloneNode(child, clone, prevLeaf, copier)
+	}
+	return clone
+}
+
+// Validate 检查 B+ 树的结构不变量，发现损坏时返回描述问题的错误，可用于在嵌入 B+ 树的代码中断言结构正确
+// 检查内容：
+//   - 每个节点内的键严格递增（允许重复的键时为非递减），子树中的键位于父节点对应分隔键划定的范围内
+//   - 每个节点的键不超过 order-1 个，非根节点的键不少于下限，内部节点的子节点比键多一个，叶子节点的键与值数量一致
+//   - 根节点的父指针为 nil，其余节点的父指针指向包含它的节点
+//   - 所有叶子节点位于同一深度，内部节点记录的子树大小等于子节点大小之和
+//   - 叶子链表的 next 与 prev 指针按从左到右的顺序双向连接所有叶子节点，且叶子节点中键的总数等于记录的键值对数量
+//
+// 时间复杂度: O(n)
+func (tree *BPlusTree[K, V]) Validate() error {
+	if tree.root == nil {
+		return errors.New("根节点为空")
+	}
+	if tree.root.parent != nil {
+		return errors.New("根节点的父指针不为空")
+	}
+	v := validation[K, V]{leafDepth: -1}
+	if err := tree.validateNode(tree.root, 0, nil, nil, &v); err != nil {
+		return err
+	}
+	leaves := v.leaves
+	count := 0
+	for i, leaf := range leaves {
+		var next *TreeNode[K, V]
+		if i+1 < len(leaves) {
+			next = leaves[i+1]
+		}
+		if leaf.next != next {
+			return fmt.Errorf("第%d个叶子节点的 next 指针没有指向相邻的叶子节点", i)
+		}
+		var prev *TreeNode[K, V]
+		if i > 0 {
+			prev = leaves[i-1]
+		}
+		if leaf.prev != prev {
+			return fmt.Errorf("第%d个叶子节点的 prev 指针没有指向相邻的叶子节点", i)
+		}
+		count += len(leaf.keys)
+	}
+	if count != tree.size {
+		return fmt.Errorf("叶子节点中有%d个键，但记录的键值对数量为%d", count, tree.size)
+	}
+	return nil
+}
+
+// validation Validate 遍历过程中收集的状态
+type validation[K any, V any] struct {
+	leaves    []*TreeNode[K, V] // 按从左到右的顺序收集的叶子节点
+	leafDepth int               // 第一个叶子节点的深度，-1 表示还没有遇到叶子节点
+}
+
+// validateNode 检查以 node 为根、位于 depth 层的子树，其中的键位于 [lo, hi) 范围内，并按从左到右的顺序收集叶子节点
+// 允许重复的键时范围为 [lo, hi]；lo 或 hi 为 nil 时表示该侧没有限制
+func (tree *BPlusTree[K, V]) validateNode(node *TreeNode[K, V], depth int, lo, hi *K, v *validation[K, V]) error {
+	if len(node.keys) > tree.order-1 {
+		return fmt.Errorf("节点有%d个键，超过上限%d", len(node.keys), tree.order-1)
+	}
+	if node != tree.root && len(node.keys) < tree.minKeys() {
+		return fmt.Errorf("非根节点只有%d个键，下限为%d", len(node.keys), tree.minKeys())
+	}
+	if node.prefix != "" && (!node.isLeaf || !tree.compress) {
+		return errors.New("只有启用前缀压缩时的叶子节点可以有公共前缀")
+	}
+	for i := range node.keys {
+		key := tree.keyAt(node, i)
+		if i > 0 && tree.aboveBound(tree.keyAt(node, i-1), key) {
+			return fmt.Errorf("节点内的键不是严格递增的: %v 位于 %v 之前", tree.keyAt(node, i-1), key)
+		}
+		if (lo != nil && tree.cmp(key, *lo) < 0) || (hi != nil && tree.aboveBound(key, *hi)) {
+			return fmt.Errorf("键 %v 超出父节点分隔键划定的范围", key)
+		}
+	}
+	if node.isLeaf {
+		if len(node.values) != len(node.keys) {
+			return fmt.Errorf("叶子节点有%d个键，但有%d个值", len(node.keys), len(node.values))
+		}
+		if v.leafDepth >= 0 && v.leafDepth != depth {
+			return fmt.Errorf("叶子节点深度不一致: %d 与 %d", v.leafDepth, depth)
+		}
+		v.leafDepth = depth
+		v.leaves = append(v.leaves, node)
+		return nil
+	}
+	if len(node.keys) == 0 {
+		return errors.New("内部节点没有键")
+	}
+	if len(node.children) != len(node.keys)+1 {
+		return fmt.Errorf("内部节点有%d个键，但有%d个子节点", len(node.keys), len(node.children))
+	}
+	count := 0
+	for _, child := range node.children {
+		count += child.subtreeSize()
+	}
+	if count != node.count {
+		return fmt.Errorf("内部节点记录的子树大小为%d，但子节点中共有%d个键值对", node.count, count)
+	}
+	for i, child := range node.children {
+		if child.parent != node {
+			return fmt.Errorf("内部节点的第%d个子节点的父指针不正确", i)
+		}
+		childLo, childHi := lo, hi
+		if i > 0 {
+			childLo = &node.keys[i-1]
+		}
+		if i < len(node.keys) {
+			childHi = &node.keys[i]
+		}
+		if err := tree.validateNode(child, depth+1, childLo, childHi, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// aboveBound 检查 key 是否超出上界 hi：不允许重复的键时上界不包含，允许时包含
+func (tree *BPlusTree[K, V]) aboveBound(key, hi K) bool {
+	c := tree.cmp(key, hi)
+	return c > 0 || (c == 0 && !tree.dup)
+}
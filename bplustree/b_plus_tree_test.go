@@ -1,42 +1,21 @@
 package bplustree
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
-	"golang.org/x/exp/constraints"
+	"godatastructure/metrics"
+	"math/rand"
+	"slices"
+	"strings"
 	"testing"
 )
 
-// 辅助函数：验证B+树的基本属性
-func validateBPlusTree[K constraints.Ordered, V any](t *testing.T, tree *BPlusTree[K, V]) {
-	if tree.root == nil {
-		t.Error("根节点不应为空")
-		return
-	}
-
-	// 验证根节点属性
-	if !tree.root.isLeaf && len(tree.root.children) == 0 {
-		t.Error("非叶子根节点必须有子节点")
-	}
-
-	// 如果根节点不是叶子节点，验证所有内部节点
-	if !tree.root.isLeaf {
-		validateInternalNode(t, tree.root, tree.order)
-	}
-}
-
-// 辅助函数：验证内部节点
-func validateInternalNode[K constraints.Ordered, V any](t *testing.T, node *TreeNode[K, V], order int) {
-	// 验证键的数量与子节点数量的关系
-	if len(node.children) != len(node.keys)+1 {
-		t.Errorf("内部节点的子节点数量应该等于键数量+1，当前键数量：%d，子节点数量：%d",
-			len(node.keys), len(node.children))
-	}
-
-	// 验证子节点的父指针
-	for _, child := range node.children {
-		if child.parent != node {
-			t.Error("子节点的父指针不正确")
-		}
+// validateBPlusTree 通过 Validate 检查 B+ 树的全部结构不变量，失败时终止测试
+func validateBPlusTree[K any, V any](t *testing.T, tree *BPlusTree[K, V]) {
+	t.Helper()
+	if err := tree.Validate(); err != nil {
+		t.Fatalf("结构检查失败: %v\n%s", err, tree)
 	}
 }
 
@@ -289,3 +268,1074 @@ func BenchmarkBPlusTreeOperations(b *testing.B) {
 		}
 	})
 }
+
+func TestBPlusTreeAll(t *testing.T) {
+	tree := NewBPlusTree[int, int](3)
+	for _, k := range []int{50, 10, 40, 20, 30, 60, 70} {
+		tree.Insert(k, k*2)
+	}
+	var keys []int
+	for k, v := range tree.All() {
+		if v != k*2 {
+			t.Errorf("键%d对应的值错误: %d", k, v)
+		}
+		keys = append(keys, k)
+	}
+	if !slices.Equal(keys, []int{10, 20, 30, 40, 50, 60, 70}) {
+		t.Errorf("遍历结果错误: %v", keys)
+	}
+}
+
+// TestBPlusTreeForEach 测试沿叶子链表回调每个键值对
+func TestBPlusTreeForEach(t *testing.T) {
+	tree := NewBPlusTree[int, int](4)
+	for i := range 100 {
+		tree.Insert(99-i, i)
+	}
+	var keys []int
+	tree.ForEach(func(k, v int) bool {
+		if v != 99-k {
+			t.Errorf("键%d对应的值错误: %d", k, v)
+		}
+		keys = append(keys, k)
+		return true
+	})
+	if len(keys) != 100 || !slices.IsSorted(keys) {
+		t.Errorf("应按升序遍历全部100个键，实际为%v", keys)
+	}
+
+	count := 0
+	tree.ForEach(func(k, v int) bool {
+		count++
+		return k < 41
+	})
+	if count != 42 {
+		t.Errorf("回调返回 false 后应停止遍历，实际调用了%d次", count)
+	}
+
+	NewBPlusTree[int, int](3).ForEach(func(k, v int) bool {
+		t.Error("空树不应调用回调")
+		return true
+	})
+}
+
+// TestBPlusTreeSizeClear 测试 B+ 树的键值对计数与清空
+func TestBPlusTreeSizeClear(t *testing.T) {
+	tree := NewBPlusTree[int, string](3)
+	if !tree.IsEmpty() {
+		t.Fatal("新建的树应为空")
+	}
+	for i := 0; i < 50; i++ {
+		tree.Insert(i, "v")
+	}
+	tree.Insert(10, "更新")
+	if tree.Size() != 50 || tree.Len() != 50 {
+		t.Errorf("期望大小为50，实际为%d", tree.Size())
+	}
+	tree.Delete(10)
+	tree.Delete(10)
+	if tree.Len() != 49 {
+		t.Errorf("删除后期望大小为49，实际为%d", tree.Len())
+	}
+	tree.Clear()
+	if !tree.IsEmpty() || tree.Size() != 0 {
+		t.Error("清空后树应为空")
+	}
+	if _, found := tree.Search(10); found {
+		t.Error("清空后不应找到键10")
+	}
+	tree.Insert(1, "一")
+	if v, found := tree.Search(1); !found || v != "一" || tree.Size() != 1 {
+		t.Error("清空后应可以继续插入")
+	}
+}
+
+// TestBPlusTreeMarshal 测试B+ 树的二进制与 JSON 序列化
+func TestBPlusTreeMarshal(t *testing.T) {
+	src := NewBPlusTree[int, string](4)
+	for i := 99; i >= 0; i-- {
+		src.Insert(i, fmt.Sprint(i))
+	}
+	check := func(t *testing.T, dst *BPlusTree[int, string]) {
+		t.Helper()
+		if dst.Size() != 100 {
+			t.Fatalf("期望大小为100，实际为%d", dst.Size())
+		}
+		for i := 0; i < 100; i++ {
+			if v, ok := dst.Search(i); !ok || v != fmt.Sprint(i) {
+				t.Fatalf("Search(%d) = (%v, %v)", i, v, ok)
+			}
+		}
+	}
+
+	t.Run("二进制", func(t *testing.T) {
+		data, err := src.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		dst := NewBPlusTree[int, string](3)
+		if err := dst.UnmarshalBinary(data); err != nil {
+			t.Fatal(err)
+		}
+		check(t, dst)
+		if err := dst.UnmarshalBinary(data[:len(data)-1]); err == nil {
+			t.Error("截断的数据应返回错误")
+		}
+		check(t, dst)
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		data, err := json.Marshal(src)
+		if err != nil {
+			t.Fatal(err)
+		}
+		dst := NewBPlusTree[int, string](3)
+		dst.Insert(1000, "旧数据")
+		if err := json.Unmarshal(data, dst); err != nil {
+			t.Fatal(err)
+		}
+		check(t, dst)
+		if _, ok := dst.Search(1000); ok {
+			t.Error("解码后应替换原有的键值对")
+		}
+	})
+}
+
+// TestBPlusTreeClone 测试 B+ 树的深拷贝
+func TestBPlusTreeClone(t *testing.T) {
+	tree := NewBPlusTree[int, []int](4)
+	for i := 0; i < 100; i++ {
+		tree.Insert(i, []int{i})
+	}
+	clone := tree.Clone(slices.Clone)
+	validateBPlusTree(t, clone)
+
+	var keys []int
+	for k := range clone.All() {
+		keys = append(keys, k)
+	}
+	if len(keys) != 100 || !slices.IsSorted(keys) {
+		t.Fatalf("拷贝的叶子链表不正确: %v", keys)
+	}
+
+	v, _ := tree.Search(10)
+	v[0] = -1
+	tree.Insert(1000, nil)
+	if v, _ := clone.Search(10); v[0] != 10 {
+		t.Errorf("深拷贝不应受原值修改的影响，实际为%v", v)
+	}
+	if _, found := clone.Search(1000); found || clone.Size() != 100 {
+		t.Error("修改原树不应影响拷贝")
+	}
+}
+
+// TestBPlusTreeValidate 测试 B+ 树结构检查
+func TestBPlusTreeValidate(t *testing.T) {
+	tree := NewBPlusTree[int, int](3)
+	for i := 0; i < 100; i++ {
+		tree.Insert((i*37)%101, i)
+		if err := tree.Validate(); err != nil {
+			t.Fatalf("第%d次插入后应通过检查: %v", i, err)
+		}
+	}
+
+	leaf := tree.findLeaf(0)
+	next := leaf.next
+	leaf.next = nil
+	if tree.Validate() == nil {
+		t.Error("应发现叶子链表断开")
+	}
+	leaf.next = next
+
+	if next != nil {
+		prev := next.prev
+		next.prev = nil
+		if tree.Validate() == nil {
+			t.Error("应发现叶子链表的 prev 指针断开")
+		}
+		next.prev = prev
+	}
+
+	leaf.keys[0], leaf.keys[1] = leaf.keys[1], leaf.keys[0]
+	if tree.Validate() == nil {
+		t.Error("应发现叶子节点内的键无序")
+	}
+	leaf.keys[0], leaf.keys[1] = leaf.keys[1], leaf.keys[0]
+
+	tree.root.keys[0] = -1
+	if tree.Validate() == nil {
+		t.Error("应发现键超出分隔键划定的范围")
+	}
+
+	t.Run("父指针", func(t *testing.T) {
+		tree := NewBPlusTree[int, int](4)
+		for i := range 50 {
+			tree.Insert(i, i)
+		}
+		leaf := tree.findLeaf(20)
+		parent := leaf.parent
+		leaf.parent = tree.root.children[0]
+		if parent == tree.root.children[0] {
+			leaf.parent = nil
+		}
+		if tree.Validate() == nil {
+			t.Error("应发现错误的父指针")
+		}
+		leaf.parent = parent
+		validateBPlusTree(t, tree)
+	})
+
+	t.Run("键的数量", func(t *testing.T) {
+		tree := NewBPlusTree[int, int](4)
+		for i := range 50 {
+			tree.Insert(i, i)
+		}
+		leaf := tree.findLeaf(49)
+		keys, values := leaf.keys, leaf.values
+		leaf.keys, leaf.values = append(keys, 50, 51, 52), append(values, 50, 51, 52)
+		leaf.addCount(3)
+		tree.size += 3
+		if err := tree.Validate(); err == nil || !strings.Contains(err.Error(), "上限") {
+			t.Errorf("应发现节点的键超过上限，实际为 %v", err)
+		}
+		leaf.keys, leaf.values = keys[:0], values[:0]
+		leaf.addCount(-3 - len(keys))
+		tree.size -= 3 + len(keys)
+		if err := tree.Validate(); err == nil || !strings.Contains(err.Error(), "下限") {
+			t.Errorf("应发现非根节点的键少于下限，实际为 %v", err)
+		}
+	})
+
+	t.Run("叶子节点深度", func(t *testing.T) {
+		tree := NewBPlusTree[int, int](5)
+		for i := range 20 {
+			tree.Insert(i, i)
+		}
+		// 在根节点下直接挂一个叶子节点，使它比其他叶子节点浅
+		last := tree.lastLeaf()
+		leaf := &TreeNode[int, int]{isLeaf: true, keys: []int{100, 101}, values: []int{0, 0}, prev: last, parent: tree.root}
+		last.next = leaf
+		tree.root.keys = append(tree.root.keys, 100)
+		tree.root.children = append(tree.root.children, leaf)
+		tree.root.count += 2
+		tree.size += 2
+		if err := tree.Validate(); err == nil || !strings.Contains(err.Error(), "深度") {
+			t.Errorf("应发现叶子节点深度不一致，实际为 %v", err)
+		}
+	})
+}
+
+// TestBPlusTreeWithRecorder 测试 B+ 树向记录器报告事件
+func TestBPlusTreeWithRecorder(t *testing.T) {
+	var c metrics.Counters
+	tree := NewBPlusTree[int, int](3, WithRecorder(&c))
+	for i := 0; i < 100; i++ {
+		tree.Insert(i, i)
+	}
+	tree.Insert(0, 1)
+	tree.Search(0)
+	if c.Count(metrics.Insert) != 100 || c.Count(metrics.Update) != 1 || c.Count(metrics.Lookup) != 1 {
+		t.Errorf("事件计数错误: %v", c.Snapshot())
+	}
+	if c.Count(metrics.Split) == 0 {
+		t.Error("插入100个键应触发节点分裂")
+	}
+	if c.Peak() != 100 {
+		t.Errorf("期望峰值为100，实际为%d", c.Peak())
+	}
+}
+
+// TestBPlusTreeWithArena 测试使用节点分配器的 B+ 树在清空时回收节点并在重建时复用
+func TestBPlusTreeWithArena(t *testing.T) {
+	tree := NewBPlusTree[int, int](4, WithArena(8))
+	for i := 0; i < 100; i++ {
+		tree.Insert(i, i)
+	}
+	slabs, _ := tree.nodes.Stats()
+
+	tree.Clear()
+	_, free := tree.nodes.Stats()
+	if free == 0 {
+		t.Fatal("清空后应回收节点")
+	}
+	for i := 0; i < 100; i++ {
+		tree.Insert(i, -i)
+	}
+	if err := tree.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if v, ok := tree.Search(50); !ok || v != -50 {
+		t.Errorf("Search(50) = (%v, %v)，期望 (-50, true)", v, ok)
+	}
+	if again, _ := tree.nodes.Stats(); again != slabs {
+		t.Errorf("重建相同规模的树不应分配新的块，之前%d块，现在%d块", slabs, again)
+	}
+}
+
+// BenchmarkBPlusTreeRebuild 比较启用与不启用节点分配器时反复清空重建的开销
+func BenchmarkBPlusTreeRebuild(b *testing.B) {
+	for name, opts := range map[string][]Option{"默认": nil, "分配器": {WithArena(0)}} {
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+			tree := NewBPlusTree[int, int](16, opts...)
+			for i := 0; i < b.N; i++ {
+				tree.Insert(i%1024, i)
+				if i%1024 == 1023 {
+					tree.Clear()
+				}
+			}
+		})
+	}
+}
+
+// TestBPlusTreeDelete 测试删除键以及删除后的借键、合并与根节点收缩
+func TestBPlusTreeDelete(t *testing.T) {
+	t.Run("删除不存在的键", func(t *testing.T) {
+		tree := NewBPlusTree[int, string](3)
+		if tree.Delete(1) {
+			t.Error("空树删除应返回false")
+		}
+		tree.Insert(1, "一")
+		if tree.Delete(2) || tree.Size() != 1 {
+			t.Error("删除不存在的键应返回false且不改变大小")
+		}
+	})
+
+	t.Run("删除所有键", func(t *testing.T) {
+		for _, order := range []int{3, 4, 5, 8} {
+			tree := NewBPlusTree[int, int](order)
+			for i := 0; i < 200; i++ {
+				tree.Insert(i, i*10)
+			}
+			for i := 0; i < 200; i++ {
+				if !tree.Delete(i) {
+					t.Fatalf("阶数%d: 删除%d失败", order, i)
+				}
+				if err := tree.Validate(); err != nil {
+					t.Fatalf("阶数%d: 删除%d后检查失败: %v", order, i, err)
+				}
+				if _, found := tree.Search(i); found {
+					t.Fatalf("阶数%d: 删除后仍能找到%d", order, i)
+				}
+				if i+1 < 200 {
+					if v, found := tree.Search(i + 1); !found || v != (i+1)*10 {
+						t.Fatalf("阶数%d: 删除%d后找不到%d", order, i, i+1)
+					}
+				}
+			}
+			if !tree.IsEmpty() || !tree.root.isLeaf {
+				t.Errorf("阶数%d: 删除所有键后应只剩空的叶子根节点", order)
+			}
+			tree.Insert(7, 70)
+			if v, found := tree.Search(7); !found || v != 70 {
+				t.Errorf("阶数%d: 删除所有键后应可以继续插入", order)
+			}
+		}
+	})
+
+	t.Run("随机插入与删除", func(t *testing.T) {
+		r := rand.New(rand.NewSource(42))
+		tree := NewBPlusTree[int, int](4)
+		ref := make(map[int]int)
+		for i := 0; i < 5000; i++ {
+			k := r.Intn(300)
+			if r.Intn(2) == 0 {
+				tree.Insert(k, i)
+				ref[k] = i
+			} else {
+				_, want := ref[k]
+				if got := tree.Delete(k); got != want {
+					t.Fatalf("Delete(%d) = %v，期望 %v", k, got, want)
+				}
+				delete(ref, k)
+			}
+			if err := tree.Validate(); err != nil {
+				t.Fatalf("第%d步后检查失败: %v", i, err)
+			}
+		}
+		if tree.Size() != len(ref) {
+			t.Fatalf("期望大小为%d，实际为%d", len(ref), tree.Size())
+		}
+		for k, v := range ref {
+			if got, found := tree.Search(k); !found || got != v {
+				t.Fatalf("Search(%d) = (%d, %v)，期望 (%d, true)", k, got, found, v)
+			}
+		}
+	})
+
+	t.Run("记录删除与合并事件", func(t *testing.T) {
+		var c metrics.Counters
+		tree := NewBPlusTree[int, int](3, WithRecorder(&c))
+		for i := 0; i < 50; i++ {
+			tree.Insert(i, i)
+		}
+		for i := 0; i < 50; i++ {
+			tree.Delete(i)
+		}
+		if c.Count(metrics.Delete) != 50 || c.Count(metrics.Merge) == 0 {
+			t.Errorf("事件计数错误: %v", c.Snapshot())
+		}
+	})
+
+	t.Run("合并时回收节点", func(t *testing.T) {
+		tree := NewBPlusTree[int, int](3, WithArena(8))
+		for i := 0; i < 100; i++ {
+			tree.Insert(i, i)
+		}
+		for i := 0; i < 100; i++ {
+			tree.Delete(i)
+		}
+		if _, free := tree.nodes.Stats(); free == 0 {
+			t.Error("删除导致的合并应回收节点")
+		}
+		for i := 0; i < 100; i++ {
+			tree.Insert(i, i)
+		}
+		if err := tree.Validate(); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+// TestBPlusTreeRangeSearch 测试沿叶子链表的范围查询
+func TestBPlusTreeRangeSearch(t *testing.T) {
+	tree := NewBPlusTree[int, string](3)
+	for i := 0; i < 100; i += 2 {
+		tree.Insert(i, fmt.Sprint(i))
+	}
+
+	tests := []struct {
+		name       string
+		start, end int
+		want       []int
+	}{
+		{"跨越多个叶子节点", 10, 21, []int{10, 12, 14, 16, 18, 20}},
+		{"边界不在树中", 11, 17, []int{12, 14, 16}},
+		{"不包含上界", 10, 12, []int{10}},
+		{"下界小于所有键", -10, 3, []int{0, 2}},
+		{"上界大于所有键", 95, 1000, []int{96, 98}},
+		{"区间内没有键", 13, 14, nil},
+		{"空区间", 20, 20, nil},
+		{"上界小于下界", 30, 20, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tree.RangeSearch(tt.start, tt.end)
+			if got == nil {
+				t.Fatal("RangeSearch 不应返回 nil")
+			}
+			var keys []int
+			for _, kv := range got {
+				if kv.Second != fmt.Sprint(kv.First) {
+					t.Errorf("键%d对应的值为%q", kv.First, kv.Second)
+				}
+				keys = append(keys, kv.First)
+			}
+			if !slices.Equal(keys, tt.want) {
+				t.Errorf("RangeSearch(%d, %d) 的键为 %v，期望 %v", tt.start, tt.end, keys, tt.want)
+			}
+		})
+	}
+
+	t.Run("回调提前停止", func(t *testing.T) {
+		var keys []int
+		tree.AscendRange(0, 100, func(key int, _ string) bool {
+			keys = append(keys, key)
+			return len(keys) < 3
+		})
+		if !slices.Equal(keys, []int{0, 2, 4}) {
+			t.Errorf("期望在第3个键后停止，实际为 %v", keys)
+		}
+	})
+
+	t.Run("删除后查询", func(t *testing.T) {
+		for i := 0; i < 100; i += 4 {
+			tree.Delete(i)
+		}
+		var keys []int
+		for _, kv := range tree.RangeSearch(0, 20) {
+			keys = append(keys, kv.First)
+		}
+		if !slices.Equal(keys, []int{2, 6, 10, 14, 18}) {
+			t.Errorf("删除后的范围查询结果为 %v", keys)
+		}
+	})
+}
+
+// TestBPlusTreeDescend 测试沿叶子链表的逆序遍历
+func TestBPlusTreeDescend(t *testing.T) {
+	tree := NewBPlusTree[int, int](3)
+	for i := 0; i < 50; i++ {
+		tree.Insert(i, -i)
+	}
+
+	t.Run("Backward", func(t *testing.T) {
+		var keys []int
+		for k, v := range tree.Backward() {
+			if v != -k {
+				t.Fatalf("键%d对应的值为%d", k, v)
+			}
+			keys = append(keys, k)
+		}
+		if len(keys) != 50 || keys[0] != 49 || keys[49] != 0 || !slices.IsSortedFunc(keys, func(a, b int) int { return b - a }) {
+			t.Errorf("逆序遍历结果错误: %v", keys)
+		}
+	})
+
+	t.Run("取最大的若干个键", func(t *testing.T) {
+		var keys []int
+		for k := range tree.Backward() {
+			keys = append(keys, k)
+			if len(keys) == 3 {
+				break
+			}
+		}
+		if !slices.Equal(keys, []int{49, 48, 47}) {
+			t.Errorf("期望 [49 48 47]，实际为 %v", keys)
+		}
+	})
+
+	t.Run("DescendRange", func(t *testing.T) {
+		tests := []struct {
+			lessOrEqual, greaterThan int
+			want                     []int
+		}{
+			{20, 15, []int{20, 19, 18, 17, 16}},
+			{100, 46, []int{49, 48, 47}},
+			{2, -10, []int{2, 1, 0}},
+			{10, 10, nil},
+			{10, 20, nil},
+		}
+		for _, tt := range tests {
+			var keys []int
+			tree.DescendRange(tt.lessOrEqual, tt.greaterThan, func(k, _ int) bool {
+				keys = append(keys, k)
+				return true
+			})
+			if !slices.Equal(keys, tt.want) {
+				t.Errorf("DescendRange(%d, %d) = %v，期望 %v", tt.lessOrEqual, tt.greaterThan, keys, tt.want)
+			}
+		}
+	})
+
+	t.Run("删除与拷贝后保持双向链表", func(t *testing.T) {
+		for i := 0; i < 50; i += 3 {
+			tree.Delete(i)
+		}
+		clone := tree.Clone(nil)
+		for _, tr := range []*BPlusTree[int, int]{tree, clone} {
+			if err := tr.Validate(); err != nil {
+				t.Fatal(err)
+			}
+			var forward, backward []int
+			for k := range tr.All() {
+				forward = append(forward, k)
+			}
+			for k := range tr.Backward() {
+				backward = append(backward, k)
+			}
+			slices.Reverse(backward)
+			if !slices.Equal(forward, backward) {
+				t.Errorf("正序与逆序遍历结果不一致: %v / %v", forward, backward)
+			}
+		}
+	})
+
+	t.Run("空树", func(t *testing.T) {
+		empty := NewBPlusTree[int, int](3)
+		for range empty.Backward() {
+			t.Error("空树不应产出键值对")
+		}
+	})
+}
+
+// TestBPlusTreeMinMax 测试最小与最大键值对
+func TestBPlusTreeMinMax(t *testing.T) {
+	tree := NewBPlusTree[int, string](3)
+	if _, _, ok := tree.Min(); ok {
+		t.Error("空树 Min 应返回false")
+	}
+	if _, _, ok := tree.Max(); ok {
+		t.Error("空树 Max 应返回false")
+	}
+
+	for _, k := range []int{50, 20, 80, 10, 90, 30, 70} {
+		tree.Insert(k, fmt.Sprint(k))
+	}
+	if k, v, ok := tree.Min(); !ok || k != 10 || v != "10" {
+		t.Errorf("Min() = (%d, %q, %v)，期望 (10, \"10\", true)", k, v, ok)
+	}
+	if k, v, ok := tree.Max(); !ok || k != 90 || v != "90" {
+		t.Errorf("Max() = (%d, %q, %v)，期望 (90, \"90\", true)", k, v, ok)
+	}
+
+	tree.Delete(10)
+	tree.Delete(90)
+	if k, _, _ := tree.Min(); k != 20 {
+		t.Errorf("删除最小键后 Min() = %d，期望 20", k)
+	}
+	if k, _, _ := tree.Max(); k != 80 {
+		t.Errorf("删除最大键后 Max() = %d，期望 80", k)
+	}
+
+	for _, k := range []int{20, 30, 50, 70, 80} {
+		tree.Delete(k)
+	}
+	if _, _, ok := tree.Min(); ok {
+		t.Error("删除所有键后 Min 应返回false")
+	}
+}
+
+// TestBPlusTreeHeight 测试树的层数随插入和删除变化
+func TestBPlusTreeHeight(t *testing.T) {
+	tree := NewBPlusTree[int, int](3)
+	if h := tree.Height(); h != 0 {
+		t.Errorf("空树高度期望为0，实际为%d", h)
+	}
+	tree.Insert(1, 1)
+	tree.Insert(2, 2)
+	if h := tree.Height(); h != 1 {
+		t.Errorf("只有根叶子节点时高度期望为1，实际为%d", h)
+	}
+	tree.Insert(3, 3)
+	if h := tree.Height(); h != 2 {
+		t.Errorf("根叶子节点分裂后高度期望为2，实际为%d", h)
+	}
+
+	for i := 4; i <= 1000; i++ {
+		tree.Insert(i, i)
+	}
+	// 阶数为3时每个非根节点至少有2个子节点
+	if h := tree.Height(); h < 2 || h > 11 {
+		t.Errorf("1000个键时高度为%d，超出合理范围", h)
+	}
+	for i := 1; i <= 1000; i++ {
+		tree.Delete(i)
+	}
+	if h := tree.Height(); h != 0 {
+		t.Errorf("删除所有键后高度期望为0，实际为%d", h)
+	}
+}
+
+// TestBPlusTreePrefixScan 测试按前缀遍历字符串键
+func TestBPlusTreePrefixScan(t *testing.T) {
+	tree := NewBPlusTree[string, int](4)
+	words := []string{"app", "apple", "application", "apply", "apt", "banana", "ap", "a", "b\xff", "b\xff\xff", "b\xffz", "c"}
+	for i, w := range words {
+		tree.Insert(w, i)
+	}
+	scan := func(prefix string) []string {
+		var got []string
+		tree.PrefixScan(prefix, func(key string, _ int) bool {
+			got = append(got, key)
+			return true
+		})
+		return got
+	}
+
+	tests := []struct {
+		prefix string
+		want   []string
+	}{
+		{"app", []string{"app", "apple", "application", "apply"}},
+		{"appl", []string{"apple", "application", "apply"}},
+		{"ap", []string{"ap", "app", "apple", "application", "apply", "apt"}},
+		{"b\xff", []string{"b\xff", "b\xffz", "b\xff\xff"}},
+		{"\xff", nil},
+		{"x", nil},
+		{"", []string{"a", "ap", "app", "apple", "application", "apply", "apt", "banana", "b\xff", "b\xffz", "b\xff\xff", "c"}},
+	}
+	for _, tt := range tests {
+		if got := scan(tt.prefix); !slices.Equal(got, tt.want) {
+			t.Errorf("PrefixScan(%q) = %q，期望 %q", tt.prefix, got, tt.want)
+		}
+	}
+
+	t.Run("提前停止", func(t *testing.T) {
+		count := 0
+		tree.PrefixScan("a", func(string, int) bool {
+			count++
+			return count < 2
+		})
+		if count != 2 {
+			t.Errorf("fn 返回 false 后应停止遍历，实际调用%d次", count)
+		}
+	})
+
+	t.Run("自定义字符串类型", func(t *testing.T) {
+		type path string
+		paths := NewBPlusTree[path, bool](3)
+		for _, p := range []path{"/usr/bin", "/usr/lib", "/var/log", "/usr"} {
+			paths.Insert(p, true)
+		}
+		var got []path
+		paths.PrefixScan("/usr/", func(key path, _ bool) bool {
+			got = append(got, key)
+			return true
+		})
+		if !slices.Equal(got, []path{"/usr/bin", "/usr/lib"}) {
+			t.Errorf("PrefixScan(/usr/) = %v", got)
+		}
+	})
+
+	t.Run("非字符串键", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("键不是字符串时应 panic")
+			}
+		}()
+		NewBPlusTree[int, int](3).PrefixScan("1", func(int, int) bool { return true })
+	})
+}
+
+// TestNewBPlusTreeFunc 测试使用自定义比较函数的 B+ 树
+func TestNewBPlusTreeFunc(t *testing.T) {
+	t.Run("组合键", func(t *testing.T) {
+		type key struct {
+			Table string
+			ID    int
+		}
+		byTableID := func(a, b key) int {
+			if c := strings.Compare(a.Table, b.Table); c != 0 {
+				return c
+			}
+			return a.ID - b.ID
+		}
+		tree := NewBPlusTreeFunc[key, string](4, byTableID)
+		for i := range 50 {
+			tree.Insert(key{"users", i}, fmt.Sprint("u", i))
+			tree.Insert(key{"orders", i}, fmt.Sprint("o", i))
+		}
+		tree.Insert(key{"users", 7}, "七")
+		if tree.Size() != 100 {
+			t.Fatalf("期望100个键值对，实际为%d", tree.Size())
+		}
+		if v, ok := tree.Search(key{"users", 7}); !ok || v != "七" {
+			t.Errorf("Search 返回 (%q, %v)，期望 (七, true)", v, ok)
+		}
+		for i := 0; i < 50; i += 2 {
+			if !tree.Delete(key{"orders", i}) {
+				t.Fatalf("应能删除 orders/%d", i)
+			}
+		}
+		if err := tree.Validate(); err != nil {
+			t.Fatal(err)
+		}
+
+		// 区间 [{users 0}, {users 10}) 只包含 users 表中 ID 小于10的键
+		got := tree.RangeSearch(key{"users", 0}, key{"users", 10})
+		if len(got) != 10 || got[0].First != (key{"users", 0}) || got[9].First != (key{"users", 9}) {
+			t.Errorf("区间查询结果不正确: %v", got)
+		}
+		first, _, _ := tree.Min()
+		if first != (key{"orders", 1}) {
+			t.Errorf("最小的键为%v，期望 {orders 1}", first)
+		}
+		if tree.Compare(key{"a", 9}, key{"b", 0}) >= 0 {
+			t.Error("Compare 应使用自定义比较函数")
+		}
+	})
+
+	t.Run("降序", func(t *testing.T) {
+		tree := NewBPlusTreeFunc[int, int](3, func(a, b int) int { return b - a })
+		for i := range 20 {
+			tree.Insert(i, i)
+		}
+		var keys []int
+		for k := range tree.All() {
+			keys = append(keys, k)
+		}
+		if !slices.IsSortedFunc(keys, func(a, b int) int { return b - a }) || len(keys) != 20 {
+			t.Errorf("应按比较函数的顺序遍历，实际为%v", keys)
+		}
+		clone := tree.Clone(nil)
+		clone.Insert(100, 100)
+		if k, _, _ := clone.Min(); k != 100 {
+			t.Errorf("副本应继承比较函数，最小的键为%d", k)
+		}
+		validateBPlusTree(t, clone)
+	})
+
+	t.Run("比较函数为nil", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("比较函数为 nil 时应 panic")
+			}
+		}()
+		NewBPlusTreeFunc[int, int](3, nil)
+	})
+}
+
+// TestBPlusTreeDuplicates 测试允许重复键的多重映射模式，并与 map[int][]int 的结果对照
+func TestBPlusTreeDuplicates(t *testing.T) {
+	tree := NewBPlusTree[int, int](4, WithDuplicates())
+	rng := rand.New(rand.NewSource(3))
+	expected := make(map[int][]int)
+	size := 0
+	for i := range 4000 {
+		// 键的范围很小，使相同的键跨越多个叶子节点
+		key := rng.Intn(20)
+		switch rng.Intn(5) {
+		case 0:
+			ok := tree.Delete(key)
+			if ok != (len(expected[key]) > 0) {
+				t.Fatalf("Delete(%d) = %v，期望 %v", key, ok, !ok)
+			}
+			if ok {
+				expected[key] = expected[key][1:]
+				size--
+			}
+		case 1:
+			if i%50 == 0 {
+				n := tree.DeleteAll(key)
+				if n != len(expected[key]) {
+					t.Fatalf("DeleteAll(%d) = %d，期望 %d", key, n, len(expected[key]))
+				}
+				size -= n
+				delete(expected, key)
+				continue
+			}
+			fallthrough
+		default:
+			tree.Insert(key, i)
+			expected[key] = append(expected[key], i)
+			size++
+		}
+		if i%500 == 0 {
+			if err := tree.Validate(); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	if tree.Size() != size {
+		t.Fatalf("期望%d个键值对，实际为%d", size, tree.Size())
+	}
+	if err := tree.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	for key := range 20 {
+		want := expected[key]
+		if got := tree.SearchAll(key); !slices.Equal(got, want) && len(got)+len(want) > 0 {
+			t.Errorf("SearchAll(%d) = %v，期望按插入顺序返回 %v", key, got, want)
+		}
+		if v, ok := tree.Search(key); ok != (len(want) > 0) || (ok && v != want[0]) {
+			t.Errorf("Search(%d) = (%d, %v)，期望返回最早插入的值", key, v, ok)
+		}
+	}
+
+	t.Run("区间遍历", func(t *testing.T) {
+		var got []int
+		tree.AscendRange(5, 8, func(_, value int) bool {
+			got = append(got, value)
+			return true
+		})
+		var want []int
+		for key := 5; key < 8; key++ {
+			want = append(want, expected[key]...)
+		}
+		if !slices.Equal(got, want) {
+			t.Errorf("区间 [5, 8) 内的值为%v，期望%v", got, want)
+		}
+		c := tree.Cursor()
+		c.Seek(5)
+		if len(expected[5]) > 0 && (!c.Next() || c.Key() != 5 || c.Value() != expected[5][0]) {
+			t.Error("Seek 应定位到相同键中最早插入的一个")
+		}
+	})
+
+	t.Run("克隆与编码", func(t *testing.T) {
+		clone := tree.Clone(nil)
+		clone.Insert(3, -1)
+		if got := clone.SearchAll(3); len(got) != len(expected[3])+1 || got[len(got)-1] != -1 {
+			t.Errorf("副本应保留多重映射模式，SearchAll(3) = %v", got)
+		}
+		data, err := tree.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		decoded := NewBPlusTree[int, int](4, WithDuplicates())
+		if err := decoded.UnmarshalBinary(data); err != nil || decoded.Size() != size {
+			t.Errorf("编码后还原得到%d个键值对，期望%d，错误为%v", decoded.Size(), size, err)
+		}
+	})
+
+	t.Run("默认模式", func(t *testing.T) {
+		unique := NewBPlusTree[int, int](4)
+		unique.Insert(1, 1)
+		unique.Insert(1, 2)
+		if got := unique.SearchAll(1); !slices.Equal(got, []int{2}) || unique.Size() != 1 {
+			t.Errorf("默认模式下重复插入应覆盖，SearchAll(1) = %v", got)
+		}
+		if got := unique.SearchAll(2); got == nil || len(got) != 0 {
+			t.Errorf("键不存在时应返回空切片，实际为%v", got)
+		}
+		if unique.DeleteAll(1) != 1 || !unique.IsEmpty() {
+			t.Error("默认模式下 DeleteAll 最多删除一个键值对")
+		}
+	})
+}
+
+// TestBPlusTreeRankSelect 测试按排名查找与求排名
+func TestBPlusTreeRankSelect(t *testing.T) {
+	t.Run("随机插入删除", func(t *testing.T) {
+		tree := NewBPlusTree[int, int](4)
+		rng := rand.New(rand.NewSource(5))
+		var keys []int
+		for i := range 3000 {
+			key := rng.Intn(1000)
+			pos, found := slices.BinarySearch(keys, key)
+			if rng.Intn(3) == 0 {
+				tree.Delete(key)
+				if found {
+					keys = slices.Delete(keys, pos, pos+1)
+				}
+			} else {
+				tree.Insert(key, key*2)
+				if !found {
+					keys = slices.Insert(keys, pos, key)
+				}
+			}
+			if i%300 == 0 {
+				if err := tree.Validate(); err != nil {
+					t.Fatal(err)
+				}
+			}
+		}
+		for key := -1; key <= 1001; key++ {
+			want, _ := slices.BinarySearch(keys, key)
+			if got := tree.Rank(key); got != want {
+				t.Fatalf("Rank(%d) = %d，期望 %d", key, got, want)
+			}
+		}
+		for k, want := range keys {
+			key, value, ok := tree.SelectKth(k)
+			if !ok || key != want || value != want*2 {
+				t.Fatalf("SelectKth(%d) = (%d, %d, %v)，期望 (%d, %d, true)", k, key, value, ok, want, want*2)
+			}
+		}
+		for _, k := range []int{-1, len(keys)} {
+			if _, _, ok := tree.SelectKth(k); ok {
+				t.Errorf("SelectKth(%d) 越界时应返回 false", k)
+			}
+		}
+	})
+
+	t.Run("空树", func(t *testing.T) {
+		tree := NewBPlusTree[int, int](3)
+		if got := tree.Rank(10); got != 0 {
+			t.Errorf("空树 Rank(10) = %d，期望 0", got)
+		}
+		if _, _, ok := tree.SelectKth(0); ok {
+			t.Error("空树 SelectKth(0) 应返回 false")
+		}
+	})
+
+	t.Run("重复的键", func(t *testing.T) {
+		tree := NewBPlusTree[int, int](3, WithDuplicates())
+		for i := range 300 {
+			tree.Insert(i%10, i)
+		}
+		for key := range 10 {
+			if got := tree.Rank(key); got != key*30 {
+				t.Errorf("Rank(%d) = %d，期望 %d", key, got, key*30)
+			}
+		}
+		// 相同的键按插入顺序排列
+		for k := range 300 {
+			key, value, ok := tree.SelectKth(k)
+			if !ok || key != k/30 || value != k%30*10+k/30 {
+				t.Fatalf("SelectKth(%d) = (%d, %d, %v)", k, key, value, ok)
+			}
+		}
+	})
+
+	t.Run("克隆与解码", func(t *testing.T) {
+		tree := NewBPlusTree[int, string](5)
+		for i := range 200 {
+			tree.Insert(i, fmt.Sprint(i))
+		}
+		var buf bytes.Buffer
+		if err := tree.Encode(&buf); err != nil {
+			t.Fatal(err)
+		}
+		decoded, err := DecodeBPlusTree[int, string](&buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, other := range []*BPlusTree[int, string]{tree.Clone(nil), decoded} {
+			if err := other.Validate(); err != nil {
+				t.Fatal(err)
+			}
+			for k := 0; k < 200; k += 7 {
+				if got := other.Rank(k); got != k {
+					t.Errorf("Rank(%d) = %d，期望 %d", k, got, k)
+				}
+				if key, _, ok := other.SelectKth(k); !ok || key != k {
+					t.Errorf("SelectKth(%d) = (%d, %v)，期望 %d", k, key, ok, k)
+				}
+			}
+		}
+	})
+}
+
+// TestBPlusTreeUpsert 测试插入时合并已有的值
+func TestBPlusTreeUpsert(t *testing.T) {
+	t.Run("计数器", func(t *testing.T) {
+		tree := NewBPlusTree[string, int](3)
+		add := func(old, new int) int { return old + new }
+		words := strings.Fields("a b a c b a d e a f g b")
+		for _, w := range words {
+			tree.Upsert(w, 1, add)
+		}
+		validateBPlusTree(t, tree)
+		want := map[string]int{"a": 4, "b": 3, "c": 1, "d": 1, "e": 1, "f": 1, "g": 1}
+		if tree.Size() != len(want) {
+			t.Fatalf("期望%d个键，实际为%d", len(want), tree.Size())
+		}
+		for w, n := range want {
+			if got, _ := tree.Search(w); got != n {
+				t.Errorf("%s 的计数为%d，期望 %d", w, got, n)
+			}
+		}
+	})
+
+	t.Run("追加列表", func(t *testing.T) {
+		tree := NewBPlusTree[int, []int](4)
+		for i := range 100 {
+			tree.Upsert(i%10, []int{i}, func(old, new []int) []int {
+				return append(old, new...)
+			})
+		}
+		for k := range 10 {
+			got, _ := tree.Search(k)
+			if len(got) != 10 || got[0] != k || got[9] != 90+k {
+				t.Errorf("键%d对应的列表为%v", k, got)
+			}
+		}
+	})
+
+	t.Run("merge 为 nil", func(t *testing.T) {
+		tree := NewBPlusTree[int, int](3)
+		tree.Upsert(1, 1, nil)
+		tree.Upsert(1, 2, nil)
+		if got, _ := tree.Search(1); got != 2 || tree.Size() != 1 {
+			t.Errorf("merge 为 nil 时应覆盖旧值，实际为%d", got)
+		}
+	})
+
+	t.Run("重复的键", func(t *testing.T) {
+		tree := NewBPlusTree[int, int](3, WithDuplicates())
+		tree.Upsert(1, 1, func(old, new int) int {
+			t.Error("允许重复的键时不应调用 merge")
+			return new
+		})
+		tree.Upsert(1, 2, func(old, new int) int {
+			t.Error("允许重复的键时不应调用 merge")
+			return new
+		})
+		if got := tree.SearchAll(1); !slices.Equal(got, []int{1, 2}) {
+			t.Errorf("允许重复的键时应添加新的键值对，实际为%v", got)
+		}
+	})
+}
@@ -0,0 +1,73 @@
+package bplustree
+
+import (
+	"strings"
+	"unsafe"
+)
+
+// WithPrefixCompression 对叶子节点中的字符串键进行前缀压缩，只能用于底层类型为 string 的键，否则创建时 panic
+// 每个叶子节点只保存一份所有键的公共前缀，键只保存去掉前缀后的部分，适合键有很长公共前缀的大型索引（例如路径、URL）。
+// 以 CPU 换内存：读取叶子节点中的键时需要拼接前缀，插入新键、借键与合并时需要重新计算公共前缀；
+// 删除键不会重新压缩，公共前缀在下一次修改该叶子节点时更新。Clone 与 Split 得到的树保留该模式
+func WithPrefixCompression() Option {
+	return func(o *options) {
+		o.compress = true
+	}
+}
+
+// keyAt 返回节点 n 中的第 i 个完整的键，叶子节点有公共前缀时拼接前缀
+func (tree *BPlusTree[K, V]) keyAt(n *TreeNode[K, V], i int) K {
+	if n.prefix == "" {
+		return n.keys[i]
+	}
+	return stringToKey[K](n.prefix + keyToString(n.keys[i]))
+}
+
+// expand 把叶子节点中的键还原为完整的键并清除公共前缀，修改叶子节点中的键之前调用
+func (tree *BPlusTree[K, V]) expand(n *TreeNode[K, V]) {
+	if n.prefix == "" {
+		return
+	}
+	for i := range n.keys {
+		n.keys[i] = tree.keyAt(n, i)
+	}
+	n.prefix = ""
+}
+
+// compressLeaf 在启用前缀压缩时重新计算叶子节点的公共前缀并截掉每个键的前缀，n 中的键必须是完整的键
+// 自定义比较函数下键不一定按字节序排列，因此公共前缀逐个比较所有的键，而不只是第一个与最后一个键；
+// 前缀与截掉前缀后的部分都复制到新的内存中，不再引用原来完整的键
+func (tree *BPlusTree[K, V]) compressLeaf(n *TreeNode[K, V]) {
+	if !tree.compress || len(n.keys) == 0 {
+		return
+	}
+	first := keyToString(n.keys[0])
+	size := len(first)
+	for _, key := range n.keys[1:] {
+		s := keyToString(key)
+		i := 0
+		for i < size && i < len(s) && first[i] == s[i] {
+			i++
+		}
+		if size = i; size == 0 {
+			return
+		}
+	}
+	if size == 0 {
+		return
+	}
+	n.prefix = strings.Clone(first[:size])
+	for i, key := range n.keys {
+		n.keys[i] = stringToKey[K](strings.Clone(keyToString(key)[size:]))
+	}
+}
+
+// keyToString 把底层类型为 string 的键转换为 string，只在启用前缀压缩（已检查键的类型）时调用
+func keyToString[K any](key K) string {
+	return *(*string)(unsafe.Pointer(&key))
+}
+
+// stringToKey 把 string 转换为底层类型为 string 的键，只在启用前缀压缩（已检查键的类型）时调用
+func stringToKey[K any](s string) K {
+	return *(*K)(unsafe.Pointer(&s))
+}
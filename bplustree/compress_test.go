@@ -0,0 +1,201 @@
+package bplustree
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// pathKey 底层类型为 string 的自定义键类型
+type pathKey string
+
+// collectPairs 按升序收集所有键值对
+func collectPairs[K comparable, V any](tree *BPlusTree[K, V]) ([]K, []V) {
+	var keys []K
+	var values []V
+	tree.ForEach(func(k K, v V) bool {
+		keys = append(keys, k)
+		values = append(values, v)
+		return true
+	})
+	return keys, values
+}
+
+// TestBPlusTreePrefixCompression 测试叶子节点的前缀压缩，所有操作的结果应与不压缩时相同
+func TestBPlusTreePrefixCompression(t *testing.T) {
+	plain := NewBPlusTree[pathKey, int](5)
+	tree := NewBPlusTree[pathKey, int](5, WithPrefixCompression())
+	rng := rand.New(rand.NewSource(13))
+	key := func() pathKey {
+		return pathKey(fmt.Sprintf("/srv/data/users/%03d/files/%04d", rng.Intn(20), rng.Intn(300)))
+	}
+	for i := range 5000 {
+		k := key()
+		switch rng.Intn(4) {
+		case 0:
+			if got, want := tree.Delete(k), plain.Delete(k); got != want {
+				t.Fatalf("Delete(%q) = %v，期望 %v", k, got, want)
+			}
+		case 1:
+			tree.Upsert(k, i, func(old, new int) int { return old + new })
+			plain.Upsert(k, i, func(old, new int) int { return old + new })
+		default:
+			tree.Insert(k, i)
+			plain.Insert(k, i)
+		}
+		if i%500 == 0 {
+			validateBPlusTree(t, tree)
+		}
+	}
+	validateBPlusTree(t, tree)
+
+	wantKeys, wantValues := collectPairs(plain)
+	gotKeys, gotValues := collectPairs(tree)
+	if !slices.Equal(gotKeys, wantKeys) || !slices.Equal(gotValues, wantValues) {
+		t.Fatal("压缩后遍历结果与不压缩时不同")
+	}
+
+	t.Run("叶子节点保存公共前缀", func(t *testing.T) {
+		prefixed, suffixBytes, fullBytes := 0, 0, 0
+		for n := tree.firstLeaf(); n != nil; n = n.next {
+			if n.prefix != "" {
+				prefixed++
+			}
+			for i, k := range n.keys {
+				suffixBytes += len(k)
+				fullBytes += len(tree.keyAt(n, i))
+			}
+			suffixBytes += len(n.prefix)
+		}
+		if prefixed == 0 || suffixBytes*2 > fullBytes {
+			t.Errorf("%d个叶子节点有公共前缀，键占用%d字节，不压缩时为%d字节", prefixed, suffixBytes, fullBytes)
+		}
+	})
+
+	t.Run("查询", func(t *testing.T) {
+		for i, k := range wantKeys {
+			if v, ok := tree.Search(k); !ok || v != wantValues[i] {
+				t.Fatalf("Search(%q) = (%d, %v)", k, v, ok)
+			}
+			if got := tree.Rank(k); got != i {
+				t.Fatalf("Rank(%q) = %d，期望 %d", k, got, i)
+			}
+			if got, _, _ := tree.SelectKth(i); got != k {
+				t.Fatalf("SelectKth(%d) = %q，期望 %q", i, got, k)
+			}
+		}
+		var got []pathKey
+		tree.PrefixScan("/srv/data/users/007/", func(k pathKey, _ int) bool {
+			got = append(got, k)
+			return true
+		})
+		var want []pathKey
+		plain.PrefixScan("/srv/data/users/007/", func(k pathKey, _ int) bool {
+			want = append(want, k)
+			return true
+		})
+		if len(want) == 0 || !slices.Equal(got, want) {
+			t.Errorf("PrefixScan 结果为%v，期望%v", got, want)
+		}
+		got = got[:0]
+		for k := range tree.Backward() {
+			got = append(got, k)
+		}
+		slices.Reverse(got)
+		if !slices.Equal(got, wantKeys) {
+			t.Error("逆序遍历结果与不压缩时不同")
+		}
+		c := tree.Cursor()
+		if !c.Last() || c.Key() != wantKeys[len(wantKeys)-1] || !c.Prev() || c.Key() != wantKeys[len(wantKeys)-2] {
+			t.Error("游标应返回完整的键")
+		}
+	})
+
+	t.Run("克隆、编码与分割", func(t *testing.T) {
+		clone := tree.Clone(nil)
+		validateBPlusTree(t, clone)
+		clone.Insert("/a", 0)
+		if _, ok := tree.Search("/a"); ok {
+			t.Error("修改副本不应影响原树")
+		}
+
+		var buf bytes.Buffer
+		if err := tree.Encode(&buf); err != nil {
+			t.Fatal(err)
+		}
+		decoded, err := DecodeBPlusTree[pathKey, int](&buf, WithPrefixCompression())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if keys, _ := collectPairs(decoded); !slices.Equal(keys, wantKeys) {
+			t.Error("解码后的键与原树不同")
+		}
+		if decoded.firstLeaf().prefix == "" {
+			t.Error("解码时应重新压缩叶子节点")
+		}
+
+		mid := wantKeys[len(wantKeys)/2]
+		left, right := clone.Split(mid)
+		validateBPlusTree(t, left)
+		validateBPlusTree(t, right)
+		if k, _, _ := right.Min(); k != mid || left.Size()+right.Size() != len(wantKeys)+1 {
+			t.Errorf("分割后右侧最小的键为%q", k)
+		}
+		right.Insert(mid+"/x", 1)
+		validateBPlusTree(t, right)
+	})
+
+	t.Run("自定义比较函数", func(t *testing.T) {
+		// 先按长度再按字节序比较，叶子节点中的第一个与最后一个键的公共前缀不一定是所有键的公共前缀
+		byLength := func(a, b string) int {
+			if len(a) != len(b) {
+				return len(a) - len(b)
+			}
+			return strings.Compare(a, b)
+		}
+		tree := NewBPlusTreeFunc[string, int](5, byLength, WithPrefixCompression())
+		for i, key := range []string{"aa", "bb", "aaa"} {
+			tree.Insert(key, i)
+		}
+		validateBPlusTree(t, tree)
+		for i, key := range []string{"aa", "bb", "aaa"} {
+			if v, ok := tree.Search(key); !ok || v != i {
+				t.Errorf("Search(%q) = (%d, %v)，期望 (%d, true)", key, v, ok, i)
+			}
+		}
+		if keys, _ := collectPairs(tree); !slices.Equal(keys, []string{"aa", "bb", "aaa"}) {
+			t.Errorf("按比较函数的顺序遍历应得到 [aa bb aaa]，实际为%q", keys)
+		}
+
+		rng := rand.New(rand.NewSource(5))
+		want := make(map[string]int)
+		for i := range 2000 {
+			key := strings.Repeat("x", rng.Intn(4)) + fmt.Sprint(rng.Intn(300))
+			if rng.Intn(4) == 0 {
+				tree.Delete(key)
+				delete(want, key)
+			} else {
+				tree.Insert(key, i)
+				want[key] = i
+			}
+		}
+		validateBPlusTree(t, tree)
+		for key, v := range want {
+			if got, ok := tree.Search(key); !ok || got != v {
+				t.Fatalf("Search(%q) = (%d, %v)，期望 (%d, true)", key, got, ok, v)
+			}
+		}
+	})
+
+	t.Run("非字符串键", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("非字符串键启用前缀压缩时应 panic")
+			}
+		}()
+		NewBPlusTree[int, int](3, WithPrefixCompression())
+	})
+}
@@ -0,0 +1,543 @@
+package bplustree
+
+import (
+	"iter"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"unsafe"
+
+	"golang.org/x/exp/constraints"
+)
+
+// latchNode ConcurrentBPlusTree 的节点，每个节点带有自己的读写锁（latch）
+// isLeaf 在节点创建后不再改变，可以不加锁读取；其余字段都需要持有 mu
+type latchNode[K constraints.Ordered, V any] struct {
+	mu       sync.RWMutex
+	isLeaf   bool               // 是否为叶子节点
+	keys     []K                // 键数组
+	children []*latchNode[K, V] // 子节点指针数组（仅对非叶子节点有效）
+	values   []V                // 值数组（仅对叶子节点有效）
+	next     *latchNode[K, V]   // 指向下一个叶子节点的指针
+}
+
+// ConcurrentBPlusTree 可以被多个 goroutine 同时读写的 B+ 树
+// 每个节点有独立的读写锁，操作从根节点向下时使用锁耦合（latch crabbing）：先锁住子节点再释放父节点，
+// 因此不同子树上的读写可以并行，而不是被一把全局锁串行化。
+//   - 查找只持有读锁，同一时刻最多锁住两个节点
+//   - 插入先乐观地以读锁下降、只对叶子节点加写锁，叶子节点不会分裂时直接完成；
+//     否则从根节点开始以写锁重新下降，只保留可能因分裂而被修改的祖先节点的锁
+//   - 删除同样先乐观地只锁叶子节点，叶子节点会少于下限时从根节点开始以写锁重新下降，
+//     只保留可能因合并而被修改的祖先节点的锁，并在父节点的锁下与兄弟节点借键或合并，根节点只剩一个子节点时树的高度减一
+//   - 遍历逐个叶子节点复制键值对后释放锁再回调，回调中可以访问同一棵树；
+//     遍历期间的并发修改可能被看到也可能看不到，但每个键最多产出一次且保持升序
+type ConcurrentBPlusTree[K constraints.Ordered, V any] struct {
+	mu    sync.RWMutex     // 保护 root 指针，根节点分裂时需要写锁
+	root  *latchNode[K, V] // 根节点
+	order int              // 树的阶数
+	size  atomic.Int64     // 键值对数量
+	moves atomic.Uint64    // 叶子节点之间借键或合并的次数，遍历据此判断是否需要重新定位
+}
+
+// NewConcurrentBPlusTree 创建新的并发 B+ 树
+// 参数：
+//   - order: 树的阶数，必须大于等于3
+//
+// 返回：
+//   - *ConcurrentBPlusTree[K, V]: 新创建的并发 B+ 树指针
+func NewConcurrentBPlusTree[K constraints.Ordered, V any](order int) *ConcurrentBPlusTree[K, V] {
+	if order < 3 {
+		panic("阶数必须至少为3")
+	}
+	return &ConcurrentBPlusTree[K, V]{
+		root:  &latchNode[K, V]{isLeaf: true},
+		order: order,
+	}
+}
+
+// childIndex 返回 key 所在子树的下标，等于分隔键的键位于右侧子树
+func (n *latchNode[K, V]) childIndex(key K) int {
+	idx, found := slices.BinarySearch(n.keys, key)
+	if found {
+		idx++
+	}
+	return idx
+}
+
+// lockRoot 锁住根节点并返回，叶子节点按 leafWrite 决定加写锁还是读锁，内部节点加读锁
+func (tree *ConcurrentBPlusTree[K, V]) lockRoot(leafWrite bool) *latchNode[K, V] {
+	tree.mu.RLock()
+	n := tree.root
+	lockNode(n, leafWrite)
+	tree.mu.RUnlock()
+	return n
+}
+
+// lockNode 按节点类型加锁
+func lockNode[K constraints.Ordered, V any](n *latchNode[K, V], leafWrite bool) {
+	if n.isLeaf && leafWrite {
+		n.mu.Lock()
+	} else {
+		n.mu.RLock()
+	}
+}
+
+// findLeafLocked 以读锁耦合的方式下降到 key 所在的叶子节点并返回
+// leafWrite 为 true 时返回的叶子节点持有写锁，否则持有读锁
+func (tree *ConcurrentBPlusTree[K, V]) findLeafLocked(key K, leafWrite bool) *latchNode[K, V] {
+	n := tree.lockRoot(leafWrite)
+	for !n.isLeaf {
+		child := n.children[n.childIndex(key)]
+		lockNode(child, leafWrite)
+		n.mu.RUnlock()
+		n = child
+	}
+	return n
+}
+
+// Search 查找键对应的值
+// 时间复杂度: O(log n)
+func (tree *ConcurrentBPlusTree[K, V]) Search(key K) (V, bool) {
+	leaf := tree.findLeafLocked(key, false)
+	defer leaf.mu.RUnlock()
+	if pos, found := slices.BinarySearch(leaf.keys, key); found {
+		return leaf.values[pos], true
+	}
+	var zero V
+	return zero, false
+}
+
+// Insert 插入键值对，键已存在时更新值
+// 时间复杂度: O(order·log n)
+func (tree *ConcurrentBPlusTree[K, V]) Insert(key K, value V) {
+	// 乐观路径：大多数插入不会引起分裂，只需要锁住叶子节点
+	leaf := tree.findLeafLocked(key, true)
+	pos, found := slices.BinarySearch(leaf.keys, key)
+	if found || len(leaf.keys) < tree.order-1 {
+		tree.insertIntoLeaf(leaf, pos, found, key, value)
+		leaf.mu.Unlock()
+		return
+	}
+	leaf.mu.Unlock()
+	tree.insertPessimistic(key, value)
+}
+
+// insertIntoLeaf 在已加写锁的叶子节点的 pos 处插入或更新键值对
+func (tree *ConcurrentBPlusTree[K, V]) insertIntoLeaf(leaf *latchNode[K, V], pos int, found bool, key K, value V) {
+	if found {
+		leaf.values[pos] = value
+		return
+	}
+	leaf.keys = slices.Insert(leaf.keys, pos, key)
+	leaf.values = slices.Insert(leaf.values, pos, value)
+	tree.size.Add(1)
+}
+
+// latchStep 悲观下降时持有写锁的节点及下降时选择的子节点下标
+type latchStep[K constraints.Ordered, V any] struct {
+	node *latchNode[K, V]
+	idx  int
+}
+
+// latchPath 悲观下降时仍持有写锁的节点，从上到下排列
+// rootLocked 为 true 时还持有 tree.mu 的写锁，此时 steps[0] 是根节点，可以替换 root 指针
+type latchPath[K constraints.Ordered, V any] struct {
+	tree       *ConcurrentBPlusTree[K, V]
+	steps      []latchStep[K, V]
+	rootLocked bool
+}
+
+// release 释放路径上的所有锁
+func (p *latchPath[K, V]) release() {
+	if p.rootLocked {
+		p.tree.mu.Unlock()
+		p.rootLocked = false
+	}
+	for _, step := range p.steps {
+		step.node.mu.Unlock()
+	}
+	p.steps = p.steps[:0]
+}
+
+// lockPath 从根节点开始以写锁下降到 key 所在的叶子节点
+// safe 判断节点在本次修改后是否一定不会影响父节点，遇到这样的子节点时释放它的所有祖先节点的锁；
+// rootSafe 判断根节点是否一定不会被替换，成立时立即释放 tree.mu
+func (tree *ConcurrentBPlusTree[K, V]) lockPath(key K, rootSafe, safe func(n *latchNode[K, V]) bool) *latchPath[K, V] {
+	tree.mu.Lock()
+	n := tree.root
+	n.mu.Lock()
+	p := &latchPath[K, V]{tree: tree, steps: []latchStep[K, V]{{node: n}}, rootLocked: true}
+	if rootSafe(n) {
+		tree.mu.Unlock()
+		p.rootLocked = false
+	}
+	for !n.isLeaf {
+		idx := n.childIndex(key)
+		p.steps[len(p.steps)-1].idx = idx
+		child := n.children[idx]
+		child.mu.Lock()
+		if safe(child) {
+			p.release()
+		}
+		p.steps = append(p.steps, latchStep[K, V]{node: child})
+		n = child
+	}
+	return p
+}
+
+// insertPessimistic 从根节点开始以写锁下降并插入
+// 子节点插入后不会分裂（键数量小于 order-1）时，它的祖先节点不会被修改，立即释放这些祖先节点的锁
+func (tree *ConcurrentBPlusTree[K, V]) insertPessimistic(key K, value V) {
+	notFull := func(n *latchNode[K, V]) bool { return len(n.keys) < tree.order-1 }
+	p := tree.lockPath(key, notFull, notFull)
+	defer p.release()
+	leaf := p.steps[len(p.steps)-1].node
+	pos, found := slices.BinarySearch(leaf.keys, key)
+	tree.insertIntoLeaf(leaf, pos, found, key, value)
+	if len(leaf.keys) >= tree.order {
+		tree.splitLeaf(p.steps)
+	}
+}
+
+// splitLeaf 分裂 held 末尾的叶子节点，held 中的节点与必要时的 root 指针都已持有写锁
+func (tree *ConcurrentBPlusTree[K, V]) splitLeaf(held []latchStep[K, V]) {
+	leaf := held[len(held)-1].node
+	mid := (len(leaf.keys) + 1) / 2
+	right := &latchNode[K, V]{
+		isLeaf: true,
+		keys:   slices.Clone(leaf.keys[mid:]),
+		values: slices.Clone(leaf.values[mid:]),
+		next:   leaf.next,
+	}
+	leaf.keys = leaf.keys[:mid]
+	leaf.values = leaf.values[:mid]
+	leaf.next = right
+	tree.insertIntoParent(held[:len(held)-1], leaf, right.keys[0], right)
+}
+
+// insertIntoParent 把分裂得到的右侧节点插入 held 末尾的父节点，held 为空时创建新的根节点
+func (tree *ConcurrentBPlusTree[K, V]) insertIntoParent(held []latchStep[K, V], left *latchNode[K, V], key K, right *latchNode[K, V]) {
+	if len(held) == 0 {
+		tree.root = &latchNode[K, V]{
+			keys:     []K{key},
+			children: []*latchNode[K, V]{left, right},
+		}
+		return
+	}
+	step := held[len(held)-1]
+	parent := step.node
+	parent.keys = slices.Insert(parent.keys, step.idx, key)
+	parent.children = slices.Insert(parent.children, step.idx+1, right)
+	if len(parent.keys) < tree.order {
+		return
+	}
+	mid := len(parent.keys) / 2
+	promote := parent.keys[mid]
+	sibling := &latchNode[K, V]{
+		keys:     slices.Clone(parent.keys[mid+1:]),
+		children: slices.Clone(parent.children[mid+1:]),
+	}
+	parent.keys = parent.keys[:mid]
+	parent.children = parent.children[:mid+1]
+	tree.insertIntoParent(held[:len(held)-1], parent, promote, sibling)
+}
+
+// Delete 删除键及其对应的值，返回键是否存在
+// 先乐观地只对叶子节点加写锁，删除后叶子节点不少于下限时直接完成；
+// 否则从根节点开始以写锁重新下降，删除后通过借键或合并恢复平衡
+// 时间复杂度: O(order·log n)
+func (tree *ConcurrentBPlusTree[K, V]) Delete(key K) bool {
+	// 乐观路径：大多数删除不会引起合并，只需要锁住叶子节点
+	leaf := tree.findLeafLocked(key, true)
+	pos, found := slices.BinarySearch(leaf.keys, key)
+	if !found || len(leaf.keys) > tree.minKeys() {
+		if found {
+			tree.deleteFromLeaf(leaf, pos)
+		}
+		leaf.mu.Unlock()
+		return found
+	}
+	leaf.mu.Unlock()
+	return tree.deletePessimistic(key)
+}
+
+// minKeys 返回非根节点至少包含的键数量
+func (tree *ConcurrentBPlusTree[K, V]) minKeys() int {
+	return (tree.order - 1) / 2
+}
+
+// deleteFromLeaf 删除已加写锁的叶子节点 pos 处的键值对
+func (tree *ConcurrentBPlusTree[K, V]) deleteFromLeaf(leaf *latchNode[K, V], pos int) {
+	leaf.keys = slices.Delete(leaf.keys, pos, pos+1)
+	leaf.values = slices.Delete(leaf.values, pos, pos+1)
+	tree.size.Add(-1)
+}
+
+// deletePessimistic 从根节点开始以写锁下降并删除
+// 子节点删除一个键后仍不少于下限（键数量大于 minKeys）时，它的祖先节点不会被修改，立即释放这些祖先节点的锁；
+// 根节点只有在内部节点仅剩一个键时才可能被替换
+func (tree *ConcurrentBPlusTree[K, V]) deletePessimistic(key K) bool {
+	p := tree.lockPath(key,
+		func(n *latchNode[K, V]) bool { return n.isLeaf || len(n.keys) > 1 },
+		func(n *latchNode[K, V]) bool { return len(n.keys) > tree.minKeys() })
+	defer p.release()
+	leaf := p.steps[len(p.steps)-1].node
+	pos, found := slices.BinarySearch(leaf.keys, key)
+	if !found {
+		return false
+	}
+	tree.deleteFromLeaf(leaf, pos)
+	tree.rebalance(p)
+	return true
+}
+
+// rebalance 从路径末尾的叶子节点向上恢复平衡
+// 路径上可能少于下限的节点及其父节点都已持有写锁，兄弟节点在父节点的写锁下加锁，
+// 其他操作只能经过父节点到达兄弟节点，因此不会与自上而下的加锁顺序形成死锁
+func (tree *ConcurrentBPlusTree[K, V]) rebalance(p *latchPath[K, V]) {
+	for i := len(p.steps) - 1; i > 0; i-- {
+		if len(p.steps[i].node.keys) >= tree.minKeys() {
+			return
+		}
+		if !tree.fixUnderflow(p.steps[i-1].node, p.steps[i-1].idx) {
+			return
+		}
+	}
+	// 根节点可以少于下限，但内部根节点没有键时只剩一个子节点，树的高度减一
+	root := p.steps[0].node
+	if p.rootLocked && !root.isLeaf && len(root.keys) == 0 {
+		tree.root = root.children[0]
+	}
+}
+
+// fixUnderflow 通过借键或合并恢复 parent 第 idx 个子节点的下限，返回父节点是否因合并减少了一个键
+// 优先与左兄弟调整，只有第一个子节点才与右兄弟调整，每次只多锁一个兄弟节点
+func (tree *ConcurrentBPlusTree[K, V]) fixUnderflow(parent *latchNode[K, V], idx int) bool {
+	if idx > 0 {
+		left := parent.children[idx-1]
+		left.mu.Lock()
+		defer left.mu.Unlock()
+		if len(left.keys) > tree.minKeys() {
+			tree.borrowFromLeft(parent, idx)
+			return false
+		}
+		tree.mergeChildren(parent, idx-1)
+		return true
+	}
+	right := parent.children[idx+1]
+	right.mu.Lock()
+	defer right.mu.Unlock()
+	if len(right.keys) > tree.minKeys() {
+		tree.borrowFromRight(parent, idx)
+		return false
+	}
+	tree.mergeChildren(parent, idx)
+	return true
+}
+
+// borrowFromLeft 把 parent 第 idx-1 个子节点的最后一个键移动到第 idx 个子节点，并更新分隔键
+// parent 与两个子节点都已持有写锁
+func (tree *ConcurrentBPlusTree[K, V]) borrowFromLeft(parent *latchNode[K, V], idx int) {
+	node, left := parent.children[idx], parent.children[idx-1]
+	last := len(left.keys) - 1
+	if node.isLeaf {
+		tree.moves.Add(1)
+		node.keys = slices.Insert(node.keys, 0, left.keys[last])
+		node.values = slices.Insert(node.values, 0, left.values[last])
+		left.keys = left.keys[:last]
+		left.values = left.values[:last]
+		parent.keys[idx-1] = node.keys[0]
+		return
+	}
+	// 内部节点借键时，父节点的分隔键下移，左兄弟的最后一个键上移
+	node.keys = slices.Insert(node.keys, 0, parent.keys[idx-1])
+	node.children = slices.Insert(node.children, 0, left.children[last+1])
+	parent.keys[idx-1] = left.keys[last]
+	left.keys = left.keys[:last]
+	left.children = left.children[:last+1]
+}
+
+// borrowFromRight 把 parent 第 idx+1 个子节点的第一个键移动到第 idx 个子节点，并更新分隔键
+// parent 与两个子节点都已持有写锁
+func (tree *ConcurrentBPlusTree[K, V]) borrowFromRight(parent *latchNode[K, V], idx int) {
+	node, right := parent.children[idx], parent.children[idx+1]
+	if node.isLeaf {
+		tree.moves.Add(1)
+		node.keys = append(node.keys, right.keys[0])
+		node.values = append(node.values, right.values[0])
+		right.keys = slices.Delete(right.keys, 0, 1)
+		right.values = slices.Delete(right.values, 0, 1)
+		parent.keys[idx] = right.keys[0]
+		return
+	}
+	// 内部节点借键时，父节点的分隔键下移，右兄弟的第一个键上移
+	node.keys = append(node.keys, parent.keys[idx])
+	node.children = append(node.children, right.children[0])
+	parent.keys[idx] = right.keys[0]
+	right.keys = slices.Delete(right.keys, 0, 1)
+	right.children = slices.Delete(right.children, 0, 1)
+}
+
+// mergeChildren 把 parent 的第 idx+1 个子节点合并到第 idx 个子节点中，并从父节点删除两者之间的分隔键
+// parent 与两个子节点都已持有写锁；被合并的叶子节点同时从叶子链表中摘除，
+// 之后不再有节点指向它，由垃圾回收释放
+func (tree *ConcurrentBPlusTree[K, V]) mergeChildren(parent *latchNode[K, V], idx int) {
+	left, right := parent.children[idx], parent.children[idx+1]
+	if left.isLeaf {
+		tree.moves.Add(1)
+		left.keys = append(left.keys, right.keys...)
+		left.values = append(left.values, right.values...)
+		left.next = right.next
+	} else {
+		left.keys = append(left.keys, parent.keys[idx])
+		left.keys = append(left.keys, right.keys...)
+		left.children = append(left.children, right.children...)
+	}
+	parent.keys = slices.Delete(parent.keys, idx, idx+1)
+	parent.children = slices.Delete(parent.children, idx+1, idx+2)
+}
+
+// AscendRange 按键升序遍历区间 [start, end) 内的键值对，fn 返回 false 时停止遍历
+// 时间复杂度: O(log n + m)，m 为区间内的键值对数量
+func (tree *ConcurrentBPlusTree[K, V]) AscendRange(start, end K, fn func(key K, value V) bool) {
+	if start >= end {
+		return
+	}
+	tree.ascend(start, true, func(key K, value V) bool {
+		return key < end && fn(key, value)
+	})
+}
+
+// All 返回按键升序遍历所有键值对的迭代器
+func (tree *ConcurrentBPlusTree[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		var zero K
+		tree.ascend(zero, false, yield)
+	}
+}
+
+// seekLeaf 以读锁耦合的方式下降到 from 所在的叶子节点，bounded 为 false 时下降到最左侧的叶子节点
+// 返回的叶子节点持有读锁
+func (tree *ConcurrentBPlusTree[K, V]) seekLeaf(from K, bounded bool) *latchNode[K, V] {
+	if bounded {
+		return tree.findLeafLocked(from, false)
+	}
+	n := tree.lockRoot(false)
+	for !n.isLeaf {
+		child := n.children[0]
+		child.mu.RLock()
+		n.mu.RUnlock()
+		n = child
+	}
+	return n
+}
+
+// ascend 按键升序遍历不小于 from 的键值对（bounded 为 false 时从最小的键开始）
+// 每个叶子节点在持有读锁时复制键值对并读取 next，释放锁后再依次回调 fn；
+// 分裂只会把键移动到右侧新建的叶子节点，并由 next 指向它，因此沿 next 前进不会漏掉遍历开始前已有的键。
+// 借键与合并可能把键移到已经遍历过的叶子节点，或者使 next 指向已被合并的节点，
+// 因此移动到下一个叶子节点时如果期间发生过借键或合并，就从根节点重新下降到上次产出的键所在的叶子节点；
+// 不大于上次产出的键的键都会被跳过，每个键最多产出一次且保持升序
+func (tree *ConcurrentBPlusTree[K, V]) ascend(from K, bounded bool, fn func(key K, value V) bool) {
+	var keys []K
+	var values []V
+	inclusive := true
+	leaf := tree.seekLeaf(from, bounded)
+	for {
+		moves := tree.moves.Load()
+		keys = append(keys[:0], leaf.keys...)
+		values = append(values[:0], leaf.values...)
+		next := leaf.next
+		leaf.mu.RUnlock()
+
+		for i, key := range keys {
+			if bounded && (key < from || key == from && !inclusive) {
+				continue
+			}
+			if !fn(key, values[i]) {
+				return
+			}
+			from, bounded, inclusive = key, true, false
+		}
+		if next == nil {
+			return
+		}
+		next.mu.RLock()
+		if tree.moves.Load() != moves {
+			next.mu.RUnlock()
+			next = tree.seekLeaf(from, bounded)
+		}
+		leaf = next
+	}
+}
+
+// Len 返回键值对数量
+// 时间复杂度: O(1)
+func (tree *ConcurrentBPlusTree[K, V]) Len() int {
+	return int(tree.size.Load())
+}
+
+// Size 返回键值对数量，与 Len 相同
+// 时间复杂度: O(1)
+func (tree *ConcurrentBPlusTree[K, V]) Size() int {
+	return tree.Len()
+}
+
+// IsEmpty 检查树是否为空
+// 时间复杂度: O(1)
+func (tree *ConcurrentBPlusTree[K, V]) IsEmpty() bool {
+	return tree.Len() == 0
+}
+
+// Height 返回树的层数，即从根节点到叶子节点经过的节点数，空树为0
+// 时间复杂度: O(log n)
+func (tree *ConcurrentBPlusTree[K, V]) Height() int {
+	if tree.IsEmpty() {
+		return 0
+	}
+	height := 1
+	n := tree.lockRoot(false)
+	for !n.isLeaf {
+		child := n.children[0]
+		child.mu.RLock()
+		n.mu.RUnlock()
+		n = child
+		height++
+	}
+	n.mu.RUnlock()
+	return height
+}
+
+// Stats 遍历所有节点并返回树的结构统计，字段含义与 BPlusTree.Stats 相同
+// 访问子节点时一直持有父节点的读锁，但不同子树在不同时刻统计，并发修改时结果只是近似值
+// 时间复杂度: O(n)
+func (tree *ConcurrentBPlusTree[K, V]) Stats() Stats {
+	var s Stats
+	s.Height = tree.Height()
+	if s.Height == 0 {
+		return s
+	}
+	tree.collectStats(tree.lockRoot(false), &s)
+	s.AvgFill = float64(s.Keys) / float64(s.Nodes*(tree.order-1))
+	return s
+}
+
+// collectStats 把以已加读锁的 n 为根的子树的统计累加到 s，返回前释放 n 的读锁
+func (tree *ConcurrentBPlusTree[K, V]) collectStats(n *latchNode[K, V], s *Stats) {
+	defer n.mu.RUnlock()
+	var key K
+	var value V
+	s.Nodes++
+	s.Keys += len(n.keys)
+	s.MemoryBytes += unsafe.Sizeof(*n) + uintptr(cap(n.keys))*unsafe.Sizeof(key)
+	if n.isLeaf {
+		s.Leaves++
+		s.MemoryBytes += uintptr(cap(n.values)) * unsafe.Sizeof(value)
+		return
+	}
+	s.MemoryBytes += uintptr(cap(n.children)) * unsafe.Sizeof(n)
+	for _, child := range n.children {
+		child.mu.RLock()
+		tree.collectStats(child, s)
+	}
+}
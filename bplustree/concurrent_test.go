@@ -0,0 +1,315 @@
+package bplustree
+
+import (
+	"math/rand"
+	"slices"
+	"sync"
+	"testing"
+
+	"golang.org/x/exp/constraints"
+)
+
+// TestConcurrentBPlusTree 测试并发 B+ 树在单个 goroutine 中的行为与 map 一致
+func TestConcurrentBPlusTree(t *testing.T) {
+	tree := NewConcurrentBPlusTree[int, int](4)
+	if !tree.IsEmpty() {
+		t.Fatal("新建的树应为空")
+	}
+	rng := rand.New(rand.NewSource(1))
+	expected := make(map[int]int)
+	for range 5000 {
+		key := rng.Intn(1000)
+		if rng.Intn(4) == 0 {
+			_, want := expected[key]
+			if got := tree.Delete(key); got != want {
+				t.Fatalf("Delete(%d) = %v，期望 %v", key, got, want)
+			}
+			delete(expected, key)
+		} else {
+			tree.Insert(key, key*2)
+			expected[key] = key * 2
+		}
+	}
+	if tree.Len() != len(expected) || tree.Size() != len(expected) {
+		t.Fatalf("期望%d个键值对，实际为%d", len(expected), tree.Len())
+	}
+	for key, want := range expected {
+		if got, ok := tree.Search(key); !ok || got != want {
+			t.Fatalf("Search(%d) = (%d, %v)，期望 (%d, true)", key, got, ok, want)
+		}
+	}
+
+	var keys []int
+	for key := range tree.All() {
+		keys = append(keys, key)
+	}
+	if len(keys) != len(expected) || !slices.IsSorted(keys) {
+		t.Errorf("All 应按升序产出%d个键，实际产出%d个", len(expected), len(keys))
+	}
+
+	var inRange []int
+	tree.AscendRange(100, 200, func(key, _ int) bool {
+		inRange = append(inRange, key)
+		return true
+	})
+	var want []int
+	for _, key := range keys {
+		if key >= 100 && key < 200 {
+			want = append(want, key)
+		}
+	}
+	if !slices.Equal(inRange, want) {
+		t.Errorf("区间 [100, 200) 内的键为%v，期望%v", inRange, want)
+	}
+}
+
+// TestConcurrentBPlusTreeParallel 测试多个 goroutine 同时插入、删除、查找和遍历
+// 使用 go test -race 运行可以检查数据竞争
+func TestConcurrentBPlusTreeParallel(t *testing.T) {
+	tree := NewConcurrentBPlusTree[int, int](5)
+	const workers, perWorker = 8, 2000
+	var wg sync.WaitGroup
+	for w := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// 每个 goroutine 只写自己的键，最终结果是确定的
+			for i := range perWorker {
+				key := i*workers + w
+				tree.Insert(key, key)
+				if i%3 == 0 {
+					tree.Delete(key)
+				}
+				tree.Search(key / 2)
+			}
+		}()
+	}
+	for range 2 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 20 {
+				prev := -1
+				for key := range tree.All() {
+					if key <= prev {
+						t.Errorf("遍历的键没有严格递增: %d 位于 %d 之后", key, prev)
+						return
+					}
+					prev = key
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	want := 0
+	for i := range perWorker {
+		if i%3 != 0 {
+			want += workers
+		}
+	}
+	if tree.Len() != want {
+		t.Fatalf("期望%d个键值对，实际为%d", want, tree.Len())
+	}
+	checkConcurrentTree(t, tree)
+	count := 0
+	for key, value := range tree.All() {
+		if key != value || (key/workers)%3 == 0 {
+			t.Fatalf("不应存在键值对 (%d, %d)", key, value)
+		}
+		count++
+	}
+	if count != want {
+		t.Errorf("遍历到%d个键值对，期望%d", count, want)
+	}
+}
+
+// checkConcurrentTree 检查并发 B+ 树的结构：键有序、非根节点不少于下限、叶子节点深度相同、叶子链表与叶子节点的顺序一致
+// 只能在没有并发修改时调用
+func checkConcurrentTree[K constraints.Ordered, V any](t *testing.T, tree *ConcurrentBPlusTree[K, V]) {
+	t.Helper()
+	var leaves []*latchNode[K, V]
+	depth := -1
+	var walk func(n *latchNode[K, V], level int)
+	walk = func(n *latchNode[K, V], level int) {
+		if !slices.IsSorted(n.keys) {
+			t.Fatalf("节点的键没有排序: %v", n.keys)
+		}
+		if n != tree.root && len(n.keys) < tree.minKeys() {
+			t.Fatalf("非根节点只有%d个键，下限为%d", len(n.keys), tree.minKeys())
+		}
+		if n.isLeaf {
+			if depth >= 0 && depth != level {
+				t.Fatalf("叶子节点的深度不同: %d 与 %d", depth, level)
+			}
+			depth = level
+			leaves = append(leaves, n)
+			return
+		}
+		if len(n.children) != len(n.keys)+1 {
+			t.Fatalf("内部节点有%d个键和%d个子节点", len(n.keys), len(n.children))
+		}
+		for _, child := range n.children {
+			walk(child, level+1)
+		}
+	}
+	walk(tree.root, 0)
+	for i, leaf := range leaves {
+		var want *latchNode[K, V]
+		if i+1 < len(leaves) {
+			want = leaves[i+1]
+		}
+		if leaf.next != want {
+			t.Fatalf("第%d个叶子节点的 next 没有指向下一个叶子节点", i)
+		}
+	}
+}
+
+// TestConcurrentBPlusTreeDelete 测试删除时借键与合并，删除所有键后树收缩为单个叶子节点
+func TestConcurrentBPlusTreeDelete(t *testing.T) {
+	for _, order := range []int{3, 4, 5, 16} {
+		tree := NewConcurrentBPlusTree[int, int](order)
+		const n = 2000
+		for i := range n {
+			tree.Insert(i, i)
+		}
+		grown := tree.Stats()
+		if grown.Height < 3 || grown.Leaves < n/(order-1) {
+			t.Fatalf("阶数为%d时插入%d个键后的统计不符合预期: %+v", order, n, grown)
+		}
+
+		keys := rand.New(rand.NewSource(int64(order))).Perm(n)
+		for i, key := range keys[:n-1] {
+			if !tree.Delete(key) {
+				t.Fatalf("Delete(%d) 应返回 true", key)
+			}
+			if i%100 == 0 {
+				checkConcurrentTree(t, tree)
+			}
+		}
+		checkConcurrentTree(t, tree)
+		last := keys[n-1]
+		if got := tree.Stats(); got.Height != 1 || got.Nodes != 1 || got.Leaves != 1 || got.Keys != 1 {
+			t.Fatalf("阶数为%d时只剩一个键后应只有一个叶子节点，实际为 %+v", order, got)
+		}
+		if v, ok := tree.Search(last); !ok || v != last {
+			t.Fatalf("Search(%d) = (%d, %v)，期望 (%d, true)", last, v, ok, last)
+		}
+
+		if !tree.Delete(last) || !tree.IsEmpty() || tree.Height() != 0 {
+			t.Fatalf("阶数为%d时删除所有键后树应为空", order)
+		}
+		if !tree.root.isLeaf || len(tree.root.keys) != 0 {
+			t.Fatalf("阶数为%d时删除所有键后根节点应为空的叶子节点", order)
+		}
+		for range tree.All() {
+			t.Fatal("空树不应产出键值对")
+		}
+	}
+}
+
+// TestConcurrentBPlusTreeParallelDelete 测试并发删除引起借键与合并时，查找和遍历仍能看到未被删除的键
+func TestConcurrentBPlusTreeParallelDelete(t *testing.T) {
+	tree := NewConcurrentBPlusTree[int, int](4)
+	const workers, perWorker = 8, 1000
+	for key := range workers * perWorker * 2 {
+		tree.Insert(key, key)
+	}
+	var wg sync.WaitGroup
+	for w := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// 偶数键始终保留，每个 goroutine 删除自己的奇数键
+			for i := range perWorker {
+				key := 2*(i*workers+w) + 1
+				if !tree.Delete(key) {
+					t.Errorf("Delete(%d) 应返回 true", key)
+					return
+				}
+				if _, ok := tree.Search(key - 1); !ok {
+					t.Errorf("Search(%d) 应找到始终保留的键", key-1)
+					return
+				}
+			}
+		}()
+	}
+	for range 2 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 10 {
+				prev, evens := -1, 0
+				for key := range tree.All() {
+					if key <= prev {
+						t.Errorf("遍历的键没有严格递增: %d 位于 %d 之后", key, prev)
+						return
+					}
+					prev = key
+					if key%2 == 0 {
+						evens++
+					}
+				}
+				if evens != workers*perWorker {
+					t.Errorf("遍历到%d个始终保留的键，期望%d", evens, workers*perWorker)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if tree.Len() != workers*perWorker {
+		t.Fatalf("期望%d个键值对，实际为%d", workers*perWorker, tree.Len())
+	}
+	checkConcurrentTree(t, tree)
+	for key := range tree.All() {
+		if key%2 != 0 {
+			t.Fatalf("不应存在键 %d", key)
+		}
+	}
+}
+
+// BenchmarkConcurrentBPlusTree 比较节点锁耦合与全局读写锁在并行读写下的性能
+func BenchmarkConcurrentBPlusTree(b *testing.B) {
+	const keys = 1 << 16
+	b.Run("锁耦合", func(b *testing.B) {
+		tree := NewConcurrentBPlusTree[int, int](64)
+		for i := range keys {
+			tree.Insert(i, i)
+		}
+		b.RunParallel(func(pb *testing.PB) {
+			rng := rand.New(rand.NewSource(rand.Int63()))
+			for pb.Next() {
+				key := rng.Intn(keys)
+				if key%10 == 0 {
+					tree.Insert(key, key)
+				} else {
+					tree.Search(key)
+				}
+			}
+		})
+	})
+	b.Run("全局锁", func(b *testing.B) {
+		var mu sync.RWMutex
+		tree := NewBPlusTree[int, int](64)
+		for i := range keys {
+			tree.Insert(i, i)
+		}
+		b.RunParallel(func(pb *testing.PB) {
+			rng := rand.New(rand.NewSource(rand.Int63()))
+			for pb.Next() {
+				key := rng.Intn(keys)
+				if key%10 == 0 {
+					mu.Lock()
+					tree.Insert(key, key)
+					mu.Unlock()
+				} else {
+					mu.RLock()
+					tree.Search(key)
+					mu.RUnlock()
+				}
+			}
+		})
+	})
+}
@@ -0,0 +1,131 @@
+package bplustree
+
+// Cursor 沿叶子链表按键升序遍历 B+ 树的游标
+// 游标不复制数据，同一时刻只引用一个叶子节点，适合流式遍历大量键值对。
+// 使用方式：
+//
+//	c := tree.Cursor()
+//	c.Seek(from)
+//	for c.Next() {
+//		key, value := c.Key(), c.Value()
+//	}
+//
+// Cursor 同时满足 iterator.Iterator[V] 接口。
+//
+// 游标也可以像 LevelDB、Pebble 的迭代器那样双向移动：First、Last、SeekGE 把游标直接定位到某个键上，
+// 之后 Next 与 Prev 沿叶子链表前进或后退，Valid 报告游标当前是否位于某个键上：
+//
+//	for ok := c.SeekGE(from); ok; ok = c.Next() {
+//		key, value := c.Key(), c.Value()
+//	}
+//	for ok := c.Last(); ok; ok = c.Prev() {
+//		key, value := c.Key(), c.Value()
+//	}
+//
+// 创建游标之后插入或删除键会使游标失效，此后只能调用 SeekFirst、Seek、First、Last 或 SeekGE 重新定位
+type Cursor[K any, V any] struct {
+	tree    *BPlusTree[K, V] // 游标所属的树
+	leaf    *TreeNode[K, V]  // 当前所在的叶子节点，为 nil 表示遍历已结束
+	pos     int              // 当前键在叶子节点中的下标
+	pending bool             // 为 true 时下一次 Next 停留在当前位置而不前进
+}
+
+// Cursor 返回位于最小的键之前的游标，第一次调用 Next 后指向最小的键
+// 时间复杂度: O(log n)
+func (tree *BPlusTree[K, V]) Cursor() *Cursor[K, V] {
+	c := &Cursor[K, V]{tree: tree}
+	c.SeekFirst()
+	return c
+}
+
+// SeekFirst 把游标移动到最小的键之前
+// 时间复杂度: O(log n)
+func (c *Cursor[K, V]) SeekFirst() {
+	c.leaf, c.pos, c.pending = c.tree.firstLeaf(), 0, true
+}
+
+// Seek 把游标移动到第一个大于等于 key 的键之前，下一次调用 Next 后指向该键
+// 时间复杂度: O(log n)
+func (c *Cursor[K, V]) Seek(key K) {
+	c.leaf, c.pos = c.tree.seek(key)
+	c.pending = true
+}
+
+// First 把游标移动到最小的键上，树为空时返回 false
+// 时间复杂度: O(log n)
+func (c *Cursor[K, V]) First() bool {
+	c.SeekFirst()
+	return c.Next()
+}
+
+// Last 把游标移动到最大的键上，树为空时返回 false
+// 时间复杂度: O(log n)
+func (c *Cursor[K, V]) Last() bool {
+	c.leaf, c.pending = c.tree.lastLeaf(), false
+	c.pos = len(c.leaf.keys) - 1
+	if c.pos < 0 {
+		c.leaf = nil
+	}
+	return c.leaf != nil
+}
+
+// SeekGE 把游标移动到第一个大于等于 key 的键上，不存在这样的键时返回 false
+// 与 Seek 不同，调用后游标已经位于该键上，不需要先调用 Next
+// 时间复杂度: O(log n)
+func (c *Cursor[K, V]) SeekGE(key K) bool {
+	c.Seek(key)
+	return c.Next()
+}
+
+// Valid 检查游标当前是否位于某个键上，为 true 时才能调用 Key 和 Value
+func (c *Cursor[K, V]) Valid() bool {
+	return c.leaf != nil && !c.pending
+}
+
+// Next 前进到下一个键，没有更多键时返回 false
+// 时间复杂度: 均摊 O(1)
+func (c *Cursor[K, V]) Next() bool {
+	if c.leaf == nil {
+		return false
+	}
+	if c.pending {
+		c.pending = false
+	} else {
+		c.pos++
+	}
+	// 越过当前叶子节点的末尾时沿 next 指针进入下一个叶子节点，空的根叶子节点也在这里结束
+	for c.leaf != nil && c.pos >= len(c.leaf.keys) {
+		c.leaf, c.pos = c.leaf.next, 0
+	}
+	return c.leaf != nil
+}
+
+// Prev 后退到上一个键，没有更多键时返回 false
+// 游标位于 Seek 或 SeekFirst 定位的位置之前时，后退到该位置之前的最后一个键；
+// 游标已经无效（例如 Next 或 Prev 返回了 false）时返回 false
+// 时间复杂度: 均摊 O(1)
+func (c *Cursor[K, V]) Prev() bool {
+	if c.leaf == nil {
+		return false
+	}
+	c.pending = false
+	c.pos--
+	// 越过当前叶子节点的开头时沿 prev 指针进入上一个叶子节点
+	for c.leaf != nil && c.pos < 0 {
+		c.leaf = c.leaf.prev
+		if c.leaf != nil {
+			c.pos = len(c.leaf.keys) - 1
+		}
+	}
+	return c.leaf != nil
+}
+
+// Key 返回当前的键，必须在 Valid 为 true 时（例如 Next 返回 true 之后）调用
+func (c *Cursor[K, V]) Key() K {
+	return c.tree.keyAt(c.leaf, c.pos)
+}
+
+// Value 返回当前的值，必须在 Valid 为 true 时（例如 Next 返回 true 之后）调用
+func (c *Cursor[K, V]) Value() V {
+	return c.leaf.values[c.pos]
+}
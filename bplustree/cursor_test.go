@@ -0,0 +1,200 @@
+package bplustree
+
+import (
+	"slices"
+	"testing"
+
+	"godatastructure/iterator"
+)
+
+// Cursor 满足拉取式迭代器接口
+var _ iterator.Iterator[int] = (*Cursor[int, int])(nil)
+
+// collectKeys 从游标的当前位置开始收集剩余的键
+func collectKeys(c *Cursor[int, int]) []int {
+	var keys []int
+	for c.Next() {
+		keys = append(keys, c.Key())
+	}
+	return keys
+}
+
+// TestCursor 测试游标的遍历与定位
+func TestCursor(t *testing.T) {
+	tree := NewBPlusTree[int, int](3)
+	var all []int
+	for i := 0; i < 60; i += 3 {
+		tree.Insert(i, i*10)
+		all = append(all, i)
+	}
+
+	t.Run("遍历所有键", func(t *testing.T) {
+		c := tree.Cursor()
+		var keys []int
+		for c.Next() {
+			if c.Value() != c.Key()*10 {
+				t.Fatalf("键%d对应的值为%d", c.Key(), c.Value())
+			}
+			keys = append(keys, c.Key())
+		}
+		if !slices.Equal(keys, all) {
+			t.Errorf("遍历结果为 %v", keys)
+		}
+		if c.Next() {
+			t.Error("遍历结束后 Next 应继续返回false")
+		}
+	})
+
+	t.Run("Seek", func(t *testing.T) {
+		c := tree.Cursor()
+		tests := []struct {
+			key   int
+			first int
+			count int
+		}{
+			{-5, 0, 20},
+			{0, 0, 20},
+			{10, 12, 16},
+			{12, 12, 16},
+			{57, 57, 1},
+			{58, 0, 0},
+		}
+		for _, tt := range tests {
+			c.Seek(tt.key)
+			keys := collectKeys(c)
+			if len(keys) != tt.count || (tt.count > 0 && keys[0] != tt.first) {
+				t.Errorf("Seek(%d) 后得到 %v", tt.key, keys)
+			}
+		}
+	})
+
+	t.Run("遍历中途重新定位", func(t *testing.T) {
+		c := tree.Cursor()
+		c.Next()
+		c.Next()
+		c.SeekFirst()
+		if !c.Next() || c.Key() != 0 {
+			t.Error("SeekFirst 后应回到最小的键")
+		}
+		c.Seek(30)
+		if got := collectKeys(c); !slices.Equal(got, all[10:]) {
+			t.Errorf("Seek(30) 后得到 %v", got)
+		}
+	})
+
+	t.Run("空树", func(t *testing.T) {
+		empty := NewBPlusTree[int, int](3)
+		c := empty.Cursor()
+		if c.Next() {
+			t.Error("空树的游标 Next 应返回false")
+		}
+		c.Seek(1)
+		if c.Next() {
+			t.Error("空树 Seek 后 Next 应返回false")
+		}
+	})
+
+	t.Run("删除后的空叶子", func(t *testing.T) {
+		tree := NewBPlusTree[int, int](4)
+		for i := 0; i < 100; i++ {
+			tree.Insert(i, i)
+		}
+		for i := 0; i < 100; i++ {
+			if i%10 != 0 {
+				tree.Delete(i)
+			}
+		}
+		c := tree.Cursor()
+		c.Seek(1)
+		if got := collectKeys(c); !slices.Equal(got, []int{10, 20, 30, 40, 50, 60, 70, 80, 90}) {
+			t.Errorf("删除后游标遍历结果为 %v", got)
+		}
+	})
+}
+
+// TestCursorBidirectional 测试游标的双向移动
+func TestCursorBidirectional(t *testing.T) {
+	tree := NewBPlusTree[int, int](3)
+	var all []int
+	for i := 0; i < 60; i += 3 {
+		tree.Insert(i, i*10)
+		all = append(all, i)
+	}
+
+	t.Run("逆序遍历", func(t *testing.T) {
+		c := tree.Cursor()
+		var keys []int
+		for ok := c.Last(); ok; ok = c.Prev() {
+			if !c.Valid() || c.Value() != c.Key()*10 {
+				t.Fatalf("键%d对应的值为%d", c.Key(), c.Value())
+			}
+			keys = append(keys, c.Key())
+		}
+		slices.Reverse(keys)
+		if !slices.Equal(keys, all) {
+			t.Errorf("逆序遍历结果为%v", keys)
+		}
+		if c.Valid() || c.Prev() {
+			t.Error("越过开头后游标应无效")
+		}
+	})
+
+	t.Run("SeekGE", func(t *testing.T) {
+		c := tree.Cursor()
+		if !c.SeekGE(7) || c.Key() != 9 {
+			t.Fatalf("SeekGE(7) 应定位到9")
+		}
+		if !c.SeekGE(9) || c.Key() != 9 {
+			t.Fatalf("SeekGE(9) 应定位到9")
+		}
+		if !c.Next() || c.Key() != 12 || !c.Prev() || c.Key() != 9 || !c.Prev() || c.Key() != 6 {
+			t.Fatal("Next 与 Prev 应在相邻的键之间移动")
+		}
+		if c.SeekGE(58) || c.Valid() {
+			t.Error("SeekGE 超过最大的键时应返回 false")
+		}
+		if !c.SeekGE(-5) || c.Key() != 0 || c.Prev() {
+			t.Error("SeekGE 小于最小的键时应定位到最小的键，且之前没有键")
+		}
+	})
+
+	t.Run("来回移动", func(t *testing.T) {
+		c := tree.Cursor()
+		if !c.First() || c.Key() != 0 {
+			t.Fatal("First 应定位到最小的键")
+		}
+		for i := 1; i < len(all); i++ {
+			if !c.Next() || c.Key() != all[i] {
+				t.Fatalf("第%d次 Next 应定位到%d", i, all[i])
+			}
+			if !c.Prev() || c.Key() != all[i-1] || !c.Next() {
+				t.Fatalf("在%d处后退再前进失败", all[i])
+			}
+		}
+		if c.Next() || c.Valid() {
+			t.Error("越过末尾后游标应无效")
+		}
+	})
+
+	t.Run("Seek 之后后退", func(t *testing.T) {
+		c := tree.Cursor()
+		c.Seek(10)
+		if c.Valid() {
+			t.Error("Seek 之后游标位于键之前，应无效")
+		}
+		if !c.Prev() || c.Key() != 9 {
+			t.Error("Seek(10) 之后 Prev 应定位到9")
+		}
+	})
+
+	t.Run("空树", func(t *testing.T) {
+		c := NewBPlusTree[int, int](3).Cursor()
+		if c.First() || c.Last() || c.SeekGE(0) || c.Valid() {
+			t.Error("空树的游标应无效")
+		}
+		c.SeekFirst()
+		if c.Prev() {
+			t.Error("空树的游标不能后退")
+		}
+	})
+}
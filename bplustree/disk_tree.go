@@ -0,0 +1,839 @@
+package bplustree
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"slices"
+
+	"godatastructure/cache"
+	"godatastructure/codec"
+	"golang.org/x/exp/constraints"
+)
+
+// diskMagic 元数据页开头的标识
+const diskMagic = "BPT1"
+
+// 节点页的类型标记，位于每页的第一个字节
+const (
+	pageLeaf     byte = 1 // 叶子节点
+	pageInternal byte = 2 // 内部节点
+	pageFree     byte = 3 // 已回收的空闲页
+)
+
+const (
+	metaSize       = 24 // 元数据页使用的字节数
+	nodeHeaderSize = 5  // 节点页的类型标记与键数量
+	leafHeaderSize = 13 // 叶子节点页的类型标记、键数量以及前后叶子的页号
+)
+
+var (
+	ErrEntryTooLarge = errors.New("键值对编码后超过单个条目允许的大小")
+	ErrWALAttached   = errors.New("已经关联了预写日志")
+)
+
+// diskNode DiskTree 缓存在内存中的节点
+// 子节点与相邻叶子通过页号引用，页号0为元数据页，因此0表示没有对应的节点
+type diskNode[K constraints.Ordered, V any] struct {
+	id       PageID   // 节点所在的页号
+	isLeaf   bool     // 是否为叶子节点
+	free     bool     // 是否为已回收的空闲页，此时 next 为下一个空闲页
+	keys     []K      // 键数组
+	values   []V      // 值数组（仅对叶子节点有效）
+	children []PageID // 子节点页号数组（仅对非叶子节点有效）
+	prev     PageID   // 上一个叶子节点的页号
+	next     PageID   // 下一个叶子节点的页号
+}
+
+// diskStep 从根节点下降时经过的内部节点及所选子节点的下标
+type diskStep[K constraints.Ordered, V any] struct {
+	node *diskNode[K, V]
+	idx  int
+}
+
+// DiskTree 保存在 Pager 中的 B+ 树，可以作为简单的存储引擎
+// 第0页为元数据页，记录页大小、阶数、根节点页号、空闲页链表和键值对数量；其余每页保存一个节点，
+// 键和值使用 codec.For 返回的编解码器编码。读取过的节点缓存在内存中，修改只作用于缓存，
+// 调用 Flush 或 Close 时才把修改过的节点写回 Pager；删除导致合并时释放的页会加入空闲页链表，供之后分配新节点时复用。
+// 通过 AttachWAL 关联预写日志后，每次修改先追加到日志，Flush 成功后清空日志，
+// 因此上次 Flush 之后的修改在崩溃后可以通过重放日志恢复。
+// 节点缓存默认不限制容量，可以通过 WithCacheSize 限制为 LRU 缓存：操作过程中访问的节点以及尚未写回的节点被固定，
+// 其余节点按最近最少使用的顺序淘汰，之后再访问时重新从 Pager 读取。DiskTree 不支持并发使用
+type DiskTree[K constraints.Ordered, V any] struct {
+	pager      Pager
+	order      int                        // 树的阶数
+	root       PageID                     // 根节点页号
+	size       int                        // 键值对数量
+	freeHead   PageID                     // 空闲页链表的第一页，0 表示没有空闲页
+	pageCount  int                        // 已分配的页数量，包括尚未写回的新页
+	entryLimit int                        // 单个键值对编码后允许的最大字节数
+	cache      *nodeCache[K, V]           // 已读取的节点
+	dirty      map[PageID]*diskNode[K, V] // 修改后尚未写回的节点，写回之前一直被固定在缓存中
+	pinned     []PageID                   // 当前操作固定的节点，操作结束时由 release 撤销
+	metaDirty  bool                       // 元数据是否需要写回
+	keyCodec   codec.Codec[K]             // 键编解码器
+	valueCodec codec.Codec[V]             // 值编解码器
+	buf        []byte                     // 读写页面的缓冲区
+	scratch    []byte                     // 检查条目大小时使用的缓冲区
+	wal        *WAL[K, V]                 // 预写日志，为 nil 时不记录
+}
+
+// DiskOption DiskTree 的可选配置
+type DiskOption func(*diskOptions)
+
+// diskOptions DiskTree 的配置项
+type diskOptions struct {
+	cacheSize int // 节点缓存的容量，0 表示不限制
+}
+
+// WithCacheSize 把 DiskTree 的节点缓存限制为最多 n 个节点的 LRU 缓存，n 小于等于0时不限制
+// 单次操作需要同时固定的节点（从根节点到叶子节点的路径及其兄弟节点）以及尚未写回的节点不会被淘汰，
+// 因此缓存可能暂时超过容量
+func WithCacheSize(n int) DiskOption {
+	return func(o *diskOptions) {
+		o.cacheSize = max(n, 0)
+	}
+}
+
+// OpenDiskTree 在 Pager 上打开 B+ 树
+// Pager 为空时按给定阶数创建新树并立即写回；否则从元数据页读取已有的树，此时 order 被忽略
+// 参数：
+//   - p: 页存储
+//   - order: 创建新树时使用的阶数，必须大于等于3
+//   - opts: 可选配置，例如 WithCacheSize
+//
+// 返回：
+//   - *DiskTree[K, V]: 打开的 B+ 树
+//   - error: 读写页面失败、元数据页损坏或页大小不足以容纳一个节点时返回的错误
+func OpenDiskTree[K constraints.Ordered, V any](p Pager, order int, opts ...DiskOption) (*DiskTree[K, V], error) {
+	var o diskOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	tree := &DiskTree[K, V]{
+		pager:      p,
+		cache:      newNodeCache[K, V](o.cacheSize),
+		dirty:      make(map[PageID]*diskNode[K, V]),
+		keyCodec:   codec.For[K](),
+		valueCodec: codec.For[V](),
+		buf:        make([]byte, p.PageSize()),
+	}
+	if p.PageCount() == 0 {
+		if order < 3 {
+			panic("阶数必须至少为3")
+		}
+		tree.order = order
+		if err := tree.computeLimit(); err != nil {
+			return nil, err
+		}
+		// 先写入元数据页，使根节点分配到第1页
+		tree.pageCount = 1
+		if err := tree.writeMeta(); err != nil {
+			return nil, err
+		}
+		root, err := tree.allocNode()
+		if err != nil {
+			return nil, err
+		}
+		root.isLeaf = true
+		tree.root = root.id
+		tree.metaDirty = true
+		tree.release()
+		return tree, tree.Flush()
+	}
+	tree.pageCount = p.PageCount()
+	if err := tree.readMeta(); err != nil {
+		return nil, err
+	}
+	return tree, tree.computeLimit()
+}
+
+// computeLimit 计算单个键值对允许的最大字节数
+// 非根节点最多保存 order-1 个键，内部节点还要保存 order 个子节点页号；
+// 每个条目不超过该限制时，任何节点编码后都能放进一页
+func (tree *DiskTree[K, V]) computeLimit() error {
+	size := tree.pager.PageSize()
+	if size < metaSize {
+		return fmt.Errorf("页大小%d小于元数据所需的%d字节", size, metaSize)
+	}
+	tree.entryLimit = (size - nodeHeaderSize - 4*tree.order) / (tree.order - 1)
+	if tree.entryLimit < 2 {
+		return fmt.Errorf("页大小%d不足以容纳阶数为%d的节点", size, tree.order)
+	}
+	return nil
+}
+
+// readMeta 从第0页读取元数据
+func (tree *DiskTree[K, V]) readMeta() error {
+	if err := tree.pager.ReadPage(0, tree.buf); err != nil {
+		return err
+	}
+	if string(tree.buf[:4]) != diskMagic {
+		return fmt.Errorf("元数据页: %w", codec.ErrFormat)
+	}
+	if pageSize := int(binary.LittleEndian.Uint32(tree.buf[4:])); pageSize != tree.pager.PageSize() {
+		return fmt.Errorf("文件的页大小为%d，与页存储的页大小%d不一致", pageSize, tree.pager.PageSize())
+	}
+	tree.order = int(binary.LittleEndian.Uint32(tree.buf[8:]))
+	tree.root = PageID(binary.LittleEndian.Uint32(tree.buf[12:]))
+	tree.freeHead = PageID(binary.LittleEndian.Uint32(tree.buf[16:]))
+	tree.size = int(binary.LittleEndian.Uint32(tree.buf[20:]))
+	if tree.order < 3 || tree.root == 0 || int(tree.root) >= tree.pageCount {
+		return fmt.Errorf("元数据页: %w", codec.ErrFormat)
+	}
+	return nil
+}
+
+// writeMeta 把元数据写入第0页
+func (tree *DiskTree[K, V]) writeMeta() error {
+	clear(tree.buf)
+	copy(tree.buf, diskMagic)
+	binary.LittleEndian.PutUint32(tree.buf[4:], uint32(tree.pager.PageSize()))
+	binary.LittleEndian.PutUint32(tree.buf[8:], uint32(tree.order))
+	binary.LittleEndian.PutUint32(tree.buf[12:], uint32(tree.root))
+	binary.LittleEndian.PutUint32(tree.buf[16:], uint32(tree.freeHead))
+	binary.LittleEndian.PutUint32(tree.buf[20:], uint32(tree.size))
+	if err := tree.pager.WritePage(0, tree.buf); err != nil {
+		return err
+	}
+	tree.metaDirty = false
+	return nil
+}
+
+// node 返回第 id 页的节点，不在缓存中时从 Pager 读取并解码
+// 返回的节点在当前操作结束（调用 release）之前被固定在缓存中
+func (tree *DiskTree[K, V]) node(id PageID) (*diskNode[K, V], error) {
+	n, ok := tree.cache.get(id)
+	if !ok {
+		if err := tree.pager.ReadPage(id, tree.buf); err != nil {
+			return nil, err
+		}
+		var err error
+		if n, err = tree.decodeNode(id, tree.buf); err != nil {
+			return nil, fmt.Errorf("第%d页: %w", id, err)
+		}
+		tree.cache.put(n)
+	}
+	tree.pin(id)
+	return n, nil
+}
+
+// pin 在当前操作结束之前固定第 id 页的节点
+func (tree *DiskTree[K, V]) pin(id PageID) {
+	tree.cache.pin(id)
+	tree.pinned = append(tree.pinned, id)
+}
+
+// release 撤销当前操作固定的所有节点，并淘汰超过缓存容量的节点
+func (tree *DiskTree[K, V]) release() {
+	for _, id := range tree.pinned {
+		tree.cache.unpin(id)
+	}
+	tree.pinned = tree.pinned[:0]
+	tree.cache.evict()
+}
+
+// markDirty 标记节点已被修改，Flush 时写回；写回之前节点一直被固定在缓存中
+func (tree *DiskTree[K, V]) markDirty(n *diskNode[K, V]) {
+	if _, ok := tree.dirty[n.id]; !ok {
+		tree.cache.pin(n.id)
+	}
+	tree.dirty[n.id] = n
+}
+
+// allocNode 分配一个空节点，优先复用空闲页链表中的页，否则在末尾追加新页
+func (tree *DiskTree[K, V]) allocNode() (*diskNode[K, V], error) {
+	var id PageID
+	if tree.freeHead != 0 {
+		free, err := tree.node(tree.freeHead)
+		if err != nil {
+			return nil, err
+		}
+		if !free.free {
+			return nil, fmt.Errorf("空闲页链表中的第%d页: %w", free.id, codec.ErrFormat)
+		}
+		id, tree.freeHead = free.id, free.next
+	} else {
+		id = PageID(tree.pageCount)
+		tree.pageCount++
+	}
+	n := &diskNode[K, V]{id: id}
+	tree.cache.put(n)
+	tree.pin(id)
+	tree.markDirty(n)
+	tree.metaDirty = true
+	return n, nil
+}
+
+// freeNode 把节点所在的页加入空闲页链表
+func (tree *DiskTree[K, V]) freeNode(n *diskNode[K, V]) {
+	free := &diskNode[K, V]{id: n.id, free: true, next: tree.freeHead}
+	tree.freeHead = n.id
+	tree.cache.put(free)
+	tree.markDirty(free)
+	tree.metaDirty = true
+}
+
+// encodeNode 把节点编码为一整页，写入 buf 并返回
+func (tree *DiskTree[K, V]) encodeNode(n *diskNode[K, V], buf []byte) ([]byte, error) {
+	var err error
+	buf = buf[:0]
+	switch {
+	case n.free:
+		buf = append(buf, pageFree)
+		buf = binary.LittleEndian.AppendUint32(buf, uint32(n.next))
+	case n.isLeaf:
+		buf = append(buf, pageLeaf)
+		buf = binary.LittleEndian.AppendUint32(buf, uint32(len(n.keys)))
+		buf = binary.LittleEndian.AppendUint32(buf, uint32(n.prev))
+		buf = binary.LittleEndian.AppendUint32(buf, uint32(n.next))
+		for i, key := range n.keys {
+			if buf, err = tree.keyCodec.Append(buf, key); err != nil {
+				return nil, err
+			}
+			if buf, err = tree.valueCodec.Append(buf, n.values[i]); err != nil {
+				return nil, err
+			}
+		}
+	default:
+		buf = append(buf, pageInternal)
+		buf = binary.LittleEndian.AppendUint32(buf, uint32(len(n.keys)))
+		for _, child := range n.children {
+			buf = binary.LittleEndian.AppendUint32(buf, uint32(child))
+		}
+		for _, key := range n.keys {
+			if buf, err = tree.keyCodec.Append(buf, key); err != nil {
+				return nil, err
+			}
+		}
+	}
+	pageSize := tree.pager.PageSize()
+	if len(buf) > pageSize {
+		return nil, fmt.Errorf("第%d页的节点编码后有%d字节，超过页大小%d", n.id, len(buf), pageSize)
+	}
+	tail := len(buf)
+	buf = buf[:pageSize]
+	clear(buf[tail:])
+	return buf, nil
+}
+
+// decodeNode 从一整页数据中解码第 id 页的节点
+func (tree *DiskTree[K, V]) decodeNode(id PageID, data []byte) (*diskNode[K, V], error) {
+	n := &diskNode[K, V]{id: id}
+	switch data[0] {
+	case pageFree:
+		n.free = true
+		n.next = PageID(binary.LittleEndian.Uint32(data[1:]))
+		return n, nil
+	case pageLeaf:
+		n.isLeaf = true
+	case pageInternal:
+	default:
+		return nil, codec.ErrFormat
+	}
+	count := int(binary.LittleEndian.Uint32(data[1:]))
+	if count >= tree.order {
+		return nil, codec.ErrFormat
+	}
+	n.keys = make([]K, count)
+	if n.isLeaf {
+		n.prev = PageID(binary.LittleEndian.Uint32(data[5:]))
+		n.next = PageID(binary.LittleEndian.Uint32(data[9:]))
+		n.values = make([]V, count)
+		data = data[leafHeaderSize:]
+	} else {
+		data = data[nodeHeaderSize:]
+		n.children = make([]PageID, count+1)
+		for i := range n.children {
+			n.children[i] = PageID(binary.LittleEndian.Uint32(data[4*i:]))
+		}
+		data = data[4*len(n.children):]
+	}
+	for i := range count {
+		key, size, err := tree.keyCodec.Decode(data)
+		if err != nil {
+			return nil, err
+		}
+		n.keys[i], data = key, data[size:]
+		if n.isLeaf {
+			value, size, err := tree.valueCodec.Decode(data)
+			if err != nil {
+				return nil, err
+			}
+			n.values[i], data = value, data[size:]
+		}
+	}
+	return n, nil
+}
+
+// Flush 按页号顺序把修改过的节点和元数据写回 Pager 并调用 Sync
+// 写回后节点仍保留在缓存中，但不再被固定，缓存超过容量时可以被淘汰；关联了预写日志时，Sync 成功后清空日志
+func (tree *DiskTree[K, V]) Flush() error {
+	ids := make([]PageID, 0, len(tree.dirty))
+	for id := range tree.dirty {
+		ids = append(ids, id)
+	}
+	// 新分配的页号是连续的，按顺序写入才能逐页追加到 Pager 末尾
+	slices.Sort(ids)
+	for _, id := range ids {
+		buf, err := tree.encodeNode(tree.dirty[id], tree.buf)
+		if err != nil {
+			return err
+		}
+		if err := tree.pager.WritePage(id, buf); err != nil {
+			return err
+		}
+		delete(tree.dirty, id)
+		tree.cache.unpin(id)
+	}
+	tree.cache.evict()
+	if tree.metaDirty {
+		if err := tree.writeMeta(); err != nil {
+			return err
+		}
+	}
+	if err := tree.pager.Sync(); err != nil {
+		return err
+	}
+	if tree.wal != nil {
+		return tree.wal.Reset()
+	}
+	return nil
+}
+
+// Close 写回所有修改后关闭 Pager，关联了预写日志时同时关闭日志
+func (tree *DiskTree[K, V]) Close() error {
+	if err := tree.Flush(); err != nil {
+		return err
+	}
+	if tree.wal != nil {
+		if err := tree.wal.Close(); err != nil {
+			return err
+		}
+	}
+	return tree.pager.Close()
+}
+
+// AttachWAL 关联预写日志
+// 先把日志中上次 Flush 之后的修改重放到树中并 Flush，之后的 Put 与 Delete 在修改节点之前先追加到日志。
+// 日志只记录逻辑修改，Flush 写回页面的过程中崩溃导致的页面不一致无法通过日志修复
+// 参数：
+//   - w: 预写日志，Close 时一并关闭
+//
+// 返回：
+//   - error: 已经关联过日志时返回 ErrWALAttached，重放或写回失败时返回对应的错误
+func (tree *DiskTree[K, V]) AttachWAL(w *WAL[K, V]) error {
+	if tree.wal != nil {
+		return ErrWALAttached
+	}
+	err := w.Replay(func(rec WALRecord[K, V]) error {
+		if rec.Op == WALInsert {
+			return tree.Put(rec.Key, rec.Value)
+		}
+		_, err := tree.Delete(rec.Key)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	tree.wal = w
+	return tree.Flush()
+}
+
+// log 在关联了预写日志时追加一条记录
+func (tree *DiskTree[K, V]) log(rec WALRecord[K, V]) error {
+	if tree.wal == nil {
+		return nil
+	}
+	return tree.wal.Append(rec)
+}
+
+// findLeaf 从根节点下降到 key 所在的叶子节点，返回叶子节点及路径上经过的内部节点
+func (tree *DiskTree[K, V]) findLeaf(key K) (*diskNode[K, V], []diskStep[K, V], error) {
+	var path []diskStep[K, V]
+	n, err := tree.node(tree.root)
+	for err == nil && !n.isLeaf {
+		// 与 BPlusTree 相同，等于分隔键的键位于右侧子树
+		idx, found := slices.BinarySearch(n.keys, key)
+		if found {
+			idx++
+		}
+		path = append(path, diskStep[K, V]{node: n, idx: idx})
+		n, err = tree.node(n.children[idx])
+	}
+	return n, path, err
+}
+
+// Get 查找键对应的值
+// 参数：
+//   - key: 要查找的键
+//
+// 返回：
+//   - V: 找到的值
+//   - bool: 是否找到该键
+//   - error: 读取页面失败时返回的错误
+//
+// 时间复杂度: O(log n)
+func (tree *DiskTree[K, V]) Get(key K) (V, bool, error) {
+	defer tree.release()
+	var zero V
+	leaf, _, err := tree.findLeaf(key)
+	if err != nil {
+		return zero, false, err
+	}
+	pos, found := slices.BinarySearch(leaf.keys, key)
+	if !found {
+		return zero, false, nil
+	}
+	return leaf.values[pos], true, nil
+}
+
+// Put 插入键值对，键已存在时更新值
+// 参数：
+//   - key: 要插入的键
+//   - value: 要插入的值
+//
+// 返回：
+//   - error: 键值对编码后超过允许的大小时返回 ErrEntryTooLarge，读取页面失败时返回对应的错误
+//
+// 时间复杂度: O(order·log n)
+func (tree *DiskTree[K, V]) Put(key K, value V) error {
+	if err := tree.checkEntry(key, value); err != nil {
+		return err
+	}
+	if err := tree.log(WALRecord[K, V]{Op: WALInsert, Key: key, Value: value}); err != nil {
+		return err
+	}
+	defer tree.release()
+	leaf, path, err := tree.findLeaf(key)
+	if err != nil {
+		return err
+	}
+	pos, found := slices.BinarySearch(leaf.keys, key)
+	tree.markDirty(leaf)
+	if found {
+		leaf.values[pos] = value
+		return nil
+	}
+	leaf.keys = slices.Insert(leaf.keys, pos, key)
+	leaf.values = slices.Insert(leaf.values, pos, value)
+	tree.size++
+	tree.metaDirty = true
+	if len(leaf.keys) < tree.order {
+		return nil
+	}
+	return tree.splitLeaf(leaf, path)
+}
+
+// checkEntry 检查键值对编码后的大小
+func (tree *DiskTree[K, V]) checkEntry(key K, value V) error {
+	var err error
+	if tree.scratch, err = tree.keyCodec.Append(tree.scratch[:0], key); err != nil {
+		return err
+	}
+	if tree.scratch, err = tree.valueCodec.Append(tree.scratch, value); err != nil {
+		return err
+	}
+	if len(tree.scratch) > tree.entryLimit {
+		return fmt.Errorf("%w: %d字节，上限为%d字节", ErrEntryTooLarge, len(tree.scratch), tree.entryLimit)
+	}
+	return nil
+}
+
+// splitLeaf 把叶子节点的后一半移动到新的右侧叶子节点，并把分隔键插入父节点
+func (tree *DiskTree[K, V]) splitLeaf(leaf *diskNode[K, V], path []diskStep[K, V]) error {
+	right, err := tree.allocNode()
+	if err != nil {
+		return err
+	}
+	mid := (len(leaf.keys) + 1) / 2
+	right.isLeaf = true
+	right.keys = slices.Clone(leaf.keys[mid:])
+	right.values = slices.Clone(leaf.values[mid:])
+	right.prev, right.next = leaf.id, leaf.next
+	if leaf.next != 0 {
+		next, err := tree.node(leaf.next)
+		if err != nil {
+			return err
+		}
+		next.prev = right.id
+		tree.markDirty(next)
+	}
+	leaf.keys = leaf.keys[:mid]
+	leaf.values = leaf.values[:mid]
+	leaf.next = right.id
+	return tree.insertIntoParent(path, leaf, right.keys[0], right)
+}
+
+// insertIntoParent 把分裂得到的右侧节点及分隔键插入 path 末尾的父节点，父节点已满时继续分裂
+func (tree *DiskTree[K, V]) insertIntoParent(path []diskStep[K, V], left *diskNode[K, V], key K, right *diskNode[K, V]) error {
+	if len(path) == 0 {
+		root, err := tree.allocNode()
+		if err != nil {
+			return err
+		}
+		root.keys = []K{key}
+		root.children = []PageID{left.id, right.id}
+		tree.root = root.id
+		return nil
+	}
+	step := path[len(path)-1]
+	parent := step.node
+	parent.keys = slices.Insert(parent.keys, step.idx, key)
+	parent.children = slices.Insert(parent.children, step.idx+1, right.id)
+	tree.markDirty(parent)
+	if len(parent.keys) < tree.order {
+		return nil
+	}
+
+	sibling, err := tree.allocNode()
+	if err != nil {
+		return err
+	}
+	mid := len(parent.keys) / 2
+	promote := parent.keys[mid]
+	sibling.keys = slices.Clone(parent.keys[mid+1:])
+	sibling.children = slices.Clone(parent.children[mid+1:])
+	parent.keys = parent.keys[:mid]
+	parent.children = parent.children[:mid+1]
+	return tree.insertIntoParent(path[:len(path)-1], parent, promote, sibling)
+}
+
+// Delete 删除键及其对应的值
+// 与 BPlusTree.Delete 相同，节点的键少于下限时向兄弟节点借键或与其合并，合并释放的页加入空闲页链表
+// 参数：
+//   - key: 要删除的键
+//
+// 返回：
+//   - bool: 键是否存在
+//   - error: 读取页面失败时返回的错误
+//
+// 时间复杂度: O(order·log n)
+func (tree *DiskTree[K, V]) Delete(key K) (bool, error) {
+	if err := tree.log(WALRecord[K, V]{Op: WALDelete, Key: key}); err != nil {
+		return false, err
+	}
+	defer tree.release()
+	leaf, path, err := tree.findLeaf(key)
+	if err != nil {
+		return false, err
+	}
+	pos, found := slices.BinarySearch(leaf.keys, key)
+	if !found {
+		return false, nil
+	}
+	leaf.keys = slices.Delete(leaf.keys, pos, pos+1)
+	leaf.values = slices.Delete(leaf.values, pos, pos+1)
+	tree.markDirty(leaf)
+	tree.size--
+	tree.metaDirty = true
+	return true, tree.rebalance(leaf, path)
+}
+
+// minKeys 返回非根节点至少包含的键数量
+func (tree *DiskTree[K, V]) minKeys() int {
+	return (tree.order - 1) / 2
+}
+
+// rebalance 在节点的键少于下限时通过借键或合并恢复平衡，path 为从根节点到该节点经过的内部节点
+func (tree *DiskTree[K, V]) rebalance(n *diskNode[K, V], path []diskStep[K, V]) error {
+	if len(path) == 0 {
+		// 内部根节点没有键时只剩一个子节点，由该子节点成为新的根
+		if !n.isLeaf && len(n.keys) == 0 {
+			tree.root = n.children[0]
+			tree.freeNode(n)
+		}
+		return nil
+	}
+	if len(n.keys) >= tree.minKeys() {
+		return nil
+	}
+
+	step := path[len(path)-1]
+	parent, idx := step.node, step.idx
+	if idx > 0 {
+		left, err := tree.node(parent.children[idx-1])
+		if err != nil {
+			return err
+		}
+		if len(left.keys) > tree.minKeys() {
+			tree.borrowFromLeft(parent, idx, left, n)
+			return nil
+		}
+	}
+	if idx < len(parent.children)-1 {
+		right, err := tree.node(parent.children[idx+1])
+		if err != nil {
+			return err
+		}
+		if len(right.keys) > tree.minKeys() {
+			tree.borrowFromRight(parent, idx, n, right)
+			return nil
+		}
+		if idx == 0 {
+			if err := tree.mergeChildren(parent, idx, n, right); err != nil {
+				return err
+			}
+			return tree.rebalance(parent, path[:len(path)-1])
+		}
+	}
+	left, err := tree.node(parent.children[idx-1])
+	if err != nil {
+		return err
+	}
+	if err := tree.mergeChildren(parent, idx-1, left, n); err != nil {
+		return err
+	}
+	return tree.rebalance(parent, path[:len(path)-1])
+}
+
+// borrowFromLeft 把左兄弟的最后一个键移动到 parent 的第 idx 个子节点 n，并更新分隔键
+func (tree *DiskTree[K, V]) borrowFromLeft(parent *diskNode[K, V], idx int, left, n *diskNode[K, V]) {
+	last := len(left.keys) - 1
+	if n.isLeaf {
+		n.keys = slices.Insert(n.keys, 0, left.keys[last])
+		n.values = slices.Insert(n.values, 0, left.values[last])
+		left.values = left.values[:last]
+		parent.keys[idx-1] = n.keys[0]
+	} else {
+		n.keys = slices.Insert(n.keys, 0, parent.keys[idx-1])
+		n.children = slices.Insert(n.children, 0, left.children[last+1])
+		left.children = left.children[:last+1]
+		parent.keys[idx-1] = left.keys[last]
+	}
+	left.keys = left.keys[:last]
+	tree.markDirty(parent)
+	tree.markDirty(left)
+	tree.markDirty(n)
+}
+
+// borrowFromRight 把右兄弟的第一个键移动到 parent 的第 idx 个子节点 n，并更新分隔键
+func (tree *DiskTree[K, V]) borrowFromRight(parent *diskNode[K, V], idx int, n, right *diskNode[K, V]) {
+	if n.isLeaf {
+		n.keys = append(n.keys, right.keys[0])
+		n.values = append(n.values, right.values[0])
+		right.values = slices.Delete(right.values, 0, 1)
+		parent.keys[idx] = right.keys[1]
+	} else {
+		n.keys = append(n.keys, parent.keys[idx])
+		n.children = append(n.children, right.children[0])
+		right.children = slices.Delete(right.children, 0, 1)
+		parent.keys[idx] = right.keys[0]
+	}
+	right.keys = slices.Delete(right.keys, 0, 1)
+	tree.markDirty(parent)
+	tree.markDirty(n)
+	tree.markDirty(right)
+}
+
+// mergeChildren 把 parent 的第 idx+1 个子节点 right 合并到第 idx 个子节点 left 中，并释放 right 所在的页
+func (tree *DiskTree[K, V]) mergeChildren(parent *diskNode[K, V], idx int, left, right *diskNode[K, V]) error {
+	if left.isLeaf {
+		left.keys = append(left.keys, right.keys...)
+		left.values = append(left.values, right.values...)
+		left.next = right.next
+		if right.next != 0 {
+			next, err := tree.node(right.next)
+			if err != nil {
+				return err
+			}
+			next.prev = left.id
+			tree.markDirty(next)
+		}
+	} else {
+		left.keys = append(left.keys, parent.keys[idx])
+		left.keys = append(left.keys, right.keys...)
+		left.children = append(left.children, right.children...)
+	}
+	parent.keys = slices.Delete(parent.keys, idx, idx+1)
+	parent.children = slices.Delete(parent.children, idx+1, idx+2)
+	tree.markDirty(parent)
+	tree.markDirty(left)
+	tree.freeNode(right)
+	return nil
+}
+
+// AscendRange 按键升序遍历区间 [start, end) 内的键值对，fn 返回 false 时停止遍历
+// 时间复杂度: O(log n + m)，m 为区间内的键值对数量
+func (tree *DiskTree[K, V]) AscendRange(start, end K, fn func(key K, value V) bool) error {
+	if start >= end {
+		return nil
+	}
+	defer tree.release()
+	leaf, _, err := tree.findLeaf(start)
+	if err != nil {
+		return err
+	}
+	pos, _ := slices.BinarySearch(leaf.keys, start)
+	return tree.ascend(leaf, pos, func(key K, value V) bool {
+		return key < end && fn(key, value)
+	})
+}
+
+// Ascend 按键升序遍历所有键值对，fn 返回 false 时停止遍历
+// 时间复杂度: O(n)
+func (tree *DiskTree[K, V]) Ascend(fn func(key K, value V) bool) error {
+	defer tree.release()
+	n, err := tree.node(tree.root)
+	for err == nil && !n.isLeaf {
+		n, err = tree.node(n.children[0])
+	}
+	if err != nil {
+		return err
+	}
+	return tree.ascend(n, 0, fn)
+}
+
+// ascend 从 leaf 的第 pos 个键开始沿叶子链表按键升序遍历，fn 返回 false 时停止
+// 进入下一个叶子节点之前撤销已经固定的节点，遍历大量键值对时缓存不会因固定的节点而持续增长
+func (tree *DiskTree[K, V]) ascend(leaf *diskNode[K, V], pos int, fn func(key K, value V) bool) error {
+	for {
+		for ; pos < len(leaf.keys); pos++ {
+			if !fn(leaf.keys[pos], leaf.values[pos]) {
+				return nil
+			}
+		}
+		if leaf.next == 0 {
+			return nil
+		}
+		next := leaf.next
+		tree.release()
+		var err error
+		if leaf, err = tree.node(next); err != nil {
+			return err
+		}
+		pos = 0
+	}
+}
+
+// Len 返回键值对数量
+// 时间复杂度: O(1)
+func (tree *DiskTree[K, V]) Len() int {
+	return tree.size
+}
+
+// IsEmpty 检查树是否为空
+// 时间复杂度: O(1)
+func (tree *DiskTree[K, V]) IsEmpty() bool {
+	return tree.size == 0
+}
+
+// Order 返回树的阶数
+func (tree *DiskTree[K, V]) Order() int {
+	return tree.order
+}
+
+// CacheStats 返回节点缓存的命中、未命中与淘汰次数
+func (tree *DiskTree[K, V]) CacheStats() cache.Stats {
+	return tree.cache.stats
+}
+
+// CacheLen 返回节点缓存中的节点数量
+func (tree *DiskTree[K, V]) CacheLen() int {
+	return len(tree.cache.items)
+}
+
+// EntryLimit 返回单个键值对编码后允许的最大字节数，由页大小和阶数决定
+func (tree *DiskTree[K, V]) EntryLimit() int {
+	return tree.entryLimit
+}
@@ -0,0 +1,276 @@
+package bplustree
+
+import (
+	"errors"
+	"math/rand"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// collectDisk 按升序收集 DiskTree 中的所有键
+func collectDisk[V any](t *testing.T, tree *DiskTree[int, V]) []int {
+	var keys []int
+	err := tree.Ascend(func(key int, _ V) bool {
+		keys = append(keys, key)
+		return true
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return keys
+}
+
+// TestDiskTree 测试 DiskTree 的插入、查找、删除与遍历，并与 map 的结果对照
+func TestDiskTree(t *testing.T) {
+	tree, err := OpenDiskTree[int, string](NewMemPager(256), 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !tree.IsEmpty() || tree.Order() != 5 {
+		t.Fatalf("新建的树应为空且阶数为5，实际阶数为%d", tree.Order())
+	}
+
+	rng := rand.New(rand.NewSource(1))
+	expected := make(map[int]string)
+	for range 3000 {
+		key := rng.Intn(500)
+		if rng.Intn(3) == 0 {
+			ok, err := tree.Delete(key)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, want := expected[key]
+			if ok != want {
+				t.Fatalf("Delete(%d) = %v，期望 %v", key, ok, want)
+			}
+			delete(expected, key)
+		} else {
+			value := strings.Repeat("v", key%7)
+			if err := tree.Put(key, value); err != nil {
+				t.Fatal(err)
+			}
+			expected[key] = value
+		}
+	}
+
+	if tree.Len() != len(expected) {
+		t.Errorf("期望%d个键值对，实际为%d", len(expected), tree.Len())
+	}
+	for key, want := range expected {
+		if got, ok, err := tree.Get(key); err != nil || !ok || got != want {
+			t.Fatalf("Get(%d) = (%q, %v, %v)，期望 (%q, true, nil)", key, got, ok, err, want)
+		}
+	}
+	if _, ok, _ := tree.Get(1000); ok {
+		t.Error("不应找到不存在的键")
+	}
+	want := make([]int, 0, len(expected))
+	for key := range expected {
+		want = append(want, key)
+	}
+	slices.Sort(want)
+	if got := collectDisk(t, tree); !slices.Equal(got, want) {
+		t.Errorf("升序遍历结果不正确: %v", got)
+	}
+
+	t.Run("区间遍历", func(t *testing.T) {
+		var got []int
+		tree.AscendRange(100, 200, func(key int, _ string) bool {
+			got = append(got, key)
+			return true
+		})
+		var in []int
+		for _, key := range want {
+			if key >= 100 && key < 200 {
+				in = append(in, key)
+			}
+		}
+		if !slices.Equal(got, in) {
+			t.Errorf("区间 [100, 200) 内的键为%v，期望%v", got, in)
+		}
+		count := 0
+		tree.AscendRange(0, 500, func(int, string) bool {
+			count++
+			return count < 3
+		})
+		if count != 3 {
+			t.Errorf("fn 返回 false 后应停止遍历，实际调用%d次", count)
+		}
+	})
+}
+
+// TestDiskTreeReopen 测试写回后重新打开能读到相同的数据
+func TestDiskTreeReopen(t *testing.T) {
+	t.Run("内存页存储", func(t *testing.T) {
+		p := NewMemPager(128)
+		tree, err := OpenDiskTree[int, int](p, 4)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := range 200 {
+			tree.Put(i, i*i)
+		}
+		for i := 0; i < 200; i += 3 {
+			tree.Delete(i)
+		}
+		if err := tree.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		reopened, err := OpenDiskTree[int, int](p, 99)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if reopened.Order() != 4 || reopened.Len() != tree.Len() {
+			t.Fatalf("重新打开后阶数为%d、大小为%d，期望4和%d", reopened.Order(), reopened.Len(), tree.Len())
+		}
+		for i := range 200 {
+			v, ok, err := reopened.Get(i)
+			if err != nil || ok != (i%3 != 0) || (ok && v != i*i) {
+				t.Fatalf("Get(%d) = (%d, %v, %v)", i, v, ok, err)
+			}
+		}
+	})
+
+	t.Run("文件页存储", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "tree.db")
+		p, err := OpenFilePager(path, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tree, err := OpenDiskTree[string, []byte](p, 32)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for i := range 1000 {
+			key := strings.Repeat("k", i%5) + string(rune('a'+i%26)) + string(rune('0'+i/26%10))
+			if err := tree.Put(key, []byte(key)); err != nil {
+				t.Fatal(err)
+			}
+		}
+		size := tree.Len()
+		if err := tree.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		p, err = OpenFilePager(path, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		reopened, err := OpenDiskTree[string, []byte](p, 32)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer reopened.Close()
+		if reopened.Len() != size {
+			t.Fatalf("重新打开后大小为%d，期望%d", reopened.Len(), size)
+		}
+		var prev string
+		count := 0
+		reopened.Ascend(func(key string, value []byte) bool {
+			if count > 0 && key <= prev {
+				t.Fatalf("键没有按升序排列: %q 位于 %q 之后", key, prev)
+			}
+			if string(value) != key {
+				t.Fatalf("键 %q 的值为 %q", key, value)
+			}
+			prev = key
+			count++
+			return true
+		})
+		if count != size {
+			t.Errorf("遍历到%d个键，期望%d", count, size)
+		}
+	})
+
+	t.Run("元数据损坏", func(t *testing.T) {
+		p := NewMemPager(128)
+		p.WritePage(0, make([]byte, 128))
+		if _, err := OpenDiskTree[int, int](p, 4); err == nil {
+			t.Error("元数据页损坏时应返回错误")
+		}
+	})
+}
+
+// TestDiskTreeFreePages 测试删除释放的页会被之后的插入复用
+func TestDiskTreeFreePages(t *testing.T) {
+	p := NewMemPager(128)
+	tree, err := OpenDiskTree[int, int](p, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range 500 {
+		tree.Put(i, i)
+	}
+	tree.Flush()
+	pages := p.PageCount()
+	for i := range 500 {
+		if ok, err := tree.Delete(i); !ok || err != nil {
+			t.Fatalf("Delete(%d) = (%v, %v)", i, ok, err)
+		}
+	}
+	if !tree.IsEmpty() || len(collectDisk(t, tree)) != 0 {
+		t.Fatal("删除所有键后树应为空")
+	}
+	tree.Flush()
+
+	reopened, err := OpenDiskTree[int, int](p, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range 500 {
+		reopened.Put(i, i)
+	}
+	reopened.Flush()
+	if p.PageCount() != pages {
+		t.Errorf("重新插入后应复用空闲页，页数从%d变为%d", pages, p.PageCount())
+	}
+	if got := collectDisk(t, reopened); len(got) != 500 || got[499] != 499 {
+		t.Errorf("重新插入后遍历到%d个键", len(got))
+	}
+}
+
+// TestDiskTreeLimits 测试页大小与条目大小的限制
+func TestDiskTreeLimits(t *testing.T) {
+	if _, err := OpenDiskTree[int, int](NewMemPager(32), 16); err == nil {
+		t.Error("页大小不足以容纳节点时应返回错误")
+	}
+
+	tree, err := OpenDiskTree[string, string](NewMemPager(256), 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	limit := tree.EntryLimit()
+	if err := tree.Put("k", strings.Repeat("x", limit)); !errors.Is(err, ErrEntryTooLarge) {
+		t.Errorf("超过大小的条目应返回 ErrEntryTooLarge，实际为%v", err)
+	}
+	for i := range 200 {
+		key := string(rune('a'+i%26)) + strings.Repeat("z", i%4)
+		if err := tree.Put(key+string(rune('A'+i/26)), strings.Repeat("x", limit-len(key)-3)); err != nil {
+			t.Fatalf("未超过上限的条目应插入成功: %v", err)
+		}
+	}
+	if err := tree.Flush(); err != nil {
+		t.Errorf("满载的节点应能写入一页: %v", err)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("阶数小于3时应 panic")
+		}
+	}()
+	OpenDiskTree[int, int](NewMemPager(0), 2)
+}
+
+// BenchmarkDiskTreePut 测试向 DiskTree 插入键值对的性能
+func BenchmarkDiskTreePut(b *testing.B) {
+	tree, err := OpenDiskTree[int, int](NewMemPager(0), 64)
+	if err != nil {
+		b.Fatal(err)
+	}
+	for i := 0; i < b.N; i++ {
+		tree.Put(i, i)
+	}
+}
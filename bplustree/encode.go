@@ -0,0 +1,239 @@
+package bplustree
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"godatastructure/codec"
+	"golang.org/x/exp/constraints"
+)
+
+// encodeMagic Encode 输出开头的标识
+const encodeMagic = "BPTS"
+
+// encodeVersion Encode 输出的格式版本
+const encodeVersion byte = 1
+
+// maxDecodeDepth 解码时允许的最大树高，用于拒绝损坏的输入
+const maxDecodeDepth = 64
+
+// Encode 把 B+ 树的阶数、键值对以及节点结构写入 w
+// 与 MarshalBinary 只保存键值对不同，Encode 按先序保存每个节点，DecodeBPlusTree 可以直接还原节点而不需要逐个插入。
+// 格式为「标识、版本号、阶数、键值对数量」，之后每个节点是「长度、类型、键数量、键、值」，
+// 内部节点之后紧跟它的各个子节点；键和值使用 codec.For 返回的编解码器编码
+// 参数：
+//   - w: 写入的目标
+//
+// 返回：
+//   - error: 编码或写入失败时返回的错误
+//
+// 时间复杂度: O(n)
+func (tree *BPlusTree[K, V]) Encode(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	header := append([]byte(encodeMagic), encodeVersion)
+	header = binary.AppendUvarint(header, uint64(tree.order))
+	header = binary.AppendUvarint(header, uint64(tree.size))
+	if _, err := bw.Write(header); err != nil {
+		return err
+	}
+	e := nodeEncoder[K, V]{w: bw, tree: tree, keyCodec: codec.For[K](), valueCodec: codec.For[V]()}
+	if err := e.encode(tree.root); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+// nodeEncoder 按先序编码节点
+type nodeEncoder[K any, V any] struct {
+	w          *bufio.Writer
+	tree       *BPlusTree[K, V]
+	keyCodec   codec.Codec[K]
+	valueCodec codec.Codec[V]
+	buf        []byte
+	frame      []byte
+}
+
+// encode 写入节点 n 及其子树
+func (e *nodeEncoder[K, V]) encode(n *TreeNode[K, V]) error {
+	var err error
+	buf := e.buf[:0]
+	if n.isLeaf {
+		buf = append(buf, pageLeaf)
+	} else {
+		buf = append(buf, pageInternal)
+	}
+	buf = binary.AppendUvarint(buf, uint64(len(n.keys)))
+	for i := range n.keys {
+		// 启用前缀压缩时写入完整的键，格式与不压缩时相同
+		if buf, err = e.keyCodec.Append(buf, e.tree.keyAt(n, i)); err != nil {
+			return err
+		}
+		if n.isLeaf {
+			if buf, err = e.valueCodec.Append(buf, n.values[i]); err != nil {
+				return err
+			}
+		}
+	}
+	e.buf = buf
+	e.frame = binary.AppendUvarint(e.frame[:0], uint64(len(buf)))
+	if _, err := e.w.Write(e.frame); err != nil {
+		return err
+	}
+	if _, err := e.w.Write(buf); err != nil {
+		return err
+	}
+	for _, child := range n.children {
+		if err := e.encode(child); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// DecodeBPlusTree 从 Encode 写入的数据中还原 B+ 树
+// 节点按保存时的结构直接重建，并恢复父节点指针和叶子链表，最后通过 Validate 检查还原结果
+// 参数：
+//   - r: 读取的来源
+//   - opts: 可选配置，例如 WithRecorder、WithArena；阶数使用保存时的值
+//
+// 返回：
+//   - *BPlusTree[K, V]: 还原的 B+ 树
+//   - error: 读取失败、数据不完整或格式错误时返回的错误
+//
+// 时间复杂度: O(n)
+func DecodeBPlusTree[K constraints.Ordered, V any](r io.Reader, opts ...Option) (*BPlusTree[K, V], error) {
+	br := bufio.NewReader(r)
+	header := make([]byte, len(encodeMagic)+1)
+	if _, err := io.ReadFull(br, header); err != nil {
+		return nil, decodeErr(err)
+	}
+	if string(header[:len(encodeMagic)]) != encodeMagic || header[len(encodeMagic)] != encodeVersion {
+		return nil, codec.ErrFormat
+	}
+	order, err := binary.ReadUvarint(br)
+	if err != nil {
+		return nil, decodeErr(err)
+	}
+	size, err := binary.ReadUvarint(br)
+	if err != nil {
+		return nil, decodeErr(err)
+	}
+	if order < 3 || order > 1<<20 {
+		return nil, fmt.Errorf("阶数%d: %w", order, codec.ErrFormat)
+	}
+
+	tree := NewBPlusTree[K, V](int(order), opts...)
+	tree.nodes.Free(tree.root)
+	d := nodeDecoder[K, V]{r: br, tree: tree, keyCodec: codec.For[K](), valueCodec: codec.For[V](), leafDepth: -1}
+	root, err := d.decode(nil, 0)
+	if err != nil {
+		return nil, err
+	}
+	tree.root = root
+	tree.size = int(size)
+	if err := tree.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", codec.ErrFormat, err)
+	}
+	return tree, nil
+}
+
+// decodeErr 把读取到末尾的错误转换为 codec.ErrTruncated
+func decodeErr(err error) error {
+	if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return codec.ErrTruncated
+	}
+	return err
+}
+
+// nodeDecoder 按先序解码节点
+type nodeDecoder[K any, V any] struct {
+	r          *bufio.Reader
+	tree       *BPlusTree[K, V]
+	keyCodec   codec.Codec[K]
+	valueCodec codec.Codec[V]
+	prevLeaf   *TreeNode[K, V] // 上一个解码的叶子节点，用于重建叶子链表
+	leafDepth  int             // 叶子节点的深度，所有叶子节点必须位于同一深度
+	buf        []byte
+}
+
+// decode 读取一个节点及其子树，depth 为节点所在的深度
+func (d *nodeDecoder[K, V]) decode(parent *TreeNode[K, V], depth int) (*TreeNode[K, V], error) {
+	if depth > maxDecodeDepth {
+		return nil, fmt.Errorf("树高超过%d: %w", maxDecodeDepth, codec.ErrFormat)
+	}
+	length, err := binary.ReadUvarint(d.r)
+	if err != nil {
+		return nil, decodeErr(err)
+	}
+	if length == 0 || length > 1<<30 {
+		return nil, codec.ErrFormat
+	}
+	if uint64(cap(d.buf)) < length {
+		d.buf = make([]byte, length)
+	}
+	data := d.buf[:length]
+	if _, err := io.ReadFull(d.r, data); err != nil {
+		return nil, decodeErr(err)
+	}
+
+	kind := data[0]
+	if kind != pageLeaf && kind != pageInternal {
+		return nil, codec.ErrFormat
+	}
+	count, size := binary.Uvarint(data[1:])
+	if size <= 0 || count >= uint64(d.tree.order) || count > uint64(len(data)) {
+		return nil, codec.ErrFormat
+	}
+	data = data[1+size:]
+	n := d.tree.newNode(TreeNode[K, V]{
+		isLeaf: kind == pageLeaf,
+		keys:   make([]K, count),
+		parent: parent,
+	})
+	if n.isLeaf {
+		n.values = make([]V, count)
+	}
+	for i := range n.keys {
+		if n.keys[i], size, err = d.keyCodec.Decode(data); err != nil {
+			return nil, err
+		}
+		data = data[size:]
+		if n.isLeaf {
+			if n.values[i], size, err = d.valueCodec.Decode(data); err != nil {
+				return nil, err
+			}
+			data = data[size:]
+		}
+	}
+	if len(data) != 0 {
+		return nil, codec.ErrFormat
+	}
+
+	if n.isLeaf {
+		if d.leafDepth >= 0 && d.leafDepth != depth {
+			return nil, fmt.Errorf("叶子节点不在同一深度: %w", codec.ErrFormat)
+		}
+		d.leafDepth = depth
+		if d.prevLeaf != nil {
+			d.prevLeaf.next = n
+			n.prev = d.prevLeaf
+		}
+		d.prevLeaf = n
+		d.tree.compressLeaf(n)
+		return n, nil
+	}
+	if count == 0 {
+		return nil, fmt.Errorf("内部节点没有键: %w", codec.ErrFormat)
+	}
+	n.children = make([]*TreeNode[K, V], count+1)
+	for i := range n.children {
+		if n.children[i], err = d.decode(n, depth+1); err != nil {
+			return nil, err
+		}
+		n.count += n.children[i].subtreeSize()
+	}
+	return n, nil
+}
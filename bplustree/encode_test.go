@@ -0,0 +1,113 @@
+package bplustree
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"godatastructure/codec"
+)
+
+// TestBPlusTreeEncode 测试 Encode 与 DecodeBPlusTree 还原相同的键值对与节点结构
+func TestBPlusTreeEncode(t *testing.T) {
+	tree := NewBPlusTree[int, string](5)
+	for i := range 300 {
+		tree.Insert(i*7%300, string(rune('a'+i%26)))
+	}
+	for i := 0; i < 300; i += 4 {
+		tree.Delete(i)
+	}
+
+	var buf bytes.Buffer
+	if err := tree.Encode(&buf); err != nil {
+		t.Fatal(err)
+	}
+	decoded, err := DecodeBPlusTree[int, string](&buf, WithArena(0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Size() != tree.Size() || decoded.order != tree.order {
+		t.Fatalf("还原后大小为%d、阶数为%d，期望%d和%d", decoded.Size(), decoded.order, tree.Size(), tree.order)
+	}
+	if decoded.String() != tree.String() {
+		t.Error("还原后的节点结构应与原树相同")
+	}
+	validateBPlusTree(t, decoded)
+
+	var keys []int
+	for key := range decoded.Backward() {
+		keys = append(keys, key)
+	}
+	if len(keys) != tree.Size() || keys[0] != 299 {
+		t.Errorf("还原后逆序遍历结果不正确: %v", keys)
+	}
+	// 还原后的树可以继续修改
+	for i := range 300 {
+		decoded.Insert(i, "x")
+	}
+	for i := range 150 {
+		decoded.Delete(i)
+	}
+	if err := decoded.Validate(); err != nil {
+		t.Error(err)
+	}
+
+	t.Run("空树", func(t *testing.T) {
+		var buf bytes.Buffer
+		NewBPlusTree[string, []byte](3).Encode(&buf)
+		empty, err := DecodeBPlusTree[string, []byte](&buf)
+		if err != nil || !empty.IsEmpty() {
+			t.Fatalf("还原空树失败: %v", err)
+		}
+		empty.Insert("k", []byte("v"))
+		if v, ok := empty.Search("k"); !ok || string(v) != "v" {
+			t.Error("还原的空树应可以继续插入")
+		}
+	})
+
+	t.Run("损坏的数据", func(t *testing.T) {
+		var buf bytes.Buffer
+		tree.Encode(&buf)
+		data := buf.Bytes()
+		if _, err := DecodeBPlusTree[int, string](bytes.NewReader(data[:len(data)-3])); !errors.Is(err, codec.ErrTruncated) {
+			t.Errorf("数据不完整时应返回 ErrTruncated，实际为%v", err)
+		}
+		if _, err := DecodeBPlusTree[int, string](bytes.NewReader([]byte("XXXX\x01"))); !errors.Is(err, codec.ErrFormat) {
+			t.Errorf("标识错误时应返回 ErrFormat，实际为%v", err)
+		}
+		// 修改记录的键值对数量
+		bad := bytes.Clone(data)
+		bad[6]++
+		if _, err := DecodeBPlusTree[int, string](bytes.NewReader(bad)); !errors.Is(err, codec.ErrFormat) {
+			t.Errorf("键值对数量不一致时应返回 ErrFormat，实际为%v", err)
+		}
+	})
+}
+
+// BenchmarkBPlusTreeDecode 比较按结构还原与逐个插入重建的性能
+func BenchmarkBPlusTreeDecode(b *testing.B) {
+	tree := NewBPlusTree[int, int](32)
+	for i := range 100000 {
+		tree.Insert(i, i)
+	}
+	b.Run("DecodeBPlusTree", func(b *testing.B) {
+		var buf bytes.Buffer
+		tree.Encode(&buf)
+		data := buf.Bytes()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			if _, err := DecodeBPlusTree[int, int](bytes.NewReader(data)); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("UnmarshalBinary", func(b *testing.B) {
+		data, _ := tree.MarshalBinary()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			if err := NewBPlusTree[int, int](32).UnmarshalBinary(data); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -0,0 +1,88 @@
+package bplustree
+
+import (
+	"container/list"
+
+	"godatastructure/cache"
+	"golang.org/x/exp/constraints"
+)
+
+// cachedNode 节点缓存中的条目
+type cachedNode[K constraints.Ordered, V any] struct {
+	node *diskNode[K, V]
+	pins int           // 固定次数，大于0时不会被淘汰
+	elem *list.Element // 在未固定链表中的位置，被固定时为 nil
+}
+
+// nodeCache DiskTree 已解码节点的 LRU 缓存
+// 被固定（pin）的节点不会被淘汰，只有固定次数降为0的节点按最近最少使用的顺序淘汰；
+// 节点数量超过容量而所有节点都被固定时，缓存可以暂时超过容量
+type nodeCache[K constraints.Ordered, V any] struct {
+	capacity int                          // 容量，0 表示不限制
+	items    map[PageID]*cachedNode[K, V] // 页号到条目的映射
+	unpinned *list.List                   // 未固定的条目，链表头部为最近使用的条目
+	stats    cache.Stats                  // 命中统计
+}
+
+// newNodeCache 创建容量为 capacity 的节点缓存，capacity 为0时不限制容量
+func newNodeCache[K constraints.Ordered, V any](capacity int) *nodeCache[K, V] {
+	return &nodeCache[K, V]{
+		capacity: capacity,
+		items:    make(map[PageID]*cachedNode[K, V]),
+		unpinned: list.New(),
+	}
+}
+
+// get 返回第 id 页的节点并计入命中统计，命中时将其标记为最近使用
+func (c *nodeCache[K, V]) get(id PageID) (*diskNode[K, V], bool) {
+	e, ok := c.items[id]
+	if !ok {
+		c.stats.Misses++
+		return nil, false
+	}
+	c.stats.Hits++
+	if e.elem != nil {
+		c.unpinned.MoveToFront(e.elem)
+	}
+	return e.node, true
+}
+
+// put 放入节点，同一页已有节点时替换它并保留固定次数
+// put 不会淘汰节点，调用方通常紧接着固定新放入的节点，淘汰推迟到 evict
+func (c *nodeCache[K, V]) put(n *diskNode[K, V]) {
+	if e, ok := c.items[n.id]; ok {
+		e.node = n
+		return
+	}
+	e := &cachedNode[K, V]{node: n}
+	e.elem = c.unpinned.PushFront(e)
+	c.items[n.id] = e
+}
+
+// pin 固定第 id 页的节点，使其不会被淘汰
+func (c *nodeCache[K, V]) pin(id PageID) {
+	e := c.items[id]
+	if e.pins == 0 {
+		c.unpinned.Remove(e.elem)
+		e.elem = nil
+	}
+	e.pins++
+}
+
+// unpin 撤销一次固定，固定次数降为0时节点重新可以被淘汰
+func (c *nodeCache[K, V]) unpin(id PageID) {
+	e := c.items[id]
+	e.pins--
+	if e.pins == 0 {
+		e.elem = c.unpinned.PushFront(e)
+	}
+}
+
+// evict 淘汰最久未使用的未固定节点，直到节点数量不超过容量或没有可以淘汰的节点
+func (c *nodeCache[K, V]) evict() {
+	for c.capacity > 0 && len(c.items) > c.capacity && c.unpinned.Len() > 0 {
+		e := c.unpinned.Remove(c.unpinned.Back()).(*cachedNode[K, V])
+		delete(c.items, e.node.id)
+		c.stats.Evictions++
+	}
+}
@@ -0,0 +1,127 @@
+package bplustree
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// TestNodeCache 测试节点缓存的 LRU 淘汰与固定
+func TestNodeCache(t *testing.T) {
+	c := newNodeCache[int, int](2)
+	for id := PageID(1); id <= 3; id++ {
+		c.put(&diskNode[int, int]{id: id})
+	}
+	c.evict()
+	if _, ok := c.get(1); ok {
+		t.Error("超过容量时应淘汰最久未使用的节点1")
+	}
+	if _, ok := c.get(2); !ok {
+		t.Fatal("节点2应仍在缓存中")
+	}
+
+	// 节点2刚被访问，但节点3被固定，放入节点4后只能淘汰节点2
+	c.pin(3)
+	c.put(&diskNode[int, int]{id: 4})
+	c.pin(4)
+	c.evict()
+	if _, ok := c.get(2); ok {
+		t.Error("节点3、4被固定时应淘汰节点2")
+	}
+	c.put(&diskNode[int, int]{id: 5})
+	c.pin(5)
+	c.evict()
+	if len(c.items) != 3 {
+		t.Errorf("所有节点都被固定时缓存可以暂时超过容量，实际有%d个节点", len(c.items))
+	}
+	c.unpin(4)
+	c.unpin(3)
+	c.unpin(5)
+	c.evict()
+	if _, ok := c.get(4); ok || len(c.items) != 2 {
+		t.Error("撤销固定后应按最近最少使用的顺序淘汰节点4")
+	}
+
+	// 替换节点时保留固定次数
+	c.pin(3)
+	c.put(&diskNode[int, int]{id: 3, free: true})
+	if n, _ := c.get(3); !n.free || c.items[3].pins != 1 {
+		t.Error("put 应替换同一页的节点并保留固定次数")
+	}
+
+	s := c.stats
+	if s.Hits != 2 || s.Misses != 3 || s.Evictions != 3 {
+		t.Errorf("统计为 %+v，期望命中2次、未命中3次、淘汰3次", s)
+	}
+}
+
+// TestDiskTreeCacheSize 测试限制节点缓存容量后 DiskTree 的读写结果不变
+func TestDiskTreeCacheSize(t *testing.T) {
+	const capacity = 8
+	pager := NewMemPager(256)
+	tree, err := OpenDiskTree[int, int](pager, 4, WithCacheSize(capacity))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rng := rand.New(rand.NewSource(9))
+	expected := make(map[int]int)
+	for i := range 4000 {
+		key := rng.Intn(800)
+		if rng.Intn(3) == 0 {
+			if _, err := tree.Delete(key); err != nil {
+				t.Fatal(err)
+			}
+			delete(expected, key)
+		} else {
+			if err := tree.Put(key, i); err != nil {
+				t.Fatal(err)
+			}
+			expected[key] = i
+		}
+		if i%100 == 99 {
+			if err := tree.Flush(); err != nil {
+				t.Fatal(err)
+			}
+			if tree.CacheLen() > capacity {
+				t.Fatalf("写回后缓存中有%d个节点，超过容量%d", tree.CacheLen(), capacity)
+			}
+		}
+	}
+
+	for key, want := range expected {
+		if got, ok, err := tree.Get(key); err != nil || !ok || got != want {
+			t.Fatalf("Get(%d) = (%d, %v, %v)，期望 (%d, true, nil)", key, got, ok, err, want)
+		}
+	}
+	want := make([]int, 0, len(expected))
+	for key := range expected {
+		want = append(want, key)
+	}
+	slices.Sort(want)
+	if got := collectDisk(t, tree); !slices.Equal(got, want) {
+		t.Fatal("升序遍历结果不正确")
+	}
+	if err := tree.Flush(); err != nil {
+		t.Fatal(err)
+	}
+	if tree.CacheLen() > capacity {
+		t.Errorf("遍历后缓存中有%d个节点，超过容量%d", tree.CacheLen(), capacity)
+	}
+
+	s := tree.CacheStats()
+	if s.Hits == 0 || s.Misses == 0 || s.Evictions == 0 {
+		t.Errorf("统计为 %+v，容量较小时应同时有命中、未命中和淘汰", s)
+	}
+
+	// 不限制容量时同样的数据不会被淘汰
+	reopened, err := OpenDiskTree[int, int](pager, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := collectDisk(t, reopened); !slices.Equal(got, want) {
+		t.Fatal("重新打开后遍历结果不正确")
+	}
+	if s := reopened.CacheStats(); s.Evictions != 0 || s.Misses != uint64(reopened.CacheLen()) {
+		t.Errorf("不限制容量时不应淘汰节点，统计为 %+v", s)
+	}
+}
@@ -0,0 +1,189 @@
+package bplustree
+
+import (
+	"errors"
+	"fmt"
+	"os"
+)
+
+// DefaultPageSize 默认的页大小
+const DefaultPageSize = 4096
+
+// PageID 页号，从0开始连续编号；磁盘上的节点之间通过页号而不是指针互相引用
+type PageID uint32
+
+var (
+	ErrPageRange = errors.New("页号超出范围")
+	ErrPageSize  = errors.New("页面数据的长度与页大小不一致")
+)
+
+// Pager 固定大小页面的存储，DiskTree 通过它读写节点
+// 页号从0开始连续编号，写入第 PageCount 页即在末尾追加一页；
+// 实现只需要负责按页读写，页面的分配与回收由 DiskTree 管理
+type Pager interface {
+	// PageSize 返回每页的字节数
+	PageSize() int
+
+	// PageCount 返回已有的页数量
+	PageCount() int
+
+	// ReadPage 把第 id 页读入 buf，buf 的长度必须等于 PageSize
+	ReadPage(id PageID, buf []byte) error
+
+	// WritePage 把 data 写入第 id 页，data 的长度必须等于 PageSize，id 最大为 PageCount
+	WritePage(id PageID, data []byte) error
+
+	// Sync 把已写入的页面持久化到底层存储
+	Sync() error
+
+	// Close 关闭存储，之后不应再调用其他方法
+	Close() error
+}
+
+// MemPager 把页面保存在内存中的 Pager，用于测试或不需要持久化的场景
+type MemPager struct {
+	pageSize int
+	pages    [][]byte
+}
+
+// NewMemPager 创建页大小为 pageSize 的内存页存储，pageSize 小于1时使用 DefaultPageSize
+func NewMemPager(pageSize int) *MemPager {
+	if pageSize < 1 {
+		pageSize = DefaultPageSize
+	}
+	return &MemPager{pageSize: pageSize}
+}
+
+// PageSize 返回每页的字节数
+func (p *MemPager) PageSize() int {
+	return p.pageSize
+}
+
+// PageCount 返回已有的页数量
+func (p *MemPager) PageCount() int {
+	return len(p.pages)
+}
+
+// ReadPage 把第 id 页读入 buf
+// 时间复杂度: O(PageSize)
+func (p *MemPager) ReadPage(id PageID, buf []byte) error {
+	if len(buf) != p.pageSize {
+		return ErrPageSize
+	}
+	if int(id) >= len(p.pages) {
+		return ErrPageRange
+	}
+	copy(buf, p.pages[id])
+	return nil
+}
+
+// WritePage 把 data 复制到第 id 页，id 等于 PageCount 时追加新页
+// 时间复杂度: O(PageSize)
+func (p *MemPager) WritePage(id PageID, data []byte) error {
+	if len(data) != p.pageSize {
+		return ErrPageSize
+	}
+	switch {
+	case int(id) == len(p.pages):
+		p.pages = append(p.pages, make([]byte, p.pageSize))
+	case int(id) > len(p.pages):
+		return ErrPageRange
+	}
+	copy(p.pages[id], data)
+	return nil
+}
+
+// Sync 内存页存储不需要持久化，总是返回 nil
+func (p *MemPager) Sync() error {
+	return nil
+}
+
+// Close 内存页存储关闭后页面仍然保留，可以再次打开 DiskTree 读取，总是返回 nil
+func (p *MemPager) Close() error {
+	return nil
+}
+
+// FilePager 把页面保存在文件中的 Pager，第 id 页位于文件偏移 id*PageSize 处
+type FilePager struct {
+	file     *os.File
+	pageSize int
+	count    int
+}
+
+// OpenFilePager 打开或创建页存储文件
+// 参数：
+//   - path: 文件路径，不存在时创建
+//   - pageSize: 页大小，小于1时使用 DefaultPageSize；打开已有文件时必须与创建时相同
+//
+// 返回：
+//   - *FilePager: 页存储
+//   - error: 打开文件失败或文件长度不是页大小的整数倍时返回的错误
+func OpenFilePager(path string, pageSize int) (*FilePager, error) {
+	if pageSize < 1 {
+		pageSize = DefaultPageSize
+	}
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	if info.Size()%int64(pageSize) != 0 {
+		file.Close()
+		return nil, fmt.Errorf("文件长度%d不是页大小%d的整数倍", info.Size(), pageSize)
+	}
+	return &FilePager{file: file, pageSize: pageSize, count: int(info.Size() / int64(pageSize))}, nil
+}
+
+// PageSize 返回每页的字节数
+func (p *FilePager) PageSize() int {
+	return p.pageSize
+}
+
+// PageCount 返回已有的页数量
+func (p *FilePager) PageCount() int {
+	return p.count
+}
+
+// ReadPage 从文件中读取第 id 页
+func (p *FilePager) ReadPage(id PageID, buf []byte) error {
+	if len(buf) != p.pageSize {
+		return ErrPageSize
+	}
+	if int(id) >= p.count {
+		return ErrPageRange
+	}
+	_, err := p.file.ReadAt(buf, int64(id)*int64(p.pageSize))
+	return err
+}
+
+// WritePage 把 data 写入文件中的第 id 页，id 等于 PageCount 时追加新页
+// 写入的数据在 Sync 之前不保证持久化
+func (p *FilePager) WritePage(id PageID, data []byte) error {
+	if len(data) != p.pageSize {
+		return ErrPageSize
+	}
+	if int(id) > p.count {
+		return ErrPageRange
+	}
+	if _, err := p.file.WriteAt(data, int64(id)*int64(p.pageSize)); err != nil {
+		return err
+	}
+	if int(id) == p.count {
+		p.count++
+	}
+	return nil
+}
+
+// Sync 把写入的页面刷新到磁盘
+func (p *FilePager) Sync() error {
+	return p.file.Sync()
+}
+
+// Close 关闭文件
+func (p *FilePager) Close() error {
+	return p.file.Close()
+}
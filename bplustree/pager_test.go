@@ -0,0 +1,91 @@
+package bplustree
+
+import (
+	"bytes"
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+// TestPager 测试内存页存储与文件页存储的读写行为一致
+func TestPager(t *testing.T) {
+	const pageSize = 64
+	open := map[string]func(t *testing.T) Pager{
+		"内存": func(t *testing.T) Pager {
+			return NewMemPager(pageSize)
+		},
+		"文件": func(t *testing.T) Pager {
+			p, err := OpenFilePager(filepath.Join(t.TempDir(), "tree.db"), pageSize)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return p
+		},
+	}
+	for name, newPager := range open {
+		t.Run(name, func(t *testing.T) {
+			p := newPager(t)
+			defer p.Close()
+			if p.PageSize() != pageSize || p.PageCount() != 0 {
+				t.Fatalf("新建的页存储应为空，页大小为%d，实际页数为%d", p.PageSize(), p.PageCount())
+			}
+			page := bytes.Repeat([]byte{7}, pageSize)
+			if err := p.WritePage(1, page); !errors.Is(err, ErrPageRange) {
+				t.Errorf("跳过页号写入应返回 ErrPageRange，实际为%v", err)
+			}
+			if err := p.WritePage(0, page[:10]); !errors.Is(err, ErrPageSize) {
+				t.Errorf("长度不足一页时应返回 ErrPageSize，实际为%v", err)
+			}
+			for i := range 3 {
+				page[0] = byte(i)
+				if err := p.WritePage(PageID(i), page); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if p.PageCount() != 3 {
+				t.Errorf("期望3页，实际为%d", p.PageCount())
+			}
+			page[0] = 9
+			if err := p.WritePage(1, page); err != nil {
+				t.Fatal(err)
+			}
+			buf := make([]byte, pageSize)
+			if err := p.ReadPage(1, buf); err != nil || buf[0] != 9 || buf[1] != 7 {
+				t.Errorf("读取覆盖后的第1页得到%v，错误为%v", buf[:2], err)
+			}
+			if err := p.ReadPage(3, buf); !errors.Is(err, ErrPageRange) {
+				t.Errorf("读取不存在的页应返回 ErrPageRange，实际为%v", err)
+			}
+			if err := p.Sync(); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+
+	t.Run("重新打开文件", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "tree.db")
+		p, err := OpenFilePager(path, pageSize)
+		if err != nil {
+			t.Fatal(err)
+		}
+		page := bytes.Repeat([]byte{5}, pageSize)
+		p.WritePage(0, page)
+		p.WritePage(1, page)
+		if err := p.Close(); err != nil {
+			t.Fatal(err)
+		}
+
+		p, err = OpenFilePager(path, pageSize)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer p.Close()
+		buf := make([]byte, pageSize)
+		if p.PageCount() != 2 || p.ReadPage(1, buf) != nil || !bytes.Equal(buf, page) {
+			t.Errorf("重新打开后应读到原来的2页，实际页数为%d", p.PageCount())
+		}
+		if _, err := OpenFilePager(path, 48); err == nil {
+			t.Error("文件长度不是页大小的整数倍时应返回错误")
+		}
+	})
+}
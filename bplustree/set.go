@@ -0,0 +1,168 @@
+package bplustree
+
+import (
+	"fmt"
+	"iter"
+	"strings"
+
+	"golang.org/x/exp/constraints"
+)
+
+// BPlusSet 只保存键的 B+ 树，用作有序集合
+// 底层是值类型为 struct{} 的 BPlusTree：struct{} 不占用内存，叶子节点的值数组不分配存储空间，
+// 移动键时对值数组的复制也没有开销，因此每个叶子节点只多出一个空切片头
+type BPlusSet[K constraints.Ordered] struct {
+	tree *BPlusTree[K, struct{}]
+}
+
+// NewBPlusSet 创建包含给定元素的有序集合
+// 参数：
+//   - order: 树的阶数，必须大于等于3
+//   - items: 初始元素，重复的元素只保留一个
+//
+// 返回：
+//   - *BPlusSet[K]: 新创建的有序集合
+//
+// 时间复杂度: O(n·order·log n)
+func NewBPlusSet[K constraints.Ordered](order int, items ...K) *BPlusSet[K] {
+	s := &BPlusSet[K]{tree: NewBPlusTree[K, struct{}](order)}
+	for _, item := range items {
+		s.Add(item)
+	}
+	return s
+}
+
+// Add 添加元素，返回是否添加了新元素
+// 时间复杂度: O(order·log n)
+func (s *BPlusSet[K]) Add(item K) bool {
+	size := s.tree.Size()
+	s.tree.Insert(item, struct{}{})
+	return s.tree.Size() > size
+}
+
+// Remove 删除元素，返回元素是否存在
+// 时间复杂度: O(order·log n)
+func (s *BPlusSet[K]) Remove(item K) bool {
+	return s.tree.Delete(item)
+}
+
+// Contains 检查元素是否存在
+// 时间复杂度: O(log n)
+func (s *BPlusSet[K]) Contains(item K) bool {
+	_, ok := s.tree.Search(item)
+	return ok
+}
+
+// Len 返回元素数量
+// 时间复杂度: O(1)
+func (s *BPlusSet[K]) Len() int {
+	return s.tree.Len()
+}
+
+// Size 返回元素数量，与 Len 相同
+// 时间复杂度: O(1)
+func (s *BPlusSet[K]) Size() int {
+	return s.tree.Size()
+}
+
+// IsEmpty 检查集合是否为空
+// 时间复杂度: O(1)
+func (s *BPlusSet[K]) IsEmpty() bool {
+	return s.tree.IsEmpty()
+}
+
+// Clear 清空集合
+// 时间复杂度: O(1)
+func (s *BPlusSet[K]) Clear() {
+	s.tree.Clear()
+}
+
+// Compare 按元素的自然顺序比较两个元素
+func (s *BPlusSet[K]) Compare(a, b K) int {
+	return s.tree.Compare(a, b)
+}
+
+// First 返回最小的元素
+// 时间复杂度: O(log n)
+func (s *BPlusSet[K]) First() (K, bool) {
+	k, _, ok := s.tree.Min()
+	return k, ok
+}
+
+// Last 返回最大的元素
+// 时间复杂度: O(log n)
+func (s *BPlusSet[K]) Last() (K, bool) {
+	k, _, ok := s.tree.Max()
+	return k, ok
+}
+
+// Rank 返回小于 item 的元素数量
+// 时间复杂度: O(order·log n)
+func (s *BPlusSet[K]) Rank(item K) int {
+	return s.tree.Rank(item)
+}
+
+// SelectKth 返回第 k 小的元素（k 从0开始），k 越界时第二个返回值为 false
+// 时间复杂度: O(order·log n)
+func (s *BPlusSet[K]) SelectKth(k int) (K, bool) {
+	item, _, ok := s.tree.SelectKth(k)
+	return item, ok
+}
+
+// AscendRange 按升序遍历区间 [start, end) 内的元素，fn 返回 false 时停止遍历
+// 时间复杂度: O(log n + m)，m 为区间内的元素数量
+func (s *BPlusSet[K]) AscendRange(start, end K, fn func(item K) bool) {
+	s.tree.AscendRange(start, end, func(item K, _ struct{}) bool {
+		return fn(item)
+	})
+}
+
+// All 返回按升序遍历所有元素的迭代器
+func (s *BPlusSet[K]) All() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		s.tree.ForEach(func(item K, _ struct{}) bool {
+			return yield(item)
+		})
+	}
+}
+
+// ToSlice 按升序返回所有元素
+// 时间复杂度: O(n)
+func (s *BPlusSet[K]) ToSlice() []K {
+	result := make([]K, 0, s.Size())
+	for item := range s.All() {
+		result = append(result, item)
+	}
+	return result
+}
+
+// Clone 返回结构相同的拷贝
+// copier 用于复制每个元素（包括内部节点中的分隔键），为 nil 时直接赋值；复制后的元素应与原元素保持相同的顺序
+// 时间复杂度: O(n)
+func (s *BPlusSet[K]) Clone(copier func(K) K) *BPlusSet[K] {
+	tree := s.tree.Clone(nil)
+	if copier != nil {
+		copyKeys(tree.root, copier)
+	}
+	return &BPlusSet[K]{tree: tree}
+}
+
+// copyKeys 用 copier 替换以 n 为根的子树中的每个键
+func copyKeys[K any, V any](n *TreeNode[K, V], copier func(K) K) {
+	for i, key := range n.keys {
+		n.keys[i] = copier(key)
+	}
+	for _, child := range n.children {
+		copyKeys(child, copier)
+	}
+}
+
+// String 按升序返回集合的字符串表示
+// 实现 fmt.Stringer 接口
+func (s *BPlusSet[K]) String() string {
+	parts := make([]string, 0, s.Size())
+	for item := range s.All() {
+		parts = append(parts, fmt.Sprintf("%v", item))
+	}
+	return "{" + strings.Join(parts, " ") + "}"
+}
@@ -0,0 +1,106 @@
+package bplustree
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// TestBPlusSet 测试只保存键的有序集合
+func TestBPlusSet(t *testing.T) {
+	t.Run("基本操作", func(t *testing.T) {
+		s := NewBPlusSet(3, 5, 1, 3, 1)
+		if s.Size() != 3 || s.Len() != 3 {
+			t.Fatalf("重复的元素只应保留一个，Size = %d", s.Size())
+		}
+		if s.Add(3) || !s.Add(4) {
+			t.Error("Add 应返回是否添加了新元素")
+		}
+		if !s.Contains(4) || s.Contains(2) {
+			t.Error("Contains 结果错误")
+		}
+		if !s.Remove(4) || s.Remove(4) {
+			t.Error("Remove 应返回元素是否存在")
+		}
+		if first, _ := s.First(); first != 1 {
+			t.Errorf("First = %d，期望 1", first)
+		}
+		if last, _ := s.Last(); last != 5 {
+			t.Errorf("Last = %d，期望 5", last)
+		}
+		if got := s.String(); got != "{1 3 5}" {
+			t.Errorf("String = %s", got)
+		}
+		s.Clear()
+		if !s.IsEmpty() {
+			t.Error("清空后集合应为空")
+		}
+		if _, ok := s.First(); ok {
+			t.Error("空集合没有最小的元素")
+		}
+	})
+
+	t.Run("随机操作", func(t *testing.T) {
+		s := NewBPlusSet[int](4)
+		ref := make(map[int]bool)
+		rng := rand.New(rand.NewSource(11))
+		for range 3000 {
+			k := rng.Intn(500)
+			if rng.Intn(3) == 0 {
+				if s.Remove(k) != ref[k] {
+					t.Fatalf("Remove(%d) 结果错误", k)
+				}
+				delete(ref, k)
+			} else {
+				if s.Add(k) == ref[k] {
+					t.Fatalf("Add(%d) 结果错误", k)
+				}
+				ref[k] = true
+			}
+		}
+		if err := s.tree.Validate(); err != nil {
+			t.Fatal(err)
+		}
+		var want []int
+		for k := range ref {
+			want = append(want, k)
+		}
+		slices.Sort(want)
+		if got := s.ToSlice(); !slices.Equal(got, want) {
+			t.Fatalf("ToSlice = %v，期望 %v", got, want)
+		}
+		for i, k := range want {
+			if got, ok := s.SelectKth(i); !ok || got != k || s.Rank(k) != i {
+				t.Fatalf("第%d个元素应为%d", i, k)
+			}
+		}
+		var ranged []int
+		s.AscendRange(100, 200, func(k int) bool {
+			ranged = append(ranged, k)
+			return true
+		})
+		lo, _ := slices.BinarySearch(want, 100)
+		hi, _ := slices.BinarySearch(want, 200)
+		if !slices.Equal(ranged, want[lo:hi]) {
+			t.Errorf("AscendRange(100, 200) = %v", ranged)
+		}
+	})
+
+	t.Run("克隆", func(t *testing.T) {
+		s := NewBPlusSet(3, 1, 2, 3, 4, 5, 6, 7)
+		clone := s.Clone(func(k int) int { return k * 10 })
+		if err := clone.tree.Validate(); err != nil {
+			t.Fatal(err)
+		}
+		if got := clone.ToSlice(); !slices.Equal(got, []int{10, 20, 30, 40, 50, 60, 70}) {
+			t.Errorf("克隆后的元素为%v", got)
+		}
+		if !clone.Contains(40) || clone.Contains(4) {
+			t.Error("克隆后内部节点的分隔键也应被复制")
+		}
+		clone.Add(8)
+		if s.Contains(8) {
+			t.Error("修改副本不应影响原集合")
+		}
+	})
+}
@@ -0,0 +1,158 @@
+package bplustree
+
+// Split 把 B+ 树按 key 分成两棵：left 包含小于 key 的键值对，right 包含大于等于 key 的键值对
+// 叶子节点直接转移给两棵新树，只有 key 所在的叶子节点会被拆开，键值对不需要重新插入；
+// 之后修复边界处不足半满的叶子节点，并自底向上重建内部节点。
+// 两棵新树继承阶数、比较函数以及是否允许重复的键，但不继承记录器和分配器；调用后原树变为空树
+// 参数：
+//   - key: 分割键
+//
+// 返回：
+//   - left: 键小于 key 的 B+ 树
+//   - right: 键大于等于 key 的 B+ 树
+//
+// 时间复杂度: O(log n + n/order)
+func (tree *BPlusTree[K, V]) Split(key K) (left, right *BPlusTree[K, V]) {
+	left, right = tree.emptyCopy(), tree.emptyCopy()
+	if tree.size == 0 {
+		return left, right
+	}
+
+	// 拆开 key 所在的叶子节点，boundary 之前（含）的叶子节点属于左侧
+	leaf, pos := tree.seek(key)
+	boundary := leaf
+	tree.expand(leaf)
+	switch {
+	case pos == 0:
+		boundary = leaf.prev
+	case pos < len(leaf.keys):
+		rightLeaf := &TreeNode[K, V]{
+			isLeaf: true,
+			keys:   append([]K(nil), leaf.keys[pos:]...),
+			values: append([]V(nil), leaf.values[pos:]...),
+			next:   leaf.next,
+			prev:   leaf,
+		}
+		if leaf.next != nil {
+			leaf.next.prev = rightLeaf
+		}
+		clear(leaf.keys[pos:])
+		clear(leaf.values[pos:])
+		leaf.keys, leaf.values = leaf.keys[:pos], leaf.values[:pos]
+		leaf.next = rightLeaf
+		tree.compressLeaf(rightLeaf)
+	}
+	tree.compressLeaf(leaf)
+
+	var leftLeaves, rightLeaves []*TreeNode[K, V]
+	inLeft := boundary != nil
+	for n := tree.firstLeaf(); n != nil; n = n.next {
+		if inLeft {
+			leftLeaves = append(leftLeaves, n)
+		} else {
+			rightLeaves = append(rightLeaves, n)
+		}
+		if n == boundary {
+			inLeft = false
+		}
+	}
+	if len(leftLeaves) > 0 {
+		leftLeaves[len(leftLeaves)-1].next = nil
+	}
+	if len(rightLeaves) > 0 {
+		rightLeaves[0].prev = nil
+	}
+
+	left.build(tree.fixBoundaryLeaf(leftLeaves, len(leftLeaves)-2))
+	right.build(tree.fixBoundaryLeaf(rightLeaves, 0))
+	tree.root = tree.newNode(TreeNode[K, V]{
+		isLeaf: true,
+		keys:   make([]K, 0),
+		values: make([]V, 0),
+	})
+	tree.size = 0
+	return left, right
+}
+
+// emptyCopy 返回与 tree 的阶数、比较函数以及是否允许重复的键相同的空树
+func (tree *BPlusTree[K, V]) emptyCopy() *BPlusTree[K, V] {
+	t := NewBPlusTreeFunc[K, V](tree.order, tree.cmp)
+	t.dup = tree.dup
+	t.compress = tree.compress
+	return t
+}
+
+// fixBoundaryLeaf 修复 leaves[i] 与 leaves[i+1] 这对相邻叶子节点中不足半满的一个
+// 两者的键可以放进一个节点时合并，否则平均分配；i 越界时不做修改。返回修复后的叶子节点列表
+func (tree *BPlusTree[K, V]) fixBoundaryLeaf(leaves []*TreeNode[K, V], i int) []*TreeNode[K, V] {
+	if i < 0 || i+1 >= len(leaves) {
+		return leaves
+	}
+	a, b := leaves[i], leaves[i+1]
+	if len(a.keys) >= tree.minKeys() && len(b.keys) >= tree.minKeys() {
+		return leaves
+	}
+	tree.expand(a)
+	tree.expand(b)
+	defer tree.compressLeaf(a)
+	total := len(a.keys) + len(b.keys)
+	if total <= tree.order-1 {
+		a.keys = append(a.keys, b.keys...)
+		a.values = append(a.values, b.values...)
+		a.next = b.next
+		if b.next != nil {
+			b.next.prev = a
+		}
+		return append(leaves[:i+1], leaves[i+2:]...)
+	}
+	keys := append(append([]K(nil), a.keys...), b.keys...)
+	values := append(append([]V(nil), a.values...), b.values...)
+	mid := total / 2
+	a.keys, a.values = keys[:mid:mid], values[:mid:mid]
+	b.keys, b.values = keys[mid:], values[mid:]
+	tree.compressLeaf(b)
+	return leaves
+}
+
+// build 以按键升序排列、彼此已经通过叶子链表连接的 leaves 作为叶子节点，自底向上建立内部节点
+// 除只有一个节点的层外，每层的子节点被平均分配给 ⌈子节点数/order⌉ 个父节点，因此每个父节点至少半满
+func (tree *BPlusTree[K, V]) build(leaves []*TreeNode[K, V]) {
+	if len(leaves) == 0 {
+		return
+	}
+	tree.size = 0
+	for _, leaf := range leaves {
+		tree.size += len(leaf.keys)
+	}
+	level := leaves
+	for len(level) > 1 {
+		m := (len(level) + tree.order - 1) / tree.order
+		parents := make([]*TreeNode[K, V], m)
+		for i := range parents {
+			group := level[i*len(level)/m : (i+1)*len(level)/m]
+			p := tree.newNode(TreeNode[K, V]{
+				keys:     make([]K, 0, len(group)-1),
+				children: append([]*TreeNode[K, V](nil), group...),
+			})
+			for j, child := range group {
+				child.parent = p
+				p.count += child.subtreeSize()
+				if j > 0 {
+					p.keys = append(p.keys, tree.minKey(child))
+				}
+			}
+			parents[i] = p
+		}
+		level = parents
+	}
+	tree.root = level[0]
+	tree.root.parent = nil
+}
+
+// minKey 返回以 n 为根的子树中最小的键
+func (tree *BPlusTree[K, V]) minKey(n *TreeNode[K, V]) K {
+	for !n.isLeaf {
+		n = n.children[0]
+	}
+	return tree.keyAt(n, 0)
+}
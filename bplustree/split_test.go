@@ -0,0 +1,93 @@
+package bplustree
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// TestBPlusTreeSplit 测试按键把 B+ 树分成两棵
+func TestBPlusTreeSplit(t *testing.T) {
+	t.Run("各个分割位置", func(t *testing.T) {
+		for _, order := range []int{3, 4, 5, 8} {
+			for _, n := range []int{0, 1, 7, 100} {
+				for key := -1; key <= n+1; key += max(1, n/17) {
+					tree := NewBPlusTree[int, int](order)
+					for i := range n {
+						tree.Insert(i*2, i)
+					}
+					left, right := tree.Split(key)
+					validateBPlusTree(t, left)
+					validateBPlusTree(t, right)
+					if !tree.IsEmpty() {
+						t.Fatalf("分割后原树应为空，实际有%d个键值对", tree.Size())
+					}
+
+					var want []int
+					for i := range n {
+						want = append(want, i*2)
+					}
+					cut, _ := slices.BinarySearch(want, key)
+					var gotLeft, gotRight []int
+					for k := range left.All() {
+						gotLeft = append(gotLeft, k)
+					}
+					for k := range right.All() {
+						gotRight = append(gotRight, k)
+					}
+					if !slices.Equal(gotLeft, want[:cut]) || !slices.Equal(gotRight, want[cut:]) {
+						t.Fatalf("阶数%d、%d个键按%d分割，左侧为%v，右侧为%v", order, n, key, gotLeft, gotRight)
+					}
+				}
+			}
+		}
+	})
+
+	t.Run("分割后继续修改", func(t *testing.T) {
+		tree := NewBPlusTree[int, int](4)
+		rng := rand.New(rand.NewSource(7))
+		for _, k := range rng.Perm(500) {
+			tree.Insert(k, k)
+		}
+		left, right := tree.Split(250)
+		for i := range 250 {
+			if i%3 == 0 {
+				left.Delete(i)
+				right.Delete(i + 250)
+			} else {
+				left.Insert(i+1000, i)
+				right.Insert(i+2000, i)
+			}
+		}
+		validateBPlusTree(t, left)
+		validateBPlusTree(t, right)
+		if k, _, _ := right.Min(); k != 251 {
+			t.Errorf("右侧最小的键应为251，实际为%d", k)
+		}
+		if got := left.Rank(1000); got != 250-84 {
+			t.Errorf("左侧 Rank(1000) = %d，期望 %d", got, 250-84)
+		}
+		tree.Insert(1, 1)
+		validateBPlusTree(t, tree)
+	})
+
+	t.Run("重复的键", func(t *testing.T) {
+		tree := NewBPlusTree[int, int](3, WithDuplicates())
+		for i := range 90 {
+			tree.Insert(i%3, i)
+		}
+		left, right := tree.Split(1)
+		validateBPlusTree(t, left)
+		validateBPlusTree(t, right)
+		if left.Size() != 30 || right.Size() != 60 {
+			t.Fatalf("左右两侧分别有%d和%d个键值对，期望30和60", left.Size(), right.Size())
+		}
+		if got := right.SearchAll(1); len(got) != 30 || got[0] != 1 {
+			t.Errorf("右侧应包含键1的全部30个值，实际为%v", got)
+		}
+		right.Insert(1, -1)
+		if got := right.SearchAll(1); got[len(got)-1] != -1 {
+			t.Error("右侧应保留允许重复的键的模式")
+		}
+	})
+}
@@ -0,0 +1,45 @@
+package bplustree
+
+import "unsafe"
+
+// Stats B+ 树的结构统计，用于根据实际负载调整阶数
+type Stats struct {
+	Height      int     // 树的层数，空树为0
+	Nodes       int     // 节点总数，包括叶子节点
+	Leaves      int     // 叶子节点数量
+	Keys        int     // 所有节点中键的总数，包括内部节点中的分隔键
+	AvgFill     float64 // 平均填充率，即节点中键的数量与最大键数量（order-1）之比的平均值
+	MemoryBytes uintptr // 节点及其键、值、子节点数组占用内存的估算值（按切片容量计算）
+}
+
+// Stats 遍历所有节点并返回树的结构统计
+// 内存估算只统计节点本身与数组的大小，不包括键和值引用的外部数据（例如字符串的内容）
+// 时间复杂度: O(n)
+func (tree *BPlusTree[K, V]) Stats() Stats {
+	var s Stats
+	s.Height = tree.Height()
+	if tree.size == 0 {
+		return s
+	}
+	tree.collectStats(tree.root, &s)
+	s.AvgFill = float64(s.Keys) / float64(s.Nodes*(tree.order-1))
+	return s
+}
+
+// collectStats 把以 n 为根的子树的统计累加到 s
+func (tree *BPlusTree[K, V]) collectStats(n *TreeNode[K, V], s *Stats) {
+	var key K
+	var value V
+	s.Nodes++
+	s.Keys += len(n.keys)
+	s.MemoryBytes += unsafe.Sizeof(*n) + uintptr(cap(n.keys))*unsafe.Sizeof(key)
+	if n.isLeaf {
+		s.Leaves++
+		s.MemoryBytes += uintptr(cap(n.values)) * unsafe.Sizeof(value)
+		return
+	}
+	s.MemoryBytes += uintptr(cap(n.children)) * unsafe.Sizeof(n)
+	for _, child := range n.children {
+		tree.collectStats(child, s)
+	}
+}
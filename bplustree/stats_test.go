@@ -0,0 +1,58 @@
+package bplustree
+
+import (
+	"testing"
+	"unsafe"
+)
+
+// TestBPlusTreeStats 测试结构统计
+func TestBPlusTreeStats(t *testing.T) {
+	t.Run("空树", func(t *testing.T) {
+		tree := NewBPlusTree[int, int](4)
+		if s := tree.Stats(); s != (Stats{}) {
+			t.Errorf("空树的统计应为零值，实际为 %+v", s)
+		}
+	})
+
+	t.Run("单个叶子节点", func(t *testing.T) {
+		tree := NewBPlusTree[int, int](4)
+		tree.Insert(1, 1)
+		tree.Insert(2, 2)
+		s := tree.Stats()
+		if s.Height != 1 || s.Nodes != 1 || s.Leaves != 1 || s.Keys != 2 {
+			t.Errorf("统计 = %+v", s)
+		}
+		if want := 2.0 / 3; s.AvgFill != want {
+			t.Errorf("AvgFill = %v，期望 %v", s.AvgFill, want)
+		}
+		if s.MemoryBytes < unsafe.Sizeof(TreeNode[int, int]{})+4*unsafe.Sizeof(0) {
+			t.Errorf("MemoryBytes = %d，小于节点与两个键值对的大小", s.MemoryBytes)
+		}
+	})
+
+	t.Run("多层", func(t *testing.T) {
+		tree := NewBPlusTree[int, int](5)
+		for i := range 1000 {
+			tree.Insert(i, i)
+		}
+		s := tree.Stats()
+		if s.Height != tree.Height() {
+			t.Errorf("Height = %d，期望 %d", s.Height, tree.Height())
+		}
+		leaves := 0
+		for n := tree.firstLeaf(); n != nil; n = n.next {
+			leaves++
+		}
+		if s.Leaves != leaves {
+			t.Errorf("Leaves = %d，期望 %d", s.Leaves, leaves)
+		}
+		// 内部节点的键数量比子节点数量少1，因此键总数为叶子中的键加上内部节点数量与子节点总数之差
+		if internal := s.Nodes - s.Leaves; s.Keys != 1000+(s.Nodes-1)-internal {
+			t.Errorf("Keys = %d，与 Nodes = %d、Leaves = %d 不一致", s.Keys, s.Nodes, s.Leaves)
+		}
+		// 除根节点外每个节点至少半满
+		if s.AvgFill < 0.5 || s.AvgFill > 1 {
+			t.Errorf("AvgFill = %v，应在 [0.5, 1] 内", s.AvgFill)
+		}
+	})
+}
@@ -0,0 +1,156 @@
+package bplustree
+
+import (
+	"iter"
+	"time"
+
+	"golang.org/x/exp/constraints"
+)
+
+// expiringEntry ExpiringTree 中保存的值及其过期时间
+type expiringEntry[V any] struct {
+	value    V
+	expireAt time.Time // 过期时间，零值表示永不过期
+}
+
+// ExpiringTree 每个键值对可以带有过期时间的 B+ 树，适合用作按时间索引的缓存
+// 过期的键值对在 Search 与遍历时被跳过；Search 遇到过期的键值对时顺便删除它，
+// 其余过期的键值对保留在树中，直到调用 Compact 时统一删除
+type ExpiringTree[K constraints.Ordered, V any] struct {
+	tree *BPlusTree[K, expiringEntry[V]]
+	now  func() time.Time // 获取当前时间的函数
+}
+
+// NewExpiringTree 创建新的带过期时间的 B+ 树
+// 参数：
+//   - order: 树的阶数，必须大于等于3
+//   - now: 获取当前时间的函数，为 nil 时使用 time.Now
+//   - opts: B+ 树的可选配置，例如 WithRecorder、WithArena
+//
+// 返回：
+//   - *ExpiringTree[K, V]: 新创建的树
+func NewExpiringTree[K constraints.Ordered, V any](order int, now func() time.Time, opts ...Option) *ExpiringTree[K, V] {
+	if now == nil {
+		now = time.Now
+	}
+	return &ExpiringTree[K, V]{tree: NewBPlusTree[K, expiringEntry[V]](order, opts...), now: now}
+}
+
+// Insert 插入永不过期的键值对，键已存在时更新值并清除过期时间
+// 时间复杂度: O(order·log n)
+func (t *ExpiringTree[K, V]) Insert(key K, value V) {
+	t.tree.Insert(key, expiringEntry[V]{value: value})
+}
+
+// InsertWithTTL 插入在 ttl 之后过期的键值对，键已存在时更新值和过期时间
+// 时间复杂度: O(order·log n)
+func (t *ExpiringTree[K, V]) InsertWithTTL(key K, value V, ttl time.Duration) {
+	t.InsertExpireAt(key, value, t.now().Add(ttl))
+}
+
+// InsertExpireAt 插入在 expireAt 时刻过期的键值对，expireAt 为零值时永不过期
+// 时间复杂度: O(order·log n)
+func (t *ExpiringTree[K, V]) InsertExpireAt(key K, value V, expireAt time.Time) {
+	t.tree.Insert(key, expiringEntry[V]{value: value, expireAt: expireAt})
+}
+
+// expired 检查 e 在 now 时刻是否已经过期
+func (e expiringEntry[V]) expired(now time.Time) bool {
+	return !e.expireAt.IsZero() && !now.Before(e.expireAt)
+}
+
+// Search 查找键对应的值，键不存在或已经过期时返回 false；过期的键值对会被删除
+// 时间复杂度: O(log n)
+func (t *ExpiringTree[K, V]) Search(key K) (V, bool) {
+	e, ok := t.tree.Search(key)
+	if ok && e.expired(t.now()) {
+		t.tree.Delete(key)
+		ok = false
+	}
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	return e.value, true
+}
+
+// ExpireAt 返回键的过期时间，永不过期时为零值；键不存在或已经过期时第二个返回值为 false
+// 时间复杂度: O(log n)
+func (t *ExpiringTree[K, V]) ExpireAt(key K) (time.Time, bool) {
+	e, ok := t.tree.Search(key)
+	if !ok || e.expired(t.now()) {
+		return time.Time{}, false
+	}
+	return e.expireAt, true
+}
+
+// Delete 删除键及其对应的值，返回键是否存在且没有过期
+// 时间复杂度: O(order·log n)
+func (t *ExpiringTree[K, V]) Delete(key K) bool {
+	e, ok := t.tree.Search(key)
+	if !ok {
+		return false
+	}
+	t.tree.Delete(key)
+	return !e.expired(t.now())
+}
+
+// AscendRange 按键升序遍历区间 [start, end) 内没有过期的键值对，fn 返回 false 时停止遍历
+// 时间复杂度: O(log n + m)，m 为区间内（包括已过期）的键值对数量
+func (t *ExpiringTree[K, V]) AscendRange(start, end K, fn func(key K, value V) bool) {
+	now := t.now()
+	t.tree.AscendRange(start, end, func(key K, e expiringEntry[V]) bool {
+		return e.expired(now) || fn(key, e.value)
+	})
+}
+
+// All 返回按键升序遍历所有没有过期的键值对的迭代器，过期判断使用开始遍历时的时间
+func (t *ExpiringTree[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		now := t.now()
+		for key, e := range t.tree.All() {
+			if !e.expired(now) && !yield(key, e.value) {
+				return
+			}
+		}
+	}
+}
+
+// Compact 删除所有已经过期的键值对，返回删除的数量
+// 时间复杂度: O(n + m·order·log n)，m 为过期的键值对数量
+func (t *ExpiringTree[K, V]) Compact() int {
+	now := t.now()
+	var expired []K
+	for key, e := range t.tree.All() {
+		if e.expired(now) {
+			expired = append(expired, key)
+		}
+	}
+	for _, key := range expired {
+		t.tree.Delete(key)
+	}
+	return len(expired)
+}
+
+// Len 返回树中键值对的数量，包括已经过期但还没有被删除的键值对
+// 时间复杂度: O(1)
+func (t *ExpiringTree[K, V]) Len() int {
+	return t.tree.Len()
+}
+
+// Size 返回树中键值对的数量，与 Len 相同
+// 时间复杂度: O(1)
+func (t *ExpiringTree[K, V]) Size() int {
+	return t.tree.Size()
+}
+
+// IsEmpty 检查树中是否没有任何键值对，包括已经过期的键值对
+// 时间复杂度: O(1)
+func (t *ExpiringTree[K, V]) IsEmpty() bool {
+	return t.tree.IsEmpty()
+}
+
+// Clear 清空树
+func (t *ExpiringTree[K, V]) Clear() {
+	t.tree.Clear()
+}
@@ -0,0 +1,85 @@
+package bplustree
+
+import (
+	"testing"
+	"time"
+)
+
+// fakeClock 测试使用的可手动推进的时钟
+type fakeClock struct {
+	now time.Time
+}
+
+// Now 返回时钟当前的时间
+func (c *fakeClock) Now() time.Time { return c.now }
+
+// TestExpiringTree 测试带过期时间的 B+ 树
+func TestExpiringTree(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(1000, 0)}
+	tree := NewExpiringTree[int, string](4, clock.Now)
+	for i := range 20 {
+		tree.InsertWithTTL(i, "v", time.Duration(i+1)*time.Second)
+	}
+	tree.Insert(100, "永久")
+
+	t.Run("查找", func(t *testing.T) {
+		if v, ok := tree.Search(5); !ok || v != "v" {
+			t.Errorf("Search(5) = (%q, %v)，期望未过期", v, ok)
+		}
+		if at, ok := tree.ExpireAt(5); !ok || !at.Equal(clock.now.Add(6*time.Second)) {
+			t.Errorf("ExpireAt(5) = (%v, %v)", at, ok)
+		}
+		if at, ok := tree.ExpireAt(100); !ok || !at.IsZero() {
+			t.Errorf("永不过期的键 ExpireAt = (%v, %v)，期望零值", at, ok)
+		}
+	})
+
+	clock.now = clock.now.Add(10 * time.Second)
+
+	t.Run("过期后跳过", func(t *testing.T) {
+		if _, ok := tree.Search(3); ok {
+			t.Error("键3应已过期")
+		}
+		if tree.Len() != 20 {
+			t.Errorf("Search 应删除遇到的过期键值对，Len = %d，期望 20", tree.Len())
+		}
+		var keys []int
+		for k := range tree.All() {
+			keys = append(keys, k)
+		}
+		if len(keys) != 11 || keys[0] != 10 || keys[10] != 100 {
+			t.Errorf("遍历应跳过过期的键，实际为%v", keys)
+		}
+		keys = keys[:0]
+		tree.AscendRange(0, 15, func(k int, v string) bool {
+			keys = append(keys, k)
+			return true
+		})
+		if len(keys) != 5 || keys[0] != 10 {
+			t.Errorf("区间遍历应跳过过期的键，实际为%v", keys)
+		}
+		if tree.Delete(2) {
+			t.Error("删除已过期的键应返回 false")
+		}
+	})
+
+	t.Run("压缩", func(t *testing.T) {
+		if n := tree.Compact(); n != 8 {
+			t.Errorf("Compact 删除了%d个键值对，期望 8", n)
+		}
+		if tree.Len() != 11 {
+			t.Errorf("压缩后 Len = %d，期望 11", tree.Len())
+		}
+		if err := tree.tree.Validate(); err != nil {
+			t.Fatal(err)
+		}
+		tree.InsertWithTTL(10, "续期", time.Hour)
+		clock.now = clock.now.Add(time.Minute)
+		if v, ok := tree.Search(10); !ok || v != "续期" {
+			t.Errorf("更新后应使用新的过期时间，Search(10) = (%q, %v)", v, ok)
+		}
+		if n := tree.Compact(); n != 9 {
+			t.Errorf("Compact 删除了%d个键值对，期望 9", n)
+		}
+	})
+}
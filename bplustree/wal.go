@@ -0,0 +1,305 @@
+package bplustree
+
+import (
+	"encoding/binary"
+	"hash/crc32"
+	"io"
+	"iter"
+	"os"
+
+	"godatastructure/codec"
+	"golang.org/x/exp/constraints"
+)
+
+// WALOp 预写日志记录的操作类型
+type WALOp byte
+
+const (
+	WALInsert WALOp = 1 // 插入或更新键值对
+	WALDelete WALOp = 2 // 删除键
+)
+
+// walHeaderSize 每条记录头部的字节数：操作类型与负载长度
+const walHeaderSize = 5
+
+// WALRecord 预写日志中的一条记录，Op 为 WALDelete 时 Value 为零值
+type WALRecord[K, V any] struct {
+	Op    WALOp
+	Key   K
+	Value V
+}
+
+// WAL 追加写入的预写日志
+// 每条记录的格式为「操作类型、负载长度、负载、CRC32 校验和」，负载为 codec.For 编码的键（以及值）。
+// 修改在应用之前先追加到日志，进程崩溃后通过 Replay 按顺序重放即可恢复；
+// 日志末尾不完整或校验失败的记录视为崩溃时未写完的记录，重放时会被截断
+type WAL[K, V any] struct {
+	file       *os.File
+	path       string
+	sync       bool           // 是否在每次追加后调用 fsync
+	buf        []byte         // 编码记录使用的缓冲区
+	keyCodec   codec.Codec[K] // 键编解码器
+	valueCodec codec.Codec[V] // 值编解码器
+}
+
+// OpenWAL 打开或创建预写日志，新记录追加到文件末尾
+// 参数：
+//   - path: 日志文件路径
+//   - sync: 为 true 时每次 Append 后都调用 fsync，保证返回后记录已经落盘；
+//     为 false 时只在 Sync 时落盘，吞吐量更高但崩溃可能丢失最近的记录
+//
+// 返回：
+//   - *WAL[K, V]: 预写日志
+//   - error: 打开文件失败时返回的错误
+func OpenWAL[K, V any](path string, sync bool) (*WAL[K, V], error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := file.Seek(0, io.SeekEnd); err != nil {
+		file.Close()
+		return nil, err
+	}
+	return &WAL[K, V]{
+		file:       file,
+		path:       path,
+		sync:       sync,
+		keyCodec:   codec.For[K](),
+		valueCodec: codec.For[V](),
+	}, nil
+}
+
+// Append 在日志末尾追加一条记录
+func (w *WAL[K, V]) Append(rec WALRecord[K, V]) error {
+	var err error
+	buf := append(w.buf[:0], byte(rec.Op), 0, 0, 0, 0)
+	if buf, err = w.keyCodec.Append(buf, rec.Key); err != nil {
+		return err
+	}
+	if rec.Op == WALInsert {
+		if buf, err = w.valueCodec.Append(buf, rec.Value); err != nil {
+			return err
+		}
+	}
+	binary.LittleEndian.PutUint32(buf[1:], uint32(len(buf)-walHeaderSize))
+	buf = binary.LittleEndian.AppendUint32(buf, crc32.ChecksumIEEE(buf))
+	w.buf = buf
+	if _, err := w.file.Write(buf); err != nil {
+		return err
+	}
+	if w.sync {
+		return w.file.Sync()
+	}
+	return nil
+}
+
+// Sync 把已追加的记录刷新到磁盘
+func (w *WAL[K, V]) Sync() error {
+	return w.file.Sync()
+}
+
+// Replay 从头按顺序把日志中的每条记录传给 fn，fn 返回错误时停止并返回该错误
+// 遇到不完整或校验失败的记录时，把日志截断到最后一条完整记录之后，之后的追加从截断处开始
+func (w *WAL[K, V]) Replay(fn func(rec WALRecord[K, V]) error) error {
+	data, err := os.ReadFile(w.path)
+	if err != nil {
+		return err
+	}
+	offset := 0
+	for offset < len(data) {
+		rec, size, ok := w.decode(data[offset:])
+		if !ok {
+			break
+		}
+		if err := fn(rec); err != nil {
+			return err
+		}
+		offset += size
+	}
+	if offset < len(data) {
+		if err := w.file.Truncate(int64(offset)); err != nil {
+			return err
+		}
+	}
+	_, err = w.file.Seek(int64(offset), io.SeekStart)
+	return err
+}
+
+// Records 返回按顺序遍历日志中所有完整记录的迭代器，不会截断日志
+func (w *WAL[K, V]) Records() iter.Seq[WALRecord[K, V]] {
+	return func(yield func(WALRecord[K, V]) bool) {
+		data, err := os.ReadFile(w.path)
+		if err != nil {
+			return
+		}
+		for len(data) > 0 {
+			rec, size, ok := w.decode(data)
+			if !ok || !yield(rec) {
+				return
+			}
+			data = data[size:]
+		}
+	}
+}
+
+// decode 解码 data 开头的一条记录，返回记录、占用的字节数以及记录是否完整有效
+func (w *WAL[K, V]) decode(data []byte) (WALRecord[K, V], int, bool) {
+	var rec WALRecord[K, V]
+	if len(data) < walHeaderSize {
+		return rec, 0, false
+	}
+	end := walHeaderSize + int(binary.LittleEndian.Uint32(data[1:]))
+	if end+4 > len(data) || binary.LittleEndian.Uint32(data[end:]) != crc32.ChecksumIEEE(data[:end]) {
+		return rec, 0, false
+	}
+	rec.Op = WALOp(data[0])
+	payload := data[walHeaderSize:end]
+	key, size, err := w.keyCodec.Decode(payload)
+	if err != nil {
+		return rec, 0, false
+	}
+	rec.Key = key
+	switch rec.Op {
+	case WALInsert:
+		if rec.Value, _, err = w.valueCodec.Decode(payload[size:]); err != nil {
+			return rec, 0, false
+		}
+	case WALDelete:
+	default:
+		return rec, 0, false
+	}
+	return rec, end + 4, true
+}
+
+// Reset 清空日志，通常在日志中的修改已经持久化到其他位置（检查点）之后调用
+func (w *WAL[K, V]) Reset() error {
+	if err := w.file.Truncate(0); err != nil {
+		return err
+	}
+	if _, err := w.file.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	return w.file.Sync()
+}
+
+// Close 关闭日志文件
+func (w *WAL[K, V]) Close() error {
+	return w.file.Close()
+}
+
+// LoggedTree 修改先写入预写日志再应用的内存 B+ 树
+// 日志是唯一的持久化手段，通过 Recover 打开时重放日志重建树；Compact 可以把日志重写为当前内容以缩短日志
+type LoggedTree[K constraints.Ordered, V any] struct {
+	tree *BPlusTree[K, V]
+	wal  *WAL[K, V]
+}
+
+// Recover 打开 path 处的预写日志，并把其中的记录重放到新建的 B+ 树中
+// 参数：
+//   - path: 日志文件路径，不存在时创建空日志
+//   - order: B+ 树的阶数
+//   - sync: 是否在每次修改后调用 fsync，见 OpenWAL
+//   - opts: B+ 树的可选配置
+//
+// 返回：
+//   - *LoggedTree[K, V]: 恢复后的 B+ 树，之后的修改继续追加到该日志
+//   - error: 打开或读取日志失败时返回的错误
+func Recover[K constraints.Ordered, V any](path string, order int, sync bool, opts ...Option) (*LoggedTree[K, V], error) {
+	wal, err := OpenWAL[K, V](path, sync)
+	if err != nil {
+		return nil, err
+	}
+	tree := NewBPlusTree[K, V](order, opts...)
+	err = wal.Replay(func(rec WALRecord[K, V]) error {
+		if rec.Op == WALInsert {
+			tree.Insert(rec.Key, rec.Value)
+		} else {
+			tree.Delete(rec.Key)
+		}
+		return nil
+	})
+	if err != nil {
+		wal.Close()
+		return nil, err
+	}
+	return &LoggedTree[K, V]{tree: tree, wal: wal}, nil
+}
+
+// Insert 先把插入记录追加到日志，成功后再插入键值对
+func (t *LoggedTree[K, V]) Insert(key K, value V) error {
+	if err := t.wal.Append(WALRecord[K, V]{Op: WALInsert, Key: key, Value: value}); err != nil {
+		return err
+	}
+	t.tree.Insert(key, value)
+	return nil
+}
+
+// Delete 先把删除记录追加到日志，成功后再删除键，返回键是否存在
+func (t *LoggedTree[K, V]) Delete(key K) (bool, error) {
+	if err := t.wal.Append(WALRecord[K, V]{Op: WALDelete, Key: key}); err != nil {
+		return false, err
+	}
+	return t.tree.Delete(key), nil
+}
+
+// Search 查找键对应的值
+// 时间复杂度: O(log n)
+func (t *LoggedTree[K, V]) Search(key K) (V, bool) {
+	return t.tree.Search(key)
+}
+
+// Tree 返回底层的 B+ 树，用于查询和遍历；直接修改它不会写入日志，重启后会丢失
+func (t *LoggedTree[K, V]) Tree() *BPlusTree[K, V] {
+	return t.tree
+}
+
+// Sync 把日志刷新到磁盘
+func (t *LoggedTree[K, V]) Sync() error {
+	return t.wal.Sync()
+}
+
+// Compact 把日志重写为只包含当前所有键值对的插入记录
+// 新日志先写入临时文件并落盘，再通过重命名原子地替换原日志，中途失败时原日志保持不变
+// 时间复杂度: O(n)
+func (t *LoggedTree[K, V]) Compact() error {
+	tmpPath := t.wal.path + ".tmp"
+	tmp, err := OpenWAL[K, V](tmpPath, false)
+	if err != nil {
+		return err
+	}
+	err = tmp.Reset()
+	for key, value := range t.tree.All() {
+		if err != nil {
+			break
+		}
+		err = tmp.Append(WALRecord[K, V]{Op: WALInsert, Key: key, Value: value})
+	}
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmpPath, t.wal.path)
+	}
+	if err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	wal, err := OpenWAL[K, V](t.wal.path, t.wal.sync)
+	if err != nil {
+		return err
+	}
+	t.wal.Close()
+	t.wal = wal
+	return nil
+}
+
+// Close 把日志刷新到磁盘并关闭
+func (t *LoggedTree[K, V]) Close() error {
+	if err := t.wal.Sync(); err != nil {
+		return err
+	}
+	return t.wal.Close()
+}
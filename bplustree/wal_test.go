@@ -0,0 +1,198 @@
+package bplustree
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// TestWAL 测试预写日志的追加、重放与截断
+func TestWAL(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tree.wal")
+	w, err := OpenWAL[string, int](path, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	records := []WALRecord[string, int]{
+		{Op: WALInsert, Key: "a", Value: 1},
+		{Op: WALInsert, Key: "b", Value: 2},
+		{Op: WALDelete, Key: "a"},
+	}
+	for _, rec := range records {
+		if err := w.Append(rec); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := slices.Collect(w.Records()); !slices.Equal(got, records) {
+		t.Errorf("读取到的记录为%v，期望%v", got, records)
+	}
+	w.Close()
+
+	t.Run("截断不完整的记录", func(t *testing.T) {
+		// 模拟崩溃时最后一条记录只写了一部分
+		info, _ := os.Stat(path)
+		if err := os.Truncate(path, info.Size()-2); err != nil {
+			t.Fatal(err)
+		}
+		w, err := OpenWAL[string, int](path, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer w.Close()
+		var got []WALRecord[string, int]
+		err = w.Replay(func(rec WALRecord[string, int]) error {
+			got = append(got, rec)
+			return nil
+		})
+		if err != nil || !slices.Equal(got, records[:2]) {
+			t.Fatalf("重放得到%v，错误为%v，期望只有前两条记录", got, err)
+		}
+		w.Append(WALRecord[string, int]{Op: WALInsert, Key: "c", Value: 3})
+		got = slices.Collect(w.Records())
+		if len(got) != 3 || got[2].Key != "c" {
+			t.Errorf("截断后追加的记录应紧跟在完整记录之后，实际为%v", got)
+		}
+	})
+
+	t.Run("清空", func(t *testing.T) {
+		w, err := OpenWAL[string, int](path, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer w.Close()
+		if err := w.Reset(); err != nil {
+			t.Fatal(err)
+		}
+		for range w.Records() {
+			t.Fatal("清空后不应有记录")
+		}
+	})
+}
+
+// TestRecover 测试通过重放预写日志恢复内存 B+ 树
+func TestRecover(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tree.wal")
+	tree, err := Recover[int, string](path, 4, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := range 100 {
+		if err := tree.Insert(i, "v"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for i := 0; i < 100; i += 2 {
+		if ok, err := tree.Delete(i); !ok || err != nil {
+			t.Fatalf("Delete(%d) = (%v, %v)", i, ok, err)
+		}
+	}
+	tree.Insert(1, "一")
+	if err := tree.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	check := func(t *testing.T, tree *LoggedTree[int, string]) {
+		t.Helper()
+		if tree.Tree().Size() != 50 {
+			t.Fatalf("恢复后期望50个键，实际为%d", tree.Tree().Size())
+		}
+		if v, ok := tree.Search(1); !ok || v != "一" {
+			t.Errorf("Search(1) = (%q, %v)，期望 (一, true)", v, ok)
+		}
+		if _, ok := tree.Search(2); ok {
+			t.Error("已删除的键不应被恢复")
+		}
+		if err := tree.Tree().Validate(); err != nil {
+			t.Error(err)
+		}
+	}
+
+	recovered, err := Recover[int, string](path, 4, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	check(t, recovered)
+
+	t.Run("压缩日志", func(t *testing.T) {
+		before, _ := os.Stat(path)
+		if err := recovered.Compact(); err != nil {
+			t.Fatal(err)
+		}
+		after, _ := os.Stat(path)
+		if after.Size() >= before.Size() {
+			t.Errorf("压缩后日志应变短，从%d字节变为%d字节", before.Size(), after.Size())
+		}
+		recovered.Insert(200, "新")
+		recovered.Close()
+
+		again, err := Recover[int, string](path, 4, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer again.Close()
+		if v, ok := again.Search(200); !ok || v != "新" {
+			t.Error("压缩后追加的记录应能被恢复")
+		}
+		again.Delete(200)
+		check(t, again)
+	})
+}
+
+// TestDiskTreeWAL 测试 DiskTree 在未 Flush 时崩溃后通过预写日志恢复
+func TestDiskTreeWAL(t *testing.T) {
+	dir := t.TempDir()
+	pager := NewMemPager(256)
+	tree, err := OpenDiskTree[int, int](pager, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w, err := OpenWAL[int, int](filepath.Join(dir, "tree.wal"), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := tree.AttachWAL(w); err != nil {
+		t.Fatal(err)
+	}
+	if err := tree.AttachWAL(w); err != ErrWALAttached {
+		t.Errorf("重复关联日志应返回 ErrWALAttached，实际为%v", err)
+	}
+	for i := range 50 {
+		tree.Put(i, i)
+	}
+	tree.Flush()
+	if len(slices.Collect(w.Records())) != 0 {
+		t.Error("Flush 之后日志应被清空")
+	}
+	for i := 50; i < 100; i++ {
+		tree.Put(i, i)
+	}
+	tree.Delete(0)
+	// 模拟崩溃：不调用 Flush，直接丢弃内存中的树
+	w.Close()
+
+	reopened, err := OpenDiskTree[int, int](pager, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reopened.Len() != 50 {
+		t.Fatalf("崩溃前 Flush 的键应有50个，实际为%d", reopened.Len())
+	}
+	w, err = OpenWAL[int, int](filepath.Join(dir, "tree.wal"), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := reopened.AttachWAL(w); err != nil {
+		t.Fatal(err)
+	}
+	defer reopened.Close()
+	if reopened.Len() != 99 {
+		t.Errorf("重放日志后应有99个键，实际为%d", reopened.Len())
+	}
+	if _, ok, _ := reopened.Get(0); ok {
+		t.Error("日志中删除的键不应存在")
+	}
+	if v, ok, _ := reopened.Get(99); !ok || v != 99 {
+		t.Error("日志中插入的键应被恢复")
+	}
+}
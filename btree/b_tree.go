@@ -0,0 +1,559 @@
+package btree
+
+import (
+	"cmp"
+	"fmt"
+	"iter"
+	"slices"
+
+	"godatastructure/metrics"
+	"golang.org/x/exp/constraints"
+)
+
+// item B 树中存储的键值对
+type item[K constraints.Ordered, V any] struct {
+	key   K
+	value V
+}
+
+// node B 树节点
+// 非叶子节点的 children 数量总是比 items 多一
+type node[K constraints.Ordered, V any] struct {
+	items    []item[K, V]  // 有序的键值对
+	children []*node[K, V] // 子节点，叶子节点为空
+}
+
+// BTree B 树（区别于 B+ 树，内部节点同样存储值）
+// 最小度数为 t 时，除根节点外每个节点包含 t-1 到 2t-1 个键。
+// 每个节点的键连续存放在切片中，相比每个节点只存一个键的红黑树，
+// 查找时的指针跳转更少，对 CPU 缓存更友好，适合存储大量有序键值对
+type BTree[K constraints.Ordered, V any] struct {
+	root     *node[K, V]
+	degree   int              // 最小度数
+	size     int              // 键值对数量
+	recorder metrics.Recorder // 指标记录器，为 nil 时不记录
+}
+
+// Option B 树的可选配置
+type Option func(*options)
+
+// options B 树的配置项
+type options struct {
+	recorder metrics.Recorder
+}
+
+// WithRecorder 使 B 树把插入、更新、删除、查找以及节点分裂、合并事件报告给 r
+// Clone 得到的副本不会继承记录器
+func WithRecorder(r metrics.Recorder) Option {
+	return func(o *options) {
+		o.recorder = r
+	}
+}
+
+// NewBTree 创建新的 B 树
+// 参数：
+//   - degree: 最小度数，必须大于等于2，每个节点最多包含 2*degree-1 个键
+//   - opts: 可选配置，例如 WithRecorder
+//
+// 返回：
+//   - *BTree[K, V]: 新创建的 B 树指针
+func NewBTree[K constraints.Ordered, V any](degree int, opts ...Option) *BTree[K, V] {
+	if degree < 2 {
+		panic("最小度数必须至少为2")
+	}
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return &BTree[K, V]{degree: degree, recorder: o.recorder}
+}
+
+// record 向记录器报告事件
+func (tree *BTree[K, V]) record(e metrics.Event) {
+	if tree.recorder != nil {
+		tree.recorder.Record(e, tree.size)
+	}
+}
+
+// Len 返回键值对数量
+// 时间复杂度: O(1)
+func (tree *BTree[K, V]) Len() int {
+	return tree.size
+}
+
+// Size 返回键值对数量，与 Len 相同
+// 时间复杂度: O(1)
+func (tree *BTree[K, V]) Size() int {
+	return tree.size
+}
+
+// IsEmpty 检查 B 树是否为空
+// 时间复杂度: O(1)
+func (tree *BTree[K, V]) IsEmpty() bool {
+	return tree.size == 0
+}
+
+// Compare 按键的自然顺序比较两个键
+func (tree *BTree[K, V]) Compare(a, b K) int {
+	return cmp.Compare(a, b)
+}
+
+// Height 返回树的高度，空树为0
+// 时间复杂度: O(log n)
+func (tree *BTree[K, V]) Height() int {
+	height := 0
+	for n := tree.root; n != nil; {
+		height++
+		if n.isLeaf() {
+			break
+		}
+		n = n.children[0]
+	}
+	return height
+}
+
+// Clear 清空 B 树
+// 时间复杂度: O(1)
+func (tree *BTree[K, V]) Clear() {
+	tree.root = nil
+	tree.size = 0
+}
+
+// Get 查找键对应的值
+// 时间复杂度: O(log n)
+func (tree *BTree[K, V]) Get(key K) (V, bool) {
+	tree.record(metrics.Lookup)
+	n := tree.root
+	for n != nil {
+		i, found := n.find(key)
+		if found {
+			return n.items[i].value, true
+		}
+		if n.isLeaf() {
+			break
+		}
+		n = n.children[i]
+	}
+	var zero V
+	return zero, false
+}
+
+// Put 插入或更新键值对
+// 时间复杂度: O(log n)
+func (tree *BTree[K, V]) Put(key K, value V) {
+	tree.Swap(key, value)
+}
+
+// Swap 插入或更新键值对，并返回被替换的旧值
+// 参数：
+//   - key: 键
+//   - value: 值
+//
+// 返回值：
+//   - V: 键已存在时被替换的旧值
+//   - bool: 键是否已存在
+//
+// 时间复杂度: O(log n)
+func (tree *BTree[K, V]) Swap(key K, value V) (V, bool) {
+	old, ok := tree.swap(key, value)
+	if ok {
+		tree.record(metrics.Update)
+	} else {
+		tree.record(metrics.Insert)
+	}
+	return old, ok
+}
+
+// swap 插入或更新键值对，返回值与 Swap 相同
+func (tree *BTree[K, V]) swap(key K, value V) (V, bool) {
+	if tree.root == nil {
+		tree.root = &node[K, V]{items: []item[K, V]{{key, value}}}
+		tree.size++
+		var zero V
+		return zero, false
+	}
+	// 根节点已满时提前分裂，树高加一
+	if len(tree.root.items) == tree.maxItems() {
+		root := &node[K, V]{children: []*node[K, V]{tree.root}}
+		tree.splitChild(root, 0)
+		tree.root = root
+	}
+
+	// 自顶向下插入，途中遇到满节点立即分裂，保证插入到叶子时不需要回溯
+	n := tree.root
+	for {
+		i, found := n.find(key)
+		if found {
+			old := n.items[i].value
+			n.items[i].value = value
+			return old, true
+		}
+		if n.isLeaf() {
+			n.items = slices.Insert(n.items, i, item[K, V]{key, value})
+			tree.size++
+			var zero V
+			return zero, false
+		}
+		if len(n.children[i].items) == tree.maxItems() {
+			tree.splitChild(n, i)
+			// 分裂后中间键上移到 n.items[i]，需要重新确定方向
+			switch {
+			case key == n.items[i].key:
+				old := n.items[i].value
+				n.items[i].value = value
+				return old, true
+			case key > n.items[i].key:
+				i++
+			}
+		}
+		n = n.children[i]
+	}
+}
+
+// Delete 删除键，返回键是否存在
+// 时间复杂度: O(log n)
+func (tree *BTree[K, V]) Delete(key K) bool {
+	_, ok := tree.LoadAndDelete(key)
+	return ok
+}
+
+// LoadAndDelete 删除键，返回被删除的值和键是否存在
+// 时间复杂度: O(log n)
+func (tree *BTree[K, V]) LoadAndDelete(key K) (V, bool) {
+	if tree.root == nil {
+		var zero V
+		return zero, false
+	}
+	value, ok := tree.delete(tree.root, key)
+	// 根节点的键被合并到子节点后，树高减一
+	if len(tree.root.items) == 0 {
+		if tree.root.isLeaf() {
+			tree.root = nil
+		} else {
+			tree.root = tree.root.children[0]
+		}
+	}
+	if ok {
+		tree.size--
+		tree.record(metrics.Delete)
+	}
+	return value, ok
+}
+
+// Min 返回最小的键值对
+// 时间复杂度: O(log n)
+func (tree *BTree[K, V]) Min() (K, V, bool) {
+	if tree.root == nil {
+		var key K
+		var value V
+		return key, value, false
+	}
+	n := tree.root
+	for !n.isLeaf() {
+		n = n.children[0]
+	}
+	return n.items[0].key, n.items[0].value, true
+}
+
+// Max 返回最大的键值对
+// 时间复杂度: O(log n)
+func (tree *BTree[K, V]) Max() (K, V, bool) {
+	if tree.root == nil {
+		var key K
+		var value V
+		return key, value, false
+	}
+	n := tree.root
+	for !n.isLeaf() {
+		n = n.children[len(n.children)-1]
+	}
+	last := n.items[len(n.items)-1]
+	return last.key, last.value, true
+}
+
+// Ascend 按键升序遍历所有键值对，fn 返回 false 时停止遍历
+// 时间复杂度: O(n)
+func (tree *BTree[K, V]) Ascend(fn func(key K, value V) bool) {
+	if tree.root != nil {
+		tree.root.ascend(nil, nil, fn)
+	}
+}
+
+// AscendRange 按键升序遍历区间 [greaterOrEqual, lessThan) 内的键值对，fn 返回 false 时停止遍历
+// 时间复杂度: O(log n + m)，m 为区间内的元素数量
+func (tree *BTree[K, V]) AscendRange(greaterOrEqual, lessThan K, fn func(key K, value V) bool) {
+	if tree.root != nil {
+		tree.root.ascend(&greaterOrEqual, &lessThan, fn)
+	}
+}
+
+// All 返回按键升序遍历所有键值对的迭代器
+// 遍历过程中不应修改 B 树
+func (tree *BTree[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		tree.Ascend(yield)
+	}
+}
+
+// maxItems 返回节点最多包含的键数量
+func (tree *BTree[K, V]) maxItems() int {
+	return 2*tree.degree - 1
+}
+
+// splitChild 将 parent 的第 i 个已满子节点从中间分裂为两个节点，中间键上移到 parent
+func (tree *BTree[K, V]) splitChild(parent *node[K, V], i int) {
+	child := parent.children[i]
+	mid := tree.degree - 1
+	middle := child.items[mid]
+
+	right := &node[K, V]{items: slices.Clone(child.items[mid+1:])}
+	clear(child.items[mid:])
+	child.items = child.items[:mid]
+	if !child.isLeaf() {
+		right.children = slices.Clone(child.children[mid+1:])
+		clear(child.children[mid+1:])
+		child.children = child.children[:mid+1]
+	}
+
+	parent.items = slices.Insert(parent.items, i, middle)
+	parent.children = slices.Insert(parent.children, i+1, right)
+	tree.record(metrics.Split)
+}
+
+// delete 从以 n 为根的子树中删除键
+// 调用时保证 n 是根节点或至少包含 degree 个键，因此删除一个键后仍满足最少键数的要求
+func (tree *BTree[K, V]) delete(n *node[K, V], key K) (V, bool) {
+	i, found := n.find(key)
+	if n.isLeaf() {
+		if !found {
+			var zero V
+			return zero, false
+		}
+		value := n.items[i].value
+		n.items = slices.Delete(n.items, i, i+1)
+		return value, true
+	}
+
+	if found {
+		value := n.items[i].value
+		switch {
+		case len(n.children[i].items) >= tree.degree:
+			// 用前驱替换后在左子树中删除前驱
+			pred := n.children[i].max()
+			n.items[i] = pred
+			tree.delete(n.children[i], pred.key)
+		case len(n.children[i+1].items) >= tree.degree:
+			// 用后继替换后在右子树中删除后继
+			succ := n.children[i+1].min()
+			n.items[i] = succ
+			tree.delete(n.children[i+1], succ.key)
+		default:
+			// 左右子节点都只有最少的键，合并后在合并节点中删除
+			tree.merge(n, i)
+			tree.delete(n.children[i], key)
+		}
+		return value, true
+	}
+
+	// 下降前保证子节点至少有 degree 个键
+	if len(n.children[i].items) < tree.degree {
+		i = tree.fill(n, i)
+	}
+	return tree.delete(n.children[i], key)
+}
+
+// fill 让 n 的第 i 个子节点至少包含 degree 个键，返回原子节点内容所在的子节点下标
+func (tree *BTree[K, V]) fill(n *node[K, V], i int) int {
+	switch {
+	case i > 0 && len(n.children[i-1].items) >= tree.degree:
+		// 从左兄弟借一个键
+		child, sibling := n.children[i], n.children[i-1]
+		last := len(sibling.items) - 1
+		child.items = slices.Insert(child.items, 0, n.items[i-1])
+		n.items[i-1] = sibling.items[last]
+		sibling.items = slices.Delete(sibling.items, last, last+1)
+		if !child.isLeaf() {
+			child.children = slices.Insert(child.children, 0, sibling.children[last+1])
+			sibling.children = slices.Delete(sibling.children, last+1, last+2)
+		}
+		return i
+	case i < len(n.items) && len(n.children[i+1].items) >= tree.degree:
+		// 从右兄弟借一个键
+		child, sibling := n.children[i], n.children[i+1]
+		child.items = append(child.items, n.items[i])
+		n.items[i] = sibling.items[0]
+		sibling.items = slices.Delete(sibling.items, 0, 1)
+		if !child.isLeaf() {
+			child.children = append(child.children, sibling.children[0])
+			sibling.children = slices.Delete(sibling.children, 0, 1)
+		}
+		return i
+	case i < len(n.items):
+		tree.merge(n, i)
+		return i
+	default:
+		tree.merge(n, i-1)
+		return i - 1
+	}
+}
+
+// merge 将 n 的第 i+1 个子节点和分隔键 n.items[i] 合并到第 i 个子节点
+func (tree *BTree[K, V]) merge(n *node[K, V], i int) {
+	left, right := n.children[i], n.children[i+1]
+	left.items = append(left.items, n.items[i])
+	left.items = append(left.items, right.items...)
+	left.children = append(left.children, right.children...)
+	n.items = slices.Delete(n.items, i, i+1)
+	n.children = slices.Delete(n.children, i+1, i+2)
+	tree.record(metrics.Merge)
+}
+
+// isLeaf 判断是否为叶子节点
+func (n *node[K, V]) isLeaf() bool {
+	return len(n.children) == 0
+}
+
+// find 二分查找第一个不小于 key 的键的下标，以及该键是否等于 key
+func (n *node[K, V]) find(key K) (int, bool) {
+	return slices.BinarySearchFunc(n.items, key, func(it item[K, V], key K) int {
+		switch {
+		case it.key < key:
+			return -1
+		case it.key > key:
+			return 1
+		}
+		return 0
+	})
+}
+
+// min 返回子树中最小的键值对
+func (n *node[K, V]) min() item[K, V] {
+	for !n.isLeaf() {
+		n = n.children[0]
+	}
+	return n.items[0]
+}
+
+// max 返回子树中最大的键值对
+func (n *node[K, V]) max() item[K, V] {
+	for !n.isLeaf() {
+		n = n.children[len(n.children)-1]
+	}
+	return n.items[len(n.items)-1]
+}
+
+// ascend 中序遍历子树中位于 [lo, hi) 内的键值对，lo、hi 为 nil 表示不限制
+// 返回 false 表示遍历已终止
+func (n *node[K, V]) ascend(lo, hi *K, fn func(key K, value V) bool) bool {
+	start := 0
+	if lo != nil {
+		start, _ = n.find(*lo)
+	}
+	for i := start; i <= len(n.items); i++ {
+		if !n.isLeaf() && !n.children[i].ascend(lo, hi, fn) {
+			return false
+		}
+		if i == len(n.items) {
+			break
+		}
+		if hi != nil && n.items[i].key >= *hi {
+			return false
+		}
+		if !fn(n.items[i].key, n.items[i].value) {
+			return false
+		}
+	}
+	return true
+}
+
+// Clone 返回结构相同的深拷贝
+// copier 用于复制每个值，为 nil 时直接赋值；键直接赋值
+// 时间复杂度: O(n)
+func (tree *BTree[K, V]) Clone(copier func(V) V) *BTree[K, V] {
+	return &BTree[K, V]{root: tree.root.clone(copier), degree: tree.degree, size: tree.size}
+}
+
+// clone 递归复制以 n 为根的子树
+func (n *node[K, V]) clone(copier func(V) V) *node[K, V] {
+	if n == nil {
+		return nil
+	}
+	clone := &node[K, V]{items: slices.Clone(n.items)}
+	if copier != nil {
+		for i := range clone.items {
+			clone.items[i].value = copier(clone.items[i].value)
+		}
+	}
+	if len(n.children) > 0 {
+		clone.children = make([]*node[K, V], len(n.children))
+		for i, child := range n.children {
+			clone.children[i] = child.clone(copier)
+		}
+	}
+	return clone
+}
+
+// Validate 检查 B 树的结构不变量，发现损坏时返回描述问题的错误
+// 检查内容：每个节点内的键严格递增且位于父节点划定的范围内，
+// 除根节点外每个节点包含 t-1 到 2t-1 个键，非叶子节点的子节点比键多一个，
+// 所有叶子节点深度相同，且键的总数等于记录的键值对数量
+// 时间复杂度: O(n)
+func (tree *BTree[K, V]) Validate() error {
+	if tree.root == nil {
+		if tree.size != 0 {
+			return fmt.Errorf("树为空，但记录的键值对数量为%d", tree.size)
+		}
+		return nil
+	}
+	count, leafDepth := 0, -1
+	if err := tree.validateNode(tree.root, nil, nil, 0, &leafDepth, &count); err != nil {
+		return err
+	}
+	if count != tree.size {
+		return fmt.Errorf("树中有%d个键，但记录的键值对数量为%d", count, tree.size)
+	}
+	return nil
+}
+
+// validateNode 检查以 n 为根的子树，lo 和 hi 为开区间边界，为 nil 时表示该侧没有限制
+func (tree *BTree[K, V]) validateNode(n *node[K, V], lo, hi *K, depth int, leafDepth, count *int) error {
+	if n != tree.root && (len(n.items) < tree.degree-1 || len(n.items) > 2*tree.degree-1) {
+		return fmt.Errorf("节点有%d个键，超出范围[%d, %d]", len(n.items), tree.degree-1, 2*tree.degree-1)
+	}
+	if n == tree.root && len(n.items) == 0 {
+		return fmt.Errorf("根节点没有键")
+	}
+	for i, it := range n.items {
+		if i > 0 && n.items[i-1].key >= it.key {
+			return fmt.Errorf("节点内的键不是严格递增的: %v 位于 %v 之前", n.items[i-1].key, it.key)
+		}
+		if (lo != nil && it.key <= *lo) || (hi != nil && it.key >= *hi) {
+			return fmt.Errorf("键 %v 超出父节点划定的范围", it.key)
+		}
+	}
+	*count += len(n.items)
+	if n.isLeaf() {
+		if *leafDepth == -1 {
+			*leafDepth = depth
+		} else if *leafDepth != depth {
+			return fmt.Errorf("叶子节点深度不一致: %d 与 %d", *leafDepth, depth)
+		}
+		return nil
+	}
+	if len(n.children) != len(n.items)+1 {
+		return fmt.Errorf("非叶子节点有%d个键，但有%d个子节点", len(n.items), len(n.children))
+	}
+	for i, child := range n.children {
+		childLo, childHi := lo, hi
+		if i > 0 {
+			childLo = &n.items[i-1].key
+		}
+		if i < len(n.items) {
+			childHi = &n.items[i].key
+		}
+		if err := tree.validateNode(child, childLo, childHi, depth+1, leafDepth, count); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,334 @@
+package btree
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"godatastructure/metrics"
+	"golang.org/x/exp/constraints"
+)
+
+// 辅助函数：验证B树的基本属性（键数范围、键有序、叶子深度一致）
+func validateBTree[K constraints.Ordered, V any](t *testing.T, tree *BTree[K, V]) {
+	t.Helper()
+	if tree.root == nil {
+		if tree.size != 0 {
+			t.Errorf("空树的大小应为0，实际为%d", tree.size)
+		}
+		return
+	}
+	leafDepth := -1
+	count := 0
+	var walk func(n *node[K, V], depth int, lo, hi *K)
+	walk = func(n *node[K, V], depth int, lo, hi *K) {
+		count += len(n.items)
+		if n != tree.root && len(n.items) < tree.degree-1 {
+			t.Errorf("节点键数量%d少于下限%d", len(n.items), tree.degree-1)
+		}
+		if len(n.items) > tree.maxItems() {
+			t.Errorf("节点键数量%d超过上限%d", len(n.items), tree.maxItems())
+		}
+		for i, it := range n.items {
+			if (lo != nil && it.key <= *lo) || (hi != nil && it.key >= *hi) {
+				t.Errorf("键%v超出父节点限定的范围", it.key)
+			}
+			if i > 0 && n.items[i-1].key >= it.key {
+				t.Error("节点内的键不是严格升序")
+			}
+		}
+		if n.isLeaf() {
+			if leafDepth == -1 {
+				leafDepth = depth
+			} else if leafDepth != depth {
+				t.Error("叶子节点深度不一致")
+			}
+			return
+		}
+		if len(n.children) != len(n.items)+1 {
+			t.Errorf("子节点数量应该等于键数量+1，当前键数量：%d，子节点数量：%d",
+				len(n.items), len(n.children))
+			return
+		}
+		for i, child := range n.children {
+			childLo, childHi := lo, hi
+			if i > 0 {
+				childLo = &n.items[i-1].key
+			}
+			if i < len(n.items) {
+				childHi = &n.items[i].key
+			}
+			walk(child, depth+1, childLo, childHi)
+		}
+	}
+	walk(tree.root, 1, nil, nil)
+	if count != tree.size {
+		t.Errorf("键数量%d与记录的大小%d不一致", count, tree.size)
+	}
+	if leafDepth != tree.Height() {
+		t.Errorf("高度%d与叶子深度%d不一致", tree.Height(), leafDepth)
+	}
+}
+
+func TestBTreeBasicOperations(t *testing.T) {
+	tree := NewBTree[int, string](2)
+
+	t.Run("空树操作", func(t *testing.T) {
+		if _, found := tree.Get(1); found {
+			t.Error("空树不应该找到任何值")
+		}
+		if tree.Delete(1) {
+			t.Error("空树删除应返回false")
+		}
+		if _, _, ok := tree.Min(); ok {
+			t.Error("空树不应有最小值")
+		}
+		if tree.Height() != 0 {
+			t.Error("空树高度应为0")
+		}
+	})
+
+	t.Run("插入和查找", func(t *testing.T) {
+		for i := 1; i <= 20; i++ {
+			if _, replaced := tree.Swap(i, string(rune('a'+i))); replaced {
+				t.Errorf("插入新键%d不应替换旧值", i)
+			}
+		}
+		for i := 1; i <= 20; i++ {
+			if v, found := tree.Get(i); !found || v != string(rune('a'+i)) {
+				t.Errorf("Get(%d) = (%v, %v)", i, v, found)
+			}
+		}
+		if old, replaced := tree.Swap(5, "五"); !replaced || old != "f" {
+			t.Errorf("更新应返回旧值，got (%v, %v)", old, replaced)
+		}
+		if v, _ := tree.Get(5); v != "五" {
+			t.Errorf("更新后期望五，实际为%v", v)
+		}
+		if tree.Len() != 20 {
+			t.Errorf("期望大小为20，实际为%d", tree.Len())
+		}
+		if k, _, _ := tree.Min(); k != 1 {
+			t.Errorf("期望最小键为1，实际为%d", k)
+		}
+		if k, _, _ := tree.Max(); k != 20 {
+			t.Errorf("期望最大键为20，实际为%d", k)
+		}
+		validateBTree(t, tree)
+	})
+
+	t.Run("删除", func(t *testing.T) {
+		if v, found := tree.LoadAndDelete(5); !found || v != "五" {
+			t.Errorf("LoadAndDelete(5) = (%v, %v)", v, found)
+		}
+		if tree.Delete(5) {
+			t.Error("重复删除应返回false")
+		}
+		validateBTree(t, tree)
+		for i := 1; i <= 20; i++ {
+			tree.Delete(i)
+			validateBTree(t, tree)
+		}
+		if tree.Len() != 0 || tree.root != nil {
+			t.Error("删除所有键后树应为空")
+		}
+	})
+}
+
+func TestBTreeAscendRange(t *testing.T) {
+	tree := NewBTree[int, int](3)
+	for i := 0; i < 100; i += 2 {
+		tree.Put(i, i*10)
+	}
+
+	var keys []int
+	tree.AscendRange(11, 21, func(key, value int) bool {
+		if value != key*10 {
+			t.Errorf("键%d对应的值错误: %d", key, value)
+		}
+		keys = append(keys, key)
+		return true
+	})
+	if !slices.Equal(keys, []int{12, 14, 16, 18, 20}) {
+		t.Errorf("范围遍历结果错误: %v", keys)
+	}
+
+	keys = nil
+	tree.Ascend(func(key, value int) bool {
+		keys = append(keys, key)
+		return len(keys) < 3
+	})
+	if !slices.Equal(keys, []int{0, 2, 4}) {
+		t.Errorf("提前终止的遍历结果错误: %v", keys)
+	}
+
+	count := 0
+	tree.AscendRange(200, 300, func(key, value int) bool {
+		count++
+		return true
+	})
+	if count != 0 {
+		t.Error("超出范围的遍历不应返回任何元素")
+	}
+}
+
+func TestBTreeRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, degree := range []int{2, 3, 8} {
+		tree := NewBTree[int, int](degree)
+		want := make(map[int]int)
+		for i := 0; i < 5000; i++ {
+			k := r.Intn(1000)
+			if r.Intn(3) == 0 {
+				v, found := tree.LoadAndDelete(k)
+				wv, wfound := want[k]
+				if found != wfound || v != wv {
+					t.Fatalf("度数%d: LoadAndDelete(%d) = (%d, %v)，期望 (%d, %v)", degree, k, v, found, wv, wfound)
+				}
+				delete(want, k)
+			} else {
+				tree.Put(k, i)
+				want[k] = i
+			}
+		}
+		validateBTree(t, tree)
+
+		var keys []int
+		tree.Ascend(func(key, value int) bool {
+			if want[key] != value {
+				t.Errorf("度数%d: 键%d的值错误", degree, key)
+			}
+			keys = append(keys, key)
+			return true
+		})
+		if len(keys) != len(want) || !slices.IsSorted(keys) {
+			t.Errorf("度数%d: 遍历结果错误", degree)
+		}
+	}
+}
+
+func TestBTreeInvalidDegree(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("度数小于2应该导致panic")
+		}
+	}()
+	NewBTree[int, int](1)
+}
+
+func BenchmarkBTree(b *testing.B) {
+	b.Run("插入", func(b *testing.B) {
+		tree := NewBTree[int, int](32)
+		for i := 0; i < b.N; i++ {
+			tree.Put(i, i)
+		}
+	})
+	b.Run("查找", func(b *testing.B) {
+		tree := NewBTree[int, int](32)
+		for i := 0; i < 100000; i++ {
+			tree.Put(i, i)
+		}
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			tree.Get(i % 100000)
+		}
+	})
+}
+
+func TestBTreeAll(t *testing.T) {
+	tree := NewBTree[int, int](2)
+	for i := 9; i >= 0; i-- {
+		tree.Put(i, -i)
+	}
+	var keys []int
+	for k, v := range tree.All() {
+		if v != -k {
+			t.Errorf("键%d对应的值错误: %d", k, v)
+		}
+		keys = append(keys, k)
+		if k == 4 {
+			break
+		}
+	}
+	if !slices.Equal(keys, []int{0, 1, 2, 3, 4}) {
+		t.Errorf("遍历结果错误: %v", keys)
+	}
+}
+
+// TestClone 测试 B 树的深拷贝
+func TestClone(t *testing.T) {
+	tree := NewBTree[int, []int](2)
+	for i := 0; i < 100; i++ {
+		tree.Put(i, []int{i})
+	}
+	clone := tree.Clone(slices.Clone)
+	validateBTree(t, clone)
+	if clone.Len() != 100 || clone.Height() != tree.Height() {
+		t.Fatalf("拷贝的结构应与原树相同")
+	}
+	v, _ := tree.Get(10)
+	v[0] = -1
+	for i := 0; i < 50; i++ {
+		tree.Delete(i)
+	}
+	validateBTree(t, clone)
+	if v, ok := clone.Get(10); !ok || v[0] != 10 {
+		t.Errorf("修改原树不应影响拷贝，Get(10) = (%v, %v)", v, ok)
+	}
+}
+
+// TestValidate 测试 B 树结构检查
+func TestValidate(t *testing.T) {
+	tree := NewBTree[int, int](2)
+	if err := tree.Validate(); err != nil {
+		t.Errorf("空树应通过检查: %v", err)
+	}
+	for i := 0; i < 200; i++ {
+		tree.Put((i*37)%211, i)
+	}
+	for i := 0; i < 100; i += 3 {
+		tree.Delete(i)
+	}
+	if err := tree.Validate(); err != nil {
+		t.Fatalf("正常使用后应通过检查: %v", err)
+	}
+
+	leaf := tree.root
+	for !leaf.isLeaf() {
+		leaf = leaf.children[0]
+	}
+	leaf.items = append(leaf.items, leaf.items...)
+	if tree.Validate() == nil {
+		t.Error("应发现节点内的键重复")
+	}
+	leaf.items = leaf.items[:len(leaf.items)/2]
+
+	tree.size++
+	if tree.Validate() == nil {
+		t.Error("应发现键的数量不一致")
+	}
+}
+
+// TestWithRecorder 测试 B 树向记录器报告事件
+func TestWithRecorder(t *testing.T) {
+	var c metrics.Counters
+	tree := NewBTree[int, int](2, WithRecorder(&c))
+	for i := 0; i < 100; i++ {
+		tree.Put(i, i)
+	}
+	tree.Put(0, 1)
+	tree.Get(0)
+	for i := 0; i < 100; i++ {
+		tree.Delete(i)
+	}
+	if c.Count(metrics.Insert) != 100 || c.Count(metrics.Update) != 1 ||
+		c.Count(metrics.Lookup) != 1 || c.Count(metrics.Delete) != 100 {
+		t.Errorf("事件计数错误: %v", c.Snapshot())
+	}
+	if c.Count(metrics.Split) == 0 || c.Count(metrics.Merge) == 0 {
+		t.Errorf("应记录节点分裂与合并: %v", c.Snapshot())
+	}
+	if c.Peak() != 100 {
+		t.Errorf("期望峰值为100，实际为%d", c.Peak())
+	}
+}
@@ -0,0 +1,271 @@
+package cache
+
+import "container/list"
+
+// arcList 条目所在的 ARC 链表
+type arcList int
+
+const (
+	arcT1 arcList = iota // 只被访问过一次的常驻条目
+	arcT2                // 被访问过多次的常驻条目
+	arcB1                // 从 T1 淘汰的幽灵条目（只保留键）
+	arcB2                // 从 T2 淘汰的幽灵条目（只保留键）
+)
+
+// arcEntry ARC 缓存中的条目
+type arcEntry[K comparable, V any] struct {
+	key   K
+	value V             // 幽灵条目的值已被清除
+	elem  *list.Element // 条目在所在链表中的节点
+	where arcList       // 条目所在的链表
+}
+
+// ARCCache 自适应替换缓存（Adaptive Replacement Cache）
+// 同时维护“最近访问”（T1）和“频繁访问”（T2）两个常驻链表，
+// 以及记录最近被淘汰键的幽灵链表 B1/B2。幽灵命中时动态调整 T1 的目标大小 p，
+// 从而在偏重时间局部性和偏重访问频率的负载之间自动平衡
+// 非并发安全
+type ARCCache[K comparable, V any] struct {
+	capacity int                   // 容量（常驻条目的上限）
+	p        int                   // T1 的目标大小
+	lists    [4]*list.List         // T1、T2、B1、B2，表头为最近使用的条目
+	items    map[K]*arcEntry[K, V] // 所有链表中的条目
+	onEvict  func(key K, value V)  // 淘汰回调
+	stats    Stats                 // 命中统计
+}
+
+// NewARC 创建 ARC 缓存
+// 参数：
+//   - capacity: 缓存容量，必须大于0
+//   - onEvict: 条目因容量不足被淘汰时的回调，可以为 nil
+//
+// 时间复杂度: O(1)
+func NewARC[K comparable, V any](capacity int, onEvict func(key K, value V)) *ARCCache[K, V] {
+	if capacity <= 0 {
+		panic("缓存容量必须大于0")
+	}
+	c := &ARCCache[K, V]{
+		capacity: capacity,
+		items:    make(map[K]*arcEntry[K, V], 2*capacity),
+		onEvict:  onEvict,
+	}
+	for i := range c.lists {
+		c.lists[i] = list.New()
+	}
+	return c
+}
+
+// Get 获取键对应的值，命中时将其移动到 T2
+// 时间复杂度: O(1)
+func (c *ARCCache[K, V]) Get(key K) (V, bool) {
+	e, ok := c.items[key]
+	if !ok || !e.resident() {
+		c.stats.Misses++
+		var zero V
+		return zero, false
+	}
+	c.stats.Hits++
+	c.moveTo(e, arcT2)
+	return e.value, true
+}
+
+// Put 插入或更新键值对
+// 时间复杂度: O(1)
+func (c *ARCCache[K, V]) Put(key K, value V) {
+	e, ok := c.items[key]
+	if ok && e.resident() {
+		e.value = value
+		c.moveTo(e, arcT2)
+		return
+	}
+
+	if ok {
+		// 幽灵命中：说明该键被过早淘汰，增大其所在一侧的目标大小
+		b1, b2 := c.lists[arcB1].Len(), c.lists[arcB2].Len()
+		if e.where == arcB1 {
+			c.p = min(c.capacity, c.p+max(b2/b1, 1))
+		} else {
+			c.p = max(0, c.p-max(b1/b2, 1))
+		}
+		if c.residentLen() >= c.capacity {
+			c.replace(e.where == arcB2)
+		}
+		e.value = value
+		c.moveTo(e, arcT2)
+		return
+	}
+
+	// 完全未命中
+	t1, b1 := c.lists[arcT1].Len(), c.lists[arcB1].Len()
+	total := t1 + b1 + c.lists[arcT2].Len() + c.lists[arcB2].Len()
+	switch {
+	case t1+b1 >= c.capacity:
+		if t1 < c.capacity {
+			c.dropGhost(arcB1)
+			if c.residentLen() >= c.capacity {
+				c.replace(false)
+			}
+		} else {
+			// B1 为空且 T1 已满，直接淘汰 T1 中最久未使用的条目
+			c.evict(c.back(arcT1))
+		}
+	case total >= c.capacity:
+		if total >= 2*c.capacity {
+			c.dropGhost(arcB2)
+		}
+		if c.residentLen() >= c.capacity {
+			c.replace(false)
+		}
+	}
+
+	e = &arcEntry[K, V]{key: key, value: value, where: arcT1}
+	e.elem = c.lists[arcT1].PushFront(e)
+	c.items[key] = e
+}
+
+// Peek 获取键对应的值，但不更新访问记录，也不计入命中统计
+// 时间复杂度: O(1)
+func (c *ARCCache[K, V]) Peek(key K) (V, bool) {
+	e, ok := c.items[key]
+	if !ok || !e.resident() {
+		var zero V
+		return zero, false
+	}
+	return e.value, true
+}
+
+// Remove 删除键，返回键是否在缓存中
+// 幽灵记录会被一并清除；主动删除不会触发淘汰回调
+// 时间复杂度: O(1)
+func (c *ARCCache[K, V]) Remove(key K) bool {
+	e, ok := c.items[key]
+	if !ok {
+		return false
+	}
+	c.lists[e.where].Remove(e.elem)
+	delete(c.items, key)
+	return e.resident()
+}
+
+// Len 返回缓存中的常驻条目数量
+// 时间复杂度: O(1)
+func (c *ARCCache[K, V]) Len() int {
+	return c.residentLen()
+}
+
+// Size 返回缓存中的条目数量，与 Len 相同
+// 时间复杂度: O(1)
+func (c *ARCCache[K, V]) Size() int {
+	return c.Len()
+}
+
+// IsEmpty 检查缓存是否为空
+// 时间复杂度: O(1)
+func (c *ARCCache[K, V]) IsEmpty() bool {
+	return c.Len() == 0
+}
+
+// Clone 返回缓存的深拷贝，保留常驻条目、幽灵记录、目标大小 p、淘汰回调和命中统计
+// copier 用于复制每个常驻条目的值，为 nil 时直接赋值
+// 时间复杂度: O(n)
+func (c *ARCCache[K, V]) Clone(copier func(V) V) *ARCCache[K, V] {
+	clone := NewARC(c.capacity, c.onEvict)
+	clone.p = c.p
+	clone.stats = c.stats
+	for where, l := range c.lists {
+		for elem := l.Front(); elem != nil; elem = elem.Next() {
+			e := *elem.Value.(*arcEntry[K, V])
+			if copier != nil && e.resident() {
+				e.value = copier(e.value)
+			}
+			e.elem = clone.lists[where].PushBack(&e)
+			clone.items[e.key] = &e
+		}
+	}
+	return clone
+}
+
+// Cap 返回缓存容量
+// 时间复杂度: O(1)
+func (c *ARCCache[K, V]) Cap() int {
+	return c.capacity
+}
+
+// Clear 清空缓存和幽灵记录，统计信息保持不变
+// 时间复杂度: O(n)
+func (c *ARCCache[K, V]) Clear() {
+	clear(c.items)
+	for _, l := range c.lists {
+		l.Init()
+	}
+	c.p = 0
+}
+
+// Stats 返回命中统计
+// 时间复杂度: O(1)
+func (c *ARCCache[K, V]) Stats() Stats {
+	return c.stats
+}
+
+// resident 判断条目是否为常驻条目
+func (e *arcEntry[K, V]) resident() bool {
+	return e.where == arcT1 || e.where == arcT2
+}
+
+// residentLen 返回常驻条目数量
+func (c *ARCCache[K, V]) residentLen() int {
+	return c.lists[arcT1].Len() + c.lists[arcT2].Len()
+}
+
+// moveTo 将条目移动到目标链表的表头
+func (c *ARCCache[K, V]) moveTo(e *arcEntry[K, V], target arcList) {
+	c.lists[e.where].Remove(e.elem)
+	e.where = target
+	e.elem = c.lists[target].PushFront(e)
+}
+
+// back 返回链表中最久未使用的条目
+func (c *ARCCache[K, V]) back(l arcList) *arcEntry[K, V] {
+	return c.lists[l].Back().Value.(*arcEntry[K, V])
+}
+
+// replace 根据目标大小 p 从 T1 或 T2 淘汰一个条目到对应的幽灵链表
+func (c *ARCCache[K, V]) replace(inB2 bool) {
+	t1 := c.lists[arcT1].Len()
+	if t1 > 0 && (t1 > c.p || (inB2 && t1 == c.p)) {
+		e := c.back(arcT1)
+		c.onEvicted(e)
+		c.moveTo(e, arcB1)
+	} else if c.lists[arcT2].Len() > 0 {
+		e := c.back(arcT2)
+		c.onEvicted(e)
+		c.moveTo(e, arcB2)
+	}
+}
+
+// evict 彻底淘汰常驻条目，不保留幽灵记录
+func (c *ARCCache[K, V]) evict(e *arcEntry[K, V]) {
+	c.onEvicted(e)
+	c.lists[e.where].Remove(e.elem)
+	delete(c.items, e.key)
+}
+
+// dropGhost 删除幽灵链表中最久的记录
+func (c *ARCCache[K, V]) dropGhost(l arcList) {
+	if c.lists[l].Len() == 0 {
+		return
+	}
+	e := c.back(l)
+	c.lists[l].Remove(e.elem)
+	delete(c.items, e.key)
+}
+
+// onEvicted 记录淘汰事件、调用回调并释放条目的值
+func (c *ARCCache[K, V]) onEvicted(e *arcEntry[K, V]) {
+	c.stats.Evictions++
+	if c.onEvict != nil {
+		c.onEvict(e.key, e.value)
+	}
+	var zero V
+	e.value = zero
+}
@@ -0,0 +1,128 @@
+package cache
+
+import (
+	"testing"
+)
+
+// TestARCCacheBasic 测试基本的读写操作
+func TestARCCacheBasic(t *testing.T) {
+	c := NewARC[string, int](2, nil)
+
+	c.Put("a", 1)
+	c.Put("b", 2)
+	if v, ok := c.Get("a"); !ok || v != 1 {
+		t.Errorf("Get(a) = (%d, %v)，期望 (1, true)", v, ok)
+	}
+	c.Put("b", 20)
+	if v, _ := c.Peek("b"); v != 20 {
+		t.Errorf("更新后期望20，实际为%d", v)
+	}
+	if c.Len() != 2 {
+		t.Errorf("期望大小为2，实际为%d", c.Len())
+	}
+
+	if !c.Remove("a") || c.Remove("a") {
+		t.Error("Remove返回值错误")
+	}
+	c.Clear()
+	if c.Len() != 0 || len(c.items) != 0 {
+		t.Error("清空后缓存应为空")
+	}
+}
+
+// TestARCCacheGhostHit 测试幽灵命中时调整目标大小
+func TestARCCacheGhostHit(t *testing.T) {
+	var evicted []int
+	c := NewARC[int, int](2, func(key int, value int) {
+		evicted = append(evicted, key)
+	})
+
+	c.Put(1, 1)
+	c.Get(1) // 1 进入 T2
+	c.Put(2, 2)
+	c.Put(3, 3) // 2 从 T1 被淘汰到 B1
+	if _, ok := c.Peek(2); ok {
+		t.Error("2应该被淘汰")
+	}
+	if e := c.items[2]; e == nil || e.where != arcB1 {
+		t.Fatal("2应该保留在幽灵链表B1中")
+	}
+
+	c.Put(2, 20) // B1 幽灵命中，增大 T1 的目标大小并直接进入 T2
+	if c.p != 1 {
+		t.Errorf("幽灵命中后p应为1，实际为%d", c.p)
+	}
+	if e := c.items[2]; e.where != arcT2 || e.value != 20 {
+		t.Error("幽灵命中的键应进入T2")
+	}
+	if e := c.items[1]; e == nil || e.where != arcB2 {
+		t.Error("1应该从T2被淘汰到B2")
+	}
+	if c.Len() > c.Cap() {
+		t.Errorf("常驻条目数%d超过容量%d", c.Len(), c.Cap())
+	}
+	if len(evicted) != 2 || evicted[0] != 2 || evicted[1] != 1 {
+		t.Errorf("淘汰顺序错误: %v", evicted)
+	}
+}
+
+// TestARCCacheScanResistance 测试扫描负载不会冲掉频繁访问的条目
+func TestARCCacheScanResistance(t *testing.T) {
+	c := NewARC[int, int](10, nil)
+	hot := []int{1, 2, 3, 4, 5}
+	for round := 0; round < 3; round++ {
+		for _, k := range hot {
+			if _, ok := c.Get(k); !ok {
+				c.Put(k, k)
+			}
+		}
+	}
+
+	// 一次性扫描大量冷数据
+	for k := 100; k < 200; k++ {
+		c.Put(k, k)
+	}
+
+	for _, k := range hot {
+		if _, ok := c.Peek(k); !ok {
+			t.Errorf("热点键%d不应被扫描冲掉", k)
+		}
+	}
+	if c.Len() > c.Cap() {
+		t.Errorf("常驻条目数%d超过容量%d", c.Len(), c.Cap())
+	}
+	if len(c.items) > 2*c.Cap() {
+		t.Errorf("条目总数%d超过容量的两倍", len(c.items))
+	}
+}
+
+// TestARCClone 测试 ARC 缓存的深拷贝
+func TestARCClone(t *testing.T) {
+	c := NewARC[int, int](2, nil)
+	c.Put(1, 1)
+	c.Put(2, 2)
+	c.Get(1)
+	c.Put(3, 3)
+
+	clone := c.Clone(nil)
+	if clone.Len() != c.Len() || clone.p != c.p || len(clone.items) != len(c.items) {
+		t.Fatal("拷贝应保留常驻条目和幽灵记录")
+	}
+	// 幽灵命中会调整 p，原缓存不受影响
+	for k := range c.items {
+		if !c.items[k].resident() {
+			clone.Put(k, k)
+			break
+		}
+	}
+	if clone.p == c.p {
+		t.Error("幽灵命中应调整拷贝的目标大小")
+	}
+	for _, l := range c.lists {
+		for e := l.Front(); e != nil; e = e.Next() {
+			if entry := e.Value.(*arcEntry[int, int]); c.items[entry.key] != entry {
+				t.Fatal("原缓存的条目不应被修改")
+			}
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package cache
+
+// Cache 缓存接口
+// LRU、LFU、ARC 等不同淘汰策略的缓存均实现此接口，便于替换和对比命中率
+type Cache[K comparable, V any] interface {
+	// Get 获取键对应的值，会更新该键的访问记录并计入命中统计
+	Get(key K) (V, bool)
+
+	// Put 插入或更新键值对，缓存已满时按淘汰策略淘汰条目
+	Put(key K, value V)
+
+	// Peek 获取键对应的值，不更新访问记录，也不计入命中统计
+	Peek(key K) (V, bool)
+
+	// Remove 删除键，返回键是否存在
+	Remove(key K) bool
+
+	// Len 返回缓存中的条目数量
+	Len() int
+
+	// Size 返回缓存中的条目数量，与 Len 相同
+	Size() int
+
+	// IsEmpty 检查缓存是否为空
+	IsEmpty() bool
+
+	// Cap 返回缓存容量
+	Cap() int
+
+	// Clear 清空缓存
+	Clear()
+
+	// Stats 返回命中统计
+	Stats() Stats
+}
+
+// 编译期检查各缓存是否实现了 Cache 接口
+var (
+	_ Cache[int, int] = (*LRUCache[int, int])(nil)
+	_ Cache[int, int] = (*LFUCache[int, int])(nil)
+	_ Cache[int, int] = (*ARCCache[int, int])(nil)
+)
+
+// Stats 缓存的命中统计
+type Stats struct {
+	Hits      uint64 // 命中次数
+	Misses    uint64 // 未命中次数
+	Evictions uint64 // 淘汰次数
+}
+
+// HitRate 返回命中率，没有任何访问时返回0
+func (s Stats) HitRate() float64 {
+	total := s.Hits + s.Misses
+	if total == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(total)
+}
@@ -0,0 +1,76 @@
+package cache
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// runTrace 在访问序列上运行缓存，未命中时写入缓存
+func runTrace(c Cache[int, int], trace []int) Stats {
+	for _, k := range trace {
+		if _, ok := c.Get(k); !ok {
+			c.Put(k, k)
+		}
+	}
+	return c.Stats()
+}
+
+// TestCachePolicies 通过统一的接口在同一访问序列上对比各淘汰策略
+func TestCachePolicies(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	trace := make([]int, 0, 20000)
+	for i := 0; i < 20000; i++ {
+		if r.Intn(10) < 8 {
+			trace = append(trace, r.Intn(50)) // 80% 的访问集中在少量热点键
+		} else {
+			trace = append(trace, 1000+r.Intn(5000))
+		}
+	}
+
+	policies := map[string]Cache[int, int]{
+		"LRU": NewLRU[int, int](64, nil),
+		"LFU": NewLFU[int, int](64, nil),
+		"ARC": NewARC[int, int](64, nil),
+	}
+	for name, c := range policies {
+		stats := runTrace(c, trace)
+		if stats.Hits+stats.Misses != uint64(len(trace)) {
+			t.Errorf("%s: 访问次数统计错误 %+v", name, stats)
+		}
+		if c.Len() > c.Cap() {
+			t.Errorf("%s: 条目数%d超过容量%d", name, c.Len(), c.Cap())
+		}
+		if stats.HitRate() < 0.5 {
+			t.Errorf("%s: 命中率%.3f过低", name, stats.HitRate())
+		}
+		t.Logf("%s 命中率: %.3f", name, stats.HitRate())
+	}
+}
+
+// 性能测试
+func BenchmarkCachePolicies(b *testing.B) {
+	r := rand.New(rand.NewSource(1))
+	trace := make([]int, 4096)
+	for i := range trace {
+		trace[i] = int(r.ExpFloat64() * 200)
+	}
+	constructors := []struct {
+		name string
+		new  func() Cache[int, int]
+	}{
+		{"LRU", func() Cache[int, int] { return NewLRU[int, int](256, nil) }},
+		{"LFU", func() Cache[int, int] { return NewLFU[int, int](256, nil) }},
+		{"ARC", func() Cache[int, int] { return NewARC[int, int](256, nil) }},
+	}
+	for _, cc := range constructors {
+		b.Run(cc.name, func(b *testing.B) {
+			c := cc.new()
+			for i := 0; i < b.N; i++ {
+				k := trace[i%len(trace)]
+				if _, ok := c.Get(k); !ok {
+					c.Put(k, k)
+				}
+			}
+		})
+	}
+}
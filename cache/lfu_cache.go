@@ -0,0 +1,246 @@
+package cache
+
+// lfuEntry LFU 缓存中的条目，同时是频次链表中的节点
+type lfuEntry[K comparable, V any] struct {
+	key   K
+	value V
+	freq  int             // 访问频次
+	prev  *lfuEntry[K, V] // 频次链表中的前一个节点
+	next  *lfuEntry[K, V] // 频次链表中的后一个节点
+}
+
+// lfuList 同一访问频次的条目组成的双向循环链表
+// 新访问的条目插入表头，淘汰时从表尾删除，因此同频次下按最近最少使用淘汰
+type lfuList[K comparable, V any] struct {
+	sentinel lfuEntry[K, V] // 哨兵节点
+	size     int            // 链表长度
+}
+
+// newLFUList 创建空的频次链表
+func newLFUList[K comparable, V any]() *lfuList[K, V] {
+	l := &lfuList[K, V]{}
+	l.sentinel.prev = &l.sentinel
+	l.sentinel.next = &l.sentinel
+	return l
+}
+
+// pushFront 将条目插入表头
+func (l *lfuList[K, V]) pushFront(e *lfuEntry[K, V]) {
+	e.prev = &l.sentinel
+	e.next = l.sentinel.next
+	l.sentinel.next.prev = e
+	l.sentinel.next = e
+	l.size++
+}
+
+// remove 从链表中删除条目
+func (l *lfuList[K, V]) remove(e *lfuEntry[K, V]) {
+	e.prev.next = e.next
+	e.next.prev = e.prev
+	e.prev = nil
+	e.next = nil
+	l.size--
+}
+
+// back 返回表尾条目，链表为空时返回 nil
+func (l *lfuList[K, V]) back() *lfuEntry[K, V] {
+	if l.size == 0 {
+		return nil
+	}
+	return l.sentinel.prev
+}
+
+// LFUCache 最不经常使用（LFU）缓存
+// 使用“频次桶 + 双向链表”的设计，Get/Put/淘汰均为 O(1)
+// 访问频次相同时淘汰最久未使用的条目
+// 非并发安全
+type LFUCache[K comparable, V any] struct {
+	capacity int                    // 容量
+	items    map[K]*lfuEntry[K, V]  // 键到条目的映射
+	freqs    map[int]*lfuList[K, V] // 访问频次到链表的映射
+	minFreq  int                    // 当前最小访问频次
+	onEvict  func(key K, value V)   // 淘汰回调
+	stats    Stats                  // 命中统计
+}
+
+// NewLFU 创建 LFU 缓存
+// 参数：
+//   - capacity: 缓存容量，必须大于0
+//   - onEvict: 条目因容量不足被淘汰时的回调，可以为 nil
+//
+// 时间复杂度: O(1)
+func NewLFU[K comparable, V any](capacity int, onEvict func(key K, value V)) *LFUCache[K, V] {
+	if capacity <= 0 {
+		panic("缓存容量必须大于0")
+	}
+	return &LFUCache[K, V]{
+		capacity: capacity,
+		items:    make(map[K]*lfuEntry[K, V], capacity),
+		freqs:    make(map[int]*lfuList[K, V]),
+		onEvict:  onEvict,
+	}
+}
+
+// Get 获取键对应的值，命中时增加其访问频次
+// 时间复杂度: O(1)
+func (c *LFUCache[K, V]) Get(key K) (V, bool) {
+	e, ok := c.items[key]
+	if !ok {
+		c.stats.Misses++
+		var zero V
+		return zero, false
+	}
+	c.stats.Hits++
+	c.touch(e)
+	return e.value, true
+}
+
+// Put 插入或更新键值对
+// 更新已存在的键时增加其访问频次；插入新键且缓存已满时先淘汰一个条目
+// 时间复杂度: O(1)
+func (c *LFUCache[K, V]) Put(key K, value V) {
+	if e, ok := c.items[key]; ok {
+		e.value = value
+		c.touch(e)
+		return
+	}
+
+	if len(c.items) >= c.capacity {
+		c.evict()
+	}
+
+	e := &lfuEntry[K, V]{key: key, value: value, freq: 1}
+	c.items[key] = e
+	c.listOf(1).pushFront(e)
+	c.minFreq = 1
+}
+
+// Peek 获取键对应的值，但不增加访问频次，也不计入命中统计
+// 时间复杂度: O(1)
+func (c *LFUCache[K, V]) Peek(key K) (V, bool) {
+	e, ok := c.items[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	return e.value, true
+}
+
+// Remove 删除键，返回键是否存在
+// 主动删除不会触发淘汰回调
+// 时间复杂度: O(1)
+func (c *LFUCache[K, V]) Remove(key K) bool {
+	e, ok := c.items[key]
+	if !ok {
+		return false
+	}
+	c.unlink(e)
+	delete(c.items, key)
+	return true
+}
+
+// Len 返回缓存中的条目数量
+// 时间复杂度: O(1)
+func (c *LFUCache[K, V]) Len() int {
+	return len(c.items)
+}
+
+// Size 返回缓存中的条目数量，与 Len 相同
+// 时间复杂度: O(1)
+func (c *LFUCache[K, V]) Size() int {
+	return c.Len()
+}
+
+// IsEmpty 检查缓存是否为空
+// 时间复杂度: O(1)
+func (c *LFUCache[K, V]) IsEmpty() bool {
+	return c.Len() == 0
+}
+
+// Clone 返回缓存的深拷贝，保留条目的访问频次与顺序、淘汰回调和命中统计
+// copier 用于复制每个值，为 nil 时直接赋值
+// 时间复杂度: O(n)
+func (c *LFUCache[K, V]) Clone(copier func(V) V) *LFUCache[K, V] {
+	clone := NewLFU(c.capacity, c.onEvict)
+	clone.minFreq = c.minFreq
+	clone.stats = c.stats
+	for freq, l := range c.freqs {
+		cl := clone.listOf(freq)
+		// 从表尾向表头依次插入表头，保持原有顺序
+		for e := l.back(); e != nil && e != &l.sentinel; e = e.prev {
+			ce := &lfuEntry[K, V]{key: e.key, value: e.value, freq: e.freq}
+			if copier != nil {
+				ce.value = copier(ce.value)
+			}
+			cl.pushFront(ce)
+			clone.items[ce.key] = ce
+		}
+	}
+	return clone
+}
+
+// Cap 返回缓存容量
+// 时间复杂度: O(1)
+func (c *LFUCache[K, V]) Cap() int {
+	return c.capacity
+}
+
+// Clear 清空缓存，统计信息保持不变
+// 时间复杂度: O(n)
+func (c *LFUCache[K, V]) Clear() {
+	clear(c.items)
+	clear(c.freqs)
+	c.minFreq = 0
+}
+
+// Stats 返回命中统计
+// 时间复杂度: O(1)
+func (c *LFUCache[K, V]) Stats() Stats {
+	return c.stats
+}
+
+// touch 将条目移动到访问频次加一后的链表
+func (c *LFUCache[K, V]) touch(e *lfuEntry[K, V]) {
+	c.unlink(e)
+	if e.freq == c.minFreq && c.freqs[e.freq] == nil {
+		c.minFreq++
+	}
+	e.freq++
+	c.listOf(e.freq).pushFront(e)
+}
+
+// unlink 将条目从所在的频次链表中摘除，链表为空时删除该频次
+func (c *LFUCache[K, V]) unlink(e *lfuEntry[K, V]) {
+	l := c.freqs[e.freq]
+	l.remove(e)
+	if l.size == 0 {
+		delete(c.freqs, e.freq)
+	}
+}
+
+// evict 淘汰最小访问频次中最久未使用的条目
+func (c *LFUCache[K, V]) evict() {
+	// Remove 之后缓存一定未满，下一次插入新键会重置 minFreq，
+	// 因此需要淘汰时 minFreq 总是有效的
+	l := c.freqs[c.minFreq]
+	if l == nil {
+		return
+	}
+	e := l.back()
+	c.unlink(e)
+	delete(c.items, e.key)
+	c.stats.Evictions++
+	if c.onEvict != nil {
+		c.onEvict(e.key, e.value)
+	}
+}
+
+// listOf 返回指定访问频次的链表，不存在时创建
+func (c *LFUCache[K, V]) listOf(freq int) *lfuList[K, V] {
+	l, ok := c.freqs[freq]
+	if !ok {
+		l = newLFUList[K, V]()
+		c.freqs[freq] = l
+	}
+	return l
+}
@@ -0,0 +1,147 @@
+package cache
+
+import (
+	"testing"
+)
+
+// TestLFUCacheBasic 测试基本的读写操作
+func TestLFUCacheBasic(t *testing.T) {
+	c := NewLFU[string, int](2, nil)
+
+	if _, ok := c.Get("a"); ok {
+		t.Error("空缓存不应命中")
+	}
+	c.Put("a", 1)
+	c.Put("b", 2)
+	if v, ok := c.Get("a"); !ok || v != 1 {
+		t.Errorf("Get(a) = (%d, %v)，期望 (1, true)", v, ok)
+	}
+	c.Put("a", 10)
+	if v, _ := c.Peek("a"); v != 10 {
+		t.Errorf("更新后期望10，实际为%d", v)
+	}
+	if c.Len() != 2 || c.Cap() != 2 {
+		t.Errorf("Len/Cap错误: %d/%d", c.Len(), c.Cap())
+	}
+
+	stats := c.Stats()
+	if stats.Hits != 1 || stats.Misses != 1 || stats.HitRate() != 0.5 {
+		t.Errorf("统计信息错误: %+v", stats)
+	}
+}
+
+// TestLFUCacheEviction 测试淘汰策略
+func TestLFUCacheEviction(t *testing.T) {
+	var evicted []string
+	c := NewLFU[string, int](3, func(key string, value int) {
+		evicted = append(evicted, key)
+	})
+
+	c.Put("a", 1)
+	c.Put("b", 2)
+	c.Put("c", 3)
+	c.Get("a")
+	c.Get("a")
+	c.Get("b")
+
+	// c 的访问频次最低，应被淘汰
+	c.Put("d", 4)
+	if _, ok := c.Peek("c"); ok {
+		t.Error("c应该被淘汰")
+	}
+
+	// b 与 d 频次分别为2和1，d 被淘汰
+	c.Put("e", 5)
+	if _, ok := c.Peek("d"); ok {
+		t.Error("d应该被淘汰")
+	}
+
+	// 同频次时淘汰最久未使用的：e 与新插入的 f 频次相同，e 更早
+	c.Put("f", 6)
+	if _, ok := c.Peek("e"); ok {
+		t.Error("e应该被淘汰")
+	}
+
+	want := []string{"c", "d", "e"}
+	if len(evicted) != len(want) {
+		t.Fatalf("淘汰回调错误: %v", evicted)
+	}
+	for i := range want {
+		if evicted[i] != want[i] {
+			t.Errorf("淘汰顺序错误，期望 %v，实际为 %v", want, evicted)
+			break
+		}
+	}
+	if c.Stats().Evictions != 3 {
+		t.Errorf("期望淘汰3次，实际为%d", c.Stats().Evictions)
+	}
+}
+
+// TestLFUCacheRemove 测试删除后仍能正确淘汰
+func TestLFUCacheRemove(t *testing.T) {
+	c := NewLFU[int, int](2, nil)
+	c.Put(1, 1)
+	c.Put(2, 2)
+	c.Get(2)
+
+	if !c.Remove(1) || c.Remove(1) {
+		t.Error("Remove返回值错误")
+	}
+	c.Put(3, 3)
+	c.Get(3)
+	c.Get(3)
+	c.Remove(3)
+
+	// 此时最小频次对应的链表已被删空
+	c.Put(4, 4)
+	c.Put(5, 5)
+	if c.Len() != 2 {
+		t.Errorf("缓存大小不应超过容量，实际为%d", c.Len())
+	}
+	if _, ok := c.Peek(5); !ok {
+		t.Error("新插入的5应该存在")
+	}
+
+	c.Clear()
+	if c.Len() != 0 {
+		t.Error("清空后缓存应为空")
+	}
+	c.Put(6, 6)
+	if v, ok := c.Get(6); !ok || v != 6 {
+		t.Error("清空后应能继续使用")
+	}
+}
+
+// 性能测试
+func BenchmarkLFUCache(b *testing.B) {
+	c := NewLFU[int, int](1024, nil)
+	for i := 0; i < b.N; i++ {
+		c.Put(i%2048, i)
+		c.Get(i % 1024)
+	}
+}
+
+// TestLFUClone 测试 LFU 缓存的深拷贝
+func TestLFUClone(t *testing.T) {
+	c := NewLFU[int, int](3, nil)
+	c.Put(1, 1)
+	c.Put(2, 2)
+	c.Put(3, 3)
+	c.Get(1)
+	c.Get(1)
+	c.Get(3)
+
+	clone := c.Clone(nil)
+	// 2 的访问频次最低，插入新键时被淘汰
+	clone.Put(4, 4)
+	if _, ok := clone.Peek(2); ok {
+		t.Error("拷贝应保留访问频次")
+	}
+	clone.Put(5, 5)
+	if _, ok := clone.Peek(4); ok {
+		t.Error("频次相同时应淘汰最久未使用的条目")
+	}
+	if _, ok := c.Peek(2); !ok || c.Len() != 3 {
+		t.Error("修改拷贝不应影响原缓存")
+	}
+}
@@ -0,0 +1,150 @@
+package cache
+
+import "container/list"
+
+// lruEntry LRU 缓存中的条目
+type lruEntry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+// LRUCache 最近最少使用（LRU）缓存
+// 使用哈希表 + 双向链表实现，链表头部为最近使用的条目，Get/Put/淘汰均为 O(1)
+// 非并发安全
+type LRUCache[K comparable, V any] struct {
+	capacity int                  // 容量
+	items    map[K]*list.Element  // 键到链表节点的映射
+	order    *list.List           // 按访问时间排序的条目链表
+	onEvict  func(key K, value V) // 淘汰回调
+	stats    Stats                // 命中统计
+}
+
+// NewLRU 创建 LRU 缓存
+// 参数：
+//   - capacity: 缓存容量，必须大于0
+//   - onEvict: 条目因容量不足被淘汰时的回调，可以为 nil
+//
+// 时间复杂度: O(1)
+func NewLRU[K comparable, V any](capacity int, onEvict func(key K, value V)) *LRUCache[K, V] {
+	if capacity <= 0 {
+		panic("缓存容量必须大于0")
+	}
+	return &LRUCache[K, V]{
+		capacity: capacity,
+		items:    make(map[K]*list.Element, capacity),
+		order:    list.New(),
+		onEvict:  onEvict,
+	}
+}
+
+// Get 获取键对应的值，命中时将其标记为最近使用
+// 时间复杂度: O(1)
+func (c *LRUCache[K, V]) Get(key K) (V, bool) {
+	elem, ok := c.items[key]
+	if !ok {
+		c.stats.Misses++
+		var zero V
+		return zero, false
+	}
+	c.stats.Hits++
+	c.order.MoveToFront(elem)
+	return elem.Value.(*lruEntry[K, V]).value, true
+}
+
+// Put 插入或更新键值对，缓存已满时淘汰最久未使用的条目
+// 时间复杂度: O(1)
+func (c *LRUCache[K, V]) Put(key K, value V) {
+	if elem, ok := c.items[key]; ok {
+		elem.Value.(*lruEntry[K, V]).value = value
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	if c.order.Len() >= c.capacity {
+		back := c.order.Back()
+		e := c.order.Remove(back).(*lruEntry[K, V])
+		delete(c.items, e.key)
+		c.stats.Evictions++
+		if c.onEvict != nil {
+			c.onEvict(e.key, e.value)
+		}
+	}
+	c.items[key] = c.order.PushFront(&lruEntry[K, V]{key: key, value: value})
+}
+
+// Peek 获取键对应的值，但不更新访问记录，也不计入命中统计
+// 时间复杂度: O(1)
+func (c *LRUCache[K, V]) Peek(key K) (V, bool) {
+	elem, ok := c.items[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+	return elem.Value.(*lruEntry[K, V]).value, true
+}
+
+// Remove 删除键，返回键是否存在
+// 主动删除不会触发淘汰回调
+// 时间复杂度: O(1)
+func (c *LRUCache[K, V]) Remove(key K) bool {
+	elem, ok := c.items[key]
+	if !ok {
+		return false
+	}
+	c.order.Remove(elem)
+	delete(c.items, key)
+	return true
+}
+
+// Len 返回缓存中的条目数量
+// 时间复杂度: O(1)
+func (c *LRUCache[K, V]) Len() int {
+	return c.order.Len()
+}
+
+// Size 返回缓存中的条目数量，与 Len 相同
+// 时间复杂度: O(1)
+func (c *LRUCache[K, V]) Size() int {
+	return c.Len()
+}
+
+// IsEmpty 检查缓存是否为空
+// 时间复杂度: O(1)
+func (c *LRUCache[K, V]) IsEmpty() bool {
+	return c.Len() == 0
+}
+
+// Clone 返回缓存的深拷贝，保留条目的访问顺序、淘汰回调和命中统计
+// copier 用于复制每个值，为 nil 时直接赋值
+// 时间复杂度: O(n)
+func (c *LRUCache[K, V]) Clone(copier func(V) V) *LRUCache[K, V] {
+	clone := NewLRU(c.capacity, c.onEvict)
+	clone.stats = c.stats
+	for elem := c.order.Front(); elem != nil; elem = elem.Next() {
+		e := *elem.Value.(*lruEntry[K, V])
+		if copier != nil {
+			e.value = copier(e.value)
+		}
+		clone.items[e.key] = clone.order.PushBack(&e)
+	}
+	return clone
+}
+
+// Cap 返回缓存容量
+// 时间复杂度: O(1)
+func (c *LRUCache[K, V]) Cap() int {
+	return c.capacity
+}
+
+// Clear 清空缓存，统计信息保持不变
+// 时间复杂度: O(n)
+func (c *LRUCache[K, V]) Clear() {
+	clear(c.items)
+	c.order.Init()
+}
+
+// Stats 返回命中统计
+// 时间复杂度: O(1)
+func (c *LRUCache[K, V]) Stats() Stats {
+	return c.stats
+}
@@ -0,0 +1,81 @@
+package cache
+
+import (
+	"testing"
+)
+
+// TestLRUCacheBasic 测试基本的读写操作
+func TestLRUCacheBasic(t *testing.T) {
+	c := NewLRU[string, int](2, nil)
+
+	if _, ok := c.Get("a"); ok {
+		t.Error("空缓存不应命中")
+	}
+	c.Put("a", 1)
+	c.Put("b", 2)
+	c.Put("a", 10)
+	if v, ok := c.Get("a"); !ok || v != 10 {
+		t.Errorf("Get(a) = (%d, %v)，期望 (10, true)", v, ok)
+	}
+	if !c.Remove("b") || c.Remove("b") {
+		t.Error("Remove返回值错误")
+	}
+	if c.Len() != 1 {
+		t.Errorf("期望大小为1，实际为%d", c.Len())
+	}
+
+	c.Clear()
+	if _, ok := c.Peek("a"); ok || c.Len() != 0 {
+		t.Error("清空后缓存应为空")
+	}
+}
+
+// TestLRUCacheEviction 测试淘汰最久未使用的条目
+func TestLRUCacheEviction(t *testing.T) {
+	var evicted []int
+	c := NewLRU[int, string](2, func(key int, value string) {
+		evicted = append(evicted, key)
+	})
+
+	c.Put(1, "一")
+	c.Put(2, "二")
+	c.Get(1)
+	c.Put(3, "三") // 2 最久未使用
+	c.Peek(1)     // Peek 不更新访问记录
+	c.Put(4, "四") // 1 最久未使用
+
+	if len(evicted) != 2 || evicted[0] != 2 || evicted[1] != 1 {
+		t.Errorf("淘汰顺序错误: %v", evicted)
+	}
+	if _, ok := c.Peek(3); !ok {
+		t.Error("3应该存在")
+	}
+	if c.Stats().Evictions != 2 {
+		t.Errorf("期望淘汰2次，实际为%d", c.Stats().Evictions)
+	}
+}
+
+// TestLRUClone 测试 LRU 缓存的深拷贝
+func TestLRUClone(t *testing.T) {
+	c := NewLRU[int, int](3, nil)
+	c.Put(1, 1)
+	c.Put(2, 2)
+	c.Put(3, 3)
+	c.Get(1)
+
+	clone := c.Clone(func(v int) int { return v * 10 })
+	if clone.Stats() != c.Stats() {
+		t.Error("拷贝应保留命中统计")
+	}
+	// 访问顺序应与原缓存相同，插入新键时淘汰2
+	clone.Put(4, 40)
+	if _, ok := clone.Peek(2); ok {
+		t.Error("拷贝应保留访问顺序")
+	}
+	if v, _ := clone.Peek(1); v != 10 {
+		t.Errorf("期望10，实际为%d", v)
+	}
+	if _, ok := c.Peek(2); !ok || c.Len() != 3 {
+		t.Error("修改拷贝不应影响原缓存")
+	}
+}
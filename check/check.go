@@ -0,0 +1,65 @@
+// Package check 提供容器结构不变量的检查与随机操作模型检查
+// Validate 检查容器内部结构是否损坏，Run 对被测容器与参考实现执行相同的随机操作序列并逐步比较结果，
+// 二者都可以在单元测试或 go test -fuzz 中使用
+package check
+
+import (
+	"fmt"
+	"iter"
+
+	"godatastructure/rbtree"
+	"golang.org/x/exp/constraints"
+)
+
+// Validator 可以检查自身结构不变量的容器
+// 实现者包括 list.SkipList、queue.CircularQueue、bplustree.BPlusTree、
+// btree.BTree、treap.Treap、heap.MinMaxHeap 与 rbtree.Tree
+type Validator interface {
+	Validate() error // 结构完好时返回 nil
+}
+
+// Sized 可以报告元素数量并遍历元素的容器，由 container.Seq 的实现者满足
+type Sized[T any] interface {
+	Size() int
+	All() iter.Seq[T]
+}
+
+// Validate 检查 v 的结构不变量
+// v 实现 Validator 时调用其 Validate 方法，否则返回错误说明 v 不支持结构检查
+// 参数：
+//   - v: 要检查的容器
+//
+// 返回：
+//   - error: 结构完好时返回 nil，否则返回包含容器类型与具体问题的错误
+func Validate(v any) error {
+	validator, ok := v.(Validator)
+	if !ok {
+		return fmt.Errorf("%T 不支持结构检查", v)
+	}
+	if err := validator.Validate(); err != nil {
+		return fmt.Errorf("%T 结构损坏: %w", v, err)
+	}
+	return nil
+}
+
+// Size 检查容器记录的元素数量与实际遍历到的元素数量一致
+// 适用于链表、栈、双端队列、动态数组等没有其他结构不变量的顺序容器
+// 时间复杂度: O(n)
+func Size[T any](s Sized[T]) error {
+	count := 0
+	for range s.All() {
+		count++
+	}
+	if count != s.Size() {
+		return fmt.Errorf("%T 记录的元素数量为%d，实际遍历到%d个元素", s, s.Size(), count)
+	}
+	return nil
+}
+
+// RedBlack 检查红黑树的所有性质，等同于 t.Validate
+// 检查内容：根节点为黑色，红色节点的子节点都是黑色，从任一节点到其所有叶子的路径包含相同数目的黑色节点，
+// 中序遍历有序，子节点的 Parent 指针指向父节点，且元素总数等于 Size
+// 时间复杂度: O(n)
+func RedBlack[T constraints.Ordered](t *rbtree.Tree[T]) error {
+	return t.Validate()
+}
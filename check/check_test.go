@@ -0,0 +1,156 @@
+package check
+
+import (
+	"errors"
+	"iter"
+	"testing"
+
+	"godatastructure/binarytree"
+	"godatastructure/bplustree"
+	"godatastructure/btree"
+	"godatastructure/heap"
+	"godatastructure/list"
+	"godatastructure/queue"
+	"godatastructure/rbtree"
+	"godatastructure/stack"
+	"godatastructure/treap"
+)
+
+func intCmp(a, b int) int {
+	return a - b
+}
+
+// TestValidate 测试各容器在正常使用后通过结构检查
+func TestValidate(t *testing.T) {
+	skip := list.NewSkipList(intCmp)
+	q, _ := queue.NewQueue[int](4)
+	bp := bplustree.NewBPlusTree[int, int](3)
+	bt := btree.NewBTree[int, int](2)
+	tr := treap.New(intCmp)
+	h := heap.NewMinMaxHeap(intCmp)
+	rb := rbtree.NewTree[int](rbtree.WithDuplicates(rbtree.CountDuplicates))
+	avl := binarytree.NewAVL(intCmp)
+	for i := range 100 {
+		v := (i * 37) % 101
+		skip.Insert(v)
+		bp.Insert(v, i)
+		bt.Put(v, i)
+		tr.Insert(v)
+		h.Push(v)
+		rb.Insert(v % 10)
+		avl.Insert(v)
+	}
+	for i := range 10 {
+		q.Offer(i)
+		if i%3 == 0 {
+			q.Poll()
+		}
+	}
+
+	for name, v := range map[string]any{
+		"跳表": skip, "循环队列": q, "B+树": bp, "B树": bt, "树堆": tr, "最小最大堆": h, "红黑树": rb, "AVL树": avl,
+	} {
+		if err := Validate(v); err != nil {
+			t.Errorf("%s: %v", name, err)
+		}
+	}
+
+	if err := Validate(stack.New[int]()); err == nil {
+		t.Error("不支持结构检查的容器应返回错误")
+	}
+}
+
+// TestSize 测试元素数量与遍历结果一致性的检查
+func TestSize(t *testing.T) {
+	s := stack.New[int]()
+	for i := range 10 {
+		s.Push(i)
+	}
+	if err := Size(s); err != nil {
+		t.Error(err)
+	}
+	if err := Size[int](lyingSize{}); err == nil {
+		t.Error("元素数量与遍历结果不一致时应返回错误")
+	}
+}
+
+// lyingSize 记录的元素数量与实际遍历结果不一致的容器
+type lyingSize struct{}
+
+func (lyingSize) Size() int { return 1 }
+
+func (lyingSize) All() iter.Seq[int] {
+	return func(func(int) bool) {}
+}
+
+// TestRedBlack 测试红黑树性质检查能够发现结构损坏
+func TestRedBlack(t *testing.T) {
+	tree := rbtree.NewTree[int]()
+	if err := RedBlack(tree); err != nil {
+		t.Errorf("空树应通过检查: %v", err)
+	}
+	for i := range 100 {
+		tree.Insert(i % 37)
+	}
+	for i := range 20 {
+		tree.Delete(i)
+	}
+	if err := RedBlack(tree); err != nil {
+		t.Fatalf("正常使用后应通过检查: %v", err)
+	}
+
+	t.Run("根节点为红色", func(t *testing.T) {
+		tree.Root.Color = rbtree.RED
+		defer func() { tree.Root.Color = rbtree.BLACK }()
+		if RedBlack(tree) == nil {
+			t.Error("应发现根节点为红色")
+		}
+	})
+
+	t.Run("黑高度不相等", func(t *testing.T) {
+		n := tree.Root.Left
+		old := n.Color
+		n.Color = !old
+		defer func() { n.Color = old }()
+		if RedBlack(tree) == nil {
+			t.Error("应发现黑高度不相等或连续的红色节点")
+		}
+	})
+
+	t.Run("违反有序性", func(t *testing.T) {
+		n := tree.Root.Left
+		old := n.Value
+		n.Value = tree.Root.Value + 1000
+		defer func() { n.Value = old }()
+		if RedBlack(tree) == nil {
+			t.Error("应发现违反二叉搜索树性质")
+		}
+	})
+
+	t.Run("父指针错误", func(t *testing.T) {
+		n := tree.Root.Right
+		n.Parent = nil
+		defer func() { n.Parent = tree.Root }()
+		if RedBlack(tree) == nil {
+			t.Error("应发现错误的父指针")
+		}
+	})
+
+	if err := RedBlack(tree); err != nil {
+		t.Errorf("恢复后应通过检查: %v", err)
+	}
+}
+
+// TestValidateWrapsError 测试 Validate 返回的错误包装了容器报告的错误
+func TestValidateWrapsError(t *testing.T) {
+	want := errors.New("损坏")
+	err := Validate(brokenValidator{want})
+	if !errors.Is(err, want) {
+		t.Errorf("错误应包装容器报告的错误，实际为%v", err)
+	}
+}
+
+// brokenValidator 总是报告结构损坏的容器
+type brokenValidator struct{ err error }
+
+func (b brokenValidator) Validate() error { return b.err }
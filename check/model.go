@@ -0,0 +1,101 @@
+package check
+
+import (
+	"fmt"
+	"iter"
+	"math/rand/v2"
+	"strings"
+)
+
+// Op 模型检查中的一种操作
+// S 为被测容器的类型，M 为参考实现的类型
+type Op[S, M any] struct {
+	Name string // 操作名称，用于失败时报告操作序列
+	// Apply 使用 r 生成参数，对被测容器与参考实现执行同一操作，结果不一致时返回错误
+	Apply func(r *rand.Rand, s S, m M) error
+}
+
+// Model 描述一次模型检查：被测容器、参考实现以及可以对二者执行的操作
+type Model[S, M any] struct {
+	New   func() (S, M)        // 创建空的被测容器与参考实现
+	Ops   []Op[S, M]           // 可选的操作，每一步等概率选择其中一种
+	Check func(s S, m M) error // 每一步之后比较二者的整体状态，可以为 nil
+}
+
+// Failure 模型检查失败的详细信息
+// 使用相同的 Seed 重新运行可以复现失败
+type Failure struct {
+	Seed uint64   // 随机数种子
+	Step int      // 失败发生在第几步，从0开始
+	Ops  []string // 到失败为止依次执行的操作名称
+	Err  error    // 具体的错误
+}
+
+// Error 返回失败的描述，只列出最近的若干个操作
+func (f *Failure) Error() string {
+	const recent = 10
+	ops := f.Ops
+	prefix := ""
+	if len(ops) > recent {
+		ops = ops[len(ops)-recent:]
+		prefix = "... "
+	}
+	return fmt.Sprintf("种子 %d 第%d步失败 (%s%s): %v", f.Seed, f.Step, prefix, strings.Join(ops, ", "), f.Err)
+}
+
+// Unwrap 返回具体的错误
+func (f *Failure) Unwrap() error {
+	return f.Err
+}
+
+// Run 以 seed 为种子随机选择 steps 次操作，依次作用于被测容器与参考实现
+// 每一步之后调用 Model.Check 比较二者的状态；被测容器实现 Validator 时还会检查其结构不变量
+// 参数：
+//   - m: 要检查的模型，Ops 不能为空
+//   - seed: 随机数种子，相同的种子产生相同的操作序列
+//   - steps: 执行的操作次数
+//
+// 返回：
+//   - error: 全部操作结果一致时返回 nil，否则返回 *Failure
+func Run[S, M any](m Model[S, M], seed uint64, steps int) error {
+	if len(m.Ops) == 0 {
+		panic("模型检查至少需要一种操作")
+	}
+	r := rand.New(rand.NewPCG(seed, seed))
+	s, ref := m.New()
+	var ops []string
+	for step := range steps {
+		op := m.Ops[r.IntN(len(m.Ops))]
+		ops = append(ops, op.Name)
+		err := op.Apply(r, s, ref)
+		if err == nil && m.Check != nil {
+			err = m.Check(s, ref)
+		}
+		if v, ok := any(s).(Validator); err == nil && ok {
+			err = v.Validate()
+		}
+		if err != nil {
+			return &Failure{Seed: seed, Step: step, Ops: ops, Err: err}
+		}
+	}
+	return nil
+}
+
+// Equal 比较 got 产出的元素与 want 是否逐个相等，不相等时返回描述第一处差异的错误
+// 时间复杂度: O(n)
+func Equal[T comparable](got iter.Seq[T], want []T) error {
+	i := 0
+	for v := range got {
+		if i >= len(want) {
+			return fmt.Errorf("元素多于期望的%d个，多出 %v", len(want), v)
+		}
+		if v != want[i] {
+			return fmt.Errorf("第%d个元素为 %v，期望 %v", i, v, want[i])
+		}
+		i++
+	}
+	if i < len(want) {
+		return fmt.Errorf("只有%d个元素，期望%d个", i, len(want))
+	}
+	return nil
+}
@@ -0,0 +1,399 @@
+package check
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"godatastructure/bplustree"
+	"godatastructure/btree"
+	"godatastructure/heap"
+	"godatastructure/list"
+	"godatastructure/queue"
+	"godatastructure/rbtree"
+	"godatastructure/stack"
+	"godatastructure/treap"
+)
+
+// sortedModel 以有序切片作为参考实现的模型
+type sortedModel struct {
+	values []int
+}
+
+// insert 插入元素并保持有序
+func (m *sortedModel) insert(v int) {
+	i, _ := slices.BinarySearch(m.values, v)
+	m.values = slices.Insert(m.values, i, v)
+}
+
+// delete 删除一个等于 v 的元素，返回是否存在
+func (m *sortedModel) delete(v int) bool {
+	i, ok := slices.BinarySearch(m.values, v)
+	if ok {
+		m.values = slices.Delete(m.values, i, i+1)
+	}
+	return ok
+}
+
+// skipListModel 以有序切片为参考实现的跳表模型，跳表允许重复元素
+func skipListModel() Model[*list.SkipList[int], *sortedModel] {
+	return Model[*list.SkipList[int], *sortedModel]{
+		New: func() (*list.SkipList[int], *sortedModel) {
+			return list.NewSkipList(intCmp), &sortedModel{}
+		},
+		Ops: []Op[*list.SkipList[int], *sortedModel]{
+			{"Insert", func(r *rand.Rand, s *list.SkipList[int], m *sortedModel) error {
+				v := r.IntN(50)
+				s.Insert(v)
+				m.insert(v)
+				return nil
+			}},
+			{"Delete", func(r *rand.Rand, s *list.SkipList[int], m *sortedModel) error {
+				v := r.IntN(50)
+				if got, want := s.Delete(v), m.delete(v); got != want {
+					return fmt.Errorf("Delete(%d) = %v，期望 %v", v, got, want)
+				}
+				return nil
+			}},
+			{"Search", func(r *rand.Rand, s *list.SkipList[int], m *sortedModel) error {
+				v := r.IntN(50)
+				_, want := slices.BinarySearch(m.values, v)
+				if got := s.Search(v) != nil; got != want {
+					return fmt.Errorf("Search(%d) 找到 = %v，期望 %v", v, got, want)
+				}
+				return nil
+			}},
+		},
+		Check: func(s *list.SkipList[int], m *sortedModel) error {
+			return Equal(s.All(), m.values)
+		},
+	}
+}
+
+// TestRunSeq 测试顺序容器与切片参考实现的模型检查
+func TestRunSeq(t *testing.T) {
+	t.Run("栈", func(t *testing.T) {
+		model := Model[stack.Stack[int], *[]int]{
+			New: func() (stack.Stack[int], *[]int) { return stack.New[int](), &[]int{} },
+			Ops: []Op[stack.Stack[int], *[]int]{
+				{"Push", func(r *rand.Rand, s stack.Stack[int], m *[]int) error {
+					v := r.Int()
+					s.Push(v)
+					*m = append(*m, v)
+					return nil
+				}},
+				{"Pop", func(r *rand.Rand, s stack.Stack[int], m *[]int) error {
+					v, err := s.Pop()
+					if len(*m) == 0 {
+						if err == nil {
+							return errors.New("空栈弹出应返回错误")
+						}
+						return nil
+					}
+					want := (*m)[len(*m)-1]
+					*m = (*m)[:len(*m)-1]
+					if err != nil || v != want {
+						return fmt.Errorf("Pop() = (%v, %v)，期望 %v", v, err, want)
+					}
+					return nil
+				}},
+			},
+			Check: func(s stack.Stack[int], m *[]int) error {
+				want := slices.Clone(*m)
+				slices.Reverse(want)
+				return errors.Join(Equal(s.All(), want), Size(s))
+			},
+		}
+		if err := Run(model, 1, 2000); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("循环队列", func(t *testing.T) {
+		model := Model[queue.Queue[int], *[]int]{
+			New: func() (queue.Queue[int], *[]int) {
+				q, _ := queue.NewQueue[int](8)
+				return q, &[]int{}
+			},
+			Ops: []Op[queue.Queue[int], *[]int]{
+				{"Offer", func(r *rand.Rand, q queue.Queue[int], m *[]int) error {
+					v := r.Int()
+					ok := q.Offer(v)
+					if ok {
+						*m = append(*m, v)
+					}
+					if want := len(*m) <= 8 && (ok || len(*m) == 8); !want {
+						return fmt.Errorf("队列中有%d个元素时 Offer 返回 %v", len(*m), ok)
+					}
+					return nil
+				}},
+				{"Poll", func(r *rand.Rand, q queue.Queue[int], m *[]int) error {
+					v, ok := q.Poll()
+					if ok != (len(*m) > 0) {
+						return fmt.Errorf("队列中有%d个元素时 Poll 返回 %v", len(*m), ok)
+					}
+					if ok {
+						if v != (*m)[0] {
+							return fmt.Errorf("Poll() = %v，期望 %v", v, (*m)[0])
+						}
+						*m = (*m)[1:]
+					}
+					return nil
+				}},
+			},
+			Check: func(q queue.Queue[int], m *[]int) error {
+				return Equal(q.All(), *m)
+			},
+		}
+		if err := Run(model, 2, 2000); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("跳表", func(t *testing.T) {
+		if err := Run(skipListModel(), 3, 2000); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+// TestRunOrdered 测试有序容器与有序切片、映射参考实现的模型检查
+func TestRunOrdered(t *testing.T) {
+	t.Run("红黑树", func(t *testing.T) {
+		model := Model[*rbtree.Tree[int], *sortedModel]{
+			New: func() (*rbtree.Tree[int], *sortedModel) { return rbtree.NewTree[int](), &sortedModel{} },
+			Ops: []Op[*rbtree.Tree[int], *sortedModel]{
+				{"Insert", func(r *rand.Rand, tree *rbtree.Tree[int], m *sortedModel) error {
+					v := r.IntN(100)
+					tree.Insert(v)
+					m.insert(v)
+					return nil
+				}},
+				{"Delete", func(r *rand.Rand, tree *rbtree.Tree[int], m *sortedModel) error {
+					v := r.IntN(100)
+					if got, want := tree.Delete(v), m.delete(v); got != want {
+						return fmt.Errorf("Delete(%d) = %v，期望 %v", v, got, want)
+					}
+					return nil
+				}},
+			},
+			Check: func(tree *rbtree.Tree[int], m *sortedModel) error {
+				return errors.Join(RedBlack(tree), Equal(tree.All(), m.values))
+			},
+		}
+		if err := Run(model, 4, 2000); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("树堆", func(t *testing.T) {
+		model := Model[*treap.Treap[int], map[int]bool]{
+			New: func() (*treap.Treap[int], map[int]bool) { return treap.New(intCmp), map[int]bool{} },
+			Ops: []Op[*treap.Treap[int], map[int]bool]{
+				{"Insert", func(r *rand.Rand, tree *treap.Treap[int], m map[int]bool) error {
+					v := r.IntN(100)
+					if got, want := tree.Insert(v), !m[v]; got != want {
+						return fmt.Errorf("Insert(%d) = %v，期望 %v", v, got, want)
+					}
+					m[v] = true
+					return nil
+				}},
+				{"Delete", func(r *rand.Rand, tree *treap.Treap[int], m map[int]bool) error {
+					v := r.IntN(100)
+					if got, want := tree.Delete(v), m[v]; got != want {
+						return fmt.Errorf("Delete(%d) = %v，期望 %v", v, got, want)
+					}
+					delete(m, v)
+					return nil
+				}},
+			},
+			Check: func(tree *treap.Treap[int], m map[int]bool) error {
+				return Equal(tree.All(), slices.Sorted(maps.Keys(m)))
+			},
+		}
+		if err := Run(model, 5, 2000); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("B树", func(t *testing.T) {
+		model := Model[*btree.BTree[int, int], map[int]int]{
+			New: func() (*btree.BTree[int, int], map[int]int) { return btree.NewBTree[int, int](2), map[int]int{} },
+			Ops: []Op[*btree.BTree[int, int], map[int]int]{
+				{"Put", func(r *rand.Rand, tree *btree.BTree[int, int], m map[int]int) error {
+					k, v := r.IntN(200), r.Int()
+					tree.Put(k, v)
+					m[k] = v
+					return nil
+				}},
+				{"Delete", func(r *rand.Rand, tree *btree.BTree[int, int], m map[int]int) error {
+					k := r.IntN(200)
+					_, want := m[k]
+					delete(m, k)
+					if got := tree.Delete(k); got != want {
+						return fmt.Errorf("Delete(%d) = %v，期望 %v", k, got, want)
+					}
+					return nil
+				}},
+				{"Get", func(r *rand.Rand, tree *btree.BTree[int, int], m map[int]int) error {
+					k := r.IntN(200)
+					want, wantOK := m[k]
+					if got, ok := tree.Get(k); got != want || ok != wantOK {
+						return fmt.Errorf("Get(%d) = (%v, %v)，期望 (%v, %v)", k, got, ok, want, wantOK)
+					}
+					return nil
+				}},
+			},
+		}
+		if err := Run(model, 6, 3000); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("B+树", func(t *testing.T) {
+		model := Model[*bplustree.BPlusTree[int, int], map[int]int]{
+			New: func() (*bplustree.BPlusTree[int, int], map[int]int) {
+				return bplustree.NewBPlusTree[int, int](4), map[int]int{}
+			},
+			Ops: []Op[*bplustree.BPlusTree[int, int], map[int]int]{
+				{"Insert", func(r *rand.Rand, tree *bplustree.BPlusTree[int, int], m map[int]int) error {
+					k, v := r.IntN(500), r.Int()
+					tree.Insert(k, v)
+					m[k] = v
+					return nil
+				}},
+				{"Search", func(r *rand.Rand, tree *bplustree.BPlusTree[int, int], m map[int]int) error {
+					k := r.IntN(500)
+					want, wantOK := m[k]
+					if got, ok := tree.Search(k); got != want || ok != wantOK {
+						return fmt.Errorf("Search(%d) = (%v, %v)，期望 (%v, %v)", k, got, ok, want, wantOK)
+					}
+					return nil
+				}},
+			},
+			Check: func(tree *bplustree.BPlusTree[int, int], m map[int]int) error {
+				if tree.Size() != len(m) {
+					return fmt.Errorf("Size() = %d，期望 %d", tree.Size(), len(m))
+				}
+				return nil
+			},
+		}
+		if err := Run(model, 7, 2000); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("最小最大堆", func(t *testing.T) {
+		model := Model[*heap.MinMaxHeap[int], *sortedModel]{
+			New: func() (*heap.MinMaxHeap[int], *sortedModel) { return heap.NewMinMaxHeap(intCmp), &sortedModel{} },
+			Ops: []Op[*heap.MinMaxHeap[int], *sortedModel]{
+				{"Push", func(r *rand.Rand, h *heap.MinMaxHeap[int], m *sortedModel) error {
+					v := r.IntN(100)
+					h.Push(v)
+					m.insert(v)
+					return nil
+				}},
+				{"PopMin", func(r *rand.Rand, h *heap.MinMaxHeap[int], m *sortedModel) error {
+					got, ok := h.PopMin()
+					if len(m.values) == 0 {
+						if ok {
+							return errors.New("空堆 PopMin 应返回 false")
+						}
+						return nil
+					}
+					want := m.values[0]
+					m.values = m.values[1:]
+					if !ok || got != want {
+						return fmt.Errorf("PopMin() = (%v, %v)，期望 %v", got, ok, want)
+					}
+					return nil
+				}},
+				{"PopMax", func(r *rand.Rand, h *heap.MinMaxHeap[int], m *sortedModel) error {
+					got, ok := h.PopMax()
+					if len(m.values) == 0 {
+						if ok {
+							return errors.New("空堆 PopMax 应返回 false")
+						}
+						return nil
+					}
+					want := m.values[len(m.values)-1]
+					m.values = m.values[:len(m.values)-1]
+					if !ok || got != want {
+						return fmt.Errorf("PopMax() = (%v, %v)，期望 %v", got, ok, want)
+					}
+					return nil
+				}},
+			},
+		}
+		if err := Run(model, 8, 3000); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
+
+// TestRunFailure 测试模型检查发现差异时报告可复现的失败信息
+func TestRunFailure(t *testing.T) {
+	model := Model[*[]int, *[]int]{
+		New: func() (*[]int, *[]int) { return &[]int{}, &[]int{} },
+		Ops: []Op[*[]int, *[]int]{
+			{"Append", func(r *rand.Rand, s, m *[]int) error {
+				v := r.IntN(10)
+				*m = append(*m, v)
+				if v != 7 { // 故意丢失元素7
+					*s = append(*s, v)
+				}
+				return nil
+			}},
+		},
+		Check: func(s, m *[]int) error {
+			return Equal(slices.Values(*s), *m)
+		},
+	}
+	err := Run(model, 42, 1000)
+	var failure *Failure
+	if !errors.As(err, &failure) {
+		t.Fatalf("期望返回 *Failure，实际为%v", err)
+	}
+	if failure.Seed != 42 || len(failure.Ops) != failure.Step+1 {
+		t.Errorf("失败信息不正确: %+v", failure)
+	}
+	again := Run(model, 42, 1000)
+	if !errors.As(again, &failure) || again.Error() != err.Error() {
+		t.Error("相同的种子应复现相同的失败")
+	}
+}
+
+// TestEqual 测试序列比较的各种差异
+func TestEqual(t *testing.T) {
+	tests := map[string]struct {
+		got, want []int
+		ok        bool
+	}{
+		"相等":   {[]int{1, 2}, []int{1, 2}, true},
+		"元素不同": {[]int{1, 3}, []int{1, 2}, false},
+		"元素过多": {[]int{1, 2, 3}, []int{1, 2}, false},
+		"元素过少": {[]int{1}, []int{1, 2}, false},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			if err := Equal(slices.Values(tt.got), tt.want); (err == nil) != tt.ok {
+				t.Errorf("Equal(%v, %v) = %v", tt.got, tt.want, err)
+			}
+		})
+	}
+}
+
+// FuzzSkipList 以随机数种子作为模糊测试输入检查跳表
+func FuzzSkipList(f *testing.F) {
+	for seed := range uint64(4) {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, seed uint64) {
+		if err := Run(skipListModel(), seed, 300); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
@@ -0,0 +1,55 @@
+// Package cmpfn 提供构造比较函数的工具
+// 二叉树、跳表、堆等基于比较函数的数据结构都接受 func(a, b T) int 形式的比较函数，
+// 约定 a < b 时返回负数，相等时返回0，a > b 时返回正数
+package cmpfn
+
+import (
+	"cmp"
+
+	"golang.org/x/exp/constraints"
+)
+
+// Natural 返回按自然顺序（升序）比较的函数
+// 浮点数的 NaN 视为小于其他所有值
+func Natural[T constraints.Ordered]() func(a, b T) int {
+	return cmp.Compare[T]
+}
+
+// Reverse 返回与 c 顺序相反的比较函数
+func Reverse[T any](c func(a, b T) int) func(a, b T) int {
+	return func(a, b T) int {
+		return c(b, a)
+	}
+}
+
+// Chain 返回依次使用多个比较函数的比较函数
+// 前一个比较函数认为相等时才使用下一个，所有比较函数都认为相等时返回0
+func Chain[T any](cmps ...func(a, b T) int) func(a, b T) int {
+	return func(a, b T) int {
+		for _, c := range cmps {
+			if r := c(a, b); r != 0 {
+				return r
+			}
+		}
+		return 0
+	}
+}
+
+// By 返回按 key 提取的字段自然顺序比较的函数
+// 参数：
+//   - key: 从元素中提取用于比较的字段
+//
+// 返回：
+//   - func(a, b T) int: 比较函数，每次比较调用 key 两次
+func By[T any, K constraints.Ordered](key func(T) K) func(a, b T) int {
+	return func(a, b T) int {
+		return cmp.Compare(key(a), key(b))
+	}
+}
+
+// ByFunc 返回按 key 提取的字段使用 c 比较的函数，用于字段本身不可排序的情况
+func ByFunc[T, K any](key func(T) K, c func(a, b K) int) func(a, b T) int {
+	return func(a, b T) int {
+		return c(key(a), key(b))
+	}
+}
@@ -0,0 +1,145 @@
+package cmpfn
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	"godatastructure/binarytree"
+	"godatastructure/list"
+)
+
+type person struct {
+	name string
+	age  int
+}
+
+// sign 把比较结果归一化为 -1、0、1
+func sign(r int) int {
+	switch {
+	case r < 0:
+		return -1
+	case r > 0:
+		return 1
+	}
+	return 0
+}
+
+// TestNatural 测试自然顺序比较
+func TestNatural(t *testing.T) {
+	ints := Natural[int]()
+	cases := []struct {
+		a, b int
+		want int
+	}{
+		{1, 2, -1},
+		{2, 2, 0},
+		{3, 2, 1},
+		{math.MinInt, math.MaxInt, -1},
+	}
+	for _, c := range cases {
+		if got := sign(ints(c.a, c.b)); got != c.want {
+			t.Errorf("Natural(%d, %d) = %d，期望%d", c.a, c.b, got, c.want)
+		}
+	}
+
+	if Natural[string]()("apple", "banana") >= 0 {
+		t.Error("apple 应小于 banana")
+	}
+	floats := Natural[float64]()
+	if floats(math.NaN(), 0) >= 0 || floats(math.NaN(), math.NaN()) != 0 {
+		t.Error("NaN 应小于其他值且与自身相等")
+	}
+}
+
+// TestReverse 测试反转比较函数
+func TestReverse(t *testing.T) {
+	desc := Reverse(Natural[int]())
+	if desc(1, 2) <= 0 || desc(2, 1) >= 0 || desc(1, 1) != 0 {
+		t.Error("反转后的比较结果不正确")
+	}
+	values := []int{3, 1, 4, 1, 5, 9, 2, 6}
+	slices.SortFunc(values, desc)
+	if !slices.Equal(values, []int{9, 6, 5, 4, 3, 2, 1, 1}) {
+		t.Errorf("降序排序结果不正确: %v", values)
+	}
+	if Reverse(desc)(1, 2) >= 0 {
+		t.Error("两次反转应恢复原顺序")
+	}
+}
+
+// TestChain 测试组合多个比较函数
+func TestChain(t *testing.T) {
+	byAgeThenName := Chain(
+		By(func(p person) int { return p.age }),
+		By(func(p person) string { return p.name }),
+	)
+	people := []person{{"丙", 30}, {"乙", 25}, {"甲", 30}, {"丁", 25}}
+	slices.SortFunc(people, byAgeThenName)
+	want := []person{{"丁", 25}, {"乙", 25}, {"丙", 30}, {"甲", 30}}
+	if !slices.Equal(people, want) {
+		t.Errorf("排序结果不正确: %v", people)
+	}
+
+	t.Run("全部相等", func(t *testing.T) {
+		if byAgeThenName(person{"甲", 1}, person{"甲", 1}) != 0 {
+			t.Error("所有字段相等时应返回0")
+		}
+	})
+
+	t.Run("空链", func(t *testing.T) {
+		if Chain[int]()(1, 2) != 0 {
+			t.Error("没有比较函数时应返回0")
+		}
+	})
+}
+
+// TestBy 测试按字段比较
+func TestBy(t *testing.T) {
+	byLen := By(func(s string) int { return len(s) })
+	if byLen("ab", "abc") >= 0 || byLen("abc", "xyz") != 0 {
+		t.Error("按长度比较结果不正确")
+	}
+
+	byAgeDesc := ByFunc(func(p person) int { return p.age }, Reverse(Natural[int]()))
+	if byAgeDesc(person{"甲", 20}, person{"乙", 30}) <= 0 {
+		t.Error("按年龄降序时20岁应排在30岁之后")
+	}
+}
+
+// TestWithContainers 测试比较函数直接用于基于比较函数的容器
+func TestWithContainers(t *testing.T) {
+	t.Run("二叉搜索树", func(t *testing.T) {
+		tree := binarytree.New(Reverse(Natural[int]()))
+		for _, v := range []int{5, 3, 8, 1} {
+			tree.Insert(v)
+		}
+		got := slices.Collect(tree.All())
+		if !slices.Equal(got, []int{8, 5, 3, 1}) {
+			t.Errorf("期望降序遍历，实际为%v", got)
+		}
+	})
+
+	t.Run("跳表", func(t *testing.T) {
+		s := list.NewSkipList(By(func(p person) string { return p.name }))
+		s.Insert(person{"乙", 2})
+		s.Insert(person{"甲", 1})
+		if p := s.Search(person{name: "甲"}); p == nil || p.age != 1 {
+			t.Errorf("按名字查找失败: %v", p)
+		}
+	})
+}
+
+// BenchmarkBy 测试按字段比较的性能
+func BenchmarkBy(b *testing.B) {
+	people := make([]person, 1000)
+	for i := range people {
+		people[i] = person{age: (i * 7919) % 1000}
+	}
+	byAge := By(func(p person) int { return p.age })
+	b.Run("字段比较", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			byAge(people[i%1000], people[(i+1)%1000])
+		}
+	})
+}
@@ -0,0 +1,197 @@
+// Package codec 定义容器统一的序列化方式
+// 各容器通过本包实现 encoding.BinaryMarshaler/BinaryUnmarshaler 与 json.Marshaler/Unmarshaler：
+// 二进制格式为「版本号、元素数量、依次编码的元素」，元素的编码方式由 Codec 决定；
+// JSON 格式中顺序容器编码为元素数组，键值对容器编码为 {"key": ..., "value": ...} 对象数组
+package codec
+
+import (
+	"encoding"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"iter"
+)
+
+// version 当前的二进制格式版本
+const version byte = 1
+
+var (
+	ErrTruncated = errors.New("数据不完整")
+	ErrFormat    = errors.New("数据格式错误")
+)
+
+// Marshaler 支持二进制与 JSON 序列化的容器
+type Marshaler interface {
+	encoding.BinaryMarshaler
+	encoding.BinaryUnmarshaler
+	json.Marshaler
+	json.Unmarshaler
+}
+
+// Entry 键值对容器在 JSON 中的元素格式
+type Entry[K, V any] struct {
+	Key   K `json:"key"`
+	Value V `json:"value"`
+}
+
+// EncodeSeq 把元素序列编码为二进制
+// 参数：
+//   - seq: 按容器顺序遍历元素的迭代器
+//   - c: 元素编解码器
+//
+// 返回：
+//   - []byte: 编码结果
+//   - error: 元素编码失败时返回的错误
+func EncodeSeq[T any](seq iter.Seq[T], c Codec[T]) ([]byte, error) {
+	var body []byte
+	var err error
+	n := 0
+	for v := range seq {
+		if body, err = c.Append(body, v); err != nil {
+			return nil, err
+		}
+		n++
+	}
+	return appendHeader(n, body), nil
+}
+
+// DecodeSeq 解码 EncodeSeq 生成的数据，按编码时的顺序返回元素
+// 时间复杂度: O(n)
+func DecodeSeq[T any](data []byte, c Codec[T]) ([]T, error) {
+	n, data, err := readHeader(data)
+	if err != nil {
+		return nil, err
+	}
+	values := make([]T, 0, n)
+	for range n {
+		v, size, err := c.Decode(data)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, v)
+		data = data[size:]
+	}
+	if len(data) != 0 {
+		return nil, ErrFormat
+	}
+	return values, nil
+}
+
+// EncodeSeq2 把键值对序列编码为二进制，每个键值对依次编码键和值
+func EncodeSeq2[K, V any](seq iter.Seq2[K, V], kc Codec[K], vc Codec[V]) ([]byte, error) {
+	var body []byte
+	var err error
+	n := 0
+	for k, v := range seq {
+		if body, err = kc.Append(body, k); err != nil {
+			return nil, err
+		}
+		if body, err = vc.Append(body, v); err != nil {
+			return nil, err
+		}
+		n++
+	}
+	return appendHeader(n, body), nil
+}
+
+// DecodeSeq2 解码 EncodeSeq2 生成的数据，按编码时的顺序对每个键值对调用 fn
+// 数据有误时不会调用 fn，调用方可以据此保证解码失败时容器保持不变
+func DecodeSeq2[K, V any](data []byte, kc Codec[K], vc Codec[V], fn func(key K, value V)) error {
+	n, data, err := readHeader(data)
+	if err != nil {
+		return err
+	}
+	entries := make([]Entry[K, V], 0, n)
+	for range n {
+		k, size, err := kc.Decode(data)
+		if err != nil {
+			return err
+		}
+		data = data[size:]
+		v, size, err := vc.Decode(data)
+		if err != nil {
+			return err
+		}
+		data = data[size:]
+		entries = append(entries, Entry[K, V]{k, v})
+	}
+	if len(data) != 0 {
+		return ErrFormat
+	}
+	for _, e := range entries {
+		fn(e.Key, e.Value)
+	}
+	return nil
+}
+
+// MarshalJSONSeq 把元素序列编码为 JSON 数组
+func MarshalJSONSeq[T any](seq iter.Seq[T]) ([]byte, error) {
+	values := []T{}
+	for v := range seq {
+		values = append(values, v)
+	}
+	return json.Marshal(values)
+}
+
+// UnmarshalJSONSeq 解码 JSON 数组
+func UnmarshalJSONSeq[T any](data []byte) ([]T, error) {
+	var values []T
+	if err := json.Unmarshal(data, &values); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
+
+// MarshalJSONSeq2 把键值对序列编码为 JSON 对象数组
+// 使用数组而不是 JSON 对象，使非字符串类型的键也能保留原始类型和遍历顺序
+func MarshalJSONSeq2[K, V any](seq iter.Seq2[K, V]) ([]byte, error) {
+	entries := []Entry[K, V]{}
+	for k, v := range seq {
+		entries = append(entries, Entry[K, V]{k, v})
+	}
+	return json.Marshal(entries)
+}
+
+// UnmarshalJSONSeq2 解码 MarshalJSONSeq2 生成的 JSON，对每个键值对调用 fn
+// 数据有误时不会调用 fn
+func UnmarshalJSONSeq2[K, V any](data []byte, fn func(key K, value V)) error {
+	var entries []Entry[K, V]
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return err
+	}
+	for _, e := range entries {
+		fn(e.Key, e.Value)
+	}
+	return nil
+}
+
+// appendHeader 在编码后的元素前加上版本号和元素数量
+func appendHeader(n int, body []byte) []byte {
+	buf := make([]byte, 0, 1+binary.MaxVarintLen64+len(body))
+	buf = append(buf, version)
+	buf = binary.AppendUvarint(buf, uint64(n))
+	return append(buf, body...)
+}
+
+// readHeader 读取版本号和元素数量，返回剩余的数据
+func readHeader(data []byte) (int, []byte, error) {
+	if len(data) == 0 {
+		return 0, nil, ErrTruncated
+	}
+	if data[0] != version {
+		return 0, nil, ErrFormat
+	}
+	n, size := binary.Uvarint(data[1:])
+	switch {
+	case size == 0:
+		return 0, nil, ErrTruncated
+	case size < 0:
+		return 0, nil, ErrFormat
+	}
+	data = data[1+size:]
+	// 每个元素至少占一个字节，数量超过剩余长度说明数据有误，避免按错误的数量分配内存
+	if n > uint64(len(data)) {
+		return 0, nil, ErrTruncated
+	}
+	return int(n), data, nil
+}
@@ -0,0 +1,269 @@
+package codec
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"testing"
+)
+
+type point struct {
+	X, Y int
+}
+
+// roundTrip 编码后再解码单个元素
+func roundTrip[T any](t *testing.T, c Codec[T], v T) T {
+	t.Helper()
+	buf, err := c.Append([]byte{0xff}, v)
+	if err != nil {
+		t.Fatalf("编码%v失败: %v", v, err)
+	}
+	if buf[0] != 0xff {
+		t.Fatal("Append 不应修改已有数据")
+	}
+	got, n, err := c.Decode(buf[1:])
+	if err != nil {
+		t.Fatalf("解码%v失败: %v", v, err)
+	}
+	if n != len(buf)-1 {
+		t.Errorf("期望消耗%d字节，实际为%d", len(buf)-1, n)
+	}
+	return got
+}
+
+// TestElementCodecs 测试内置元素编解码器的往返编码
+func TestElementCodecs(t *testing.T) {
+	t.Run("整数", func(t *testing.T) {
+		for _, v := range []int64{0, 1, -1, 63, -64, math.MaxInt64, math.MinInt64} {
+			if got := roundTrip(t, Int[int64](), v); got != v {
+				t.Errorf("期望%d，实际为%d", v, got)
+			}
+		}
+		for _, v := range []uint8{0, 1, 127, 255} {
+			if got := roundTrip(t, Int[uint8](), v); got != v {
+				t.Errorf("期望%d，实际为%d", v, got)
+			}
+		}
+	})
+
+	t.Run("浮点数", func(t *testing.T) {
+		for _, v := range []float64{0, -1.5, math.Pi, math.Inf(-1), math.SmallestNonzeroFloat64} {
+			if got := roundTrip(t, Float[float64](), v); got != v {
+				t.Errorf("期望%v，实际为%v", v, got)
+			}
+		}
+		if got := roundTrip(t, Float[float64](), math.NaN()); !math.IsNaN(got) {
+			t.Errorf("期望NaN，实际为%v", got)
+		}
+		if got := roundTrip(t, Float[float32](), 1.25); got != 1.25 {
+			t.Errorf("期望1.25，实际为%v", got)
+		}
+	})
+
+	t.Run("字符串与字节", func(t *testing.T) {
+		for _, v := range []string{"", "a", "你好，世界"} {
+			if got := roundTrip(t, String[string](), v); got != v {
+				t.Errorf("期望%q，实际为%q", v, got)
+			}
+		}
+		data := []byte{1, 2, 3}
+		got := roundTrip(t, Bytes(), data)
+		if !slices.Equal(got, data) {
+			t.Errorf("期望%v，实际为%v", data, got)
+		}
+	})
+
+	t.Run("布尔值", func(t *testing.T) {
+		for _, v := range []bool{true, false} {
+			if got := roundTrip(t, Bool(), v); got != v {
+				t.Errorf("期望%v，实际为%v", v, got)
+			}
+		}
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		p := point{3, -4}
+		if got := roundTrip(t, JSON[point](), p); got != p {
+			t.Errorf("期望%v，实际为%v", p, got)
+		}
+		if _, err := JSON[func()]().Append(nil, func() {}); err == nil {
+			t.Error("无法编码为 JSON 的元素应返回错误")
+		}
+	})
+}
+
+// TestDecodeErrors 测试解码不完整或格式错误的数据
+func TestDecodeErrors(t *testing.T) {
+	cases := map[string]struct {
+		decode func() error
+		want   error
+	}{
+		"整数为空": {func() error { _, _, err := Int[int]().Decode(nil); return err }, ErrTruncated},
+		"整数溢出": {func() error {
+			buf, _ := Int[int]().Append(nil, 300)
+			_, _, err := Int[int8]().Decode(buf)
+			return err
+		}, ErrFormat},
+		"浮点数不完整": {func() error { _, _, err := Float[float64]().Decode([]byte{1, 2}); return err }, ErrTruncated},
+		"字符串不完整": {func() error { _, _, err := String[string]().Decode([]byte{5, 'a'}); return err }, ErrTruncated},
+		"布尔值非法":  {func() error { _, _, err := Bool().Decode([]byte{2}); return err }, ErrFormat},
+		"序列为空":   {func() error { _, err := DecodeSeq(nil, Int[int]()); return err }, ErrTruncated},
+		"版本号错误":  {func() error { _, err := DecodeSeq([]byte{99, 0}, Int[int]()); return err }, ErrFormat},
+		"数量过大":   {func() error { _, err := DecodeSeq([]byte{version, 100, 1}, Int[int]()); return err }, ErrTruncated},
+		"多余数据":   {func() error { _, err := DecodeSeq([]byte{version, 1, 2, 3}, Int[int]()); return err }, ErrFormat},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			if err := c.decode(); !errors.Is(err, c.want) {
+				t.Errorf("期望错误%v，实际为%v", c.want, err)
+			}
+		})
+	}
+}
+
+// TestSeq 测试元素序列的二进制与 JSON 编码
+func TestSeq(t *testing.T) {
+	values := []string{"a", "", "中文"}
+	data, err := EncodeSeq(slices.Values(values), For[string]())
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := DecodeSeq(data, For[string]())
+	if err != nil || !slices.Equal(got, values) {
+		t.Errorf("DecodeSeq = (%v, %v)，期望%v", got, err, values)
+	}
+
+	t.Run("空序列", func(t *testing.T) {
+		data, _ := EncodeSeq(slices.Values([]int(nil)), For[int]())
+		got, err := DecodeSeq(data, For[int]())
+		if err != nil || len(got) != 0 {
+			t.Errorf("DecodeSeq = (%v, %v)", got, err)
+		}
+		js, _ := MarshalJSONSeq(slices.Values([]int(nil)))
+		if string(js) != "[]" {
+			t.Errorf("空序列应编码为[]，实际为%s", js)
+		}
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		js, err := MarshalJSONSeq(slices.Values(values))
+		if err != nil || string(js) != `["a","","中文"]` {
+			t.Errorf("MarshalJSONSeq = (%s, %v)", js, err)
+		}
+		got, err := UnmarshalJSONSeq[string](js)
+		if err != nil || !slices.Equal(got, values) {
+			t.Errorf("UnmarshalJSONSeq = (%v, %v)", got, err)
+		}
+	})
+}
+
+// TestSeq2 测试键值对序列的二进制与 JSON 编码
+func TestSeq2(t *testing.T) {
+	keys := []int{3, 1, 2}
+	values := []point{{1, 1}, {2, 2}, {3, 3}}
+	seq := func(yield func(int, point) bool) {
+		for i, k := range keys {
+			if !yield(k, values[i]) {
+				return
+			}
+		}
+	}
+
+	data, err := EncodeSeq2(seq, For[int](), For[point]())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var gotKeys []int
+	var gotValues []point
+	err = DecodeSeq2(data, For[int](), For[point](), func(k int, v point) {
+		gotKeys = append(gotKeys, k)
+		gotValues = append(gotValues, v)
+	})
+	if err != nil || !slices.Equal(gotKeys, keys) || !slices.Equal(gotValues, values) {
+		t.Errorf("DecodeSeq2 = (%v, %v, %v)", gotKeys, gotValues, err)
+	}
+
+	t.Run("错误数据不回调", func(t *testing.T) {
+		called := false
+		err := DecodeSeq2(data[:len(data)-1], For[int](), For[point](), func(int, point) { called = true })
+		if err == nil || called {
+			t.Errorf("截断的数据应返回错误且不回调，err=%v called=%v", err, called)
+		}
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		js, err := MarshalJSONSeq2(seq)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := `[{"key":3,"value":{"X":1,"Y":1}},{"key":1,"value":{"X":2,"Y":2}},{"key":2,"value":{"X":3,"Y":3}}]`
+		if string(js) != want {
+			t.Errorf("期望%s，实际为%s", want, js)
+		}
+		gotKeys = nil
+		err = UnmarshalJSONSeq2(js, func(k int, v point) { gotKeys = append(gotKeys, k) })
+		if err != nil || !slices.Equal(gotKeys, keys) {
+			t.Errorf("UnmarshalJSONSeq2 = (%v, %v)", gotKeys, err)
+		}
+	})
+}
+
+// celsius 摄氏温度
+type celsius float64
+
+// centiCodec 把温度按百分之一度编码为整数的测试用编解码器
+type centiCodec struct{}
+
+func (centiCodec) Append(buf []byte, v celsius) ([]byte, error) {
+	return Int[int64]().Append(buf, int64(v*100))
+}
+
+func (centiCodec) Decode(data []byte) (celsius, int, error) {
+	v, n, err := Int[int64]().Decode(data)
+	return celsius(v) / 100, n, err
+}
+
+// TestFor 测试按类型选择编解码器与注册自定义编解码器
+func TestFor(t *testing.T) {
+	if _, ok := For[int]().(intCodec[int]); !ok {
+		t.Error("int 应使用内置整数编解码器")
+	}
+	if _, ok := For[[]byte]().(bytesCodec); !ok {
+		t.Error("[]byte 应使用内置字节编解码器")
+	}
+	if _, ok := For[point]().(jsonCodec[point]); !ok {
+		t.Error("结构体应使用 JSON 编解码器")
+	}
+
+	Register[celsius](centiCodec{})
+	c := For[celsius]()
+	if _, ok := c.(centiCodec); !ok {
+		t.Fatal("应返回注册的编解码器")
+	}
+	buf, _ := c.Append(nil, 36.5)
+	if len(buf) != 2 {
+		t.Errorf("自定义编码应为2字节，实际为%d", len(buf))
+	}
+	if got := roundTrip(t, c, 36.5); got != 36.5 {
+		t.Errorf("期望36.5，实际为%v", got)
+	}
+}
+
+// BenchmarkEncodeSeq 测试序列编码的性能
+func BenchmarkEncodeSeq(b *testing.B) {
+	values := make([]int, 10000)
+	for i := range values {
+		values[i] = i * 31
+	}
+	b.Run("整数编码", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			EncodeSeq(slices.Values(values), For[int]())
+		}
+	})
+	data, _ := EncodeSeq(slices.Values(values), For[int]())
+	b.Run("整数解码", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			DecodeSeq(data, For[int]())
+		}
+	})
+}
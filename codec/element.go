@@ -0,0 +1,250 @@
+package codec
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"math"
+	"reflect"
+	"sync"
+
+	"golang.org/x/exp/constraints"
+)
+
+// Codec 元素编解码器
+// 每个元素的编码结果至少占一个字节，且必须能够从后续数据中自行确定长度
+type Codec[T any] interface {
+	// Append 把 v 的编码追加到 buf 末尾并返回新的切片
+	Append(buf []byte, v T) ([]byte, error)
+
+	// Decode 从 data 开头解码一个元素，返回元素和消耗的字节数
+	Decode(data []byte) (T, int, error)
+}
+
+// registry 通过 Register 注册的编解码器，键为元素类型
+var registry sync.Map
+
+// Register 为类型 T 注册编解码器，之后 For[T] 返回该编解码器
+// 应在程序初始化时调用，重复注册时后注册的生效
+func Register[T any](c Codec[T]) {
+	registry.Store(reflect.TypeFor[T](), c)
+}
+
+// For 返回类型 T 的编解码器
+// 优先返回通过 Register 注册的编解码器；
+// 其次为整数、浮点数、字符串、布尔值和 []byte 返回内置的紧凑编解码器；
+// 其余类型使用 JSON 编码
+func For[T any]() Codec[T] {
+	if c, ok := registry.Load(reflect.TypeFor[T]()); ok {
+		return c.(Codec[T])
+	}
+	var c any
+	switch any(*new(T)).(type) {
+	case int:
+		c = Int[int]()
+	case int8:
+		c = Int[int8]()
+	case int16:
+		c = Int[int16]()
+	case int32:
+		c = Int[int32]()
+	case int64:
+		c = Int[int64]()
+	case uint:
+		c = Int[uint]()
+	case uint8:
+		c = Int[uint8]()
+	case uint16:
+		c = Int[uint16]()
+	case uint32:
+		c = Int[uint32]()
+	case uint64:
+		c = Int[uint64]()
+	case uintptr:
+		c = Int[uintptr]()
+	case float32:
+		c = Float[float32]()
+	case float64:
+		c = Float[float64]()
+	case string:
+		c = String[string]()
+	case bool:
+		c = Bool()
+	case []byte:
+		c = Bytes()
+	default:
+		return JSON[T]()
+	}
+	return c.(Codec[T])
+}
+
+// Int 返回整数编解码器，有符号整数使用 zigzag 变长编码，无符号整数使用变长编码
+func Int[T constraints.Integer]() Codec[T] {
+	return intCodec[T]{}
+}
+
+type intCodec[T constraints.Integer] struct{}
+
+func (intCodec[T]) signed() bool {
+	return ^T(0) < 0
+}
+
+func (c intCodec[T]) Append(buf []byte, v T) ([]byte, error) {
+	if c.signed() {
+		return binary.AppendVarint(buf, int64(v)), nil
+	}
+	return binary.AppendUvarint(buf, uint64(v)), nil
+}
+
+func (c intCodec[T]) Decode(data []byte) (T, int, error) {
+	var v T
+	var size int
+	if c.signed() {
+		var x int64
+		x, size = binary.Varint(data)
+		v = T(x)
+		if size > 0 && int64(v) != x {
+			return 0, 0, ErrFormat
+		}
+	} else {
+		var x uint64
+		x, size = binary.Uvarint(data)
+		v = T(x)
+		if size > 0 && uint64(v) != x {
+			return 0, 0, ErrFormat
+		}
+	}
+	switch {
+	case size == 0:
+		return 0, 0, ErrTruncated
+	case size < 0:
+		return 0, 0, ErrFormat
+	}
+	return v, size, nil
+}
+
+// Float 返回浮点数编解码器，每个元素固定占8个字节
+func Float[T constraints.Float]() Codec[T] {
+	return floatCodec[T]{}
+}
+
+type floatCodec[T constraints.Float] struct{}
+
+func (floatCodec[T]) Append(buf []byte, v T) ([]byte, error) {
+	return binary.BigEndian.AppendUint64(buf, math.Float64bits(float64(v))), nil
+}
+
+func (floatCodec[T]) Decode(data []byte) (T, int, error) {
+	if len(data) < 8 {
+		return 0, 0, ErrTruncated
+	}
+	return T(math.Float64frombits(binary.BigEndian.Uint64(data))), 8, nil
+}
+
+// String 返回字符串编解码器，编码为变长的长度前缀加字符串内容
+func String[T ~string]() Codec[T] {
+	return stringCodec[T]{}
+}
+
+type stringCodec[T ~string] struct{}
+
+func (stringCodec[T]) Append(buf []byte, v T) ([]byte, error) {
+	buf = binary.AppendUvarint(buf, uint64(len(v)))
+	return append(buf, v...), nil
+}
+
+func (stringCodec[T]) Decode(data []byte) (T, int, error) {
+	b, size, err := readBytes(data)
+	return T(b), size, err
+}
+
+// Bytes 返回字节切片编解码器，解码结果不与输入数据共享内存
+func Bytes() Codec[[]byte] {
+	return bytesCodec{}
+}
+
+type bytesCodec struct{}
+
+func (bytesCodec) Append(buf []byte, v []byte) ([]byte, error) {
+	buf = binary.AppendUvarint(buf, uint64(len(v)))
+	return append(buf, v...), nil
+}
+
+func (bytesCodec) Decode(data []byte) ([]byte, int, error) {
+	b, size, err := readBytes(data)
+	if err != nil {
+		return nil, 0, err
+	}
+	return append([]byte(nil), b...), size, nil
+}
+
+// Bool 返回布尔值编解码器，每个元素占1个字节
+func Bool() Codec[bool] {
+	return boolCodec{}
+}
+
+type boolCodec struct{}
+
+func (boolCodec) Append(buf []byte, v bool) ([]byte, error) {
+	if v {
+		return append(buf, 1), nil
+	}
+	return append(buf, 0), nil
+}
+
+func (boolCodec) Decode(data []byte) (bool, int, error) {
+	if len(data) == 0 {
+		return false, 0, ErrTruncated
+	}
+	switch data[0] {
+	case 0:
+		return false, 1, nil
+	case 1:
+		return true, 1, nil
+	}
+	return false, 0, ErrFormat
+}
+
+// JSON 返回使用 encoding/json 的编解码器，编码为变长的长度前缀加 JSON 文本
+// 适用于任意可以 JSON 序列化的类型
+func JSON[T any]() Codec[T] {
+	return jsonCodec[T]{}
+}
+
+type jsonCodec[T any] struct{}
+
+func (jsonCodec[T]) Append(buf []byte, v T) ([]byte, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return buf, err
+	}
+	buf = binary.AppendUvarint(buf, uint64(len(b)))
+	return append(buf, b...), nil
+}
+
+func (jsonCodec[T]) Decode(data []byte) (T, int, error) {
+	var v T
+	b, size, err := readBytes(data)
+	if err != nil {
+		return v, 0, err
+	}
+	if err := json.Unmarshal(b, &v); err != nil {
+		return v, 0, err
+	}
+	return v, size, nil
+}
+
+// readBytes 读取带长度前缀的字节串，返回的切片与 data 共享内存
+func readBytes(data []byte) ([]byte, int, error) {
+	n, size := binary.Uvarint(data)
+	switch {
+	case size == 0:
+		return nil, 0, ErrTruncated
+	case size < 0:
+		return nil, 0, ErrFormat
+	}
+	if n > uint64(len(data)-size) {
+		return nil, 0, ErrTruncated
+	}
+	end := size + int(n)
+	return data[size:end], end, nil
+}
@@ -0,0 +1,160 @@
+package concurrent
+
+import (
+	"iter"
+	"sync"
+
+	"godatastructure/btree"
+	"godatastructure/codec"
+	"golang.org/x/exp/constraints"
+)
+
+// BTree 使用读写锁保护的 B 树
+type BTree[K constraints.Ordered, V any] struct {
+	mu sync.RWMutex
+	t  *btree.BTree[K, V]
+}
+
+// NewBTree 返回 t 的并发安全包装
+// 时间复杂度: O(1)
+func NewBTree[K constraints.Ordered, V any](t *btree.BTree[K, V]) *BTree[K, V] {
+	return &BTree[K, V]{t: t}
+}
+
+// Len 返回键值对数量
+func (t *BTree[K, V]) Len() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.t.Len()
+}
+
+// Size 返回键值对数量，与 Len 相同
+func (t *BTree[K, V]) Size() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.t.Size()
+}
+
+// IsEmpty 检查 B 树是否为空
+func (t *BTree[K, V]) IsEmpty() bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.t.IsEmpty()
+}
+
+// Compare 按键的自然顺序比较两个键
+func (t *BTree[K, V]) Compare(a, b K) int {
+	return t.t.Compare(a, b)
+}
+
+// Height 返回树的高度，空树为0
+func (t *BTree[K, V]) Height() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.t.Height()
+}
+
+// Clear 清空 B 树
+func (t *BTree[K, V]) Clear() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.t.Clear()
+}
+
+// Get 查找键对应的值
+func (t *BTree[K, V]) Get(key K) (V, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.t.Get(key)
+}
+
+// Put 插入或更新键值对
+func (t *BTree[K, V]) Put(key K, value V) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.t.Put(key, value)
+}
+
+// Swap 插入或更新键值对，并返回被替换的旧值
+func (t *BTree[K, V]) Swap(key K, value V) (V, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.t.Swap(key, value)
+}
+
+// Delete 删除键，返回键是否存在
+func (t *BTree[K, V]) Delete(key K) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.t.Delete(key)
+}
+
+// LoadAndDelete 删除键，返回被删除的值和键是否存在
+func (t *BTree[K, V]) LoadAndDelete(key K) (V, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.t.LoadAndDelete(key)
+}
+
+// Min 返回最小的键值对
+func (t *BTree[K, V]) Min() (K, V, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.t.Min()
+}
+
+// Max 返回最大的键值对
+func (t *BTree[K, V]) Max() (K, V, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.t.Max()
+}
+
+// Ascend 按键升序遍历调用时刻的快照，fn 在锁外调用，返回 false 时停止遍历
+func (t *BTree[K, V]) Ascend(fn func(key K, value V) bool) {
+	for k, v := range t.All() {
+		if !fn(k, v) {
+			return
+		}
+	}
+}
+
+// AscendRange 按键升序遍历调用时刻快照中 [greaterOrEqual, lessThan) 范围内的键值对，fn 在锁外调用
+func (t *BTree[K, V]) AscendRange(greaterOrEqual, lessThan K, fn func(key K, value V) bool) {
+	t.mu.RLock()
+	var entries []codec.Entry[K, V]
+	t.t.AscendRange(greaterOrEqual, lessThan, func(key K, value V) bool {
+		entries = append(entries, codec.Entry[K, V]{Key: key, Value: value})
+		return true
+	})
+	t.mu.RUnlock()
+	for _, e := range entries {
+		if !fn(e.Key, e.Value) {
+			return
+		}
+	}
+}
+
+// All 返回按键升序遍历调用时刻快照的迭代器
+func (t *BTree[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		t.mu.RLock()
+		var entries []codec.Entry[K, V]
+		for k, v := range t.t.All() {
+			entries = append(entries, codec.Entry[K, V]{Key: k, Value: v})
+		}
+		t.mu.RUnlock()
+		for _, e := range entries {
+			if !yield(e.Key, e.Value) {
+				return
+			}
+		}
+	}
+}
+
+// Clone 返回深拷贝的并发安全包装
+func (t *BTree[K, V]) Clone(copier func(V) V) *BTree[K, V] {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return NewBTree(t.t.Clone(copier))
+}
@@ -0,0 +1,66 @@
+package concurrent
+
+import (
+	"sync"
+	"testing"
+
+	"godatastructure/btree"
+)
+
+// TestBTree 测试 B 树包装的并发读写与遍历
+func TestBTree(t *testing.T) {
+	tree := NewBTree(btree.NewBTree[int, int](2))
+	var wg sync.WaitGroup
+	for i := range 500 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			tree.Put(i, i*i)
+		}()
+		go func() {
+			defer wg.Done()
+			tree.Get(i)
+		}()
+	}
+	wg.Wait()
+	if tree.Len() != 500 || tree.Height() == 0 {
+		t.Fatalf("期望大小为500，实际为%d", tree.Len())
+	}
+	if v, ok := tree.Get(20); !ok || v != 400 {
+		t.Errorf("Get(20) = (%v, %v)，期望 (400, true)", v, ok)
+	}
+	if k, _, ok := tree.Min(); !ok || k != 0 {
+		t.Errorf("最小键应为0，实际为%v", k)
+	}
+
+	var keys []int
+	tree.AscendRange(10, 15, func(key, value int) bool {
+		tree.Delete(key) // 回调在锁外调用，不应死锁
+		keys = append(keys, key)
+		return true
+	})
+	if len(keys) != 5 || tree.Len() != 495 {
+		t.Errorf("期望遍历并删除5个键，实际为%v", keys)
+	}
+
+	count := 0
+	tree.Ascend(func(key, value int) bool {
+		count++
+		return count < 3
+	})
+	if count != 3 {
+		t.Error("返回false时应停止遍历")
+	}
+
+	clone := tree.Clone(nil)
+	if old, ok := tree.Swap(0, -1); !ok || old != 0 {
+		t.Errorf("Swap(0) = (%v, %v)，期望 (0, true)", old, ok)
+	}
+	if v, _ := clone.Get(0); v != 0 {
+		t.Error("修改原树不应影响拷贝")
+	}
+	tree.Clear()
+	if !tree.IsEmpty() || clone.Size() != 495 {
+		t.Error("清空原树不应影响拷贝")
+	}
+}
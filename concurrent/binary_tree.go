@@ -0,0 +1,281 @@
+package concurrent
+
+import (
+	"iter"
+	"slices"
+	"sync"
+
+	"godatastructure/binarytree"
+)
+
+// BinaryTree 使用读写锁保护的二叉搜索树，可以包装普通二叉搜索树、伸展树和 AVL 树
+type BinaryTree[T any] struct {
+	mu sync.RWMutex
+	t  binarytree.BinaryTree[T]
+}
+
+// NewBinaryTree 返回 t 的并发安全包装
+// 时间复杂度: O(1)
+func NewBinaryTree[T any](t binarytree.BinaryTree[T]) binarytree.BinaryTree[T] {
+	return &BinaryTree[T]{t: t}
+}
+
+// Insert 插入元素，返回元素数量是否增加
+func (t *BinaryTree[T]) Insert(value T) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.t.Insert(value)
+}
+
+// Search 查找元素
+// 伸展树的查找会调整树的结构，因此持有写锁；
+// 为避免在锁外访问树的内部，返回的是节点的副本，其 Left 和 Right 恒为 nil
+func (t *BinaryTree[T]) Search(value T) *binarytree.TreeNode[T] {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	n := t.t.Search(value)
+	if n == nil {
+		return nil
+	}
+	return &binarytree.TreeNode[T]{Value: n.Value}
+}
+
+// Remove 删除元素，返回元素是否存在
+func (t *BinaryTree[T]) Remove(value T) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.t.Remove(value)
+}
+
+// RemoveAll 删除值等于 value 的所有元素，返回删除的元素数量
+func (t *BinaryTree[T]) RemoveAll(value T) int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.t.RemoveAll(value)
+}
+
+// RemoveAndGet 删除一个值等于 value 的元素，返回树中存储的值
+func (t *BinaryTree[T]) RemoveAndGet(value T) (T, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.t.RemoveAndGet(value)
+}
+
+// RemoveFunc 删除所有满足 pred 的元素，返回删除的元素数量
+// pred 在持有写锁时调用，不能再访问该树
+func (t *BinaryTree[T]) RemoveFunc(pred func(T) bool) int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.t.RemoveFunc(pred)
+}
+
+// Count 返回值等于 value 的元素数量
+func (t *BinaryTree[T]) Count(value T) int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.t.Count(value)
+}
+
+// PreOrderTraversal 对调用时刻的快照进行前序遍历，f 在锁外调用，返回 false 时停止
+func (t *BinaryTree[T]) PreOrderTraversal(f func(T) bool) {
+	t.PreOrder()(f)
+}
+
+// InOrderTraversal 对调用时刻的快照进行中序遍历，f 在锁外调用，返回 false 时停止
+func (t *BinaryTree[T]) InOrderTraversal(f func(T) bool) {
+	t.InOrder()(f)
+}
+
+// MorrisInOrderTraversal 对调用时刻的快照进行中序遍历，f 在锁外调用，返回 false 时停止
+// Morris 遍历会临时修改节点的指针，因此收集快照时持有写锁
+func (t *BinaryTree[T]) MorrisInOrderTraversal(f func(T) bool) {
+	snapshot(&t.mu, func() iter.Seq[T] { return t.t.MorrisInOrderTraversal })(f)
+}
+
+// PostOrderTraversal 对调用时刻的快照进行后序遍历，f 在锁外调用，返回 false 时停止
+func (t *BinaryTree[T]) PostOrderTraversal(f func(T) bool) {
+	t.PostOrder()(f)
+}
+
+// PreOrder 返回前序遍历调用时刻快照的迭代器
+func (t *BinaryTree[T]) PreOrder() iter.Seq[T] {
+	return snapshot(t.mu.RLocker(), t.t.PreOrder)
+}
+
+// InOrder 返回中序遍历调用时刻快照的迭代器
+func (t *BinaryTree[T]) InOrder() iter.Seq[T] {
+	return snapshot(t.mu.RLocker(), t.t.InOrder)
+}
+
+// PostOrder 返回后序遍历调用时刻快照的迭代器
+func (t *BinaryTree[T]) PostOrder() iter.Seq[T] {
+	return snapshot(t.mu.RLocker(), t.t.PostOrder)
+}
+
+// All 返回中序遍历调用时刻快照的迭代器
+func (t *BinaryTree[T]) All() iter.Seq[T] {
+	return snapshot(t.mu.RLocker(), t.t.All)
+}
+
+// Leaves 返回所有叶子节点的元素
+func (t *BinaryTree[T]) Leaves() []T {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.t.Leaves()
+}
+
+// Paths 对调用时刻的快照把每条从根节点到叶子节点的路径传给 fn，fn 在锁外调用
+func (t *BinaryTree[T]) Paths(fn func(path []T)) {
+	var paths [][]T
+	t.mu.RLock()
+	t.t.Paths(func(path []T) {
+		paths = append(paths, slices.Clone(path))
+	})
+	t.mu.RUnlock()
+	for _, path := range paths {
+		fn(path)
+	}
+}
+
+// Size 返回元素数量
+func (t *BinaryTree[T]) Size() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.t.Size()
+}
+
+// Height 返回树高
+func (t *BinaryTree[T]) Height() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.t.Height()
+}
+
+// Depth 返回值等于 value 的节点的深度，不存在时返回-1
+// 伸展树的 Depth 也不会调整树的结构，因此只需持有读锁
+func (t *BinaryTree[T]) Depth(value T) int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.t.Depth(value)
+}
+
+// Min 返回最小元素
+// 伸展树会把最小元素旋转到根部，因此持有写锁
+func (t *BinaryTree[T]) Min() (T, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.t.Min()
+}
+
+// Max 返回最大元素
+// 伸展树会把最大元素旋转到根部，因此持有写锁
+func (t *BinaryTree[T]) Max() (T, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.t.Max()
+}
+
+// Kth 返回第 k 小的元素（k 从0开始）
+// 各实现的 Kth 都不会调整树的结构，因此只需持有读锁
+func (t *BinaryTree[T]) Kth(k int) (T, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.t.Kth(k)
+}
+
+// LCA 返回值为 a 和 b 的两个节点的最近公共祖先
+// 与 Search 一样返回节点的副本，其 Left 和 Right 恒为 nil
+func (t *BinaryTree[T]) LCA(a, b T) (*binarytree.TreeNode[T], bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	n, ok := t.t.LCA(a, b)
+	if !ok {
+		return nil, false
+	}
+	return &binarytree.TreeNode[T]{Value: n.Value}, true
+}
+
+// Serialize 按前序把树的结构和元素编码为二进制
+func (t *BinaryTree[T]) Serialize() ([]byte, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.t.Serialize()
+}
+
+// Deserialize 从 Serialize 生成的数据中还原树的结构，替换树中的所有元素
+func (t *BinaryTree[T]) Deserialize(data []byte) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.t.Deserialize(data)
+}
+
+// Invert 交换所有节点的左右子树，同时反转比较函数
+func (t *BinaryTree[T]) Invert() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.t.Invert()
+}
+
+// IsSymmetric 检查树是否与其镜像相同
+func (t *BinaryTree[T]) IsSymmetric() bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.t.IsSymmetric()
+}
+
+// Validate 检查被包装的树的结构不变量
+func (t *BinaryTree[T]) Validate() error {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.t.Validate()
+}
+
+// IsBalanced 检查树是否高度平衡
+func (t *BinaryTree[T]) IsBalanced() bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.t.IsBalanced()
+}
+
+// IsEmpty 检查树是否为空
+func (t *BinaryTree[T]) IsEmpty() bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.t.IsEmpty()
+}
+
+// Clear 清空树
+func (t *BinaryTree[T]) Clear() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.t.Clear()
+}
+
+// Compare 使用比较函数比较两个元素
+// Invert 会反转比较函数，因此需要持有读锁
+func (t *BinaryTree[T]) Compare(a, b T) int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.t.Compare(a, b)
+}
+
+// Clone 返回深拷贝的并发安全包装
+func (t *BinaryTree[T]) Clone(copier func(T) T) binarytree.BinaryTree[T] {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return NewBinaryTree(t.t.Clone(copier))
+}
+
+// String 返回逐层缩进的树结构，用于调试
+func (t *BinaryTree[T]) String() string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.t.String()
+}
+
+// ToDOT 返回 Graphviz DOT 格式的树结构
+func (t *BinaryTree[T]) ToDOT() string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.t.ToDOT()
+}
@@ -0,0 +1,114 @@
+package concurrent
+
+import (
+	"slices"
+	"sync"
+	"testing"
+
+	"godatastructure/binarytree"
+)
+
+// TestBinaryTree 测试二叉搜索树与伸展树包装的并发读写
+func TestBinaryTree(t *testing.T) {
+	trees := map[string]binarytree.BinaryTree[int]{
+		"二叉搜索树": NewBinaryTree(binarytree.New(intCmp)),
+		"伸展树":   NewBinaryTree(binarytree.NewSplay(intCmp)),
+		"AVL树":  NewBinaryTree(binarytree.NewAVL(intCmp)),
+		"树堆":    NewBinaryTree[int](binarytree.NewTreap(intCmp)),
+	}
+	for name, tree := range trees {
+		t.Run(name, func(t *testing.T) {
+			var wg sync.WaitGroup
+			for i := range 200 {
+				wg.Add(2)
+				go func() {
+					defer wg.Done()
+					tree.Insert(i)
+				}()
+				go func() {
+					defer wg.Done()
+					tree.Search(i) // 伸展树的查找会修改结构
+				}()
+			}
+			wg.Wait()
+			if tree.Size() != 200 {
+				t.Fatalf("期望大小为200，实际为%d", tree.Size())
+			}
+
+			if h, d := tree.Height(), tree.Depth(7); h < 8 || d < 0 || d >= h {
+				t.Errorf("Height() = %d, Depth(7) = %d，不符合200个节点的树", h, d)
+			}
+
+			if lo, _ := tree.Min(); lo != 0 {
+				t.Errorf("Min() = %d，期望 0", lo)
+			}
+			if hi, _ := tree.Max(); hi != 199 {
+				t.Errorf("Max() = %d，期望 199", hi)
+			}
+
+			if v, ok := tree.Kth(100); !ok || v != 100 {
+				t.Errorf("Kth(100) = %d, %v，期望 100", v, ok)
+			}
+
+			if data, err := tree.Serialize(); err != nil {
+				t.Error(err)
+			} else if err := tree.Deserialize(data); err != nil || tree.Size() != 200 {
+				t.Errorf("序列化后还原失败: %v", err)
+			}
+
+			n := tree.Search(7)
+			if n == nil || n.Value != 7 || n.Left != nil || n.Right != nil {
+				t.Errorf("Search(7) = %v，期望值为7且没有子节点的副本", n)
+			}
+
+			var inorder []int
+			tree.InOrderTraversal(func(v int) bool {
+				tree.Size() // 回调在锁外调用，不应死锁
+				inorder = append(inorder, v)
+				return true
+			})
+			if len(inorder) != 200 || !slices.IsSorted(inorder) {
+				t.Error("中序遍历结果应为升序的200个元素")
+			}
+
+			pathCount := 0
+			tree.Paths(func(path []int) {
+				tree.Size() // 回调在锁外调用，不应死锁
+				pathCount++
+			})
+			if leaves := tree.Leaves(); pathCount != len(leaves) || pathCount == 0 {
+				t.Errorf("路径数量%d应等于叶子数量%d", pathCount, len(leaves))
+			}
+
+			var morris []int
+			tree.MorrisInOrderTraversal(func(v int) bool {
+				morris = append(morris, v)
+				return len(morris) < 10
+			})
+			if !slices.Equal(morris, inorder[:10]) {
+				t.Errorf("Morris 遍历应在10个元素后停止，实际为%v", morris)
+			}
+			if got := slices.Collect(tree.PreOrder()); len(got) != 200 {
+				t.Errorf("前序迭代器应产出200个元素，实际为%d", len(got))
+			}
+
+			tree.Insert(7)
+			if tree.Count(7) != 2 || tree.RemoveAll(7) != 2 || tree.Count(7) != 0 {
+				t.Error("Count 与 RemoveAll 应处理重复插入的元素")
+			}
+			if v, ok := tree.RemoveAndGet(8); !ok || v != 8 || tree.Search(8) != nil {
+				t.Errorf("RemoveAndGet(8) = %d, %v，期望 8", v, ok)
+			}
+			if n := tree.RemoveFunc(func(v int) bool { return v >= 100 }); n != 100 || tree.Size() != 98 {
+				t.Errorf("RemoveFunc 删除了%d个元素，剩余%d个", n, tree.Size())
+			}
+
+			for v := range tree.All() {
+				tree.Remove(v)
+			}
+			if !tree.IsEmpty() {
+				t.Errorf("遍历中删除全部元素后应为空，实际大小为%d", tree.Size())
+			}
+		})
+	}
+}
@@ -0,0 +1,26 @@
+// Package concurrent 提供各容器的并发安全包装
+// 包装类型实现与被包装容器相同的接口，所有方法都在读写锁的保护下调用被包装的容器：
+// 只读方法持有读锁，修改方法持有写锁。遍历方法先在锁内复制元素，再在锁外产出或回调，
+// 因此遍历期间可以安全地访问同一容器，但遍历结果是调用时刻的快照。
+// 包装后不应再直接访问被包装的容器
+package concurrent
+
+import (
+	"iter"
+	"slices"
+	"sync"
+)
+
+// snapshot 在持有锁时收集 all 产出的所有元素，返回在锁外产出这些元素的迭代器
+func snapshot[T any](l sync.Locker, all func() iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		l.Lock()
+		values := slices.Collect(all())
+		l.Unlock()
+		for _, v := range values {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package concurrent
+
+import (
+	"iter"
+	"slices"
+	"sync"
+	"testing"
+)
+
+func intCmp(a, b int) int {
+	return a - b
+}
+
+// TestSnapshot 测试快照迭代器在锁外产出元素并支持提前终止
+func TestSnapshot(t *testing.T) {
+	var mu sync.Mutex
+	values := []int{1, 2, 3}
+	seq := snapshot(&mu, func() iter.Seq[int] {
+		return slices.Values(values)
+	})
+
+	var got []int
+	for v := range seq {
+		// 锁已释放，再次加锁不应死锁
+		mu.Lock()
+		mu.Unlock()
+		got = append(got, v)
+	}
+	if !slices.Equal(got, values) {
+		t.Errorf("期望%v，实际为%v", values, got)
+	}
+
+	count := 0
+	for range seq {
+		count++
+		break
+	}
+	if count != 1 {
+		t.Error("迭代器未能提前终止")
+	}
+}
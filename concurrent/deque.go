@@ -0,0 +1,123 @@
+package concurrent
+
+import (
+	"iter"
+	"sync"
+
+	"godatastructure/queue"
+)
+
+// Deque 使用读写锁保护的双端队列
+type Deque[T any] struct {
+	mu sync.RWMutex
+	d  queue.Deque[T]
+}
+
+// NewDeque 返回 d 的并发安全包装
+// 时间复杂度: O(1)
+func NewDeque[T any](d queue.Deque[T]) queue.Deque[T] {
+	return &Deque[T]{d: d}
+}
+
+// PushFront 在队首插入元素
+func (d *Deque[T]) PushFront(value T) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.d.PushFront(value)
+}
+
+// PushBack 在队尾插入元素
+func (d *Deque[T]) PushBack(value T) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.d.PushBack(value)
+}
+
+// PopFront 移除并返回队首元素
+func (d *Deque[T]) PopFront() (T, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.d.PopFront()
+}
+
+// PopBack 移除并返回队尾元素
+func (d *Deque[T]) PopBack() (T, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.d.PopBack()
+}
+
+// Front 查看队首元素但不移除
+func (d *Deque[T]) Front() (T, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.d.Front()
+}
+
+// Back 查看队尾元素但不移除
+func (d *Deque[T]) Back() (T, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.d.Back()
+}
+
+// IsEmpty 检查双端队列是否为空
+func (d *Deque[T]) IsEmpty() bool {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.d.IsEmpty()
+}
+
+// Size 获取双端队列中元素个数
+func (d *Deque[T]) Size() int {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.d.Size()
+}
+
+// Clear 清空双端队列
+func (d *Deque[T]) Clear() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.d.Clear()
+}
+
+// All 返回从队首到队尾遍历调用时刻快照的迭代器
+func (d *Deque[T]) All() iter.Seq[T] {
+	return snapshot(d.mu.RLocker(), d.d.All)
+}
+
+// MarshalBinary 把双端队列编码为二进制
+func (d *Deque[T]) MarshalBinary() ([]byte, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.d.MarshalBinary()
+}
+
+// UnmarshalBinary 从二进制解码并替换双端队列中的所有元素
+func (d *Deque[T]) UnmarshalBinary(data []byte) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.d.UnmarshalBinary(data)
+}
+
+// MarshalJSON 把双端队列编码为 JSON 数组
+func (d *Deque[T]) MarshalJSON() ([]byte, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.d.MarshalJSON()
+}
+
+// UnmarshalJSON 从 JSON 数组解码并替换双端队列中的所有元素
+func (d *Deque[T]) UnmarshalJSON(data []byte) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.d.UnmarshalJSON(data)
+}
+
+// Clone 返回深拷贝的并发安全包装
+func (d *Deque[T]) Clone(copier func(T) T) queue.Deque[T] {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return NewDeque(d.d.Clone(copier))
+}
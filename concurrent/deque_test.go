@@ -0,0 +1,61 @@
+package concurrent
+
+import (
+	"slices"
+	"sync"
+	"testing"
+
+	"godatastructure/queue"
+)
+
+// TestDeque 测试双端队列包装的基本操作与并发读写
+func TestDeque(t *testing.T) {
+	d := NewDeque(queue.NewDeque[int]())
+	var wg sync.WaitGroup
+	for i := range 500 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			d.PushFront(i)
+		}()
+		go func() {
+			defer wg.Done()
+			d.PushBack(i)
+		}()
+	}
+	wg.Wait()
+	if d.Size() != 1000 {
+		t.Fatalf("期望大小为1000，实际为%d", d.Size())
+	}
+
+	for range 500 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			if _, err := d.PopFront(); err != nil {
+				t.Error(err)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			if _, err := d.PopBack(); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+	if !d.IsEmpty() {
+		t.Errorf("全部弹出后应为空，实际大小为%d", d.Size())
+	}
+
+	d.PushBack(1)
+	d.PushBack(2)
+	clone := d.Clone(nil)
+	d.Clear()
+	if got := slices.Collect(clone.All()); !slices.Equal(got, []int{1, 2}) {
+		t.Errorf("清空原队列不应影响拷贝，实际为%v", got)
+	}
+	if _, err := d.Front(); err == nil {
+		t.Error("空队列查看队首应返回错误")
+	}
+}
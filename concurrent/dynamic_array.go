@@ -0,0 +1,130 @@
+package concurrent
+
+import (
+	"iter"
+	"sync"
+
+	dynamicarray "godatastructure/array"
+)
+
+// DynamicArray 使用读写锁保护的动态数组
+type DynamicArray[T any] struct {
+	mu sync.RWMutex
+	a  dynamicarray.DynamicArray[T]
+}
+
+// NewDynamicArray 返回 a 的并发安全包装
+// 时间复杂度: O(1)
+func NewDynamicArray[T any](a dynamicarray.DynamicArray[T]) dynamicarray.DynamicArray[T] {
+	return &DynamicArray[T]{a: a}
+}
+
+// Append 在数组末尾添加元素
+func (a *DynamicArray[T]) Append(value T) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.a.Append(value)
+}
+
+// Insert 在指定位置插入元素
+func (a *DynamicArray[T]) Insert(index int, value T) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.a.Insert(index, value)
+}
+
+// Remove 删除指定位置的元素并返回
+func (a *DynamicArray[T]) Remove(index int) (T, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.a.Remove(index)
+}
+
+// Get 获取指定位置的元素
+func (a *DynamicArray[T]) Get(index int) (T, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.a.Get(index)
+}
+
+// Set 设置指定位置的元素
+func (a *DynamicArray[T]) Set(index int, value T) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.a.Set(index, value)
+}
+
+// Len 获取数组当前长度
+func (a *DynamicArray[T]) Len() int {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.a.Len()
+}
+
+// Cap 获取数组当前容量
+func (a *DynamicArray[T]) Cap() int {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.a.Cap()
+}
+
+// Size 获取数组当前长度，与 Len 相同
+func (a *DynamicArray[T]) Size() int {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.a.Size()
+}
+
+// IsEmpty 检查数组是否为空
+func (a *DynamicArray[T]) IsEmpty() bool {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.a.IsEmpty()
+}
+
+// Clear 清空数组并恢复初始容量
+func (a *DynamicArray[T]) Clear() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.a.Clear()
+}
+
+// All 返回按下标顺序遍历调用时刻快照的迭代器
+func (a *DynamicArray[T]) All() iter.Seq[T] {
+	return snapshot(a.mu.RLocker(), a.a.All)
+}
+
+// MarshalBinary 把数组编码为二进制
+func (a *DynamicArray[T]) MarshalBinary() ([]byte, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.a.MarshalBinary()
+}
+
+// UnmarshalBinary 从二进制解码并替换数组中的所有元素
+func (a *DynamicArray[T]) UnmarshalBinary(data []byte) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.a.UnmarshalBinary(data)
+}
+
+// MarshalJSON 把数组编码为 JSON 数组
+func (a *DynamicArray[T]) MarshalJSON() ([]byte, error) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.a.MarshalJSON()
+}
+
+// UnmarshalJSON 从 JSON 数组解码并替换数组中的所有元素
+func (a *DynamicArray[T]) UnmarshalJSON(data []byte) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.a.UnmarshalJSON(data)
+}
+
+// Clone 返回深拷贝的并发安全包装
+func (a *DynamicArray[T]) Clone(copier func(T) T) dynamicarray.DynamicArray[T] {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return NewDynamicArray(a.a.Clone(copier))
+}
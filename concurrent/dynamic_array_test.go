@@ -0,0 +1,62 @@
+package concurrent
+
+import (
+	"encoding/json"
+	"slices"
+	"sync"
+	"testing"
+
+	dynamicarray "godatastructure/array"
+)
+
+// TestDynamicArray 测试动态数组包装的基本操作与并发读写
+func TestDynamicArray(t *testing.T) {
+	a := NewDynamicArray(dynamicarray.New[int]())
+	var wg sync.WaitGroup
+	for i := range 1000 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			a.Append(i)
+			a.Get(0)
+		}()
+	}
+	wg.Wait()
+	if a.Len() != 1000 || a.Size() != 1000 || a.Cap() < 1000 {
+		t.Fatalf("期望长度为1000，实际为%d", a.Len())
+	}
+
+	for i := range 1000 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := a.Set(i, i*2); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+	if v, err := a.Get(10); err != nil || v != 20 {
+		t.Errorf("Get(10) = (%v, %v)，期望 (20, nil)", v, err)
+	}
+
+	data, err := json.Marshal(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dst := NewDynamicArray(dynamicarray.New[int]())
+	if err := json.Unmarshal(data, dst); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(slices.Collect(dst.All()), slices.Collect(a.All())) {
+		t.Error("解码结果与原数组不一致")
+	}
+
+	a.Clear()
+	if !a.IsEmpty() {
+		t.Error("清空后应为空")
+	}
+	if _, err := a.Remove(0); err == nil {
+		t.Error("越界删除应返回错误")
+	}
+}
@@ -0,0 +1,149 @@
+package concurrent
+
+import (
+	"iter"
+	"sync"
+
+	"godatastructure/list"
+)
+
+// LinkedList 使用读写锁保护的链表
+type LinkedList[T comparable] struct {
+	mu sync.RWMutex
+	l  list.LinkedList[T]
+}
+
+// NewLinkedList 返回 l 的并发安全包装
+// 时间复杂度: O(1)
+func NewLinkedList[T comparable](l list.LinkedList[T]) list.LinkedList[T] {
+	return &LinkedList[T]{l: l}
+}
+
+// Append 在链表末尾添加节点
+func (l *LinkedList[T]) Append(value T) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.l.Append(value)
+}
+
+// Prepend 在链表头部添加节点
+func (l *LinkedList[T]) Prepend(value T) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.l.Prepend(value)
+}
+
+// Insert 在指定位置插入节点
+func (l *LinkedList[T]) Insert(index int, value T) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.l.Insert(index, value)
+}
+
+// Remove 删除指定值的节点
+func (l *LinkedList[T]) Remove(value T) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.l.Remove(value)
+}
+
+// RemoveAt 删除指定位置的节点
+func (l *LinkedList[T]) RemoveAt(index int) (T, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.l.RemoveAt(index)
+}
+
+// Find 查找指定值的节点
+// 为避免在锁外访问链表内部，返回的是节点的副本，其 Next 恒为 nil
+func (l *LinkedList[T]) Find(value T) *list.Node[T] {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	n := l.l.Find(value)
+	if n == nil {
+		return nil
+	}
+	return &list.Node[T]{Value: n.Value}
+}
+
+// Get 获取指定位置的值
+func (l *LinkedList[T]) Get(index int) (T, bool) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.l.Get(index)
+}
+
+// Set 设置指定位置的值
+func (l *LinkedList[T]) Set(index int, value T) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.l.Set(index, value)
+}
+
+// IsEmpty 检查链表是否为空
+func (l *LinkedList[T]) IsEmpty() bool {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.l.IsEmpty()
+}
+
+// Size 获取链表长度
+func (l *LinkedList[T]) Size() int {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.l.Size()
+}
+
+// Clear 清空链表
+func (l *LinkedList[T]) Clear() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.l.Clear()
+}
+
+// ToSlice 将链表转换为切片
+func (l *LinkedList[T]) ToSlice() []T {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.l.ToSlice()
+}
+
+// All 返回从头到尾遍历调用时刻快照的迭代器
+func (l *LinkedList[T]) All() iter.Seq[T] {
+	return snapshot(l.mu.RLocker(), l.l.All)
+}
+
+// MarshalBinary 把链表编码为二进制
+func (l *LinkedList[T]) MarshalBinary() ([]byte, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.l.MarshalBinary()
+}
+
+// UnmarshalBinary 从二进制解码并替换链表中的所有元素
+func (l *LinkedList[T]) UnmarshalBinary(data []byte) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.l.UnmarshalBinary(data)
+}
+
+// MarshalJSON 把链表编码为 JSON 数组
+func (l *LinkedList[T]) MarshalJSON() ([]byte, error) {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.l.MarshalJSON()
+}
+
+// UnmarshalJSON 从 JSON 数组解码并替换链表中的所有元素
+func (l *LinkedList[T]) UnmarshalJSON(data []byte) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.l.UnmarshalJSON(data)
+}
+
+// Clone 返回深拷贝的并发安全包装
+func (l *LinkedList[T]) Clone(copier func(T) T) list.LinkedList[T] {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return NewLinkedList(l.l.Clone(copier))
+}
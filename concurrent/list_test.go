@@ -0,0 +1,66 @@
+package concurrent
+
+import (
+	"slices"
+	"sync"
+	"testing"
+
+	"godatastructure/list"
+)
+
+// TestLinkedList 测试链表包装的基本操作与并发读写
+func TestLinkedList(t *testing.T) {
+	l := NewLinkedList(list.New[int]())
+	var wg sync.WaitGroup
+	for i := range 100 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			l.Append(i)
+		}()
+		go func() {
+			defer wg.Done()
+			l.Find(i)
+			l.Size()
+		}()
+	}
+	wg.Wait()
+	if l.Size() != 100 {
+		t.Fatalf("期望大小为100，实际为%d", l.Size())
+	}
+
+	t.Run("Find返回副本", func(t *testing.T) {
+		n := l.Find(42)
+		if n == nil || n.Value != 42 || n.Next != nil {
+			t.Fatalf("Find(42) = %v，期望值为42且 Next 为 nil 的副本", n)
+		}
+		n.Value = -1
+		if l.Find(-1) != nil {
+			t.Error("修改副本不应影响链表")
+		}
+		if l.Find(1000) != nil {
+			t.Error("不存在的值应返回nil")
+		}
+	})
+
+	t.Run("遍历中修改", func(t *testing.T) {
+		count := 0
+		for v := range l.All() {
+			l.Remove(v) // 遍历中删除不应死锁
+			count++
+		}
+		if count != 100 || !l.IsEmpty() {
+			t.Errorf("期望遍历并删除100个元素，实际遍历%d个，剩余%d个", count, l.Size())
+		}
+	})
+
+	l.Append(1)
+	l.Prepend(0)
+	l.Insert(2, 2)
+	if got := l.ToSlice(); !slices.Equal(got, []int{0, 1, 2}) {
+		t.Errorf("期望[0 1 2]，实际为%v", got)
+	}
+	if _, ok := l.Clone(nil).(*LinkedList[int]); !ok {
+		t.Error("拷贝应仍是并发安全的包装")
+	}
+}
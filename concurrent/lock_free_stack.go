@@ -0,0 +1,175 @@
+package concurrent
+
+import (
+	"errors"
+	"iter"
+	"sync/atomic"
+
+	"godatastructure/codec"
+	"godatastructure/stack"
+)
+
+// lockFreeNode 无锁栈的节点，创建后不再修改
+type lockFreeNode[T any] struct {
+	value T
+	next  *lockFreeNode[T]
+	size  int // 以该节点为栈顶时栈中元素个数
+}
+
+// LockFreeStack 基于 CAS 的无锁栈（Treiber 栈）
+// 节点创建后不可变，由垃圾回收保证被弹出的节点不会被复用，因此不存在 ABA 问题。
+// 每个节点记录了以它为栈顶时的元素个数，Size 与 All 观察到的总是同一时刻的栈
+type LockFreeStack[T any] struct {
+	head atomic.Pointer[lockFreeNode[T]]
+}
+
+// NewLockFreeStack 创建一个新的空无锁栈
+// 时间复杂度: O(1)
+func NewLockFreeStack[T any]() stack.Stack[T] {
+	return &LockFreeStack[T]{}
+}
+
+// Push 将元素压入栈顶
+// 时间复杂度: 无竞争时O(1)
+func (s *LockFreeStack[T]) Push(value T) {
+	n := &lockFreeNode[T]{value: value}
+	for {
+		top := s.head.Load()
+		n.next = top
+		n.size = top.len() + 1
+		if s.head.CompareAndSwap(top, n) {
+			return
+		}
+	}
+}
+
+// Pop 弹出并返回栈顶元素
+// 如果栈为空，返回错误
+// 时间复杂度: 无竞争时O(1)
+func (s *LockFreeStack[T]) Pop() (T, error) {
+	for {
+		top := s.head.Load()
+		if top == nil {
+			var zero T
+			return zero, errors.New("栈为空")
+		}
+		if s.head.CompareAndSwap(top, top.next) {
+			return top.value, nil
+		}
+	}
+}
+
+// Peek 返回栈顶元素但不移除
+// 如果栈为空，返回错误
+// 时间复杂度: O(1)
+func (s *LockFreeStack[T]) Peek() (T, error) {
+	top := s.head.Load()
+	if top == nil {
+		var zero T
+		return zero, errors.New("栈为空")
+	}
+	return top.value, nil
+}
+
+// IsEmpty 检查栈是否为空
+// 时间复杂度: O(1)
+func (s *LockFreeStack[T]) IsEmpty() bool {
+	return s.head.Load() == nil
+}
+
+// Size 返回栈中元素的个数
+// 时间复杂度: O(1)
+func (s *LockFreeStack[T]) Size() int {
+	return s.head.Load().len()
+}
+
+// Clear 清空栈
+// 时间复杂度: O(1)
+func (s *LockFreeStack[T]) Clear() {
+	s.head.Store(nil)
+}
+
+// All 返回从栈顶到栈底遍历调用时刻快照的迭代器
+// 时间复杂度: O(n)
+func (s *LockFreeStack[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for n := s.head.Load(); n != nil; n = n.next {
+			if !yield(n.value) {
+				return
+			}
+		}
+	}
+}
+
+// MarshalBinary 使用 codec.For[T] 返回的元素编解码器把栈编码为二进制，按从栈顶到栈底的顺序编码
+// 实现 encoding.BinaryMarshaler 接口
+func (s *LockFreeStack[T]) MarshalBinary() ([]byte, error) {
+	return codec.EncodeSeq(s.All(), codec.For[T]())
+}
+
+// UnmarshalBinary 从 MarshalBinary 生成的数据中解码，替换栈中的所有元素
+// 解码失败时栈保持不变，实现 encoding.BinaryUnmarshaler 接口
+func (s *LockFreeStack[T]) UnmarshalBinary(data []byte) error {
+	values, err := codec.DecodeSeq(data, codec.For[T]())
+	if err != nil {
+		return err
+	}
+	s.load(values)
+	return nil
+}
+
+// MarshalJSON 把栈编码为 JSON 数组，实现 json.Marshaler 接口
+func (s *LockFreeStack[T]) MarshalJSON() ([]byte, error) {
+	return codec.MarshalJSONSeq(s.All())
+}
+
+// UnmarshalJSON 从 JSON 数组中解码，替换栈中的所有元素
+// 解码失败时栈保持不变，实现 json.Unmarshaler 接口
+func (s *LockFreeStack[T]) UnmarshalJSON(data []byte) error {
+	values, err := codec.UnmarshalJSONSeq[T](data)
+	if err != nil {
+		return err
+	}
+	s.load(values)
+	return nil
+}
+
+// load 用 values 替换栈中的元素，values 的第一个元素为栈顶
+// 新的栈先在本地构建完成，再以一次原子写入替换
+func (s *LockFreeStack[T]) load(values []T) {
+	s.head.Store(build(values, nil))
+}
+
+// Clone 返回栈在调用时刻的深拷贝
+// copier 用于复制每个元素，为 nil 时直接赋值
+// 时间复杂度: O(n)
+func (s *LockFreeStack[T]) Clone(copier func(T) T) stack.Stack[T] {
+	var values []T
+	for v := range s.All() {
+		values = append(values, v)
+	}
+	clone := &LockFreeStack[T]{}
+	clone.head.Store(build(values, copier))
+	return clone
+}
+
+// build 以 values 的第一个元素为栈顶构建节点链
+func build[T any](values []T, copier func(T) T) *lockFreeNode[T] {
+	var top *lockFreeNode[T]
+	for i := len(values) - 1; i >= 0; i-- {
+		v := values[i]
+		if copier != nil {
+			v = copier(v)
+		}
+		top = &lockFreeNode[T]{value: v, next: top, size: top.len() + 1}
+	}
+	return top
+}
+
+// len 返回以 n 为栈顶时栈中元素个数，n 为 nil 时返回0
+func (n *lockFreeNode[T]) len() int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
@@ -0,0 +1,101 @@
+package concurrent
+
+import (
+	"slices"
+	"sync"
+	"testing"
+)
+
+// TestLockFreeStack 测试无锁栈的基本操作、序列化与深拷贝
+func TestLockFreeStack(t *testing.T) {
+	s := NewLockFreeStack[int]()
+	if _, err := s.Peek(); err == nil {
+		t.Error("空栈查看栈顶应返回错误")
+	}
+	for i := range 5 {
+		s.Push(i)
+	}
+	if s.Size() != 5 || s.IsEmpty() {
+		t.Fatalf("期望大小为5，实际为%d", s.Size())
+	}
+	if v, err := s.Pop(); err != nil || v != 4 {
+		t.Errorf("Pop() = (%v, %v)，期望 (4, nil)", v, err)
+	}
+	if got := slices.Collect(s.All()); !slices.Equal(got, []int{3, 2, 1, 0}) {
+		t.Errorf("期望从栈顶到栈底为[3 2 1 0]，实际为%v", got)
+	}
+
+	t.Run("序列化", func(t *testing.T) {
+		data, err := s.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		dst := NewLockFreeStack[int]()
+		dst.Push(100)
+		if err := dst.UnmarshalBinary(data); err != nil {
+			t.Fatal(err)
+		}
+		if got := slices.Collect(dst.All()); !slices.Equal(got, []int{3, 2, 1, 0}) || dst.Size() != 4 {
+			t.Errorf("解码结果错误: %v", got)
+		}
+		if err := dst.UnmarshalJSON([]byte("[1,")); err == nil {
+			t.Error("无效的 JSON 应返回错误")
+		}
+		if dst.Size() != 4 {
+			t.Error("解码失败时栈应保持不变")
+		}
+	})
+
+	t.Run("深拷贝", func(t *testing.T) {
+		clone := s.Clone(func(v int) int { return v * 10 })
+		s.Clear()
+		if !s.IsEmpty() || s.Size() != 0 {
+			t.Error("清空后应为空")
+		}
+		if got := slices.Collect(clone.All()); !slices.Equal(got, []int{30, 20, 10, 0}) || clone.Size() != 4 {
+			t.Errorf("拷贝结果错误: %v", got)
+		}
+	})
+}
+
+// TestLockFreeStackConcurrent 测试并发压栈与弹栈时不丢失也不重复元素
+func TestLockFreeStackConcurrent(t *testing.T) {
+	s := NewLockFreeStack[int]()
+	const n = 1000
+	var wg sync.WaitGroup
+	for i := range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.Push(i)
+		}()
+	}
+	wg.Wait()
+	if s.Size() != n {
+		t.Fatalf("期望大小为%d，实际为%d", n, s.Size())
+	}
+
+	var mu sync.Mutex
+	seen := make(map[int]bool)
+	for range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			v, err := s.Pop()
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			if seen[v] {
+				t.Errorf("元素%d被重复弹出", v)
+			}
+			seen[v] = true
+		}()
+	}
+	wg.Wait()
+	if len(seen) != n || !s.IsEmpty() {
+		t.Errorf("期望弹出%d个不同元素，实际为%d", n, len(seen))
+	}
+}
@@ -0,0 +1,162 @@
+package concurrent
+
+import (
+	"iter"
+	"sync"
+
+	"godatastructure/rbtree"
+	"golang.org/x/exp/constraints"
+)
+
+// Tree 使用读写锁保护的红黑树
+// 不暴露根节点，避免调用方在锁外读写树的内部结构
+type Tree[T constraints.Ordered] struct {
+	mu sync.RWMutex
+	t  *rbtree.Tree[T]
+}
+
+// NewTree 返回 t 的并发安全包装
+// 时间复杂度: O(1)
+func NewTree[T constraints.Ordered](t *rbtree.Tree[T]) *Tree[T] {
+	return &Tree[T]{t: t}
+}
+
+// NewSyncTree 创建并发安全的空红黑树
+// 参数：
+//   - opts: 红黑树的可选配置，例如 rbtree.WithRecorder
+//
+// 时间复杂度: O(1)
+func NewSyncTree[T constraints.Ordered](opts ...rbtree.Option) *Tree[T] {
+	return NewTree(rbtree.NewTree[T](opts...))
+}
+
+// Insert 插入元素，返回是否插入了元素
+func (t *Tree[T]) Insert(value T) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.t.Insert(value)
+}
+
+// Delete 删除元素，返回元素是否存在
+func (t *Tree[T]) Delete(value T) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.t.Delete(value)
+}
+
+// Search 查找元素是否存在
+func (t *Tree[T]) Search(value T) bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.t.Search(value)
+}
+
+// Size 返回节点数量
+func (t *Tree[T]) Size() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.t.Size()
+}
+
+// IsEmpty 检查树是否为空
+func (t *Tree[T]) IsEmpty() bool {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.t.IsEmpty()
+}
+
+// Clear 清空树
+func (t *Tree[T]) Clear() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.t.Clear()
+}
+
+// Compare 按元素的自然顺序比较两个元素
+func (t *Tree[T]) Compare(a, b T) int {
+	return t.t.Compare(a, b)
+}
+
+// All 返回按升序遍历调用时刻快照的迭代器
+func (t *Tree[T]) All() iter.Seq[T] {
+	return snapshot(t.mu.RLocker(), t.t.All)
+}
+
+// Clone 返回深拷贝的并发安全包装
+func (t *Tree[T]) Clone(copier func(T) T) *Tree[T] {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return NewTree(t.t.Clone(copier))
+}
+
+// Min 返回最小的元素
+func (t *Tree[T]) Min() (T, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.t.Min()
+}
+
+// Max 返回最大的元素
+func (t *Tree[T]) Max() (T, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.t.Max()
+}
+
+// Floor 返回小于等于 value 的最大元素
+func (t *Tree[T]) Floor(value T) (T, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.t.Floor(value)
+}
+
+// Ceiling 返回大于等于 value 的最小元素
+func (t *Tree[T]) Ceiling(value T) (T, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.t.Ceiling(value)
+}
+
+// Rank 返回严格小于 value 的元素数量
+func (t *Tree[T]) Rank(value T) int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.t.Rank(value)
+}
+
+// Kth 返回第 k 小的元素（k 从0开始）
+func (t *Tree[T]) Kth(k int) (T, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.t.Kth(k)
+}
+
+// InOrder 按升序遍历调用时刻的快照，fn 在锁外调用，返回 false 时停止遍历
+func (t *Tree[T]) InOrder(fn func(T) bool) {
+	t.All()(fn)
+}
+
+// AscendRange 按升序遍历调用时刻快照中 [lo, hi) 范围内的元素，fn 在锁外调用
+func (t *Tree[T]) AscendRange(lo, hi T, fn func(T) bool) {
+	t.mu.RLock()
+	var values []T
+	t.t.AscendRange(lo, hi, func(v T) bool {
+		values = append(values, v)
+		return true
+	})
+	t.mu.RUnlock()
+	for _, v := range values {
+		if !fn(v) {
+			return
+		}
+	}
+}
+
+// Snapshot 返回调用时刻的独立副本
+// 副本不受锁保护，也不会被之后的修改影响，适合在不阻塞写操作的情况下进行多次长时间的遍历或查询
+// 时间复杂度: O(n)
+func (t *Tree[T]) Snapshot() *rbtree.Tree[T] {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.t.Clone(nil)
+}
@@ -0,0 +1,122 @@
+package concurrent
+
+import (
+	"slices"
+	"sync"
+	"testing"
+
+	"godatastructure/rbtree"
+)
+
+// TestTree 测试红黑树包装的并发读写与深拷贝
+func TestTree(t *testing.T) {
+	tree := NewTree(rbtree.NewTree[int]())
+	var wg sync.WaitGroup
+	for i := range 500 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			tree.Insert(i)
+		}()
+		go func() {
+			defer wg.Done()
+			tree.Search(i)
+		}()
+	}
+	wg.Wait()
+	if tree.Size() != 500 {
+		t.Fatalf("期望大小为500，实际为%d", tree.Size())
+	}
+
+	for i := 0; i < 500; i += 2 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if !tree.Delete(i) {
+				t.Errorf("删除%d应返回true", i)
+			}
+		}()
+	}
+	wg.Wait()
+	got := slices.Collect(tree.All())
+	if len(got) != 250 || !slices.IsSorted(got) || got[0] != 1 {
+		t.Errorf("删除偶数后遍历结果错误，长度为%d", len(got))
+	}
+
+	clone := tree.Clone(nil)
+	tree.Clear()
+	if !tree.IsEmpty() || clone.Size() != 250 || !clone.Search(499) {
+		t.Error("清空原树不应影响拷贝")
+	}
+	if tree.Compare(1, 2) >= 0 {
+		t.Error("Compare(1, 2) 应小于0")
+	}
+}
+
+// TestSyncTree 测试并发安全红黑树的查询与快照遍历
+func TestSyncTree(t *testing.T) {
+	tree := NewSyncTree[int]()
+	for i := range 100 {
+		tree.Insert(i * 2)
+	}
+
+	t.Run("查询", func(t *testing.T) {
+		if v, _ := tree.Min(); v != 0 {
+			t.Errorf("Min() = %d，期望0", v)
+		}
+		if v, _ := tree.Max(); v != 198 {
+			t.Errorf("Max() = %d，期望198", v)
+		}
+		if v, _ := tree.Floor(51); v != 50 {
+			t.Errorf("Floor(51) = %d，期望50", v)
+		}
+		if v, _ := tree.Ceiling(51); v != 52 {
+			t.Errorf("Ceiling(51) = %d，期望52", v)
+		}
+		if r := tree.Rank(51); r != 26 {
+			t.Errorf("Rank(51) = %d，期望26", r)
+		}
+		if v, ok := tree.Kth(10); !ok || v != 20 {
+			t.Errorf("Kth(10) = (%d, %v)，期望20", v, ok)
+		}
+	})
+
+	t.Run("遍历期间修改", func(t *testing.T) {
+		// 回调在锁外执行，可以修改同一棵树；遍历结果是调用时刻的快照
+		var got []int
+		tree.AscendRange(10, 20, func(v int) bool {
+			tree.Insert(v + 1)
+			got = append(got, v)
+			return true
+		})
+		if !slices.Equal(got, []int{10, 12, 14, 16, 18}) {
+			t.Errorf("AscendRange(10, 20) = %v", got)
+		}
+		n := 0
+		tree.InOrder(func(v int) bool {
+			tree.Delete(v)
+			n++
+			return n < 10
+		})
+		if n != 10 || tree.Size() != 95 {
+			t.Errorf("遍历了%d个元素，删除后大小为%d", n, tree.Size())
+		}
+	})
+
+	t.Run("快照", func(t *testing.T) {
+		snap := tree.Snapshot()
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 1000 {
+				tree.Insert(1000 + i)
+			}
+		}()
+		got := slices.Collect(snap.All())
+		wg.Wait()
+		if len(got) != 95 || snap.Size() != 95 || tree.Size() != 1095 {
+			t.Errorf("快照大小为%d，原树大小为%d", len(got), tree.Size())
+		}
+	})
+}
@@ -0,0 +1,113 @@
+package concurrent
+
+import (
+	"iter"
+	"sync"
+
+	"godatastructure/list"
+)
+
+// SkipList 使用读写锁保护的跳表
+// 跳表内部的随机数生成器不是并发安全的，因此插入必须持有写锁
+type SkipList[T any] struct {
+	mu sync.RWMutex
+	s  *list.SkipList[T]
+}
+
+// NewSkipList 返回 s 的并发安全包装
+// 时间复杂度: O(1)
+func NewSkipList[T any](s *list.SkipList[T]) *SkipList[T] {
+	return &SkipList[T]{s: s}
+}
+
+// Insert 插入元素
+func (s *SkipList[T]) Insert(value T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.s.Insert(value)
+}
+
+// Search 查找元素，找到时返回元素副本的指针，未找到时返回 nil
+func (s *SkipList[T]) Search(value T) *T {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	p := s.s.Search(value)
+	if p == nil {
+		return nil
+	}
+	v := *p
+	return &v
+}
+
+// Delete 删除元素，返回元素是否存在
+func (s *SkipList[T]) Delete(value T) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.s.Delete(value)
+}
+
+// Size 返回元素数量
+func (s *SkipList[T]) Size() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.s.Size()
+}
+
+// IsEmpty 检查跳表是否为空
+func (s *SkipList[T]) IsEmpty() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.s.IsEmpty()
+}
+
+// Clear 清空跳表
+func (s *SkipList[T]) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.s.Clear()
+}
+
+// Compare 使用跳表的比较函数比较两个元素
+func (s *SkipList[T]) Compare(a, b T) int {
+	return s.s.Compare(a, b)
+}
+
+// All 返回按升序遍历调用时刻快照的迭代器
+func (s *SkipList[T]) All() iter.Seq[T] {
+	return snapshot(s.mu.RLocker(), s.s.All)
+}
+
+// MarshalBinary 把跳表编码为二进制
+func (s *SkipList[T]) MarshalBinary() ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.s.MarshalBinary()
+}
+
+// UnmarshalBinary 从二进制解码并替换跳表中的所有元素
+func (s *SkipList[T]) UnmarshalBinary(data []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.s.UnmarshalBinary(data)
+}
+
+// MarshalJSON 把跳表编码为 JSON 数组
+func (s *SkipList[T]) MarshalJSON() ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.s.MarshalJSON()
+}
+
+// UnmarshalJSON 从 JSON 数组解码并替换跳表中的所有元素
+func (s *SkipList[T]) UnmarshalJSON(data []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.s.UnmarshalJSON(data)
+}
+
+// Clone 返回深拷贝的并发安全包装
+func (s *SkipList[T]) Clone(copier func(T) T) *SkipList[T] {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return NewSkipList(s.s.Clone(copier))
+}
@@ -0,0 +1,59 @@
+package concurrent
+
+import (
+	"slices"
+	"sync"
+	"testing"
+
+	"godatastructure/list"
+)
+
+// TestSkipList 测试跳表包装的并发读写、序列化与深拷贝
+func TestSkipList(t *testing.T) {
+	s := NewSkipList(list.NewSkipList(intCmp))
+	var wg sync.WaitGroup
+	for i := range 500 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			s.Insert(i)
+		}()
+		go func() {
+			defer wg.Done()
+			s.Search(i)
+		}()
+	}
+	wg.Wait()
+	if s.Size() != 500 {
+		t.Fatalf("期望大小为500，实际为%d", s.Size())
+	}
+
+	p := s.Search(42)
+	if p == nil || *p != 42 {
+		t.Fatal("应找到元素42")
+	}
+	*p = -1
+	if s.Search(-1) != nil {
+		t.Error("修改返回的副本不应影响跳表")
+	}
+
+	data, err := s.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	dst := NewSkipList(list.NewSkipList(intCmp))
+	if err := dst.UnmarshalBinary(data); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(slices.Collect(dst.All()), slices.Collect(s.All())) {
+		t.Error("解码结果与原跳表不一致")
+	}
+
+	clone := s.Clone(nil)
+	for v := range s.All() {
+		s.Delete(v) // 遍历中删除不应死锁
+	}
+	if !s.IsEmpty() || clone.Size() != 500 {
+		t.Error("删除原跳表的元素不应影响拷贝")
+	}
+}
@@ -0,0 +1,102 @@
+package concurrent
+
+import (
+	"iter"
+	"sync"
+
+	"godatastructure/stack"
+)
+
+// Stack 使用读写锁保护的栈
+type Stack[T any] struct {
+	mu sync.RWMutex
+	s  stack.Stack[T]
+}
+
+// NewStack 返回 s 的并发安全包装
+// 时间复杂度: O(1)
+func NewStack[T any](s stack.Stack[T]) stack.Stack[T] {
+	return &Stack[T]{s: s}
+}
+
+// Push 将元素压入栈顶
+func (s *Stack[T]) Push(value T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.s.Push(value)
+}
+
+// Pop 弹出栈顶元素
+func (s *Stack[T]) Pop() (T, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.s.Pop()
+}
+
+// Peek 查看栈顶元素但不移除
+func (s *Stack[T]) Peek() (T, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.s.Peek()
+}
+
+// IsEmpty 检查栈是否为空
+func (s *Stack[T]) IsEmpty() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.s.IsEmpty()
+}
+
+// Size 获取栈中元素个数
+func (s *Stack[T]) Size() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.s.Size()
+}
+
+// Clear 清空栈
+func (s *Stack[T]) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.s.Clear()
+}
+
+// All 返回从栈顶到栈底遍历调用时刻快照的迭代器
+func (s *Stack[T]) All() iter.Seq[T] {
+	return snapshot(s.mu.RLocker(), s.s.All)
+}
+
+// MarshalBinary 把栈编码为二进制
+func (s *Stack[T]) MarshalBinary() ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.s.MarshalBinary()
+}
+
+// UnmarshalBinary 从二进制解码并替换栈中的所有元素
+func (s *Stack[T]) UnmarshalBinary(data []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.s.UnmarshalBinary(data)
+}
+
+// MarshalJSON 把栈编码为 JSON 数组
+func (s *Stack[T]) MarshalJSON() ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.s.MarshalJSON()
+}
+
+// UnmarshalJSON 从 JSON 数组解码并替换栈中的所有元素
+func (s *Stack[T]) UnmarshalJSON(data []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.s.UnmarshalJSON(data)
+}
+
+// Clone 返回深拷贝的并发安全包装
+func (s *Stack[T]) Clone(copier func(T) T) stack.Stack[T] {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return NewStack(s.s.Clone(copier))
+}
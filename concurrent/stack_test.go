@@ -0,0 +1,84 @@
+package concurrent
+
+import (
+	"encoding/json"
+	"slices"
+	"sync"
+	"testing"
+
+	"godatastructure/stack"
+)
+
+// TestStack 测试栈包装的基本操作、序列化与深拷贝
+func TestStack(t *testing.T) {
+	s := NewStack(stack.New[int]())
+	for i := range 5 {
+		s.Push(i)
+	}
+	if v, err := s.Peek(); err != nil || v != 4 {
+		t.Errorf("Peek() = (%v, %v)，期望 (4, nil)", v, err)
+	}
+	if got := slices.Collect(s.All()); !slices.Equal(got, []int{4, 3, 2, 1, 0}) {
+		t.Errorf("期望从栈顶到栈底为[4 3 2 1 0]，实际为%v", got)
+	}
+
+	data, err := json.Marshal(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dst := NewStack(stack.New[int]())
+	if err := json.Unmarshal(data, dst); err != nil {
+		t.Fatal(err)
+	}
+	if dst.Size() != 5 {
+		t.Errorf("解码后期望大小为5，实际为%d", dst.Size())
+	}
+
+	clone := s.Clone(nil)
+	if _, ok := clone.(*Stack[int]); !ok {
+		t.Error("拷贝应仍是并发安全的包装")
+	}
+	s.Clear()
+	if !s.IsEmpty() || clone.Size() != 5 {
+		t.Error("清空原栈不应影响拷贝")
+	}
+	if _, err := s.Pop(); err == nil {
+		t.Error("空栈弹出应返回错误")
+	}
+}
+
+// TestStackConcurrent 测试并发压栈、弹栈以及遍历中修改
+func TestStackConcurrent(t *testing.T) {
+	s := NewStack(stack.New[int]())
+	var wg sync.WaitGroup
+	for i := range 1000 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.Push(i)
+		}()
+	}
+	wg.Wait()
+	if s.Size() != 1000 {
+		t.Fatalf("期望大小为1000，实际为%d", s.Size())
+	}
+
+	for range s.All() {
+		s.Push(0) // 遍历中写入不应死锁
+		break
+	}
+
+	for range 1001 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := s.Pop(); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+	if !s.IsEmpty() {
+		t.Errorf("全部弹出后应为空，实际大小为%d", s.Size())
+	}
+}
@@ -0,0 +1,37 @@
+package container
+
+import "iter"
+
+// Container 所有容器的公共接口
+type Container interface {
+	Size() int     // 返回元素数量
+	IsEmpty() bool // 判断容器是否为空
+	Clear()        // 清空容器
+}
+
+// Seq 可以按顺序遍历元素的容器，例如链表、栈、队列、有序树
+type Seq[T any] interface {
+	Container
+	All() iter.Seq[T] // 按容器定义的顺序遍历所有元素
+}
+
+// Map 键值对容器，哈希表与有序映射可以互相替换
+type Map[K, V any] interface {
+	Container
+	Get(key K) (V, bool)  // 获取键对应的值
+	Put(key K, value V)   // 插入或更新键值对
+	Delete(key K) bool    // 删除键，返回键是否存在
+	All() iter.Seq2[K, V] // 遍历所有键值对
+}
+
+// Ordered 按比较函数维护元素顺序的容器
+type Ordered[T any] interface {
+	Compare(a, b T) int // 返回容器使用的比较结果：a < b 时为负数，相等时为0，a > b 时为正数
+}
+
+// Cloner 可以深拷贝的容器，C 为 Clone 返回的容器类型
+// copier 用于复制每个元素（键值对容器中为每个值），为 nil 时直接赋值；
+// 嵌套容器（例如值为链表的哈希表）可以在 copier 中调用内层容器的 Clone 实现逐层深拷贝
+type Cloner[T, C any] interface {
+	Clone(copier func(T) T) C
+}
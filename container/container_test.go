@@ -0,0 +1,313 @@
+package container
+
+import (
+	"slices"
+	"testing"
+
+	dynamicarray "godatastructure/array"
+	"godatastructure/binarytree"
+	"godatastructure/bplustree"
+	"godatastructure/btree"
+	"godatastructure/cache"
+	"godatastructure/codec"
+	"godatastructure/concurrent"
+	"godatastructure/graph"
+	"godatastructure/hashtable"
+	"godatastructure/heap"
+	"godatastructure/list"
+	"godatastructure/persistent"
+	"godatastructure/queue"
+	"godatastructure/rbtree"
+	"godatastructure/rtree"
+	"godatastructure/set"
+	"godatastructure/stack"
+	"godatastructure/treap"
+	"godatastructure/trie"
+)
+
+func intCmp(a, b int) int {
+	return a - b
+}
+
+// 编译期检查各容器实现了公共接口
+var (
+	_ Container = bplustree.NewBPlusTree[int, int](3)
+	_ Container = bplustree.NewBPlusSet[int](3)
+	_ Container = cache.NewLRU[int, int](1, nil)
+	_ Container = cache.NewLFU[int, int](1, nil)
+	_ Container = cache.NewARC[int, int](1, nil)
+	_ Container = graph.New[int, int](true)
+	_ Container = heap.NewMinMaxHeap(intCmp)
+	_ Container = heap.NewPairingHeap(intCmp)
+	_ Container = rtree.NewRTree[int](4)
+	_ Container = trie.New[int]()
+	_ Container = trie.NewRadixTree[int]()
+
+	_ Seq[int] = list.New[int]()
+	_ Seq[int] = list.NewSkipList(intCmp)
+	_ Seq[int] = stack.New[int]()
+	_ Seq[int] = queue.NewDefaultQueue[int]()
+	_ Seq[int] = queue.NewDeque[int]()
+	_ Seq[int] = dynamicarray.New[int]()
+	_ Seq[int] = binarytree.New(intCmp)
+	_ Seq[int] = binarytree.NewSplay(intCmp)
+	_ Seq[int] = binarytree.NewAVL(intCmp)
+	_ Seq[int] = binarytree.NewTreap(intCmp)
+	_ Seq[int] = rbtree.NewTree[int]()
+	_ Seq[int] = treap.New(intCmp)
+	_ Seq[int] = treap.NewMultiset(intCmp)
+	_ Seq[int] = set.New[int]()
+	_ Seq[int] = set.NewTreeSet[int]()
+	_ Seq[int] = set.NewMultiset[int]()
+	_ Seq[int] = set.NewTreeMultiset[int]()
+	_ Seq[int] = bplustree.NewBPlusSet[int](3)
+	_ Seq[int] = concurrent.NewStack(stack.New[int]())
+	_ Seq[int] = concurrent.NewLockFreeStack[int]()
+	_ Seq[int] = concurrent.NewTree(rbtree.NewTree[int]())
+	_ Seq[int] = concurrent.NewSkipList(list.NewSkipList(intCmp))
+
+	_ Map[int, int] = hashtable.New[int, int](8)
+	_ Map[int, int] = btree.NewBTree[int, int](2)
+	_ Map[int, int] = concurrent.NewBTree(btree.NewBTree[int, int](2))
+	_ Map[int, int] = rbtree.NewMap[int, int]()
+
+	_ Ordered[int] = list.NewSkipList(intCmp)
+	_ Ordered[int] = binarytree.New(intCmp)
+	_ Ordered[int] = binarytree.NewTreap(intCmp)
+	_ Ordered[int] = rbtree.NewTree[int]()
+	_ Ordered[int] = rbtree.NewMap[int, int]()
+	_ Ordered[int] = treap.New(intCmp)
+	_ Ordered[int] = treap.NewMultiset(intCmp)
+	_ Ordered[int] = set.NewTreeSet[int]()
+	_ Ordered[int] = set.NewTreeMultiset[int]()
+	_ Ordered[int] = btree.NewBTree[int, int](2)
+	_ Ordered[int] = bplustree.NewBPlusTree[int, int](3)
+	_ Ordered[int] = bplustree.NewBPlusSet[int](3)
+
+	_ codec.Marshaler = list.New[int]()
+	_ codec.Marshaler = list.NewSkipList(intCmp)
+	_ codec.Marshaler = stack.New[int]()
+	_ codec.Marshaler = queue.NewDefaultQueue[int]()
+	_ codec.Marshaler = queue.NewDeque[int]()
+	_ codec.Marshaler = dynamicarray.New[int]()
+	_ codec.Marshaler = hashtable.New[int, int](8)
+	_ codec.Marshaler = bplustree.NewBPlusTree[int, int](3)
+	_ codec.Marshaler = rbtree.NewTree[int]()
+	_ codec.Marshaler = rbtree.NewMap[int, int]()
+
+	_ Cloner[int, list.LinkedList[int]]              = list.New[int]()
+	_ Cloner[int, *list.SkipList[int]]               = list.NewSkipList(intCmp)
+	_ Cloner[int, stack.Stack[int]]                  = stack.New[int]()
+	_ Cloner[int, queue.Queue[int]]                  = queue.NewDefaultQueue[int]()
+	_ Cloner[int, queue.Deque[int]]                  = queue.NewDeque[int]()
+	_ Cloner[int, dynamicarray.DynamicArray[int]]    = dynamicarray.New[int]()
+	_ Cloner[int, *hashtable.HashTable[int, int]]    = hashtable.New[int, int](8)
+	_ Cloner[int, binarytree.BinaryTree[int]]        = binarytree.New(intCmp)
+	_ Cloner[int, binarytree.BinaryTree[int]]        = binarytree.NewTreap(intCmp)
+	_ Cloner[int, *rbtree.Tree[int]]                 = rbtree.NewTree[int]()
+	_ Cloner[int, *rbtree.Map[int, int]]             = rbtree.NewMap[int, int]()
+	_ Cloner[int, *btree.BTree[int, int]]            = btree.NewBTree[int, int](2)
+	_ Cloner[int, *bplustree.BPlusTree[int, int]]    = bplustree.NewBPlusTree[int, int](3)
+	_ Cloner[int, *bplustree.BPlusSet[int]]          = bplustree.NewBPlusSet[int](3)
+	_ Cloner[int, *treap.Treap[int]]                 = treap.New(intCmp)
+	_ Cloner[int, *treap.Multiset[int]]              = treap.NewMultiset(intCmp)
+	_ Cloner[int, *set.Set[int]]                     = set.New[int]()
+	_ Cloner[int, *set.TreeSet[int]]                 = set.NewTreeSet[int]()
+	_ Cloner[int, *set.Multiset[int]]                = set.NewMultiset[int]()
+	_ Cloner[int, *set.TreeMultiset[int]]            = set.NewTreeMultiset[int]()
+	_ Cloner[int, *heap.MinMaxHeap[int]]             = heap.NewMinMaxHeap(intCmp)
+	_ Cloner[int, *heap.PairingHeap[int]]            = heap.NewPairingHeap(intCmp)
+	_ Cloner[int, *trie.Trie[int]]                   = trie.New[int]()
+	_ Cloner[int, *trie.RadixTree[int]]              = trie.NewRadixTree[int]()
+	_ Cloner[int, *rtree.RTree[int]]                 = rtree.NewRTree[int](4)
+	_ Cloner[int, *graph.Graph[int, int]]            = graph.New[int, int](true)
+	_ Cloner[int, *persistent.PersistentVector[int]] = persistent.NewVector[int]()
+	_ Cloner[int, *cache.LRUCache[int, int]]         = cache.NewLRU[int, int](1, nil)
+	_ Cloner[int, *cache.LFUCache[int, int]]         = cache.NewLFU[int, int](1, nil)
+	_ Cloner[int, *cache.ARCCache[int, int]]         = cache.NewARC[int, int](1, nil)
+)
+
+// TestMap 测试哈希表、B 树与红黑树通过 Map 接口互相替换
+func TestMap(t *testing.T) {
+	maps := map[string]Map[int, string]{
+		"哈希表": hashtable.New[int, string](4),
+		"B树":  btree.NewBTree[int, string](2),
+		"红黑树": rbtree.NewMap[int, string](),
+	}
+	for name, m := range maps {
+		t.Run(name, func(t *testing.T) {
+			if !m.IsEmpty() {
+				t.Fatal("新建的映射应为空")
+			}
+			for i := range 20 {
+				m.Put(i, string(rune('a'+i)))
+			}
+			m.Put(3, "三")
+			if m.Size() != 20 {
+				t.Errorf("期望大小为20，实际为%d", m.Size())
+			}
+			if v, ok := m.Get(3); !ok || v != "三" {
+				t.Errorf("Get(3) = (%v, %v)，期望 (三, true)", v, ok)
+			}
+			if !m.Delete(3) || m.Delete(3) {
+				t.Error("第一次删除应返回true，重复删除应返回false")
+			}
+			if _, ok := m.Get(3); ok {
+				t.Error("删除后不应找到键3")
+			}
+
+			var keys []int
+			for k := range m.All() {
+				keys = append(keys, k)
+			}
+			slices.Sort(keys)
+			if len(keys) != 19 || keys[0] != 0 || keys[18] != 19 {
+				t.Errorf("遍历得到的键不正确: %v", keys)
+			}
+
+			m.Clear()
+			if !m.IsEmpty() || m.Size() != 0 {
+				t.Errorf("清空后应为空，实际大小为%d", m.Size())
+			}
+			m.Put(1, "一")
+			if v, ok := m.Get(1); !ok || v != "一" {
+				t.Error("清空后应可以继续使用")
+			}
+		})
+	}
+}
+
+// TestSeq 测试各顺序容器的 Size、IsEmpty、Clear 与 All 行为一致
+func TestSeq(t *testing.T) {
+	fill := map[string]func() Seq[int]{
+		"链表": func() Seq[int] {
+			l := list.New[int]()
+			for i := range 5 {
+				l.Append(i)
+			}
+			return l
+		},
+		"跳表": func() Seq[int] {
+			s := list.NewSkipList(intCmp)
+			for i := range 5 {
+				s.Insert(i)
+			}
+			return s
+		},
+		"栈": func() Seq[int] {
+			s := stack.New[int]()
+			for i := range 5 {
+				s.Push(i)
+			}
+			return s
+		},
+		"双端队列": func() Seq[int] {
+			d := queue.NewDeque[int]()
+			for i := range 5 {
+				d.PushBack(i)
+			}
+			return d
+		},
+		"动态数组": func() Seq[int] {
+			a := dynamicarray.New[int]()
+			for i := range 5 {
+				a.Append(i)
+			}
+			return a
+		},
+		"二叉搜索树": func() Seq[int] {
+			tree := binarytree.New(intCmp)
+			for i := range 5 {
+				tree.Insert(i)
+			}
+			return tree
+		},
+		"伸展树": func() Seq[int] {
+			tree := binarytree.NewSplay(intCmp)
+			for i := range 5 {
+				tree.Insert(i)
+			}
+			return tree
+		},
+		"AVL树": func() Seq[int] {
+			tree := binarytree.NewAVL(intCmp)
+			for i := range 5 {
+				tree.Insert(i)
+			}
+			return tree
+		},
+		"红黑树": func() Seq[int] {
+			tree := rbtree.NewTree[int]()
+			for i := range 5 {
+				tree.Insert(i)
+			}
+			return tree
+		},
+		"树堆": func() Seq[int] {
+			tree := treap.New(intCmp)
+			for i := range 5 {
+				tree.Insert(i)
+			}
+			return tree
+		},
+		"有序集合": func() Seq[int] {
+			return set.NewTreeSet(0, 1, 2, 3, 4)
+		},
+	}
+	for name, newSeq := range fill {
+		t.Run(name, func(t *testing.T) {
+			s := newSeq()
+			if s.Size() != 5 || s.IsEmpty() {
+				t.Fatalf("期望大小为5，实际为%d", s.Size())
+			}
+			count := 0
+			for range s.All() {
+				count++
+			}
+			if count != 5 {
+				t.Errorf("期望遍历5个元素，实际为%d", count)
+			}
+			s.Clear()
+			if !s.IsEmpty() || s.Size() != 0 {
+				t.Errorf("清空后应为空，实际大小为%d", s.Size())
+			}
+			for range s.All() {
+				t.Fatal("清空后不应遍历到元素")
+			}
+		})
+	}
+}
+
+// TestClonerNested 测试通过 copier 逐层深拷贝嵌套容器
+func TestClonerNested(t *testing.T) {
+	groups := hashtable.New[string, list.LinkedList[int]](4)
+	for _, name := range []string{"奇数", "偶数"} {
+		groups.Put(name, list.New[int]())
+	}
+	for i := range 10 {
+		name := "偶数"
+		if i%2 == 1 {
+			name = "奇数"
+		}
+		l, _ := groups.Get(name)
+		l.Append(i)
+	}
+
+	clone := groups.Clone(func(l list.LinkedList[int]) list.LinkedList[int] {
+		return l.Clone(nil)
+	})
+	odd, _ := groups.Get("奇数")
+	odd.Clear()
+
+	cloneOdd, _ := clone.Get("奇数")
+	if got := cloneOdd.ToSlice(); !slices.Equal(got, []int{1, 3, 5, 7, 9}) {
+		t.Errorf("内层链表应被深拷贝，实际为%v", got)
+	}
+
+	shallow := groups.Clone(nil)
+	shallowEven, _ := shallow.Get("偶数")
+	even, _ := groups.Get("偶数")
+	if shallowEven != even {
+		t.Error("copier 为 nil 时内层容器应被共享")
+	}
+}
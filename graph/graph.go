@@ -0,0 +1,191 @@
+package graph
+
+import (
+	"errors"
+
+	"golang.org/x/exp/constraints"
+)
+
+// 定义图操作可能遇到的错误
+var (
+	// ErrVertexNotFound 顶点不存在时返回此错误
+	ErrVertexNotFound = errors.New("顶点不存在")
+	// ErrNegativeWeight Dijkstra 算法遇到负权边时返回此错误
+	ErrNegativeWeight = errors.New("存在负权边")
+	// ErrNegativeCycle 存在从源点可达的负权回路时返回此错误
+	ErrNegativeCycle = errors.New("存在负权回路")
+)
+
+// Weight 边权类型约束
+type Weight interface {
+	constraints.Integer | constraints.Float
+}
+
+// Edge 带权边
+type Edge[V comparable, W Weight] struct {
+	From   V // 起点
+	To     V // 终点
+	Weight W // 边权
+}
+
+// Graph 使用邻接表存储的带权图
+// V 为顶点类型，W 为边权类型
+type Graph[V comparable, W Weight] struct {
+	directed  bool               // 是否为有向图
+	adjacency map[V][]Edge[V, W] // 邻接表
+	order     []V                // 顶点的插入顺序，保证遍历结果确定
+	edges     int                // 边的数量
+}
+
+// New 创建新的带权图
+// 参数：
+//   - directed: true 表示有向图，false 表示无向图
+//
+// 时间复杂度: O(1)
+func New[V comparable, W Weight](directed bool) *Graph[V, W] {
+	return &Graph[V, W]{
+		directed:  directed,
+		adjacency: make(map[V][]Edge[V, W]),
+	}
+}
+
+// AddVertex 添加顶点，顶点已存在时不做任何操作
+// 时间复杂度: O(1)
+func (g *Graph[V, W]) AddVertex(v V) {
+	if _, ok := g.adjacency[v]; ok {
+		return
+	}
+	g.adjacency[v] = nil
+	g.order = append(g.order, v)
+}
+
+// AddEdge 添加带权边，端点不存在时自动添加
+// 无向图中会同时添加两个方向的边
+// 时间复杂度: O(1)
+func (g *Graph[V, W]) AddEdge(from, to V, weight W) {
+	g.AddVertex(from)
+	g.AddVertex(to)
+	g.adjacency[from] = append(g.adjacency[from], Edge[V, W]{From: from, To: to, Weight: weight})
+	if !g.directed && from != to {
+		g.adjacency[to] = append(g.adjacency[to], Edge[V, W]{From: to, To: from, Weight: weight})
+	}
+	g.edges++
+}
+
+// HasVertex 检查顶点是否存在
+// 时间复杂度: O(1)
+func (g *Graph[V, W]) HasVertex(v V) bool {
+	_, ok := g.adjacency[v]
+	return ok
+}
+
+// Neighbors 返回从顶点 v 出发的所有边
+// 时间复杂度: O(d)，d 为顶点的出度
+func (g *Graph[V, W]) Neighbors(v V) []Edge[V, W] {
+	edges := g.adjacency[v]
+	result := make([]Edge[V, W], len(edges))
+	copy(result, edges)
+	return result
+}
+
+// Vertices 按插入顺序返回所有顶点
+// 时间复杂度: O(n)
+func (g *Graph[V, W]) Vertices() []V {
+	result := make([]V, len(g.order))
+	copy(result, g.order)
+	return result
+}
+
+// Edges 返回所有边，无向图的每条边只返回一次
+// 时间复杂度: O(n + m)
+func (g *Graph[V, W]) Edges() []Edge[V, W] {
+	result := make([]Edge[V, W], 0, g.edges)
+	for _, v := range g.order {
+		result = append(result, g.adjacency[v]...)
+	}
+	if g.directed {
+		return result
+	}
+
+	// 无向图每条边存储了两次，按出现次数去掉反向的副本
+	seen := make(map[Edge[V, W]]int)
+	unique := result[:0]
+	for _, e := range result {
+		if e.From == e.To {
+			// 自环只存储了一次
+			unique = append(unique, e)
+			continue
+		}
+		reverse := Edge[V, W]{From: e.To, To: e.From, Weight: e.Weight}
+		if seen[reverse] > 0 {
+			seen[reverse]--
+			continue
+		}
+		seen[e]++
+		unique = append(unique, e)
+	}
+	return unique
+}
+
+// IsDirected 返回是否为有向图
+func (g *Graph[V, W]) IsDirected() bool {
+	return g.directed
+}
+
+// Order 返回顶点数量
+// 时间复杂度: O(1)
+func (g *Graph[V, W]) Order() int {
+	return len(g.order)
+}
+
+// Size 返回边的数量
+// 时间复杂度: O(1)
+func (g *Graph[V, W]) Size() int {
+	return g.edges
+}
+
+// Clone 返回图的深拷贝，保留顶点的插入顺序和边的顺序
+// copier 用于复制每个顶点，为 nil 时直接赋值；不同的顶点复制后必须仍然不同
+// 时间复杂度: O(V + E)
+func (g *Graph[V, W]) Clone(copier func(V) V) *Graph[V, W] {
+	clone := &Graph[V, W]{
+		directed:  g.directed,
+		adjacency: make(map[V][]Edge[V, W], len(g.adjacency)),
+		order:     make([]V, len(g.order)),
+		edges:     g.edges,
+	}
+	vertices := make(map[V]V, len(g.order))
+	for i, v := range g.order {
+		c := v
+		if copier != nil {
+			c = copier(v)
+		}
+		vertices[v] = c
+		clone.order[i] = c
+	}
+	for v, edges := range g.adjacency {
+		var cloned []Edge[V, W]
+		if edges != nil {
+			cloned = make([]Edge[V, W], len(edges))
+			for i, e := range edges {
+				cloned[i] = Edge[V, W]{From: vertices[e.From], To: vertices[e.To], Weight: e.Weight}
+			}
+		}
+		clone.adjacency[vertices[v]] = cloned
+	}
+	return clone
+}
+
+// IsEmpty 检查图中是否没有顶点
+// 时间复杂度: O(1)
+func (g *Graph[V, W]) IsEmpty() bool {
+	return len(g.order) == 0
+}
+
+// Clear 删除所有顶点和边，保留图的方向性
+// 时间复杂度: O(1)
+func (g *Graph[V, W]) Clear() {
+	g.adjacency = make(map[V][]Edge[V, W])
+	g.order = nil
+	g.edges = 0
+}
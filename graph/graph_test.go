@@ -0,0 +1,87 @@
+package graph
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+// TestGraphDirected 测试有向图的基本操作
+func TestGraphDirected(t *testing.T) {
+	g := New[string, int](true)
+	g.AddEdge("a", "b", 1)
+	g.AddEdge("a", "c", 2)
+	g.AddEdge("b", "c", 3)
+	g.AddVertex("d")
+	g.AddVertex("a")
+
+	if g.Order() != 4 {
+		t.Errorf("期望4个顶点，实际为%d", g.Order())
+	}
+	if g.Size() != 3 {
+		t.Errorf("期望3条边，实际为%d", g.Size())
+	}
+	if !g.HasVertex("d") || g.HasVertex("x") {
+		t.Error("HasVertex结果不正确")
+	}
+	if n := g.Neighbors("a"); len(n) != 2 || n[0].To != "b" || n[1].To != "c" {
+		t.Errorf("a的邻接边不正确: %v", n)
+	}
+	if n := g.Neighbors("c"); len(n) != 0 {
+		t.Errorf("有向图中c不应有出边: %v", n)
+	}
+
+	vertices := g.Vertices()
+	want := []string{"a", "b", "c", "d"}
+	for i := range want {
+		if vertices[i] != want[i] {
+			t.Errorf("顶点顺序错误，期望 %v，实际为 %v", want, vertices)
+			break
+		}
+	}
+	if len(g.Edges()) != 3 {
+		t.Errorf("期望3条边，实际为 %v", g.Edges())
+	}
+}
+
+// TestGraphUndirected 测试无向图的基本操作
+func TestGraphUndirected(t *testing.T) {
+	g := New[int, float64](false)
+	g.AddEdge(1, 2, 0.5)
+	g.AddEdge(2, 3, 1.5)
+	g.AddEdge(3, 3, 1)
+
+	if g.IsDirected() {
+		t.Error("应为无向图")
+	}
+	if n := g.Neighbors(2); len(n) != 2 {
+		t.Errorf("无向图中2应有两条边: %v", n)
+	}
+	if edges := g.Edges(); len(edges) != 3 {
+		t.Errorf("无向图的每条边应只返回一次: %v", edges)
+	}
+}
+
+// TestGraphClone 测试图的深拷贝
+func TestGraphClone(t *testing.T) {
+	g := New[string, int](false)
+	g.AddEdge("a", "b", 1)
+	g.AddEdge("b", "c", 2)
+	g.AddVertex("d")
+
+	clone := g.Clone(strings.ToUpper)
+	g.AddEdge("c", "d", 3)
+
+	if clone.Order() != 4 || clone.Size() != 2 {
+		t.Errorf("期望4个顶点2条边，实际为%d和%d", clone.Order(), clone.Size())
+	}
+	if !slices.Equal(clone.Vertices(), []string{"A", "B", "C", "D"}) {
+		t.Errorf("顶点顺序不正确: %v", clone.Vertices())
+	}
+	if n := clone.Neighbors("B"); len(n) != 2 || n[0].To != "A" || n[1].To != "C" {
+		t.Errorf("B 的邻边不正确: %v", n)
+	}
+	if len(clone.Neighbors("D")) != 0 {
+		t.Error("修改原图不应影响拷贝")
+	}
+}
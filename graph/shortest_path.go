@@ -0,0 +1,135 @@
+package graph
+
+import (
+	"cmp"
+
+	"godatastructure/heap"
+)
+
+// distItem 优先队列中的元素：顶点及其当前最短距离
+type distItem[V comparable, W Weight] struct {
+	vertex V
+	dist   W
+}
+
+// Dijkstra 使用 Dijkstra 算法计算从 source 出发的单源最短路径
+// 优先队列使用配对堆，通过 DecreaseKey 更新距离，避免重复入队
+// 返回值：
+//   - map[V]W: 源点到各可达顶点的最短距离，不可达的顶点不出现在结果中
+//   - map[V]V: 各可达顶点在最短路径上的前驱顶点
+//   - error: 源点不存在时返回 ErrVertexNotFound，存在负权边时返回 ErrNegativeWeight
+//
+// 时间复杂度: O(m + n log n)
+func (g *Graph[V, W]) Dijkstra(source V) (map[V]W, map[V]V, error) {
+	if !g.HasVertex(source) {
+		return nil, nil, ErrVertexNotFound
+	}
+	for _, edges := range g.adjacency {
+		for _, e := range edges {
+			if e.Weight < 0 {
+				return nil, nil, ErrNegativeWeight
+			}
+		}
+	}
+
+	dist := map[V]W{source: 0}
+	prev := make(map[V]V)
+	visited := make(map[V]bool)
+
+	pq := heap.NewPairingHeap(func(a, b distItem[V, W]) int {
+		return cmp.Compare(a.dist, b.dist)
+	})
+	handles := map[V]*heap.Element[distItem[V, W]]{
+		source: pq.Push(distItem[V, W]{vertex: source, dist: 0}),
+	}
+
+	for !pq.IsEmpty() {
+		current, _ := pq.Pop()
+		visited[current.vertex] = true
+
+		for _, e := range g.adjacency[current.vertex] {
+			if visited[e.To] {
+				continue
+			}
+			nd := current.dist + e.Weight
+			if d, ok := dist[e.To]; ok && nd >= d {
+				continue
+			}
+			dist[e.To] = nd
+			prev[e.To] = current.vertex
+			if h, ok := handles[e.To]; ok {
+				_ = pq.DecreaseKey(h, distItem[V, W]{vertex: e.To, dist: nd})
+			} else {
+				handles[e.To] = pq.Push(distItem[V, W]{vertex: e.To, dist: nd})
+			}
+		}
+	}
+	return dist, prev, nil
+}
+
+// BellmanFord 使用 Bellman-Ford 算法计算从 source 出发的单源最短路径，支持负权边
+// 返回值：
+//   - map[V]W: 源点到各可达顶点的最短距离，不可达的顶点不出现在结果中
+//   - map[V]V: 各可达顶点在最短路径上的前驱顶点
+//   - error: 源点不存在时返回 ErrVertexNotFound，存在从源点可达的负权回路时返回 ErrNegativeCycle
+//
+// 时间复杂度: O(n * m)
+func (g *Graph[V, W]) BellmanFord(source V) (map[V]W, map[V]V, error) {
+	if !g.HasVertex(source) {
+		return nil, nil, ErrVertexNotFound
+	}
+
+	dist := map[V]W{source: 0}
+	prev := make(map[V]V)
+
+	// relax 对所有边做一轮松弛，返回是否有距离被更新
+	relax := func() bool {
+		updated := false
+		for _, v := range g.order {
+			d, ok := dist[v]
+			if !ok {
+				continue
+			}
+			for _, e := range g.adjacency[v] {
+				nd := d + e.Weight
+				if old, ok := dist[e.To]; !ok || nd < old {
+					dist[e.To] = nd
+					prev[e.To] = v
+					updated = true
+				}
+			}
+		}
+		return updated
+	}
+
+	for i := 1; i < len(g.order); i++ {
+		if !relax() {
+			return dist, prev, nil
+		}
+	}
+	// 经过 n-1 轮后仍能松弛，说明存在负权回路
+	if relax() {
+		return nil, nil, ErrNegativeCycle
+	}
+	return dist, prev, nil
+}
+
+// PathTo 根据前驱表还原从 source 到 target 的路径
+// target 不可达时返回 nil
+// 时间复杂度: O(k)，k 为路径长度
+func PathTo[V comparable](prev map[V]V, source, target V) []V {
+	path := []V{target}
+	for current := target; current != source; {
+		p, ok := prev[current]
+		if !ok {
+			return nil
+		}
+		path = append(path, p)
+		current = p
+	}
+
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
@@ -0,0 +1,104 @@
+package graph
+
+import (
+	"errors"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// buildSampleGraph 构建测试用的有向带权图
+func buildSampleGraph() *Graph[string, int] {
+	g := New[string, int](true)
+	g.AddEdge("s", "a", 10)
+	g.AddEdge("s", "b", 5)
+	g.AddEdge("b", "a", 3)
+	g.AddEdge("a", "c", 1)
+	g.AddEdge("b", "c", 9)
+	g.AddEdge("c", "d", 4)
+	g.AddEdge("b", "d", 2)
+	g.AddVertex("x") // 不可达的顶点
+	return g
+}
+
+// TestDijkstra 测试 Dijkstra 算法
+func TestDijkstra(t *testing.T) {
+	g := buildSampleGraph()
+	dist, prev, err := g.Dijkstra("s")
+	if err != nil {
+		t.Fatalf("Dijkstra失败: %v", err)
+	}
+
+	want := map[string]int{"s": 0, "a": 8, "b": 5, "c": 9, "d": 7}
+	if !reflect.DeepEqual(dist, want) {
+		t.Errorf("距离错误，期望 %v，实际为 %v", want, dist)
+	}
+	if path := PathTo(prev, "s", "c"); !reflect.DeepEqual(path, []string{"s", "b", "a", "c"}) {
+		t.Errorf("s到c的路径错误: %v", path)
+	}
+	if path := PathTo(prev, "s", "x"); path != nil {
+		t.Errorf("不可达顶点的路径应为nil: %v", path)
+	}
+	if path := PathTo(prev, "s", "s"); !reflect.DeepEqual(path, []string{"s"}) {
+		t.Errorf("源点到自身的路径错误: %v", path)
+	}
+
+	t.Run("错误情况", func(t *testing.T) {
+		if _, _, err := g.Dijkstra("none"); !errors.Is(err, ErrVertexNotFound) {
+			t.Errorf("期望ErrVertexNotFound，实际为%v", err)
+		}
+		g.AddEdge("d", "s", -1)
+		if _, _, err := g.Dijkstra("s"); !errors.Is(err, ErrNegativeWeight) {
+			t.Errorf("期望ErrNegativeWeight，实际为%v", err)
+		}
+	})
+}
+
+// TestBellmanFord 测试 Bellman-Ford 算法
+func TestBellmanFord(t *testing.T) {
+	g := New[string, int](true)
+	g.AddEdge("s", "a", 4)
+	g.AddEdge("s", "b", 5)
+	g.AddEdge("b", "a", -3)
+	g.AddEdge("a", "c", 2)
+
+	dist, prev, err := g.BellmanFord("s")
+	if err != nil {
+		t.Fatalf("BellmanFord失败: %v", err)
+	}
+	want := map[string]int{"s": 0, "a": 2, "b": 5, "c": 4}
+	if !reflect.DeepEqual(dist, want) {
+		t.Errorf("距离错误，期望 %v，实际为 %v", want, dist)
+	}
+	if path := PathTo(prev, "s", "c"); !reflect.DeepEqual(path, []string{"s", "b", "a", "c"}) {
+		t.Errorf("s到c的路径错误: %v", path)
+	}
+
+	t.Run("负权回路", func(t *testing.T) {
+		g.AddEdge("c", "b", -5)
+		if _, _, err := g.BellmanFord("s"); !errors.Is(err, ErrNegativeCycle) {
+			t.Errorf("期望ErrNegativeCycle，实际为%v", err)
+		}
+	})
+}
+
+// TestShortestPathRandom 随机非负权图上两种算法结果一致
+func TestShortestPathRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	g := New[int, int](true)
+	for i := 0; i < 50; i++ {
+		g.AddVertex(i)
+	}
+	for i := 0; i < 300; i++ {
+		g.AddEdge(r.Intn(50), r.Intn(50), r.Intn(100))
+	}
+
+	d1, _, err1 := g.Dijkstra(0)
+	d2, _, err2 := g.BellmanFord(0)
+	if err1 != nil || err2 != nil {
+		t.Fatalf("计算失败: %v, %v", err1, err2)
+	}
+	if !reflect.DeepEqual(d1, d2) {
+		t.Errorf("两种算法结果不一致:\n%v\n%v", d1, d2)
+	}
+}
@@ -0,0 +1,174 @@
+package hashtable
+
+import (
+	"fmt"
+	"hash/maphash"
+	"math"
+	"math/rand/v2"
+	"reflect"
+	"sync"
+	"unsafe"
+)
+
+// hashFunc 计算 p 指向的值的哈希值，h 为之前的哈希状态，组合类型按字段依次传递
+type hashFunc func(p unsafe.Pointer, h uint64) uint64
+
+var (
+	stringSeed = maphash.MakeSeed() // 组合类型中字符串字段使用的种子，结果再与哈希状态混合
+	hashFuncs  sync.Map             // reflect.Type 到 hashFunc 的缓存，接口类型的键按动态类型查找
+)
+
+// newHasher 按键的底层类型选择哈希函数，相等（==）的键总是得到相同的哈希值
+// 每次调用使用新的随机种子，不同哈希表中键的分布互不相关；
+//   - 整数、布尔、指针和通道：按位读取后用 splitmix64 的混合函数打散，不分配内存
+//   - 浮点数：+0 与 -0 相等，先统一为 +0 再按位混合
+//   - 字符串：使用 hash/maphash
+//   - 结构体和数组：按上述规则逐个字段、逐个元素计算，跳过空白字段
+//   - 接口：按动态类型计算，指针按地址而不是指向的内容计算；动态类型不可比较时 panic
+func newHasher[K comparable]() func(K) uint64 {
+	seed := maphash.MakeSeed()
+	salt := rand.Uint64()
+	typ := reflect.TypeFor[K]()
+	switch typ.Kind() {
+	case reflect.String:
+		return func(key K) uint64 {
+			return maphash.String(seed, *(*string)(unsafe.Pointer(&key)))
+		}
+	case reflect.Float32:
+		return func(key K) uint64 {
+			return mix(float32Bits(*(*float32)(unsafe.Pointer(&key))), salt)
+		}
+	case reflect.Float64:
+		return func(key K) uint64 {
+			return mix(float64Bits(*(*float64)(unsafe.Pointer(&key))), salt)
+		}
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Pointer, reflect.UnsafePointer, reflect.Chan:
+		switch typ.Size() {
+		case 1:
+			return func(key K) uint64 { return mix(uint64(*(*uint8)(unsafe.Pointer(&key))), salt) }
+		case 2:
+			return func(key K) uint64 { return mix(uint64(*(*uint16)(unsafe.Pointer(&key))), salt) }
+		case 4:
+			return func(key K) uint64 { return mix(uint64(*(*uint32)(unsafe.Pointer(&key))), salt) }
+		case 8:
+			return func(key K) uint64 { return mix(*(*uint64)(unsafe.Pointer(&key)), salt) }
+		}
+	}
+	fn := hashFuncFor(typ)
+	return func(key K) uint64 {
+		return fn(unsafe.Pointer(&key), salt)
+	}
+}
+
+// hashFuncFor 返回类型 t 的哈希函数，结果按类型缓存
+func hashFuncFor(t reflect.Type) hashFunc {
+	if fn, ok := hashFuncs.Load(t); ok {
+		return fn.(hashFunc)
+	}
+	fn, _ := hashFuncs.LoadOrStore(t, buildHashFunc(t))
+	return fn.(hashFunc)
+}
+
+// buildHashFunc 按类型 t 的种类构造哈希函数，组合类型递归构造字段和元素的哈希函数
+func buildHashFunc(t reflect.Type) hashFunc {
+	switch t.Kind() {
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Pointer, reflect.UnsafePointer, reflect.Chan:
+		switch t.Size() {
+		case 1:
+			return func(p unsafe.Pointer, h uint64) uint64 { return mix(uint64(*(*uint8)(p)), h) }
+		case 2:
+			return func(p unsafe.Pointer, h uint64) uint64 { return mix(uint64(*(*uint16)(p)), h) }
+		case 4:
+			return func(p unsafe.Pointer, h uint64) uint64 { return mix(uint64(*(*uint32)(p)), h) }
+		default:
+			return func(p unsafe.Pointer, h uint64) uint64 { return mix(*(*uint64)(p), h) }
+		}
+	case reflect.Float32:
+		return func(p unsafe.Pointer, h uint64) uint64 { return mix(float32Bits(*(*float32)(p)), h) }
+	case reflect.Float64:
+		return func(p unsafe.Pointer, h uint64) uint64 { return mix(float64Bits(*(*float64)(p)), h) }
+	case reflect.Complex64:
+		return func(p unsafe.Pointer, h uint64) uint64 {
+			c := *(*complex64)(p)
+			return mix(float32Bits(imag(c)), mix(float32Bits(real(c)), h))
+		}
+	case reflect.Complex128:
+		return func(p unsafe.Pointer, h uint64) uint64 {
+			c := *(*complex128)(p)
+			return mix(float64Bits(imag(c)), mix(float64Bits(real(c)), h))
+		}
+	case reflect.String:
+		return func(p unsafe.Pointer, h uint64) uint64 { return mix(maphash.String(stringSeed, *(*string)(p)), h) }
+	case reflect.Array:
+		elem, size, n := buildHashFunc(t.Elem()), t.Elem().Size(), t.Len()
+		return func(p unsafe.Pointer, h uint64) uint64 {
+			for i := range n {
+				h = elem(unsafe.Add(p, uintptr(i)*size), h)
+			}
+			return h
+		}
+	case reflect.Struct:
+		type field struct {
+			offset uintptr
+			fn     hashFunc
+		}
+		var fields []field
+		for i := range t.NumField() {
+			// 比较结构体时忽略空白字段
+			if f := t.Field(i); f.Name != "_" {
+				fields = append(fields, field{f.Offset, buildHashFunc(f.Type)})
+			}
+		}
+		return func(p unsafe.Pointer, h uint64) uint64 {
+			for _, f := range fields {
+				h = f.fn(unsafe.Add(p, f.offset), h)
+			}
+			return h
+		}
+	case reflect.Interface:
+		return func(p unsafe.Pointer, h uint64) uint64 {
+			v := reflect.NewAt(t, p).Elem()
+			if v.IsNil() {
+				return mix(0, h)
+			}
+			// 动态值不可寻址，复制一份后按动态类型计算
+			d := reflect.New(v.Elem().Type())
+			d.Elem().Set(v.Elem())
+			return hashFuncFor(d.Elem().Type())(d.UnsafePointer(), h)
+		}
+	}
+	return func(unsafe.Pointer, uint64) uint64 {
+		panic(fmt.Sprintf("哈希表的键类型 %v 不可比较", t))
+	}
+}
+
+// float32Bits 返回浮点数的位表示，-0 与 +0 相等，统一为 +0
+func float32Bits(f float32) uint64 {
+	if f == 0 {
+		return 0
+	}
+	return uint64(math.Float32bits(f))
+}
+
+// float64Bits 返回浮点数的位表示，-0 与 +0 相等，统一为 +0
+func float64Bits(f float64) uint64 {
+	if f == 0 {
+		return 0
+	}
+	return math.Float64bits(f)
+}
+
+// mix 使用 splitmix64 的混合函数打散 x，输入的每一位都会影响输出的所有位
+func mix(x, salt uint64) uint64 {
+	x ^= salt
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
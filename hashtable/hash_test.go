@@ -0,0 +1,152 @@
+package hashtable
+
+import (
+	"math"
+	"testing"
+)
+
+// TestHasher 测试各类键的哈希函数对相等的键给出相同的哈希值，并且能区分不同的键
+func TestHasher(t *testing.T) {
+	type id int32
+	type name string
+	type point struct{ X, Y int }
+
+	t.Run("整数", func(t *testing.T) {
+		h := newHasher[id]()
+		if h(42) != h(42) || h(42) == h(43) {
+			t.Error("具名整数类型的哈希值不正确")
+		}
+		b := newHasher[bool]()
+		if b(true) == b(false) {
+			t.Error("true 与 false 的哈希值不应相同")
+		}
+	})
+
+	t.Run("字符串", func(t *testing.T) {
+		h := newHasher[name]()
+		key := name("al") + name("ice") // 不同的底层数组
+		if h(key) != h("alice") || h("alice") == h("bob") {
+			t.Error("具名字符串类型的哈希值不正确")
+		}
+	})
+
+	t.Run("浮点数", func(t *testing.T) {
+		h := newHasher[float64]()
+		if h(0) != h(math.Copysign(0, -1)) {
+			t.Error("+0 与 -0 相等，哈希值应相同")
+		}
+		if h(1.5) == h(2.5) {
+			t.Error("不同浮点数的哈希值不应相同")
+		}
+		h32 := newHasher[float32]()
+		if h32(0) != h32(float32(math.Copysign(0, -1))) {
+			t.Error("float32 的 +0 与 -0 哈希值应相同")
+		}
+	})
+
+	t.Run("指针", func(t *testing.T) {
+		a, b := new(int), new(int)
+		h := newHasher[*int]()
+		if h(a) != h(a) || h(a) == h(b) {
+			t.Error("指针应按地址计算哈希值")
+		}
+	})
+
+	t.Run("结构体", func(t *testing.T) {
+		h := newHasher[point]()
+		if h(point{1, 2}) != h(point{1, 2}) || h(point{1, 2}) == h(point{2, 1}) {
+			t.Error("结构体的哈希值不正确")
+		}
+		type nested struct {
+			Name  string
+			Pos   [2]float64
+			Inner any
+		}
+		n := newHasher[nested]()
+		a := nested{"al" + string([]byte("ice")), [2]float64{math.Copysign(0, -1), 1}, point{1, 2}}
+		b := nested{"alice", [2]float64{0, 1}, point{1, 2}}
+		if a != b || n(a) != n(b) {
+			t.Error("相等的嵌套结构体哈希值应相同")
+		}
+	})
+
+	t.Run("接口", func(t *testing.T) {
+		h := newHasher[any]()
+		if h(0.0) != h(math.Copysign(0, -1)) || h(nil) != h(nil) || h("a") != h("a") {
+			t.Error("相等的接口值哈希值应相同")
+		}
+		p := new(int)
+		before := h(p)
+		*p = 42
+		if h(p) != before {
+			t.Error("接口中的指针应按地址计算哈希值")
+		}
+	})
+
+	t.Run("不可比较的动态类型", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("动态类型不可比较时应 panic")
+			}
+		}()
+		newHasher[any]()([]int{1})
+	})
+}
+
+// TestCompositeKeys 测试包含浮点数的结构体键和指向可变内容的接口键
+func TestCompositeKeys(t *testing.T) {
+	type key struct {
+		ID int
+		F  float64
+	}
+	negZero := math.Copysign(0, -1)
+	for range 200 {
+		ht := New[key, int](16)
+		ht.Put(key{1, negZero}, 1)
+		if v, ok := ht.Get(key{1, 0}); !ok || v != 1 {
+			t.Fatal("-0 与 +0 相等，应找到同一个键")
+		}
+	}
+
+	ht := New[any, string](16)
+	p := &struct{ N int }{1}
+	ht.Put(p, "p")
+	p.N = 2 // 修改指向的内容不影响按地址比较的键
+	if v, ok := ht.Get(p); !ok || v != "p" {
+		t.Error("修改指针指向的内容后应仍能找到该键")
+	}
+	if _, ok := ht.Get(&struct{ N int }{2}); ok {
+		t.Error("不同地址的指针不应被找到")
+	}
+}
+
+// TestHasherDistribution 测试连续整数键在桶之间分布均匀
+func TestHasherDistribution(t *testing.T) {
+	const buckets, keys = 64, 64 * 100
+	h := newHasher[int]()
+	counts := make([]int, buckets)
+	for i := range keys {
+		counts[h(i*buckets)%buckets]++ // 都是桶数量的倍数，取模哈希会全部落入同一个桶
+	}
+	for i, c := range counts {
+		if c < 50 || c > 150 {
+			t.Fatalf("桶%d中有%d个键，期望接近100", i, c)
+		}
+	}
+}
+
+// TestHashNoAlloc 测试整数和字符串键的查找不分配内存
+func TestHashNoAlloc(t *testing.T) {
+	ints := New[int, int](16)
+	strs := New[string, int](16)
+	for i := range 100 {
+		ints.Put(i, i)
+		strs.Put(string(rune('a'+i%26))+"key", i)
+	}
+	if n := testing.AllocsPerRun(100, func() { ints.Get(42) }); n != 0 {
+		t.Errorf("整数键的查找分配了%v次内存", n)
+	}
+	if n := testing.AllocsPerRun(100, func() { strs.Get("akey") }); n != 0 {
+		t.Errorf("字符串键的查找分配了%v次内存", n)
+	}
+}
@@ -1,19 +1,23 @@
 package hashtable
 
 import (
-	"fmt"
-	"hash/fnv"
+	"iter"
 	"sync"
 	"sync/atomic"
+
+	"godatastructure/codec"
+	"godatastructure/metrics"
 )
 
 // HashTable 线程安全的泛型哈希表结构
 type HashTable[K comparable, V any] struct {
-	buckets    []*bucket[K, V] // 桶数组
-	size       atomic.Int64    // 使用原子计数器存储元素数量
-	bucketSize int             // 桶的数量
-	mu         sync.RWMutex    // 用于扩容的读写锁
-	resizing   atomic.Bool     // 标记是否正在进行扩容
+	buckets    []*bucket[K, V]  // 桶数组
+	size       atomic.Int64     // 使用原子计数器存储元素数量
+	bucketSize int              // 桶的数量
+	mu         sync.RWMutex     // 用于扩容的读写锁
+	resizing   atomic.Bool      // 标记是否正在进行扩容
+	recorder   metrics.Recorder // 指标记录器，为 nil 时不记录
+	hasher     func(K) uint64   // 键的哈希函数，由 newHasher 按键的类型选择
 }
 
 // bucket 定义了哈希桶结构
@@ -28,15 +32,41 @@ type entry[K comparable, V any] struct {
 	value V
 }
 
+// Option 哈希表的可选配置
+type Option func(*options)
+
+// options 哈希表的配置项
+type options struct {
+	recorder metrics.Recorder
+}
+
+// WithRecorder 使哈希表把插入、更新、删除、查找和重新哈希事件报告给 r
+// 哈希表会在多个协程中同时调用 r，因此 r 必须是并发安全的，例如 *metrics.Counters；
+// Clone 得到的副本不会继承记录器
+func WithRecorder(r metrics.Recorder) Option {
+	return func(o *options) {
+		o.recorder = r
+	}
+}
+
 // New 创建一个新的哈希表实例
-func New[K comparable, V any](initialSize int) *HashTable[K, V] {
+// 参数：
+//   - initialSize: 初始桶数量，小于1时使用16
+//   - opts: 可选配置，例如 WithRecorder
+func New[K comparable, V any](initialSize int, opts ...Option) *HashTable[K, V] {
 	if initialSize < 1 {
 		initialSize = 16
 	}
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
 
 	ht := &HashTable[K, V]{
 		buckets:    make([]*bucket[K, V], initialSize),
 		bucketSize: initialSize,
+		recorder:   o.recorder,
+		hasher:     newHasher[K](),
 	}
 
 	for i := 0; i < initialSize; i++ {
@@ -48,15 +78,20 @@ func New[K comparable, V any](initialSize int) *HashTable[K, V] {
 	return ht
 }
 
-// hash 计算给定键的哈希值
+// record 向记录器报告事件
+func (ht *HashTable[K, V]) record(e metrics.Event) {
+	if ht.recorder != nil {
+		ht.recorder.Record(e, ht.Size())
+	}
+}
+
+// hash 计算给定键所在桶的下标
 func (ht *HashTable[K, V]) hash(key K) int {
-	keyStr := fmt.Sprintf("%v", key)
-	h := fnv.New32a()
-	h.Write([]byte(keyStr))
+	h := ht.hasher(key)
 	ht.mu.RLock()
 	bucketSize := ht.bucketSize
 	ht.mu.RUnlock()
-	return int(h.Sum32()) % bucketSize
+	return int(h % uint64(bucketSize))
 }
 
 // Put 向哈希表中插入键值对
@@ -93,14 +128,57 @@ func (ht *HashTable[K, V]) Put(key K, value V) {
 
 			// 增加计数并检查是否需要扩容
 			newSize := ht.size.Add(1)
+			ht.record(metrics.Insert)
 			if float64(newSize)/float64(ht.bucketSize) > 0.75 {
 				ht.tryResize()
 			}
 			retry = false
 		} else {
 			bucket.mu.Unlock()
+			ht.record(metrics.Update)
+		}
+	}
+}
+
+// GetOrSet 键存在时返回已有的值，否则插入 value 并返回 value，与 sync.Map.LoadOrStore 相同
+// 查找与插入在同一次持有桶锁期间完成，并且期间持有读锁阻止扩容，
+// 多个协程同时对同一个键调用 GetOrSet 时只有一个协程的值会被存入，其余协程都得到这个值；
+// 原子性只针对其他 GetOrSet 调用以及同一个桶上的 Put、Delete：Put 会覆盖 GetOrSet 存入的值，
+// 而 Put、Get、Delete 不阻止扩容，与扩容并发时的行为与这些方法本身相同
+// 参数：
+//   - key: 键
+//   - value: 键不存在时插入的值
+//
+// 返回：
+//   - actual: 调用结束时哈希表中该键对应的值
+//   - loaded: 键已存在时为 true，插入了 value 时为 false
+func (ht *HashTable[K, V]) GetOrSet(key K, value V) (actual V, loaded bool) {
+	h := ht.hasher(key)
+	ht.mu.RLock()
+	bucket := ht.buckets[int(h%uint64(ht.bucketSize))]
+	bucket.mu.Lock()
+	for _, e := range bucket.entries {
+		if e.key == key {
+			bucket.mu.Unlock()
+			ht.mu.RUnlock()
+			ht.record(metrics.Lookup)
+			return e.value, true
 		}
 	}
+	bucket.entries = append(bucket.entries, entry[K, V]{
+		key:   key,
+		value: value,
+	})
+	bucket.mu.Unlock()
+	newSize := ht.size.Add(1)
+	bucketSize := ht.bucketSize // 扩容在写锁下修改桶数量，必须在释放读锁之前读取
+	ht.mu.RUnlock()
+
+	ht.record(metrics.Insert)
+	if float64(newSize)/float64(bucketSize) > 0.75 {
+		ht.tryResize()
+	}
+	return value, false
 }
 
 // Get 从哈希表中获取值
@@ -133,6 +211,7 @@ func (ht *HashTable[K, V]) Get(key K) (V, bool) {
 		}
 	}
 
+	ht.record(metrics.Lookup)
 	return result, found
 }
 
@@ -167,6 +246,9 @@ func (ht *HashTable[K, V]) Delete(key K) bool {
 		}
 	}
 
+	if deleted {
+		ht.record(metrics.Delete)
+	}
 	return deleted
 }
 
@@ -207,10 +289,8 @@ func (ht *HashTable[K, V]) tryResize() {
 		oldBucket.mu.Unlock()
 
 		for _, e := range entries {
-			// 计算新的哈希值
-			h := fnv.New32a()
-			h.Write([]byte(fmt.Sprintf("%v", e.key)))
-			newIndex := int(h.Sum32()) % newSize
+			// 计算新的桶下标
+			newIndex := int(ht.hasher(e.key) % uint64(newSize))
 
 			// 将条目放入新桶
 			newBucket := newBuckets[newIndex]
@@ -223,9 +303,179 @@ func (ht *HashTable[K, V]) tryResize() {
 	// 更新哈希表状态
 	ht.buckets = newBuckets
 	ht.bucketSize = newSize
+	ht.record(metrics.Resize)
 }
 
 // Size 返回哈希表中的元素数量
 func (ht *HashTable[K, V]) Size() int {
 	return int(ht.size.Load())
 }
+
+// IsEmpty 判断哈希表是否为空
+func (ht *HashTable[K, V]) IsEmpty() bool {
+	return ht.Size() == 0
+}
+
+// Clear 清空哈希表，桶的数量保持不变
+func (ht *HashTable[K, V]) Clear() {
+	ht.mu.RLock()
+	defer ht.mu.RUnlock()
+
+	for _, b := range ht.buckets {
+		b.mu.Lock()
+		ht.size.Add(-int64(len(b.entries)))
+		clear(b.entries)
+		b.entries = b.entries[:0]
+		b.mu.Unlock()
+	}
+}
+
+// Range 对每个键值对调用 fn，fn 返回 false 时停止，遍历顺序不确定
+// 并发修改下的语义与 sync.Map.Range 相同：遍历期间一直存在的键恰好访问一次，
+// 遍历期间插入或删除的键可能被访问也可能不被访问，访问到的值可能是遍历开始后任意时刻的值；
+// fn 在锁外调用，可以安全地读写哈希表
+// 时间复杂度: O(n + m)，m 为桶的数量
+func (ht *HashTable[K, V]) Range(fn func(K, V) bool) {
+	ht.All()(fn)
+}
+
+// All 返回遍历所有键值对的迭代器，遍历顺序不确定，可以用于 for range 语句
+// 逐个桶复制条目后再回调，回调中可以安全地读写哈希表；
+// 并发修改下的语义与 Range 相同，遍历期间的并发修改可能不会被观察到
+func (ht *HashTable[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		ht.mu.RLock()
+		buckets := ht.buckets
+		ht.mu.RUnlock()
+
+		for _, b := range buckets {
+			b.mu.RLock()
+			entries := make([]entry[K, V], len(b.entries))
+			copy(entries, b.entries)
+			b.mu.RUnlock()
+
+			for _, e := range entries {
+				if !yield(e.key, e.value) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Keys 返回所有键的快照，顺序不确定
+// 复制期间持有读锁，不会与扩容并发执行；与 Clone 一样，其他协程仍可修改尚未复制的桶
+// 时间复杂度: O(n + m)，m 为桶的数量
+func (ht *HashTable[K, V]) Keys() []K {
+	keys := make([]K, 0, ht.Size())
+	ht.snapshot(func(e *entry[K, V]) {
+		keys = append(keys, e.key)
+	})
+	return keys
+}
+
+// Values 返回所有值的快照，顺序不确定，值直接赋值
+// 时间复杂度: O(n + m)
+func (ht *HashTable[K, V]) Values() []V {
+	values := make([]V, 0, ht.Size())
+	ht.snapshot(func(e *entry[K, V]) {
+		values = append(values, e.value)
+	})
+	return values
+}
+
+// snapshot 持有读锁逐个桶对每个条目调用 fn，fn 不能访问哈希表
+func (ht *HashTable[K, V]) snapshot(fn func(e *entry[K, V])) {
+	ht.mu.RLock()
+	defer ht.mu.RUnlock()
+	for _, b := range ht.buckets {
+		b.mu.RLock()
+		for i := range b.entries {
+			fn(&b.entries[i])
+		}
+		b.mu.RUnlock()
+	}
+}
+
+// MarshalBinary 使用 codec.For 返回的键、值编解码器把哈希表编码为二进制，键值对的顺序不确定
+// 实现 encoding.BinaryMarshaler 接口
+func (ht *HashTable[K, V]) MarshalBinary() ([]byte, error) {
+	return codec.EncodeSeq2(ht.All(), codec.For[K](), codec.For[V]())
+}
+
+// UnmarshalBinary 从 MarshalBinary 生成的数据中解码，替换哈希表中的所有键值对
+// 解码失败时哈希表保持不变，实现 encoding.BinaryUnmarshaler 接口
+func (ht *HashTable[K, V]) UnmarshalBinary(data []byte) error {
+	var keys []K
+	var values []V
+	err := codec.DecodeSeq2(data, codec.For[K](), codec.For[V](), func(key K, value V) {
+		keys = append(keys, key)
+		values = append(values, value)
+	})
+	if err != nil {
+		return err
+	}
+	ht.load(keys, values)
+	return nil
+}
+
+// MarshalJSON 把哈希表编码为 {"key": ..., "value": ...} 对象组成的 JSON 数组，实现 json.Marshaler 接口
+func (ht *HashTable[K, V]) MarshalJSON() ([]byte, error) {
+	return codec.MarshalJSONSeq2(ht.All())
+}
+
+// UnmarshalJSON 从 MarshalJSON 生成的 JSON 中解码，替换哈希表中的所有键值对
+// 解码失败时哈希表保持不变，实现 json.Unmarshaler 接口
+func (ht *HashTable[K, V]) UnmarshalJSON(data []byte) error {
+	var keys []K
+	var values []V
+	err := codec.UnmarshalJSONSeq2(data, func(key K, value V) {
+		keys = append(keys, key)
+		values = append(values, value)
+	})
+	if err != nil {
+		return err
+	}
+	ht.load(keys, values)
+	return nil
+}
+
+// load 清空哈希表后放入所有键值对
+func (ht *HashTable[K, V]) load(keys []K, values []V) {
+	ht.Clear()
+	for i, key := range keys {
+		ht.Put(key, values[i])
+	}
+}
+
+// Clone 返回哈希表的深拷贝，新哈希表的桶数量与原哈希表相同
+// copier 用于复制每个值，为 nil 时直接赋值；键直接赋值
+// 复制期间持有读锁，不会与扩容并发执行，但复制各个桶时其他协程仍可修改尚未复制的桶
+// 时间复杂度: O(n)
+func (ht *HashTable[K, V]) Clone(copier func(V) V) *HashTable[K, V] {
+	ht.mu.RLock()
+	defer ht.mu.RUnlock()
+
+	clone := &HashTable[K, V]{
+		buckets:    make([]*bucket[K, V], ht.bucketSize),
+		bucketSize: ht.bucketSize,
+		hasher:     ht.hasher, // 副本沿用原有的桶布局，必须使用相同的哈希函数
+	}
+	var size int64
+	for i, b := range ht.buckets {
+		b.mu.RLock()
+		entries := make([]entry[K, V], len(b.entries), max(cap(b.entries), 8))
+		copy(entries, b.entries)
+		b.mu.RUnlock()
+		// 在桶锁之外复制值，避免 copier 访问哈希表时死锁
+		if copier != nil {
+			for j := range entries {
+				entries[j].value = copier(entries[j].value)
+			}
+		}
+		clone.buckets[i] = &bucket[K, V]{entries: entries}
+		size += int64(len(entries))
+	}
+	clone.size.Store(size)
+	return clone
+}
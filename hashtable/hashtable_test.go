@@ -1,9 +1,13 @@
 package hashtable
 
 import (
+	"encoding/json"
 	"fmt"
+	"slices"
 	"sync"
 	"testing"
+
+	"godatastructure/metrics"
 )
 
 // TestBasicOperations 测试基本的CRUD操作
@@ -237,3 +241,300 @@ func BenchmarkHashTable(b *testing.B) {
 		}
 	})
 }
+
+// TestAll 测试遍历所有键值对，以及在遍历中修改哈希表
+func TestAll(t *testing.T) {
+	ht := New[int, int](4)
+	for i := 0; i < 100; i++ {
+		ht.Put(i, i*i)
+	}
+	seen := make(map[int]bool)
+	for k, v := range ht.All() {
+		if k >= 1000 {
+			continue // 遍历期间写入的键可能被观察到
+		}
+		if v != k*k {
+			t.Errorf("键%d对应的值错误: %d", k, v)
+		}
+		seen[k] = true
+		ht.Put(k+1000, 0) // 遍历中写入不应死锁
+	}
+	if len(seen) != 100 {
+		t.Errorf("期望遍历100个键，实际为%d", len(seen))
+	}
+
+	count := 0
+	for range ht.All() {
+		count++
+		if count == 10 {
+			break
+		}
+	}
+	if count != 10 {
+		t.Error("迭代器未能提前终止")
+	}
+}
+
+// TestRange 测试 Range 的提前终止，以及并发修改时一直存在的键恰好访问一次
+func TestRange(t *testing.T) {
+	ht := New[int, int](4)
+	for i := 0; i < 1000; i++ {
+		ht.Put(i, i)
+	}
+
+	count := 0
+	ht.Range(func(int, int) bool {
+		count++
+		return count < 10
+	})
+	if count != 10 {
+		t.Errorf("fn 返回 false 后应停止，实际调用了%d次", count)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		// 并发插入和删除其他键，并触发扩容
+		for i := 1000; i < 5000; i++ {
+			ht.Put(i, i)
+			if i%2 == 0 {
+				ht.Delete(i)
+			}
+		}
+	}()
+	seen := make(map[int]int)
+	ht.Range(func(k, v int) bool {
+		seen[k]++
+		ht.Get(k) // 回调中读取不应死锁
+		return true
+	})
+	<-done
+	for i := 0; i < 1000; i++ {
+		if seen[i] != 1 {
+			t.Fatalf("一直存在的键%d被访问了%d次", i, seen[i])
+		}
+	}
+}
+
+// TestKeysValues 测试键与值的快照
+func TestKeysValues(t *testing.T) {
+	ht := New[int, string](4)
+	if len(ht.Keys()) != 0 || len(ht.Values()) != 0 {
+		t.Error("空哈希表的快照应为空")
+	}
+	for i := 0; i < 100; i++ {
+		ht.Put(i, fmt.Sprint(i))
+	}
+	ht.Delete(50)
+
+	keys := ht.Keys()
+	slices.Sort(keys)
+	var want []int
+	for i := 0; i < 100; i++ {
+		if i != 50 {
+			want = append(want, i)
+		}
+	}
+	if !slices.Equal(keys, want) {
+		t.Errorf("Keys() 返回%d个键，期望 %d", len(keys), len(want))
+	}
+
+	values := ht.Values()
+	slices.Sort(values)
+	wantValues := make([]string, len(want))
+	for i, k := range want {
+		wantValues[i] = fmt.Sprint(k)
+	}
+	slices.Sort(wantValues)
+	if !slices.Equal(values, wantValues) {
+		t.Error("Values() 的结果与插入的值不一致")
+	}
+
+	// 快照与哈希表互不影响，可以在遍历快照时删除
+	for _, k := range ht.Keys() {
+		ht.Delete(k)
+	}
+	if !ht.IsEmpty() || len(keys) != 99 {
+		t.Error("按快照删除全部键后哈希表应为空")
+	}
+}
+
+// TestGetOrSet 测试键不存在时插入、存在时返回已有的值，以及并发初始化同一个键
+func TestGetOrSet(t *testing.T) {
+	ht := New[string, int](4)
+	if v, loaded := ht.GetOrSet("a", 1); loaded || v != 1 {
+		t.Errorf("GetOrSet(a, 1) = %d, %v，期望插入 1", v, loaded)
+	}
+	if v, loaded := ht.GetOrSet("a", 2); !loaded || v != 1 {
+		t.Errorf("GetOrSet(a, 2) = %d, %v，期望返回已有的 1", v, loaded)
+	}
+	if v, _ := ht.Get("a"); v != 1 || ht.Size() != 1 {
+		t.Error("已有的值不应被覆盖")
+	}
+
+	t.Run("并发初始化", func(t *testing.T) {
+		ht := New[int, int](4)
+		const workers, keys = 8, 500
+		var wg sync.WaitGroup
+		actual := make([][]int, workers)
+		stored := make([]int, workers)
+		for w := range workers {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				actual[w] = make([]int, keys)
+				for k := range keys {
+					v, loaded := ht.GetOrSet(k, w)
+					if !loaded {
+						stored[w]++
+					}
+					actual[w][k] = v
+				}
+			}()
+		}
+		wg.Wait()
+
+		total := 0
+		for _, n := range stored {
+			total += n
+		}
+		if total != keys || ht.Size() != keys {
+			t.Fatalf("共存入%d个值，哈希表大小为%d，期望均为%d", total, ht.Size(), keys)
+		}
+		for k := range keys {
+			v, _ := ht.Get(k)
+			for w := range workers {
+				if actual[w][k] != v {
+					t.Fatalf("协程%d得到键%d的值为%d，哈希表中为%d", w, k, actual[w][k], v)
+				}
+			}
+		}
+	})
+}
+
+// TestClear 测试清空哈希表
+func TestClear(t *testing.T) {
+	ht := New[string, int](4)
+	for i := 0; i < 100; i++ {
+		ht.Put(fmt.Sprint(i), i)
+	}
+	ht.Clear()
+	if !ht.IsEmpty() || ht.Size() != 0 {
+		t.Errorf("清空后应为空，实际大小为%d", ht.Size())
+	}
+	if _, ok := ht.Get("1"); ok {
+		t.Error("清空后不应找到键")
+	}
+	ht.Put("a", 1)
+	if v, ok := ht.Get("a"); !ok || v != 1 || ht.Size() != 1 {
+		t.Error("清空后应可以继续插入")
+	}
+}
+
+// TestMarshal 测试哈希表的二进制与 JSON 序列化
+func TestMarshal(t *testing.T) {
+	src := New[int, string](4)
+	for i := 0; i < 100; i++ {
+		src.Put(i, fmt.Sprint(i))
+	}
+	check := func(t *testing.T, dst *HashTable[int, string]) {
+		t.Helper()
+		if dst.Size() != 100 {
+			t.Fatalf("期望大小为100，实际为%d", dst.Size())
+		}
+		for i := 0; i < 100; i++ {
+			if v, ok := dst.Get(i); !ok || v != fmt.Sprint(i) {
+				t.Fatalf("Get(%d) = (%v, %v)", i, v, ok)
+			}
+		}
+	}
+
+	t.Run("二进制", func(t *testing.T) {
+		data, err := src.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		dst := New[int, string](4)
+		if err := dst.UnmarshalBinary(data); err != nil {
+			t.Fatal(err)
+		}
+		check(t, dst)
+		if err := dst.UnmarshalBinary(data[:len(data)-1]); err == nil {
+			t.Error("截断的数据应返回错误")
+		}
+		check(t, dst)
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		data, err := json.Marshal(src)
+		if err != nil {
+			t.Fatal(err)
+		}
+		dst := New[int, string](4)
+		dst.Put(1000, "旧数据")
+		if err := json.Unmarshal(data, dst); err != nil {
+			t.Fatal(err)
+		}
+		check(t, dst)
+		if _, ok := dst.Get(1000); ok {
+			t.Error("解码后应替换原有的键值对")
+		}
+	})
+}
+
+// TestClone 测试哈希表的深拷贝
+func TestClone(t *testing.T) {
+	ht := New[string, []int](4)
+	for i := 0; i < 50; i++ {
+		ht.Put(fmt.Sprint(i), []int{i})
+	}
+	clone := ht.Clone(func(v []int) []int { return append([]int(nil), v...) })
+	if clone.Size() != 50 {
+		t.Fatalf("期望大小为50，实际为%d", clone.Size())
+	}
+
+	v, _ := ht.Get("7")
+	v[0] = 100
+	ht.Delete("8")
+	clone.Put("new", nil)
+
+	if v, _ := clone.Get("7"); v[0] != 7 {
+		t.Errorf("深拷贝不应受原值修改的影响，实际为%v", v)
+	}
+	if _, ok := clone.Get("8"); !ok {
+		t.Error("删除原哈希表的键不应影响拷贝")
+	}
+	if _, ok := ht.Get("new"); ok || ht.Size() != 49 {
+		t.Error("修改拷贝不应影响原哈希表")
+	}
+}
+
+// TestWithRecorder 测试哈希表在并发读写时向记录器报告事件
+func TestWithRecorder(t *testing.T) {
+	var c metrics.Counters
+	ht := New[int, int](4, WithRecorder(&c))
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			ht.Put(i, i)
+			ht.Get(i)
+		}(i)
+	}
+	wg.Wait()
+	ht.Put(0, 1)
+	ht.Delete(1)
+	ht.Delete(1000)
+
+	if c.Count(metrics.Insert) != 100 || c.Count(metrics.Update) != 1 ||
+		c.Count(metrics.Lookup) != 100 || c.Count(metrics.Delete) != 1 {
+		t.Errorf("事件计数错误: %v", c.Snapshot())
+	}
+	if c.Count(metrics.Resize) == 0 {
+		t.Error("插入100个元素应触发重新哈希")
+	}
+	if c.Peak() != 100 {
+		t.Errorf("期望峰值为100，实际为%d", c.Peak())
+	}
+}
@@ -0,0 +1,219 @@
+package heap
+
+import (
+	"fmt"
+	"math/bits"
+)
+
+// MinMaxHeap 最小最大堆
+// 偶数层（根为第0层）为最小层，奇数层为最大层，
+// 可同时在 O(1) 时间内访问最小值和最大值，在 O(log n) 时间内删除最小值或最大值
+type MinMaxHeap[T any] struct {
+	elements []T              // 使用切片存储完全二叉树
+	cmp      func(a, b T) int // 比较函数
+}
+
+// NewMinMaxHeap 创建新的最小最大堆
+// 时间复杂度: O(1)
+func NewMinMaxHeap[T any](cmp func(a, b T) int) *MinMaxHeap[T] {
+	return &MinMaxHeap[T]{cmp: cmp}
+}
+
+// Push 插入元素
+// 时间复杂度: O(log n)
+func (h *MinMaxHeap[T]) Push(value T) {
+	h.elements = append(h.elements, value)
+	h.bubbleUp(len(h.elements) - 1)
+}
+
+// PeekMin 返回最小元素但不移除
+// 时间复杂度: O(1)
+func (h *MinMaxHeap[T]) PeekMin() (T, bool) {
+	if len(h.elements) == 0 {
+		var zero T
+		return zero, false
+	}
+	return h.elements[0], true
+}
+
+// PeekMax 返回最大元素但不移除
+// 时间复杂度: O(1)
+func (h *MinMaxHeap[T]) PeekMax() (T, bool) {
+	if len(h.elements) == 0 {
+		var zero T
+		return zero, false
+	}
+	return h.elements[h.maxIndex()], true
+}
+
+// PopMin 移除并返回最小元素
+// 时间复杂度: O(log n)
+func (h *MinMaxHeap[T]) PopMin() (T, bool) {
+	if len(h.elements) == 0 {
+		var zero T
+		return zero, false
+	}
+	return h.removeAt(0), true
+}
+
+// PopMax 移除并返回最大元素
+// 时间复杂度: O(log n)
+func (h *MinMaxHeap[T]) PopMax() (T, bool) {
+	if len(h.elements) == 0 {
+		var zero T
+		return zero, false
+	}
+	return h.removeAt(h.maxIndex()), true
+}
+
+// Size 返回堆中元素数量
+// 时间复杂度: O(1)
+func (h *MinMaxHeap[T]) Size() int {
+	return len(h.elements)
+}
+
+// Clear 清空堆
+// 时间复杂度: O(1)
+func (h *MinMaxHeap[T]) Clear() {
+	h.elements = nil
+}
+
+// IsEmpty 检查堆是否为空
+// 时间复杂度: O(1)
+func (h *MinMaxHeap[T]) IsEmpty() bool {
+	return len(h.elements) == 0
+}
+
+// maxIndex 返回最大元素的下标，最大元素位于根的两个子节点之一
+func (h *MinMaxHeap[T]) maxIndex() int {
+	switch len(h.elements) {
+	case 1:
+		return 0
+	case 2:
+		return 1
+	}
+	if h.cmp(h.elements[1], h.elements[2]) >= 0 {
+		return 1
+	}
+	return 2
+}
+
+// removeAt 用最后一个元素替换下标 i 处的元素并向下调整
+func (h *MinMaxHeap[T]) removeAt(i int) T {
+	last := len(h.elements) - 1
+	value := h.elements[i]
+	h.elements[i] = h.elements[last]
+	var zero T
+	h.elements[last] = zero // 清除引用，帮助垃圾回收
+	h.elements = h.elements[:last]
+	if i < last {
+		h.trickleDown(i, isMinLevel(i))
+	}
+	return value
+}
+
+// isMinLevel 判断下标 i 是否位于最小层
+func isMinLevel(i int) bool {
+	return (bits.Len(uint(i+1))-1)%2 == 0
+}
+
+// better 在最小层比较时返回 a < b，在最大层比较时返回 a > b
+func (h *MinMaxHeap[T]) better(a, b T, min bool) bool {
+	if min {
+		return h.cmp(a, b) < 0
+	}
+	return h.cmp(a, b) > 0
+}
+
+// bubbleUp 将新插入的元素向上调整到正确位置
+func (h *MinMaxHeap[T]) bubbleUp(i int) {
+	if i == 0 {
+		return
+	}
+	min := isMinLevel(i)
+	parent := (i - 1) / 2
+	// 与父节点违反所在层的顺序时，交换后沿父节点所在的层继续调整
+	if h.better(h.elements[parent], h.elements[i], min) {
+		h.elements[i], h.elements[parent] = h.elements[parent], h.elements[i]
+		h.bubbleUpGrandparent(parent, !min)
+	} else {
+		h.bubbleUpGrandparent(i, min)
+	}
+}
+
+// bubbleUpGrandparent 沿祖父节点链向上调整
+func (h *MinMaxHeap[T]) bubbleUpGrandparent(i int, min bool) {
+	for i > 2 {
+		grandparent := ((i-1)/2 - 1) / 2
+		if !h.better(h.elements[i], h.elements[grandparent], min) {
+			return
+		}
+		h.elements[i], h.elements[grandparent] = h.elements[grandparent], h.elements[i]
+		i = grandparent
+	}
+}
+
+// trickleDown 将下标 i 处的元素向下调整
+// min 为 true 时按最小层规则调整，否则按最大层规则调整
+func (h *MinMaxHeap[T]) trickleDown(i int, min bool) {
+	n := len(h.elements)
+	for {
+		// 在子节点和孙节点中找出最优者
+		m := -1
+		first := 2*i + 1
+		for _, c := range []int{first, first + 1, 2*first + 1, 2*first + 2, 2*first + 3, 2*first + 4} {
+			if c < n && (m == -1 || h.better(h.elements[c], h.elements[m], min)) {
+				m = c
+			}
+		}
+		if m == -1 || !h.better(h.elements[m], h.elements[i], min) {
+			return
+		}
+
+		h.elements[i], h.elements[m] = h.elements[m], h.elements[i]
+		if m <= first+1 {
+			// 最优者是子节点，交换后即满足堆性质
+			return
+		}
+		// 最优者是孙节点，交换后还需与其父节点比较
+		parent := (m - 1) / 2
+		if h.better(h.elements[parent], h.elements[m], min) {
+			h.elements[m], h.elements[parent] = h.elements[parent], h.elements[m]
+		}
+		i = m
+	}
+}
+
+// Clone 返回堆的深拷贝
+// copier 用于复制每个元素，为 nil 时直接赋值；复制后的元素应与原元素保持相同的顺序
+// 时间复杂度: O(n)
+func (h *MinMaxHeap[T]) Clone(copier func(T) T) *MinMaxHeap[T] {
+	elements := make([]T, len(h.elements))
+	for i, v := range h.elements {
+		if copier != nil {
+			v = copier(v)
+		}
+		elements[i] = v
+	}
+	return &MinMaxHeap[T]{elements: elements, cmp: h.cmp}
+}
+
+// Validate 检查最小最大堆的结构不变量，发现损坏时返回描述问题的错误
+// 检查内容：最小层的元素不大于其所有后代，最大层的元素不小于其所有后代。
+// 由传递性，只需检查每个元素与父节点和祖父节点的关系
+// 时间复杂度: O(n)
+func (h *MinMaxHeap[T]) Validate() error {
+	for i := 1; i < len(h.elements); i++ {
+		parent := (i - 1) / 2
+		if h.better(h.elements[i], h.elements[parent], isMinLevel(parent)) {
+			return fmt.Errorf("下标%d的元素与父节点违反所在层的顺序", i)
+		}
+		if parent > 0 {
+			grandparent := (parent - 1) / 2
+			if h.better(h.elements[i], h.elements[grandparent], isMinLevel(grandparent)) {
+				return fmt.Errorf("下标%d的元素与祖父节点违反所在层的顺序", i)
+			}
+		}
+	}
+	return nil
+}
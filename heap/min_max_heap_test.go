@@ -0,0 +1,140 @@
+package heap
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+// TestMinMaxHeapEmpty 测试空堆操作
+func TestMinMaxHeapEmpty(t *testing.T) {
+	h := NewMinMaxHeap(intCmp)
+	if !h.IsEmpty() || h.Size() != 0 {
+		t.Error("新创建的堆应该为空")
+	}
+	if _, ok := h.PeekMin(); ok {
+		t.Error("空堆PeekMin应该返回false")
+	}
+	if _, ok := h.PeekMax(); ok {
+		t.Error("空堆PeekMax应该返回false")
+	}
+	if _, ok := h.PopMin(); ok {
+		t.Error("空堆PopMin应该返回false")
+	}
+	if _, ok := h.PopMax(); ok {
+		t.Error("空堆PopMax应该返回false")
+	}
+}
+
+// TestMinMaxHeapBasic 测试基本操作
+func TestMinMaxHeapBasic(t *testing.T) {
+	h := NewMinMaxHeap(intCmp)
+	for _, v := range []int{5, 1, 9, 3, 7} {
+		h.Push(v)
+	}
+
+	if v, _ := h.PeekMin(); v != 1 {
+		t.Errorf("PeekMin期望1，实际为%d", v)
+	}
+	if v, _ := h.PeekMax(); v != 9 {
+		t.Errorf("PeekMax期望9，实际为%d", v)
+	}
+
+	if v, _ := h.PopMax(); v != 9 {
+		t.Errorf("PopMax期望9，实际为%d", v)
+	}
+	if v, _ := h.PopMin(); v != 1 {
+		t.Errorf("PopMin期望1，实际为%d", v)
+	}
+	if v, _ := h.PopMax(); v != 7 {
+		t.Errorf("PopMax期望7，实际为%d", v)
+	}
+	if h.Size() != 2 {
+		t.Errorf("期望大小为2，实际为%d", h.Size())
+	}
+}
+
+// TestMinMaxHeapRandom 随机交替弹出最小值和最大值，与排序结果对比
+func TestMinMaxHeapRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	h := NewMinMaxHeap(intCmp)
+	values := make([]int, 500)
+	for i := range values {
+		values[i] = r.Intn(1000)
+		h.Push(values[i])
+	}
+	sort.Ints(values)
+
+	lo, hi := 0, len(values)-1
+	for lo <= hi {
+		if r.Intn(2) == 0 {
+			got, _ := h.PopMin()
+			if got != values[lo] {
+				t.Fatalf("PopMin期望%d，实际为%d", values[lo], got)
+			}
+			lo++
+		} else {
+			got, _ := h.PopMax()
+			if got != values[hi] {
+				t.Fatalf("PopMax期望%d，实际为%d", values[hi], got)
+			}
+			hi--
+		}
+	}
+	if !h.IsEmpty() {
+		t.Error("全部弹出后堆应为空")
+	}
+}
+
+// TestMinMaxHeapTopK 使用最小最大堆维护有界的前K大元素
+func TestMinMaxHeapTopK(t *testing.T) {
+	const k = 5
+	h := NewMinMaxHeap(intCmp)
+	for i := 0; i < 100; i++ {
+		h.Push(i)
+		if h.Size() > k {
+			h.PopMin()
+		}
+	}
+	for want := 95; want < 100; want++ {
+		if got, _ := h.PopMin(); got != want {
+			t.Errorf("期望%d，实际为%d", want, got)
+		}
+	}
+}
+
+// TestMinMaxHeapClone 测试最小最大堆的深拷贝
+func TestMinMaxHeapClone(t *testing.T) {
+	h := NewMinMaxHeap(intCmp)
+	for _, v := range []int{5, 1, 9, 3, 7} {
+		h.Push(v)
+	}
+	clone := h.Clone(nil)
+	h.PopMin()
+	h.PopMax()
+	if v, _ := clone.PeekMin(); v != 1 {
+		t.Errorf("期望最小值为1，实际为%d", v)
+	}
+	if v, _ := clone.PeekMax(); v != 9 || clone.Size() != 5 {
+		t.Errorf("修改原堆不应影响拷贝，最大值为%d", v)
+	}
+}
+
+// TestMinMaxHeapValidate 测试最小最大堆结构检查
+func TestMinMaxHeapValidate(t *testing.T) {
+	h := NewMinMaxHeap(intCmp)
+	for i := 0; i < 100; i++ {
+		h.Push((i * 37) % 101)
+		if i%5 == 0 {
+			h.PopMax()
+		}
+	}
+	if err := h.Validate(); err != nil {
+		t.Fatalf("正常使用后应通过检查: %v", err)
+	}
+
+	h.elements[0] = 1000
+	if h.Validate() == nil {
+		t.Error("应发现根节点大于后代")
+	}
+}
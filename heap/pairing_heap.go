@@ -0,0 +1,263 @@
+package heap
+
+import "errors"
+
+// 定义堆操作可能遇到的错误
+var (
+	// ErrInvalidElement 元素不属于当前堆或已被弹出时返回此错误
+	ErrInvalidElement = errors.New("无效的堆元素")
+	// ErrKeyIncreased DecreaseKey 传入的新值大于原值时返回此错误
+	ErrKeyIncreased = errors.New("新值不能大于原值")
+)
+
+// Element 配对堆中的元素句柄
+// Push 时返回，用于后续的 DecreaseKey 操作
+type Element[T any] struct {
+	Value   T           // 元素值
+	child   *Element[T] // 最左侧的子节点
+	next    *Element[T] // 右侧的兄弟节点
+	prev    *Element[T] // 左侧的兄弟节点；若为最左子节点则指向父节点
+	removed bool        // 是否已从堆中弹出
+	owner   *heapID     // Push 时所在堆的标识，Meld 后沿 parent 找到当前所属的堆
+}
+
+// heapID 堆的标识，Meld 时把被合并堆的标识指向当前堆的标识，使其元素的归属在 O(1) 内转移
+// 查找时做路径压缩，与并查集相同
+type heapID struct {
+	parent *heapID // 合并到的堆的标识，为 nil 表示仍是某个堆的当前标识
+}
+
+// find 返回 id 所在合并链上的最终标识，并把路径上的标识直接指向它
+func (id *heapID) find() *heapID {
+	root := id
+	for root.parent != nil {
+		root = root.parent
+	}
+	for id != root {
+		id, id.parent = id.parent, root
+	}
+	return root
+}
+
+// PairingHeap 配对堆（最小堆）
+// 比较函数返回值小于0表示 a 的优先级高于 b
+// 支持 O(1) 的 Push/Meld/Peek，均摊 O(log n) 的 Pop，以及均摊 o(log n) 的 DecreaseKey
+type PairingHeap[T any] struct {
+	root *Element[T]      // 堆顶元素
+	size int              // 元素数量
+	cmp  func(a, b T) int // 比较函数
+	id   *heapID          // 堆的标识，第一次 Push 时创建
+}
+
+// NewPairingHeap 创建新的配对堆
+// 时间复杂度: O(1)
+func NewPairingHeap[T any](cmp func(a, b T) int) *PairingHeap[T] {
+	return &PairingHeap[T]{cmp: cmp}
+}
+
+// Push 插入元素并返回其句柄
+// 时间复杂度: O(1)
+func (h *PairingHeap[T]) Push(value T) *Element[T] {
+	if h.id == nil {
+		h.id = &heapID{}
+	}
+	e := &Element[T]{Value: value, owner: h.id}
+	h.root = h.link(h.root, e)
+	h.size++
+	return e
+}
+
+// Peek 返回堆顶元素但不移除
+// 时间复杂度: O(1)
+func (h *PairingHeap[T]) Peek() (T, bool) {
+	if h.root == nil {
+		var zero T
+		return zero, false
+	}
+	return h.root.Value, true
+}
+
+// Pop 移除并返回堆顶元素
+// 时间复杂度: 均摊 O(log n)
+func (h *PairingHeap[T]) Pop() (T, bool) {
+	if h.root == nil {
+		var zero T
+		return zero, false
+	}
+	old := h.root
+	h.root = h.mergePairs(old.child)
+	h.size--
+
+	old.child = nil
+	old.removed = true
+	return old.Value, true
+}
+
+// DecreaseKey 将元素的值减小为 value
+// 参数：
+//   - e: Push 返回的元素句柄
+//   - value: 新值，不能大于原值
+//
+// 返回值：
+//   - error: 元素已弹出或不属于当前堆时返回 ErrInvalidElement，新值更大时返回 ErrKeyIncreased
+//
+// 时间复杂度: 均摊 o(log n)
+func (h *PairingHeap[T]) DecreaseKey(e *Element[T], value T) error {
+	if e == nil || e.removed || h.id == nil || e.owner.find() != h.id {
+		return ErrInvalidElement
+	}
+	if h.cmp(value, e.Value) > 0 {
+		return ErrKeyIncreased
+	}
+	e.Value = value
+	if e == h.root {
+		return nil
+	}
+
+	// 将以 e 为根的子树从原位置剪下
+	if e.prev.child == e {
+		e.prev.child = e.next
+	} else {
+		e.prev.next = e.next
+	}
+	if e.next != nil {
+		e.next.prev = e.prev
+	}
+	e.next = nil
+	e.prev = nil
+
+	h.root = h.link(h.root, e)
+	return nil
+}
+
+// Meld 将另一个堆合并到当前堆中，合并后 other 被清空
+// other 中元素的句柄在合并后仍然有效，归属于当前堆
+// 时间复杂度: O(1)
+func (h *PairingHeap[T]) Meld(other *PairingHeap[T]) {
+	if other == nil || other == h || other.root == nil {
+		return
+	}
+	if h.id == nil {
+		h.id = &heapID{}
+	}
+	other.id.parent = h.id
+	h.root = h.link(h.root, other.root)
+	h.size += other.size
+	other.root = nil
+	other.size = 0
+	other.id = nil
+}
+
+// Size 返回堆中元素数量
+// 时间复杂度: O(1)
+func (h *PairingHeap[T]) Size() int {
+	return h.size
+}
+
+// IsEmpty 检查堆是否为空
+// 时间复杂度: O(1)
+func (h *PairingHeap[T]) IsEmpty() bool {
+	return h.size == 0
+}
+
+// Clear 清空堆，清空后原有元素的句柄全部失效
+// 时间复杂度: O(n)
+func (h *PairingHeap[T]) Clear() {
+	stack := []*Element[T]{}
+	if h.root != nil {
+		stack = append(stack, h.root)
+	}
+	for len(stack) > 0 {
+		e := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		for c := e.child; c != nil; {
+			next := c.next
+			stack = append(stack, c)
+			c = next
+		}
+		*e = Element[T]{Value: e.Value, removed: true}
+	}
+	h.root = nil
+	h.size = 0
+}
+
+// Clone 返回结构相同的深拷贝，原堆中元素的句柄不能用于新堆
+// copier 用于复制每个元素，为 nil 时直接赋值；复制后的元素应与原元素保持相同的顺序
+// 时间复杂度: O(n)
+func (h *PairingHeap[T]) Clone(copier func(T) T) *PairingHeap[T] {
+	id := &heapID{}
+	return &PairingHeap[T]{root: cloneElement(h.root, nil, id, copier), size: h.size, cmp: h.cmp, id: id}
+}
+
+// cloneElement 复制 e 及其所有子节点和右侧兄弟节点，prev 为复制后 e 的 prev 指针，owner 为新堆的标识
+func cloneElement[T any](e, prev *Element[T], owner *heapID, copier func(T) T) *Element[T] {
+	var head *Element[T]
+	for ; e != nil; e = e.next {
+		value := e.Value
+		if copier != nil {
+			value = copier(value)
+		}
+		clone := &Element[T]{Value: value, prev: prev, owner: owner}
+		clone.child = cloneElement(e.child, clone, owner, copier)
+		if head == nil {
+			head = clone
+		} else {
+			prev.next = clone
+		}
+		prev = clone
+	}
+	return head
+}
+
+// link 合并两棵堆序树，优先级较低的根成为另一根的最左子节点
+// 时间复杂度: O(1)
+func (h *PairingHeap[T]) link(a, b *Element[T]) *Element[T] {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	if h.cmp(b.Value, a.Value) < 0 {
+		a, b = b, a
+	}
+	b.next = a.child
+	if a.child != nil {
+		a.child.prev = b
+	}
+	b.prev = a
+	a.child = b
+	a.next = nil
+	a.prev = nil
+	return a
+}
+
+// mergePairs 两趟合并子节点链表：先从左到右两两合并，再从右到左依次合并
+// 使用迭代实现，避免子节点过多时递归过深
+// 时间复杂度: 均摊 O(log n)
+func (h *PairingHeap[T]) mergePairs(first *Element[T]) *Element[T] {
+	if first == nil {
+		return nil
+	}
+
+	var pairs []*Element[T]
+	for first != nil {
+		a := first
+		b := a.next
+		if b == nil {
+			a.prev = nil
+			pairs = append(pairs, a)
+			break
+		}
+		first = b.next
+		a.next, a.prev = nil, nil
+		b.next, b.prev = nil, nil
+		pairs = append(pairs, h.link(a, b))
+	}
+
+	result := pairs[len(pairs)-1]
+	for i := len(pairs) - 2; i >= 0; i-- {
+		result = h.link(pairs[i], result)
+	}
+	return result
+}
@@ -0,0 +1,243 @@
+package heap
+
+import (
+	"errors"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+// 比较函数
+func intCmp(a, b int) int {
+	if a < b {
+		return -1
+	}
+	if a > b {
+		return 1
+	}
+	return 0
+}
+
+// TestPairingHeapPushPop 测试插入和弹出操作
+func TestPairingHeapPushPop(t *testing.T) {
+	h := NewPairingHeap(intCmp)
+
+	if _, ok := h.Pop(); ok {
+		t.Error("空堆弹出应该返回false")
+	}
+	if _, ok := h.Peek(); ok {
+		t.Error("空堆Peek应该返回false")
+	}
+
+	values := []int{5, 3, 8, 1, 9, 2, 7, 4, 6, 0}
+	for _, v := range values {
+		h.Push(v)
+	}
+	if h.Size() != len(values) {
+		t.Errorf("期望大小为%d，实际为%d", len(values), h.Size())
+	}
+
+	for want := 0; want < len(values); want++ {
+		if top, _ := h.Peek(); top != want {
+			t.Errorf("Peek期望%d，实际为%d", want, top)
+		}
+		got, ok := h.Pop()
+		if !ok || got != want {
+			t.Errorf("Pop期望%d，实际为%d", want, got)
+		}
+	}
+	if !h.IsEmpty() {
+		t.Error("全部弹出后堆应为空")
+	}
+}
+
+// TestPairingHeapDecreaseKey 测试减小键值操作
+func TestPairingHeapDecreaseKey(t *testing.T) {
+	h := NewPairingHeap(intCmp)
+	elems := make([]*Element[int], 0)
+	for i := 10; i < 20; i++ {
+		elems = append(elems, h.Push(i))
+	}
+
+	t.Run("减小为最小值", func(t *testing.T) {
+		if err := h.DecreaseKey(elems[7], 1); err != nil {
+			t.Fatalf("DecreaseKey失败: %v", err)
+		}
+		if top, _ := h.Peek(); top != 1 {
+			t.Errorf("堆顶期望为1，实际为%d", top)
+		}
+	})
+
+	t.Run("增大键值", func(t *testing.T) {
+		if err := h.DecreaseKey(elems[0], 100); !errors.Is(err, ErrKeyIncreased) {
+			t.Errorf("期望ErrKeyIncreased，实际为%v", err)
+		}
+	})
+
+	t.Run("已弹出的元素", func(t *testing.T) {
+		h.Pop()
+		if err := h.DecreaseKey(elems[7], 0); !errors.Is(err, ErrInvalidElement) {
+			t.Errorf("期望ErrInvalidElement，实际为%v", err)
+		}
+	})
+
+	t.Run("其他堆的元素", func(t *testing.T) {
+		other := NewPairingHeap(intCmp)
+		e := other.Push(5)
+		if err := h.DecreaseKey(e, 0); !errors.Is(err, ErrInvalidElement) {
+			t.Errorf("期望ErrInvalidElement，实际为%v", err)
+		}
+		if err := NewPairingHeap(intCmp).DecreaseKey(e, 0); !errors.Is(err, ErrInvalidElement) {
+			t.Errorf("空堆期望ErrInvalidElement，实际为%v", err)
+		}
+		if err := h.Clone(nil).DecreaseKey(elems[0], 0); !errors.Is(err, ErrInvalidElement) {
+			t.Errorf("克隆的堆期望ErrInvalidElement，实际为%v", err)
+		}
+		if top, _ := other.Peek(); top != 5 || h.Size() != 9 {
+			t.Error("传入其他堆的元素不应修改任何堆")
+		}
+	})
+}
+
+// TestPairingHeapMeld 测试合并操作
+func TestPairingHeapMeld(t *testing.T) {
+	a := NewPairingHeap(intCmp)
+	b := NewPairingHeap(intCmp)
+	for i := 0; i < 10; i += 2 {
+		a.Push(i)
+	}
+	var handle *Element[int]
+	for i := 1; i < 10; i += 2 {
+		handle = b.Push(i)
+	}
+
+	a.Meld(b)
+	if a.Size() != 10 || !b.IsEmpty() {
+		t.Fatalf("合并后大小错误: a=%d, b=%d", a.Size(), b.Size())
+	}
+
+	// 合并后原句柄归属于 a，不能再用于 b
+	if err := b.DecreaseKey(handle, -1); !errors.Is(err, ErrInvalidElement) {
+		t.Errorf("期望ErrInvalidElement，实际为%v", err)
+	}
+	c := NewPairingHeap(intCmp)
+	c.Push(100)
+	c.Meld(a)
+	a.Meld(c)
+	if a.Size() != 11 || !c.IsEmpty() {
+		t.Fatalf("再次合并后大小错误: a=%d, c=%d", a.Size(), c.Size())
+	}
+	if err := c.DecreaseKey(handle, -1); !errors.Is(err, ErrInvalidElement) {
+		t.Errorf("期望ErrInvalidElement，实际为%v", err)
+	}
+
+	// 合并后原句柄依然有效
+	if err := a.DecreaseKey(handle, -1); err != nil {
+		t.Fatalf("合并后DecreaseKey失败: %v", err)
+	}
+
+	want := []int{-1, 0, 1, 2, 3, 4, 5, 6, 7, 8, 100}
+	for _, w := range want {
+		if got, _ := a.Pop(); got != w {
+			t.Errorf("期望%d，实际为%d", w, got)
+		}
+	}
+}
+
+// TestPairingHeapRandom 随机操作与排序结果对比
+func TestPairingHeapRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	h := NewPairingHeap(intCmp)
+	elems := make([]*Element[int], 0)
+	for i := 0; i < 1000; i++ {
+		elems = append(elems, h.Push(r.Intn(10000)))
+	}
+	for i := 0; i < 200; i++ {
+		e := elems[r.Intn(len(elems))]
+		_ = h.DecreaseKey(e, e.Value-r.Intn(100))
+	}
+
+	expected := make([]int, 0, len(elems))
+	for _, e := range elems {
+		expected = append(expected, e.Value)
+	}
+	sort.Ints(expected)
+
+	for i, want := range expected {
+		got, ok := h.Pop()
+		if !ok || got != want {
+			t.Fatalf("第%d次弹出期望%d，实际为%d", i, want, got)
+		}
+	}
+}
+
+// 性能测试
+func BenchmarkPairingHeap(b *testing.B) {
+	h := NewPairingHeap(intCmp)
+
+	b.Run("插入", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			h.Push(i)
+		}
+	})
+
+	b.Run("弹出", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			h.Pop()
+		}
+	})
+}
+
+// TestPairingHeapClear 测试清空配对堆后句柄失效
+func TestPairingHeapClear(t *testing.T) {
+	h := NewPairingHeap(intCmp)
+	handles := make([]*Element[int], 0, 10)
+	for i := 0; i < 10; i++ {
+		handles = append(handles, h.Push(i))
+	}
+	h.Pop()
+	h.Clear()
+	if !h.IsEmpty() || h.Size() != 0 {
+		t.Errorf("清空后应为空，实际大小为%d", h.Size())
+	}
+	for _, handle := range handles {
+		if err := h.DecreaseKey(handle, -1); err != ErrInvalidElement {
+			t.Fatalf("清空后旧句柄应失效，实际返回%v", err)
+		}
+	}
+	h.Push(1)
+	if v, _ := h.Peek(); v != 1 || h.Size() != 1 {
+		t.Error("清空后应可以继续使用")
+	}
+}
+
+// TestPairingHeapClone 测试配对堆的深拷贝
+func TestPairingHeapClone(t *testing.T) {
+	h := NewPairingHeap(intCmp)
+	handles := make([]*Element[int], 0, 20)
+	for i := 0; i < 20; i++ {
+		handles = append(handles, h.Push((i*7)%20))
+	}
+	// 弹出一次使堆形成多层结构
+	h.Pop()
+	clone := h.Clone(func(v int) int { return v + 100 })
+	if err := h.DecreaseKey(handles[5], -1); err != nil {
+		t.Fatal(err)
+	}
+
+	var got []int
+	for !clone.IsEmpty() {
+		v, _ := clone.Pop()
+		got = append(got, v)
+	}
+	want := make([]int, 0, 19)
+	for i := 1; i < 20; i++ {
+		want = append(want, i+100)
+	}
+	if !sort.IntsAreSorted(got) || len(got) != 19 || got[0] != want[0] || got[18] != want[18] {
+		t.Errorf("拷贝的弹出顺序不正确: %v", got)
+	}
+	if v, _ := h.Peek(); v != -1 || h.Size() != 19 {
+		t.Error("清空拷贝不应影响原堆")
+	}
+}
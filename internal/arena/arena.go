@@ -0,0 +1,84 @@
+// Package arena 为基于节点的容器提供按块分配、空闲链表回收的对象池
+// 容器通过各自包中的 WithArena 选项启用，未启用时使用值为 nil 的 *Arena，
+// 其 Alloc 退化为 new(T)，Free 不做任何事，因此容器代码不需要区分两种情况
+package arena
+
+// DefaultSlabSize 未指定块大小时每块包含的对象数量
+const DefaultSlabSize = 64
+
+// Arena T 类型对象的分配器，非并发安全
+// 对象从预先分配的块中依次切出，释放的对象被清零后放入空闲链表，下次分配时优先复用。
+// 只要块中还有一个对象被引用，整个块就不会被垃圾回收，
+// 因此适合节点频繁创建和删除、但总量相对稳定的场景
+type Arena[T any] struct {
+	slabSize int  // 每块包含的对象数量
+	slab     []T  // 当前块中尚未分配的对象
+	free     []*T // 已释放、可以复用的对象
+	slabs    int  // 已分配的块数量
+}
+
+// New 创建分配器
+// 参数：
+//   - slabSize: 每块包含的对象数量，小于1时使用 DefaultSlabSize
+//
+// 时间复杂度: O(1)
+func New[T any](slabSize int) *Arena[T] {
+	if slabSize < 1 {
+		slabSize = DefaultSlabSize
+	}
+	return &Arena[T]{slabSize: slabSize}
+}
+
+// Alloc 返回一个零值对象，优先复用已释放的对象
+// a 为 nil 时等同于 new(T)
+// 时间复杂度: 均摊 O(1)
+func (a *Arena[T]) Alloc() *T {
+	if a == nil {
+		return new(T)
+	}
+	if n := len(a.free); n > 0 {
+		p := a.free[n-1]
+		a.free[n-1] = nil
+		a.free = a.free[:n-1]
+		return p
+	}
+	if len(a.slab) == 0 {
+		a.slab = make([]T, a.slabSize)
+		a.slabs++
+	}
+	p := &a.slab[0]
+	a.slab = a.slab[1:]
+	return p
+}
+
+// Free 清零对象并放入空闲链表，调用后不能再通过任何指针访问该对象
+// a 或 p 为 nil 时不做任何事
+// 时间复杂度: O(1)
+func (a *Arena[T]) Free(p *T) {
+	if a == nil || p == nil {
+		return
+	}
+	var zero T
+	*p = zero
+	a.free = append(a.free, p)
+}
+
+// Reset 丢弃空闲链表和当前块，之后分配的对象都来自新的块
+// 已分配的对象仍然有效，不再被引用的块由垃圾回收器回收
+// 时间复杂度: O(1)
+func (a *Arena[T]) Reset() {
+	if a == nil {
+		return
+	}
+	a.slab = nil
+	a.free = nil
+}
+
+// Stats 返回已分配的块数量和空闲链表中的对象数量
+// 时间复杂度: O(1)
+func (a *Arena[T]) Stats() (slabs, free int) {
+	if a == nil {
+		return 0, 0
+	}
+	return a.slabs, len(a.free)
+}
@@ -0,0 +1,99 @@
+package arena
+
+import "testing"
+
+type node struct {
+	value int
+	next  *node
+}
+
+// TestAlloc 测试按块分配与复用释放的对象
+func TestAlloc(t *testing.T) {
+	a := New[node](4)
+	var nodes []*node
+	for i := range 10 {
+		n := a.Alloc()
+		if n.value != 0 || n.next != nil {
+			t.Fatal("分配的对象应为零值")
+		}
+		n.value = i
+		nodes = append(nodes, n)
+	}
+	if slabs, free := a.Stats(); slabs != 3 || free != 0 {
+		t.Errorf("期望3个块、0个空闲对象，实际为%d、%d", slabs, free)
+	}
+
+	a.Free(nodes[3])
+	a.Free(nil)
+	if nodes[3].value != 0 {
+		t.Error("释放的对象应被清零")
+	}
+	if _, free := a.Stats(); free != 1 {
+		t.Errorf("期望1个空闲对象，实际为%d", free)
+	}
+	if p := a.Alloc(); p != nodes[3] {
+		t.Error("应优先复用释放的对象")
+	}
+	for i, n := range nodes {
+		if i != 3 && n.value != i {
+			t.Errorf("分配新对象不应影响已分配的对象，第%d个为%d", i, n.value)
+		}
+	}
+
+	a.Free(nodes[0])
+	a.Reset()
+	if _, free := a.Stats(); free != 0 {
+		t.Error("重置后空闲链表应为空")
+	}
+	if p := a.Alloc(); p == nodes[0] {
+		t.Error("重置后不应复用之前释放的对象")
+	}
+}
+
+// TestNilArena 测试值为 nil 的分配器退化为普通分配
+func TestNilArena(t *testing.T) {
+	var a *Arena[node]
+	p := a.Alloc()
+	if p == nil || a.Alloc() == p {
+		t.Error("nil 分配器应每次返回新的对象")
+	}
+	p.value = 1
+	a.Free(p)
+	if p.value != 1 {
+		t.Error("nil 分配器释放对象时不应修改对象")
+	}
+	a.Reset()
+	if slabs, free := a.Stats(); slabs != 0 || free != 0 {
+		t.Error("nil 分配器的统计应为0")
+	}
+	if New[node](0).slabSize != DefaultSlabSize {
+		t.Error("块大小小于1时应使用默认值")
+	}
+}
+
+var sink *node
+
+// BenchmarkAlloc 比较直接分配与使用分配器分配、释放对象的开销
+func BenchmarkAlloc(b *testing.B) {
+	b.Run("new", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			sink = new(node)
+		}
+	})
+	b.Run("arena", func(b *testing.B) {
+		b.ReportAllocs()
+		a := New[node](DefaultSlabSize)
+		for range b.N {
+			sink = a.Alloc()
+		}
+	})
+	b.Run("arena复用", func(b *testing.B) {
+		b.ReportAllocs()
+		a := New[node](DefaultSlabSize)
+		for range b.N {
+			sink = a.Alloc()
+			a.Free(sink)
+		}
+	})
+}
@@ -0,0 +1,74 @@
+package iterator
+
+import "iter"
+
+// Iterable 可以按顺序遍历所有元素的容器
+// 遍历顺序由具体容器决定，例如链表按插入位置、有序树按升序
+type Iterable[T any] interface {
+	All() iter.Seq[T]
+}
+
+// Iterable2 可以按顺序遍历所有键值对的容器
+type Iterable2[K, V any] interface {
+	All() iter.Seq2[K, V]
+}
+
+// Iterator 经典的拉取式迭代器
+// 使用方式：
+//
+//	for it.Next() {
+//		v := it.Value()
+//	}
+type Iterator[T any] interface {
+	Next() bool // 前进到下一个元素，没有更多元素时返回 false
+	Value() T   // 返回当前元素，必须在 Next 返回 true 之后调用
+}
+
+// pullIterator 基于 iter.Pull 的迭代器
+type pullIterator[T any] struct {
+	next  func() (T, bool)
+	value T
+}
+
+// FromSeq 将 iter.Seq 转换为拉取式迭代器
+// 返回的 stop 函数用于提前结束迭代并释放资源，与 iter.Pull 相同，
+// 未遍历完所有元素时必须调用 stop；stop 可以重复调用
+// 时间复杂度: O(1)
+func FromSeq[T any](seq iter.Seq[T]) (Iterator[T], func()) {
+	next, stop := iter.Pull(seq)
+	return &pullIterator[T]{next: next}, stop
+}
+
+// Next 前进到下一个元素
+func (it *pullIterator[T]) Next() bool {
+	var ok bool
+	it.value, ok = it.next()
+	return ok
+}
+
+// Value 返回当前元素
+func (it *pullIterator[T]) Value() T {
+	return it.value
+}
+
+// ToSeq 将拉取式迭代器转换为 iter.Seq，迭代器只能被消费一次
+// 时间复杂度: O(1)
+func ToSeq[T any](it Iterator[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for it.Next() {
+			if !yield(it.Value()) {
+				return
+			}
+		}
+	}
+}
+
+// Collect 按遍历顺序将容器中的所有元素收集到切片中
+// 时间复杂度: O(n)
+func Collect[T any](it Iterable[T]) []T {
+	result := make([]T, 0)
+	for v := range it.All() {
+		result = append(result, v)
+	}
+	return result
+}
@@ -0,0 +1,139 @@
+package iterator
+
+import (
+	"slices"
+	"testing"
+
+	dynamicarray "godatastructure/array"
+	"godatastructure/binarytree"
+	"godatastructure/bplustree"
+	"godatastructure/btree"
+	"godatastructure/hashtable"
+	"godatastructure/list"
+	"godatastructure/persistent"
+	"godatastructure/queue"
+	"godatastructure/rbtree"
+	"godatastructure/rtree"
+	"godatastructure/set"
+	"godatastructure/stack"
+	"godatastructure/treap"
+	"godatastructure/trie"
+)
+
+func intCmp(a, b int) int {
+	return a - b
+}
+
+// 编译期检查各容器实现了统一的迭代接口
+var (
+	_ Iterable[int] = list.New[int]()
+	_ Iterable[int] = list.NewSkipList(intCmp)
+	_ Iterable[int] = stack.New[int]()
+	_ Iterable[int] = queue.NewDefaultQueue[int]()
+	_ Iterable[int] = queue.NewDeque[int]()
+	_ Iterable[int] = dynamicarray.New[int]()
+	_ Iterable[int] = binarytree.New(intCmp)
+	_ Iterable[int] = binarytree.NewSplay(intCmp)
+	_ Iterable[int] = binarytree.NewAVL(intCmp)
+	_ Iterable[int] = binarytree.NewTreap(intCmp)
+	_ Iterable[int] = rbtree.NewTree[int]()
+	_ Iterable[int] = treap.New(intCmp)
+	_ Iterable[int] = set.New[int]()
+	_ Iterable[int] = set.NewTreeSet[int]()
+	_ Iterable[int] = persistent.NewVector[int]()
+
+	_ Iterable2[string, int]     = hashtable.New[string, int](16)
+	_ Iterable2[int, string]     = bplustree.NewBPlusTree[int, string](3)
+	_ Iterable2[int, string]     = btree.NewBTree[int, string](2)
+	_ Iterable2[rtree.Rect, int] = rtree.NewRTree[int](4)
+	_ Iterable2[string, int]     = trie.New[int]()
+	_ Iterable2[string, int]     = trie.NewRadixTree[int]()
+)
+
+// TestFromSeq 测试将 iter.Seq 转换为拉取式迭代器
+func TestFromSeq(t *testing.T) {
+	l := list.New[int]()
+	for i := 1; i <= 5; i++ {
+		l.Append(i)
+	}
+
+	it, stop := FromSeq(l.All())
+	defer stop()
+	var got []int
+	for it.Next() {
+		got = append(got, it.Value())
+	}
+	if !slices.Equal(got, []int{1, 2, 3, 4, 5}) {
+		t.Errorf("迭代结果错误: %v", got)
+	}
+	if it.Next() {
+		t.Error("迭代结束后Next应返回false")
+	}
+}
+
+// TestFromSeqStop 测试提前结束迭代
+func TestFromSeqStop(t *testing.T) {
+	tree := rbtree.NewTree[int]()
+	for _, v := range []int{5, 3, 8, 1} {
+		tree.Insert(v)
+	}
+	it, stop := FromSeq(tree.All())
+	if !it.Next() || it.Value() != 1 {
+		t.Fatal("第一个元素应为1")
+	}
+	stop()
+	stop()
+	if it.Next() {
+		t.Error("stop之后Next应返回false")
+	}
+}
+
+// TestToSeq 测试将拉取式迭代器转换回 iter.Seq
+func TestToSeq(t *testing.T) {
+	s := stack.New[int]()
+	for i := 1; i <= 4; i++ {
+		s.Push(i)
+	}
+	it, stop := FromSeq(s.All())
+	defer stop()
+
+	var got []int
+	for v := range ToSeq(it) {
+		got = append(got, v)
+		if len(got) == 2 {
+			break
+		}
+	}
+	if !slices.Equal(got, []int{4, 3}) {
+		t.Errorf("迭代结果错误: %v", got)
+	}
+	// 迭代器从中断处继续
+	if !it.Next() || it.Value() != 2 {
+		t.Error("迭代器应从中断处继续")
+	}
+}
+
+// TestCollect 测试通过统一接口收集不同容器的元素
+func TestCollect(t *testing.T) {
+	q := queue.NewDefaultQueue[int]()
+	tree := binarytree.New(intCmp)
+	for _, v := range []int{3, 1, 2} {
+		q.Offer(v)
+		tree.Insert(v)
+	}
+	cases := map[string]struct {
+		container Iterable[int]
+		want      []int
+	}{
+		"队列":  {q, []int{3, 1, 2}},
+		"二叉树": {tree, []int{1, 2, 3}},
+		"空栈":  {stack.New[int](), []int{}},
+	}
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := Collect(c.container); !slices.Equal(got, c.want) {
+				t.Errorf("期望 %v，实际为 %v", c.want, got)
+			}
+		})
+	}
+}
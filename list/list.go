@@ -1,5 +1,12 @@
 package list
 
+import (
+	"iter"
+
+	"godatastructure/codec"
+	"godatastructure/internal/arena"
+)
+
 // Node 链表节点定义
 // 类型参数 T 必须是可比较的类型
 type Node[T comparable] struct {
@@ -10,37 +17,83 @@ type Node[T comparable] struct {
 // LinkedList 链表接口
 // 定义了单链表支持的所有操作
 type LinkedList[T comparable] interface {
-	Append(value T)               // 在链表末尾添加节点
-	Prepend(value T)              // 在链表头部添加节点
-	Insert(index int, value T)    // 在指定位置插入节点
-	Remove(value T) bool          // 删除指定值的节点
-	RemoveAt(index int) (T, bool) // 删除指定位置的节点
-	Find(value T) *Node[T]        // 查找指定值的节点
-	Get(index int) (T, bool)      // 获取指定位置的值
-	Set(index int, value T) bool  // 设置指定位置的值
-	IsEmpty() bool                // 检查链表是否为空
-	Size() int                    // 获取链表长度
-	Clear()                       // 清空链表
-	ToSlice() []T                 // 将链表转换为切片
+	Append(value T)                       // 在链表末尾添加节点
+	Prepend(value T)                      // 在链表头部添加节点
+	Insert(index int, value T)            // 在指定位置插入节点
+	Remove(value T) bool                  // 删除指定值的节点
+	RemoveAt(index int) (T, bool)         // 删除指定位置的节点
+	Find(value T) *Node[T]                // 查找指定值的节点
+	Get(index int) (T, bool)              // 获取指定位置的值
+	Set(index int, value T) bool          // 设置指定位置的值
+	IsEmpty() bool                        // 检查链表是否为空
+	Size() int                            // 获取链表长度
+	Clear()                               // 清空链表
+	ToSlice() []T                         // 将链表转换为切片
+	All() iter.Seq[T]                     // 返回从头到尾遍历链表的迭代器
+	codec.Marshaler                       // 二进制与 JSON 序列化，按从头到尾的顺序编码
+	Clone(copier func(T) T) LinkedList[T] // 返回深拷贝，copier 为 nil 时直接复制元素
 }
 
 // linkedList 链表实现
 type linkedList[T comparable] struct {
-	head *Node[T] // 头节点指针
-	tail *Node[T] // 尾节点指针
-	size int      // 链表大小
+	head  *Node[T]              // 头节点指针
+	tail  *Node[T]              // 尾节点指针
+	size  int                   // 链表大小
+	nodes *arena.Arena[Node[T]] // 节点分配器，为 nil 时直接分配
+}
+
+// Option 链表和跳表的可选配置
+type Option func(*options)
+
+// options 链表和跳表的配置项
+type options struct {
+	arena    bool // 是否使用节点分配器
+	slabSize int  // 分配器每块包含的节点数量
+}
+
+// WithArena 使链表或跳表从按块分配的对象池中分配节点，并在删除节点和 Clear 时回收复用，
+// 以减少频繁插入删除时的垃圾回收压力。slabSize 为每块包含的节点数量，小于1时使用默认值。
+// 启用后，Find 返回的节点在被删除后可能被复用，不应再访问；Clone 得到的副本不会继承分配器
+func WithArena(slabSize int) Option {
+	return func(o *options) {
+		o.arena = true
+		o.slabSize = slabSize
+	}
+}
+
+// newOptions 依次应用配置
+func newOptions(opts []Option) options {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
 }
 
 // New 创建新的链表
+// 参数：
+//   - opts: 可选配置，例如 WithArena
+//
 // 时间复杂度: O(1)
-func New[T comparable]() LinkedList[T] {
-	return &linkedList[T]{}
+func New[T comparable](opts ...Option) LinkedList[T] {
+	l := &linkedList[T]{}
+	if o := newOptions(opts); o.arena {
+		l.nodes = arena.New[Node[T]](o.slabSize)
+	}
+	return l
+}
+
+// newNode 分配值为 value 的节点
+func (l *linkedList[T]) newNode(value T) *Node[T] {
+	n := l.nodes.Alloc()
+	n.Value = value
+	return n
 }
 
 // Append 在链表末尾添加节点
 // 时间复杂度: O(1) - 由于维护了tail指针
 func (l *linkedList[T]) Append(value T) {
-	newNode := &Node[T]{Value: value}
+	newNode := l.newNode(value)
 	if l.head == nil {
 		// 空链表情况
 		l.head = newNode
@@ -56,7 +109,7 @@ func (l *linkedList[T]) Append(value T) {
 // Prepend 在链表头部添加节点
 // 时间复杂度: O(1)
 func (l *linkedList[T]) Prepend(value T) {
-	newNode := &Node[T]{Value: value}
+	newNode := l.newNode(value)
 	if l.head == nil {
 		l.head = newNode
 		l.tail = newNode
@@ -86,7 +139,7 @@ func (l *linkedList[T]) Insert(index int, value T) {
 		return
 	}
 
-	newNode := &Node[T]{Value: value}
+	newNode := l.newNode(value)
 	prevNode := l.getNodeAt(index - 1)
 	newNode.Next = prevNode.Next
 	prevNode.Next = newNode
@@ -103,11 +156,13 @@ func (l *linkedList[T]) Remove(value T) bool {
 
 	// 处理头节点的特殊情况
 	if l.head.Value == value {
+		removed := l.head
 		l.head = l.head.Next
 		if l.head == nil {
 			l.tail = nil
 		}
 		l.size--
+		l.nodes.Free(removed)
 		return true
 	}
 
@@ -121,6 +176,7 @@ func (l *linkedList[T]) Remove(value T) bool {
 				l.tail = prev
 			}
 			l.size--
+			l.nodes.Free(current)
 			return true
 		}
 		prev = current
@@ -150,7 +206,9 @@ func (l *linkedList[T]) RemoveAt(index int) (T, bool) {
 		}
 	}
 	l.size--
-	return removedNode.Value, true
+	value := removedNode.Value
+	l.nodes.Free(removedNode)
+	return value, true
 }
 func (l *linkedList[T]) Find(value T) *Node[T] {
 	current := l.head
@@ -192,6 +250,13 @@ func (l *linkedList[T]) Size() int {
 	return l.size
 }
 func (l *linkedList[T]) Clear() {
+	if l.nodes != nil {
+		for n := l.head; n != nil; {
+			next := n.Next
+			l.nodes.Free(n)
+			n = next
+		}
+	}
 	l.head = nil
 	l.tail = nil
 	l.size = 0
@@ -205,3 +270,71 @@ func (l *linkedList[T]) ToSlice() []T {
 	}
 	return slice
 }
+
+// All 返回从头到尾遍历链表的迭代器
+// 遍历过程中不应修改链表
+func (l *linkedList[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for current := l.head; current != nil; current = current.Next {
+			if !yield(current.Value) {
+				return
+			}
+		}
+	}
+}
+
+// MarshalBinary 使用 codec.For[T] 返回的元素编解码器把链表编码为二进制，按从头到尾的顺序编码
+// 实现 encoding.BinaryMarshaler 接口
+func (l *linkedList[T]) MarshalBinary() ([]byte, error) {
+	return codec.EncodeSeq(l.All(), codec.For[T]())
+}
+
+// UnmarshalBinary 从 MarshalBinary 生成的数据中解码，替换链表中的所有元素
+// 解码失败时链表保持不变，实现 encoding.BinaryUnmarshaler 接口
+func (l *linkedList[T]) UnmarshalBinary(data []byte) error {
+	values, err := codec.DecodeSeq(data, codec.For[T]())
+	if err != nil {
+		return err
+	}
+	l.load(values)
+	return nil
+}
+
+// MarshalJSON 把链表编码为 JSON 数组，实现 json.Marshaler 接口
+func (l *linkedList[T]) MarshalJSON() ([]byte, error) {
+	return codec.MarshalJSONSeq(l.All())
+}
+
+// UnmarshalJSON 从 JSON 数组中解码，替换链表中的所有元素
+// 解码失败时链表保持不变，实现 json.Unmarshaler 接口
+func (l *linkedList[T]) UnmarshalJSON(data []byte) error {
+	values, err := codec.UnmarshalJSONSeq[T](data)
+	if err != nil {
+		return err
+	}
+	l.load(values)
+	return nil
+}
+
+// load 清空链表后按顺序放入 values 中的元素
+func (l *linkedList[T]) load(values []T) {
+	l.Clear()
+	for _, v := range values {
+		l.Append(v)
+	}
+}
+
+// Clone 返回链表的深拷贝
+// copier 用于复制每个元素，为 nil 时直接赋值
+// 时间复杂度: O(n)
+func (l *linkedList[T]) Clone(copier func(T) T) LinkedList[T] {
+	clone := &linkedList[T]{}
+	for current := l.head; current != nil; current = current.Next {
+		value := current.Value
+		if copier != nil {
+			value = copier(value)
+		}
+		clone.Append(value)
+	}
+	return clone
+}
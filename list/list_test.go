@@ -1,6 +1,8 @@
 package list
 
 import (
+	"encoding/json"
+	"slices"
 	"testing"
 )
 
@@ -348,3 +350,147 @@ func TestDifferentTypes(t *testing.T) {
 		}
 	})
 }
+
+// TestAll 测试迭代器
+func TestAll(t *testing.T) {
+	l := New[int]()
+	for i := 1; i <= 5; i++ {
+		l.Append(i)
+	}
+	var got []int
+	for v := range l.All() {
+		got = append(got, v)
+		if v == 3 {
+			break
+		}
+	}
+	if !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("迭代结果错误: %v", got)
+	}
+}
+
+// TestMarshal 测试链表的二进制与 JSON 序列化
+func TestMarshal(t *testing.T) {
+	src := New[int]()
+	for _, v := range []int{3, 1, 2} {
+		src.Append(v)
+	}
+	want := slices.Collect(src.All())
+
+	t.Run("二进制", func(t *testing.T) {
+		data, err := src.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		dst := New[int]()
+		if err := dst.UnmarshalBinary(data); err != nil {
+			t.Fatal(err)
+		}
+		if got := slices.Collect(dst.All()); !slices.Equal(got, want) {
+			t.Errorf("期望%v，实际为%v", want, got)
+		}
+		if err := dst.UnmarshalBinary(data[:len(data)-1]); err == nil {
+			t.Error("截断的数据应返回错误")
+		}
+		if dst.Size() != len(want) {
+			t.Error("解码失败时应保持不变")
+		}
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		data, err := json.Marshal(src)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != `[3,1,2]` {
+			t.Errorf("JSON 为%s", data)
+		}
+		dst := New[int]()
+		if err := json.Unmarshal(data, dst); err != nil {
+			t.Fatal(err)
+		}
+		if got := slices.Collect(dst.All()); !slices.Equal(got, want) {
+			t.Errorf("期望%v，实际为%v", want, got)
+		}
+	})
+}
+
+// TestClone 测试链表的深拷贝
+func TestClone(t *testing.T) {
+	a, b, c := 1, 2, 3
+	l := New[*int]()
+	l.Append(&a)
+	l.Append(&b)
+	l.Append(&c)
+
+	deep := l.Clone(func(p *int) *int {
+		v := *p
+		return &v
+	})
+	shallow := l.Clone(nil)
+	a = 100
+	l.Append(&a)
+
+	if deep.Size() != 3 || shallow.Size() != 3 {
+		t.Fatalf("拷贝的大小应为3，实际为%d和%d", deep.Size(), shallow.Size())
+	}
+	if v, _ := deep.Get(0); *v != 1 {
+		t.Errorf("深拷贝不应受原元素修改的影响，实际为%d", *v)
+	}
+	if v, _ := shallow.Get(0); v != &a {
+		t.Error("copier 为 nil 时应直接复制元素")
+	}
+	deep.Append(&c)
+	if deep.Size() != 4 || l.Size() != 4 {
+		t.Error("修改拷贝不应影响原链表")
+	}
+}
+
+// TestWithArena 测试使用节点分配器的链表在删除和清空时回收节点
+func TestWithArena(t *testing.T) {
+	l := New[int](WithArena(4))
+	for i := 0; i < 10; i++ {
+		l.Append(i)
+	}
+	l.Remove(0)
+	l.Remove(5)
+	l.RemoveAt(0)
+	nodes := l.(*linkedList[int]).nodes
+	if _, free := nodes.Stats(); free != 3 {
+		t.Errorf("期望回收3个节点，实际为%d", free)
+	}
+
+	l.Prepend(100)
+	l.Insert(1, 200)
+	if got := l.ToSlice(); !slices.Equal(got, []int{100, 200, 2, 3, 4, 6, 7, 8, 9}) {
+		t.Errorf("复用节点后链表内容错误: %v", got)
+	}
+	if _, free := nodes.Stats(); free != 1 {
+		t.Errorf("插入应复用回收的节点，剩余%d个", free)
+	}
+
+	l.Clear()
+	if _, free := nodes.Stats(); free != 10 {
+		t.Errorf("清空后应回收全部节点，实际为%d", free)
+	}
+	l.Append(1)
+	if got := l.ToSlice(); !slices.Equal(got, []int{1}) || l.Size() != 1 {
+		t.Errorf("清空后链表内容错误: %v", got)
+	}
+}
+
+// BenchmarkLinkedListChurn 比较启用与不启用节点分配器时反复插入删除的开销
+func BenchmarkLinkedListChurn(b *testing.B) {
+	for name, opts := range map[string][]Option{"默认": nil, "分配器": {WithArena(0)}} {
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+			l := New[int](opts...)
+			for i := 0; i < b.N; i++ {
+				l.Append(i)
+				if l.Size() > 64 {
+					l.RemoveAt(0)
+				}
+			}
+		})
+	}
+}
@@ -1,8 +1,13 @@
 package list
 
 import (
+	"fmt"
+	"iter"
 	"math/rand"
 	"time"
+
+	"godatastructure/codec"
+	"godatastructure/internal/arena"
 )
 
 // 跳表实现
@@ -19,19 +24,29 @@ type node[T any] struct {
 
 // SkipList 跳表结构
 type SkipList[T any] struct {
-	header *node[T]         // 头节点（哨兵节点）
-	level  int              // 当前最大层数
-	cmp    func(a, b T) int // 比较函数
-	rand   *rand.Rand       // 随机数生成器
+	header *node[T]              // 头节点（哨兵节点）
+	level  int                   // 当前最大层数
+	size   int                   // 元素数量
+	cmp    func(a, b T) int      // 比较函数
+	rand   *rand.Rand            // 随机数生成器
+	nodes  *arena.Arena[node[T]] // 节点分配器，为 nil 时直接分配
 }
 
-func NewSkipList[T any](cmp func(a, b T) int) *SkipList[T] {
-	return &SkipList[T]{
+// NewSkipList 创建空的跳表
+// 参数：
+//   - cmp: 比较函数
+//   - opts: 可选配置，例如 WithArena。启用分配器后，Search 返回的指针在元素被删除后不应再访问
+func NewSkipList[T any](cmp func(a, b T) int, opts ...Option) *SkipList[T] {
+	s := &SkipList[T]{
 		header: &node[T]{next: make([]*node[T], MaxLevel)},
 		level:  1,
 		cmp:    cmp,
 		rand:   rand.New(rand.NewSource(time.Now().UnixNano())),
 	}
+	if o := newOptions(opts); o.arena {
+		s.nodes = arena.New[node[T]](o.slabSize)
+	}
+	return s
 }
 
 func (s *SkipList[T]) randomLevel() int {
@@ -61,11 +76,14 @@ func (s *SkipList[T]) Insert(value T) {
 		s.level = level
 	}
 
-	newNode := &node[T]{value: value, next: make([]*node[T], level)}
+	newNode := s.nodes.Alloc()
+	newNode.value = value
+	newNode.next = make([]*node[T], level)
 	for i := 0; i < level; i++ {
 		newNode.next[i] = update[i].next[i]
 		update[i].next[i] = newNode
 	}
+	s.size++
 }
 
 func (s *SkipList[T]) Search(value T) *T {
@@ -97,6 +115,7 @@ func (s *SkipList[T]) Delete(value T) bool {
 	current = current.next[0]
 	if current != nil && s.cmp(current.value, value) == 0 {
 		found = true
+		s.size--
 		for i := 0; i < s.level; i++ {
 			if update[i].next[i] != current {
 				break
@@ -106,6 +125,151 @@ func (s *SkipList[T]) Delete(value T) bool {
 		for s.level > 1 && s.header.next[s.level-1] == nil {
 			s.level--
 		}
+		s.nodes.Free(current)
 	}
 	return found
 }
+
+// Size 返回元素数量
+// 时间复杂度: O(1)
+func (s *SkipList[T]) Size() int {
+	return s.size
+}
+
+// IsEmpty 检查跳表是否为空
+// 时间复杂度: O(1)
+func (s *SkipList[T]) IsEmpty() bool {
+	return s.size == 0
+}
+
+// Clear 清空跳表
+// 时间复杂度: O(1)，启用分配器时为 O(n)
+func (s *SkipList[T]) Clear() {
+	if s.nodes != nil {
+		for n := s.header.next[0]; n != nil; {
+			next := n.next[0]
+			s.nodes.Free(n)
+			n = next
+		}
+	}
+	s.header = &node[T]{next: make([]*node[T], MaxLevel)}
+	s.level = 1
+	s.size = 0
+}
+
+// Compare 使用跳表的比较函数比较两个元素
+func (s *SkipList[T]) Compare(a, b T) int {
+	return s.cmp(a, b)
+}
+
+// All 返回按升序遍历跳表的迭代器
+// 遍历过程中不应修改跳表
+func (s *SkipList[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for current := s.header.next[0]; current != nil; current = current.next[0] {
+			if !yield(current.value) {
+				return
+			}
+		}
+	}
+}
+
+// MarshalBinary 使用 codec.For[T] 返回的元素编解码器把跳表编码为二进制，按升序编码
+// 实现 encoding.BinaryMarshaler 接口
+func (s *SkipList[T]) MarshalBinary() ([]byte, error) {
+	return codec.EncodeSeq(s.All(), codec.For[T]())
+}
+
+// UnmarshalBinary 从 MarshalBinary 生成的数据中解码，替换跳表中的所有元素
+// 解码失败时跳表保持不变，实现 encoding.BinaryUnmarshaler 接口
+// 需要在通过 NewSkipList 创建的跳表上调用，元素按跳表的比较函数重新排序
+func (s *SkipList[T]) UnmarshalBinary(data []byte) error {
+	values, err := codec.DecodeSeq(data, codec.For[T]())
+	if err != nil {
+		return err
+	}
+	s.load(values)
+	return nil
+}
+
+// MarshalJSON 把跳表编码为 JSON 数组，实现 json.Marshaler 接口
+func (s *SkipList[T]) MarshalJSON() ([]byte, error) {
+	return codec.MarshalJSONSeq(s.All())
+}
+
+// UnmarshalJSON 从 JSON 数组中解码，替换跳表中的所有元素
+// 解码失败时跳表保持不变，实现 json.Unmarshaler 接口
+// 需要在通过 NewSkipList 创建的跳表上调用
+func (s *SkipList[T]) UnmarshalJSON(data []byte) error {
+	values, err := codec.UnmarshalJSONSeq[T](data)
+	if err != nil {
+		return err
+	}
+	s.load(values)
+	return nil
+}
+
+// load 清空跳表后按顺序放入 values 中的元素
+func (s *SkipList[T]) load(values []T) {
+	s.Clear()
+	for _, v := range values {
+		s.Insert(v)
+	}
+}
+
+// Clone 返回跳表的深拷贝，新跳表保持与原跳表相同的层级结构
+// copier 用于复制每个元素，为 nil 时直接赋值；复制后的元素应与原元素保持相同的顺序
+// 时间复杂度: O(n)
+func (s *SkipList[T]) Clone(copier func(T) T) *SkipList[T] {
+	clone := NewSkipList(s.cmp)
+	clone.level = s.level
+	clone.size = s.size
+	// 先复制所有节点，再按原跳表的指针关系连接各层
+	nodes := map[*node[T]]*node[T]{s.header: clone.header}
+	for current := s.header.next[0]; current != nil; current = current.next[0] {
+		value := current.value
+		if copier != nil {
+			value = copier(value)
+		}
+		nodes[current] = &node[T]{value: value, next: make([]*node[T], len(current.next))}
+	}
+	for old, n := range nodes {
+		for i, next := range old.next {
+			if next != nil {
+				n.next[i] = nodes[next]
+			}
+		}
+	}
+	return clone
+}
+
+// Validate 检查跳表的结构不变量，发现损坏时返回描述问题的错误
+// 检查内容：每一层都按比较函数非递减有序，第 i 层上的节点至少有 i+1 层指针，
+// 高于当前层数的头节点指针为空，第0层的节点数等于元素数量
+// 时间复杂度: O(n)
+func (s *SkipList[T]) Validate() error {
+	if s.level < 1 || s.level > MaxLevel {
+		return fmt.Errorf("跳表层数%d超出范围[1, %d]", s.level, MaxLevel)
+	}
+	for i := s.level; i < MaxLevel; i++ {
+		if s.header.next[i] != nil {
+			return fmt.Errorf("第%d层高于当前层数%d，但头节点指针不为空", i, s.level)
+		}
+	}
+	for i := 0; i < s.level; i++ {
+		count := 0
+		for n := s.header.next[i]; n != nil; n = n.next[i] {
+			if len(n.next) <= i {
+				return fmt.Errorf("第%d层上的节点只有%d层指针", i, len(n.next))
+			}
+			if next := n.next[i]; next != nil && s.cmp(n.value, next.value) > 0 {
+				return fmt.Errorf("第%d层无序: %v 位于 %v 之前", i, n.value, next.value)
+			}
+			count++
+		}
+		if i == 0 && count != s.size {
+			return fmt.Errorf("第0层有%d个节点，但记录的元素数量为%d", count, s.size)
+		}
+	}
+	return nil
+}
@@ -1,7 +1,9 @@
 package list
 
 import (
+	"encoding/json"
 	"math/rand"
+	"slices"
 	"testing"
 	"time"
 )
@@ -280,3 +282,201 @@ func TestSkipListOrderMaintenance(t *testing.T) {
 		current = current.next[0]
 	}
 }
+
+// TestSkipListAll 测试按升序遍历
+func TestSkipListAll(t *testing.T) {
+	sl := NewSkipList(intCmp)
+	for _, v := range []int{5, 1, 4, 2, 3} {
+		sl.Insert(v)
+	}
+	var got []int
+	for v := range sl.All() {
+		got = append(got, v)
+	}
+	if !slices.Equal(got, []int{1, 2, 3, 4, 5}) {
+		t.Errorf("迭代结果错误: %v", got)
+	}
+}
+
+// TestSkipListSizeClear 测试跳表的元素计数与清空
+func TestSkipListSizeClear(t *testing.T) {
+	s := NewSkipList(intCmp)
+	if !s.IsEmpty() || s.Size() != 0 {
+		t.Fatal("新建的跳表应为空")
+	}
+	for _, v := range []int{5, 3, 8, 3} {
+		s.Insert(v)
+	}
+	if s.Size() != 4 {
+		t.Errorf("期望大小为4，实际为%d", s.Size())
+	}
+	s.Delete(8)
+	s.Delete(100)
+	if s.Size() != 3 {
+		t.Errorf("删除后期望大小为3，实际为%d", s.Size())
+	}
+	if s.Compare(1, 2) >= 0 {
+		t.Error("Compare(1, 2) 应返回负数")
+	}
+	s.Clear()
+	if !s.IsEmpty() || s.Search(5) != nil {
+		t.Error("清空后跳表应为空")
+	}
+	s.Insert(1)
+	if s.Search(1) == nil || s.Size() != 1 {
+		t.Error("清空后应可以继续插入")
+	}
+}
+
+// TestSkipListMarshal 测试跳表的二进制与 JSON 序列化
+func TestSkipListMarshal(t *testing.T) {
+	src := NewSkipList(intCmp)
+	for _, v := range []int{3, 1, 2} {
+		src.Insert(v)
+	}
+	want := slices.Collect(src.All())
+
+	t.Run("二进制", func(t *testing.T) {
+		data, err := src.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		dst := NewSkipList(intCmp)
+		if err := dst.UnmarshalBinary(data); err != nil {
+			t.Fatal(err)
+		}
+		if got := slices.Collect(dst.All()); !slices.Equal(got, want) {
+			t.Errorf("期望%v，实际为%v", want, got)
+		}
+		if err := dst.UnmarshalBinary(data[:len(data)-1]); err == nil {
+			t.Error("截断的数据应返回错误")
+		}
+		if dst.Size() != len(want) {
+			t.Error("解码失败时应保持不变")
+		}
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		data, err := json.Marshal(src)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != `[1,2,3]` {
+			t.Errorf("JSON 为%s", data)
+		}
+		dst := NewSkipList(intCmp)
+		if err := json.Unmarshal(data, dst); err != nil {
+			t.Fatal(err)
+		}
+		if got := slices.Collect(dst.All()); !slices.Equal(got, want) {
+			t.Errorf("期望%v，实际为%v", want, got)
+		}
+	})
+}
+
+// TestSkipListClone 测试跳表的深拷贝保持层级结构
+func TestSkipListClone(t *testing.T) {
+	s := NewSkipList(intCmp)
+	for i := 0; i < 200; i++ {
+		s.Insert((i * 37) % 200)
+	}
+	clone := s.Clone(nil)
+	if !slices.Equal(slices.Collect(clone.All()), slices.Collect(s.All())) {
+		t.Fatal("拷贝的元素应与原跳表相同")
+	}
+	// 各层的节点数量应相同
+	for i := 0; i < MaxLevel; i++ {
+		count := func(s *SkipList[int]) int {
+			n := 0
+			for x := s.header.next[i]; x != nil; x = x.next[i] {
+				n++
+			}
+			return n
+		}
+		if count(s) != count(clone) {
+			t.Fatalf("第%d层节点数量不同", i)
+		}
+	}
+
+	s.Delete(10)
+	clone.Insert(1000)
+	if clone.Search(10) == nil || s.Search(1000) != nil {
+		t.Error("拷贝与原跳表应互不影响")
+	}
+	if doubled := s.Clone(func(v int) int { return v * 2 }); doubled.Search(398) == nil {
+		t.Error("copier 应作用于每个元素")
+	}
+}
+
+// TestSkipListValidate 测试跳表结构检查
+func TestSkipListValidate(t *testing.T) {
+	s := NewSkipList(intCmp)
+	for i := 0; i < 200; i++ {
+		s.Insert((i * 7) % 50)
+	}
+	for i := 0; i < 20; i++ {
+		s.Delete(i)
+	}
+	if err := s.Validate(); err != nil {
+		t.Fatalf("正常使用后应通过检查: %v", err)
+	}
+
+	first := s.header.next[0]
+	first.value, first.next[0].value = first.next[0].value+1, first.value
+	if s.Validate() == nil {
+		t.Error("应发现第0层无序")
+	}
+	first.value, first.next[0].value = first.next[0].value, first.value-1
+
+	s.size++
+	if s.Validate() == nil {
+		t.Error("应发现元素数量不一致")
+	}
+}
+
+// TestSkipListWithArena 测试使用节点分配器的跳表在删除和清空时回收节点
+func TestSkipListWithArena(t *testing.T) {
+	s := NewSkipList(intCmp, WithArena(8))
+	for i := 0; i < 100; i++ {
+		s.Insert(i % 50)
+	}
+	for i := 0; i < 50; i += 2 {
+		s.Delete(i)
+	}
+	if _, free := s.nodes.Stats(); free != 25 {
+		t.Errorf("期望回收25个节点，实际为%d", free)
+	}
+	for i := 0; i < 25; i++ {
+		s.Insert(1000 + i)
+	}
+	if _, free := s.nodes.Stats(); free != 0 {
+		t.Errorf("插入应复用回收的节点，剩余%d个", free)
+	}
+	if err := s.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if s.Size() != 100 || s.Search(1000) == nil || s.Search(0) == nil {
+		t.Error("复用节点后跳表内容错误")
+	}
+
+	s.Clear()
+	if _, free := s.nodes.Stats(); free != 100 {
+		t.Errorf("清空后应回收全部节点，实际为%d", free)
+	}
+}
+
+// BenchmarkSkipListChurn 比较启用与不启用节点分配器时反复插入删除的开销
+func BenchmarkSkipListChurn(b *testing.B) {
+	for name, opts := range map[string][]Option{"默认": nil, "分配器": {WithArena(0)}} {
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+			s := NewSkipList(intCmp, opts...)
+			for i := 0; i < b.N; i++ {
+				s.Insert(i)
+				if i >= 1024 {
+					s.Delete(i - 1024)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+// Package metrics 为容器提供可选的指标记录
+// 容器通过各自包中的 WithRecorder 选项在创建时启用指标记录，未启用时不产生额外开销。
+// Counters 使用原子计数器统计各类事件的次数和峰值大小，实现了 expvar.Var 接口，
+// 也可以通过 Snapshot 导出给 Prometheus 等监控系统；RecorderFunc 可以把事件转发给任意回调
+package metrics
+
+import (
+	"encoding/json"
+	"sync/atomic"
+)
+
+// Event 容器中发生的事件
+type Event int
+
+const (
+	Insert   Event = iota // 插入新元素
+	Update                // 更新已有元素的值
+	Delete                // 删除元素
+	Lookup                // 查找元素
+	Resize                // 底层存储扩容、缩容或哈希表重新哈希
+	Rotation              // 树的旋转
+	Split                 // 树节点分裂
+	Merge                 // 树节点合并
+	numEvents
+)
+
+// eventNames 事件名称，用于导出指标
+var eventNames = [numEvents]string{
+	Insert:   "insert",
+	Update:   "update",
+	Delete:   "delete",
+	Lookup:   "lookup",
+	Resize:   "resize",
+	Rotation: "rotation",
+	Split:    "split",
+	Merge:    "merge",
+}
+
+// String 返回事件名称
+func (e Event) String() string {
+	if e < 0 || e >= numEvents {
+		return "unknown"
+	}
+	return eventNames[e]
+}
+
+// Recorder 接收容器事件的记录器
+// 容器在每次事件发生后调用 Record，size 为事件发生后容器中的元素数量。
+// 并发安全的容器可能在多个协程中同时调用 Record，此时记录器也必须是并发安全的
+type Recorder interface {
+	Record(e Event, size int)
+}
+
+// RecorderFunc 把普通函数适配为 Recorder
+type RecorderFunc func(e Event, size int)
+
+// Record 调用 f(e, size)
+func (f RecorderFunc) Record(e Event, size int) {
+	f(e, size)
+}
+
+// Counters 统计各类事件次数和峰值大小的记录器，并发安全
+// 零值即可使用，同一个 Counters 可以被多个容器共享以统计总量
+type Counters struct {
+	counts [numEvents]atomic.Int64
+	peak   atomic.Int64
+}
+
+// Record 增加事件计数并更新峰值大小
+// 时间复杂度: O(1)
+func (c *Counters) Record(e Event, size int) {
+	if e >= 0 && e < numEvents {
+		c.counts[e].Add(1)
+	}
+	for {
+		peak := c.peak.Load()
+		if int64(size) <= peak || c.peak.CompareAndSwap(peak, int64(size)) {
+			return
+		}
+	}
+}
+
+// Count 返回事件发生的次数
+// 时间复杂度: O(1)
+func (c *Counters) Count(e Event) int64 {
+	if e < 0 || e >= numEvents {
+		return 0
+	}
+	return c.counts[e].Load()
+}
+
+// Peak 返回记录到的最大元素数量
+// 时间复杂度: O(1)
+func (c *Counters) Peak() int64 {
+	return c.peak.Load()
+}
+
+// Reset 将所有计数和峰值大小清零
+// 时间复杂度: O(1)
+func (c *Counters) Reset() {
+	for i := range c.counts {
+		c.counts[i].Store(0)
+	}
+	c.peak.Store(0)
+}
+
+// Snapshot 返回以事件名称为键的计数快照，峰值大小的键为 "peak_size"
+// 各计数分别读取，并发记录时快照中的不同计数可能不是同一时刻的值
+// 时间复杂度: O(1)
+func (c *Counters) Snapshot() map[string]int64 {
+	m := make(map[string]int64, len(eventNames)+1)
+	for e, name := range eventNames {
+		m[name] = c.counts[e].Load()
+	}
+	m["peak_size"] = c.peak.Load()
+	return m
+}
+
+// String 把快照编码为 JSON 对象，实现 expvar.Var 接口，可以直接通过 expvar.Publish 发布
+func (c *Counters) String() string {
+	data, _ := json.Marshal(c.Snapshot())
+	return string(data)
+}
@@ -0,0 +1,80 @@
+package metrics
+
+import (
+	"encoding/json"
+	"expvar"
+	"sync"
+	"testing"
+)
+
+// 编译期检查 Counters 可以通过 expvar 发布
+var _ expvar.Var = (*Counters)(nil)
+
+// TestCounters 测试事件计数与峰值大小
+func TestCounters(t *testing.T) {
+	var c Counters
+	c.Record(Insert, 1)
+	c.Record(Insert, 2)
+	c.Record(Delete, 1)
+	c.Record(Event(-1), 0) // 未知事件只更新峰值
+	if c.Count(Insert) != 2 || c.Count(Delete) != 1 || c.Count(Rotation) != 0 {
+		t.Errorf("计数错误: %v", c.Snapshot())
+	}
+	if c.Peak() != 2 {
+		t.Errorf("期望峰值为2，实际为%d", c.Peak())
+	}
+	if c.Count(Event(100)) != 0 {
+		t.Error("未知事件的计数应为0")
+	}
+
+	c.Reset()
+	if c.Count(Insert) != 0 || c.Peak() != 0 {
+		t.Error("重置后计数应为0")
+	}
+}
+
+// TestCountersConcurrent 测试并发记录时计数与峰值正确
+func TestCountersConcurrent(t *testing.T) {
+	var c Counters
+	var wg sync.WaitGroup
+	for i := range 1000 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c.Record(Lookup, i)
+		}()
+	}
+	wg.Wait()
+	if c.Count(Lookup) != 1000 || c.Peak() != 999 {
+		t.Errorf("期望1000次查找、峰值999，实际为%d次、峰值%d", c.Count(Lookup), c.Peak())
+	}
+}
+
+// TestCountersString 测试导出为 JSON
+func TestCountersString(t *testing.T) {
+	var c Counters
+	c.Record(Split, 5)
+	var m map[string]int64
+	if err := json.Unmarshal([]byte(c.String()), &m); err != nil {
+		t.Fatal(err)
+	}
+	if m["split"] != 1 || m["peak_size"] != 5 || m["insert"] != 0 || len(m) != int(numEvents)+1 {
+		t.Errorf("导出结果错误: %v", m)
+	}
+}
+
+// TestRecorderFunc 测试函数适配器
+func TestRecorderFunc(t *testing.T) {
+	var events []Event
+	var r Recorder = RecorderFunc(func(e Event, size int) {
+		events = append(events, e)
+	})
+	r.Record(Resize, 0)
+	r.Record(Merge, 0)
+	if len(events) != 2 || events[0] != Resize || events[1] != Merge {
+		t.Errorf("回调收到的事件错误: %v", events)
+	}
+	if Resize.String() != "resize" || Event(-1).String() != "unknown" {
+		t.Error("事件名称错误")
+	}
+}
@@ -0,0 +1,263 @@
+package persistent
+
+import (
+	"errors"
+	"iter"
+)
+
+const (
+	bits  = 5         // 每层使用的下标位数
+	width = 1 << bits // 每个节点的分支数
+	mask  = width - 1
+)
+
+var (
+	ErrIndexOutOfRange = errors.New("索引越界")
+	ErrEmptyVector     = errors.New("向量为空")
+)
+
+// vectorNode 前缀树节点，内部节点使用 children，叶子节点使用 values
+// 节点一旦被某个版本引用就不再修改，修改时复制路径上的节点
+type vectorNode[T any] struct {
+	children []*vectorNode[T]
+	values   []T
+}
+
+// PersistentVector 持久化不可变向量
+// 采用32叉前缀树加尾部缓冲区的结构（与 Clojure 的 PersistentVector 相同），
+// 每次修改返回新版本，新旧版本共享未修改的节点，旧版本保持不变，
+// 因此多个协程可以不加锁地并发读取任意版本
+// 零值不可用，请使用 NewVector 创建
+type PersistentVector[T any] struct {
+	size  int            // 元素数量
+	shift uint           // 根节点所在层的位移量
+	root  *vectorNode[T] // 前缀树的根节点
+	tail  []T            // 尾部缓冲区，最多 width 个元素，不在前缀树中
+}
+
+// NewVector 创建包含给定元素的向量
+// 时间复杂度: O(n)
+func NewVector[T any](values ...T) *PersistentVector[T] {
+	v := &PersistentVector[T]{shift: bits, root: &vectorNode[T]{}}
+	for _, value := range values {
+		v = v.Append(value)
+	}
+	return v
+}
+
+// Len 返回元素数量
+// 时间复杂度: O(1)
+func (v *PersistentVector[T]) Len() int {
+	return v.size
+}
+
+// IsEmpty 判断向量是否为空
+// 时间复杂度: O(1)
+func (v *PersistentVector[T]) IsEmpty() bool {
+	return v.size == 0
+}
+
+// Get 获取指定位置的元素
+// 时间复杂度: O(log32 n)
+func (v *PersistentVector[T]) Get(index int) (T, error) {
+	if index < 0 || index >= v.size {
+		var zero T
+		return zero, ErrIndexOutOfRange
+	}
+	return v.leafFor(index)[index&mask], nil
+}
+
+// Set 返回把指定位置的元素替换为 value 后的新版本
+// 时间复杂度: O(log32 n)
+func (v *PersistentVector[T]) Set(index int, value T) (*PersistentVector[T], error) {
+	if index < 0 || index >= v.size {
+		return nil, ErrIndexOutOfRange
+	}
+	if index >= v.tailOffset() {
+		tail := append([]T(nil), v.tail...)
+		tail[index&mask] = value
+		return &PersistentVector[T]{size: v.size, shift: v.shift, root: v.root, tail: tail}, nil
+	}
+	root := v.assoc(v.shift, v.root, index, value)
+	return &PersistentVector[T]{size: v.size, shift: v.shift, root: root, tail: v.tail}, nil
+}
+
+// Append 返回在末尾追加 value 后的新版本
+// 时间复杂度: O(log32 n)，尾部缓冲区未满时 O(1)
+func (v *PersistentVector[T]) Append(value T) *PersistentVector[T] {
+	// 尾部缓冲区未满，复制后追加
+	if v.size-v.tailOffset() < width {
+		tail := make([]T, len(v.tail)+1, width)
+		copy(tail, v.tail)
+		tail[len(v.tail)] = value
+		return &PersistentVector[T]{size: v.size + 1, shift: v.shift, root: v.root, tail: tail}
+	}
+
+	// 尾部缓冲区已满，放入前缀树后开始新的缓冲区
+	tailNode := &vectorNode[T]{values: v.tail}
+	shift := v.shift
+	var root *vectorNode[T]
+	if v.size>>bits > 1<<v.shift {
+		// 根节点已满，树高加一
+		root = &vectorNode[T]{children: []*vectorNode[T]{v.root, newPath(v.shift, tailNode)}}
+		shift += bits
+	} else {
+		root = v.pushTail(v.shift, v.root, tailNode)
+	}
+	tail := make([]T, 1, width)
+	tail[0] = value
+	return &PersistentVector[T]{size: v.size + 1, shift: shift, root: root, tail: tail}
+}
+
+// Pop 返回删除最后一个元素后的新版本，以及被删除的元素
+// 时间复杂度: O(log32 n)
+func (v *PersistentVector[T]) Pop() (*PersistentVector[T], T, error) {
+	if v.size == 0 {
+		var zero T
+		return nil, zero, ErrEmptyVector
+	}
+	last := v.tail[len(v.tail)-1]
+	if v.size == 1 {
+		return NewVector[T](), last, nil
+	}
+	if len(v.tail) > 1 {
+		tail := append([]T(nil), v.tail[:len(v.tail)-1]...)
+		return &PersistentVector[T]{size: v.size - 1, shift: v.shift, root: v.root, tail: tail}, last, nil
+	}
+
+	// 尾部缓冲区只剩一个元素，把前缀树中的最后一个叶子作为新的缓冲区
+	tail := v.leafFor(v.size - 2)
+	root := v.popTail(v.shift, v.root)
+	shift := v.shift
+	if root == nil {
+		root = &vectorNode[T]{}
+	}
+	if shift > bits && len(root.children) == 1 {
+		// 根节点只剩一个子节点，树高减一
+		root = root.children[0]
+		shift -= bits
+	}
+	return &PersistentVector[T]{size: v.size - 1, shift: shift, root: root, tail: tail}, last, nil
+}
+
+// All 返回按下标顺序遍历所有元素的迭代器
+func (v *PersistentVector[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := 0; i < v.size; i += width {
+			for _, value := range v.leafFor(i) {
+				if !yield(value) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Clone 返回元素经过 copier 复制后的新向量，新向量不与原向量共享节点
+// 向量本身不可变，只有元素需要深拷贝时才需要调用；copier 为 nil 时直接赋值
+// 时间复杂度: O(n)
+func (v *PersistentVector[T]) Clone(copier func(T) T) *PersistentVector[T] {
+	values := v.ToSlice()
+	if copier != nil {
+		for i := range values {
+			values[i] = copier(values[i])
+		}
+	}
+	return NewVector(values...)
+}
+
+// ToSlice 返回包含所有元素的切片
+// 时间复杂度: O(n)
+func (v *PersistentVector[T]) ToSlice() []T {
+	result := make([]T, 0, v.size)
+	for value := range v.All() {
+		result = append(result, value)
+	}
+	return result
+}
+
+// tailOffset 返回尾部缓冲区第一个元素的下标
+func (v *PersistentVector[T]) tailOffset() int {
+	if v.size < width {
+		return 0
+	}
+	return (v.size - 1) >> bits << bits
+}
+
+// leafFor 返回包含指定下标的叶子节点的元素切片
+func (v *PersistentVector[T]) leafFor(index int) []T {
+	if index >= v.tailOffset() {
+		return v.tail
+	}
+	n := v.root
+	for level := v.shift; level > 0; level -= bits {
+		n = n.children[(index>>level)&mask]
+	}
+	return n.values
+}
+
+// assoc 复制从 n 到目标叶子的路径并替换元素
+func (v *PersistentVector[T]) assoc(level uint, n *vectorNode[T], index int, value T) *vectorNode[T] {
+	if level == 0 {
+		values := append([]T(nil), n.values...)
+		values[index&mask] = value
+		return &vectorNode[T]{values: values}
+	}
+	children := append([]*vectorNode[T](nil), n.children...)
+	sub := (index >> level) & mask
+	children[sub] = v.assoc(level-bits, children[sub], index, value)
+	return &vectorNode[T]{children: children}
+}
+
+// pushTail 复制路径并把已满的尾部缓冲区作为新的叶子挂到前缀树的最右侧
+func (v *PersistentVector[T]) pushTail(level uint, parent, tailNode *vectorNode[T]) *vectorNode[T] {
+	sub := ((v.size - 1) >> level) & mask
+	children := make([]*vectorNode[T], len(parent.children), sub+1)
+	copy(children, parent.children)
+
+	var child *vectorNode[T]
+	switch {
+	case level == bits:
+		child = tailNode
+	case sub < len(parent.children):
+		child = v.pushTail(level-bits, parent.children[sub], tailNode)
+	default:
+		child = newPath(level-bits, tailNode)
+	}
+	if sub < len(children) {
+		children[sub] = child
+	} else {
+		children = append(children, child)
+	}
+	return &vectorNode[T]{children: children}
+}
+
+// popTail 复制路径并移除前缀树最右侧的叶子，子树变为空时返回 nil
+func (v *PersistentVector[T]) popTail(level uint, n *vectorNode[T]) *vectorNode[T] {
+	sub := ((v.size - 2) >> level) & mask
+	if level > bits {
+		child := v.popTail(level-bits, n.children[sub])
+		if child == nil && sub == 0 {
+			return nil
+		}
+		children := append([]*vectorNode[T](nil), n.children[:sub+1]...)
+		if child == nil {
+			children = children[:sub]
+		} else {
+			children[sub] = child
+		}
+		return &vectorNode[T]{children: children}
+	}
+	if sub == 0 {
+		return nil
+	}
+	return &vectorNode[T]{children: append([]*vectorNode[T](nil), n.children[:sub]...)}
+}
+
+// newPath 创建从 level 层到叶子 n 的单链路径
+func newPath[T any](level uint, n *vectorNode[T]) *vectorNode[T] {
+	if level == 0 {
+		return n
+	}
+	return &vectorNode[T]{children: []*vectorNode[T]{newPath(level-bits, n)}}
+}
@@ -0,0 +1,202 @@
+package persistent
+
+import (
+	"slices"
+	"sync"
+	"testing"
+)
+
+// TestPersistentVectorBasic 测试基本的读写操作
+func TestPersistentVectorBasic(t *testing.T) {
+	empty := NewVector[int]()
+	if !empty.IsEmpty() || empty.Len() != 0 {
+		t.Error("新建的向量应为空")
+	}
+	if _, err := empty.Get(0); err != ErrIndexOutOfRange {
+		t.Errorf("空向量Get应返回ErrIndexOutOfRange，实际为%v", err)
+	}
+	if _, _, err := empty.Pop(); err != ErrEmptyVector {
+		t.Errorf("空向量Pop应返回ErrEmptyVector，实际为%v", err)
+	}
+
+	v1 := NewVector(1, 2, 3)
+	v2 := v1.Append(4)
+	v3, err := v2.Set(0, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := v3.Set(4, 0); err != ErrIndexOutOfRange {
+		t.Errorf("越界Set应返回ErrIndexOutOfRange，实际为%v", err)
+	}
+
+	// 旧版本保持不变
+	if got := v1.ToSlice(); !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("v1被修改: %v", got)
+	}
+	if got := v2.ToSlice(); !slices.Equal(got, []int{1, 2, 3, 4}) {
+		t.Errorf("v2被修改: %v", got)
+	}
+	if got := v3.ToSlice(); !slices.Equal(got, []int{100, 2, 3, 4}) {
+		t.Errorf("v3内容错误: %v", got)
+	}
+
+	v4, last, err := v3.Pop()
+	if err != nil || last != 4 || v4.Len() != 3 {
+		t.Errorf("Pop结果错误: %v, %d, %v", v4.ToSlice(), last, err)
+	}
+	if v3.Len() != 4 {
+		t.Error("Pop不应修改原版本")
+	}
+}
+
+// TestPersistentVectorLarge 测试跨越多层前缀树的追加、修改和删除
+func TestPersistentVectorLarge(t *testing.T) {
+	const n = width*width*2 + 77 // 需要三层前缀树
+	versions := make([]*PersistentVector[int], 0, n+1)
+	v := NewVector[int]()
+	versions = append(versions, v)
+	for i := 0; i < n; i++ {
+		v = v.Append(i)
+		versions = append(versions, v)
+	}
+
+	for i := 0; i < n; i++ {
+		if got, err := v.Get(i); err != nil || got != i {
+			t.Fatalf("Get(%d) = (%d, %v)", i, got, err)
+		}
+	}
+	// 抽查历史版本
+	for _, size := range []int{0, 1, 31, 32, 33, 1024, 1056, 1057, n} {
+		if got := versions[size].Len(); got != size {
+			t.Errorf("版本%d的长度为%d", size, got)
+		}
+		if size > 0 {
+			if last, _ := versions[size].Get(size - 1); last != size-1 {
+				t.Errorf("版本%d的最后一个元素为%d", size, last)
+			}
+		}
+	}
+
+	// 修改树中和尾部缓冲区中的元素
+	w := v
+	for _, i := range []int{0, 31, 32, 1023, 1024, 2000, n - 1} {
+		var err error
+		if w, err = w.Set(i, -i); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, i := range []int{0, 31, 32, 1023, 1024, 2000, n - 1} {
+		if got, _ := w.Get(i); got != -i {
+			t.Errorf("Set后Get(%d) = %d", i, got)
+		}
+		if got, _ := v.Get(i); got != i {
+			t.Errorf("原版本的元素%d被修改为%d", i, got)
+		}
+	}
+
+	// 逐个删除，每个中间版本都应与追加时的版本一致
+	for size := n; size > 0; size-- {
+		var last int
+		var err error
+		v, last, err = v.Pop()
+		if err != nil || last != size-1 {
+			t.Fatalf("Pop() = (%d, %v)，期望 %d", last, err, size-1)
+		}
+		if v.Len() != size-1 || v.shift != versions[size-1].shift {
+			t.Fatalf("删除后长度或树高错误: %d, %d", v.Len(), v.shift)
+		}
+		if size%97 == 0 && !slices.Equal(v.ToSlice(), versions[size-1].ToSlice()) {
+			t.Fatalf("删除到%d个元素时内容错误", size-1)
+		}
+	}
+	if !v.IsEmpty() {
+		t.Error("全部删除后向量应为空")
+	}
+}
+
+// TestPersistentVectorAll 测试迭代器
+func TestPersistentVectorAll(t *testing.T) {
+	v := NewVector[int]()
+	for i := 0; i < 100; i++ {
+		v = v.Append(i * 2)
+	}
+	count := 0
+	for value := range v.All() {
+		if value != count*2 {
+			t.Errorf("下标%d的元素为%d", count, value)
+		}
+		count++
+		if count == 50 {
+			break
+		}
+	}
+	if count != 50 {
+		t.Error("迭代器未能提前终止")
+	}
+}
+
+// TestPersistentVectorConcurrentReaders 测试并发读取共享结构的多个版本
+func TestPersistentVectorConcurrentReaders(t *testing.T) {
+	base := NewVector[int]()
+	for i := 0; i < 5000; i++ {
+		base = base.Append(i)
+	}
+	var wg sync.WaitGroup
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			mine, _ := base.Set(g, -1)
+			for i := 0; i < base.Len(); i++ {
+				want := i
+				if i == g {
+					want = -1
+				}
+				if got, _ := mine.Get(i); got != want {
+					t.Errorf("协程%d: Get(%d) = %d", g, i, got)
+					return
+				}
+				if got, _ := base.Get(i); got != i {
+					t.Errorf("协程%d: 原版本Get(%d) = %d", g, i, got)
+					return
+				}
+			}
+		}(g)
+	}
+	wg.Wait()
+}
+
+// 性能测试
+func BenchmarkPersistentVector(b *testing.B) {
+	b.Run("追加", func(b *testing.B) {
+		v := NewVector[int]()
+		for i := 0; i < b.N; i++ {
+			v = v.Append(i)
+		}
+	})
+	v := NewVector[int]()
+	for i := 0; i < 100000; i++ {
+		v = v.Append(i)
+	}
+	b.Run("读取", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			v.Get(i % 100000)
+		}
+	})
+	b.Run("修改", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			v.Set(i%100000, i)
+		}
+	})
+}
+
+// TestPersistentVectorClone 测试向量元素的深拷贝
+func TestPersistentVectorClone(t *testing.T) {
+	v := NewVector([]int{1}, []int{2})
+	clone := v.Clone(slices.Clone)
+	first, _ := v.Get(0)
+	first[0] = 100
+	if got, _ := clone.Get(0); got[0] != 1 || clone.Len() != 2 {
+		t.Errorf("深拷贝不应受原元素修改的影响，实际为%v", got)
+	}
+}
@@ -1,18 +1,27 @@
 package queue
 
-import "errors"
+import (
+	"errors"
+	"iter"
+
+	"godatastructure/codec"
+)
 
 // Deque 双端队列接口
 // 支持在队列两端进行插入和删除操作
 type Deque[T any] interface {
-	PushFront(value T)    // 在队首插入元素
-	PushBack(value T)     // 在队尾插入元素
-	PopFront() (T, error) // 移除并返回队首元素
-	PopBack() (T, error)  // 移除并返回队尾元素
-	Front() (T, error)    // 查看队首元素但不移除
-	Back() (T, error)     // 查看队尾元素但不移除
-	IsEmpty() bool        // 检查双端队列是否为空
-	Size() int            // 获取双端队列中元素个数
+	PushFront(value T)               // 在队首插入元素
+	PushBack(value T)                // 在队尾插入元素
+	PopFront() (T, error)            // 移除并返回队首元素
+	PopBack() (T, error)             // 移除并返回队尾元素
+	Front() (T, error)               // 查看队首元素但不移除
+	Back() (T, error)                // 查看队尾元素但不移除
+	IsEmpty() bool                   // 检查双端队列是否为空
+	Size() int                       // 获取双端队列中元素个数
+	Clear()                          // 清空双端队列
+	All() iter.Seq[T]                // 返回从队首到队尾遍历的迭代器
+	codec.Marshaler                  // 二进制与 JSON 序列化，按从队首到队尾的顺序编码
+	Clone(copier func(T) T) Deque[T] // 返回深拷贝，copier 为 nil 时直接复制元素
 }
 
 // deque 双端队列的具体实现
@@ -94,3 +103,73 @@ func (d *deque[T]) IsEmpty() bool {
 func (d *deque[T]) Size() int {
 	return len(d.elements)
 }
+
+// Clear 清空双端队列
+// 时间复杂度: O(1)
+func (d *deque[T]) Clear() {
+	d.elements = []T{}
+}
+
+// All 返回从队首到队尾遍历的迭代器
+// 时间复杂度: O(n)
+func (d *deque[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, v := range d.elements {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// MarshalBinary 使用 codec.For[T] 返回的元素编解码器把双端队列编码为二进制，按从队首到队尾的顺序编码
+// 实现 encoding.BinaryMarshaler 接口
+func (d *deque[T]) MarshalBinary() ([]byte, error) {
+	return codec.EncodeSeq(d.All(), codec.For[T]())
+}
+
+// UnmarshalBinary 从 MarshalBinary 生成的数据中解码，替换双端队列中的所有元素
+// 解码失败时双端队列保持不变，实现 encoding.BinaryUnmarshaler 接口
+func (d *deque[T]) UnmarshalBinary(data []byte) error {
+	values, err := codec.DecodeSeq(data, codec.For[T]())
+	if err != nil {
+		return err
+	}
+	d.load(values)
+	return nil
+}
+
+// MarshalJSON 把双端队列编码为 JSON 数组，实现 json.Marshaler 接口
+func (d *deque[T]) MarshalJSON() ([]byte, error) {
+	return codec.MarshalJSONSeq(d.All())
+}
+
+// UnmarshalJSON 从 JSON 数组中解码，替换双端队列中的所有元素
+// 解码失败时双端队列保持不变，实现 json.Unmarshaler 接口
+func (d *deque[T]) UnmarshalJSON(data []byte) error {
+	values, err := codec.UnmarshalJSONSeq[T](data)
+	if err != nil {
+		return err
+	}
+	d.load(values)
+	return nil
+}
+
+// load 用 values 替换双端队列中的元素，values 的第一个元素为队首
+func (d *deque[T]) load(values []T) {
+	d.elements = append([]T{}, values...)
+}
+
+// Clone 返回双端队列的深拷贝
+// copier 用于复制每个元素，为 nil 时直接赋值
+// 时间复杂度: O(n)
+func (d *deque[T]) Clone(copier func(T) T) Deque[T] {
+	elements := make([]T, len(d.elements))
+	for i, v := range d.elements {
+		if copier != nil {
+			v = copier(v)
+		}
+		elements[i] = v
+	}
+	return &deque[T]{elements: elements}
+}
@@ -1,6 +1,8 @@
 package queue
 
 import (
+	"encoding/json"
+	"slices"
 	"testing"
 )
 
@@ -260,3 +262,83 @@ func TestDifferentTypes(t *testing.T) {
 		}
 	})
 }
+
+// TestDequeAll 测试从队首到队尾遍历
+func TestDequeAll(t *testing.T) {
+	d := NewDeque[int]()
+	d.PushBack(2)
+	d.PushFront(1)
+	d.PushBack(3)
+	var got []int
+	for v := range d.All() {
+		got = append(got, v)
+	}
+	if !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("迭代结果错误: %v", got)
+	}
+}
+
+// TestDequeMarshal 测试双端队列的二进制与 JSON 序列化
+func TestDequeMarshal(t *testing.T) {
+	src := NewDeque[int]()
+	for _, v := range []int{1, 2, 3} {
+		src.PushBack(v)
+	}
+	want := slices.Collect(src.All())
+
+	t.Run("二进制", func(t *testing.T) {
+		data, err := src.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		dst := NewDeque[int]()
+		if err := dst.UnmarshalBinary(data); err != nil {
+			t.Fatal(err)
+		}
+		if got := slices.Collect(dst.All()); !slices.Equal(got, want) {
+			t.Errorf("期望%v，实际为%v", want, got)
+		}
+		if err := dst.UnmarshalBinary(data[:len(data)-1]); err == nil {
+			t.Error("截断的数据应返回错误")
+		}
+		if dst.Size() != len(want) {
+			t.Error("解码失败时应保持不变")
+		}
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		data, err := json.Marshal(src)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != `[1,2,3]` {
+			t.Errorf("JSON 为%s", data)
+		}
+		dst := NewDeque[int]()
+		if err := json.Unmarshal(data, dst); err != nil {
+			t.Fatal(err)
+		}
+		if got := slices.Collect(dst.All()); !slices.Equal(got, want) {
+			t.Errorf("期望%v，实际为%v", want, got)
+		}
+	})
+}
+
+// TestDequeClone 测试双端队列的深拷贝
+func TestDequeClone(t *testing.T) {
+	d := NewDeque[[]int]()
+	d.PushBack([]int{1})
+	d.PushBack([]int{2})
+
+	clone := d.Clone(slices.Clone)
+	front, _ := d.Front()
+	front[0] = 100
+	clone.PopBack()
+
+	if d.Size() != 2 || clone.Size() != 1 {
+		t.Errorf("拷贝与原队列应互不影响，大小为%d和%d", d.Size(), clone.Size())
+	}
+	if v, _ := clone.Front(); v[0] != 1 {
+		t.Errorf("深拷贝不应受原元素修改的影响，实际为%v", v)
+	}
+}
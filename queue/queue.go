@@ -3,6 +3,9 @@ package queue
 import (
 	"errors"
 	"fmt"
+	"iter"
+
+	"godatastructure/codec"
 )
 
 // 定义队列操作可能遇到的错误
@@ -65,6 +68,19 @@ type Queue[T any] interface {
 	// Clear 清空队列中的所有元素
 	// 时间复杂度: O(n)
 	Clear()
+
+	// All 返回从队首到队尾遍历的迭代器
+	// 遍历过程中不应修改队列
+	All() iter.Seq[T]
+
+	// Marshaler 二进制与 JSON 序列化，按从队首到队尾的顺序编码
+	// 解码时元素数量超过容量会扩大容量
+	codec.Marshaler
+
+	// Clone 返回容量相同的深拷贝
+	// copier 用于复制每个元素，为 nil 时直接赋值
+	// 时间复杂度: O(n)
+	Clone(copier func(T) T) Queue[T]
 }
 
 // CircularQueue 循环队列的具体实现
@@ -262,3 +278,101 @@ func (q *CircularQueue[T]) ToSlice() []T {
 	}
 	return result
 }
+
+// All 返回从队首到队尾遍历的迭代器
+// 时间复杂度: O(n)
+func (q *CircularQueue[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		idx := q.front
+		for i := 0; i < q.size; i++ {
+			if !yield(q.elements[idx]) {
+				return
+			}
+			idx = (idx + 1) % q.capacity
+		}
+	}
+}
+
+// MarshalBinary 使用 codec.For[T] 返回的元素编解码器把队列编码为二进制，按从队首到队尾的顺序编码
+// 实现 encoding.BinaryMarshaler 接口
+func (q *CircularQueue[T]) MarshalBinary() ([]byte, error) {
+	return codec.EncodeSeq(q.All(), codec.For[T]())
+}
+
+// UnmarshalBinary 从 MarshalBinary 生成的数据中解码，替换队列中的所有元素
+// 解码失败时队列保持不变，实现 encoding.BinaryUnmarshaler 接口
+func (q *CircularQueue[T]) UnmarshalBinary(data []byte) error {
+	values, err := codec.DecodeSeq(data, codec.For[T]())
+	if err != nil {
+		return err
+	}
+	q.load(values)
+	return nil
+}
+
+// MarshalJSON 把队列编码为 JSON 数组，实现 json.Marshaler 接口
+func (q *CircularQueue[T]) MarshalJSON() ([]byte, error) {
+	return codec.MarshalJSONSeq(q.All())
+}
+
+// UnmarshalJSON 从 JSON 数组中解码，替换队列中的所有元素
+// 解码失败时队列保持不变，实现 json.Unmarshaler 接口
+func (q *CircularQueue[T]) UnmarshalJSON(data []byte) error {
+	values, err := codec.UnmarshalJSONSeq[T](data)
+	if err != nil {
+		return err
+	}
+	q.load(values)
+	return nil
+}
+
+// load 用 values 替换队列中的元素，容量不足时扩大到 values 的长度
+func (q *CircularQueue[T]) load(values []T) {
+	q.capacity = max(q.capacity, len(values), 1)
+	q.elements = make([]T, q.capacity)
+	copy(q.elements, values)
+	q.front = 0
+	q.rear = len(values) % q.capacity
+	q.size = len(values)
+}
+
+// Clone 返回容量相同的深拷贝，新队列中的元素从数组开头连续存放
+// copier 用于复制每个元素，为 nil 时直接赋值
+// 时间复杂度: O(n)
+func (q *CircularQueue[T]) Clone(copier func(T) T) Queue[T] {
+	clone := &CircularQueue[T]{
+		elements: make([]T, q.capacity),
+		rear:     q.size % q.capacity,
+		size:     q.size,
+		capacity: q.capacity,
+	}
+	i := 0
+	for v := range q.All() {
+		if copier != nil {
+			v = copier(v)
+		}
+		clone.elements[i] = v
+		i++
+	}
+	return clone
+}
+
+// Validate 检查循环队列的结构不变量，发现损坏时返回描述问题的错误
+// 检查内容：底层数组长度等于容量，队首、队尾索引在数组范围内，
+// 元素数量不超过容量，且队尾索引等于队首索引加元素数量对容量取模
+// 时间复杂度: O(1)
+func (q *CircularQueue[T]) Validate() error {
+	if q.capacity <= 0 || len(q.elements) != q.capacity {
+		return fmt.Errorf("底层数组长度%d与容量%d不一致", len(q.elements), q.capacity)
+	}
+	if q.front < 0 || q.front >= q.capacity || q.rear < 0 || q.rear >= q.capacity {
+		return fmt.Errorf("队首索引%d或队尾索引%d超出范围[0, %d)", q.front, q.rear, q.capacity)
+	}
+	if q.size < 0 || q.size > q.capacity {
+		return fmt.Errorf("元素数量%d超出范围[0, %d]", q.size, q.capacity)
+	}
+	if (q.front+q.size)%q.capacity != q.rear {
+		return fmt.Errorf("队首索引%d加元素数量%d与队尾索引%d不一致", q.front, q.size, q.rear)
+	}
+	return nil
+}
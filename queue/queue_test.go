@@ -1,7 +1,9 @@
 package queue
 
 import (
+	"encoding/json"
 	"errors"
+	"slices"
 	"testing"
 )
 
@@ -251,3 +253,147 @@ func TestQueueString(t *testing.T) {
 		t.Errorf("String() = %v, want %v", s, expected)
 	}
 }
+
+// TestQueueAll 测试环绕后的遍历顺序
+func TestQueueAll(t *testing.T) {
+	q, _ := NewQueue[int](4)
+	for i := 1; i <= 4; i++ {
+		q.Offer(i)
+	}
+	q.Poll()
+	q.Poll()
+	q.Offer(5) // 队尾环绕到数组头部
+
+	var got []int
+	for v := range q.All() {
+		got = append(got, v)
+	}
+	if !slices.Equal(got, []int{3, 4, 5}) {
+		t.Errorf("迭代结果错误: %v", got)
+	}
+}
+
+// TestMarshal 测试队列的二进制与 JSON 序列化
+func TestMarshal(t *testing.T) {
+	q, _ := NewQueue[int](3)
+	src := q.(*CircularQueue[int])
+	// 先出队再入队，使元素在循环数组中绕回
+	for _, v := range []int{0, 0, 1} {
+		src.Offer(v)
+	}
+	src.Poll()
+	src.Poll()
+	src.Offer(2)
+	src.Offer(3)
+	want := slices.Collect(src.All())
+
+	t.Run("二进制", func(t *testing.T) {
+		data, err := src.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		dst := newSmallQueue()
+		if err := dst.UnmarshalBinary(data); err != nil {
+			t.Fatal(err)
+		}
+		if got := slices.Collect(dst.All()); !slices.Equal(got, want) {
+			t.Errorf("期望%v，实际为%v", want, got)
+		}
+		if err := dst.UnmarshalBinary(data[:len(data)-1]); err == nil {
+			t.Error("截断的数据应返回错误")
+		}
+		if dst.Size() != len(want) {
+			t.Error("解码失败时应保持不变")
+		}
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		data, err := json.Marshal(src)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != `[1,2,3]` {
+			t.Errorf("JSON 为%s", data)
+		}
+		dst := newSmallQueue()
+		if err := json.Unmarshal(data, dst); err != nil {
+			t.Fatal(err)
+		}
+		if got := slices.Collect(dst.All()); !slices.Equal(got, want) {
+			t.Errorf("期望%v，实际为%v", want, got)
+		}
+	})
+
+	t.Run("扩大容量", func(t *testing.T) {
+		dst := newSmallQueue()
+		if err := json.Unmarshal([]byte(`[1,2,3,4,5]`), dst); err != nil {
+			t.Fatal(err)
+		}
+		if dst.Size() != 5 || !dst.IsFull() {
+			t.Errorf("期望容量扩大到5且已满，实际大小为%d", dst.Size())
+		}
+		if dst.Offer(6) {
+			t.Error("已满时入队应失败")
+		}
+		if v, _ := dst.Poll(); v != 1 {
+			t.Errorf("期望队首为1，实际为%d", v)
+		}
+	})
+}
+
+// newSmallQueue 创建容量为1的队列
+func newSmallQueue() *CircularQueue[int] {
+	q, _ := NewQueue[int](1)
+	return q.(*CircularQueue[int])
+}
+
+// TestClone 测试循环队列的深拷贝
+func TestClone(t *testing.T) {
+	q, _ := NewQueue[int](3)
+	// 使元素在循环数组中绕回
+	q.Offer(0)
+	q.Offer(1)
+	q.Poll()
+	q.Offer(2)
+	q.Offer(3)
+
+	clone := q.Clone(func(v int) int { return v * 10 })
+	if got := slices.Collect(clone.All()); !slices.Equal(got, []int{10, 20, 30}) {
+		t.Errorf("期望[10 20 30]，实际为%v", got)
+	}
+	if !clone.IsFull() || clone.Offer(4) {
+		t.Error("拷贝的容量应与原队列相同")
+	}
+	clone.Poll()
+	clone.Offer(40)
+	if got := slices.Collect(q.All()); !slices.Equal(got, []int{1, 2, 3}) {
+		t.Errorf("修改拷贝不应影响原队列，实际为%v", got)
+	}
+	if got := slices.Collect(clone.All()); !slices.Equal(got, []int{20, 30, 40}) {
+		t.Errorf("期望[20 30 40]，实际为%v", got)
+	}
+}
+
+// TestCircularQueueValidate 测试循环队列结构检查
+func TestCircularQueueValidate(t *testing.T) {
+	q, _ := NewQueue[int](4)
+	cq := q.(*CircularQueue[int])
+	for i := 0; i < 10; i++ {
+		q.Offer(i)
+		if i%2 == 0 {
+			q.Poll()
+		}
+		if err := cq.Validate(); err != nil {
+			t.Fatalf("第%d次操作后应通过检查: %v", i, err)
+		}
+	}
+
+	cq.rear = (cq.rear + 1) % cq.capacity
+	if cq.Validate() == nil {
+		t.Error("应发现队尾索引与元素数量不一致")
+	}
+	cq.rear = cq.capacity
+	if cq.Validate() == nil {
+		t.Error("应发现队尾索引越界")
+	}
+}
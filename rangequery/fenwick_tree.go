@@ -0,0 +1,132 @@
+package rangequery
+
+import (
+	"math/bits"
+
+	"golang.org/x/exp/constraints"
+)
+
+// Number 可以求和的数值类型
+type Number interface {
+	constraints.Integer | constraints.Float
+}
+
+// FenwickTree 树状数组（Binary Indexed Tree）
+// 支持单点增量更新和前缀和查询，对外使用从0开始的下标
+type FenwickTree[T Number] struct {
+	tree []T // tree[i] 存储区间 (i-lowbit(i), i] 的和，下标从1开始，tree[0] 不使用
+}
+
+// NewFenwickTree 创建长度为 n、所有元素为0的树状数组
+// 时间复杂度: O(n)
+func NewFenwickTree[T Number](n int) *FenwickTree[T] {
+	if n < 0 {
+		panic("元素数量不能为负数")
+	}
+	return &FenwickTree[T]{tree: make([]T, n+1)}
+}
+
+// NewFenwickTreeFrom 使用给定的初始值创建树状数组
+// 时间复杂度: O(n)
+func NewFenwickTreeFrom[T Number](values []T) *FenwickTree[T] {
+	f := &FenwickTree[T]{tree: make([]T, len(values)+1)}
+	copy(f.tree[1:], values)
+	// 每个节点把自己的和累加到父节点，线性时间完成建树
+	for i := 1; i < len(f.tree); i++ {
+		if parent := i + i&-i; parent < len(f.tree) {
+			f.tree[parent] += f.tree[i]
+		}
+	}
+	return f
+}
+
+// Len 返回元素数量
+// 时间复杂度: O(1)
+func (f *FenwickTree[T]) Len() int {
+	return len(f.tree) - 1
+}
+
+// Update 将下标 i 处的元素增加 delta
+// 时间复杂度: O(log n)
+func (f *FenwickTree[T]) Update(i int, delta T) {
+	f.checkIndex(i)
+	for i++; i < len(f.tree); i += i & -i {
+		f.tree[i] += delta
+	}
+}
+
+// PrefixSum 返回区间 [0, i] 内元素的和
+// 时间复杂度: O(log n)
+func (f *FenwickTree[T]) PrefixSum(i int) T {
+	f.checkIndex(i)
+	var sum T
+	for i++; i > 0; i -= i & -i {
+		sum += f.tree[i]
+	}
+	return sum
+}
+
+// RangeSum 返回闭区间 [i, j] 内元素的和
+// 时间复杂度: O(log n)
+func (f *FenwickTree[T]) RangeSum(i, j int) T {
+	if i > j {
+		panic("区间起点不能大于终点")
+	}
+	sum := f.PrefixSum(j)
+	if i > 0 {
+		sum -= f.PrefixSum(i - 1)
+	}
+	return sum
+}
+
+// Get 返回下标 i 处的元素
+// 时间复杂度: O(log n)
+func (f *FenwickTree[T]) Get(i int) T {
+	return f.RangeSum(i, i)
+}
+
+// Total 返回所有元素的和
+// 时间复杂度: O(log n)
+func (f *FenwickTree[T]) Total() T {
+	if f.Len() == 0 {
+		return 0
+	}
+	return f.PrefixSum(f.Len() - 1)
+}
+
+// LowerBound 返回满足 PrefixSum(i) >= target 的最小下标 i
+// 要求所有元素非负，此时前缀和单调不减；例如元素表示各值的出现次数时，
+// LowerBound(k) 即为第 k 小的值
+// 参数：
+//   - target: 目标累计和
+//
+// 返回值：
+//   - int: 满足条件的最小下标
+//   - bool: 所有元素的和小于 target 时返回 false
+//
+// 时间复杂度: O(log n)
+func (f *FenwickTree[T]) LowerBound(target T) (int, bool) {
+	n := f.Len()
+	if n == 0 {
+		return 0, false
+	}
+	// 从最高位开始倍增，pos 始终满足 tree 前 pos 项之和 < target
+	pos := 0
+	for step := 1 << (bits.Len(uint(n)) - 1); step > 0; step >>= 1 {
+		if next := pos + step; next <= n && f.tree[next] < target {
+			pos = next
+			target -= f.tree[next]
+		}
+	}
+	if pos == n {
+		return 0, false
+	}
+	return pos, true
+}
+
+// checkIndex 检查下标是否越界
+func (f *FenwickTree[T]) checkIndex(i int) {
+	if i < 0 || i >= f.Len() {
+		panic("索引越界")
+	}
+}
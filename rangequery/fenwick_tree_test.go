@@ -0,0 +1,128 @@
+package rangequery
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// TestFenwickTreeSums 测试前缀和与区间和
+func TestFenwickTreeSums(t *testing.T) {
+	values := []int{3, 2, -1, 6, 5, 4, -3, 3, 7, 2}
+	built := NewFenwickTreeFrom(values)
+	updated := NewFenwickTree[int](len(values))
+	for i, v := range values {
+		updated.Update(i, v)
+	}
+
+	for name, f := range map[string]*FenwickTree[int]{"批量建树": built, "逐个更新": updated} {
+		t.Run(name, func(t *testing.T) {
+			prefix := 0
+			for i, v := range values {
+				prefix += v
+				if got := f.PrefixSum(i); got != prefix {
+					t.Errorf("PrefixSum(%d) = %d，期望 %d", i, got, prefix)
+				}
+				if got := f.Get(i); got != v {
+					t.Errorf("Get(%d) = %d，期望 %d", i, got, v)
+				}
+			}
+			if got := f.RangeSum(2, 5); got != 14 {
+				t.Errorf("RangeSum(2, 5) = %d，期望 14", got)
+			}
+			if f.Total() != prefix || f.Len() != len(values) {
+				t.Errorf("Total/Len错误: %d/%d", f.Total(), f.Len())
+			}
+		})
+	}
+}
+
+// TestFenwickTreeRandom 与朴素实现对比随机更新和查询
+func TestFenwickTreeRandom(t *testing.T) {
+	const n = 200
+	r := rand.New(rand.NewSource(1))
+	naive := make([]float64, n)
+	f := NewFenwickTree[float64](n)
+	for round := 0; round < 2000; round++ {
+		i := r.Intn(n)
+		delta := float64(r.Intn(21) - 10)
+		naive[i] += delta
+		f.Update(i, delta)
+
+		lo, hi := r.Intn(n), r.Intn(n)
+		if lo > hi {
+			lo, hi = hi, lo
+		}
+		want := 0.0
+		for k := lo; k <= hi; k++ {
+			want += naive[k]
+		}
+		if got := f.RangeSum(lo, hi); got != want {
+			t.Fatalf("RangeSum(%d, %d) = %v，期望 %v", lo, hi, got, want)
+		}
+	}
+}
+
+// TestFenwickTreeLowerBound 测试按累计和查找下标
+func TestFenwickTreeLowerBound(t *testing.T) {
+	// 下标表示值，元素表示出现次数：值1出现2次，值3出现1次，值4出现3次
+	f := NewFenwickTreeFrom([]int{0, 2, 0, 1, 3, 0})
+	cases := []struct {
+		k    int
+		want int
+	}{{1, 1}, {2, 1}, {3, 3}, {4, 4}, {6, 4}}
+	for _, c := range cases {
+		if got, ok := f.LowerBound(c.k); !ok || got != c.want {
+			t.Errorf("LowerBound(%d) = (%d, %v)，期望 (%d, true)", c.k, got, ok, c.want)
+		}
+	}
+	if _, ok := f.LowerBound(7); ok {
+		t.Error("目标超过总和时应返回false")
+	}
+	if _, ok := NewFenwickTree[int](0).LowerBound(1); ok {
+		t.Error("空树应返回false")
+	}
+
+	f.Update(4, -3)
+	if got, _ := f.LowerBound(3); got != 3 {
+		t.Errorf("更新后LowerBound(3) = %d，期望 3", got)
+	}
+	if _, ok := f.LowerBound(4); ok {
+		t.Error("更新后目标超过总和时应返回false")
+	}
+}
+
+// TestFenwickTreeInvalidArgs 测试非法参数
+func TestFenwickTreeInvalidArgs(t *testing.T) {
+	f := NewFenwickTree[int](3)
+	cases := map[string]func(){
+		"负数长度": func() { NewFenwickTree[int](-1) },
+		"下标越界": func() { f.Update(3, 1) },
+		"负数下标": func() { f.PrefixSum(-1) },
+		"区间反转": func() { f.RangeSum(2, 1) },
+	}
+	for name, fn := range cases {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Error("非法参数应该导致panic")
+				}
+			}()
+			fn()
+		})
+	}
+}
+
+// 性能测试
+func BenchmarkFenwickTree(b *testing.B) {
+	f := NewFenwickTree[int](1 << 16)
+	b.Run("更新", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			f.Update(i&(1<<16-1), 1)
+		}
+	})
+	b.Run("前缀和", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			f.PrefixSum(i & (1<<16 - 1))
+		}
+	})
+}
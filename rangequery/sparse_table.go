@@ -0,0 +1,57 @@
+package rangequery
+
+import "math/bits"
+
+// SparseTable 稀疏表，用于静态数组的区间查询
+// 要求合并函数满足结合律和幂等性（combine(x, x) == x），例如 min、max、gcd、按位与/或，
+// 此时任意区间都可以由两个可能重叠的 2 的幂长度区间合并得到
+// 构建后不可修改
+type SparseTable[T any] struct {
+	table   [][]T          // table[k][i] 为区间 [i, i+2^k) 的合并结果
+	combine func(a, b T) T // 合并函数
+}
+
+// NewSparseTable 使用给定的数组和合并函数构建稀疏表
+// 参数：
+//   - values: 初始数组，构建时会被复制
+//   - combine: 满足结合律和幂等性的合并函数
+//
+// 时间复杂度: O(n log n)
+func NewSparseTable[T any](values []T, combine func(a, b T) T) *SparseTable[T] {
+	n := len(values)
+	levels := 1
+	if n > 0 {
+		levels = bits.Len(uint(n))
+	}
+	table := make([][]T, levels)
+	table[0] = append([]T(nil), values...)
+	for k := 1; k < levels; k++ {
+		half := 1 << (k - 1)
+		prev := table[k-1]
+		row := make([]T, n-(1<<k)+1)
+		for i := range row {
+			row[i] = combine(prev[i], prev[i+half])
+		}
+		table[k] = row
+	}
+	return &SparseTable[T]{table: table, combine: combine}
+}
+
+// Len 返回数组长度
+// 时间复杂度: O(1)
+func (st *SparseTable[T]) Len() int {
+	return len(st.table[0])
+}
+
+// Query 返回闭区间 [i, j] 内所有元素的合并结果
+// 时间复杂度: O(1)
+func (st *SparseTable[T]) Query(i, j int) T {
+	if i < 0 || j >= st.Len() {
+		panic("索引越界")
+	}
+	if i > j {
+		panic("区间起点不能大于终点")
+	}
+	k := bits.Len(uint(j-i+1)) - 1
+	return st.combine(st.table[k][i], st.table[k][j-(1<<k)+1])
+}
@@ -0,0 +1,114 @@
+package rangequery
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// gcd 最大公约数
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+// TestSparseTable 测试区间最小值、最大值和最大公约数查询
+func TestSparseTable(t *testing.T) {
+	values := []int{5, 2, 8, 6, 3, 7, 4, 1, 9}
+	minTable := NewSparseTable(values, func(a, b int) int { return min(a, b) })
+	maxTable := NewSparseTable(values, func(a, b int) int { return max(a, b) })
+
+	cases := []struct {
+		i, j     int
+		min, max int
+	}{
+		{0, 0, 5, 5},
+		{0, 8, 1, 9},
+		{1, 4, 2, 8},
+		{4, 6, 3, 7},
+		{2, 7, 1, 8},
+	}
+	for _, c := range cases {
+		if got := minTable.Query(c.i, c.j); got != c.min {
+			t.Errorf("min[%d, %d] = %d，期望 %d", c.i, c.j, got, c.min)
+		}
+		if got := maxTable.Query(c.i, c.j); got != c.max {
+			t.Errorf("max[%d, %d] = %d，期望 %d", c.i, c.j, got, c.max)
+		}
+	}
+
+	gcdTable := NewSparseTable([]int{12, 18, 24, 9, 30}, gcd)
+	if got := gcdTable.Query(0, 2); got != 6 {
+		t.Errorf("gcd[0, 2] = %d，期望 6", got)
+	}
+	if got := gcdTable.Query(0, 4); got != 3 {
+		t.Errorf("gcd[0, 4] = %d，期望 3", got)
+	}
+
+	// 构建后修改原数组不影响查询结果
+	values[0] = -1
+	if minTable.Query(0, 1) != 2 {
+		t.Error("稀疏表应复制初始数组")
+	}
+}
+
+// TestSparseTableRandom 与朴素实现对比所有区间
+func TestSparseTableRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 7, 64, 100} {
+		values := make([]int, n)
+		for i := range values {
+			values[i] = r.Intn(1000)
+		}
+		st := NewSparseTable(values, func(a, b int) int { return min(a, b) })
+		if st.Len() != n {
+			t.Errorf("期望长度为%d，实际为%d", n, st.Len())
+		}
+		for i := 0; i < n; i++ {
+			want := values[i]
+			for j := i; j < n; j++ {
+				want = min(want, values[j])
+				if got := st.Query(i, j); got != want {
+					t.Fatalf("n=%d: Query(%d, %d) = %d，期望 %d", n, i, j, got, want)
+				}
+			}
+		}
+	}
+}
+
+// TestSparseTableInvalidArgs 测试非法参数
+func TestSparseTableInvalidArgs(t *testing.T) {
+	st := NewSparseTable([]int{1, 2, 3}, func(a, b int) int { return min(a, b) })
+	empty := NewSparseTable([]int{}, func(a, b int) int { return min(a, b) })
+	cases := map[string]func(){
+		"下标越界": func() { st.Query(0, 3) },
+		"负数下标": func() { st.Query(-1, 1) },
+		"区间反转": func() { st.Query(2, 1) },
+		"空表查询": func() { empty.Query(0, 0) },
+	}
+	for name, fn := range cases {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Error("非法参数应该导致panic")
+				}
+			}()
+			fn()
+		})
+	}
+}
+
+// 性能测试
+func BenchmarkSparseTable(b *testing.B) {
+	values := make([]int, 1<<16)
+	for i := range values {
+		values[i] = i * 7 % 1000
+	}
+	st := NewSparseTable(values, func(a, b int) int { return min(a, b) })
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		l := i & (1<<15 - 1)
+		st.Query(l, l+1<<14)
+	}
+}
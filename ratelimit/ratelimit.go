@@ -0,0 +1,14 @@
+package ratelimit
+
+import "time"
+
+// Limiter 限流器接口
+type Limiter interface {
+	Allow() bool                      // 判断当前时刻是否允许一个请求通过
+	AllowN(now time.Time, n int) bool // 判断 now 时刻是否允许 n 个请求同时通过
+}
+
+var (
+	_ Limiter = (*TokenBucket)(nil)
+	_ Limiter = (*SlidingWindowLog)(nil)
+)
@@ -0,0 +1,54 @@
+package ratelimit
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// TestLimiterConcurrent 测试并发调用时通过的请求数不超过上限
+func TestLimiterConcurrent(t *testing.T) {
+	limiters := map[string]Limiter{
+		"令牌桶":    NewTokenBucket(0.001, 100),
+		"滑动窗口日志": NewSlidingWindowLog(100, time.Hour),
+	}
+	for name, l := range limiters {
+		t.Run(name, func(t *testing.T) {
+			var allowed atomic.Int64
+			var wg sync.WaitGroup
+			for g := 0; g < 8; g++ {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					for i := 0; i < 50; i++ {
+						if l.Allow() {
+							allowed.Add(1)
+						}
+					}
+				}()
+			}
+			wg.Wait()
+			if got := allowed.Load(); got != 100 {
+				t.Errorf("期望通过100个请求，实际为%d", got)
+			}
+		})
+	}
+}
+
+// 性能测试
+func BenchmarkLimiter(b *testing.B) {
+	now := time.Unix(1000, 0)
+	b.Run("令牌桶", func(b *testing.B) {
+		tb := NewTokenBucket(1e9, 1000)
+		for i := 0; i < b.N; i++ {
+			tb.AllowN(now.Add(time.Duration(i)), 1)
+		}
+	})
+	b.Run("滑动窗口日志", func(b *testing.B) {
+		sw := NewSlidingWindowLog(1000, time.Microsecond)
+		for i := 0; i < b.N; i++ {
+			sw.AllowN(now.Add(time.Duration(i)), 1)
+		}
+	})
+}
@@ -0,0 +1,79 @@
+package ratelimit
+
+import (
+	"sync"
+	"time"
+
+	"godatastructure/queue"
+)
+
+// SlidingWindowLog 滑动窗口日志限流器
+// 使用双端队列按时间顺序记录窗口内每个请求的时间戳，
+// 任意长度为 window 的时间段内最多允许 limit 个请求，没有固定窗口在边界处的突发问题
+// 内存占用与 limit 成正比；并发安全
+type SlidingWindowLog struct {
+	mu     sync.Mutex
+	limit  int                    // 窗口内允许的最大请求数
+	window time.Duration          // 窗口长度
+	log    queue.Deque[time.Time] // 窗口内请求的时间戳，队首最早
+}
+
+// NewSlidingWindowLog 创建滑动窗口日志限流器
+// 参数：
+//   - limit: 窗口内允许的最大请求数，必须大于0
+//   - window: 窗口长度，必须大于0
+//
+// 时间复杂度: O(1)
+func NewSlidingWindowLog(limit int, window time.Duration) *SlidingWindowLog {
+	if limit <= 0 {
+		panic("请求数上限必须大于0")
+	}
+	if window <= 0 {
+		panic("窗口长度必须大于0")
+	}
+	return &SlidingWindowLog{limit: limit, window: window, log: queue.NewDeque[time.Time]()}
+}
+
+// Allow 判断当前时刻是否允许一个请求通过
+// 时间复杂度: 均摊 O(1)
+func (sw *SlidingWindowLog) Allow() bool {
+	return sw.AllowN(time.Now(), 1)
+}
+
+// AllowN 判断 now 时刻是否允许 n 个请求同时通过，允许时记录 n 个时间戳
+// 时间复杂度: 均摊 O(n)
+func (sw *SlidingWindowLog) AllowN(now time.Time, n int) bool {
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+
+	sw.expire(now)
+	if sw.log.Size()+n > sw.limit {
+		return false
+	}
+	for i := 0; i < n; i++ {
+		sw.log.PushBack(now)
+	}
+	return true
+}
+
+// Count 返回 now 时刻窗口内已通过的请求数
+// 时间复杂度: 均摊 O(1)
+func (sw *SlidingWindowLog) Count(now time.Time) int {
+	sw.mu.Lock()
+	defer sw.mu.Unlock()
+
+	sw.expire(now)
+	return sw.log.Size()
+}
+
+// expire 移除已滑出窗口 (now-window, now] 的时间戳
+func (sw *SlidingWindowLog) expire(now time.Time) {
+	boundary := now.Add(-sw.window)
+	for !sw.log.IsEmpty() {
+		oldest, _ := sw.log.Front()
+		if oldest.After(boundary) {
+			break
+		}
+		sw.log.PopFront()
+	}
+}
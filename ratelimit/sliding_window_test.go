@@ -0,0 +1,63 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+)
+
+// TestSlidingWindowLog 测试窗口内的请求数限制和窗口滑动
+func TestSlidingWindowLog(t *testing.T) {
+	sw := NewSlidingWindowLog(3, time.Second)
+	start := time.Unix(1000, 0)
+
+	if !sw.AllowN(start, 2) {
+		t.Fatal("前2个请求应该通过")
+	}
+	if !sw.AllowN(start.Add(500*time.Millisecond), 1) {
+		t.Fatal("第3个请求应该通过")
+	}
+	if sw.AllowN(start.Add(900*time.Millisecond), 1) {
+		t.Error("窗口内已有3个请求，第4个不应通过")
+	}
+
+	// 最早的2个请求恰好滑出窗口
+	now := start.Add(time.Second)
+	if got := sw.Count(now); got != 1 {
+		t.Errorf("期望窗口内有1个请求，实际为%d", got)
+	}
+	if sw.AllowN(now, 3) {
+		t.Error("超出剩余额度的批量请求不应通过")
+	}
+	if !sw.AllowN(now, 2) {
+		t.Error("窗口滑动后应允许2个请求")
+	}
+	if sw.Count(now) != 3 {
+		t.Errorf("期望窗口内有3个请求，实际为%d", sw.Count(now))
+	}
+
+	// 与固定窗口不同，边界两侧不会出现双倍突发
+	if sw.AllowN(start.Add(1400*time.Millisecond), 1) {
+		t.Error("滑动窗口内请求数已满，不应通过")
+	}
+	if !sw.AllowN(start.Add(1500*time.Millisecond), 1) {
+		t.Error("第3个请求滑出窗口后应该通过")
+	}
+}
+
+// TestSlidingWindowLogInvalidArgs 测试非法参数
+func TestSlidingWindowLogInvalidArgs(t *testing.T) {
+	cases := map[string]func(){
+		"上限为0":   func() { NewSlidingWindowLog(0, time.Second) },
+		"窗口长度为0": func() { NewSlidingWindowLog(1, 0) },
+	}
+	for name, fn := range cases {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Error("非法参数应该导致panic")
+				}
+			}()
+			fn()
+		})
+	}
+}
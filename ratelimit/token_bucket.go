@@ -0,0 +1,77 @@
+package ratelimit
+
+import (
+	"sync"
+	"time"
+)
+
+// TokenBucket 令牌桶限流器
+// 令牌以固定速率生成，桶中最多保存 burst 个令牌，每个请求消耗一个令牌，
+// 因此允许不超过 burst 的突发流量，长期平均速率不超过 rate
+// 并发安全
+type TokenBucket struct {
+	mu     sync.Mutex
+	rate   float64   // 每秒生成的令牌数
+	burst  float64   // 桶容量
+	tokens float64   // 当前令牌数
+	last   time.Time // 上一次更新令牌数的时间
+}
+
+// NewTokenBucket 创建令牌桶限流器，初始时桶是满的
+// 参数：
+//   - rate: 每秒生成的令牌数，必须大于0
+//   - burst: 桶容量，必须大于0
+//
+// 时间复杂度: O(1)
+func NewTokenBucket(rate float64, burst int) *TokenBucket {
+	if rate <= 0 {
+		panic("令牌生成速率必须大于0")
+	}
+	if burst <= 0 {
+		panic("桶容量必须大于0")
+	}
+	return &TokenBucket{rate: rate, burst: float64(burst), tokens: float64(burst)}
+}
+
+// Allow 判断当前时刻是否允许一个请求通过
+// 时间复杂度: O(1)
+func (tb *TokenBucket) Allow() bool {
+	return tb.AllowN(time.Now(), 1)
+}
+
+// AllowN 判断 now 时刻是否允许 n 个请求同时通过，允许时消耗 n 个令牌
+// 时间早于上一次调用时按上一次调用的时间计算
+// 时间复杂度: O(1)
+func (tb *TokenBucket) AllowN(now time.Time, n int) bool {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+
+	tb.refill(now)
+	if tb.tokens < float64(n) {
+		return false
+	}
+	tb.tokens -= float64(n)
+	return true
+}
+
+// Tokens 返回 now 时刻桶中可用的令牌数
+// 时间复杂度: O(1)
+func (tb *TokenBucket) Tokens(now time.Time) float64 {
+	tb.mu.Lock()
+	defer tb.mu.Unlock()
+
+	tb.refill(now)
+	return tb.tokens
+}
+
+// refill 按经过的时间补充令牌
+func (tb *TokenBucket) refill(now time.Time) {
+	if tb.last.IsZero() {
+		tb.last = now
+		return
+	}
+	if elapsed := now.Sub(tb.last); elapsed > 0 {
+		tb.tokens = min(tb.burst, tb.tokens+elapsed.Seconds()*tb.rate)
+		tb.last = now
+	}
+}
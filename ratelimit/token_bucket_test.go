@@ -0,0 +1,73 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+)
+
+// TestTokenBucket 测试突发流量和令牌补充
+func TestTokenBucket(t *testing.T) {
+	tb := NewTokenBucket(10, 5) // 每秒10个令牌，容量5
+	start := time.Unix(1000, 0)
+
+	t.Run("突发流量", func(t *testing.T) {
+		for i := 0; i < 5; i++ {
+			if !tb.AllowN(start, 1) {
+				t.Fatalf("第%d个请求应该通过", i+1)
+			}
+		}
+		if tb.AllowN(start, 1) {
+			t.Error("令牌耗尽后请求不应通过")
+		}
+	})
+
+	t.Run("令牌补充", func(t *testing.T) {
+		now := start.Add(250 * time.Millisecond) // 补充2.5个令牌
+		if !tb.AllowN(now, 2) {
+			t.Error("补充后应允许2个请求")
+		}
+		if tb.AllowN(now, 1) {
+			t.Error("剩余0.5个令牌不应允许请求")
+		}
+		if got := tb.Tokens(now); got < 0.49 || got > 0.51 {
+			t.Errorf("期望剩余0.5个令牌，实际为%v", got)
+		}
+	})
+
+	t.Run("容量上限", func(t *testing.T) {
+		now := start.Add(time.Hour)
+		if got := tb.Tokens(now); got != 5 {
+			t.Errorf("令牌数不应超过容量，实际为%v", got)
+		}
+		if tb.AllowN(now, 6) {
+			t.Error("超过容量的请求数不应通过")
+		}
+		if !tb.AllowN(now, 5) {
+			t.Error("等于容量的请求数应该通过")
+		}
+	})
+
+	t.Run("时间回退", func(t *testing.T) {
+		if tb.AllowN(start, 1) {
+			t.Error("时间回退不应补充令牌")
+		}
+	})
+}
+
+// TestTokenBucketInvalidArgs 测试非法参数
+func TestTokenBucketInvalidArgs(t *testing.T) {
+	cases := map[string]func(){
+		"速率为0": func() { NewTokenBucket(0, 1) },
+		"容量为0": func() { NewTokenBucket(1, 0) },
+	}
+	for name, fn := range cases {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r == nil {
+					t.Error("非法参数应该导致panic")
+				}
+			}()
+			fn()
+		})
+	}
+}
@@ -0,0 +1,200 @@
+package rbtree
+
+import (
+	"fmt"
+	"iter"
+	"unsafe"
+
+	"golang.org/x/exp/constraints"
+)
+
+// Interval 闭区间 [Low, High]
+type Interval[T constraints.Ordered] struct {
+	Low  T // 下界
+	High T // 上界
+}
+
+// Overlaps 检查两个闭区间是否有公共部分
+func (iv Interval[T]) Overlaps(other Interval[T]) bool {
+	return iv.Low <= other.High && other.Low <= iv.High
+}
+
+// intervalNode 区间树的节点，红黑树节点的值为区间的下界
+// Node 必须是第一个字段，使 *Node[T] 可以转换回 *intervalNode[T]
+type intervalNode[T constraints.Ordered] struct {
+	Node[T]
+	high    T // 区间的上界
+	maxHigh T // 以该节点为根的子树中所有区间上界的最大值
+}
+
+// intervalOf 返回包含红黑树节点 n 的区间树节点，n 必须由 IntervalTree 创建
+func intervalOf[T constraints.Ordered](n *Node[T]) *intervalNode[T] {
+	return (*intervalNode[T])(unsafe.Pointer(n))
+}
+
+// IntervalTree 基于红黑树的区间树，可以查询与给定区间重叠的所有区间
+// 节点按区间的下界排序，并额外记录子树中上界的最大值，插入、删除和旋转时与子树大小一起维护；
+// 查询时跳过最大上界小于查询下界的子树。同一区间可以插入多次
+type IntervalTree[T constraints.Ordered] struct {
+	tree Tree[T] // 按下界排序的红黑树，节点均为 intervalNode
+}
+
+// NewIntervalTree 创建空的区间树
+// 时间复杂度: O(1)
+func NewIntervalTree[T constraints.Ordered]() *IntervalTree[T] {
+	it := &IntervalTree[T]{}
+	it.tree.augment = updateMaxHigh[T]
+	return it
+}
+
+// updateMaxHigh 由节点自身和子节点重新计算子树中上界的最大值
+func updateMaxHigh[T constraints.Ordered](n *Node[T]) {
+	in := intervalOf(n)
+	in.maxHigh = in.high
+	for _, child := range []*Node[T]{n.Left, n.Right} {
+		if child != nil {
+			in.maxHigh = max(in.maxHigh, intervalOf(child).maxHigh)
+		}
+	}
+}
+
+// Insert 插入闭区间 [low, high]，low 大于 high 时 panic
+// 时间复杂度: O(log n)
+func (it *IntervalTree[T]) Insert(low, high T) {
+	if low > high {
+		panic(fmt.Sprintf("区间的下界%v大于上界%v", low, high))
+	}
+	n := &intervalNode[T]{Node: Node[T]{Value: low}, high: high, maxHigh: high}
+	it.tree.insertNode(&n.Node)
+}
+
+// Delete 删除一个闭区间 [low, high]，返回区间是否存在
+// 时间复杂度: O(log n + k)，k 为下界等于 low 的区间数量
+func (it *IntervalTree[T]) Delete(low, high T) bool {
+	for n := it.tree.upperNode(low, true); n != nil && n.Value == low; n = successor(n) {
+		if intervalOf(n).high == high {
+			it.tree.removeNode(n)
+			return true
+		}
+	}
+	return false
+}
+
+// Overlaps 返回与闭区间 [low, high] 重叠的所有区间，按下界升序排列
+// 时间复杂度: O(min(n, k·log n))，k 为结果中的区间数量
+func (it *IntervalTree[T]) Overlaps(low, high T) []Interval[T] {
+	var result []Interval[T]
+	it.overlaps(it.tree.Root, low, high, func(iv Interval[T]) bool {
+		result = append(result, iv)
+		return true
+	})
+	return result
+}
+
+// Stab 返回包含 point 的所有区间，按下界升序排列
+// 时间复杂度: O(min(n, k·log n))，k 为结果中的区间数量
+func (it *IntervalTree[T]) Stab(point T) []Interval[T] {
+	return it.Overlaps(point, point)
+}
+
+// AnyOverlap 返回任意一个与闭区间 [low, high] 重叠的区间，不存在时第二个返回值为 false
+// 适合只需要判断是否冲突的场景
+// 时间复杂度: O(log n)
+func (it *IntervalTree[T]) AnyOverlap(low, high T) (Interval[T], bool) {
+	query := Interval[T]{low, high}
+	n := it.tree.Root
+	for n != nil {
+		if iv := intervalAt(n); iv.Overlaps(query) {
+			return iv, true
+		}
+		// 左子树中的最大上界不小于 low 时，若左子树中没有重叠的区间，右子树中也不会有
+		if n.Left != nil && intervalOf(n.Left).maxHigh >= low {
+			n = n.Left
+		} else {
+			n = n.Right
+		}
+	}
+	return Interval[T]{}, false
+}
+
+// overlaps 按下界升序把子树 n 中与 [low, high] 重叠的区间传给 fn，fn 返回 false 时停止
+// 返回 false 表示遍历被提前终止
+func (it *IntervalTree[T]) overlaps(n *Node[T], low, high T, fn func(Interval[T]) bool) bool {
+	// 子树中所有区间的上界都小于 low 时没有重叠的区间
+	if n == nil || intervalOf(n).maxHigh < low {
+		return true
+	}
+	if !it.overlaps(n.Left, low, high, fn) {
+		return false
+	}
+	// 右子树中区间的下界都不小于当前节点，当前节点的下界已大于 high 时可以跳过
+	if n.Value > high {
+		return true
+	}
+	if intervalOf(n).high >= low && !fn(intervalAt(n)) {
+		return false
+	}
+	return it.overlaps(n.Right, low, high, fn)
+}
+
+// intervalAt 返回节点 n 保存的区间
+func intervalAt[T constraints.Ordered](n *Node[T]) Interval[T] {
+	return Interval[T]{n.Value, intervalOf(n).high}
+}
+
+// All 返回按下界升序遍历所有区间的迭代器
+// 遍历过程中不应修改区间树
+func (it *IntervalTree[T]) All() iter.Seq[Interval[T]] {
+	return func(yield func(Interval[T]) bool) {
+		if it.tree.Root == nil {
+			return
+		}
+		for n := minimum(it.tree.Root); n != nil; n = successor(n) {
+			if !yield(intervalAt(n)) {
+				return
+			}
+		}
+	}
+}
+
+// Size 返回区间数量
+// 时间复杂度: O(1)
+func (it *IntervalTree[T]) Size() int {
+	return it.tree.size
+}
+
+// IsEmpty 检查区间树是否为空
+// 时间复杂度: O(1)
+func (it *IntervalTree[T]) IsEmpty() bool {
+	return it.tree.size == 0
+}
+
+// Clear 清空区间树
+// 时间复杂度: O(1)
+func (it *IntervalTree[T]) Clear() {
+	it.tree.Clear()
+}
+
+// Validate 检查底层红黑树的结构不变量，以及每个节点记录的最大上界
+// 时间复杂度: O(n)
+func (it *IntervalTree[T]) Validate() error {
+	if err := it.tree.Validate(); err != nil {
+		return err
+	}
+	if it.tree.Root == nil {
+		return nil
+	}
+	for n := minimum(it.tree.Root); n != nil; n = successor(n) {
+		in := intervalOf(n)
+		want := in.high
+		for _, child := range []*Node[T]{n.Left, n.Right} {
+			if child != nil {
+				want = max(want, intervalOf(child).maxHigh)
+			}
+		}
+		if in.maxHigh != want {
+			return fmt.Errorf("区间 [%v, %v] 记录的最大上界为%v，实际为%v", n.Value, in.high, in.maxHigh, want)
+		}
+	}
+	return nil
+}
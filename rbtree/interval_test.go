@@ -0,0 +1,146 @@
+package rbtree
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// TestIntervalTree 测试区间树的插入、删除和重叠查询
+func TestIntervalTree(t *testing.T) {
+	t.Run("空树", func(t *testing.T) {
+		it := NewIntervalTree[int]()
+		if !it.IsEmpty() || it.Size() != 0 {
+			t.Error("新建的区间树应为空")
+		}
+		if got := it.Overlaps(0, 10); len(got) != 0 {
+			t.Errorf("空树不应有重叠的区间，实际为%v", got)
+		}
+		if _, ok := it.AnyOverlap(0, 10); ok {
+			t.Error("空树不应有重叠的区间")
+		}
+		if it.Delete(1, 2) {
+			t.Error("删除不存在的区间应返回 false")
+		}
+	})
+
+	t.Run("基本查询", func(t *testing.T) {
+		it := NewIntervalTree[int]()
+		for _, iv := range [][2]int{{15, 20}, {10, 30}, {17, 19}, {5, 20}, {12, 15}, {30, 40}} {
+			it.Insert(iv[0], iv[1])
+		}
+		want := []Interval[int]{{5, 20}, {10, 30}, {12, 15}}
+		if got := it.Overlaps(6, 14); !slices.Equal(got, want) {
+			t.Errorf("Overlaps(6, 14) = %v，期望 %v", got, want)
+		}
+		want = []Interval[int]{{10, 30}, {30, 40}}
+		if got := it.Stab(30); !slices.Equal(got, want) {
+			t.Errorf("Stab(30) = %v，期望 %v", got, want)
+		}
+		if got := it.Overlaps(41, 50); len(got) != 0 {
+			t.Errorf("Overlaps(41, 50) 应为空，实际为%v", got)
+		}
+		if iv, ok := it.AnyOverlap(21, 25); !ok || iv != (Interval[int]{10, 30}) {
+			t.Errorf("AnyOverlap(21, 25) = %v, %v，期望 [10 30]", iv, ok)
+		}
+		if err := it.Validate(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("重复区间", func(t *testing.T) {
+		it := NewIntervalTree[int]()
+		it.Insert(1, 5)
+		it.Insert(1, 5)
+		it.Insert(1, 3)
+		if it.Size() != 3 {
+			t.Errorf("Size() = %d，期望 3", it.Size())
+		}
+		if !it.Delete(1, 5) || it.Size() != 2 {
+			t.Error("应只删除一个重复的区间")
+		}
+		if it.Delete(1, 4) {
+			t.Error("上界不同的区间不应被删除")
+		}
+		if got := it.Stab(4); len(got) != 1 {
+			t.Errorf("Stab(4) 应只剩一个区间，实际为%v", got)
+		}
+		if err := it.Validate(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	t.Run("下界大于上界", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("下界大于上界时应 panic")
+			}
+		}()
+		NewIntervalTree[int]().Insert(2, 1)
+	})
+
+	t.Run("清空", func(t *testing.T) {
+		it := NewIntervalTree[int]()
+		it.Insert(1, 2)
+		it.Clear()
+		if !it.IsEmpty() || len(it.Stab(1)) != 0 {
+			t.Error("清空后区间树应为空")
+		}
+	})
+}
+
+// TestIntervalTreeRandom 随机插入、删除，并与暴力查找的结果比较
+func TestIntervalTreeRandom(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	it := NewIntervalTree[int]()
+	var all []Interval[int]
+	for i := range 2000 {
+		if len(all) > 0 && rng.Intn(3) == 0 {
+			j := rng.Intn(len(all))
+			if !it.Delete(all[j].Low, all[j].High) {
+				t.Fatalf("删除已插入的区间 %v 失败", all[j])
+			}
+			all = slices.Delete(all, j, j+1)
+		} else {
+			lo := rng.Intn(1000)
+			iv := Interval[int]{lo, lo + rng.Intn(100)}
+			it.Insert(iv.Low, iv.High)
+			all = append(all, iv)
+		}
+		if i%100 == 0 {
+			if err := it.Validate(); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		lo := rng.Intn(1100)
+		query := Interval[int]{lo, lo + rng.Intn(50)}
+		var want []Interval[int]
+		for _, iv := range all {
+			if iv.Overlaps(query) {
+				want = append(want, iv)
+			}
+		}
+		got := it.Overlaps(query.Low, query.High)
+		cmp := func(a, b Interval[int]) int {
+			if a.Low != b.Low {
+				return a.Low - b.Low
+			}
+			return a.High - b.High
+		}
+		slices.SortFunc(want, cmp)
+		slices.SortFunc(got, cmp)
+		if !slices.Equal(got, want) {
+			t.Fatalf("Overlaps(%d, %d) = %v，期望 %v", query.Low, query.High, got, want)
+		}
+		if iv, ok := it.AnyOverlap(query.Low, query.High); ok != (len(want) > 0) || (ok && !iv.Overlaps(query)) {
+			t.Fatalf("AnyOverlap(%d, %d) = %v, %v", query.Low, query.High, iv, ok)
+		}
+	}
+	if it.Size() != len(all) {
+		t.Errorf("Size() = %d，期望 %d", it.Size(), len(all))
+	}
+	if got := slices.Collect(it.All()); len(got) != len(all) {
+		t.Errorf("All 遍历了%d个区间，期望 %d", len(got), len(all))
+	}
+}
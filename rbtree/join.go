@@ -0,0 +1,173 @@
+package rbtree
+
+import (
+	"errors"
+
+	"golang.org/x/exp/constraints"
+)
+
+var (
+	ErrJoinOrder = errors.New("合并的树与当前树的元素范围重叠")
+)
+
+// Join 将 other 中的所有元素并入当前红黑树，合并后 other 变为空树
+// 要求 other 中的所有元素都大于等于当前树的最大元素，AllowDuplicates 以外的模式下要求严格大于。取出 other 的最小节点作为连接节点，
+// 沿较高一侧的边界下降到黑高度相同的位置挂上较矮的树，再按插入的方式修复红黑树性质
+// 参数：
+//   - other: 待合并的红黑树
+//
+// 返回：
+//   - error: 不满足顺序要求时返回 ErrJoinOrder，两棵树均保持不变
+//
+// 时间复杂度: O(log n)
+func (t *Tree[T]) Join(other *Tree[T]) error {
+	if t == other || other.Root == nil {
+		return nil
+	}
+	if maxValue, ok := t.Max(); ok {
+		if minValue, _ := other.Min(); minValue < maxValue || t.dup != AllowDuplicates && minValue == maxValue {
+			return ErrJoinOrder
+		}
+	}
+	size := t.size + other.size
+	if t.Root == nil {
+		t.Root = other.Root
+	} else {
+		mid := minimum(other.Root)
+		other.deleteNode(mid)
+		t.Root, _ = join(t.Root, blackHeight(t.Root), mid, other.Root, blackHeight(other.Root))
+	}
+	t.size = size
+	other.Root = nil
+	other.size = 0
+	return nil
+}
+
+// Split 按 value 将红黑树分裂为两棵：left 包含所有小于 value 的元素，right 包含其余元素
+// 沿查找 value 的路径自底向上，把路径两侧的子树与路径上的节点依次连接起来。
+// 两棵新树继承重复值的处理方式，但不继承记录器和分配器；分裂后原树变为空树
+// 参数：
+//   - value: 分割值
+//
+// 返回：
+//   - left: 元素小于 value 的红黑树
+//   - right: 元素大于等于 value 的红黑树
+//
+// 时间复杂度: O(log n)
+func (t *Tree[T]) Split(value T) (left, right *Tree[T]) {
+	l, _, r, _ := split(t.Root, blackHeight(t.Root), value)
+	t.Root = nil
+	t.size = 0
+	return &Tree[T]{Root: l, size: sizeOf(l), dup: t.dup}, &Tree[T]{Root: r, size: sizeOf(r), dup: t.dup}
+}
+
+// split 按 value 分裂以 n 为根、黑高度为 h 的子树，返回两部分的根节点及各自的黑高度
+// 返回的根节点都是黑色
+func split[T constraints.Ordered](n *Node[T], h int, value T) (l *Node[T], lh int, r *Node[T], rh int) {
+	if n == nil {
+		return nil, 0, nil, 0
+	}
+	childH := h
+	if n.Color == BLACK {
+		childH--
+	}
+	left, right := detach(n.Left), detach(n.Right)
+	n.Left, n.Right = nil, nil
+	if n.Value < value {
+		rl, rlh, rr, rrh := split(right, childH, value)
+		l, lh = join(left, childH, n, rl, rlh)
+		return l, lh, rr, rrh
+	}
+	ll, llh, lr, lrh := split(left, childH, value)
+	r, rh = join(lr, lrh, n, right, childH)
+	return ll, llh, r, rh
+}
+
+// join 以 mid 为连接节点合并两棵子树，l 中的元素都不大于 mid，r 中的元素都不小于 mid
+// lh、rh 为两棵子树的黑高度，返回合并后的根节点及其黑高度
+// 时间复杂度: O(|lh-rh|+1)
+func join[T constraints.Ordered](l *Node[T], lh int, mid *Node[T], r *Node[T], rh int) (*Node[T], int) {
+	// 红色的根节点改为黑色不会破坏红黑树性质，只会使黑高度增加1
+	if colorOf(l) == RED {
+		l.Color = BLACK
+		lh++
+	}
+	if colorOf(r) == RED {
+		r.Color = BLACK
+		rh++
+	}
+
+	// 在较高的树中沿靠近另一棵树的边界下降，找到黑高度与较矮的树相同的黑色节点 c
+	var t Tree[T]
+	var parent *Node[T]
+	var c *Node[T]
+	h := max(lh, rh)
+	if lh >= rh {
+		t.Root, c = l, l
+		for h > rh || colorOf(c) == RED {
+			if c.Color == BLACK {
+				h--
+			}
+			parent, c = c, c.Right
+		}
+		mid.Left, mid.Right = c, r
+	} else {
+		t.Root, c = r, r
+		for h > lh || colorOf(c) == RED {
+			if c.Color == BLACK {
+				h--
+			}
+			parent, c = c, c.Left
+		}
+		mid.Left, mid.Right = l, c
+	}
+
+	// 用红色的 mid 替换 c，c 与较矮的树成为 mid 的子节点
+	mid.Parent = parent
+	if mid.Left != nil {
+		mid.Left.Parent = mid
+	}
+	if mid.Right != nil {
+		mid.Right.Parent = mid
+	}
+	mid.Color = RED
+	mid.size = sizeOf(mid.Left) + sizeOf(mid.Right) + mid.count
+	added := mid.size - sizeOf(c)
+	switch {
+	case parent == nil:
+		t.Root = mid
+	case lh >= rh:
+		parent.Right = mid
+	default:
+		parent.Left = mid
+	}
+	for n := parent; n != nil; n = n.Parent {
+		n.size += added
+	}
+
+	height := max(lh, rh)
+	if t.fixInsert(mid) {
+		height++
+	}
+	return t.Root, height
+}
+
+// detach 断开节点与父节点的连接并返回该节点
+func detach[T constraints.Ordered](n *Node[T]) *Node[T] {
+	if n != nil {
+		n.Parent = nil
+	}
+	return n
+}
+
+// blackHeight 返回从 n 到叶子节点的路径上黑色节点的数量（包含 n，不包含 nil 叶子节点）
+// 时间复杂度: O(log n)
+func blackHeight[T constraints.Ordered](n *Node[T]) int {
+	h := 0
+	for ; n != nil; n = n.Left {
+		if n.Color == BLACK {
+			h++
+		}
+	}
+	return h
+}
@@ -0,0 +1,108 @@
+package rbtree
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// TestSplitJoin 测试按值分裂与合并后红黑树的性质和元素
+func TestSplitJoin(t *testing.T) {
+	r := rand.New(rand.NewSource(4))
+	for round := 0; round < 50; round++ {
+		tree := NewTree[int]()
+		n := r.Intn(300)
+		var values []int
+		for i := 0; i < n; i++ {
+			v := r.Intn(200)
+			tree.Insert(v)
+			values = append(values, v)
+		}
+		slices.Sort(values)
+
+		pivot := r.Intn(220) - 10
+		left, right := tree.Split(pivot)
+		if !tree.IsEmpty() || tree.Root != nil {
+			t.Fatal("分裂后原树应为空")
+		}
+		validateRedBlackProperties(t, left)
+		validateRedBlackProperties(t, right)
+		idx, _ := slices.BinarySearch(values, pivot)
+		if got := slices.Collect(left.All()); !slices.Equal(got, values[:idx]) || left.Size() != idx {
+			t.Fatalf("Split(%d) 的左侧为%v，期望%v", pivot, got, values[:idx])
+		}
+		if got := slices.Collect(right.All()); !slices.Equal(got, values[idx:]) || right.Size() != n-idx {
+			t.Fatalf("Split(%d) 的右侧为%v，期望%v", pivot, got, values[idx:])
+		}
+
+		if err := left.Join(right); err != nil {
+			t.Fatal(err)
+		}
+		validateRedBlackProperties(t, left)
+		if got := slices.Collect(left.All()); !slices.Equal(got, values) || left.Size() != n {
+			t.Fatalf("合并后的元素为%v，期望%v", got, values)
+		}
+		if !right.IsEmpty() {
+			t.Fatal("合并后 other 应为空")
+		}
+		if n > 0 {
+			if v, _ := left.Kth(n / 2); v != values[n/2] {
+				t.Fatalf("合并后 Kth(%d) = %d，期望 %d", n/2, v, values[n/2])
+			}
+		}
+	}
+
+	t.Run("大小悬殊的树", func(t *testing.T) {
+		big, small := NewTree[int](), NewTree[int]()
+		for i := 0; i < 1000; i++ {
+			big.Insert(i)
+		}
+		small.Insert(1000)
+		small.Insert(1001)
+		if err := big.Join(small); err != nil {
+			t.Fatal(err)
+		}
+		validateRedBlackProperties(t, big)
+		small.Insert(-2)
+		small.Insert(-1)
+		if err := small.Join(big); err != nil {
+			t.Fatal(err)
+		}
+		validateRedBlackProperties(t, small)
+		if got := slices.Collect(small.All()); len(got) != 1004 || got[0] != -2 || got[1003] != 1001 || !slices.IsSorted(got) {
+			t.Errorf("合并后的元素不正确，大小为%d", len(got))
+		}
+	})
+
+	t.Run("顺序错误", func(t *testing.T) {
+		a, b := NewTree[int](), NewTree[int]()
+		for i := 0; i < 10; i++ {
+			a.Insert(i)
+			b.Insert(i + 5)
+		}
+		if err := a.Join(b); err != ErrJoinOrder {
+			t.Errorf("顺序错误的合并应返回ErrJoinOrder，实际为%v", err)
+		}
+		if a.Size() != 10 || b.Size() != 10 {
+			t.Error("合并失败时两棵树应保持不变")
+		}
+		empty := NewTree[int]()
+		if err := empty.Join(a); err != nil || empty.Size() != 10 || !a.IsEmpty() {
+			t.Error("空树合并应直接接管 other 的元素")
+		}
+	})
+}
+
+// BenchmarkSplitJoin 测试分裂后再合并的性能
+func BenchmarkSplitJoin(b *testing.B) {
+	tree := NewTree[int]()
+	for i := 0; i < 100000; i++ {
+		tree.Insert(i)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		left, right := tree.Split(i % 100000)
+		left.Join(right)
+		tree = left
+	}
+}
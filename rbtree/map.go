@@ -0,0 +1,208 @@
+package rbtree
+
+import (
+	"cmp"
+	"iter"
+	"unsafe"
+
+	"godatastructure/codec"
+	"golang.org/x/exp/constraints"
+)
+
+// mapNode Map 的节点，在红黑树节点之后保存键对应的值
+// Node 必须是第一个字段，使 *Node[K] 可以转换回 *mapNode[K, V]
+type mapNode[K constraints.Ordered, V any] struct {
+	Node[K]
+	value V
+}
+
+// entry 返回包含红黑树节点 n 的 Map 节点，n 必须由 Map 创建
+func entry[K constraints.Ordered, V any](n *Node[K]) *mapNode[K, V] {
+	return (*mapNode[K, V])(unsafe.Pointer(n))
+}
+
+// Map 基于红黑树的有序映射，键按升序存储
+// 与 Tree 共用插入、删除以及旋转和修复的逻辑，每个键只保存一个值
+type Map[K constraints.Ordered, V any] struct {
+	tree Tree[K] // 按键排序的红黑树，节点均为 mapNode
+}
+
+// NewMap 创建空的有序映射
+// 时间复杂度: O(1)
+func NewMap[K constraints.Ordered, V any]() *Map[K, V] {
+	return &Map[K, V]{}
+}
+
+// Put 插入键值对，键已存在时更新值
+// 时间复杂度: O(log n)
+func (m *Map[K, V]) Put(key K, value V) {
+	if n := m.tree.findNode(key); n != nil {
+		entry[K, V](n).value = value
+		return
+	}
+	n := &mapNode[K, V]{Node: Node[K]{Value: key}, value: value}
+	m.tree.insertNode(&n.Node)
+}
+
+// Get 获取键对应的值
+// 时间复杂度: O(log n)
+func (m *Map[K, V]) Get(key K) (V, bool) {
+	if n := m.tree.findNode(key); n != nil {
+		return entry[K, V](n).value, true
+	}
+	var zero V
+	return zero, false
+}
+
+// Contains 检查键是否存在
+// 时间复杂度: O(log n)
+func (m *Map[K, V]) Contains(key K) bool {
+	return m.tree.findNode(key) != nil
+}
+
+// Delete 删除键及其对应的值，返回键是否存在
+// 时间复杂度: O(log n)
+func (m *Map[K, V]) Delete(key K) bool {
+	n := m.tree.findNode(key)
+	if n == nil {
+		return false
+	}
+	m.tree.removeNode(n)
+	return true
+}
+
+// Len 返回键值对数量
+// 时间复杂度: O(1)
+func (m *Map[K, V]) Len() int {
+	return m.tree.size
+}
+
+// Size 返回键值对数量，与 Len 相同
+// 时间复杂度: O(1)
+func (m *Map[K, V]) Size() int {
+	return m.tree.size
+}
+
+// IsEmpty 检查映射是否为空
+// 时间复杂度: O(1)
+func (m *Map[K, V]) IsEmpty() bool {
+	return m.tree.size == 0
+}
+
+// Clear 清空映射
+// 时间复杂度: O(1)
+func (m *Map[K, V]) Clear() {
+	m.tree.Clear()
+}
+
+// Compare 按键的自然顺序比较两个键
+func (m *Map[K, V]) Compare(a, b K) int {
+	return cmp.Compare(a, b)
+}
+
+// All 返回按键升序遍历所有键值对的迭代器
+// 遍历过程中不应修改映射
+func (m *Map[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		if m.tree.Root == nil {
+			return
+		}
+		for n := minimum(m.tree.Root); n != nil; n = successor(n) {
+			if !yield(n.Value, entry[K, V](n).value) {
+				return
+			}
+		}
+	}
+}
+
+// Keys 返回按升序遍历所有键的迭代器
+func (m *Map[K, V]) Keys() iter.Seq[K] {
+	return m.tree.All()
+}
+
+// Values 返回按键升序遍历所有值的迭代器
+func (m *Map[K, V]) Values() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for _, v := range m.All() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Clone 返回结构和颜色都相同的深拷贝
+// copier 用于复制每个值，为 nil 时直接赋值；键直接赋值
+// 时间复杂度: O(n)
+func (m *Map[K, V]) Clone(copier func(V) V) *Map[K, V] {
+	clone := &Map[K, V]{}
+	clone.tree.Root = cloneMapNode(m.tree.Root, nil, copier)
+	clone.tree.size = m.tree.size
+	return clone
+}
+
+// cloneMapNode 递归复制以 n 为根的子树，parent 为复制后的父节点
+func cloneMapNode[K constraints.Ordered, V any](n, parent *Node[K], copier func(V) V) *Node[K] {
+	if n == nil {
+		return nil
+	}
+	value := entry[K, V](n).value
+	if copier != nil {
+		value = copier(value)
+	}
+	clone := &mapNode[K, V]{Node: Node[K]{Value: n.Value, Color: n.Color, Parent: parent, count: n.count, size: n.size}, value: value}
+	clone.Left = cloneMapNode(n.Left, &clone.Node, copier)
+	clone.Right = cloneMapNode(n.Right, &clone.Node, copier)
+	return &clone.Node
+}
+
+// MarshalBinary 使用 codec.For 返回的键、值编解码器把映射编码为二进制，按键升序编码
+// 实现 encoding.BinaryMarshaler 接口，encoding/gob 也会通过它编码映射
+func (m *Map[K, V]) MarshalBinary() ([]byte, error) {
+	return codec.EncodeSeq2(m.All(), codec.For[K](), codec.For[V]())
+}
+
+// UnmarshalBinary 从 MarshalBinary 生成的数据中解码，替换映射中的所有键值对
+// 解码失败时映射保持不变，实现 encoding.BinaryUnmarshaler 接口
+func (m *Map[K, V]) UnmarshalBinary(data []byte) error {
+	var keys []K
+	var values []V
+	err := codec.DecodeSeq2(data, codec.For[K](), codec.For[V](), func(key K, value V) {
+		keys = append(keys, key)
+		values = append(values, value)
+	})
+	if err != nil {
+		return err
+	}
+	m.load(keys, values)
+	return nil
+}
+
+// MarshalJSON 把映射编码为按键升序排列的 {"key": ..., "value": ...} 对象数组，实现 json.Marshaler 接口
+func (m *Map[K, V]) MarshalJSON() ([]byte, error) {
+	return codec.MarshalJSONSeq2(m.All())
+}
+
+// UnmarshalJSON 从 MarshalJSON 生成的 JSON 中解码，替换映射中的所有键值对
+// 解码失败时映射保持不变，实现 json.Unmarshaler 接口
+func (m *Map[K, V]) UnmarshalJSON(data []byte) error {
+	var keys []K
+	var values []V
+	err := codec.UnmarshalJSONSeq2(data, func(key K, value V) {
+		keys = append(keys, key)
+		values = append(values, value)
+	})
+	if err != nil {
+		return err
+	}
+	m.load(keys, values)
+	return nil
+}
+
+// load 清空映射后依次放入键值对，重复的键保留最后一个值
+func (m *Map[K, V]) load(keys []K, values []V) {
+	m.Clear()
+	for i, k := range keys {
+		m.Put(k, values[i])
+	}
+}
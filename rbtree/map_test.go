@@ -0,0 +1,153 @@
+package rbtree
+
+import (
+	"encoding/json"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// TestMap 测试有序映射的插入、更新、删除与遍历
+func TestMap(t *testing.T) {
+	m := NewMap[int, string]()
+	if !m.IsEmpty() {
+		t.Fatal("新建的映射应为空")
+	}
+	if _, ok := m.Get(1); ok {
+		t.Error("空映射中不应找到键")
+	}
+
+	r := rand.New(rand.NewSource(1))
+	want := make(map[int]string)
+	for i := 0; i < 3000; i++ {
+		k := r.Intn(300)
+		if r.Intn(3) == 0 {
+			_, exists := want[k]
+			if m.Delete(k) != exists {
+				t.Fatalf("Delete(%d) 的返回值应为%v", k, exists)
+			}
+			delete(want, k)
+		} else {
+			v := string(rune('a' + i%26))
+			m.Put(k, v)
+			want[k] = v
+		}
+		if i%100 == 0 {
+			validateRedBlackProperties(t, &m.tree)
+		}
+	}
+	validateRedBlackProperties(t, &m.tree)
+	if m.Len() != len(want) || m.Size() != len(want) {
+		t.Fatalf("期望大小为%d，实际为%d", len(want), m.Len())
+	}
+	for k, v := range want {
+		if got, ok := m.Get(k); !ok || got != v {
+			t.Fatalf("Get(%d) = (%q, %v)，期望 %q", k, got, ok, v)
+		}
+	}
+
+	t.Run("有序遍历", func(t *testing.T) {
+		keys := slices.Sorted(func(yield func(int) bool) {
+			for k := range want {
+				if !yield(k) {
+					return
+				}
+			}
+		})
+		if got := slices.Collect(m.Keys()); !slices.Equal(got, keys) {
+			t.Error("Keys 应按升序返回所有键")
+		}
+		values := slices.Collect(m.Values())
+		i := 0
+		for k, v := range m.All() {
+			if k != keys[i] || v != want[k] || values[i] != v {
+				t.Fatalf("第%d个键值对为(%d, %q)", i, k, v)
+			}
+			i++
+		}
+		for range m.All() {
+			break
+		}
+	})
+
+	t.Run("更新已有的键", func(t *testing.T) {
+		m := NewMap[string, int]()
+		m.Put("a", 1)
+		m.Put("a", 2)
+		if v, _ := m.Get("a"); v != 2 || m.Len() != 1 {
+			t.Errorf("更新后值为%d、大小为%d", v, m.Len())
+		}
+		m.Clear()
+		if !m.IsEmpty() || m.Contains("a") {
+			t.Error("清空后映射应为空")
+		}
+	})
+}
+
+// TestMapMarshal 测试有序映射的二进制与 JSON 序列化
+func TestMapMarshal(t *testing.T) {
+	src := NewMap[string, int]()
+	for i, k := range []string{"b", "c", "a"} {
+		src.Put(k, i)
+	}
+
+	t.Run("二进制", func(t *testing.T) {
+		data, err := src.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		dst := NewMap[string, int]()
+		dst.Put("z", 9)
+		if err := dst.UnmarshalBinary(data); err != nil {
+			t.Fatal(err)
+		}
+		if v, _ := dst.Get("c"); v != 1 || dst.Len() != 3 || dst.Contains("z") {
+			t.Errorf("解码后大小为%d，c 的值为%d", dst.Len(), v)
+		}
+		if err := dst.UnmarshalBinary(data[:len(data)-1]); err == nil || dst.Len() != 3 {
+			t.Error("截断的数据应返回错误并保持不变")
+		}
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		data, err := json.Marshal(src)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := `[{"key":"a","value":2},{"key":"b","value":0},{"key":"c","value":1}]`; string(data) != want {
+			t.Errorf("JSON 为%s", data)
+		}
+		dst := NewMap[string, int]()
+		if err := json.Unmarshal(data, dst); err != nil {
+			t.Fatal(err)
+		}
+		if got := slices.Collect(dst.Keys()); !slices.Equal(got, []string{"a", "b", "c"}) {
+			t.Errorf("解码后的键为%v", got)
+		}
+	})
+}
+
+// TestMapClone 测试有序映射的深拷贝
+func TestMapClone(t *testing.T) {
+	m := NewMap[int, []int]()
+	for i := 0; i < 100; i++ {
+		m.Put(i, []int{i})
+	}
+	clone := m.Clone(func(v []int) []int { return slices.Clone(v) })
+	if err := clone.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if clone.tree.String() != m.tree.String() {
+		t.Error("拷贝的结构和颜色应与原映射相同")
+	}
+	v, _ := m.Get(5)
+	v[0] = -1
+	m.Delete(6)
+	clone.Put(200, nil)
+	if got, _ := clone.Get(5); got[0] != 5 || !clone.Contains(6) || m.Contains(200) {
+		t.Error("修改原映射和副本不应互相影响")
+	}
+	if clone.Len() != 101 || m.Len() != 99 {
+		t.Errorf("修改后大小为%d和%d", clone.Len(), m.Len())
+	}
+}
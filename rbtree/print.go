@@ -0,0 +1,106 @@
+package rbtree
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"golang.org/x/exp/constraints"
+)
+
+// String 返回树结构的字符串表示，用于调试
+// 每行一个节点，子节点按 L（左）、R（右）标出并逐层缩进，节点值后标注颜色 R（红）或 B（黑），
+// CountDuplicates 模式下值出现多次时再标注次数，例如：
+//
+//	20(B)
+//	├── L: 10(B)
+//	└── R: 30×2(R)
+func (t *Tree[T]) String() string {
+	if t.Root == nil {
+		return "空树"
+	}
+	var sb strings.Builder
+	sb.WriteString(nodeLabel(t.Root))
+	sb.WriteByte('\n')
+	printChildren(&sb, t.Root, "")
+	return sb.String()
+}
+
+// printChildren 递归打印 n 的子节点，prefix 为子节点所在行的前缀
+func printChildren[T constraints.Ordered](sb *strings.Builder, n *Node[T], prefix string) {
+	type child struct {
+		side string
+		node *Node[T]
+	}
+	var children []child
+	if n.Left != nil {
+		children = append(children, child{"L", n.Left})
+	}
+	if n.Right != nil {
+		children = append(children, child{"R", n.Right})
+	}
+	for i, c := range children {
+		connector, indent := "├── ", "│   "
+		if i == len(children)-1 {
+			connector, indent = "└── ", "    "
+		}
+		sb.WriteString(prefix + connector + c.side + ": " + nodeLabel(c.node) + "\n")
+		printChildren(sb, c.node, prefix+indent)
+	}
+}
+
+// nodeLabel 返回节点的值及颜色标记
+func nodeLabel[T constraints.Ordered](n *Node[T]) string {
+	if n.Color == RED {
+		return valueLabel(n) + "(R)"
+	}
+	return valueLabel(n) + "(B)"
+}
+
+// valueLabel 返回节点的值，次数大于1时附加次数
+func valueLabel[T constraints.Ordered](n *Node[T]) string {
+	if n.count > 1 {
+		return fmt.Sprintf("%v×%d", n.Value, n.count)
+	}
+	return fmt.Sprint(n.Value)
+}
+
+// WriteDOT 把树结构以 Graphviz DOT 格式写入 w，节点按颜色填充，边上标注左右子节点
+// 输出可以用 dot -Tsvg 等命令渲染
+// 参数：
+//   - w: 写入的目标
+//
+// 返回：
+//   - error: 写入失败时返回的错误
+//
+// 时间复杂度: O(n)
+func (t *Tree[T]) WriteDOT(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	bw.WriteString("digraph rbtree {\n")
+	bw.WriteString("\tnode [style=filled, fontcolor=white];\n")
+	id := 0
+	var walk func(n *Node[T]) int
+	walk = func(n *Node[T]) int {
+		self := id
+		id++
+		fill := "black"
+		if n.Color == RED {
+			fill = "red"
+		}
+		fmt.Fprintf(bw, "\tn%d [label=%s, fillcolor=%s];\n", self, strconv.Quote(valueLabel(n)), fill)
+		if n.Left != nil {
+			fmt.Fprintf(bw, "\tn%d -> n%d [label=\"L\"];\n", self, walk(n.Left))
+		}
+		if n.Right != nil {
+			fmt.Fprintf(bw, "\tn%d -> n%d [label=\"R\"];\n", self, walk(n.Right))
+		}
+		return self
+	}
+	if t.Root != nil {
+		walk(t.Root)
+	}
+	bw.WriteString("}\n")
+	return bw.Flush()
+}
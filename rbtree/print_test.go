@@ -0,0 +1,52 @@
+package rbtree
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// TestString 测试树结构的字符串表示
+func TestString(t *testing.T) {
+	tree := NewTree[int](WithDuplicates(CountDuplicates))
+	if tree.String() != "空树" {
+		t.Errorf("空树的字符串表示为%q", tree.String())
+	}
+	for _, v := range []int{20, 10, 30, 30, 40} {
+		tree.Insert(v)
+	}
+	want := `20(B)
+├── L: 10(B)
+└── R: 30×2(B)
+    └── R: 40(R)
+`
+	if got := tree.String(); got != want {
+		t.Errorf("字符串表示为\n%s期望\n%s", got, want)
+	}
+}
+
+// TestWriteDOT 测试以 DOT 格式输出树结构
+func TestWriteDOT(t *testing.T) {
+	tree := NewTree[string]()
+	for _, v := range []string{"b", "a", "c", `"q"`} {
+		tree.Insert(v)
+	}
+	var buf bytes.Buffer
+	if err := tree.WriteDOT(&buf); err != nil {
+		t.Fatal(err)
+	}
+	got := buf.String()
+	for _, line := range []string{
+		"digraph rbtree {",
+		`n0 [label="b", fillcolor=black];`,
+		`[label="\"q\"", fillcolor=red];`,
+		`n0 -> n1 [label="L"];`,
+	} {
+		if !strings.Contains(got, line) {
+			t.Errorf("DOT 输出中缺少 %s:\n%s", line, got)
+		}
+	}
+	if strings.Count(got, "->") != 3 || !strings.HasSuffix(got, "}\n") {
+		t.Errorf("DOT 输出不正确:\n%s", got)
+	}
+}
@@ -1,6 +1,14 @@
 package rbtree
 
 import (
+	"cmp"
+	"iter"
+	"math/bits"
+	"slices"
+
+	"godatastructure/codec"
+	"godatastructure/internal/arena"
+	"godatastructure/metrics"
 	"golang.org/x/exp/constraints"
 )
 
@@ -20,20 +28,163 @@ type Node[T constraints.Ordered] struct {
 	Left   *Node[T] // 左子节点
 	Right  *Node[T] // 右子节点
 	Parent *Node[T] // 父节点
+	count  int      // 值出现的次数，只有 CountDuplicates 模式下会大于1
+	size   int      // 以该节点为根的子树中的元素数量（计入重复次数），用于 Rank 和 Kth
 }
 
+// DuplicatePolicy 插入已存在的值时的处理方式
+type DuplicatePolicy uint8
+
+const (
+	AllowDuplicates  DuplicatePolicy = iota // 默认：每次插入都创建新节点，相等的值各占一个节点
+	CountDuplicates                         // 多重集合：每个值只有一个节点，节点记录值出现的次数
+	RejectDuplicates                        // 严格集合：插入已存在的值时不做任何修改
+)
+
 // Tree 红黑树结构
 type Tree[T constraints.Ordered] struct {
-	Root *Node[T] // 根节点
-	size int      // 树中节点数量
+	Root     *Node[T]              // 根节点
+	size     int                   // 树中元素数量（计入重复次数）
+	recorder metrics.Recorder      // 指标记录器，为 nil 时不记录
+	nodes    *arena.Arena[Node[T]] // 节点分配器，为 nil 时直接分配
+	dup      DuplicatePolicy       // 插入重复值时的处理方式
+	augment  func(n *Node[T])      // 由子节点重新计算 n 的附加信息（例如区间树的最大端点），为 nil 时不需要
+}
+
+// Option 红黑树的可选配置
+type Option func(*options)
+
+// options 红黑树的配置项
+type options struct {
+	recorder metrics.Recorder
+	arena    bool            // 是否使用节点分配器
+	slabSize int             // 分配器每块包含的节点数量
+	dup      DuplicatePolicy // 插入重复值时的处理方式
+}
+
+// WithRecorder 使红黑树把插入、删除、查找和旋转事件报告给 r
+// Clone 得到的副本不会继承记录器
+func WithRecorder(r metrics.Recorder) Option {
+	return func(o *options) {
+		o.recorder = r
+	}
+}
+
+// WithArena 使红黑树从按块分配的对象池中分配节点，并在删除节点和 Clear 时回收复用，
+// 以减少频繁插入删除时的垃圾回收压力。slabSize 为每块包含的节点数量，小于1时使用默认值。
+// 启用后，通过 Root 取得的节点在被删除后可能被复用，不应再访问；Clone 得到的副本不会继承分配器
+func WithArena(slabSize int) Option {
+	return func(o *options) {
+		o.arena = true
+		o.slabSize = slabSize
+	}
+}
+
+// WithDuplicates 设置插入已存在的值时的处理方式，默认为 AllowDuplicates
+// Clone、Split 得到的红黑树继承该设置
+func WithDuplicates(p DuplicatePolicy) Option {
+	return func(o *options) {
+		o.dup = p
+	}
 }
 
 // NewTree 创建新的红黑树
+// 参数：
+//   - opts: 可选配置，例如 WithRecorder、WithArena、WithDuplicates
+//
 // 时间复杂度: O(1)
-func NewTree[T constraints.Ordered]() *Tree[T] {
-	return &Tree[T]{
-		Root: nil,
-		size: 0,
+func NewTree[T constraints.Ordered](opts ...Option) *Tree[T] {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	t := &Tree[T]{
+		Root:     nil,
+		size:     0,
+		recorder: o.recorder,
+		dup:      o.dup,
+	}
+	if o.arena {
+		t.nodes = arena.New[Node[T]](o.slabSize)
+	}
+	return t
+}
+
+// NewTreeFromSorted 由按升序排列的元素直接构建红黑树
+// 每次取中间的元素作为子树的根节点，得到各层都填满、只有最底层可能不满的树；
+// 最底层的节点着红色，其余节点着黑色，因此所有路径上的黑色节点数量相同
+// 参数：
+//   - values: 按升序排列的元素，可以包含重复的元素，按 WithDuplicates 的设置处理；未排序时会 panic
+//   - opts: 可选配置，例如 WithRecorder、WithArena、WithDuplicates
+//
+// 时间复杂度: O(n)
+func NewTreeFromSorted[T constraints.Ordered](values []T, opts ...Option) *Tree[T] {
+	if !slices.IsSorted(values) {
+		panic("元素必须按升序排列")
+	}
+	t := NewTree[T](opts...)
+	t.build(values)
+	return t
+}
+
+// build 用按升序排列的 values 替换树中的内容
+// 时间复杂度: O(n)
+func (t *Tree[T]) build(values []T) {
+	t.Clear()
+	var counts []int // 每个值出现的次数，为 nil 时都是1
+	switch t.dup {
+	case CountDuplicates:
+		var unique []T
+		for i, v := range values {
+			if i > 0 && v == values[i-1] {
+				counts[len(counts)-1]++
+				continue
+			}
+			unique = append(unique, v)
+			counts = append(counts, 1)
+		}
+		values = unique
+	case RejectDuplicates:
+		values = slices.Compact(slices.Clone(values))
+	}
+	// 最底层的深度，只有一个节点时根节点本身即最底层，仍需着黑色
+	redDepth := bits.Len(uint(len(values))) - 1
+	t.Root = t.buildNode(values, counts, nil, 0, redDepth)
+	t.size = sizeOf(t.Root)
+}
+
+// buildNode 以 values 的中间元素为根节点构建子树，depth 为根节点的深度
+func (t *Tree[T]) buildNode(values []T, counts []int, parent *Node[T], depth, redDepth int) *Node[T] {
+	if len(values) == 0 {
+		return nil
+	}
+	mid := len(values) / 2
+	n := t.nodes.Alloc()
+	n.Value = values[mid]
+	n.Parent = parent
+	n.count = 1
+	if counts != nil {
+		n.count = counts[mid]
+	}
+	if depth == redDepth && depth > 0 {
+		n.Color = RED
+	} else {
+		n.Color = BLACK
+	}
+	var leftCounts, rightCounts []int
+	if counts != nil {
+		leftCounts, rightCounts = counts[:mid], counts[mid+1:]
+	}
+	n.Left = t.buildNode(values[:mid], leftCounts, n, depth+1, redDepth)
+	n.Right = t.buildNode(values[mid+1:], rightCounts, n, depth+1, redDepth)
+	n.size = sizeOf(n.Left) + sizeOf(n.Right) + n.count
+	return n
+}
+
+// record 向记录器报告事件
+func (t *Tree[T]) record(e metrics.Event) {
+	if t.recorder != nil {
+		t.recorder.Record(e, t.size)
 	}
 }
 
@@ -44,29 +195,54 @@ func NewTree[T constraints.Ordered]() *Tree[T] {
 // 3. 所有叶子节点都是黑色
 // 4. 如果一个节点是红色，则它的子节点必须是黑色
 // 5. 从任一节点到其每个叶子的所有路径都包含相同数目的黑色节点
+// 值已存在时按 WithDuplicates 的设置处理，返回是否插入了元素：只有 RejectDuplicates 模式下插入已存在的值时返回 false
 // 时间复杂度: O(log n)
-func (t *Tree[T]) Insert(value T) {
-	// 创建新节点，初始为红色
-	newNode := &Node[T]{
-		Value:  value,
-		Color:  RED, // 新节点默认为红色
-		Left:   nil,
-		Right:  nil,
-		Parent: nil,
+func (t *Tree[T]) Insert(value T) bool {
+	if t.dup != AllowDuplicates {
+		if node := t.findNode(value); node != nil {
+			if t.dup == RejectDuplicates {
+				return false
+			}
+			node.count++
+			for n := node; n != nil; n = n.Parent {
+				n.size++
+			}
+			t.size++
+			t.record(metrics.Insert)
+			return true
+		}
 	}
+	// 创建新节点，初始为红色
+	newNode := t.nodes.Alloc()
+	newNode.Value = value
+	t.insertNode(newNode)
+	return true
+}
+
+// insertNode 把尚未连接的节点 newNode 插入树中并修复红黑树性质
+// 值相等的节点放在已有节点的右侧
+// 时间复杂度: O(log n)
+func (t *Tree[T]) insertNode(newNode *Node[T]) {
+	newNode.Color = RED // 新节点默认为红色
+	newNode.count = 1
+	newNode.size = 1
 
 	// 如果是空树，直接作为根节点
 	if t.Root == nil {
 		t.Root = newNode
 		t.fixInsert(newNode) // 修复可能违反的红黑树性质
+		t.size++
+		t.record(metrics.Insert)
 		return
 	}
 
 	// 找到合适的插入位置
+	value := newNode.Value
 	current := t.Root
 	var parent *Node[T]
 	for current != nil {
 		parent = current
+		current.size++ // 新节点将位于路径上每个节点的子树中
 		if value < current.Value {
 			current = current.Left
 		} else {
@@ -82,23 +258,40 @@ func (t *Tree[T]) Insert(value T) {
 		parent.Right = newNode
 	}
 
+	// 新节点的祖先节点的附加信息需要在旋转之前更新
+	t.augmentPath(newNode)
+
 	// 修复红黑树性质
 	t.fixInsert(newNode)
 	t.size++
+	t.record(metrics.Insert)
+}
+
+// augmentPath 自底向上重新计算从 n 到根节点路径上各节点的附加信息
+// 时间复杂度: O(log n)
+func (t *Tree[T]) augmentPath(n *Node[T]) {
+	if t.augment == nil {
+		return
+	}
+	for ; n != nil; n = n.Parent {
+		t.augment(n)
+	}
 }
 
 // fixInsert 修复插入后可能违反的红黑树性质
+// 返回根节点是否由红色变为黑色，此时树的黑高度增加1
 // 时间复杂度: O(log n)，最多需要旋转O(log n)次
-func (t *Tree[T]) fixInsert(node *Node[T]) {
+func (t *Tree[T]) fixInsert(node *Node[T]) bool {
 	// 情况1：节点是根节点
 	if node.Parent == nil {
+		grew := node.Color == RED
 		node.Color = BLACK
-		return
+		return grew
 	}
 
 	// 如果父节点是黑色，不需要修复
 	if node.Parent.Color == BLACK {
-		return
+		return false
 	}
 
 	// 获取父节点、叔叔节点和祖父节点
@@ -118,8 +311,7 @@ func (t *Tree[T]) fixInsert(node *Node[T]) {
 		parent.Color = BLACK
 		uncle.Color = BLACK
 		grandparent.Color = RED
-		t.fixInsert(grandparent)
-		return
+		return t.fixInsert(grandparent)
 	}
 
 	// 情况3：叔叔节点是黑色（或NIL），当前节点是“内侧子节点”
@@ -143,6 +335,7 @@ func (t *Tree[T]) fixInsert(node *Node[T]) {
 	} else {
 		t.rotateLeft(grandparent)
 	}
+	return false
 }
 
 // rotateLeft 左旋操作
@@ -166,6 +359,15 @@ func (t *Tree[T]) rotateLeft(node *Node[T]) {
 
 	rightChild.Left = node
 	node.Parent = rightChild
+
+	// 旋转后 rightChild 的子树即原来 node 的子树
+	rightChild.size = node.size
+	node.size = sizeOf(node.Left) + sizeOf(node.Right) + node.count
+	if t.augment != nil {
+		t.augment(node)
+		t.augment(rightChild)
+	}
+	t.record(metrics.Rotation)
 }
 
 // rotateRight 右旋操作
@@ -189,15 +391,287 @@ func (t *Tree[T]) rotateRight(node *Node[T]) {
 
 	leftChild.Right = node
 	node.Parent = leftChild
+
+	leftChild.size = node.size
+	node.size = sizeOf(node.Left) + sizeOf(node.Right) + node.count
+	if t.augment != nil {
+		t.augment(node)
+		t.augment(leftChild)
+	}
+	t.record(metrics.Rotation)
+}
+
+// Delete 删除一个值为 value 的元素
+// CountDuplicates 模式下值出现多次时只减少一次计数
+// 返回是否成功删除
+// 时间复杂度: O(log n)
+func (t *Tree[T]) Delete(value T) bool {
+	node := t.findNode(value)
+	if node == nil {
+		return false
+	}
+	t.deleteOne(node)
+	return true
+}
+
+// DeleteMin 删除并返回最小的元素，树为空时第二个返回值为 false
+// 时间复杂度: O(log n)
+func (t *Tree[T]) DeleteMin() (T, bool) {
+	if t.Root == nil {
+		return nodeValue[T](nil)
+	}
+	node := minimum(t.Root)
+	value := node.Value
+	t.deleteOne(node)
+	return value, true
+}
+
+// DeleteMax 删除并返回最大的元素，树为空时第二个返回值为 false
+// 时间复杂度: O(log n)
+func (t *Tree[T]) DeleteMax() (T, bool) {
+	if t.Root == nil {
+		return nodeValue[T](nil)
+	}
+	node := maximum(t.Root)
+	value := node.Value
+	t.deleteOne(node)
+	return value, true
+}
+
+// deleteOne 删除节点 node 中的一个元素，节点的次数大于1时只减少次数，否则摘除并回收节点
+// 时间复杂度: O(log n)
+func (t *Tree[T]) deleteOne(node *Node[T]) {
+	if node.count > 1 {
+		node.count--
+		for n := node; n != nil; n = n.Parent {
+			n.size--
+		}
+		t.size--
+		t.record(metrics.Delete)
+		return
+	}
+	t.removeNode(node)
+	t.nodes.Free(node)
+}
+
+// RemoveOne 删除一个值为 value 的元素，与 Delete 相同
+// 时间复杂度: O(log n)
+func (t *Tree[T]) RemoveOne(value T) bool {
+	return t.Delete(value)
+}
+
+// RemoveAll 删除值为 value 的所有元素，返回删除的元素数量
+// 时间复杂度: O(k·log n)，k 为值为 value 的节点数量，只有 AllowDuplicates 模式下可能大于1
+func (t *Tree[T]) RemoveAll(value T) int {
+	removed := 0
+	for node := t.findNode(value); node != nil; node = t.findNode(value) {
+		removed += node.count
+		t.removeNode(node)
+		t.nodes.Free(node)
+	}
+	return removed
+}
+
+// Count 返回值为 value 的元素数量
+// 时间复杂度: O(log n)
+func (t *Tree[T]) Count(value T) int {
+	return t.rank(value, true) - t.rank(value, false)
+}
+
+// removeNode 从树中摘除节点 node 并更新元素数量
+// 时间复杂度: O(log n)
+func (t *Tree[T]) removeNode(node *Node[T]) {
+	t.deleteNode(node)
+	t.size -= node.count
+	t.record(metrics.Delete)
+}
+
+// deleteNode 从树中摘除节点 z
+// 当 z 有两个子节点时，用其后继节点 y 顶替 z 的位置（移动节点而非复制值）
+// 时间复杂度: O(log n)
+func (t *Tree[T]) deleteNode(z *Node[T]) {
+	var x, xParent *Node[T] // x 为顶替被删除位置的节点（可能为 nil），xParent 为其父节点
+	y := z
+	yColor := y.Color
+
+	// z 的祖先节点的子树都少了 z 的元素；有两个子节点时 z 的后继节点会移到 z 的位置，
+	// 它在 z 之下的祖先节点的子树还少了后继节点的元素
+	if z.Left != nil && z.Right != nil {
+		succ := minimum(z.Right)
+		for n := succ.Parent; n != z; n = n.Parent {
+			n.size -= succ.count
+		}
+	}
+	for n := z.Parent; n != nil; n = n.Parent {
+		n.size -= z.count
+	}
+
+	if z.Left == nil {
+		x = z.Right
+		xParent = z.Parent
+		t.transplant(z, z.Right)
+	} else if z.Right == nil {
+		x = z.Left
+		xParent = z.Parent
+		t.transplant(z, z.Left)
+	} else {
+		y = minimum(z.Right)
+		yColor = y.Color
+		x = y.Right
+		if y.Parent == z {
+			xParent = y
+		} else {
+			xParent = y.Parent
+			t.transplant(y, y.Right)
+			y.Right = z.Right
+			y.Right.Parent = y
+		}
+		t.transplant(z, y)
+		y.Left = z.Left
+		y.Left.Parent = y
+		y.Color = z.Color
+		y.size = z.size - z.count
+	}
+
+	z.Left, z.Right, z.Parent = nil, nil, nil
+	// 结构发生变化的位置在 xParent，它到根节点路径上的附加信息需要在旋转之前更新
+	t.augmentPath(xParent)
+
+	// 删除黑色节点会导致路径上的黑色节点数减少，需要修复
+	if yColor == BLACK {
+		t.fixDelete(x, xParent)
+	}
+}
+
+// fixDelete 修复删除后可能违反的红黑树性质
+// x 携带了一层“额外的黑色”，通过旋转和重新着色将其消除
+// 时间复杂度: O(log n)，最多旋转3次
+func (t *Tree[T]) fixDelete(x, parent *Node[T]) {
+	for x != t.Root && colorOf(x) == BLACK {
+		if x == parent.Left {
+			sibling := parent.Right
+			// 情况1：兄弟节点是红色，旋转后转化为兄弟节点为黑色的情况
+			if colorOf(sibling) == RED {
+				sibling.Color = BLACK
+				parent.Color = RED
+				t.rotateLeft(parent)
+				sibling = parent.Right
+			}
+			// 情况2：兄弟节点的两个子节点都是黑色，兄弟节点变红，额外的黑色上移
+			if colorOf(sibling.Left) == BLACK && colorOf(sibling.Right) == BLACK {
+				sibling.Color = RED
+				x = parent
+				parent = x.Parent
+				continue
+			}
+			// 情况3：兄弟节点的右子节点是黑色，旋转后转化为情况4
+			if colorOf(sibling.Right) == BLACK {
+				sibling.Left.Color = BLACK
+				sibling.Color = RED
+				t.rotateRight(sibling)
+				sibling = parent.Right
+			}
+			// 情况4：兄弟节点的右子节点是红色，旋转父节点并重新着色后修复完成
+			sibling.Color = parent.Color
+			parent.Color = BLACK
+			sibling.Right.Color = BLACK
+			t.rotateLeft(parent)
+			x = t.Root
+		} else {
+			sibling := parent.Left
+			if colorOf(sibling) == RED {
+				sibling.Color = BLACK
+				parent.Color = RED
+				t.rotateRight(parent)
+				sibling = parent.Left
+			}
+			if colorOf(sibling.Left) == BLACK && colorOf(sibling.Right) == BLACK {
+				sibling.Color = RED
+				x = parent
+				parent = x.Parent
+				continue
+			}
+			if colorOf(sibling.Left) == BLACK {
+				sibling.Right.Color = BLACK
+				sibling.Color = RED
+				t.rotateLeft(sibling)
+				sibling = parent.Left
+			}
+			sibling.Color = parent.Color
+			parent.Color = BLACK
+			sibling.Left.Color = BLACK
+			t.rotateRight(parent)
+			x = t.Root
+		}
+	}
+	if x != nil {
+		x.Color = BLACK
+	}
+}
+
+// transplant 用以 v 为根的子树替换以 u 为根的子树
+// 时间复杂度: O(1)
+func (t *Tree[T]) transplant(u, v *Node[T]) {
+	if u.Parent == nil {
+		t.Root = v
+	} else if u == u.Parent.Left {
+		u.Parent.Left = v
+	} else {
+		u.Parent.Right = v
+	}
+	if v != nil {
+		v.Parent = u.Parent
+	}
+}
+
+// minimum 返回以 node 为根的子树中的最小节点
+// 时间复杂度: O(log n)
+func minimum[T constraints.Ordered](node *Node[T]) *Node[T] {
+	for node.Left != nil {
+		node = node.Left
+	}
+	return node
+}
+
+// maximum 返回以 node 为根的子树中的最大节点
+// 时间复杂度: O(log n)
+func maximum[T constraints.Ordered](node *Node[T]) *Node[T] {
+	for node.Right != nil {
+		node = node.Right
+	}
+	return node
+}
+
+// sizeOf 返回以 node 为根的子树中的节点数量，nil 节点为0
+func sizeOf[T constraints.Ordered](node *Node[T]) int {
+	if node == nil {
+		return 0
+	}
+	return node.size
+}
+
+// colorOf 返回节点颜色，nil 节点视为黑色
+func colorOf[T constraints.Ordered](node *Node[T]) Color {
+	if node == nil {
+		return BLACK
+	}
+	return node.Color
 }
 
 // Search 查找节点
 // 时间复杂度: O(log n)
 func (t *Tree[T]) Search(value T) bool {
+	t.record(metrics.Lookup)
+	return t.findNode(value) != nil
+}
+
+// findNode 查找值为 value 的节点，不存在时返回 nil
+// 时间复杂度: O(log n)
+func (t *Tree[T]) findNode(value T) *Node[T] {
 	current := t.Root
 	for current != nil {
 		if current.Value == value {
-			return true
+			return current
 		}
 		if value < current.Value {
 			current = current.Left
@@ -205,7 +679,155 @@ func (t *Tree[T]) Search(value T) bool {
 			current = current.Right
 		}
 	}
-	return false
+	return nil
+}
+
+// Min 返回最小的元素，树为空时第二个返回值为 false
+// 时间复杂度: O(log n)
+func (t *Tree[T]) Min() (T, bool) {
+	if t.Root == nil {
+		return nodeValue[T](nil)
+	}
+	return minimum(t.Root).Value, true
+}
+
+// Max 返回最大的元素，树为空时第二个返回值为 false
+// 时间复杂度: O(log n)
+func (t *Tree[T]) Max() (T, bool) {
+	if t.Root == nil {
+		return nodeValue[T](nil)
+	}
+	return maximum(t.Root).Value, true
+}
+
+// Floor 返回小于等于 value 的最大元素
+// 时间复杂度: O(log n)
+func (t *Tree[T]) Floor(value T) (T, bool) {
+	return nodeValue(t.lowerNode(value, true))
+}
+
+// Ceiling 返回大于等于 value 的最小元素
+// 时间复杂度: O(log n)
+func (t *Tree[T]) Ceiling(value T) (T, bool) {
+	return nodeValue(t.upperNode(value, true))
+}
+
+// Predecessor 返回严格小于 value 的最大元素，value 不必在树中
+// 时间复杂度: O(log n)
+func (t *Tree[T]) Predecessor(value T) (T, bool) {
+	return nodeValue(t.lowerNode(value, false))
+}
+
+// Successor 返回严格大于 value 的最小元素，value 不必在树中
+// 时间复杂度: O(log n)
+func (t *Tree[T]) Successor(value T) (T, bool) {
+	return nodeValue(t.upperNode(value, false))
+}
+
+// lowerNode 返回小于 value（inclusive 为 true 时小于等于）的最大节点，不存在时返回 nil
+// 时间复杂度: O(log n)
+func (t *Tree[T]) lowerNode(value T, inclusive bool) *Node[T] {
+	var result *Node[T]
+	node := t.Root
+	for node != nil {
+		if node.Value < value || inclusive && node.Value == value {
+			result = node
+			node = node.Right
+		} else {
+			node = node.Left
+		}
+	}
+	return result
+}
+
+// upperNode 返回大于 value（inclusive 为 true 时大于等于）的最小节点，不存在时返回 nil
+// 时间复杂度: O(log n)
+func (t *Tree[T]) upperNode(value T, inclusive bool) *Node[T] {
+	var result *Node[T]
+	node := t.Root
+	for node != nil {
+		if node.Value > value || inclusive && node.Value == value {
+			result = node
+			node = node.Left
+		} else {
+			node = node.Right
+		}
+	}
+	return result
+}
+
+// nodeValue 返回节点的值，node 为 nil 时第二个返回值为 false
+func nodeValue[T constraints.Ordered](node *Node[T]) (T, bool) {
+	if node == nil {
+		var zero T
+		return zero, false
+	}
+	return node.Value, true
+}
+
+// Rank 返回严格小于 value 的元素数量
+// 下降时累加左子树的元素数量，不需要遍历
+// 时间复杂度: O(log n)
+func (t *Tree[T]) Rank(value T) int {
+	return t.rank(value, false)
+}
+
+// rank 返回小于 value（inclusive 为 true 时小于等于）的元素数量
+func (t *Tree[T]) rank(value T, inclusive bool) int {
+	rank := 0
+	node := t.Root
+	for node != nil {
+		if value < node.Value || !inclusive && value == node.Value {
+			node = node.Left
+		} else {
+			rank += sizeOf(node.Left) + node.count
+			node = node.Right
+		}
+	}
+	return rank
+}
+
+// Kth 返回第 k 小的元素（k 从0开始），k 越界时第二个返回值为 false
+// 时间复杂度: O(log n)
+func (t *Tree[T]) Kth(k int) (T, bool) {
+	if k < 0 || k >= t.size {
+		return nodeValue[T](nil)
+	}
+	node := t.Root
+	for {
+		ls := sizeOf(node.Left)
+		switch {
+		case k < ls:
+			node = node.Left
+		case k < ls+node.count:
+			return node.Value, true
+		default:
+			k -= ls + node.count
+			node = node.Right
+		}
+	}
+}
+
+// Height 返回树的高度，即从根节点到最深的节点经过的节点数量，空树为0
+// 红黑树的高度不超过 2·log2(n+1)，可以用于监控树的平衡情况
+// 时间复杂度: O(n)
+func (t *Tree[T]) Height() int {
+	return height(t.Root)
+}
+
+// height 返回以 n 为根的子树的高度
+func height[T constraints.Ordered](n *Node[T]) int {
+	if n == nil {
+		return 0
+	}
+	return max(height(n.Left), height(n.Right)) + 1
+}
+
+// BlackHeight 返回从根节点到任一叶子节点的路径上黑色节点的数量（包含根节点，不包含 nil 叶子节点），空树为0
+// 红黑树的所有路径上黑色节点的数量相同，因此只需沿最左侧的路径计数
+// 时间复杂度: O(log n)
+func (t *Tree[T]) BlackHeight() int {
+	return blackHeight(t.Root)
 }
 
 // Size 返回树中节点数量
@@ -213,3 +835,211 @@ func (t *Tree[T]) Search(value T) bool {
 func (t *Tree[T]) Size() int {
 	return t.size
 }
+
+// IsEmpty 检查树是否为空
+// 时间复杂度: O(1)
+func (t *Tree[T]) IsEmpty() bool {
+	return t.size == 0
+}
+
+// Clear 清空树
+// 时间复杂度: O(1)，启用分配器时为 O(n)
+func (t *Tree[T]) Clear() {
+	if t.nodes != nil {
+		t.freeNodes(t.Root)
+	}
+	t.Root = nil
+	t.size = 0
+}
+
+// freeNodes 把以 n 为根的子树中的节点全部交还给分配器
+func (t *Tree[T]) freeNodes(n *Node[T]) {
+	if n == nil {
+		return
+	}
+	t.freeNodes(n.Left)
+	t.freeNodes(n.Right)
+	t.nodes.Free(n)
+}
+
+// Compare 按元素的自然顺序比较两个元素
+func (t *Tree[T]) Compare(a, b T) int {
+	return cmp.Compare(a, b)
+}
+
+// All 返回按升序遍历所有元素的迭代器
+// 利用父指针查找后继节点，不需要额外的栈空间
+// 遍历过程中不应修改红黑树
+func (t *Tree[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if t.Root == nil {
+			return
+		}
+		for node := minimum(t.Root); node != nil; node = successor(node) {
+			for range node.count {
+				if !yield(node.Value) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// InOrder 按升序把每个元素传给 fn，fn 返回 false 时停止遍历
+// 遍历过程中不应修改红黑树
+// 时间复杂度: O(n)
+func (t *Tree[T]) InOrder(fn func(T) bool) {
+	t.All()(fn)
+}
+
+// AscendRange 按升序把区间 [lo, hi) 内的元素传给 fn，fn 返回 false 时停止遍历
+// 先下降到第一个大于等于 lo 的节点，再沿后继节点前进，不会访问区间之外的子树
+// 遍历过程中不应修改红黑树
+// 时间复杂度: O(log n + m)，m 为区间内的元素数量
+func (t *Tree[T]) AscendRange(lo, hi T, fn func(T) bool) {
+	for node := t.upperNode(lo, true); node != nil && node.Value < hi; node = successor(node) {
+		for range node.count {
+			if !fn(node.Value) {
+				return
+			}
+		}
+	}
+}
+
+// DescendRange 按降序把区间 [lo, hi) 内的元素传给 fn，fn 返回 false 时停止遍历
+// 先下降到最后一个小于 hi 的节点，再沿前驱节点后退
+// 遍历过程中不应修改红黑树
+// 时间复杂度: O(log n + m)，m 为区间内的元素数量
+func (t *Tree[T]) DescendRange(lo, hi T, fn func(T) bool) {
+	for node := t.lowerNode(hi, false); node != nil && node.Value >= lo; node = predecessor(node) {
+		for range node.count {
+			if !fn(node.Value) {
+				return
+			}
+		}
+	}
+}
+
+// Backward 返回按降序遍历所有元素的迭代器
+// 遍历过程中不应修改红黑树
+func (t *Tree[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		if t.Root != nil {
+			t.descendFrom(maximum(t.Root), yield)
+		}
+	}
+}
+
+// Descend 按降序把每个元素传给 fn，fn 返回 false 时停止遍历，适合取前 N 大的元素
+// 遍历过程中不应修改红黑树
+// 时间复杂度: O(log n + m)，m 为遍历的元素数量
+func (t *Tree[T]) Descend(fn func(T) bool) {
+	t.Backward()(fn)
+}
+
+// DescendFrom 从小于等于 value 的最大元素开始按降序把元素传给 fn，fn 返回 false 时停止遍历
+// 遍历过程中不应修改红黑树
+// 时间复杂度: O(log n + m)，m 为遍历的元素数量
+func (t *Tree[T]) DescendFrom(value T, fn func(T) bool) {
+	t.descendFrom(t.lowerNode(value, true), fn)
+}
+
+// descendFrom 从 node 开始沿前驱节点按降序遍历，node 为 nil 时不遍历
+func (t *Tree[T]) descendFrom(node *Node[T], fn func(T) bool) {
+	for ; node != nil; node = predecessor(node) {
+		for range node.count {
+			if !fn(node.Value) {
+				return
+			}
+		}
+	}
+}
+
+// successor 返回中序遍历中的后继节点，不存在时返回 nil
+func successor[T constraints.Ordered](node *Node[T]) *Node[T] {
+	if node.Right != nil {
+		return minimum(node.Right)
+	}
+	parent := node.Parent
+	for parent != nil && node == parent.Right {
+		node = parent
+		parent = parent.Parent
+	}
+	return parent
+}
+
+// predecessor 返回中序遍历中的前驱节点，不存在时返回 nil
+func predecessor[T constraints.Ordered](node *Node[T]) *Node[T] {
+	if node.Left != nil {
+		return maximum(node.Left)
+	}
+	parent := node.Parent
+	for parent != nil && node == parent.Left {
+		node = parent
+		parent = parent.Parent
+	}
+	return parent
+}
+
+// MarshalBinary 使用 codec.For[T] 返回的元素编解码器把红黑树编码为二进制，按升序编码
+// 实现 encoding.BinaryMarshaler 接口，encoding/gob 也会通过它编码红黑树
+func (t *Tree[T]) MarshalBinary() ([]byte, error) {
+	return codec.EncodeSeq(t.All(), codec.For[T]())
+}
+
+// UnmarshalBinary 从 MarshalBinary 生成的数据中解码，替换红黑树中的所有元素
+// 只保存元素而不保存节点的颜色与结构，解码时由有序的元素直接构建平衡的红黑树
+// 解码失败时红黑树保持不变，实现 encoding.BinaryUnmarshaler 接口
+func (t *Tree[T]) UnmarshalBinary(data []byte) error {
+	values, err := codec.DecodeSeq(data, codec.For[T]())
+	if err != nil {
+		return err
+	}
+	t.load(values)
+	return nil
+}
+
+// MarshalJSON 把红黑树编码为按升序排列的 JSON 数组，实现 json.Marshaler 接口
+func (t *Tree[T]) MarshalJSON() ([]byte, error) {
+	return codec.MarshalJSONSeq(t.All())
+}
+
+// UnmarshalJSON 从 JSON 数组中解码，替换红黑树中的所有元素
+// 解码失败时红黑树保持不变，实现 json.Unmarshaler 接口
+func (t *Tree[T]) UnmarshalJSON(data []byte) error {
+	values, err := codec.UnmarshalJSONSeq[T](data)
+	if err != nil {
+		return err
+	}
+	t.load(values)
+	return nil
+}
+
+// load 用 values 中的元素替换红黑树的内容，values 通常已按升序排列，可以直接构建
+func (t *Tree[T]) load(values []T) {
+	slices.Sort(values)
+	t.build(values)
+}
+
+// Clone 返回结构和颜色都相同的深拷贝，可以在副本上进行试探性的修改而不影响原树
+// copier 用于复制每个元素，为 nil 时直接赋值；复制后的元素应与原元素保持相同的顺序
+// 副本继承重复值的处理方式，但不继承记录器和分配器
+// 时间复杂度: O(n)
+func (t *Tree[T]) Clone(copier func(T) T) *Tree[T] {
+	return &Tree[T]{Root: cloneNode(t.Root, nil, copier), size: t.size, dup: t.dup}
+}
+
+// cloneNode 递归复制以 n 为根的子树，parent 为复制后的父节点
+func cloneNode[T constraints.Ordered](n, parent *Node[T], copier func(T) T) *Node[T] {
+	if n == nil {
+		return nil
+	}
+	value := n.Value
+	if copier != nil {
+		value = copier(value)
+	}
+	clone := &Node[T]{Value: value, Color: n.Color, Parent: parent, count: n.count, size: n.size}
+	clone.Left = cloneNode(n.Left, clone, copier)
+	clone.Right = cloneNode(n.Right, clone, copier)
+	return clone
+}
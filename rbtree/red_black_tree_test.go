@@ -1,67 +1,23 @@
 package rbtree
 
 import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
 	"fmt"
+	"godatastructure/metrics"
 	"golang.org/x/exp/constraints"
+	"math/rand"
+	"slices"
 	"testing"
 )
 
 // validateRedBlackProperties 验证红黑树的所有性质
 func validateRedBlackProperties[T constraints.Ordered](t *testing.T, tree *Tree[T]) {
-	if tree.Root == nil {
-		return // 空树是有效的红黑树
-	}
-
-	// 验证根节点是黑色（性质2）
-	if tree.Root.Color != BLACK {
-		t.Error("根节点必须是黑色")
-	}
-
-	// 验证从根节点开始的所有性质
-	blackHeight, err := validateNode(tree.Root, BLACK)
-	if err != nil {
+	t.Helper()
+	if err := tree.Validate(); err != nil {
 		t.Errorf("红黑树性质验证失败: %v", err)
 	}
-
-	t.Logf("红黑树黑高度为: %d", blackHeight)
-}
-
-// validateNode 验证节点及其子树的红黑树性质
-func validateNode[T constraints.Ordered](node *Node[T], parentColor Color) (int, error) {
-	if node == nil {
-		return 1, nil // NIL节点被视为黑色
-	}
-
-	// 检查红色节点的子节点是否为黑色（性质4）
-	if node.Color == RED && parentColor == RED {
-		return 0, fmt.Errorf("发现连续的红色节点")
-	}
-
-	// 递归验证左子树
-	leftBlackHeight, err := validateNode(node.Left, node.Color)
-	if err != nil {
-		return 0, err
-	}
-
-	// 递归验证右子树
-	rightBlackHeight, err := validateNode(node.Right, node.Color)
-	if err != nil {
-		return 0, err
-	}
-
-	// 验证左右子树的黑高度相同（性质5）
-	if leftBlackHeight != rightBlackHeight {
-		return 0, fmt.Errorf("左右子树的黑高度不相等：左 %d, 右 %d",
-			leftBlackHeight, rightBlackHeight)
-	}
-
-	// 计算当前节点的黑高度
-	blackHeight := leftBlackHeight
-	if node.Color == BLACK {
-		blackHeight++
-	}
-
-	return blackHeight, nil
 }
 
 func TestRedBlackTreeBasicOperations(t *testing.T) {
@@ -141,6 +97,77 @@ func TestRedBlackTreeProperties(t *testing.T) {
 	})
 }
 
+func TestRedBlackTreeDelete(t *testing.T) {
+	t.Run("删除不存在的值", func(t *testing.T) {
+		tree := NewTree[int]()
+		if tree.Delete(1) {
+			t.Error("空树删除应该返回false")
+		}
+		tree.Insert(1)
+		if tree.Delete(2) {
+			t.Error("删除不存在的值应该返回false")
+		}
+	})
+
+	t.Run("删除各类节点", func(t *testing.T) {
+		tree := NewTree[int]()
+		values := []int{7, 3, 18, 10, 22, 8, 11, 26, 2, 6}
+		for _, v := range values {
+			tree.Insert(v)
+		}
+		if tree.Size() != len(values) {
+			t.Errorf("期望大小为%d，实际为%d", len(values), tree.Size())
+		}
+
+		// 依次删除叶子节点、单子节点和双子节点，以及根节点
+		for i, v := range []int{2, 22, 18, 7, 10, 3, 26, 6, 8, 11} {
+			if !tree.Delete(v) {
+				t.Fatalf("删除%d失败", v)
+			}
+			if tree.Search(v) {
+				t.Errorf("删除后仍能找到%d", v)
+			}
+			if tree.Size() != len(values)-i-1 {
+				t.Errorf("删除后大小错误，期望%d，实际为%d", len(values)-i-1, tree.Size())
+			}
+			validateRedBlackProperties(t, tree)
+		}
+		if tree.Root != nil {
+			t.Error("删除全部节点后根节点应为nil")
+		}
+	})
+
+	t.Run("随机插入删除", func(t *testing.T) {
+		tree := NewTree[int]()
+		r := rand.New(rand.NewSource(1))
+		present := make(map[int]bool)
+		for i := 0; i < 2000; i++ {
+			v := r.Intn(200)
+			if present[v] {
+				if !tree.Delete(v) {
+					t.Fatalf("删除已存在的值%d失败", v)
+				}
+				delete(present, v)
+			} else {
+				tree.Insert(v)
+				present[v] = true
+			}
+			if i%50 == 0 {
+				validateRedBlackProperties(t, tree)
+			}
+		}
+		validateRedBlackProperties(t, tree)
+		if tree.Size() != len(present) {
+			t.Errorf("期望大小为%d，实际为%d", len(present), tree.Size())
+		}
+		for v := range present {
+			if !tree.Search(v) {
+				t.Errorf("未找到值%d", v)
+			}
+		}
+	})
+}
+
 // 添加性能测试
 func BenchmarkRedBlackTree(b *testing.B) {
 	tree := NewTree[int]()
@@ -157,3 +184,664 @@ func BenchmarkRedBlackTree(b *testing.B) {
 		}
 	})
 }
+
+// TestRedBlackTreeAll 测试按升序遍历
+func TestRedBlackTreeAll(t *testing.T) {
+	tree := NewTree[int]()
+	r := rand.New(rand.NewSource(1))
+	want := make([]int, 0, 200)
+	for i := 0; i < 200; i++ {
+		v := r.Intn(1000)
+		tree.Insert(v)
+		want = append(want, v)
+	}
+	slices.Sort(want)
+
+	got := make([]int, 0, 200)
+	for v := range tree.All() {
+		got = append(got, v)
+	}
+	if !slices.Equal(got, want) {
+		t.Error("中序遍历结果与排序结果不一致")
+	}
+	for range NewTree[int]().All() {
+		t.Error("空树不应产生元素")
+	}
+
+	t.Run("InOrder", func(t *testing.T) {
+		got = got[:0]
+		tree.InOrder(func(v int) bool {
+			got = append(got, v)
+			return true
+		})
+		if !slices.Equal(got, want) {
+			t.Error("InOrder 结果与排序结果不一致")
+		}
+		got = got[:0]
+		tree.InOrder(func(v int) bool {
+			got = append(got, v)
+			return len(got) < 10
+		})
+		if !slices.Equal(got, want[:10]) {
+			t.Errorf("fn 返回 false 后应停止遍历，实际得到%v", got)
+		}
+	})
+}
+
+// TestClone 测试红黑树的深拷贝
+func TestClone(t *testing.T) {
+	tree := NewTree[int]()
+	for i := 0; i < 100; i++ {
+		tree.Insert((i * 37) % 100)
+	}
+	clone := tree.Clone(nil)
+	validateRedBlackProperties(t, clone)
+	if !slices.Equal(slices.Collect(clone.All()), slices.Collect(tree.All())) {
+		t.Fatal("拷贝的元素应与原树相同")
+	}
+	if clone.Root.Parent != nil || clone.Root.Left.Parent != clone.Root {
+		t.Error("拷贝的父节点指针不正确")
+	}
+	if clone.String() != tree.String() {
+		t.Error("拷贝的结构和颜色应与原树相同")
+	}
+	for i := 0; i < 50; i++ {
+		tree.Delete(i)
+	}
+	validateRedBlackProperties(t, clone)
+	if clone.Size() != 100 || !clone.Search(0) {
+		t.Error("修改原树不应影响拷贝")
+	}
+}
+
+// TestWithRecorder 测试红黑树向记录器报告事件
+func TestWithRecorder(t *testing.T) {
+	var c metrics.Counters
+	tree := NewTree[int](WithRecorder(&c))
+	for i := 0; i < 100; i++ {
+		tree.Insert(i)
+	}
+	tree.Search(5)
+	tree.Delete(5)
+	tree.Delete(1000)
+	if c.Count(metrics.Insert) != 100 || c.Count(metrics.Lookup) != 1 || c.Count(metrics.Delete) != 1 {
+		t.Errorf("事件计数错误: %v", c.Snapshot())
+	}
+	// 顺序插入会不断触发旋转
+	if c.Count(metrics.Rotation) == 0 {
+		t.Error("顺序插入应触发旋转")
+	}
+	if c.Peak() != 100 {
+		t.Errorf("期望峰值为100，实际为%d", c.Peak())
+	}
+}
+
+// TestWithArena 测试使用节点分配器的红黑树在删除和清空时回收节点
+func TestWithArena(t *testing.T) {
+	tree := NewTree[int](WithArena(16))
+	for i := 0; i < 200; i++ {
+		tree.Insert(i)
+	}
+	for i := 0; i < 200; i += 2 {
+		tree.Delete(i)
+	}
+	validateRedBlackProperties(t, tree)
+	if _, free := tree.nodes.Stats(); free != 100 {
+		t.Errorf("期望回收100个节点，实际为%d", free)
+	}
+	for i := 0; i < 200; i += 2 {
+		tree.Insert(i)
+	}
+	validateRedBlackProperties(t, tree)
+	if got := slices.Collect(tree.All()); len(got) != 200 || !slices.IsSorted(got) {
+		t.Error("复用节点后红黑树内容错误")
+	}
+
+	tree.Clear()
+	if _, free := tree.nodes.Stats(); free != 200 {
+		t.Errorf("清空后应回收全部节点，实际为%d", free)
+	}
+}
+
+// BenchmarkRedBlackTreeChurn 比较启用与不启用节点分配器时反复插入删除的开销
+func BenchmarkRedBlackTreeChurn(b *testing.B) {
+	for name, opts := range map[string][]Option{"默认": nil, "分配器": {WithArena(0)}} {
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+			tree := NewTree[int](opts...)
+			for i := 0; i < b.N; i++ {
+				tree.Insert(i)
+				if i >= 1024 {
+					tree.Delete(i - 1024)
+				}
+			}
+		})
+	}
+}
+
+// TestMinMax 测试返回最小和最大的元素
+func TestMinMax(t *testing.T) {
+	tree := NewTree[int]()
+	if _, ok := tree.Min(); ok {
+		t.Error("空树的 Min 应返回 false")
+	}
+	if _, ok := tree.Max(); ok {
+		t.Error("空树的 Max 应返回 false")
+	}
+	for _, v := range []int{50, 20, 80, 10, 30, 70, 90, 5} {
+		tree.Insert(v)
+	}
+	if v, ok := tree.Min(); !ok || v != 5 {
+		t.Errorf("Min() = (%d, %v)，期望 (5, true)", v, ok)
+	}
+	if v, ok := tree.Max(); !ok || v != 90 {
+		t.Errorf("Max() = (%d, %v)，期望 (90, true)", v, ok)
+	}
+	tree.Delete(5)
+	tree.Delete(90)
+	if v, _ := tree.Min(); v != 10 {
+		t.Errorf("删除最小值后 Min() = %d，期望 10", v)
+	}
+	if v, _ := tree.Max(); v != 80 {
+		t.Errorf("删除最大值后 Max() = %d，期望 80", v)
+	}
+}
+
+// TestNeighbors 测试 Floor、Ceiling、Predecessor 和 Successor 查询
+func TestNeighbors(t *testing.T) {
+	tree := NewTree[int]()
+	if _, ok := tree.Floor(1); ok {
+		t.Error("空树的 Floor 应返回 false")
+	}
+	r := rand.New(rand.NewSource(2))
+	var values []int
+	for i := 0; i < 300; i++ {
+		v := r.Intn(500) * 2 // 只插入偶数，奇数用于查询不存在的值
+		if !tree.Search(v) {
+			tree.Insert(v)
+			values = append(values, v)
+		}
+	}
+	slices.Sort(values)
+
+	// 对照在有序切片上二分查找的结果
+	check := func(name string, got int, ok bool, idx int) {
+		t.Helper()
+		if idx < 0 || idx >= len(values) {
+			if ok {
+				t.Errorf("%s 应返回 false，实际为%d", name, got)
+			}
+			return
+		}
+		if !ok || got != values[idx] {
+			t.Errorf("%s = (%d, %v)，期望 %d", name, got, ok, values[idx])
+		}
+	}
+	for q := -1; q <= 1001; q++ {
+		i, found := slices.BinarySearch(values, q)
+		v, ok := tree.Floor(q)
+		if found {
+			check(fmt.Sprintf("Floor(%d)", q), v, ok, i)
+		} else {
+			check(fmt.Sprintf("Floor(%d)", q), v, ok, i-1)
+		}
+		v, ok = tree.Ceiling(q)
+		check(fmt.Sprintf("Ceiling(%d)", q), v, ok, i)
+		v, ok = tree.Predecessor(q)
+		check(fmt.Sprintf("Predecessor(%d)", q), v, ok, i-1)
+		v, ok = tree.Successor(q)
+		if found {
+			check(fmt.Sprintf("Successor(%d)", q), v, ok, i+1)
+		} else {
+			check(fmt.Sprintf("Successor(%d)", q), v, ok, i)
+		}
+	}
+}
+
+// TestRange 测试按升序和降序遍历区间内的元素
+func TestRange(t *testing.T) {
+	tree := NewTree[int]()
+	for i := 0; i < 100; i++ {
+		tree.Insert((i * 37) % 100)
+	}
+	tree.Insert(50) // 重复的值应被访问两次
+
+	collect := func(visit func(lo, hi int, fn func(int) bool), lo, hi, limit int) []int {
+		var got []int
+		visit(lo, hi, func(v int) bool {
+			got = append(got, v)
+			return len(got) < limit
+		})
+		return got
+	}
+	tests := []struct {
+		name   string
+		lo, hi int
+		limit  int
+		want   []int
+	}{
+		{"区间内部", 45, 53, 100, []int{45, 46, 47, 48, 49, 50, 50, 51, 52}},
+		{"超出范围", -10, 3, 100, []int{0, 1, 2}},
+		{"空区间", 20, 20, 100, nil},
+		{"下界大于上界", 30, 20, 100, nil},
+		{"提前终止", 10, 90, 3, []int{10, 11, 12}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := collect(tree.AscendRange, tt.lo, tt.hi, tt.limit); !slices.Equal(got, tt.want) {
+				t.Errorf("AscendRange(%d, %d) = %v，期望 %v", tt.lo, tt.hi, got, tt.want)
+			}
+			want := slices.Clone(tt.want)
+			if tt.limit < 100 {
+				// 降序遍历提前终止时得到的是区间末尾的元素
+				want = []int{tt.hi - 1, tt.hi - 2, tt.hi - 3}
+			} else {
+				slices.Reverse(want)
+			}
+			if got := collect(tree.DescendRange, tt.lo, tt.hi, tt.limit); !slices.Equal(got, want) {
+				t.Errorf("DescendRange(%d, %d) = %v，期望 %v", tt.lo, tt.hi, got, want)
+			}
+		})
+	}
+}
+
+// TestRankKth 测试按排名查询与查询元素的排名
+func TestRankKth(t *testing.T) {
+	tree := NewTree[int](WithArena(8))
+	r := rand.New(rand.NewSource(3))
+	var values []int
+	for i := 0; i < 2000; i++ {
+		v := r.Intn(300)
+		if r.Intn(3) == 0 {
+			if idx, found := slices.BinarySearch(values, v); found {
+				tree.Delete(v)
+				values = slices.Delete(values, idx, idx+1)
+			}
+		} else {
+			tree.Insert(v) // 允许重复的值
+			idx, _ := slices.BinarySearch(values, v)
+			values = slices.Insert(values, idx, v)
+		}
+		if i%100 == 0 {
+			validateRedBlackProperties(t, tree)
+		}
+	}
+	validateRedBlackProperties(t, tree)
+
+	for k, want := range values {
+		if got, ok := tree.Kth(k); !ok || got != want {
+			t.Fatalf("Kth(%d) = (%d, %v)，期望 %d", k, got, ok, want)
+		}
+	}
+	for _, k := range []int{-1, len(values)} {
+		if _, ok := tree.Kth(k); ok {
+			t.Errorf("Kth(%d) 越界时应返回 false", k)
+		}
+	}
+	for v := -1; v <= 301; v++ {
+		want, _ := slices.BinarySearch(values, v)
+		if got := tree.Rank(v); got != want {
+			t.Fatalf("Rank(%d) = %d，期望 %d", v, got, want)
+		}
+	}
+
+	clone := tree.Clone(nil)
+	if got, _ := clone.Kth(len(values) / 2); got != values[len(values)/2] {
+		t.Error("拷贝应保留子树大小")
+	}
+}
+
+// TestMarshal 测试红黑树的二进制、gob 与 JSON 序列化
+func TestMarshal(t *testing.T) {
+	src := NewTree[int]()
+	for i := 0; i < 100; i++ {
+		src.Insert((i * 37) % 100)
+	}
+	src.Insert(42)
+	want := slices.Collect(src.All())
+
+	t.Run("二进制", func(t *testing.T) {
+		data, err := src.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		dst := NewTree[int]()
+		dst.Insert(1000)
+		if err := dst.UnmarshalBinary(data); err != nil {
+			t.Fatal(err)
+		}
+		validateRedBlackProperties(t, dst)
+		if got := slices.Collect(dst.All()); !slices.Equal(got, want) || dst.Size() != len(want) {
+			t.Errorf("期望%v，实际为%v", want, got)
+		}
+		if err := dst.UnmarshalBinary(data[:len(data)-1]); err == nil {
+			t.Error("截断的数据应返回错误")
+		}
+		if dst.Size() != len(want) {
+			t.Error("解码失败时应保持不变")
+		}
+	})
+
+	t.Run("gob", func(t *testing.T) {
+		var buf bytes.Buffer
+		if err := gob.NewEncoder(&buf).Encode(src); err != nil {
+			t.Fatal(err)
+		}
+		dst := NewTree[int]()
+		if err := gob.NewDecoder(&buf).Decode(dst); err != nil {
+			t.Fatal(err)
+		}
+		validateRedBlackProperties(t, dst)
+		if got := slices.Collect(dst.All()); !slices.Equal(got, want) {
+			t.Errorf("期望%v，实际为%v", want, got)
+		}
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		small := NewTree[string]()
+		for _, v := range []string{"b", "c", "a"} {
+			small.Insert(v)
+		}
+		data, err := json.Marshal(small)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != `["a","b","c"]` {
+			t.Errorf("JSON 为%s", data)
+		}
+		dst := NewTree[string]()
+		if err := json.Unmarshal(data, dst); err != nil {
+			t.Fatal(err)
+		}
+		validateRedBlackProperties(t, dst)
+		if got := slices.Collect(dst.All()); !slices.Equal(got, []string{"a", "b", "c"}) {
+			t.Errorf("解码结果为%v", got)
+		}
+		if err := json.Unmarshal([]byte(`{}`), dst); err == nil || dst.Size() != 3 {
+			t.Error("格式错误时应返回错误并保持不变")
+		}
+	})
+}
+
+// TestNewTreeFromSorted 测试由有序元素构建的红黑树满足红黑树性质且可以继续修改
+func TestNewTreeFromSorted(t *testing.T) {
+	for n := 0; n <= 130; n++ {
+		values := make([]int, n)
+		for i := range values {
+			values[i] = i / 2 * 2 // 包含重复的元素
+		}
+		tree := NewTreeFromSorted(values)
+		validateRedBlackProperties(t, tree)
+		if got := slices.Collect(tree.All()); tree.Size() != n || !slices.Equal(got, values) {
+			t.Fatalf("n=%d 时构建结果为%v", n, got)
+		}
+		for i := 0; i < n; i += 3 {
+			tree.Delete(values[i])
+			tree.Insert(values[i] + 1)
+		}
+		validateRedBlackProperties(t, tree)
+	}
+
+	t.Run("使用分配器", func(t *testing.T) {
+		tree := NewTreeFromSorted([]int{1, 2, 3, 4, 5}, WithArena(4))
+		tree.Delete(3)
+		if _, free := tree.nodes.Stats(); free != 1 {
+			t.Errorf("期望回收1个节点，实际为%d", free)
+		}
+	})
+
+	t.Run("未排序", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("元素未排序时应 panic")
+			}
+		}()
+		NewTreeFromSorted([]int{2, 1})
+	})
+}
+
+// BenchmarkNewTreeFromSorted 比较由有序元素直接构建与逐个插入的性能
+func BenchmarkNewTreeFromSorted(b *testing.B) {
+	values := make([]int, 100000)
+	for i := range values {
+		values[i] = i
+	}
+	b.Run("NewTreeFromSorted", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			NewTreeFromSorted(values)
+		}
+	})
+	b.Run("Insert", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			tree := NewTree[int]()
+			for _, v := range values {
+				tree.Insert(v)
+			}
+		}
+	})
+}
+
+// TestDuplicatePolicy 测试三种重复值处理方式下的插入、删除、计数与排名
+func TestDuplicatePolicy(t *testing.T) {
+	for name, policy := range map[string]DuplicatePolicy{
+		"允许重复": AllowDuplicates,
+		"计数":   CountDuplicates,
+		"拒绝重复": RejectDuplicates,
+	} {
+		t.Run(name, func(t *testing.T) {
+			tree := NewTree[int](WithDuplicates(policy))
+			r := rand.New(rand.NewSource(5))
+			counts := make(map[int]int)
+			for i := 0; i < 3000; i++ {
+				v := r.Intn(50)
+				switch r.Intn(5) {
+				case 0:
+					if got := tree.RemoveAll(v); got != counts[v] {
+						t.Fatalf("RemoveAll(%d) = %d，期望 %d", v, got, counts[v])
+					}
+					delete(counts, v)
+				case 1:
+					if got := tree.RemoveOne(v); got != (counts[v] > 0) {
+						t.Fatalf("RemoveOne(%d) = %v", v, got)
+					}
+					if counts[v] > 0 {
+						counts[v]--
+					}
+				default:
+					want := policy != RejectDuplicates || counts[v] == 0
+					if got := tree.Insert(v); got != want {
+						t.Fatalf("Insert(%d) = %v，期望 %v", v, got, want)
+					}
+					if want {
+						counts[v]++
+					}
+				}
+				if i%100 == 0 {
+					validateRedBlackProperties(t, tree)
+				}
+			}
+			validateRedBlackProperties(t, tree)
+
+			var want []int
+			for v := 0; v < 50; v++ {
+				if got := tree.Count(v); got != counts[v] {
+					t.Fatalf("Count(%d) = %d，期望 %d", v, got, counts[v])
+				}
+				for range counts[v] {
+					want = append(want, v)
+				}
+			}
+			if got := slices.Collect(tree.All()); tree.Size() != len(want) || !slices.Equal(got, want) {
+				t.Fatalf("遍历结果为%v，期望%v", got, want)
+			}
+			for k, v := range want {
+				if got, _ := tree.Kth(k); got != v {
+					t.Fatalf("Kth(%d) = %d，期望 %d", k, got, v)
+				}
+				if got, _ := slices.BinarySearch(want, v); tree.Rank(v) != got {
+					t.Fatalf("Rank(%d) = %d，期望 %d", v, tree.Rank(v), got)
+				}
+			}
+
+			// 拷贝、由有序元素构建以及分裂后的树保持相同的处理方式
+			clone := tree.Clone(nil)
+			built := NewTreeFromSorted(want, WithDuplicates(policy))
+			validateRedBlackProperties(t, built)
+			if !slices.Equal(slices.Collect(built.All()), want) {
+				t.Error("由有序元素构建的树与原树不同")
+			}
+			left, right := clone.Split(25)
+			validateRedBlackProperties(t, left)
+			validateRedBlackProperties(t, right)
+			if err := left.Join(right); err != nil || !slices.Equal(slices.Collect(left.All()), want) {
+				t.Errorf("分裂后合并的结果不正确: %v", err)
+			}
+			for _, tr := range []*Tree[int]{clone, built, left} {
+				tr.Insert(7)
+				tr.Insert(7)
+			}
+			if built.Count(7) != left.Count(7) || tree.Count(7) != counts[7] {
+				t.Error("派生的树应使用相同的处理方式")
+			}
+		})
+	}
+
+	t.Run("计数模式的节点数量", func(t *testing.T) {
+		tree := NewTree[string](WithDuplicates(CountDuplicates))
+		for range 100 {
+			tree.Insert("a")
+		}
+		tree.Insert("b")
+		if tree.Size() != 101 || tree.Root.Left != nil && tree.Root.Right != nil {
+			t.Errorf("重复的值应共用一个节点，大小为%d", tree.Size())
+		}
+		a, b := NewTree[int](WithDuplicates(CountDuplicates)), NewTree[int]()
+		a.Insert(1)
+		b.Insert(1)
+		if err := a.Join(b); err != ErrJoinOrder {
+			t.Errorf("计数模式下合并相等的值应返回ErrJoinOrder，实际为%v", err)
+		}
+	})
+}
+
+// TestHeight 测试树的高度与黑高度
+func TestHeight(t *testing.T) {
+	tree := NewTree[int]()
+	if tree.Height() != 0 || tree.BlackHeight() != 0 {
+		t.Error("空树的高度与黑高度应为0")
+	}
+	tree.Insert(1)
+	if tree.Height() != 1 || tree.BlackHeight() != 1 {
+		t.Errorf("只有根节点时高度为%d，黑高度为%d", tree.Height(), tree.BlackHeight())
+	}
+	for i := 2; i <= 10000; i++ {
+		tree.Insert(i)
+	}
+	// 顺序插入时红黑树仍保持平衡：高度不超过 2·log2(n+1)，黑高度不超过高度且至少为高度的一半
+	h, bh := tree.Height(), tree.BlackHeight()
+	if h > 2*14 || bh > h || 2*bh < h {
+		t.Errorf("高度为%d，黑高度为%d", h, bh)
+	}
+	if full := NewTreeFromSorted(make([]int, 1023)); full.Height() != 10 || full.BlackHeight() != 9 {
+		t.Errorf("1023个元素构建的树高度为%d，黑高度为%d", full.Height(), full.BlackHeight())
+	}
+}
+
+// TestDeleteMinMax 测试删除并返回最小和最大的元素
+func TestDeleteMinMax(t *testing.T) {
+	tree := NewTree[int](WithArena(8))
+	if _, ok := tree.DeleteMin(); ok {
+		t.Error("空树的 DeleteMin 应返回 false")
+	}
+	if _, ok := tree.DeleteMax(); ok {
+		t.Error("空树的 DeleteMax 应返回 false")
+	}
+	r := rand.New(rand.NewSource(6))
+	var values []int
+	for i := 0; i < 200; i++ {
+		v := r.Intn(100)
+		tree.Insert(v)
+		values = append(values, v)
+	}
+	slices.Sort(values)
+	for len(values) > 0 {
+		var got, want int
+		if len(values)%2 == 0 {
+			got, _ = tree.DeleteMin()
+			want, values = values[0], values[1:]
+		} else {
+			got, _ = tree.DeleteMax()
+			want, values = values[len(values)-1], values[:len(values)-1]
+		}
+		if got != want {
+			t.Fatalf("删除得到%d，期望%d", got, want)
+		}
+		if tree.Size() != len(values) {
+			t.Fatalf("删除后大小为%d，期望%d", tree.Size(), len(values))
+		}
+		if len(values)%20 == 0 {
+			validateRedBlackProperties(t, tree)
+		}
+	}
+	if tree.Root != nil {
+		t.Error("删除全部元素后根节点应为nil")
+	}
+
+	t.Run("计数模式", func(t *testing.T) {
+		tree := NewTree[int](WithDuplicates(CountDuplicates))
+		for _, v := range []int{3, 1, 1, 2} {
+			tree.Insert(v)
+		}
+		var got []int
+		for v, ok := tree.DeleteMin(); ok; v, ok = tree.DeleteMin() {
+			got = append(got, v)
+		}
+		if !slices.Equal(got, []int{1, 1, 2, 3}) {
+			t.Errorf("依次删除最小值得到%v", got)
+		}
+	})
+}
+
+// TestDescend 测试按降序遍历
+func TestDescend(t *testing.T) {
+	tree := NewTree[int](WithDuplicates(CountDuplicates))
+	for range NewTree[int]().Backward() {
+		t.Error("空树不应产生元素")
+	}
+	for _, v := range []int{50, 20, 80, 20, 90, 10, 60} {
+		tree.Insert(v)
+	}
+	if got := slices.Collect(tree.Backward()); !slices.Equal(got, []int{90, 80, 60, 50, 20, 20, 10}) {
+		t.Errorf("Backward 结果为%v", got)
+	}
+
+	// 排行榜取前3名
+	var top []int
+	tree.Descend(func(v int) bool {
+		top = append(top, v)
+		return len(top) < 3
+	})
+	if !slices.Equal(top, []int{90, 80, 60}) {
+		t.Errorf("Descend 取前3个元素为%v", top)
+	}
+
+	tests := []struct {
+		from int
+		want []int
+	}{
+		{60, []int{60, 50, 20, 20, 10}},
+		{55, []int{50, 20, 20, 10}},
+		{100, []int{90, 80, 60, 50, 20, 20, 10}},
+		{5, nil},
+	}
+	for _, tt := range tests {
+		var got []int
+		tree.DescendFrom(tt.from, func(v int) bool {
+			got = append(got, v)
+			return true
+		})
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("DescendFrom(%d) = %v，期望 %v", tt.from, got, tt.want)
+		}
+	}
+}
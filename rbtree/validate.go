@@ -0,0 +1,98 @@
+package rbtree
+
+import (
+	"fmt"
+
+	"golang.org/x/exp/constraints"
+)
+
+// Validate 检查红黑树的结构不变量，可以在批量操作之后检查红黑树是否完好
+// 检查内容：根节点为黑色，红色节点的子节点都是黑色，从任一节点到其所有叶子的路径包含相同数目的黑色节点，
+// 中序遍历有序（AllowDuplicates 以外的模式下严格递增），子节点的 Parent 指针指向父节点，
+// 每个节点记录的次数与子树大小正确，且元素总数等于 Size
+// 返回：
+//   - error: 结构完好时返回 nil，否则返回描述第一个问题的错误
+//
+// 时间复杂度: O(n)
+func (t *Tree[T]) Validate() error {
+	if t.Root == nil {
+		if t.size != 0 {
+			return fmt.Errorf("红黑树为空，但记录的元素数量为%d", t.size)
+		}
+		return nil
+	}
+	if t.Root.Color != BLACK {
+		return fmt.Errorf("根节点必须是黑色")
+	}
+	if t.Root.Parent != nil {
+		return fmt.Errorf("根节点的 Parent 指针不为空")
+	}
+	if _, err := t.validateNode(t.Root, nil, nil); err != nil {
+		return err
+	}
+	if t.Root.size != t.size {
+		return fmt.Errorf("红黑树中有%d个元素，但记录的元素数量为%d", t.Root.size, t.size)
+	}
+	return nil
+}
+
+// validateNode 检查以 n 为根的子树中的值位于区间 [lo, hi] 内，返回子树的黑高度
+// lo 或 hi 为 nil 时表示该侧没有限制；AllowDuplicates 模式下相等的值可能因旋转出现在任意一侧，
+// 其余模式下每个值只有一个节点，区间不包含端点
+func (t *Tree[T]) validateNode(n *Node[T], lo, hi *T) (int, error) {
+	if n == nil {
+		return 1, nil // NIL 节点被视为黑色
+	}
+	if (lo != nil && n.Value < *lo) || (hi != nil && n.Value > *hi) {
+		return 0, fmt.Errorf("节点 %v 违反二叉搜索树性质", n.Value)
+	}
+	if t.dup != AllowDuplicates && (lo != nil && n.Value == *lo || hi != nil && n.Value == *hi) {
+		return 0, fmt.Errorf("值 %v 出现在多个节点中", n.Value)
+	}
+	for _, child := range []*Node[T]{n.Left, n.Right} {
+		if child == nil {
+			continue
+		}
+		if child.Parent != n {
+			return 0, fmt.Errorf("节点 %v 的 Parent 指针没有指向父节点 %v", child.Value, n.Value)
+		}
+		if n.Color == RED && child.Color == RED {
+			return 0, fmt.Errorf("发现连续的红色节点: %v 与 %v", n.Value, child.Value)
+		}
+	}
+	left, err := t.validateNode(n.Left, lo, &n.Value)
+	if err != nil {
+		return 0, err
+	}
+	right, err := t.validateNode(n.Right, &n.Value, hi)
+	if err != nil {
+		return 0, err
+	}
+	if left != right {
+		return 0, fmt.Errorf("节点 %v 左右子树的黑高度不相等：左 %d, 右 %d", n.Value, left, right)
+	}
+	if err := validateCount(n); err != nil {
+		return 0, err
+	}
+	if n.Color == BLACK {
+		left++
+	}
+	return left, nil
+}
+
+// validateCount 检查节点记录的次数与子树大小
+func validateCount[T constraints.Ordered](n *Node[T]) error {
+	if n.count < 1 {
+		return fmt.Errorf("节点 %v 记录的次数为%d", n.Value, n.count)
+	}
+	if size := sizeOf(n.Left) + sizeOf(n.Right) + n.count; n.size != size {
+		return fmt.Errorf("节点 %v 记录的子树大小为%d，实际为%d", n.Value, n.size, size)
+	}
+	return nil
+}
+
+// Validate 检查映射底层红黑树的结构不变量
+// 时间复杂度: O(n)
+func (m *Map[K, V]) Validate() error {
+	return m.tree.Validate()
+}
@@ -0,0 +1,84 @@
+package rbtree
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestValidate 测试 Validate 能够发现各类结构损坏
+func TestValidate(t *testing.T) {
+	tree := NewTree[int]()
+	if err := tree.Validate(); err != nil {
+		t.Errorf("空树应通过检查: %v", err)
+	}
+	for i := range 100 {
+		tree.Insert(i % 37)
+	}
+	for i := range 20 {
+		tree.Delete(i)
+	}
+	if err := tree.Validate(); err != nil {
+		t.Fatalf("正常使用后应通过检查: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		corrupt func() (restore func())
+		want    string
+	}{
+		{"根节点为红色", func() func() {
+			tree.Root.Color = RED
+			return func() { tree.Root.Color = BLACK }
+		}, "根节点必须是黑色"},
+		{"黑高度不相等", func() func() {
+			n := minimum(tree.Root)
+			old := n.Color
+			n.Color = BLACK
+			if old == BLACK {
+				n.Color = RED
+			}
+			return func() { n.Color = old }
+		}, "节点"},
+		{"违反有序性", func() func() {
+			n := tree.Root.Left
+			old := n.Value
+			n.Value = tree.Root.Value + 1000
+			return func() { n.Value = old }
+		}, "违反二叉搜索树性质"},
+		{"父指针错误", func() func() {
+			n := tree.Root.Right
+			n.Parent = nil
+			return func() { n.Parent = tree.Root }
+		}, "Parent 指针"},
+		{"子树大小错误", func() func() {
+			n := tree.Root.Left
+			n.size++
+			return func() { n.size-- }
+		}, "子树大小"},
+		{"元素数量错误", func() func() {
+			tree.size++
+			return func() { tree.size-- }
+		}, "记录的元素数量"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer tt.corrupt()()
+			if err := tree.Validate(); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("应返回包含 %q 的错误，实际为%v", tt.want, err)
+			}
+		})
+	}
+	if err := tree.Validate(); err != nil {
+		t.Fatalf("恢复后应通过检查: %v", err)
+	}
+
+	t.Run("计数模式下的重复节点", func(t *testing.T) {
+		tree := NewTree[int](WithDuplicates(CountDuplicates))
+		tree.Insert(1)
+		tree.Insert(1)
+		tree.insertNode(&Node[int]{Value: 1}) // 绕过计数直接插入相同值的节点
+		if err := tree.Validate(); err == nil || !strings.Contains(err.Error(), "多个节点") {
+			t.Errorf("应发现相同的值出现在多个节点中，实际为%v", err)
+		}
+	})
+}
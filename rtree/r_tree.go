@@ -0,0 +1,378 @@
+package rtree
+
+import (
+	"iter"
+	"math"
+	"slices"
+)
+
+// entry 节点中的条目
+// 叶子节点的条目保存数据的包围盒和值，内部节点的条目保存子节点及其包围盒
+type entry[T comparable] struct {
+	rect  Rect
+	child *node[T]
+	value T
+}
+
+// node R 树节点
+type node[T comparable] struct {
+	level   int        // 节点所在层，叶子节点为0
+	entries []entry[T] // 条目
+}
+
+// RTree R 树，用于索引平面上的矩形
+// 子树选择和节点分裂采用 R* 树的启发式策略：
+// 插入时优先选择重叠面积增量最小的子树，分裂时先按周长之和选择分裂轴，
+// 再在该轴上选择重叠面积最小的分组（未实现 R* 树的强制重插）
+// 适合按区域查询地理信息等包含面积的对象
+type RTree[T comparable] struct {
+	root       *node[T]
+	maxEntries int // 节点最多包含的条目数
+	minEntries int // 非根节点最少包含的条目数
+	size       int // 数据条目数量
+}
+
+// NewRTree 创建新的 R 树
+// 参数：
+//   - maxEntries: 节点最多包含的条目数，必须大于等于4；节点最少条目数取其40%
+//
+// 返回：
+//   - *RTree[T]: 新创建的 R 树指针
+func NewRTree[T comparable](maxEntries int) *RTree[T] {
+	if maxEntries < 4 {
+		panic("节点容量必须至少为4")
+	}
+	return &RTree[T]{
+		root:       &node[T]{},
+		maxEntries: maxEntries,
+		minEntries: max(2, maxEntries*2/5),
+	}
+}
+
+// Len 返回数据条目数量
+// 时间复杂度: O(1)
+func (tree *RTree[T]) Len() int {
+	return tree.size
+}
+
+// Size 返回数据条目数量，与 Len 相同
+// 时间复杂度: O(1)
+func (tree *RTree[T]) Size() int {
+	return tree.size
+}
+
+// IsEmpty 检查 R 树是否为空
+// 时间复杂度: O(1)
+func (tree *RTree[T]) IsEmpty() bool {
+	return tree.size == 0
+}
+
+// Height 返回树的高度
+// 时间复杂度: O(1)
+func (tree *RTree[T]) Height() int {
+	return tree.root.level + 1
+}
+
+// Clear 清空 R 树
+// 时间复杂度: O(1)
+func (tree *RTree[T]) Clear() {
+	tree.root = &node[T]{}
+	tree.size = 0
+}
+
+// Insert 插入矩形及其关联的值，允许重复插入
+// 时间复杂度: O(log n)
+func (tree *RTree[T]) Insert(rect Rect, value T) {
+	if !rect.valid() {
+		panic("矩形的最小坐标不能大于最大坐标")
+	}
+	tree.insert(entry[T]{rect: rect, value: value}, 0)
+	tree.size++
+}
+
+// Delete 删除一个矩形和值都相等的条目，返回是否找到该条目
+// 时间复杂度: O(log n)，包围盒重叠严重时最坏 O(n)
+func (tree *RTree[T]) Delete(rect Rect, value T) bool {
+	var orphans []*node[T]
+	if !tree.delete(tree.root, rect, value, &orphans) {
+		return false
+	}
+	tree.size--
+
+	// 根节点只剩一个子节点时，树高减一
+	for tree.root.level > 0 && len(tree.root.entries) == 1 {
+		tree.root = tree.root.entries[0].child
+	}
+	// 把条目不足而被移除的节点中的条目重新插入到原来的层
+	for _, n := range orphans {
+		for _, e := range n.entries {
+			tree.insert(e, n.level)
+		}
+	}
+	return true
+}
+
+// Search 返回所有与 query 相交的矩形所关联的值
+// 时间复杂度: O(log n + m)，m 为结果数量，包围盒重叠严重时最坏 O(n)
+func (tree *RTree[T]) Search(query Rect) []T {
+	var result []T
+	tree.SearchFunc(query, func(rect Rect, value T) bool {
+		result = append(result, value)
+		return true
+	})
+	return result
+}
+
+// SearchFunc 遍历所有与 query 相交的条目，fn 返回 false 时停止遍历
+// 时间复杂度: O(log n + m)，m 为结果数量，包围盒重叠严重时最坏 O(n)
+func (tree *RTree[T]) SearchFunc(query Rect, fn func(rect Rect, value T) bool) {
+	tree.root.search(query, fn)
+}
+
+// All 返回遍历所有条目的迭代器，遍历顺序不确定
+// 遍历过程中不应修改 R 树
+func (tree *RTree[T]) All() iter.Seq2[Rect, T] {
+	return func(yield func(Rect, T) bool) {
+		tree.root.all(yield)
+	}
+}
+
+// Clone 返回结构相同的深拷贝
+// copier 用于复制每个条目关联的值，为 nil 时直接赋值；矩形直接赋值
+// 时间复杂度: O(n)
+func (tree *RTree[T]) Clone(copier func(T) T) *RTree[T] {
+	return &RTree[T]{
+		root:       tree.root.clone(copier),
+		maxEntries: tree.maxEntries,
+		minEntries: tree.minEntries,
+		size:       tree.size,
+	}
+}
+
+// Bounds 返回包含所有条目的最小矩形，空树返回 false
+// 时间复杂度: O(M)，M 为节点容量
+func (tree *RTree[T]) Bounds() (Rect, bool) {
+	if len(tree.root.entries) == 0 {
+		return Rect{}, false
+	}
+	return tree.root.bounds(), true
+}
+
+// insert 将条目插入到指定层的节点中，必要时分裂节点并增加树高
+func (tree *RTree[T]) insert(e entry[T], level int) {
+	if sibling := tree.insertAt(tree.root, e, level); sibling != nil {
+		old := tree.root
+		tree.root = &node[T]{
+			level: old.level + 1,
+			entries: []entry[T]{
+				{rect: old.bounds(), child: old},
+				{rect: sibling.bounds(), child: sibling},
+			},
+		}
+	}
+}
+
+// insertAt 递归插入条目，节点溢出时分裂并返回新的兄弟节点
+func (tree *RTree[T]) insertAt(n *node[T], e entry[T], level int) *node[T] {
+	if n.level == level {
+		n.entries = append(n.entries, e)
+	} else {
+		i := n.chooseSubtree(e.rect)
+		child := n.entries[i].child
+		sibling := tree.insertAt(child, e, level)
+		n.entries[i].rect = child.bounds()
+		if sibling != nil {
+			n.entries = append(n.entries, entry[T]{rect: sibling.bounds(), child: sibling})
+		}
+	}
+	if len(n.entries) > tree.maxEntries {
+		return tree.split(n)
+	}
+	return nil
+}
+
+// delete 递归删除条目，条目数低于下限的子节点会被摘除并记录到 orphans
+func (tree *RTree[T]) delete(n *node[T], rect Rect, value T, orphans *[]*node[T]) bool {
+	if n.level == 0 {
+		for i, e := range n.entries {
+			if e.rect == rect && e.value == value {
+				n.entries = slices.Delete(n.entries, i, i+1)
+				return true
+			}
+		}
+		return false
+	}
+	for i, e := range n.entries {
+		if !e.rect.Contains(rect) || !tree.delete(e.child, rect, value, orphans) {
+			continue
+		}
+		if len(e.child.entries) < tree.minEntries {
+			*orphans = append(*orphans, e.child)
+			n.entries = slices.Delete(n.entries, i, i+1)
+		} else {
+			n.entries[i].rect = e.child.bounds()
+		}
+		return true
+	}
+	return false
+}
+
+// split 使用 R* 树的分裂算法把溢出的节点分为两个，返回新节点
+func (tree *RTree[T]) split(n *node[T]) *node[T] {
+	// 每个轴分别按最小坐标和最大坐标排序
+	sortKeys := [2][2]func(r Rect) float64{
+		{func(r Rect) float64 { return r.MinX }, func(r Rect) float64 { return r.MaxX }},
+		{func(r Rect) float64 { return r.MinY }, func(r Rect) float64 { return r.MaxY }},
+	}
+
+	// 选择所有分组的周长之和最小的轴
+	bestAxis, bestMargin := 0, math.Inf(1)
+	for axis, keys := range sortKeys {
+		margin := 0.0
+		for _, key := range keys {
+			tree.sortEntries(n.entries, key)
+			tree.eachDistribution(n.entries, func(k int, left, right Rect) {
+				margin += left.Margin() + right.Margin()
+			})
+		}
+		if margin < bestMargin {
+			bestAxis, bestMargin = axis, margin
+		}
+	}
+
+	// 在选定的轴上选择重叠面积最小、其次总面积最小的分组
+	var bestKey func(r Rect) float64
+	bestK := 0
+	bestOverlap, bestArea := math.Inf(1), math.Inf(1)
+	for _, key := range sortKeys[bestAxis] {
+		tree.sortEntries(n.entries, key)
+		tree.eachDistribution(n.entries, func(k int, left, right Rect) {
+			overlap, area := left.overlap(right), left.Area()+right.Area()
+			if overlap < bestOverlap || (overlap == bestOverlap && area < bestArea) {
+				bestKey, bestK = key, k
+				bestOverlap, bestArea = overlap, area
+			}
+		})
+	}
+
+	tree.sortEntries(n.entries, bestKey)
+	sibling := &node[T]{level: n.level, entries: slices.Clone(n.entries[bestK:])}
+	clear(n.entries[bestK:])
+	n.entries = n.entries[:bestK]
+	return sibling
+}
+
+// sortEntries 按给定的坐标对条目稳定排序
+func (tree *RTree[T]) sortEntries(entries []entry[T], key func(r Rect) float64) {
+	slices.SortStableFunc(entries, func(a, b entry[T]) int {
+		ka, kb := key(a.rect), key(b.rect)
+		switch {
+		case ka < kb:
+			return -1
+		case ka > kb:
+			return 1
+		}
+		return 0
+	})
+}
+
+// eachDistribution 枚举所有满足最少条目数的分组方式，
+// 前 k 个条目为第一组，其余为第二组，回调参数为两组的包围盒
+func (tree *RTree[T]) eachDistribution(entries []entry[T], fn func(k int, left, right Rect)) {
+	n := len(entries)
+	// 预先计算前缀和后缀包围盒
+	prefix := make([]Rect, n)
+	suffix := make([]Rect, n)
+	prefix[0] = entries[0].rect
+	for i := 1; i < n; i++ {
+		prefix[i] = prefix[i-1].Union(entries[i].rect)
+	}
+	suffix[n-1] = entries[n-1].rect
+	for i := n - 2; i >= 0; i-- {
+		suffix[i] = suffix[i+1].Union(entries[i].rect)
+	}
+	for k := tree.minEntries; k <= n-tree.minEntries; k++ {
+		fn(k, prefix[k-1], suffix[k])
+	}
+}
+
+// chooseSubtree 选择插入矩形时的子节点下标
+// 子节点为叶子时选择重叠面积增量最小的，否则选择面积增量最小的，平局时选择面积较小的
+func (n *node[T]) chooseSubtree(rect Rect) int {
+	best := 0
+	bestOverlap, bestEnlarge, bestArea := math.Inf(1), math.Inf(1), math.Inf(1)
+	for i, e := range n.entries {
+		union := e.rect.Union(rect)
+		area := e.rect.Area()
+		enlarge := union.Area() - area
+		overlap := 0.0
+		if n.level == 1 {
+			for j, other := range n.entries {
+				if j != i {
+					overlap += union.overlap(other.rect) - e.rect.overlap(other.rect)
+				}
+			}
+		}
+		if overlap < bestOverlap ||
+			(overlap == bestOverlap && enlarge < bestEnlarge) ||
+			(overlap == bestOverlap && enlarge == bestEnlarge && area < bestArea) {
+			best = i
+			bestOverlap, bestEnlarge, bestArea = overlap, enlarge, area
+		}
+	}
+	return best
+}
+
+// bounds 返回节点所有条目的包围盒
+func (n *node[T]) bounds() Rect {
+	r := n.entries[0].rect
+	for _, e := range n.entries[1:] {
+		r = r.Union(e.rect)
+	}
+	return r
+}
+
+// search 递归查找与 query 相交的条目，返回 false 表示遍历已终止
+func (n *node[T]) search(query Rect, fn func(rect Rect, value T) bool) bool {
+	for _, e := range n.entries {
+		if !e.rect.Intersects(query) {
+			continue
+		}
+		if n.level == 0 {
+			if !fn(e.rect, e.value) {
+				return false
+			}
+		} else if !e.child.search(query, fn) {
+			return false
+		}
+	}
+	return true
+}
+
+// all 递归遍历子树中的所有条目，返回 false 表示遍历已终止
+func (n *node[T]) all(fn func(rect Rect, value T) bool) bool {
+	for _, e := range n.entries {
+		if n.level == 0 {
+			if !fn(e.rect, e.value) {
+				return false
+			}
+		} else if !e.child.all(fn) {
+			return false
+		}
+	}
+	return true
+}
+
+// clone 递归复制以 n 为根的子树
+func (n *node[T]) clone(copier func(T) T) *node[T] {
+	clone := &node[T]{level: n.level, entries: slices.Clone(n.entries)}
+	for i := range clone.entries {
+		e := &clone.entries[i]
+		if n.level > 0 {
+			e.child = e.child.clone(copier)
+		} else if copier != nil {
+			e.value = copier(e.value)
+		}
+	}
+	return clone
+}
@@ -0,0 +1,240 @@
+package rtree
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// 辅助函数：验证R树的基本属性（条目数量范围、包围盒正确、叶子深度一致）
+func validateRTree[T comparable](t *testing.T, tree *RTree[T]) {
+	t.Helper()
+	count := 0
+	var walk func(n *node[T])
+	walk = func(n *node[T]) {
+		if n != tree.root && len(n.entries) < tree.minEntries {
+			t.Errorf("节点条目数量%d少于下限%d", len(n.entries), tree.minEntries)
+		}
+		if len(n.entries) > tree.maxEntries {
+			t.Errorf("节点条目数量%d超过上限%d", len(n.entries), tree.maxEntries)
+		}
+		if n.level == 0 {
+			count += len(n.entries)
+			return
+		}
+		for _, e := range n.entries {
+			if e.child.level != n.level-1 {
+				t.Error("子节点层数错误")
+			}
+			if e.rect != e.child.bounds() {
+				t.Error("条目的包围盒与子节点不一致")
+			}
+			walk(e.child)
+		}
+	}
+	walk(tree.root)
+	if count != tree.size {
+		t.Errorf("条目数量%d与记录的大小%d不一致", count, tree.size)
+	}
+}
+
+// randomRect 生成随机的小矩形
+func randomRect(r *rand.Rand) Rect {
+	x, y := r.Float64()*1000, r.Float64()*1000
+	return Rect{x, y, x + r.Float64()*20, y + r.Float64()*20}
+}
+
+// bruteForce 朴素地找出与 query 相交的所有值
+func bruteForce(rects []Rect, query Rect) []int {
+	var result []int
+	for i, r := range rects {
+		if r.Intersects(query) {
+			result = append(result, i)
+		}
+	}
+	return result
+}
+
+func TestRTreeBasicOperations(t *testing.T) {
+	tree := NewRTree[string](4)
+
+	t.Run("空树操作", func(t *testing.T) {
+		if len(tree.Search(Rect{0, 0, 100, 100})) != 0 {
+			t.Error("空树不应该找到任何值")
+		}
+		if tree.Delete(Rect{0, 0, 1, 1}, "a") {
+			t.Error("空树删除应返回false")
+		}
+		if _, ok := tree.Bounds(); ok {
+			t.Error("空树不应有包围盒")
+		}
+	})
+
+	t.Run("插入和查找", func(t *testing.T) {
+		tree.Insert(Rect{0, 0, 1, 1}, "a")
+		tree.Insert(Rect{2, 2, 3, 3}, "b")
+		tree.Insert(Rect{5, 5, 8, 8}, "c")
+		tree.Insert(Rect{0.5, 0.5, 2.5, 2.5}, "d")
+		tree.Insert(Rect{10, 10, 10, 10}, "e")
+
+		got := tree.Search(Rect{0, 0, 2, 2})
+		slices.Sort(got)
+		if !slices.Equal(got, []string{"a", "b", "d"}) {
+			t.Errorf("查找结果错误: %v", got)
+		}
+		if got := tree.Search(Rect{9, 9, 11, 11}); !slices.Equal(got, []string{"e"}) {
+			t.Errorf("查找点的结果错误: %v", got)
+		}
+		if b, _ := tree.Bounds(); b != (Rect{0, 0, 10, 10}) {
+			t.Errorf("包围盒错误: %v", b)
+		}
+		if tree.Len() != 5 || tree.Height() != 2 {
+			t.Errorf("Len/Height错误: %d/%d", tree.Len(), tree.Height())
+		}
+		validateRTree(t, tree)
+	})
+
+	t.Run("删除", func(t *testing.T) {
+		if tree.Delete(Rect{0, 0, 1, 1}, "x") {
+			t.Error("值不同的条目不应被删除")
+		}
+		if !tree.Delete(Rect{0, 0, 1, 1}, "a") {
+			t.Error("删除已存在的条目失败")
+		}
+		if got := tree.Search(Rect{0, 0, 0.2, 0.2}); len(got) != 0 {
+			t.Errorf("删除后仍能找到: %v", got)
+		}
+		validateRTree(t, tree)
+	})
+
+	t.Run("非法矩形", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("非法矩形应该导致panic")
+			}
+		}()
+		tree.Insert(Rect{1, 1, 0, 0}, "bad")
+	})
+}
+
+func TestRTreeRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	tree := NewRTree[int](8)
+	rects := make([]Rect, 2000)
+	for i := range rects {
+		rects[i] = randomRect(r)
+		tree.Insert(rects[i], i)
+	}
+	validateRTree(t, tree)
+
+	check := func() {
+		for q := 0; q < 50; q++ {
+			query := randomRect(r)
+			query.MaxX += 50
+			query.MaxY += 50
+			got := tree.Search(query)
+			slices.Sort(got)
+			if want := bruteForce(rects, query); !slices.Equal(got, want) {
+				t.Fatalf("查找%v结果错误，期望%d个，实际为%d个", query, len(want), len(got))
+			}
+		}
+	}
+	check()
+
+	// 删除一半条目，被删除的矩形替换为查不到的位置
+	for i := 0; i < len(rects); i += 2 {
+		if !tree.Delete(rects[i], i) {
+			t.Fatalf("删除条目%d失败", i)
+		}
+		rects[i] = Rect{-10, -10, -10, -10}
+	}
+	if tree.Len() != len(rects)/2 {
+		t.Errorf("删除后期望大小为%d，实际为%d", len(rects)/2, tree.Len())
+	}
+	validateRTree(t, tree)
+	check()
+
+	// 删除剩余条目
+	for i := 1; i < len(rects); i += 2 {
+		if !tree.Delete(rects[i], i) {
+			t.Fatalf("删除条目%d失败", i)
+		}
+	}
+	if tree.Len() != 0 || tree.Height() != 1 {
+		t.Errorf("全部删除后树应为空，Len=%d Height=%d", tree.Len(), tree.Height())
+	}
+}
+
+func TestRTreeSearchFuncStop(t *testing.T) {
+	tree := NewRTree[int](4)
+	for i := 0; i < 100; i++ {
+		tree.Insert(Rect{float64(i), 0, float64(i), 0}, i)
+	}
+	count := 0
+	tree.SearchFunc(Rect{0, 0, 100, 0}, func(rect Rect, value int) bool {
+		count++
+		return count < 10
+	})
+	if count != 10 {
+		t.Errorf("提前终止后期望访问10个条目，实际为%d", count)
+	}
+}
+
+func BenchmarkRTree(b *testing.B) {
+	r := rand.New(rand.NewSource(1))
+	b.Run("插入", func(b *testing.B) {
+		tree := NewRTree[int](16)
+		for i := 0; i < b.N; i++ {
+			tree.Insert(randomRect(r), i)
+		}
+	})
+	b.Run("查找", func(b *testing.B) {
+		tree := NewRTree[int](16)
+		for i := 0; i < 100000; i++ {
+			tree.Insert(randomRect(r), i)
+		}
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			tree.Search(randomRect(r))
+		}
+	})
+}
+
+func TestRTreeAll(t *testing.T) {
+	tree := NewRTree[int](4)
+	for i := 0; i < 50; i++ {
+		tree.Insert(Rect{float64(i), 0, float64(i + 1), 1}, i)
+	}
+	var values []int
+	for rect, v := range tree.All() {
+		if rect.MinX != float64(v) {
+			t.Errorf("条目%d的矩形错误: %v", v, rect)
+		}
+		values = append(values, v)
+	}
+	slices.Sort(values)
+	if len(values) != 50 || values[0] != 0 || values[49] != 49 {
+		t.Errorf("遍历结果错误: %v", values)
+	}
+}
+
+// TestClone 测试 R 树的深拷贝
+func TestClone(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	tree := NewRTree[int](4)
+	rects := make([]Rect, 100)
+	for i := range rects {
+		rects[i] = randomRect(r)
+		tree.Insert(rects[i], i)
+	}
+	clone := tree.Clone(nil)
+	validateRTree(t, clone)
+	for i := 0; i < 50; i++ {
+		tree.Delete(rects[i], i)
+	}
+	validateRTree(t, clone)
+	all := Rect{-1e9, -1e9, 1e9, 1e9}
+	if got := clone.Search(all); len(got) != 100 || clone.Len() != 100 {
+		t.Errorf("修改原树不应影响拷贝，拷贝中有%d个条目", len(got))
+	}
+}
@@ -0,0 +1,60 @@
+package rtree
+
+// Rect 平面上与坐标轴对齐的矩形（包围盒）
+// 点可以表示为 MinX == MaxX 且 MinY == MaxY 的矩形
+type Rect struct {
+	MinX, MinY float64 // 左下角坐标
+	MaxX, MaxY float64 // 右上角坐标
+}
+
+// Area 返回矩形面积
+// 时间复杂度: O(1)
+func (r Rect) Area() float64 {
+	return (r.MaxX - r.MinX) * (r.MaxY - r.MinY)
+}
+
+// Margin 返回矩形的半周长
+// 时间复杂度: O(1)
+func (r Rect) Margin() float64 {
+	return (r.MaxX - r.MinX) + (r.MaxY - r.MinY)
+}
+
+// Intersects 判断两个矩形是否相交（边界接触也算相交）
+// 时间复杂度: O(1)
+func (r Rect) Intersects(other Rect) bool {
+	return r.MinX <= other.MaxX && other.MinX <= r.MaxX &&
+		r.MinY <= other.MaxY && other.MinY <= r.MaxY
+}
+
+// Contains 判断矩形是否完全包含 other
+// 时间复杂度: O(1)
+func (r Rect) Contains(other Rect) bool {
+	return r.MinX <= other.MinX && other.MaxX <= r.MaxX &&
+		r.MinY <= other.MinY && other.MaxY <= r.MaxY
+}
+
+// Union 返回同时包含两个矩形的最小矩形
+// 时间复杂度: O(1)
+func (r Rect) Union(other Rect) Rect {
+	return Rect{
+		MinX: min(r.MinX, other.MinX),
+		MinY: min(r.MinY, other.MinY),
+		MaxX: max(r.MaxX, other.MaxX),
+		MaxY: max(r.MaxY, other.MaxY),
+	}
+}
+
+// overlap 返回两个矩形重叠部分的面积
+func (r Rect) overlap(other Rect) float64 {
+	w := min(r.MaxX, other.MaxX) - max(r.MinX, other.MinX)
+	h := min(r.MaxY, other.MaxY) - max(r.MinY, other.MinY)
+	if w <= 0 || h <= 0 {
+		return 0
+	}
+	return w * h
+}
+
+// valid 判断矩形的最小坐标是否不大于最大坐标
+func (r Rect) valid() bool {
+	return r.MinX <= r.MaxX && r.MinY <= r.MaxY
+}
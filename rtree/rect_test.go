@@ -0,0 +1,37 @@
+package rtree
+
+import (
+	"testing"
+)
+
+// TestRect 测试矩形的几何运算
+func TestRect(t *testing.T) {
+	a := Rect{0, 0, 4, 3}
+	b := Rect{2, 1, 6, 5}
+	c := Rect{5, 4, 7, 7}
+
+	if a.Area() != 12 || a.Margin() != 7 {
+		t.Errorf("面积或半周长错误: %v, %v", a.Area(), a.Margin())
+	}
+	if !a.Intersects(b) || a.Intersects(c) {
+		t.Error("Intersects结果错误")
+	}
+	if !a.Intersects(Rect{4, 3, 4, 3}) {
+		t.Error("边界上的点应视为相交")
+	}
+	if !a.Contains(Rect{1, 1, 2, 2}) || a.Contains(b) {
+		t.Error("Contains结果错误")
+	}
+	if u := a.Union(c); u != (Rect{0, 0, 7, 7}) {
+		t.Errorf("Union结果错误: %v", u)
+	}
+	if o := a.overlap(b); o != 4 {
+		t.Errorf("重叠面积应为4，实际为%v", o)
+	}
+	if a.overlap(c) != 0 {
+		t.Error("不相交的矩形重叠面积应为0")
+	}
+	if (Rect{1, 0, 0, 1}).valid() {
+		t.Error("最小坐标大于最大坐标的矩形无效")
+	}
+}
@@ -0,0 +1,249 @@
+package set
+
+import (
+	"fmt"
+	"iter"
+	"strings"
+)
+
+// Multiset 基于哈希表的多重集合，记录每个元素出现的次数
+// 集合代数按次数逐元素计算：Sum 为次数之和，Union 取较大值，Intersection 取较小值，
+// Difference 为次数之差（不小于0）；运算结果均返回新的多重集合
+type Multiset[T comparable] struct {
+	counts map[T]int // 每个元素出现的次数，只保存次数大于0的元素
+	total  int       // 所有元素出现次数之和
+}
+
+// NewMultiset 创建包含给定元素的多重集合，重复的元素会被计数
+// 时间复杂度: O(n)
+func NewMultiset[T comparable](items ...T) *Multiset[T] {
+	m := &Multiset[T]{counts: make(map[T]int, len(items))}
+	for _, item := range items {
+		m.Add(item)
+	}
+	return m
+}
+
+// Add 添加一次元素，返回添加后元素的次数
+// 时间复杂度: O(1)
+func (m *Multiset[T]) Add(item T) int {
+	return m.AddN(item, 1)
+}
+
+// AddN 添加 n 次元素，返回添加后元素的次数
+// n 必须大于等于0，否则 panic
+// 时间复杂度: O(1)
+func (m *Multiset[T]) AddN(item T, n int) int {
+	if n < 0 {
+		panic("添加次数不能为负数")
+	}
+	if n == 0 {
+		return m.counts[item]
+	}
+	m.counts[item] += n
+	m.total += n
+	return m.counts[item]
+}
+
+// Remove 删除一次元素，返回元素是否存在
+// 时间复杂度: O(1)
+func (m *Multiset[T]) Remove(item T) bool {
+	return m.RemoveN(item, 1) > 0
+}
+
+// RemoveN 最多删除 n 次元素，返回实际删除的次数
+// 时间复杂度: O(1)
+func (m *Multiset[T]) RemoveN(item T, n int) int {
+	count := m.counts[item]
+	if n <= 0 || count == 0 {
+		return 0
+	}
+	if n >= count {
+		delete(m.counts, item)
+		m.total -= count
+		return count
+	}
+	m.counts[item] = count - n
+	m.total -= n
+	return n
+}
+
+// RemoveAll 删除元素的所有出现，返回删除的次数
+// 时间复杂度: O(1)
+func (m *Multiset[T]) RemoveAll(item T) int {
+	count := m.counts[item]
+	delete(m.counts, item)
+	m.total -= count
+	return count
+}
+
+// SetCount 把元素的次数设置为 n，n 为0时删除该元素，返回原来的次数
+// n 必须大于等于0，否则 panic
+// 时间复杂度: O(1)
+func (m *Multiset[T]) SetCount(item T, n int) int {
+	if n < 0 {
+		panic("元素次数不能为负数")
+	}
+	old := m.RemoveAll(item)
+	m.AddN(item, n)
+	return old
+}
+
+// Count 返回元素出现的次数，不存在时返回0
+// 时间复杂度: O(1)
+func (m *Multiset[T]) Count(item T) int {
+	return m.counts[item]
+}
+
+// Contains 检查元素是否至少出现一次
+// 时间复杂度: O(1)
+func (m *Multiset[T]) Contains(item T) bool {
+	return m.counts[item] > 0
+}
+
+// Distinct 返回不同元素的数量
+// 时间复杂度: O(1)
+func (m *Multiset[T]) Distinct() int {
+	return len(m.counts)
+}
+
+// TotalLen 返回所有元素出现次数之和
+// 时间复杂度: O(1)
+func (m *Multiset[T]) TotalLen() int {
+	return m.total
+}
+
+// Size 返回所有元素出现次数之和，与 TotalLen 相同
+// 时间复杂度: O(1)
+func (m *Multiset[T]) Size() int {
+	return m.total
+}
+
+// IsEmpty 检查多重集合是否为空
+// 时间复杂度: O(1)
+func (m *Multiset[T]) IsEmpty() bool {
+	return m.total == 0
+}
+
+// Clear 清空多重集合
+// 时间复杂度: O(n)
+func (m *Multiset[T]) Clear() {
+	clear(m.counts)
+	m.total = 0
+}
+
+// Clone 返回多重集合的拷贝
+// copier 用于复制每个元素，为 nil 时直接赋值；复制后相等的元素次数会合并
+// 时间复杂度: O(n)
+func (m *Multiset[T]) Clone(copier func(T) T) *Multiset[T] {
+	clone := &Multiset[T]{counts: make(map[T]int, len(m.counts))}
+	for item, count := range m.counts {
+		if copier != nil {
+			item = copier(item)
+		}
+		clone.AddN(item, count)
+	}
+	return clone
+}
+
+// All 返回遍历所有元素的迭代器，每个元素按其次数重复产出，不同元素之间的顺序不确定
+func (m *Multiset[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for item, count := range m.counts {
+			for range count {
+				if !yield(item) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Counts 返回遍历每个不同元素及其次数的迭代器，顺序不确定
+func (m *Multiset[T]) Counts() iter.Seq2[T, int] {
+	return func(yield func(T, int) bool) {
+		for item, count := range m.counts {
+			if !yield(item, count) {
+				return
+			}
+		}
+	}
+}
+
+// Sum 返回次数逐元素相加的多重集合
+// 时间复杂度: O(n + m)
+func (m *Multiset[T]) Sum(other *Multiset[T]) *Multiset[T] {
+	result := m.Clone(nil)
+	for item, count := range other.counts {
+		result.AddN(item, count)
+	}
+	return result
+}
+
+// Union 返回次数逐元素取较大值的多重集合
+// 时间复杂度: O(n + m)
+func (m *Multiset[T]) Union(other *Multiset[T]) *Multiset[T] {
+	result := m.Clone(nil)
+	for item, count := range other.counts {
+		if count > result.counts[item] {
+			result.SetCount(item, count)
+		}
+	}
+	return result
+}
+
+// Intersection 返回次数逐元素取较小值的多重集合
+// 时间复杂度: O(min(n, m))
+func (m *Multiset[T]) Intersection(other *Multiset[T]) *Multiset[T] {
+	small, large := m, other
+	if len(small.counts) > len(large.counts) {
+		small, large = large, small
+	}
+	result := NewMultiset[T]()
+	for item, count := range small.counts {
+		result.AddN(item, min(count, large.counts[item]))
+	}
+	return result
+}
+
+// Difference 返回次数逐元素相减的多重集合，次数不足时视为0
+// 时间复杂度: O(n)
+func (m *Multiset[T]) Difference(other *Multiset[T]) *Multiset[T] {
+	result := NewMultiset[T]()
+	for item, count := range m.counts {
+		if diff := count - other.counts[item]; diff > 0 {
+			result.AddN(item, diff)
+		}
+	}
+	return result
+}
+
+// IsSubset 检查 m 中每个元素的次数是否都不超过其在 other 中的次数
+// 时间复杂度: O(n)
+func (m *Multiset[T]) IsSubset(other *Multiset[T]) bool {
+	if m.total > other.total {
+		return false
+	}
+	for item, count := range m.counts {
+		if count > other.counts[item] {
+			return false
+		}
+	}
+	return true
+}
+
+// Equal 检查两个多重集合中每个元素的次数是否都相同
+// 时间复杂度: O(n)
+func (m *Multiset[T]) Equal(other *Multiset[T]) bool {
+	return m.total == other.total && len(m.counts) == len(other.counts) && m.IsSubset(other)
+}
+
+// String 返回多重集合的字符串表示，格式为 {元素:次数 ...}，元素顺序不确定
+// 实现 fmt.Stringer 接口
+func (m *Multiset[T]) String() string {
+	parts := make([]string, 0, len(m.counts))
+	for item, count := range m.counts {
+		parts = append(parts, fmt.Sprintf("%v:%d", item, count))
+	}
+	return "{" + strings.Join(parts, " ") + "}"
+}
@@ -0,0 +1,127 @@
+package set
+
+import (
+	"slices"
+	"sort"
+	"testing"
+)
+
+// TestMultisetBasicOperations 测试多重集合的基本操作
+func TestMultisetBasicOperations(t *testing.T) {
+	m := NewMultiset(1, 2, 2, 3, 3, 3)
+	if m.Distinct() != 3 || m.TotalLen() != 6 || m.Size() != 6 {
+		t.Errorf("Distinct=%d TotalLen=%d Size=%d", m.Distinct(), m.TotalLen(), m.Size())
+	}
+	if got := m.Count(3); got != 3 {
+		t.Errorf("Count(3)期望3，实际为%d", got)
+	}
+	if got := m.Count(4); got != 0 {
+		t.Errorf("Count(4)期望0，实际为%d", got)
+	}
+	if got := m.Add(1); got != 2 {
+		t.Errorf("Add(1)期望返回2，实际为%d", got)
+	}
+	if got := m.AddN(4, 0); got != 0 || m.Contains(4) {
+		t.Error("添加0次不应加入元素")
+	}
+
+	if !m.Remove(2) || m.Count(2) != 1 {
+		t.Error("删除一次元素失败")
+	}
+	if !m.Remove(2) || m.Contains(2) || m.Remove(2) {
+		t.Error("次数减为0后元素应被删除")
+	}
+	if got := m.RemoveN(3, 5); got != 3 || m.Contains(3) {
+		t.Errorf("RemoveN期望删除3次，实际为%d", got)
+	}
+	if got := m.RemoveAll(1); got != 2 {
+		t.Errorf("RemoveAll期望删除2次，实际为%d", got)
+	}
+	if !m.IsEmpty() || m.Distinct() != 0 {
+		t.Errorf("删除所有元素后应为空: %v", m)
+	}
+
+	if old := m.SetCount(5, 4); old != 0 || m.Count(5) != 4 || m.TotalLen() != 4 {
+		t.Error("SetCount设置次数失败")
+	}
+	if old := m.SetCount(5, 0); old != 4 || m.Contains(5) || !m.IsEmpty() {
+		t.Error("SetCount为0时应删除元素")
+	}
+
+	m.AddN(7, 2)
+	m.Clear()
+	if !m.IsEmpty() || m.Count(7) != 0 {
+		t.Error("清空后多重集合应为空")
+	}
+}
+
+// TestMultisetIteration 测试多重集合的遍历
+func TestMultisetIteration(t *testing.T) {
+	m := NewMultiset("a", "b", "b")
+	got := slices.Collect(m.All())
+	sort.Strings(got)
+	if !slices.Equal(got, []string{"a", "b", "b"}) {
+		t.Errorf("All结果错误: %v", got)
+	}
+	counts := make(map[string]int)
+	for item, count := range m.Counts() {
+		counts[item] = count
+	}
+	if len(counts) != 2 || counts["a"] != 1 || counts["b"] != 2 {
+		t.Errorf("Counts结果错误: %v", counts)
+	}
+	if s := NewMultiset(1, 1).String(); s != "{1:2}" {
+		t.Errorf("String结果错误: %s", s)
+	}
+}
+
+// TestMultisetAlgebra 测试多重集合的代数运算
+func TestMultisetAlgebra(t *testing.T) {
+	a := NewMultiset(1, 1, 1, 2, 3)
+	b := NewMultiset(1, 2, 2, 4)
+
+	tests := []struct {
+		name string
+		got  *Multiset[int]
+		want *Multiset[int]
+	}{
+		{"Sum", a.Sum(b), NewMultiset(1, 1, 1, 1, 2, 2, 2, 3, 4)},
+		{"Union", a.Union(b), NewMultiset(1, 1, 1, 2, 2, 3, 4)},
+		{"Intersection", a.Intersection(b), NewMultiset(1, 2)},
+		{"Difference", a.Difference(b), NewMultiset(1, 1, 3)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !tt.got.Equal(tt.want) {
+				t.Errorf("期望 %v，实际为 %v", tt.want, tt.got)
+			}
+			if tt.got.TotalLen() != tt.want.TotalLen() {
+				t.Errorf("TotalLen期望%d，实际为%d", tt.want.TotalLen(), tt.got.TotalLen())
+			}
+		})
+	}
+
+	if a.Count(1) != 3 || b.Count(2) != 2 {
+		t.Error("运算不应修改参与运算的多重集合")
+	}
+	if !NewMultiset(1, 2).IsSubset(a) || NewMultiset(2, 2).IsSubset(a) {
+		t.Error("IsSubset应比较次数")
+	}
+	if a.Equal(NewMultiset(1, 2, 3)) {
+		t.Error("次数不同的多重集合不应相等")
+	}
+}
+
+// TestMultisetClone 测试多重集合的拷贝
+func TestMultisetClone(t *testing.T) {
+	m := NewMultiset(1, 2, 2)
+	clone := m.Clone(nil)
+	clone.Add(2)
+	if m.Count(2) != 2 || clone.Count(2) != 3 {
+		t.Error("修改拷贝不应影响原多重集合")
+	}
+	merged := m.Clone(func(v int) int { return v / 10 })
+	if merged.Count(0) != 3 || merged.Distinct() != 1 {
+		t.Errorf("复制后相等的元素次数应合并: %v", merged)
+	}
+}
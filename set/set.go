@@ -0,0 +1,192 @@
+package set
+
+import (
+	"fmt"
+	"iter"
+	"strings"
+)
+
+// Set 基于哈希表的集合
+// 支持常用的集合代数运算，运算结果均返回新的集合，不修改参与运算的集合
+type Set[T comparable] struct {
+	items map[T]struct{} // 存储元素的哈希表
+}
+
+// New 创建包含给定元素的集合
+// 时间复杂度: O(n)
+func New[T comparable](items ...T) *Set[T] {
+	s := &Set[T]{items: make(map[T]struct{}, len(items))}
+	for _, item := range items {
+		s.items[item] = struct{}{}
+	}
+	return s
+}
+
+// Add 添加元素，返回是否添加了新元素
+// 时间复杂度: O(1)
+func (s *Set[T]) Add(item T) bool {
+	if _, ok := s.items[item]; ok {
+		return false
+	}
+	s.items[item] = struct{}{}
+	return true
+}
+
+// Remove 删除元素，返回元素是否存在
+// 时间复杂度: O(1)
+func (s *Set[T]) Remove(item T) bool {
+	if _, ok := s.items[item]; !ok {
+		return false
+	}
+	delete(s.items, item)
+	return true
+}
+
+// Contains 检查元素是否存在
+// 时间复杂度: O(1)
+func (s *Set[T]) Contains(item T) bool {
+	_, ok := s.items[item]
+	return ok
+}
+
+// Size 返回元素数量
+// 时间复杂度: O(1)
+func (s *Set[T]) Size() int {
+	return len(s.items)
+}
+
+// IsEmpty 检查集合是否为空
+// 时间复杂度: O(1)
+func (s *Set[T]) IsEmpty() bool {
+	return len(s.items) == 0
+}
+
+// Clear 清空集合
+// 时间复杂度: O(n)
+func (s *Set[T]) Clear() {
+	clear(s.items)
+}
+
+// Clone 返回集合的拷贝
+// copier 用于复制每个元素，为 nil 时直接赋值；复制后相等的元素只保留一个
+// 时间复杂度: O(n)
+func (s *Set[T]) Clone(copier func(T) T) *Set[T] {
+	clone := &Set[T]{items: make(map[T]struct{}, len(s.items))}
+	for item := range s.items {
+		if copier != nil {
+			item = copier(item)
+		}
+		clone.items[item] = struct{}{}
+	}
+	return clone
+}
+
+// All 返回遍历所有元素的迭代器，遍历顺序不确定
+func (s *Set[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for item := range s.items {
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+// ToSlice 将集合转换为切片，元素顺序不确定
+// 时间复杂度: O(n)
+func (s *Set[T]) ToSlice() []T {
+	result := make([]T, 0, len(s.items))
+	for item := range s.items {
+		result = append(result, item)
+	}
+	return result
+}
+
+// Union 返回两个集合的并集
+// 时间复杂度: O(n + m)
+func (s *Set[T]) Union(other *Set[T]) *Set[T] {
+	result := &Set[T]{items: make(map[T]struct{}, len(s.items)+len(other.items))}
+	for item := range s.items {
+		result.items[item] = struct{}{}
+	}
+	for item := range other.items {
+		result.items[item] = struct{}{}
+	}
+	return result
+}
+
+// Intersection 返回两个集合的交集
+// 时间复杂度: O(min(n, m))
+func (s *Set[T]) Intersection(other *Set[T]) *Set[T] {
+	small, large := s, other
+	if len(small.items) > len(large.items) {
+		small, large = large, small
+	}
+	result := New[T]()
+	for item := range small.items {
+		if _, ok := large.items[item]; ok {
+			result.items[item] = struct{}{}
+		}
+	}
+	return result
+}
+
+// Difference 返回差集，即属于 s 但不属于 other 的元素
+// 时间复杂度: O(n)
+func (s *Set[T]) Difference(other *Set[T]) *Set[T] {
+	result := New[T]()
+	for item := range s.items {
+		if _, ok := other.items[item]; !ok {
+			result.items[item] = struct{}{}
+		}
+	}
+	return result
+}
+
+// SymmetricDifference 返回对称差集，即只属于其中一个集合的元素
+// 时间复杂度: O(n + m)
+func (s *Set[T]) SymmetricDifference(other *Set[T]) *Set[T] {
+	result := s.Difference(other)
+	for item := range other.items {
+		if _, ok := s.items[item]; !ok {
+			result.items[item] = struct{}{}
+		}
+	}
+	return result
+}
+
+// IsSubset 检查 s 是否为 other 的子集
+// 时间复杂度: O(n)
+func (s *Set[T]) IsSubset(other *Set[T]) bool {
+	if len(s.items) > len(other.items) {
+		return false
+	}
+	for item := range s.items {
+		if _, ok := other.items[item]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// IsSuperset 检查 s 是否为 other 的超集
+// 时间复杂度: O(m)
+func (s *Set[T]) IsSuperset(other *Set[T]) bool {
+	return other.IsSubset(s)
+}
+
+// Equal 检查两个集合是否包含相同的元素
+// 时间复杂度: O(n)
+func (s *Set[T]) Equal(other *Set[T]) bool {
+	return len(s.items) == len(other.items) && s.IsSubset(other)
+}
+
+// String 返回集合的字符串表示，元素顺序不确定
+// 实现 fmt.Stringer 接口
+func (s *Set[T]) String() string {
+	parts := make([]string, 0, len(s.items))
+	for item := range s.items {
+		parts = append(parts, fmt.Sprintf("%v", item))
+	}
+	return "{" + strings.Join(parts, " ") + "}"
+}
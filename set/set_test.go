@@ -0,0 +1,124 @@
+package set
+
+import (
+	"slices"
+	"sort"
+	"testing"
+)
+
+// sortedSlice 返回排好序的元素切片，便于比较
+func sortedSlice(s *Set[int]) []int {
+	result := s.ToSlice()
+	sort.Ints(result)
+	return result
+}
+
+// sliceEqual 辅助函数：比较两个切片是否相等
+func sliceEqual(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// TestSetBasicOperations 测试基本操作
+func TestSetBasicOperations(t *testing.T) {
+	s := New(1, 2, 2, 3)
+	if s.Size() != 3 {
+		t.Errorf("期望大小为3，实际为%d", s.Size())
+	}
+
+	if s.Add(3) {
+		t.Error("添加已存在的元素应返回false")
+	}
+	if !s.Add(4) || !s.Contains(4) {
+		t.Error("添加新元素失败")
+	}
+	if !s.Remove(1) || s.Contains(1) {
+		t.Error("删除元素失败")
+	}
+	if s.Remove(100) {
+		t.Error("删除不存在的元素应返回false")
+	}
+
+	count := 0
+	for range s.All() {
+		count++
+	}
+	if count != s.Size() {
+		t.Errorf("迭代次数%d与大小%d不一致", count, s.Size())
+	}
+
+	s.Clear()
+	if !s.IsEmpty() {
+		t.Error("清空后集合应为空")
+	}
+}
+
+// TestSetAlgebra 测试集合代数运算
+func TestSetAlgebra(t *testing.T) {
+	a := New(1, 2, 3, 4)
+	b := New(3, 4, 5)
+
+	tests := []struct {
+		name string
+		got  *Set[int]
+		want []int
+	}{
+		{"并集", a.Union(b), []int{1, 2, 3, 4, 5}},
+		{"交集", a.Intersection(b), []int{3, 4}},
+		{"差集", a.Difference(b), []int{1, 2}},
+		{"对称差集", a.SymmetricDifference(b), []int{1, 2, 5}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sortedSlice(tt.got); !sliceEqual(got, tt.want) {
+				t.Errorf("期望 %v，实际为 %v", tt.want, got)
+			}
+		})
+	}
+
+	// 运算不应修改原集合
+	if a.Size() != 4 || b.Size() != 3 {
+		t.Error("集合运算不应修改参与运算的集合")
+	}
+}
+
+// TestSetSubset 测试子集判断
+func TestSetSubset(t *testing.T) {
+	a := New(1, 2)
+	b := New(1, 2, 3)
+
+	if !a.IsSubset(b) || b.IsSubset(a) {
+		t.Error("IsSubset结果不正确")
+	}
+	if !b.IsSuperset(a) || a.IsSuperset(b) {
+		t.Error("IsSuperset结果不正确")
+	}
+	if !New[int]().IsSubset(a) {
+		t.Error("空集是任意集合的子集")
+	}
+	if !a.Equal(New(2, 1)) || a.Equal(b) {
+		t.Error("Equal结果不正确")
+	}
+}
+
+// TestClone 测试集合的拷贝
+func TestClone(t *testing.T) {
+	s := New(1, 2, 3)
+	clone := s.Clone(nil)
+	s.Remove(1)
+	clone.Add(4)
+	if !slices.Equal(sortedSlice(clone), []int{1, 2, 3, 4}) || s.Size() != 2 {
+		t.Errorf("拷贝与原集合应互不影响，拷贝为%v", sortedSlice(clone))
+	}
+	// 复制后相等的元素只保留一个
+	if merged := New(1, 2, 3, 4).Clone(func(v int) int { return v / 2 }); merged.Size() != 3 {
+		t.Errorf("期望大小为3，实际为%d", merged.Size())
+	}
+}
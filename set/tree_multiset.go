@@ -0,0 +1,285 @@
+package set
+
+import (
+	"fmt"
+	"iter"
+	"strings"
+
+	"godatastructure/btree"
+	"golang.org/x/exp/constraints"
+)
+
+// treeMultisetDegree 有序多重集合底层 B 树的最小度数
+const treeMultisetDegree = 16
+
+// TreeMultiset 基于 B 树的有序多重集合，记录每个元素出现的次数
+// 元素按升序遍历；集合代数的语义与 Multiset 相同，但通过按升序归并两侧的元素完成，不需要逐个查找
+type TreeMultiset[T constraints.Ordered] struct {
+	tree  *btree.BTree[T, int] // 元素到次数的映射，只保存次数大于0的元素
+	total int                  // 所有元素出现次数之和
+}
+
+// NewTreeMultiset 创建包含给定元素的有序多重集合，重复的元素会被计数
+// 时间复杂度: O(n log n)
+func NewTreeMultiset[T constraints.Ordered](items ...T) *TreeMultiset[T] {
+	m := &TreeMultiset[T]{tree: btree.NewBTree[T, int](treeMultisetDegree)}
+	for _, item := range items {
+		m.Add(item)
+	}
+	return m
+}
+
+// Add 添加一次元素，返回添加后元素的次数
+// 时间复杂度: O(log n)
+func (m *TreeMultiset[T]) Add(item T) int {
+	return m.AddN(item, 1)
+}
+
+// AddN 添加 n 次元素，返回添加后元素的次数
+// n 必须大于等于0，否则 panic
+// 时间复杂度: O(log n)
+func (m *TreeMultiset[T]) AddN(item T, n int) int {
+	if n < 0 {
+		panic("添加次数不能为负数")
+	}
+	count, _ := m.tree.Get(item)
+	if n == 0 {
+		return count
+	}
+	m.tree.Put(item, count+n)
+	m.total += n
+	return count + n
+}
+
+// Remove 删除一次元素，返回元素是否存在
+// 时间复杂度: O(log n)
+func (m *TreeMultiset[T]) Remove(item T) bool {
+	return m.RemoveN(item, 1) > 0
+}
+
+// RemoveN 最多删除 n 次元素，返回实际删除的次数
+// 时间复杂度: O(log n)
+func (m *TreeMultiset[T]) RemoveN(item T, n int) int {
+	if n <= 0 {
+		return 0
+	}
+	count, ok := m.tree.Get(item)
+	if !ok {
+		return 0
+	}
+	if n >= count {
+		m.tree.Delete(item)
+		m.total -= count
+		return count
+	}
+	m.tree.Put(item, count-n)
+	m.total -= n
+	return n
+}
+
+// RemoveAll 删除元素的所有出现，返回删除的次数
+// 时间复杂度: O(log n)
+func (m *TreeMultiset[T]) RemoveAll(item T) int {
+	count, _ := m.tree.LoadAndDelete(item)
+	m.total -= count
+	return count
+}
+
+// SetCount 把元素的次数设置为 n，n 为0时删除该元素，返回原来的次数
+// n 必须大于等于0，否则 panic
+// 时间复杂度: O(log n)
+func (m *TreeMultiset[T]) SetCount(item T, n int) int {
+	if n < 0 {
+		panic("元素次数不能为负数")
+	}
+	old := m.RemoveAll(item)
+	m.AddN(item, n)
+	return old
+}
+
+// Count 返回元素出现的次数，不存在时返回0
+// 时间复杂度: O(log n)
+func (m *TreeMultiset[T]) Count(item T) int {
+	count, _ := m.tree.Get(item)
+	return count
+}
+
+// Contains 检查元素是否至少出现一次
+// 时间复杂度: O(log n)
+func (m *TreeMultiset[T]) Contains(item T) bool {
+	_, ok := m.tree.Get(item)
+	return ok
+}
+
+// Distinct 返回不同元素的数量
+// 时间复杂度: O(1)
+func (m *TreeMultiset[T]) Distinct() int {
+	return m.tree.Len()
+}
+
+// TotalLen 返回所有元素出现次数之和
+// 时间复杂度: O(1)
+func (m *TreeMultiset[T]) TotalLen() int {
+	return m.total
+}
+
+// Size 返回所有元素出现次数之和，与 TotalLen 相同
+// 时间复杂度: O(1)
+func (m *TreeMultiset[T]) Size() int {
+	return m.total
+}
+
+// IsEmpty 检查多重集合是否为空
+// 时间复杂度: O(1)
+func (m *TreeMultiset[T]) IsEmpty() bool {
+	return m.total == 0
+}
+
+// Clear 清空多重集合
+// 时间复杂度: O(1)
+func (m *TreeMultiset[T]) Clear() {
+	m.tree.Clear()
+	m.total = 0
+}
+
+// Compare 按元素的自然顺序比较两个元素
+func (m *TreeMultiset[T]) Compare(a, b T) int {
+	return m.tree.Compare(a, b)
+}
+
+// First 返回最小的元素及其次数
+// 时间复杂度: O(log n)
+func (m *TreeMultiset[T]) First() (T, int, bool) {
+	return m.tree.Min()
+}
+
+// Last 返回最大的元素及其次数
+// 时间复杂度: O(log n)
+func (m *TreeMultiset[T]) Last() (T, int, bool) {
+	return m.tree.Max()
+}
+
+// Clone 返回有序多重集合的拷贝
+// copier 用于复制每个元素，为 nil 时直接赋值；复制后相等的元素次数会合并
+// 时间复杂度: O(n log n)
+func (m *TreeMultiset[T]) Clone(copier func(T) T) *TreeMultiset[T] {
+	clone := NewTreeMultiset[T]()
+	for item, count := range m.tree.All() {
+		if copier != nil {
+			item = copier(item)
+		}
+		clone.AddN(item, count)
+	}
+	return clone
+}
+
+// All 返回按升序遍历所有元素的迭代器，每个元素按其次数重复产出
+// 遍历过程中不应修改多重集合
+func (m *TreeMultiset[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for item, count := range m.tree.All() {
+			for range count {
+				if !yield(item) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Counts 返回按升序遍历每个不同元素及其次数的迭代器
+// 遍历过程中不应修改多重集合
+func (m *TreeMultiset[T]) Counts() iter.Seq2[T, int] {
+	return m.tree.All()
+}
+
+// ToSlice 按升序返回所有元素，每个元素按其次数重复
+// 时间复杂度: O(n)
+func (m *TreeMultiset[T]) ToSlice() []T {
+	result := make([]T, 0, m.total)
+	for item := range m.All() {
+		result = append(result, item)
+	}
+	return result
+}
+
+// Sum 返回次数逐元素相加的有序多重集合
+// 时间复杂度: O((n + m) log(n + m))
+func (m *TreeMultiset[T]) Sum(other *TreeMultiset[T]) *TreeMultiset[T] {
+	return m.combine(other, func(a, b int) int { return a + b })
+}
+
+// Union 返回次数逐元素取较大值的有序多重集合
+// 时间复杂度: O((n + m) log(n + m))
+func (m *TreeMultiset[T]) Union(other *TreeMultiset[T]) *TreeMultiset[T] {
+	return m.combine(other, func(a, b int) int { return max(a, b) })
+}
+
+// Intersection 返回次数逐元素取较小值的有序多重集合
+// 时间复杂度: O((n + m) log(n + m))
+func (m *TreeMultiset[T]) Intersection(other *TreeMultiset[T]) *TreeMultiset[T] {
+	return m.combine(other, func(a, b int) int { return min(a, b) })
+}
+
+// Difference 返回次数逐元素相减的有序多重集合，次数不足时视为0
+// 时间复杂度: O((n + m) log(n + m))
+func (m *TreeMultiset[T]) Difference(other *TreeMultiset[T]) *TreeMultiset[T] {
+	return m.combine(other, func(a, b int) int { return max(a-b, 0) })
+}
+
+// IsSubset 检查 m 中每个元素的次数是否都不超过其在 other 中的次数
+// 时间复杂度: O(n log m)
+func (m *TreeMultiset[T]) IsSubset(other *TreeMultiset[T]) bool {
+	if m.total > other.total {
+		return false
+	}
+	for item, count := range m.tree.All() {
+		if count > other.Count(item) {
+			return false
+		}
+	}
+	return true
+}
+
+// Equal 检查两个有序多重集合中每个元素的次数是否都相同
+// 时间复杂度: O(n log m)
+func (m *TreeMultiset[T]) Equal(other *TreeMultiset[T]) bool {
+	return m.total == other.total && m.Distinct() == other.Distinct() && m.IsSubset(other)
+}
+
+// String 按升序返回有序多重集合的字符串表示，格式为 {元素:次数 ...}
+// 实现 fmt.Stringer 接口
+func (m *TreeMultiset[T]) String() string {
+	parts := make([]string, 0, m.Distinct())
+	for item, count := range m.tree.All() {
+		parts = append(parts, fmt.Sprintf("%v:%d", item, count))
+	}
+	return "{" + strings.Join(parts, " ") + "}"
+}
+
+// combine 按升序归并两个多重集合的元素，使用 f 计算每个元素在结果中的次数
+// 只在一侧出现的元素在另一侧的次数视为0，结果次数为0的元素不加入结果
+func (m *TreeMultiset[T]) combine(other *TreeMultiset[T], f func(a, b int) int) *TreeMultiset[T] {
+	result := NewTreeMultiset[T]()
+	nextA, stopA := iter.Pull2(m.tree.All())
+	defer stopA()
+	nextB, stopB := iter.Pull2(other.tree.All())
+	defer stopB()
+	a, ca, okA := nextA()
+	b, cb, okB := nextB()
+	for okA || okB {
+		switch {
+		case !okB || (okA && a < b):
+			result.AddN(a, f(ca, 0))
+			a, ca, okA = nextA()
+		case !okA || b < a:
+			result.AddN(b, f(0, cb))
+			b, cb, okB = nextB()
+		default:
+			result.AddN(a, f(ca, cb))
+			a, ca, okA = nextA()
+			b, cb, okB = nextB()
+		}
+	}
+	return result
+}
@@ -0,0 +1,99 @@
+package set
+
+import (
+	"slices"
+	"testing"
+)
+
+// TestTreeMultisetBasicOperations 测试有序多重集合的基本操作
+func TestTreeMultisetBasicOperations(t *testing.T) {
+	m := NewTreeMultiset(3, 1, 2, 3, 2, 3)
+	if m.Distinct() != 3 || m.TotalLen() != 6 {
+		t.Errorf("Distinct=%d TotalLen=%d", m.Distinct(), m.TotalLen())
+	}
+	if got := m.ToSlice(); !sliceEqual(got, []int{1, 2, 2, 3, 3, 3}) {
+		t.Errorf("有序遍历结果错误: %v", got)
+	}
+	if m.String() != "{1:1 2:2 3:3}" {
+		t.Errorf("String结果错误: %s", m)
+	}
+	if item, count, ok := m.First(); !ok || item != 1 || count != 1 {
+		t.Errorf("First() = %d, %d, %v", item, count, ok)
+	}
+	if item, count, ok := m.Last(); !ok || item != 3 || count != 3 {
+		t.Errorf("Last() = %d, %d, %v", item, count, ok)
+	}
+
+	if !m.Remove(1) || m.Contains(1) || m.Remove(1) {
+		t.Error("次数减为0后元素应被删除")
+	}
+	if got := m.RemoveN(3, 2); got != 2 || m.Count(3) != 1 {
+		t.Errorf("RemoveN期望删除2次，实际为%d", got)
+	}
+	if got := m.RemoveAll(2); got != 2 || m.TotalLen() != 1 {
+		t.Errorf("RemoveAll期望删除2次，实际为%d", got)
+	}
+	if old := m.SetCount(3, 5); old != 1 || m.TotalLen() != 5 {
+		t.Error("SetCount设置次数失败")
+	}
+
+	m.Clear()
+	if !m.IsEmpty() || m.Distinct() != 0 {
+		t.Error("清空后多重集合应为空")
+	}
+	if _, _, ok := m.First(); ok {
+		t.Error("空多重集合First应返回false")
+	}
+}
+
+// TestTreeMultisetAlgebra 测试有序多重集合的代数运算
+func TestTreeMultisetAlgebra(t *testing.T) {
+	a := NewTreeMultiset(1, 1, 1, 2, 3)
+	b := NewTreeMultiset(0, 1, 2, 2, 4)
+
+	tests := []struct {
+		name string
+		got  *TreeMultiset[int]
+		want []int
+	}{
+		{"Sum", a.Sum(b), []int{0, 1, 1, 1, 1, 2, 2, 2, 3, 4}},
+		{"Union", a.Union(b), []int{0, 1, 1, 1, 2, 2, 3, 4}},
+		{"Intersection", a.Intersection(b), []int{1, 2}},
+		{"Difference", a.Difference(b), []int{1, 1, 3}},
+		{"与空集合求并集", a.Union(NewTreeMultiset[int]()), []int{1, 1, 1, 2, 3}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.got.ToSlice(); !sliceEqual(got, tt.want) {
+				t.Errorf("期望 %v，实际为 %v", tt.want, got)
+			}
+			if tt.got.TotalLen() != len(tt.want) {
+				t.Errorf("TotalLen期望%d，实际为%d", len(tt.want), tt.got.TotalLen())
+			}
+		})
+	}
+
+	if !a.Intersection(b).IsSubset(a) || a.IsSubset(b) {
+		t.Error("IsSubset结果错误")
+	}
+	if !a.Equal(NewTreeMultiset(3, 2, 1, 1, 1)) || a.Equal(b) {
+		t.Error("Equal结果错误")
+	}
+}
+
+// TestTreeMultisetClone 测试有序多重集合的拷贝
+func TestTreeMultisetClone(t *testing.T) {
+	m := NewTreeMultiset(1, 2, 2)
+	clone := m.Clone(nil)
+	clone.Add(3)
+	if m.Contains(3) || clone.TotalLen() != 4 {
+		t.Error("修改拷贝不应影响原多重集合")
+	}
+	var counts []int
+	for _, count := range m.Counts() {
+		counts = append(counts, count)
+	}
+	if !slices.Equal(counts, []int{1, 2}) {
+		t.Errorf("Counts结果错误: %v", counts)
+	}
+}
@@ -0,0 +1,181 @@
+package set
+
+import (
+	"fmt"
+	"iter"
+	"strings"
+
+	"godatastructure/rbtree"
+	"golang.org/x/exp/constraints"
+)
+
+// TreeSet 基于红黑树的有序集合
+// 元素按升序存储，支持有序遍历、Floor/Ceiling 查询和范围子集
+type TreeSet[T constraints.Ordered] struct {
+	tree *rbtree.Tree[T] // 底层红黑树
+}
+
+// NewTreeSet 创建包含给定元素的有序集合
+// 时间复杂度: O(n log n)
+func NewTreeSet[T constraints.Ordered](items ...T) *TreeSet[T] {
+	s := &TreeSet[T]{tree: rbtree.NewTree[T](rbtree.WithDuplicates(rbtree.RejectDuplicates))}
+	for _, item := range items {
+		s.Add(item)
+	}
+	return s
+}
+
+// Add 添加元素，返回是否添加了新元素
+// 时间复杂度: O(log n)
+func (s *TreeSet[T]) Add(item T) bool {
+	return s.tree.Insert(item)
+}
+
+// Remove 删除元素，返回元素是否存在
+// 时间复杂度: O(log n)
+func (s *TreeSet[T]) Remove(item T) bool {
+	return s.tree.Delete(item)
+}
+
+// Contains 检查元素是否存在
+// 时间复杂度: O(log n)
+func (s *TreeSet[T]) Contains(item T) bool {
+	return s.tree.Search(item)
+}
+
+// Size 返回元素数量
+// 时间复杂度: O(1)
+func (s *TreeSet[T]) Size() int {
+	return s.tree.Size()
+}
+
+// IsEmpty 检查集合是否为空
+// 时间复杂度: O(1)
+func (s *TreeSet[T]) IsEmpty() bool {
+	return s.tree.Size() == 0
+}
+
+// Clear 清空集合
+// 时间复杂度: O(1)
+func (s *TreeSet[T]) Clear() {
+	s.tree.Clear()
+}
+
+// Compare 按元素的自然顺序比较两个元素
+func (s *TreeSet[T]) Compare(a, b T) int {
+	return s.tree.Compare(a, b)
+}
+
+// First 返回最小的元素
+// 时间复杂度: O(log n)
+func (s *TreeSet[T]) First() (T, bool) {
+	return s.tree.Min()
+}
+
+// Last 返回最大的元素
+// 时间复杂度: O(log n)
+func (s *TreeSet[T]) Last() (T, bool) {
+	return s.tree.Max()
+}
+
+// Floor 返回小于等于 item 的最大元素
+// 时间复杂度: O(log n)
+func (s *TreeSet[T]) Floor(item T) (T, bool) {
+	return s.tree.Floor(item)
+}
+
+// Ceiling 返回大于等于 item 的最小元素
+// 时间复杂度: O(log n)
+func (s *TreeSet[T]) Ceiling(item T) (T, bool) {
+	return s.tree.Ceiling(item)
+}
+
+// Clone 返回有序集合的拷贝
+// copier 用于复制每个元素，为 nil 时直接赋值；复制后的元素应与原元素保持相同的顺序
+// 时间复杂度: O(n)
+func (s *TreeSet[T]) Clone(copier func(T) T) *TreeSet[T] {
+	return &TreeSet[T]{tree: s.tree.Clone(copier)}
+}
+
+// All 返回按升序遍历所有元素的迭代器
+func (s *TreeSet[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		s.ascend(s.tree.Root, func(T) bool { return true }, func(T) bool { return true }, yield)
+	}
+}
+
+// ToSlice 按升序返回所有元素
+// 时间复杂度: O(n)
+func (s *TreeSet[T]) ToSlice() []T {
+	result := make([]T, 0, s.Size())
+	for item := range s.All() {
+		result = append(result, item)
+	}
+	return result
+}
+
+// HeadSet 返回所有小于 to 的元素组成的新集合
+// 时间复杂度: O(log n + k)，k 为结果中的元素数量
+func (s *TreeSet[T]) HeadSet(to T) *TreeSet[T] {
+	return s.subSet(
+		func(T) bool { return true },
+		func(v T) bool { return v < to },
+	)
+}
+
+// TailSet 返回所有大于等于 from 的元素组成的新集合
+// 时间复杂度: O(log n + k)
+func (s *TreeSet[T]) TailSet(from T) *TreeSet[T] {
+	return s.subSet(
+		func(v T) bool { return v >= from },
+		func(T) bool { return true },
+	)
+}
+
+// SubSet 返回所有位于 [from, to) 区间内的元素组成的新集合
+// 时间复杂度: O(log n + k)
+func (s *TreeSet[T]) SubSet(from, to T) *TreeSet[T] {
+	return s.subSet(
+		func(v T) bool { return v >= from },
+		func(v T) bool { return v < to },
+	)
+}
+
+// String 按升序返回集合的字符串表示
+// 实现 fmt.Stringer 接口
+func (s *TreeSet[T]) String() string {
+	parts := make([]string, 0, s.Size())
+	for item := range s.All() {
+		parts = append(parts, fmt.Sprintf("%v", item))
+	}
+	return "{" + strings.Join(parts, " ") + "}"
+}
+
+// subSet 按升序收集满足上下界条件的元素，再由有序的元素直接构建新集合
+func (s *TreeSet[T]) subSet(aboveLow, belowHigh func(T) bool) *TreeSet[T] {
+	var items []T
+	s.ascend(s.tree.Root, aboveLow, belowHigh, func(v T) bool {
+		items = append(items, v)
+		return true
+	})
+	return &TreeSet[T]{tree: rbtree.NewTreeFromSorted(items, rbtree.WithDuplicates(rbtree.RejectDuplicates))}
+}
+
+// ascend 按升序遍历满足上下界条件的元素，剪去不可能满足条件的子树
+// 返回 false 表示遍历被提前终止
+func (s *TreeSet[T]) ascend(node *rbtree.Node[T], aboveLow, belowHigh func(T) bool, yield func(T) bool) bool {
+	if node == nil {
+		return true
+	}
+	inLow, inHigh := aboveLow(node.Value), belowHigh(node.Value)
+	if inLow && !s.ascend(node.Left, aboveLow, belowHigh, yield) {
+		return false
+	}
+	if inLow && inHigh && !yield(node.Value) {
+		return false
+	}
+	if inHigh {
+		return s.ascend(node.Right, aboveLow, belowHigh, yield)
+	}
+	return true
+}
@@ -0,0 +1,128 @@
+package set
+
+import (
+	"slices"
+	"testing"
+)
+
+// TestTreeSetBasicOperations 测试有序集合的基本操作
+func TestTreeSetBasicOperations(t *testing.T) {
+	s := NewTreeSet(5, 1, 3, 3, 9)
+	if s.Size() != 4 {
+		t.Errorf("期望大小为4，实际为%d", s.Size())
+	}
+	if s.Add(5) {
+		t.Error("添加已存在的元素应返回false")
+	}
+	if !s.Add(7) || !s.Contains(7) {
+		t.Error("添加新元素失败")
+	}
+	if got := s.ToSlice(); !sliceEqual(got, []int{1, 3, 5, 7, 9}) {
+		t.Errorf("有序遍历结果错误: %v", got)
+	}
+
+	if !s.Remove(3) || s.Contains(3) || s.Remove(3) {
+		t.Error("删除元素失败")
+	}
+	if first, _ := s.First(); first != 1 {
+		t.Errorf("First期望1，实际为%d", first)
+	}
+	if last, _ := s.Last(); last != 9 {
+		t.Errorf("Last期望9，实际为%d", last)
+	}
+	if s.String() != "{1 5 7 9}" {
+		t.Errorf("String结果错误: %s", s)
+	}
+
+	s.Clear()
+	if !s.IsEmpty() {
+		t.Error("清空后集合应为空")
+	}
+	if _, ok := s.First(); ok {
+		t.Error("空集合First应返回false")
+	}
+}
+
+// TestTreeSetFloorCeiling 测试 Floor 和 Ceiling 查询
+func TestTreeSetFloorCeiling(t *testing.T) {
+	s := NewTreeSet(10, 20, 30, 40)
+
+	tests := []struct {
+		item      int
+		floor     int
+		floorOK   bool
+		ceiling   int
+		ceilingOK bool
+	}{
+		{5, 0, false, 10, true},
+		{10, 10, true, 10, true},
+		{25, 20, true, 30, true},
+		{40, 40, true, 40, true},
+		{45, 40, true, 0, false},
+	}
+	for _, tt := range tests {
+		f, ok := s.Floor(tt.item)
+		if f != tt.floor || ok != tt.floorOK {
+			t.Errorf("Floor(%d) = (%d, %v)，期望 (%d, %v)", tt.item, f, ok, tt.floor, tt.floorOK)
+		}
+		c, ok := s.Ceiling(tt.item)
+		if c != tt.ceiling || ok != tt.ceilingOK {
+			t.Errorf("Ceiling(%d) = (%d, %v)，期望 (%d, %v)", tt.item, c, ok, tt.ceiling, tt.ceilingOK)
+		}
+	}
+}
+
+// TestTreeSetRangeViews 测试范围子集
+func TestTreeSetRangeViews(t *testing.T) {
+	s := NewTreeSet[int]()
+	for i := 0; i < 100; i++ {
+		s.Add(i)
+	}
+
+	if got := s.HeadSet(3).ToSlice(); !sliceEqual(got, []int{0, 1, 2}) {
+		t.Errorf("HeadSet(3) = %v", got)
+	}
+	if got := s.TailSet(97).ToSlice(); !sliceEqual(got, []int{97, 98, 99}) {
+		t.Errorf("TailSet(97) = %v", got)
+	}
+	if got := s.SubSet(40, 44).ToSlice(); !sliceEqual(got, []int{40, 41, 42, 43}) {
+		t.Errorf("SubSet(40, 44) = %v", got)
+	}
+	if got := s.SubSet(50, 50); !got.IsEmpty() {
+		t.Errorf("空区间应返回空集合，实际为 %v", got)
+	}
+
+	// 子集由有序元素直接构建，应是合法的红黑树并且仍然拒绝重复元素
+	sub := s.SubSet(10, 90)
+	if err := sub.tree.Validate(); err != nil {
+		t.Fatalf("子集的红黑树不合法: %v", err)
+	}
+	if sub.Add(10) || !sub.Add(90) || sub.Size() != 81 {
+		t.Errorf("子集添加元素结果错误，大小为%d", sub.Size())
+	}
+	if err := sub.tree.Validate(); err != nil {
+		t.Errorf("添加元素后子集的红黑树不合法: %v", err)
+	}
+
+	// 提前终止遍历
+	count := 0
+	for v := range s.All() {
+		if v >= 9 {
+			break
+		}
+		count++
+	}
+	if count != 9 {
+		t.Errorf("提前终止后期望遍历9个元素，实际为%d", count)
+	}
+}
+
+// TestTreeSetClone 测试有序集合的拷贝
+func TestTreeSetClone(t *testing.T) {
+	s := NewTreeSet(3, 1, 2)
+	clone := s.Clone(func(v int) int { return v * 10 })
+	s.Add(4)
+	if got := slices.Collect(clone.All()); !slices.Equal(got, []int{10, 20, 30}) {
+		t.Errorf("期望[10 20 30]，实际为%v", got)
+	}
+}
@@ -1,15 +1,24 @@
 package stack
 
-import "errors"
+import (
+	"errors"
+	"iter"
+
+	"godatastructure/codec"
+)
 
 // Stack 栈接口
 // 支持泛型类型T
 type Stack[T any] interface {
-	Push(value T)     // 将元素压入栈顶
-	Pop() (T, error)  // 弹出栈顶元素
-	Peek() (T, error) // 查看栈顶元素但不移除
-	IsEmpty() bool    // 检查栈是否为空
-	Size() int        // 获取栈中元素个数
+	Push(value T)                    // 将元素压入栈顶
+	Pop() (T, error)                 // 弹出栈顶元素
+	Peek() (T, error)                // 查看栈顶元素但不移除
+	IsEmpty() bool                   // 检查栈是否为空
+	Size() int                       // 获取栈中元素个数
+	Clear()                          // 清空栈
+	All() iter.Seq[T]                // 返回从栈顶到栈底遍历的迭代器
+	codec.Marshaler                  // 二进制与 JSON 序列化，按从栈顶到栈底的顺序编码
+	Clone(copier func(T) T) Stack[T] // 返回深拷贝，copier 为 nil 时直接复制元素
 }
 
 // stack 栈的结构体
@@ -66,3 +75,76 @@ func (s *stack[T]) IsEmpty() bool {
 func (s *stack[T]) Size() int {
 	return len(s.elements)
 }
+
+// Clear 清空栈
+// 时间复杂度: O(1)
+func (s *stack[T]) Clear() {
+	s.elements = []T{}
+}
+
+// All 返回从栈顶到栈底遍历的迭代器，顺序与依次出栈的顺序相同
+// 时间复杂度: O(n)
+func (s *stack[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := len(s.elements) - 1; i >= 0; i-- {
+			if !yield(s.elements[i]) {
+				return
+			}
+		}
+	}
+}
+
+// MarshalBinary 使用 codec.For[T] 返回的元素编解码器把栈编码为二进制，按从栈顶到栈底的顺序编码
+// 实现 encoding.BinaryMarshaler 接口
+func (s *stack[T]) MarshalBinary() ([]byte, error) {
+	return codec.EncodeSeq(s.All(), codec.For[T]())
+}
+
+// UnmarshalBinary 从 MarshalBinary 生成的数据中解码，替换栈中的所有元素
+// 解码失败时栈保持不变，实现 encoding.BinaryUnmarshaler 接口
+func (s *stack[T]) UnmarshalBinary(data []byte) error {
+	values, err := codec.DecodeSeq(data, codec.For[T]())
+	if err != nil {
+		return err
+	}
+	s.load(values)
+	return nil
+}
+
+// MarshalJSON 把栈编码为 JSON 数组，实现 json.Marshaler 接口
+func (s *stack[T]) MarshalJSON() ([]byte, error) {
+	return codec.MarshalJSONSeq(s.All())
+}
+
+// UnmarshalJSON 从 JSON 数组中解码，替换栈中的所有元素
+// 解码失败时栈保持不变，实现 json.Unmarshaler 接口
+func (s *stack[T]) UnmarshalJSON(data []byte) error {
+	values, err := codec.UnmarshalJSONSeq[T](data)
+	if err != nil {
+		return err
+	}
+	s.load(values)
+	return nil
+}
+
+// load 用 values 替换栈中的元素，values 的第一个元素为栈顶
+func (s *stack[T]) load(values []T) {
+	s.elements = make([]T, len(values))
+	for i, v := range values {
+		s.elements[len(values)-1-i] = v
+	}
+}
+
+// Clone 返回栈的深拷贝
+// copier 用于复制每个元素，为 nil 时直接赋值
+// 时间复杂度: O(n)
+func (s *stack[T]) Clone(copier func(T) T) Stack[T] {
+	elements := make([]T, len(s.elements))
+	for i, v := range s.elements {
+		if copier != nil {
+			v = copier(v)
+		}
+		elements[i] = v
+	}
+	return &stack[T]{elements: elements}
+}
@@ -1,6 +1,8 @@
 package stack
 
 import (
+	"encoding/json"
+	"slices"
 	"testing"
 )
 
@@ -190,3 +192,92 @@ func TestStackWithCustomTypes(t *testing.T) {
 		t.Errorf("期望出栈的人员信息为 %v, 实际为 %v", p1, top)
 	}
 }
+
+// TestAll 测试从栈顶到栈底遍历
+func TestAll(t *testing.T) {
+	s := New[int]()
+	for i := 1; i <= 3; i++ {
+		s.Push(i)
+	}
+	var got []int
+	for v := range s.All() {
+		got = append(got, v)
+	}
+	if !slices.Equal(got, []int{3, 2, 1}) {
+		t.Errorf("迭代结果错误: %v", got)
+	}
+	if s.Size() != 3 {
+		t.Error("遍历不应修改栈")
+	}
+}
+
+// TestMarshal 测试栈的二进制与 JSON 序列化
+func TestMarshal(t *testing.T) {
+	src := New[int]()
+	for _, v := range []int{1, 2, 3} {
+		src.Push(v)
+	}
+	want := slices.Collect(src.All())
+
+	t.Run("二进制", func(t *testing.T) {
+		data, err := src.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		dst := New[int]()
+		if err := dst.UnmarshalBinary(data); err != nil {
+			t.Fatal(err)
+		}
+		if got := slices.Collect(dst.All()); !slices.Equal(got, want) {
+			t.Errorf("期望%v，实际为%v", want, got)
+		}
+		if err := dst.UnmarshalBinary(data[:len(data)-1]); err == nil {
+			t.Error("截断的数据应返回错误")
+		}
+		if dst.Size() != len(want) {
+			t.Error("解码失败时应保持不变")
+		}
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		data, err := json.Marshal(src)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(data) != `[3,2,1]` {
+			t.Errorf("JSON 为%s", data)
+		}
+		dst := New[int]()
+		if err := json.Unmarshal(data, dst); err != nil {
+			t.Fatal(err)
+		}
+		if got := slices.Collect(dst.All()); !slices.Equal(got, want) {
+			t.Errorf("期望%v，实际为%v", want, got)
+		}
+	})
+}
+
+// TestClone 测试栈的深拷贝
+func TestClone(t *testing.T) {
+	s := New[[]int]()
+	s.Push([]int{1})
+	s.Push([]int{2})
+
+	clone := s.Clone(slices.Clone)
+	top, _ := s.Peek()
+	top[0] = 100
+	s.Push([]int{3})
+
+	if clone.Size() != 2 {
+		t.Fatalf("期望大小为2，实际为%d", clone.Size())
+	}
+	if v, _ := clone.Pop(); v[0] != 2 {
+		t.Errorf("深拷贝不应受原元素修改的影响，实际为%v", v)
+	}
+	if v, _ := clone.Pop(); v[0] != 1 {
+		t.Errorf("出栈顺序应与原栈相同，实际为%v", v)
+	}
+	if s.Size() != 3 {
+		t.Error("修改拷贝不应影响原栈")
+	}
+}
@@ -0,0 +1,274 @@
+// Package stream 提供基于 iter.Seq 的惰性流水线
+// Filter、Map、Take 等中间操作只组合迭代器而不复制元素，直到 ToSlice、Reduce 等终止操作才真正遍历数据源；
+// 除 Sorted 外，各阶段逐个传递元素，不会产生中间切片
+package stream
+
+import (
+	"iter"
+	"slices"
+
+	"godatastructure/iterator"
+)
+
+// Stream 元素类型为 T 的惰性流
+// Stream 是不可变的值，每个中间操作都返回新的流；
+// 每次执行终止操作都会重新遍历数据源，因此对同一个流多次执行终止操作会观察到数据源的最新状态
+type Stream[T any] struct {
+	seq iter.Seq[T]
+}
+
+// From 从任意可遍历的容器创建流，例如链表、有序树或另一个流
+// 时间复杂度: O(1)
+func From[T any](c iterator.Iterable[T]) Stream[T] {
+	return Stream[T]{seq: c.All()}
+}
+
+// FromSeq 从 iter.Seq 创建流
+// 时间复杂度: O(1)
+func FromSeq[T any](seq iter.Seq[T]) Stream[T] {
+	return Stream[T]{seq: seq}
+}
+
+// Of 从给定的元素创建流
+// 时间复杂度: O(1)
+func Of[T any](values ...T) Stream[T] {
+	return Stream[T]{seq: slices.Values(values)}
+}
+
+// All 返回按顺序产出流中元素的迭代器，使 Stream 满足 iterator.Iterable 接口
+func (s Stream[T]) All() iter.Seq[T] {
+	return s.seq
+}
+
+// Filter 只保留满足 pred 的元素
+func (s Stream[T]) Filter(pred func(T) bool) Stream[T] {
+	return Stream[T]{seq: func(yield func(T) bool) {
+		for v := range s.seq {
+			if pred(v) && !yield(v) {
+				return
+			}
+		}
+	}}
+}
+
+// Map 使用 f 转换每个元素
+// Go 的方法不能声明类型参数，因此 Map 是函数而不是方法
+func Map[T, U any](s Stream[T], f func(T) U) Stream[U] {
+	return Stream[U]{seq: func(yield func(U) bool) {
+		for v := range s.seq {
+			if !yield(f(v)) {
+				return
+			}
+		}
+	}}
+}
+
+// FlatMap 把每个元素转换为一个流并依次连接
+func FlatMap[T, U any](s Stream[T], f func(T) Stream[U]) Stream[U] {
+	return Stream[U]{seq: func(yield func(U) bool) {
+		for v := range s.seq {
+			for u := range f(v).seq {
+				if !yield(u) {
+					return
+				}
+			}
+		}
+	}}
+}
+
+// Take 只保留前 n 个元素，取够后立即停止遍历数据源
+func (s Stream[T]) Take(n int) Stream[T] {
+	return Stream[T]{seq: func(yield func(T) bool) {
+		if n <= 0 {
+			return
+		}
+		i := 0
+		for v := range s.seq {
+			if !yield(v) {
+				return
+			}
+			i++
+			if i == n {
+				return
+			}
+		}
+	}}
+}
+
+// Skip 跳过前 n 个元素
+func (s Stream[T]) Skip(n int) Stream[T] {
+	return Stream[T]{seq: func(yield func(T) bool) {
+		i := 0
+		for v := range s.seq {
+			if i < n {
+				i++
+				continue
+			}
+			if !yield(v) {
+				return
+			}
+		}
+	}}
+}
+
+// TakeWhile 保留开头连续满足 pred 的元素，遇到第一个不满足的元素时停止
+func (s Stream[T]) TakeWhile(pred func(T) bool) Stream[T] {
+	return Stream[T]{seq: func(yield func(T) bool) {
+		for v := range s.seq {
+			if !pred(v) || !yield(v) {
+				return
+			}
+		}
+	}}
+}
+
+// Peek 在元素经过时调用 f，不改变流中的元素，常用于调试流水线
+func (s Stream[T]) Peek(f func(T)) Stream[T] {
+	return Stream[T]{seq: func(yield func(T) bool) {
+		for v := range s.seq {
+			f(v)
+			if !yield(v) {
+				return
+			}
+		}
+	}}
+}
+
+// Distinct 去除重复元素，保留每个元素第一次出现的位置
+// 需要记录已经出现过的元素，空间复杂度为 O(不同元素的数量)
+func Distinct[T comparable](s Stream[T]) Stream[T] {
+	return DistinctBy(s, func(v T) T { return v })
+}
+
+// DistinctBy 按 key 返回的键去除重复元素，保留每个键第一次出现的元素
+func DistinctBy[T any, K comparable](s Stream[T], key func(T) K) Stream[T] {
+	return Stream[T]{seq: func(yield func(T) bool) {
+		seen := make(map[K]struct{})
+		for v := range s.seq {
+			k := key(v)
+			if _, ok := seen[k]; ok {
+				continue
+			}
+			seen[k] = struct{}{}
+			if !yield(v) {
+				return
+			}
+		}
+	}}
+}
+
+// Sorted 按 cmp 稳定排序
+// 排序需要看到所有元素，因此遍历时会先把上游元素收集到切片中，这是唯一会物化元素的中间操作
+func (s Stream[T]) Sorted(cmp func(a, b T) int) Stream[T] {
+	return Stream[T]{seq: func(yield func(T) bool) {
+		values := slices.Collect(s.seq)
+		slices.SortStableFunc(values, cmp)
+		for _, v := range values {
+			if !yield(v) {
+				return
+			}
+		}
+	}}
+}
+
+// Concat 依次连接多个流
+func Concat[T any](streams ...Stream[T]) Stream[T] {
+	return Stream[T]{seq: func(yield func(T) bool) {
+		for _, s := range streams {
+			for v := range s.seq {
+				if !yield(v) {
+					return
+				}
+			}
+		}
+	}}
+}
+
+// ToSlice 按顺序把流中的元素收集到切片中
+// 时间复杂度: O(n)
+func (s Stream[T]) ToSlice() []T {
+	result := make([]T, 0)
+	for v := range s.seq {
+		result = append(result, v)
+	}
+	return result
+}
+
+// Collect 按顺序把流中的元素逐个交给 add，用于收集到任意容器中，
+// 例如 s.Collect(l.Append) 追加到链表，s.Collect(st.Push) 压入栈
+// 时间复杂度: O(n)
+func (s Stream[T]) Collect(add func(T)) {
+	for v := range s.seq {
+		add(v)
+	}
+}
+
+// ForEach 按顺序对每个元素调用 f
+// 时间复杂度: O(n)
+func (s Stream[T]) ForEach(f func(T)) {
+	for v := range s.seq {
+		f(v)
+	}
+}
+
+// Reduce 使用 f 从左到右归约所有元素，流为空时返回零值和 false
+// 时间复杂度: O(n)
+func (s Stream[T]) Reduce(f func(acc, v T) T) (T, bool) {
+	var acc T
+	first := true
+	for v := range s.seq {
+		if first {
+			acc, first = v, false
+			continue
+		}
+		acc = f(acc, v)
+	}
+	return acc, !first
+}
+
+// Fold 以 init 为初始值，使用 f 从左到右把所有元素累积为 A 类型的结果
+// 时间复杂度: O(n)
+func Fold[T, A any](s Stream[T], init A, f func(acc A, v T) A) A {
+	acc := init
+	for v := range s.seq {
+		acc = f(acc, v)
+	}
+	return acc
+}
+
+// Count 返回流中元素的数量
+// 时间复杂度: O(n)
+func (s Stream[T]) Count() int {
+	n := 0
+	for range s.seq {
+		n++
+	}
+	return n
+}
+
+// First 返回第一个元素，流为空时返回零值和 false；找到后立即停止遍历
+// 时间复杂度: O(1)
+func (s Stream[T]) First() (T, bool) {
+	for v := range s.seq {
+		return v, true
+	}
+	var zero T
+	return zero, false
+}
+
+// AnyMatch 判断是否存在满足 pred 的元素，找到后立即停止遍历
+// 时间复杂度: O(n)
+func (s Stream[T]) AnyMatch(pred func(T) bool) bool {
+	for v := range s.seq {
+		if pred(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// AllMatch 判断是否所有元素都满足 pred，空流返回 true
+// 时间复杂度: O(n)
+func (s Stream[T]) AllMatch(pred func(T) bool) bool {
+	return !s.AnyMatch(func(v T) bool { return !pred(v) })
+}
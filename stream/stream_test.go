@@ -0,0 +1,251 @@
+package stream
+
+import (
+	"iter"
+	"slices"
+	"testing"
+
+	"godatastructure/list"
+	"godatastructure/stack"
+)
+
+func intCmp(a, b int) int {
+	return a - b
+}
+
+// counting 返回产出 0..n-1 的流以及记录已产出元素数量的计数器
+func counting(n int) (Stream[int], *int) {
+	pulled := 0
+	return FromSeq(func(yield func(int) bool) {
+		for i := range n {
+			pulled++
+			if !yield(i) {
+				return
+			}
+		}
+	}), &pulled
+}
+
+// TestFrom 测试从容器和切片创建流
+func TestFrom(t *testing.T) {
+	t.Run("从链表创建", func(t *testing.T) {
+		l := list.New[int]()
+		for i := range 5 {
+			l.Append(i)
+		}
+		got := From[int](l).ToSlice()
+		if !slices.Equal(got, []int{0, 1, 2, 3, 4}) {
+			t.Errorf("From(l) = %v", got)
+		}
+	})
+
+	t.Run("反映数据源的最新状态", func(t *testing.T) {
+		l := list.New[int]()
+		s := From[int](l)
+		if n := s.Count(); n != 0 {
+			t.Errorf("Count() = %d, want 0", n)
+		}
+		l.Append(1)
+		if n := s.Count(); n != 1 {
+			t.Errorf("Count() = %d, want 1", n)
+		}
+	})
+
+	t.Run("空流", func(t *testing.T) {
+		got := Of[int]().ToSlice()
+		if got == nil || len(got) != 0 {
+			t.Errorf("ToSlice() = %#v, want empty slice", got)
+		}
+	})
+
+	t.Run("流满足Iterable接口", func(t *testing.T) {
+		got := From[int](Of(1, 2, 3)).ToSlice()
+		if !slices.Equal(got, []int{1, 2, 3}) {
+			t.Errorf("From(stream) = %v", got)
+		}
+	})
+}
+
+// TestIntermediate 测试中间操作
+func TestIntermediate(t *testing.T) {
+	tests := []struct {
+		name string
+		s    Stream[int]
+		want []int
+	}{
+		{"Filter", Of(1, 2, 3, 4, 5, 6).Filter(func(v int) bool { return v%2 == 0 }), []int{2, 4, 6}},
+		{"Map", Map(Of(1, 2, 3), func(v int) int { return v * v }), []int{1, 4, 9}},
+		{"FlatMap", FlatMap(Of(1, 2, 3), func(v int) Stream[int] { return Of(v, -v) }), []int{1, -1, 2, -2, 3, -3}},
+		{"Take", Of(1, 2, 3, 4).Take(2), []int{1, 2}},
+		{"Take超过长度", Of(1, 2).Take(5), []int{1, 2}},
+		{"Take零个", Of(1, 2).Take(0), []int{}},
+		{"Skip", Of(1, 2, 3, 4).Skip(3), []int{4}},
+		{"Skip超过长度", Of(1, 2).Skip(5), []int{}},
+		{"TakeWhile", Of(1, 2, 5, 1).TakeWhile(func(v int) bool { return v < 3 }), []int{1, 2}},
+		{"Distinct", Distinct(Of(3, 1, 3, 2, 1)), []int{3, 1, 2}},
+		{"DistinctBy", DistinctBy(Of(1, 2, 3, 4, 5), func(v int) int { return v % 2 }), []int{1, 2}},
+		{"Sorted", Of(3, 1, 2).Sorted(intCmp), []int{1, 2, 3}},
+		{"Concat", Concat(Of(1), Of[int](), Of(2, 3)), []int{1, 2, 3}},
+		{"组合", Map(Distinct(Of(5, 4, 5, 3, 4, 1)).Sorted(intCmp).Skip(1).Take(2), func(v int) int { return v * 10 }), []int{30, 40}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.s.ToSlice(); !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("Sorted稳定", func(t *testing.T) {
+		type pair struct{ k, v int }
+		got := Of(pair{2, 0}, pair{1, 1}, pair{2, 2}, pair{1, 3}).
+			Sorted(func(a, b pair) int { return a.k - b.k }).ToSlice()
+		want := []pair{{1, 1}, {1, 3}, {2, 0}, {2, 2}}
+		if !slices.Equal(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("Map改变类型", func(t *testing.T) {
+		got := Map(Of(1, 22, 333), func(v int) string { return string(rune('a' + v%26)) }).ToSlice()
+		if !slices.Equal(got, []string{"b", "w", "v"}) {
+			t.Errorf("got %v", got)
+		}
+	})
+}
+
+// TestLazy 测试中间操作按需从数据源取元素
+func TestLazy(t *testing.T) {
+	t.Run("构建流水线不遍历数据源", func(t *testing.T) {
+		s, pulled := counting(100)
+		mapped := 0
+		_ = Map(s.Filter(func(v int) bool { return v%2 == 0 }), func(v int) int {
+			mapped++
+			return v
+		}).Sorted(intCmp).Take(3)
+		if *pulled != 0 || mapped != 0 {
+			t.Errorf("pulled = %d, mapped = %d, want 0", *pulled, mapped)
+		}
+	})
+
+	t.Run("Take提前停止", func(t *testing.T) {
+		s, pulled := counting(1000)
+		got := s.Filter(func(v int) bool { return v%3 == 0 }).Take(3).ToSlice()
+		if !slices.Equal(got, []int{0, 3, 6}) {
+			t.Errorf("got %v", got)
+		}
+		if *pulled != 7 {
+			t.Errorf("pulled = %d, want 7", *pulled)
+		}
+	})
+
+	t.Run("First和AnyMatch提前停止", func(t *testing.T) {
+		s, pulled := counting(1000)
+		if v, ok := s.Skip(4).First(); !ok || v != 4 {
+			t.Errorf("First() = %d, %v", v, ok)
+		}
+		if *pulled != 5 {
+			t.Errorf("pulled = %d, want 5", *pulled)
+		}
+		*pulled = 0
+		if !s.AnyMatch(func(v int) bool { return v == 9 }) {
+			t.Error("AnyMatch() = false")
+		}
+		if *pulled != 10 {
+			t.Errorf("pulled = %d, want 10", *pulled)
+		}
+	})
+
+	t.Run("元素逐个经过各阶段", func(t *testing.T) {
+		var trace []string
+		Map(Of(1, 2).Peek(func(v int) { trace = append(trace, "peek") }), func(v int) int {
+			trace = append(trace, "map")
+			return v
+		}).ForEach(func(int) { trace = append(trace, "each") })
+		want := []string{"peek", "map", "each", "peek", "map", "each"}
+		if !slices.Equal(trace, want) {
+			t.Errorf("trace = %v, want %v", trace, want)
+		}
+	})
+
+	t.Run("提前退出遍历", func(t *testing.T) {
+		s, pulled := counting(100)
+		next, stop := iter.Pull(Concat(s.Skip(1), s).All())
+		defer stop()
+		if v, ok := next(); !ok || v != 1 {
+			t.Errorf("next() = %d, %v", v, ok)
+		}
+		stop()
+		if *pulled != 2 {
+			t.Errorf("pulled = %d, want 2", *pulled)
+		}
+	})
+}
+
+// TestTerminal 测试终止操作
+func TestTerminal(t *testing.T) {
+	t.Run("Reduce", func(t *testing.T) {
+		sum, ok := Of(1, 2, 3, 4).Reduce(func(acc, v int) int { return acc + v })
+		if !ok || sum != 10 {
+			t.Errorf("Reduce() = %d, %v, want 10, true", sum, ok)
+		}
+		if _, ok := Of[int]().Reduce(func(acc, v int) int { return acc + v }); ok {
+			t.Error("空流 Reduce() ok = true")
+		}
+	})
+
+	t.Run("Fold", func(t *testing.T) {
+		got := Fold(Of(1, 2, 3), "", func(acc string, v int) string { return acc + string(rune('0'+v)) })
+		if got != "123" {
+			t.Errorf("Fold() = %q", got)
+		}
+	})
+
+	t.Run("Collect到栈", func(t *testing.T) {
+		st := stack.New[int]()
+		Of(1, 2, 3).Collect(st.Push)
+		if v, err := st.Peek(); err != nil || v != 3 || st.Size() != 3 {
+			t.Errorf("Peek() = %d, %v, Size() = %d", v, err, st.Size())
+		}
+	})
+
+	t.Run("Count和First", func(t *testing.T) {
+		if n := Of(1, 2, 3).Filter(func(v int) bool { return v > 1 }).Count(); n != 2 {
+			t.Errorf("Count() = %d, want 2", n)
+		}
+		if _, ok := Of[int]().First(); ok {
+			t.Error("空流 First() ok = true")
+		}
+	})
+
+	t.Run("AllMatch", func(t *testing.T) {
+		positive := func(v int) bool { return v > 0 }
+		if !Of(1, 2).AllMatch(positive) || Of(1, -1).AllMatch(positive) || !Of[int]().AllMatch(positive) {
+			t.Error("AllMatch 结果错误")
+		}
+	})
+}
+
+// BenchmarkPipeline 测试流水线与手写循环的开销
+func BenchmarkPipeline(b *testing.B) {
+	values := make([]int, 1024)
+	for i := range values {
+		values[i] = i
+	}
+	b.Run("Stream", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			Map(Of(values...).Filter(func(v int) bool { return v%2 == 0 }), func(v int) int { return v * 3 }).Count()
+		}
+	})
+	b.Run("Loop", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			n := 0
+			for _, v := range values {
+				if v%2 == 0 {
+					_ = v * 3
+					n++
+				}
+			}
+		}
+	})
+}
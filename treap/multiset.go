@@ -0,0 +1,149 @@
+package treap
+
+import (
+	"iter"
+
+	"godatastructure/binarytree"
+)
+
+// Multiset 基于树堆的有序多重集合，支持顺序统计
+// 底层使用允许重复元素的 binarytree.Treap，每次出现占用一个节点，
+// 因此 Kth、CountLess 等查询都按出现次数计算，
+// 用法与 GNU pb_ds 的 tree_order_statistics 相同
+type Multiset[T any] struct {
+	t *binarytree.Treap[T] // 底层树堆
+}
+
+// NewMultiset 创建空的有序多重集合
+// 时间复杂度: O(1)
+func NewMultiset[T any](cmp func(a, b T) int) *Multiset[T] {
+	return &Multiset[T]{t: binarytree.NewTreap(cmp)}
+}
+
+// Size 返回元素数量，重复元素按出现次数计算
+// 时间复杂度: O(1)
+func (m *Multiset[T]) Size() int {
+	return m.t.Size()
+}
+
+// IsEmpty 判断多重集合是否为空
+// 时间复杂度: O(1)
+func (m *Multiset[T]) IsEmpty() bool {
+	return m.t.IsEmpty()
+}
+
+// Clear 清空多重集合
+// 时间复杂度: O(1)
+func (m *Multiset[T]) Clear() {
+	m.t.Clear()
+}
+
+// Compare 使用多重集合的比较函数比较两个元素
+func (m *Multiset[T]) Compare(a, b T) int {
+	return m.t.Compare(a, b)
+}
+
+// Insert 插入一次元素，元素已存在时增加一次出现
+// 时间复杂度: 期望 O(log n)
+func (m *Multiset[T]) Insert(value T) {
+	m.t.Insert(value)
+}
+
+// Erase 删除一次元素，元素不存在时返回 false
+// 时间复杂度: 期望 O(log n)
+func (m *Multiset[T]) Erase(value T) bool {
+	return m.t.Remove(value)
+}
+
+// EraseAll 删除元素的所有出现，返回删除的次数
+// 时间复杂度: 期望 O(k log n)，k 为删除的次数
+func (m *Multiset[T]) EraseAll(value T) int {
+	count := 0
+	for m.t.Remove(value) {
+		count++
+	}
+	return count
+}
+
+// Contains 判断元素是否至少出现一次
+// 时间复杂度: 期望 O(log n)
+func (m *Multiset[T]) Contains(value T) bool {
+	return m.t.Search(value) != nil
+}
+
+// Count 返回元素出现的次数
+// 时间复杂度: 期望 O(log n)
+func (m *Multiset[T]) Count(value T) int {
+	return m.t.Count(value)
+}
+
+// CountLess 返回严格小于 value 的元素数量，即 value 第一次出现时的名次
+// 时间复杂度: 期望 O(log n)
+func (m *Multiset[T]) CountLess(value T) int {
+	return m.t.Rank(value)
+}
+
+// CountLessOrEqual 返回小于等于 value 的元素数量
+// 时间复杂度: 期望 O(log n)
+func (m *Multiset[T]) CountLessOrEqual(value T) int {
+	return m.t.Rank(value) + m.t.Count(value)
+}
+
+// Kth 返回第 k 小的元素（k 从0开始），重复元素按出现次数占用多个名次
+// 时间复杂度: 期望 O(log n)
+func (m *Multiset[T]) Kth(k int) (T, bool) {
+	return m.t.Kth(k)
+}
+
+// Min 返回最小元素
+// 时间复杂度: 期望 O(log n)
+func (m *Multiset[T]) Min() (T, bool) {
+	return m.t.Kth(0)
+}
+
+// Max 返回最大元素
+// 时间复杂度: 期望 O(log n)
+func (m *Multiset[T]) Max() (T, bool) {
+	return m.t.Kth(m.Size() - 1)
+}
+
+// NextGreater 返回严格大于 value 的最小元素
+// 时间复杂度: 期望 O(log n)
+func (m *Multiset[T]) NextGreater(value T) (T, bool) {
+	return m.t.Kth(m.CountLessOrEqual(value))
+}
+
+// NextSmaller 返回严格小于 value 的最大元素
+// 时间复杂度: 期望 O(log n)
+func (m *Multiset[T]) NextSmaller(value T) (T, bool) {
+	return m.t.Kth(m.t.Rank(value) - 1)
+}
+
+// All 返回按升序遍历所有元素的迭代器，重复元素按出现次数依次产出
+func (m *Multiset[T]) All() iter.Seq[T] {
+	return m.t.All()
+}
+
+// ToSlice 返回按升序排列的所有元素
+// 时间复杂度: O(n)
+func (m *Multiset[T]) ToSlice() []T {
+	result := make([]T, 0, m.Size())
+	for v := range m.All() {
+		result = append(result, v)
+	}
+	return result
+}
+
+// Clone 返回结构和优先级都相同的深拷贝
+// copier 用于复制每个元素，为 nil 时直接赋值；复制后的元素应与原元素保持相同的顺序
+// 时间复杂度: O(n)
+func (m *Multiset[T]) Clone(copier func(T) T) *Multiset[T] {
+	return &Multiset[T]{t: m.t.Clone(copier).(*binarytree.Treap[T])}
+}
+
+// Validate 检查多重集合的结构不变量，发现损坏时返回描述问题的错误
+// 检查内容与 Treap.Validate 相同，但允许重复元素
+// 时间复杂度: O(n)
+func (m *Multiset[T]) Validate() error {
+	return m.t.Validate()
+}
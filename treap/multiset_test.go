@@ -0,0 +1,157 @@
+package treap
+
+import (
+	"math/rand"
+	"slices"
+	"sort"
+	"testing"
+)
+
+// TestMultisetBasic 测试多重集合的插入与删除
+func TestMultisetBasic(t *testing.T) {
+	m := NewMultiset(intCmp)
+	for _, v := range []int{5, 1, 5, 3, 5, 1} {
+		m.Insert(v)
+	}
+	if m.Size() != 6 {
+		t.Errorf("Size() = %d，期望 6", m.Size())
+	}
+	if got := m.ToSlice(); !slices.Equal(got, []int{1, 1, 3, 5, 5, 5}) {
+		t.Errorf("ToSlice() = %v", got)
+	}
+	if m.Count(5) != 3 || m.Count(1) != 2 || m.Count(4) != 0 {
+		t.Errorf("Count 结果错误: %d %d %d", m.Count(5), m.Count(1), m.Count(4))
+	}
+
+	if !m.Erase(5) || m.Count(5) != 2 {
+		t.Error("Erase 应只删除一次出现")
+	}
+	if m.Erase(4) {
+		t.Error("删除不存在的元素应返回false")
+	}
+	if n := m.EraseAll(1); n != 2 || m.Contains(1) {
+		t.Errorf("EraseAll(1) = %d，期望 2", n)
+	}
+	if n := m.EraseAll(1); n != 0 {
+		t.Errorf("再次 EraseAll(1) = %d，期望 0", n)
+	}
+	if err := m.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	m.Clear()
+	if !m.IsEmpty() {
+		t.Error("清空后应为空")
+	}
+	if _, ok := m.Min(); ok {
+		t.Error("空多重集合 Min 应返回false")
+	}
+}
+
+// TestMultisetOrderStatistics 测试多重集合的顺序统计查询
+func TestMultisetOrderStatistics(t *testing.T) {
+	m := NewMultiset(intCmp)
+	for _, v := range []int{10, 20, 20, 20, 30, 40, 40} {
+		m.Insert(v)
+	}
+
+	t.Run("Kth", func(t *testing.T) {
+		want := []int{10, 20, 20, 20, 30, 40, 40}
+		for k, w := range want {
+			if v, ok := m.Kth(k); !ok || v != w {
+				t.Errorf("Kth(%d) = (%d, %v)，期望 %d", k, v, ok, w)
+			}
+		}
+		if _, ok := m.Kth(len(want)); ok {
+			t.Error("越界的k应返回false")
+		}
+	})
+
+	t.Run("CountLess", func(t *testing.T) {
+		cases := map[int][2]int{5: {0, 0}, 10: {0, 1}, 20: {1, 4}, 25: {4, 4}, 40: {5, 7}, 50: {7, 7}}
+		for v, want := range cases {
+			if got := m.CountLess(v); got != want[0] {
+				t.Errorf("CountLess(%d) = %d，期望 %d", v, got, want[0])
+			}
+			if got := m.CountLessOrEqual(v); got != want[1] {
+				t.Errorf("CountLessOrEqual(%d) = %d，期望 %d", v, got, want[1])
+			}
+		}
+	})
+
+	t.Run("NextGreater和NextSmaller", func(t *testing.T) {
+		tests := []struct {
+			value     int
+			greater   int
+			greaterOK bool
+			smaller   int
+			smallerOK bool
+		}{
+			{5, 10, true, 0, false},
+			{10, 20, true, 0, false},
+			{20, 30, true, 10, true},
+			{35, 40, true, 30, true},
+			{40, 0, false, 30, true},
+		}
+		for _, tt := range tests {
+			if v, ok := m.NextGreater(tt.value); v != tt.greater || ok != tt.greaterOK {
+				t.Errorf("NextGreater(%d) = (%d, %v)", tt.value, v, ok)
+			}
+			if v, ok := m.NextSmaller(tt.value); v != tt.smaller || ok != tt.smallerOK {
+				t.Errorf("NextSmaller(%d) = (%d, %v)", tt.value, v, ok)
+			}
+		}
+	})
+}
+
+// TestMultisetRandom 与排序切片对比随机操作的结果
+func TestMultisetRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(7))
+	m := NewMultiset(intCmp)
+	var ref []int
+	for i := 0; i < 3000; i++ {
+		v := r.Intn(50)
+		if r.Intn(3) == 0 {
+			idx := sort.SearchInts(ref, v)
+			found := idx < len(ref) && ref[idx] == v
+			if found {
+				ref = slices.Delete(ref, idx, idx+1)
+			}
+			if m.Erase(v) != found {
+				t.Fatalf("Erase(%d) 结果错误", v)
+			}
+		} else {
+			ref = slices.Insert(ref, sort.SearchInts(ref, v), v)
+			m.Insert(v)
+		}
+		if k := r.Intn(len(ref) + 1); k < len(ref) {
+			if got, _ := m.Kth(k); got != ref[k] {
+				t.Fatalf("Kth(%d) = %d，期望 %d", k, got, ref[k])
+			}
+		}
+		if got, want := m.CountLess(v), sort.SearchInts(ref, v); got != want {
+			t.Fatalf("CountLess(%d) = %d，期望 %d", v, got, want)
+		}
+	}
+	if err := m.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(m.ToSlice(), ref) {
+		t.Error("最终元素不一致")
+	}
+}
+
+// TestMultisetClone 测试多重集合的深拷贝
+func TestMultisetClone(t *testing.T) {
+	m := NewMultiset(intCmp)
+	m.Insert(1)
+	m.Insert(1)
+	clone := m.Clone(nil)
+	clone.Insert(1)
+	if m.Count(1) != 2 || clone.Count(1) != 3 {
+		t.Error("修改拷贝不应影响原多重集合")
+	}
+	if err := clone.Validate(); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,166 @@
+package treap
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+
+	"godatastructure/binarytree"
+)
+
+var (
+	ErrMergeOrder = errors.New("合并的树中存在不大于当前树最大值的元素")
+)
+
+// Treap 树堆（随机化二叉搜索树）
+// 按值满足二叉搜索树性质，按随机优先级满足大根堆性质，期望高度为 O(log n)
+// 以按值分裂（Split）与合并（Merge）作为基本操作，并维护子树大小以支持顺序统计
+// 不存储重复元素；底层使用 binarytree.Treap，插入前先检查元素是否已存在
+type Treap[T any] struct {
+	t *binarytree.Treap[T] // 底层树堆
+}
+
+// New 创建空的树堆
+// 时间复杂度: O(1)
+func New[T any](cmp func(a, b T) int) *Treap[T] {
+	return &Treap[T]{t: binarytree.NewTreap(cmp)}
+}
+
+// Size 返回元素数量
+// 时间复杂度: O(1)
+func (t *Treap[T]) Size() int {
+	return t.t.Size()
+}
+
+// IsEmpty 判断树堆是否为空
+// 时间复杂度: O(1)
+func (t *Treap[T]) IsEmpty() bool {
+	return t.t.IsEmpty()
+}
+
+// Clear 清空树堆
+// 时间复杂度: O(1)
+func (t *Treap[T]) Clear() {
+	t.t.Clear()
+}
+
+// Compare 使用树堆的比较函数比较两个元素
+func (t *Treap[T]) Compare(a, b T) int {
+	return t.t.Compare(a, b)
+}
+
+// Insert 插入元素，元素已存在时返回 false
+// 时间复杂度: 期望 O(log n)
+func (t *Treap[T]) Insert(value T) bool {
+	if t.Contains(value) {
+		return false
+	}
+	t.t.Insert(value)
+	return true
+}
+
+// Delete 删除元素，元素不存在时返回 false
+// 时间复杂度: 期望 O(log n)
+func (t *Treap[T]) Delete(value T) bool {
+	return t.t.Remove(value)
+}
+
+// Contains 判断元素是否存在
+// 时间复杂度: 期望 O(log n)
+func (t *Treap[T]) Contains(value T) bool {
+	return t.t.Search(value) != nil
+}
+
+// Min 返回最小元素
+// 时间复杂度: 期望 O(log n)
+func (t *Treap[T]) Min() (T, bool) {
+	return t.t.Kth(0)
+}
+
+// Max 返回最大元素
+// 时间复杂度: 期望 O(log n)
+func (t *Treap[T]) Max() (T, bool) {
+	return t.t.Kth(t.Size() - 1)
+}
+
+// Kth 返回第 k 小的元素（k 从0开始）
+// 时间复杂度: 期望 O(log n)
+func (t *Treap[T]) Kth(k int) (T, bool) {
+	return t.t.Kth(k)
+}
+
+// Rank 返回严格小于 value 的元素数量
+// 时间复杂度: 期望 O(log n)
+func (t *Treap[T]) Rank(value T) int {
+	return t.t.Rank(value)
+}
+
+// Split 按 key 将树堆分裂为两棵：左树包含所有小于 key 的元素，右树包含其余元素
+// 分裂后原树堆变为空树
+// 时间复杂度: 期望 O(log n)
+func (t *Treap[T]) Split(key T) (*Treap[T], *Treap[T]) {
+	left, right := t.t.Split(key)
+	return &Treap[T]{t: left}, &Treap[T]{t: right}
+}
+
+// Merge 将 other 中的所有元素并入当前树堆，合并后 other 变为空树
+// 要求 other 中的所有元素都大于当前树堆的最大元素
+// 参数：
+//   - other: 待合并的树堆
+//
+// 返回值：
+//   - error: 不满足顺序要求时返回 ErrMergeOrder，两棵树均保持不变
+//
+// 时间复杂度: 期望 O(log n)
+func (t *Treap[T]) Merge(other *Treap[T]) error {
+	if t == other || other.IsEmpty() {
+		return nil
+	}
+	// 底层树堆允许相等的元素，严格大于的要求在这里检查
+	if maxValue, ok := t.Max(); ok {
+		if minValue, _ := other.Min(); t.Compare(maxValue, minValue) >= 0 {
+			return ErrMergeOrder
+		}
+	}
+	return t.t.Merge(other.t)
+}
+
+// All 返回按升序遍历所有元素的迭代器
+func (t *Treap[T]) All() iter.Seq[T] {
+	return t.t.All()
+}
+
+// ToSlice 返回按升序排列的所有元素
+// 时间复杂度: O(n)
+func (t *Treap[T]) ToSlice() []T {
+	result := make([]T, 0, t.Size())
+	for v := range t.All() {
+		result = append(result, v)
+	}
+	return result
+}
+
+// Clone 返回结构和优先级都相同的深拷贝
+// copier 用于复制每个元素，为 nil 时直接赋值；复制后的元素应与原元素保持相同的顺序
+// 时间复杂度: O(n)
+func (t *Treap[T]) Clone(copier func(T) T) *Treap[T] {
+	return &Treap[T]{t: t.t.Clone(copier).(*binarytree.Treap[T])}
+}
+
+// Validate 检查树堆的结构不变量，发现损坏时返回描述问题的错误
+// 检查内容：按比较函数满足二叉搜索树性质且没有重复元素，
+// 父节点的优先级不小于子节点，且每个节点记录的子树大小正确
+// 时间复杂度: O(n)
+func (t *Treap[T]) Validate() error {
+	if err := t.t.Validate(); err != nil {
+		return err
+	}
+	var prev T
+	for i, v := range t.ToSlice() {
+		if i > 0 && t.Compare(prev, v) == 0 {
+			return fmt.Errorf("元素 %v 重复", v)
+		}
+		prev = v
+	}
+	return nil
+}
@@ -0,0 +1,223 @@
+package treap
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"godatastructure/binarytree"
+)
+
+func intCmp(a, b int) int {
+	return a - b
+}
+
+// checkTreap 检查二叉搜索树性质、堆性质和子树大小
+func checkTreap[T any](t *testing.T, tr *Treap[T]) {
+	t.Helper()
+	if err := tr.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	values := tr.ToSlice()
+	for i := 1; i < len(values); i++ {
+		if tr.Compare(values[i-1], values[i]) >= 0 {
+			t.Fatal("中序遍历结果不是严格升序")
+		}
+	}
+}
+
+// TestTreapBasic 测试插入、删除和查询
+func TestTreapBasic(t *testing.T) {
+	tr := New(intCmp)
+	if _, ok := tr.Min(); ok || !tr.IsEmpty() {
+		t.Error("空树堆不应有最小值")
+	}
+
+	for _, v := range []int{5, 3, 8, 1, 4, 7, 9} {
+		if !tr.Insert(v) {
+			t.Errorf("插入%d应返回true", v)
+		}
+	}
+	if tr.Insert(5) {
+		t.Error("重复插入应返回false")
+	}
+	if tr.Size() != 7 {
+		t.Errorf("期望大小为7，实际为%d", tr.Size())
+	}
+	if !tr.Contains(4) || tr.Contains(6) {
+		t.Error("Contains结果错误")
+	}
+	if v, _ := tr.Min(); v != 1 {
+		t.Errorf("期望最小值为1，实际为%d", v)
+	}
+	if v, _ := tr.Max(); v != 9 {
+		t.Errorf("期望最大值为9，实际为%d", v)
+	}
+
+	if !tr.Delete(5) || tr.Delete(5) {
+		t.Error("Delete返回值错误")
+	}
+	if got := tr.ToSlice(); !slices.Equal(got, []int{1, 3, 4, 7, 8, 9}) {
+		t.Errorf("删除后元素错误: %v", got)
+	}
+	checkTreap(t, tr)
+
+	tr.Clear()
+	if tr.Size() != 0 {
+		t.Error("清空后树堆应为空")
+	}
+}
+
+// TestTreapOrderStatistics 测试 Kth 与 Rank
+func TestTreapOrderStatistics(t *testing.T) {
+	tr := New(intCmp)
+	for i := 0; i < 100; i++ {
+		tr.Insert(i * 2)
+	}
+	for k := 0; k < 100; k++ {
+		if v, ok := tr.Kth(k); !ok || v != k*2 {
+			t.Errorf("Kth(%d) = (%d, %v)，期望 (%d, true)", k, v, ok, k*2)
+		}
+	}
+	if _, ok := tr.Kth(100); ok {
+		t.Error("越界的k应返回false")
+	}
+	if _, ok := tr.Kth(-1); ok {
+		t.Error("负数k应返回false")
+	}
+
+	cases := map[int]int{-5: 0, 0: 0, 1: 1, 2: 1, 51: 26, 198: 99, 500: 100}
+	for v, want := range cases {
+		if got := tr.Rank(v); got != want {
+			t.Errorf("Rank(%d) = %d，期望 %d", v, got, want)
+		}
+	}
+}
+
+// TestTreapSplitMerge 测试按键分裂与合并
+func TestTreapSplitMerge(t *testing.T) {
+	tr := New(intCmp)
+	for i := 1; i <= 10; i++ {
+		tr.Insert(i)
+	}
+
+	left, right := tr.Split(6)
+	if !tr.IsEmpty() {
+		t.Error("分裂后原树堆应为空")
+	}
+	if got := left.ToSlice(); !slices.Equal(got, []int{1, 2, 3, 4, 5}) {
+		t.Errorf("左树元素错误: %v", got)
+	}
+	if got := right.ToSlice(); !slices.Equal(got, []int{6, 7, 8, 9, 10}) {
+		t.Errorf("右树元素错误: %v", got)
+	}
+	checkTreap(t, left)
+	checkTreap(t, right)
+
+	if err := right.Merge(left); err != ErrMergeOrder {
+		t.Errorf("顺序错误的合并应返回ErrMergeOrder，实际为%v", err)
+	}
+	if left.Size() != 5 || right.Size() != 5 {
+		t.Error("合并失败时两棵树应保持不变")
+	}
+
+	if err := left.Merge(right); err != nil {
+		t.Fatalf("合并失败: %v", err)
+	}
+	if left.Size() != 10 || !right.IsEmpty() {
+		t.Error("合并后元素数量错误")
+	}
+	checkTreap(t, left)
+
+	// 与空树合并
+	empty := New(intCmp)
+	if err := empty.Merge(left); err != nil || empty.Size() != 10 {
+		t.Error("空树合并非空树失败")
+	}
+}
+
+// TestTreapRandom 与有序切片对比随机操作
+func TestTreapRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	tr := New(intCmp)
+	var want []int
+	for i := 0; i < 3000; i++ {
+		v := r.Intn(500)
+		idx, found := slices.BinarySearch(want, v)
+		if r.Intn(3) == 0 {
+			if tr.Delete(v) != found {
+				t.Fatalf("Delete(%d)返回值错误", v)
+			}
+			if found {
+				want = slices.Delete(want, idx, idx+1)
+			}
+		} else {
+			if tr.Insert(v) == found {
+				t.Fatalf("Insert(%d)返回值错误", v)
+			}
+			if !found {
+				want = slices.Insert(want, idx, v)
+			}
+		}
+	}
+	if got := tr.ToSlice(); !slices.Equal(got, want) {
+		t.Fatal("随机操作后元素不一致")
+	}
+	checkTreap(t, tr)
+}
+
+// 性能测试
+func BenchmarkTreap(b *testing.B) {
+	b.Run("插入", func(b *testing.B) {
+		tr := New(intCmp)
+		for i := 0; i < b.N; i++ {
+			tr.Insert(i)
+		}
+	})
+	b.Run("Kth", func(b *testing.B) {
+		tr := New(intCmp)
+		for i := 0; i < 10000; i++ {
+			tr.Insert(i)
+		}
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			tr.Kth(i % 10000)
+		}
+	})
+}
+
+// TestClone 测试树堆的深拷贝
+func TestClone(t *testing.T) {
+	tr := New(intCmp)
+	for i := 0; i < 100; i++ {
+		tr.Insert(i)
+	}
+	clone := tr.Clone(nil)
+	checkTreap(t, clone)
+	if !slices.Equal(clone.ToSlice(), tr.ToSlice()) {
+		t.Fatal("拷贝的元素应与原树堆相同")
+	}
+	left, _ := tr.Split(50)
+	if left.Size() != 50 || clone.Size() != 100 {
+		t.Error("分裂原树堆不应影响拷贝")
+	}
+	checkTreap(t, clone)
+}
+
+// TestValidate 测试树堆结构检查，发现结构损坏的情形由 binarytree 的测试覆盖
+func TestValidate(t *testing.T) {
+	tree := New(intCmp)
+	for i := 0; i < 200; i++ {
+		tree.Insert((i * 37) % 101)
+	}
+	if err := tree.Validate(); err != nil {
+		t.Fatalf("正常使用后应通过检查: %v", err)
+	}
+
+	dup := &Treap[int]{t: binarytree.NewTreap(intCmp)}
+	dup.t.Insert(1)
+	dup.t.Insert(1)
+	if dup.Validate() == nil {
+		t.Error("应发现重复元素")
+	}
+}
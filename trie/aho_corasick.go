@@ -0,0 +1,140 @@
+package trie
+
+import (
+	"errors"
+	"io"
+	"strings"
+)
+
+// Occurrence 模式串在文本中的一次出现
+type Occurrence struct {
+	Pattern string // 匹配到的模式串
+	Start   int    // 起始位置（字节偏移，包含）
+	End     int    // 结束位置（字节偏移，不包含）
+}
+
+// AhoCorasick AC 自动机
+// 在前缀树的基础上为每个节点增加失配指针，
+// 一次扫描文本即可找出所有模式串的全部出现位置
+type AhoCorasick struct {
+	trie     *Trie[int]                // 模式串前缀树，值为模式串下标
+	patterns []string                  // 模式串列表
+	fail     map[*node[int]]*node[int] // 失配指针
+	output   map[*node[int]][]int      // 到达节点时匹配的模式串下标（含沿失配指针可达的）
+}
+
+// NewAhoCorasick 根据模式串集合构建 AC 自动机
+// 空模式串和重复的模式串会被忽略
+// 时间复杂度: O(L)，L 为所有模式串的总长度
+func NewAhoCorasick(patterns []string) *AhoCorasick {
+	ac := &AhoCorasick{
+		trie:   New[int](),
+		fail:   make(map[*node[int]]*node[int]),
+		output: make(map[*node[int]][]int),
+	}
+	for _, p := range patterns {
+		if p == "" || ac.trie.Contains(p) {
+			continue
+		}
+		ac.trie.Insert(p, len(ac.patterns))
+		ac.patterns = append(ac.patterns, p)
+	}
+	ac.build()
+	return ac
+}
+
+// build 按层次遍历计算失配指针和输出集合
+func (ac *AhoCorasick) build() {
+	root := ac.trie.root
+	ac.fail[root] = root
+
+	queue := make([]*node[int], 0)
+	for _, ch := range sortedKeys(root.children) {
+		child := root.children[ch]
+		ac.fail[child] = root
+		ac.output[child] = ac.ownOutput(child)
+		queue = append(queue, child)
+	}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, ch := range sortedKeys(current.children) {
+			child := current.children[ch]
+			ac.fail[child] = ac.next(ac.fail[current], ch)
+
+			// 失配指针指向更浅的节点，其输出集合已经计算完毕
+			out := ac.ownOutput(child)
+			out = append(out, ac.output[ac.fail[child]]...)
+			ac.output[child] = out
+			queue = append(queue, child)
+		}
+	}
+}
+
+// ownOutput 返回节点自身对应的模式串
+func (ac *AhoCorasick) ownOutput(n *node[int]) []int {
+	if n.isEnd {
+		return []int{n.value}
+	}
+	return nil
+}
+
+// next 计算状态 state 读入字符 ch 后的下一个状态
+func (ac *AhoCorasick) next(state *node[int], ch rune) *node[int] {
+	root := ac.trie.root
+	for {
+		if child, ok := state.children[ch]; ok {
+			return child
+		}
+		if state == root {
+			return root
+		}
+		state = ac.fail[state]
+	}
+}
+
+// Match 从 r 中流式读取文本，每找到一次模式串的出现就调用 fn
+// fn 返回 false 时停止匹配
+// 同一结束位置的多个匹配按模式串长度从长到短报告
+// 时间复杂度: O(n + z)，n 为文本长度，z 为匹配次数
+func (ac *AhoCorasick) Match(r io.RuneReader, fn func(Occurrence) bool) error {
+	state := ac.trie.root
+	offset := 0
+	for {
+		ch, size, err := r.ReadRune()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		offset += size
+		state = ac.next(state, ch)
+		for _, idx := range ac.output[state] {
+			p := ac.patterns[idx]
+			if !fn(Occurrence{Pattern: p, Start: offset - len(p), End: offset}) {
+				return nil
+			}
+		}
+	}
+}
+
+// FindAll 返回所有模式串在 text 中的全部出现位置，按结束位置排序
+// 时间复杂度: O(n + z)
+func (ac *AhoCorasick) FindAll(text string) []Occurrence {
+	result := make([]Occurrence, 0)
+	// strings.Reader 读取不会出错
+	_ = ac.Match(strings.NewReader(text), func(o Occurrence) bool {
+		result = append(result, o)
+		return true
+	})
+	return result
+}
+
+// Patterns 返回自动机中的模式串
+func (ac *AhoCorasick) Patterns() []string {
+	result := make([]string, len(ac.patterns))
+	copy(result, ac.patterns)
+	return result
+}
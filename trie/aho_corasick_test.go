@@ -0,0 +1,74 @@
+package trie
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestAhoCorasickFindAll 测试查找全部匹配
+func TestAhoCorasickFindAll(t *testing.T) {
+	ac := NewAhoCorasick([]string{"he", "she", "his", "hers", "", "he"})
+	if got := ac.Patterns(); !reflect.DeepEqual(got, []string{"he", "she", "his", "hers"}) {
+		t.Errorf("应忽略空模式串和重复模式串，实际为 %v", got)
+	}
+
+	got := ac.FindAll("ushers")
+	want := []Occurrence{
+		{Pattern: "she", Start: 1, End: 4},
+		{Pattern: "he", Start: 2, End: 4},
+		{Pattern: "hers", Start: 2, End: 6},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("FindAll(ushers) = %v，期望 %v", got, want)
+	}
+}
+
+// TestAhoCorasickOverlapping 测试重叠匹配和多字节字符
+func TestAhoCorasickOverlapping(t *testing.T) {
+	ac := NewAhoCorasick([]string{"aa", "中国", "国人"})
+
+	if got := ac.FindAll("aaaa"); len(got) != 3 {
+		t.Errorf("aaaa中应有3处重叠匹配，实际为 %v", got)
+	}
+
+	got := ac.FindAll("我是中国人")
+	want := []Occurrence{
+		{Pattern: "中国", Start: 6, End: 12},
+		{Pattern: "国人", Start: 9, End: 15},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("FindAll = %v，期望 %v", got, want)
+	}
+
+	if got := ac.FindAll("没有匹配"); len(got) != 0 {
+		t.Errorf("不应有匹配，实际为 %v", got)
+	}
+}
+
+// TestAhoCorasickMatchStream 测试流式匹配和提前终止
+func TestAhoCorasickMatchStream(t *testing.T) {
+	ac := NewAhoCorasick([]string{"abc", "bcd", "c"})
+	text := strings.Repeat("abcd", 100)
+
+	count := 0
+	err := ac.Match(strings.NewReader(text), func(o Occurrence) bool {
+		if text[o.Start:o.End] != o.Pattern {
+			t.Errorf("匹配位置错误: %+v", o)
+		}
+		count++
+		return true
+	})
+	if err != nil || count != 300 {
+		t.Errorf("期望匹配300次，实际为%d, err = %v", count, err)
+	}
+
+	count = 0
+	_ = ac.Match(strings.NewReader(text), func(o Occurrence) bool {
+		count++
+		return count < 5
+	})
+	if count != 5 {
+		t.Errorf("提前终止后期望回调5次，实际为%d", count)
+	}
+}
@@ -0,0 +1,86 @@
+package trie
+
+// Wildcard 通配符，匹配任意单个字符
+const Wildcard = '?'
+
+// Match 按字典序返回与模式匹配的所有单词
+// 模式中的 '?' 匹配任意单个字符，其余字符需精确匹配
+// 时间复杂度: 最坏 O(k)，k 为遍历的节点数；不含通配符时为 O(m)
+func (t *Trie[V]) Match(pattern string) []string {
+	result := make([]string, 0)
+	runes := []rune(pattern)
+	t.match(t.root, runes, make([]rune, 0, len(runes)), &result)
+	return result
+}
+
+// match 递归匹配模式的剩余部分
+func (t *Trie[V]) match(n *node[V], pattern []rune, path []rune, result *[]string) {
+	if len(pattern) == 0 {
+		if n.isEnd {
+			*result = append(*result, string(path))
+		}
+		return
+	}
+
+	ch := pattern[0]
+	if ch != Wildcard {
+		if child, ok := n.children[ch]; ok {
+			t.match(child, pattern[1:], append(path, ch), result)
+		}
+		return
+	}
+	for _, c := range sortedKeys(n.children) {
+		t.match(n.children[c], pattern[1:], append(path, c), result)
+	}
+}
+
+// FuzzySearch 按字典序返回与 word 的编辑距离不超过 maxDistance 的所有单词
+// 编辑距离为 Levenshtein 距离（插入、删除、替换各计1）
+// 沿前缀树逐行计算动态规划表，当某一行的最小值超过 maxDistance 时剪枝，
+// 因此无需扫描全部单词
+// 时间复杂度: O(k * m)，k 为未被剪枝的节点数，m 为 word 的长度
+func (t *Trie[V]) FuzzySearch(word string, maxDistance int) []string {
+	result := make([]string, 0)
+	if maxDistance < 0 {
+		return result
+	}
+	target := []rune(word)
+
+	// 第一行：空前缀到 word 各前缀的距离
+	row := make([]int, len(target)+1)
+	for i := range row {
+		row[i] = i
+	}
+	if t.root.isEnd && row[len(target)] <= maxDistance {
+		result = append(result, "")
+	}
+	for _, ch := range sortedKeys(t.root.children) {
+		t.fuzzy(t.root.children[ch], ch, target, row, maxDistance, []rune{ch}, &result)
+	}
+	return result
+}
+
+// fuzzy 计算当前节点对应的动态规划行并递归处理子节点
+func (t *Trie[V]) fuzzy(n *node[V], ch rune, target []rune, prevRow []int, maxDistance int, path []rune, result *[]string) {
+	row := make([]int, len(prevRow))
+	row[0] = prevRow[0] + 1
+	rowMin := row[0]
+	for i := 1; i < len(row); i++ {
+		cost := 1
+		if target[i-1] == ch {
+			cost = 0
+		}
+		row[i] = min(prevRow[i]+1, row[i-1]+1, prevRow[i-1]+cost)
+		rowMin = min(rowMin, row[i])
+	}
+
+	if n.isEnd && row[len(target)] <= maxDistance {
+		*result = append(*result, string(path))
+	}
+	if rowMin > maxDistance {
+		return
+	}
+	for _, c := range sortedKeys(n.children) {
+		t.fuzzy(n.children[c], c, target, row, maxDistance, append(path, c), result)
+	}
+}
@@ -0,0 +1,59 @@
+package trie
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestTrieMatch 测试通配符匹配
+func TestTrieMatch(t *testing.T) {
+	tr := New[int]()
+	for i, w := range []string{"cat", "cot", "cut", "cart", "bat", "ca", "中国", "中文"} {
+		tr.Insert(w, i)
+	}
+
+	tests := []struct {
+		pattern string
+		want    []string
+	}{
+		{"c?t", []string{"cat", "cot", "cut"}},
+		{"?at", []string{"bat", "cat"}},
+		{"ca??", []string{"cart"}},
+		{"???", []string{"bat", "cat", "cot", "cut"}},
+		{"cat", []string{"cat"}},
+		{"c?", []string{"ca"}},
+		{"中?", []string{"中国", "中文"}},
+		{"x?", []string{}},
+	}
+	for _, tt := range tests {
+		if got := tr.Match(tt.pattern); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Match(%q) = %v，期望 %v", tt.pattern, got, tt.want)
+		}
+	}
+}
+
+// TestTrieFuzzySearch 测试模糊查询
+func TestTrieFuzzySearch(t *testing.T) {
+	tr := New[int]()
+	for i, w := range []string{"hello", "help", "hell", "shell", "world", "word", "yellow"} {
+		tr.Insert(w, i)
+	}
+
+	tests := []struct {
+		word    string
+		maxDist int
+		want    []string
+	}{
+		{"hello", 0, []string{"hello"}},
+		{"helo", 1, []string{"hell", "hello", "help"}},
+		{"wrld", 1, []string{"world"}},
+		{"hello", 2, []string{"hell", "hello", "help", "shell", "yellow"}},
+		{"xyz", 1, []string{}},
+		{"hello", -1, []string{}},
+	}
+	for _, tt := range tests {
+		if got := tr.FuzzySearch(tt.word, tt.maxDist); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("FuzzySearch(%q, %d) = %v，期望 %v", tt.word, tt.maxDist, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,321 @@
+package trie
+
+import (
+	"iter"
+	"sort"
+	"strings"
+)
+
+// radixNode 基数树节点
+type radixNode[V any] struct {
+	prefix   string          // 从父节点到当前节点的边上的字符串
+	children []*radixNode[V] // 子节点，按边的首字节升序排列
+	value    V               // 键对应的值（仅 hasValue 为 true 时有效）
+	hasValue bool            // 当前节点是否对应一个键
+}
+
+// RadixTree 基数树（压缩前缀树 / PATRICIA 树）
+// 只有一个子节点且不对应键的节点会与子节点合并，共享的前缀只存储一次，
+// 相比普通前缀树节省大量内存，适用于 IP 路由、URL 路由等场景
+// 以字节为单位比较键
+type RadixTree[V any] struct {
+	root *radixNode[V] // 根节点，prefix 恒为空
+	size int           // 键的数量
+}
+
+// NewRadixTree 创建新的基数树
+// 时间复杂度: O(1)
+func NewRadixTree[V any]() *RadixTree[V] {
+	return &RadixTree[V]{root: &radixNode[V]{}}
+}
+
+// Insert 插入键值对，键已存在时更新值
+// 时间复杂度: O(m)，m 为键的长度
+func (t *RadixTree[V]) Insert(key string, value V) {
+	n := t.root
+	search := key
+	for {
+		if len(search) == 0 {
+			if !n.hasValue {
+				t.size++
+			}
+			n.value = value
+			n.hasValue = true
+			return
+		}
+
+		idx, child := n.getChild(search[0])
+		if child == nil {
+			n.addChild(&radixNode[V]{prefix: search, value: value, hasValue: true})
+			t.size++
+			return
+		}
+
+		common := commonPrefixLen(search, child.prefix)
+		if common == len(child.prefix) {
+			n = child
+			search = search[common:]
+			continue
+		}
+
+		// 边只匹配了一部分，需要在公共前缀处分裂
+		split := &radixNode[V]{prefix: search[:common]}
+		n.children[idx] = split
+		child.prefix = child.prefix[common:]
+		split.addChild(child)
+
+		search = search[common:]
+		if len(search) == 0 {
+			split.value = value
+			split.hasValue = true
+		} else {
+			split.addChild(&radixNode[V]{prefix: search, value: value, hasValue: true})
+		}
+		t.size++
+		return
+	}
+}
+
+// Get 获取键对应的值
+// 时间复杂度: O(m)
+func (t *RadixTree[V]) Get(key string) (V, bool) {
+	n := t.root
+	search := key
+	for len(search) > 0 {
+		_, child := n.getChild(search[0])
+		if child == nil || !strings.HasPrefix(search, child.prefix) {
+			var zero V
+			return zero, false
+		}
+		n = child
+		search = search[len(child.prefix):]
+	}
+	if !n.hasValue {
+		var zero V
+		return zero, false
+	}
+	return n.value, true
+}
+
+// Delete 删除键，并合并删除后只剩一个子节点的节点
+// 返回是否成功删除
+// 时间复杂度: O(m)
+func (t *RadixTree[V]) Delete(key string) bool {
+	var parent *radixNode[V]
+	n := t.root
+	search := key
+	for len(search) > 0 {
+		_, child := n.getChild(search[0])
+		if child == nil || !strings.HasPrefix(search, child.prefix) {
+			return false
+		}
+		parent = n
+		n = child
+		search = search[len(child.prefix):]
+	}
+	if !n.hasValue {
+		return false
+	}
+
+	var zero V
+	n.value = zero
+	n.hasValue = false
+	t.size--
+
+	if n == t.root {
+		return true
+	}
+	switch len(n.children) {
+	case 0:
+		parent.removeChild(n.prefix[0])
+		// 父节点删除子节点后可能只剩一个子节点，需要与其合并
+		if parent != t.root && !parent.hasValue && len(parent.children) == 1 {
+			parent.mergeChild()
+		}
+	case 1:
+		n.mergeChild()
+	}
+	return true
+}
+
+// LongestPrefixMatch 查找作为 key 前缀的最长键
+// 返回值：
+//   - string: 匹配到的最长键
+//   - V: 该键对应的值
+//   - bool: 是否存在匹配
+//
+// 时间复杂度: O(m)
+func (t *RadixTree[V]) LongestPrefixMatch(key string) (string, V, bool) {
+	var (
+		matched  *radixNode[V]
+		matchLen int
+	)
+	n := t.root
+	consumed := 0
+	for {
+		if n.hasValue {
+			matched = n
+			matchLen = consumed
+		}
+		if consumed == len(key) {
+			break
+		}
+		_, child := n.getChild(key[consumed])
+		if child == nil || !strings.HasPrefix(key[consumed:], child.prefix) {
+			break
+		}
+		n = child
+		consumed += len(child.prefix)
+	}
+
+	if matched == nil {
+		var zero V
+		return "", zero, false
+	}
+	return key[:matchLen], matched.value, true
+}
+
+// KeysWithPrefix 按字典序返回以 prefix 为前缀的所有键
+// 时间复杂度: O(m + k)，k 为遍历的节点数
+func (t *RadixTree[V]) KeysWithPrefix(prefix string) []string {
+	result := make([]string, 0)
+	n := t.root
+	path := ""
+	search := prefix
+	for len(search) > 0 {
+		_, child := n.getChild(search[0])
+		if child == nil {
+			return result
+		}
+		switch {
+		case strings.HasPrefix(search, child.prefix):
+			search = search[len(child.prefix):]
+		case strings.HasPrefix(child.prefix, search):
+			// 前缀终止于边的中间，该子树中的键都满足条件
+			search = ""
+		default:
+			return result
+		}
+		path += child.prefix
+		n = child
+	}
+	n.walk(path, func(key string, _ V) bool {
+		result = append(result, key)
+		return true
+	})
+	return result
+}
+
+// Size 返回键的数量
+// 时间复杂度: O(1)
+func (t *RadixTree[V]) Size() int {
+	return t.size
+}
+
+// IsEmpty 检查基数树是否为空
+// 时间复杂度: O(1)
+func (t *RadixTree[V]) IsEmpty() bool {
+	return t.size == 0
+}
+
+// Clear 清空基数树
+// 时间复杂度: O(1)
+func (t *RadixTree[V]) Clear() {
+	t.root = &radixNode[V]{}
+	t.size = 0
+}
+
+// Clone 返回基数树的深拷贝
+// copier 用于复制每个键对应的值，为 nil 时直接赋值
+// 时间复杂度: O(N)，N 为节点数量
+func (t *RadixTree[V]) Clone(copier func(V) V) *RadixTree[V] {
+	return &RadixTree[V]{root: t.root.clone(copier), size: t.size}
+}
+
+// clone 递归复制以 n 为根的子树
+func (n *radixNode[V]) clone(copier func(V) V) *radixNode[V] {
+	clone := &radixNode[V]{prefix: n.prefix, value: n.value, hasValue: n.hasValue}
+	if n.hasValue && copier != nil {
+		clone.value = copier(n.value)
+	}
+	if len(n.children) > 0 {
+		clone.children = make([]*radixNode[V], len(n.children))
+		for i, child := range n.children {
+			clone.children[i] = child.clone(copier)
+		}
+	}
+	return clone
+}
+
+// All 返回按字典序遍历所有键值对的迭代器
+// 遍历过程中不应修改基数树
+func (t *RadixTree[V]) All() iter.Seq2[string, V] {
+	return func(yield func(string, V) bool) {
+		t.root.walk("", yield)
+	}
+}
+
+// getChild 二分查找首字节为 b 的子节点，返回其下标和节点
+func (n *radixNode[V]) getChild(b byte) (int, *radixNode[V]) {
+	i := sort.Search(len(n.children), func(i int) bool {
+		return n.children[i].prefix[0] >= b
+	})
+	if i < len(n.children) && n.children[i].prefix[0] == b {
+		return i, n.children[i]
+	}
+	return -1, nil
+}
+
+// addChild 按首字节有序插入子节点
+func (n *radixNode[V]) addChild(child *radixNode[V]) {
+	b := child.prefix[0]
+	i := sort.Search(len(n.children), func(i int) bool {
+		return n.children[i].prefix[0] >= b
+	})
+	n.children = append(n.children, nil)
+	copy(n.children[i+1:], n.children[i:])
+	n.children[i] = child
+}
+
+// removeChild 删除首字节为 b 的子节点
+func (n *radixNode[V]) removeChild(b byte) {
+	i, child := n.getChild(b)
+	if child == nil {
+		return
+	}
+	copy(n.children[i:], n.children[i+1:])
+	n.children[len(n.children)-1] = nil
+	n.children = n.children[:len(n.children)-1]
+}
+
+// mergeChild 将唯一的子节点合并到当前节点
+func (n *radixNode[V]) mergeChild() {
+	child := n.children[0]
+	n.prefix += child.prefix
+	n.value = child.value
+	n.hasValue = child.hasValue
+	n.children = child.children
+}
+
+// walk 按字典序遍历以当前节点为根的子树，fn 返回 false 时停止遍历
+// 返回 false 表示遍历已终止
+func (n *radixNode[V]) walk(path string, fn func(key string, value V) bool) bool {
+	if n.hasValue && !fn(path, n.value) {
+		return false
+	}
+	for _, child := range n.children {
+		if !child.walk(path+child.prefix, fn) {
+			return false
+		}
+	}
+	return true
+}
+
+// commonPrefixLen 返回两个字符串公共前缀的长度
+func commonPrefixLen(a, b string) int {
+	i := 0
+	for i < len(a) && i < len(b) && a[i] == b[i] {
+		i++
+	}
+	return i
+}
@@ -0,0 +1,178 @@
+package trie
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// TestRadixTreeInsertAndGet 测试插入和查找
+func TestRadixTreeInsertAndGet(t *testing.T) {
+	tr := NewRadixTree[int]()
+	keys := []string{"romane", "romanus", "romulus", "rubens", "ruber", "rubicon", "rubicundus", "r", ""}
+	for i, k := range keys {
+		tr.Insert(k, i)
+	}
+	if tr.Size() != len(keys) {
+		t.Fatalf("期望大小为%d，实际为%d", len(keys), tr.Size())
+	}
+	for i, k := range keys {
+		if got, ok := tr.Get(k); !ok || got != i {
+			t.Errorf("Get(%q) = (%d, %v)，期望 (%d, true)", k, got, ok, i)
+		}
+	}
+	for _, k := range []string{"rom", "roman", "rubi", "rx", "romanes"} {
+		if _, ok := tr.Get(k); ok {
+			t.Errorf("不应找到键%q", k)
+		}
+	}
+
+	// 共享前缀只存储一次
+	if len(tr.root.children) != 1 || tr.root.children[0].prefix != "r" {
+		t.Errorf("根节点应只有一条边r")
+	}
+}
+
+// TestRadixTreeDelete 测试删除及节点合并
+func TestRadixTreeDelete(t *testing.T) {
+	tr := NewRadixTree[int]()
+	tr.Insert("test", 1)
+	tr.Insert("team", 2)
+	tr.Insert("toast", 3)
+
+	if tr.Delete("te") {
+		t.Error("删除不存在的键应该返回false")
+	}
+	if !tr.Delete("team") {
+		t.Fatal("删除team失败")
+	}
+	if got, ok := tr.Get("test"); !ok || got != 1 {
+		t.Error("删除team不应影响test")
+	}
+
+	// 删除后 "te" + "st" 应合并为 "est"
+	_, tNode := tr.root.getChild('t')
+	_, eNode := tNode.getChild('e')
+	if eNode == nil || eNode.prefix != "est" {
+		t.Errorf("删除后节点未合并")
+	}
+
+	tr.Delete("test")
+	tr.Delete("toast")
+	if !tr.IsEmpty() || len(tr.root.children) != 0 {
+		t.Error("删除全部键后应为空")
+	}
+}
+
+// TestRadixTreeLongestPrefixMatch 测试最长前缀匹配
+func TestRadixTreeLongestPrefixMatch(t *testing.T) {
+	tr := NewRadixTree[string]()
+	tr.Insert("/", "root")
+	tr.Insert("/api", "api")
+	tr.Insert("/api/v1", "v1")
+	tr.Insert("/static", "static")
+
+	tests := []struct {
+		path    string
+		wantKey string
+		wantVal string
+		wantOK  bool
+	}{
+		{"/api/v1/users", "/api/v1", "v1", true},
+		{"/api/v2", "/api", "api", true},
+		{"/apix", "/api", "api", true},
+		{"/index.html", "/", "root", true},
+		{"/static", "/static", "static", true},
+		{"index", "", "", false},
+	}
+	for _, tt := range tests {
+		key, val, ok := tr.LongestPrefixMatch(tt.path)
+		if key != tt.wantKey || val != tt.wantVal || ok != tt.wantOK {
+			t.Errorf("LongestPrefixMatch(%q) = (%q, %q, %v)，期望 (%q, %q, %v)",
+				tt.path, key, val, ok, tt.wantKey, tt.wantVal, tt.wantOK)
+		}
+	}
+}
+
+// TestRadixTreeKeysWithPrefix 测试前缀查询
+func TestRadixTreeKeysWithPrefix(t *testing.T) {
+	tr := NewRadixTree[int]()
+	for i, k := range []string{"car", "cart", "carbon", "cat", "dog"} {
+		tr.Insert(k, i)
+	}
+	if got, want := tr.KeysWithPrefix("ca"), []string{"car", "carbon", "cart", "cat"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("KeysWithPrefix(ca) = %v，期望 %v", got, want)
+	}
+	if got, want := tr.KeysWithPrefix("carb"), []string{"carbon"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("KeysWithPrefix(carb) = %v，期望 %v", got, want)
+	}
+	if got := tr.KeysWithPrefix("cab"); len(got) != 0 {
+		t.Errorf("KeysWithPrefix(cab) 应为空，实际为 %v", got)
+	}
+}
+
+// TestRadixTreeRandom 随机插入删除与map对比
+func TestRadixTreeRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	tr := NewRadixTree[int]()
+	expected := make(map[string]int)
+	for i := 0; i < 2000; i++ {
+		key := fmt.Sprintf("%b", r.Intn(256))
+		if r.Intn(3) == 0 {
+			_, exists := expected[key]
+			if tr.Delete(key) != exists {
+				t.Fatalf("Delete(%q)返回值错误", key)
+			}
+			delete(expected, key)
+		} else {
+			tr.Insert(key, i)
+			expected[key] = i
+		}
+	}
+	if tr.Size() != len(expected) {
+		t.Fatalf("期望大小为%d，实际为%d", len(expected), tr.Size())
+	}
+	for k, v := range expected {
+		if got, ok := tr.Get(k); !ok || got != v {
+			t.Errorf("Get(%q) = (%d, %v)，期望 (%d, true)", k, got, ok, v)
+		}
+	}
+}
+
+// TestRadixTreeAll 测试按字典序遍历所有键
+func TestRadixTreeAll(t *testing.T) {
+	tr := NewRadixTree[int]()
+	for i, k := range []string{"romane", "romanus", "romulus", "rubens", "ruber", "rubicon"} {
+		tr.Insert(k, i)
+	}
+	var got []string
+	for key := range tr.All() {
+		got = append(got, key)
+		if len(got) == 3 {
+			break
+		}
+	}
+	want := []string{"romane", "romanus", "romulus"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("期望 %v，实际为 %v", want, got)
+	}
+}
+
+// TestRadixTreeClone 测试基数树的深拷贝
+func TestRadixTreeClone(t *testing.T) {
+	tr := NewRadixTree[int]()
+	for i, key := range []string{"romane", "romanus", "romulus", "rubens", "ruber"} {
+		tr.Insert(key, i)
+	}
+	clone := tr.Clone(func(v int) int { return v * 10 })
+	tr.Delete("romulus")
+	tr.Insert("rubicon", 5)
+
+	if v, ok := clone.Get("romulus"); !ok || v != 20 {
+		t.Errorf("Get(romulus) = (%v, %v)，期望 (20, true)", v, ok)
+	}
+	if _, ok := clone.Get("rubicon"); ok || clone.Size() != 5 {
+		t.Error("修改原基数树不应影响拷贝")
+	}
+}
@@ -0,0 +1,218 @@
+package trie
+
+import (
+	"iter"
+	"sort"
+)
+
+// node 前缀树节点
+type node[V any] struct {
+	children map[rune]*node[V] // 子节点，以字符为键
+	value    V                 // 单词对应的值（仅 isEnd 为 true 时有效）
+	isEnd    bool              // 是否为某个单词的结尾
+}
+
+// Trie 前缀树（字典树）
+// 以 rune 为单位存储字符串，每个单词可关联一个类型为 V 的值
+type Trie[V any] struct {
+	root *node[V] // 根节点，表示空前缀
+	size int      // 单词数量
+}
+
+// New 创建新的前缀树
+// 时间复杂度: O(1)
+func New[V any]() *Trie[V] {
+	return &Trie[V]{root: newNode[V]()}
+}
+
+// newNode 创建新的前缀树节点
+func newNode[V any]() *node[V] {
+	return &node[V]{children: make(map[rune]*node[V])}
+}
+
+// Insert 插入单词及其对应的值，单词已存在时更新值
+// 时间复杂度: O(m)，m 为单词长度
+func (t *Trie[V]) Insert(word string, value V) {
+	current := t.root
+	for _, ch := range word {
+		child, ok := current.children[ch]
+		if !ok {
+			child = newNode[V]()
+			current.children[ch] = child
+		}
+		current = child
+	}
+	if !current.isEnd {
+		current.isEnd = true
+		t.size++
+	}
+	current.value = value
+}
+
+// Get 获取单词对应的值
+// 时间复杂度: O(m)
+func (t *Trie[V]) Get(word string) (V, bool) {
+	n := t.find(word)
+	if n == nil || !n.isEnd {
+		var zero V
+		return zero, false
+	}
+	return n.value, true
+}
+
+// Contains 检查单词是否存在
+// 时间复杂度: O(m)
+func (t *Trie[V]) Contains(word string) bool {
+	n := t.find(word)
+	return n != nil && n.isEnd
+}
+
+// Delete 删除单词，并回收不再被使用的节点
+// 返回是否成功删除
+// 时间复杂度: O(m)
+func (t *Trie[V]) Delete(word string) bool {
+	// 记录路径，用于删除后自底向上回收空节点
+	runes := []rune(word)
+	path := make([]*node[V], 0, len(runes)+1)
+	current := t.root
+	path = append(path, current)
+	for _, ch := range runes {
+		child, ok := current.children[ch]
+		if !ok {
+			return false
+		}
+		current = child
+		path = append(path, current)
+	}
+	if !current.isEnd {
+		return false
+	}
+
+	var zero V
+	current.isEnd = false
+	current.value = zero
+	t.size--
+
+	for i := len(path) - 1; i > 0; i-- {
+		n := path[i]
+		if n.isEnd || len(n.children) > 0 {
+			break
+		}
+		delete(path[i-1].children, runes[i-1])
+	}
+	return true
+}
+
+// HasPrefix 检查是否存在以 prefix 为前缀的单词
+// 时间复杂度: O(m)
+func (t *Trie[V]) HasPrefix(prefix string) bool {
+	n := t.find(prefix)
+	// 除根节点外，所有节点都位于某个单词的路径上
+	return n != nil && (n != t.root || t.size > 0)
+}
+
+// WordsWithPrefix 按字典序返回以 prefix 为前缀的单词
+// 参数：
+//   - prefix: 前缀
+//   - limit: 最多返回的单词数量，小于等于0表示不限制
+//
+// 时间复杂度: O(m + k)，k 为遍历的节点数
+func (t *Trie[V]) WordsWithPrefix(prefix string, limit int) []string {
+	result := make([]string, 0)
+	n := t.find(prefix)
+	if n == nil {
+		return result
+	}
+	t.walk(n, []rune(prefix), func(word string, _ V) bool {
+		result = append(result, word)
+		return limit <= 0 || len(result) < limit
+	})
+	return result
+}
+
+// Size 返回单词数量
+// 时间复杂度: O(1)
+func (t *Trie[V]) Size() int {
+	return t.size
+}
+
+// IsEmpty 检查前缀树是否为空
+// 时间复杂度: O(1)
+func (t *Trie[V]) IsEmpty() bool {
+	return t.size == 0
+}
+
+// All 返回按字典序遍历所有单词及其值的迭代器
+// 遍历过程中不应修改前缀树
+func (t *Trie[V]) All() iter.Seq2[string, V] {
+	return func(yield func(string, V) bool) {
+		t.walk(t.root, nil, yield)
+	}
+}
+
+// Clear 清空前缀树
+// 时间复杂度: O(1)
+func (t *Trie[V]) Clear() {
+	t.root = newNode[V]()
+	t.size = 0
+}
+
+// Clone 返回前缀树的深拷贝
+// copier 用于复制每个单词对应的值，为 nil 时直接赋值
+// 时间复杂度: O(N)，N 为节点数量
+func (t *Trie[V]) Clone(copier func(V) V) *Trie[V] {
+	return &Trie[V]{root: t.root.clone(copier), size: t.size}
+}
+
+// clone 递归复制以 n 为根的子树
+func (n *node[V]) clone(copier func(V) V) *node[V] {
+	clone := &node[V]{
+		children: make(map[rune]*node[V], len(n.children)),
+		value:    n.value,
+		isEnd:    n.isEnd,
+	}
+	if n.isEnd && copier != nil {
+		clone.value = copier(n.value)
+	}
+	for r, child := range n.children {
+		clone.children[r] = child.clone(copier)
+	}
+	return clone
+}
+
+// find 查找字符串对应的节点，不存在时返回 nil
+func (t *Trie[V]) find(s string) *node[V] {
+	current := t.root
+	for _, ch := range s {
+		child, ok := current.children[ch]
+		if !ok {
+			return nil
+		}
+		current = child
+	}
+	return current
+}
+
+// walk 深度优先按字典序遍历单词，fn 返回 false 时停止遍历
+// 返回 false 表示遍历已终止
+func (t *Trie[V]) walk(n *node[V], prefix []rune, fn func(word string, value V) bool) bool {
+	if n.isEnd && !fn(string(prefix), n.value) {
+		return false
+	}
+	for _, ch := range sortedKeys(n.children) {
+		if !t.walk(n.children[ch], append(prefix, ch), fn) {
+			return false
+		}
+	}
+	return true
+}
+
+// sortedKeys 返回排好序的子节点字符
+func sortedKeys[V any](children map[rune]*node[V]) []rune {
+	keys := make([]rune, 0, len(children))
+	for ch := range children {
+		keys = append(keys, ch)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	return keys
+}
@@ -0,0 +1,162 @@
+package trie
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestTrieInsertAndGet 测试插入和查找
+func TestTrieInsertAndGet(t *testing.T) {
+	tr := New[int]()
+
+	t.Run("空树操作", func(t *testing.T) {
+		if _, ok := tr.Get("a"); ok {
+			t.Error("空树不应找到任何单词")
+		}
+		if tr.HasPrefix("") {
+			t.Error("空树不应存在任何前缀")
+		}
+	})
+
+	t.Run("插入和查找", func(t *testing.T) {
+		words := map[string]int{"apple": 1, "app": 2, "banana": 3, "中国": 4, "中文": 5}
+		for w, v := range words {
+			tr.Insert(w, v)
+		}
+		if tr.Size() != len(words) {
+			t.Errorf("期望大小为%d，实际为%d", len(words), tr.Size())
+		}
+		for w, v := range words {
+			if got, ok := tr.Get(w); !ok || got != v {
+				t.Errorf("Get(%q) = (%d, %v)，期望 (%d, true)", w, got, ok, v)
+			}
+		}
+		if tr.Contains("ap") {
+			t.Error("前缀ap不是单词")
+		}
+	})
+
+	t.Run("更新已存在的单词", func(t *testing.T) {
+		tr.Insert("app", 20)
+		if got, _ := tr.Get("app"); got != 20 {
+			t.Errorf("更新后期望20，实际为%d", got)
+		}
+		if tr.Size() != 5 {
+			t.Errorf("更新不应改变大小，实际为%d", tr.Size())
+		}
+	})
+}
+
+// TestTrieDelete 测试删除
+func TestTrieDelete(t *testing.T) {
+	tr := New[int]()
+	tr.Insert("app", 1)
+	tr.Insert("apple", 2)
+
+	if tr.Delete("ap") {
+		t.Error("删除不存在的单词应该返回false")
+	}
+	if !tr.Delete("apple") {
+		t.Error("删除apple失败")
+	}
+	if !tr.Contains("app") {
+		t.Error("删除apple不应影响app")
+	}
+	if tr.HasPrefix("appl") {
+		t.Error("删除后应回收apple独有的节点")
+	}
+	if !tr.Delete("app") || !tr.IsEmpty() {
+		t.Error("删除全部单词后应为空")
+	}
+	if len(tr.root.children) != 0 {
+		t.Error("删除全部单词后根节点不应有子节点")
+	}
+}
+
+// TestTrieWordsWithPrefix 测试前缀查询
+func TestTrieWordsWithPrefix(t *testing.T) {
+	tr := New[struct{}]()
+	for _, w := range []string{"car", "cart", "carbon", "cat", "dog", "ca"} {
+		tr.Insert(w, struct{}{})
+	}
+
+	tests := []struct {
+		prefix string
+		limit  int
+		want   []string
+	}{
+		{"ca", 0, []string{"ca", "car", "carbon", "cart", "cat"}},
+		{"car", 0, []string{"car", "carbon", "cart"}},
+		{"ca", 2, []string{"ca", "car"}},
+		{"x", 0, []string{}},
+		{"", 0, []string{"ca", "car", "carbon", "cart", "cat", "dog"}},
+	}
+	for _, tt := range tests {
+		got := tr.WordsWithPrefix(tt.prefix, tt.limit)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("WordsWithPrefix(%q, %d) = %v，期望 %v", tt.prefix, tt.limit, got, tt.want)
+		}
+	}
+
+	if !tr.HasPrefix("do") || tr.HasPrefix("dot") {
+		t.Error("HasPrefix结果不正确")
+	}
+}
+
+// 性能测试
+func BenchmarkTrie(b *testing.B) {
+	tr := New[int]()
+	words := []string{"alpha", "beta", "gamma", "delta", "epsilon", "zeta", "eta", "theta"}
+
+	b.Run("插入", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			tr.Insert(words[i%len(words)], i)
+		}
+	})
+
+	b.Run("查找", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			tr.Get(words[i%len(words)])
+		}
+	})
+}
+
+// TestTrieAll 测试按字典序遍历所有单词
+func TestTrieAll(t *testing.T) {
+	tr := New[int]()
+	words := []string{"tea", "ten", "to", "inn", "in", "中文"}
+	for i, w := range words {
+		tr.Insert(w, i)
+	}
+	var got []string
+	for word, value := range tr.All() {
+		if words[value] != word {
+			t.Errorf("单词%s对应的值错误: %d", word, value)
+		}
+		got = append(got, word)
+	}
+	want := []string{"in", "inn", "tea", "ten", "to", "中文"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("期望 %v，实际为 %v", want, got)
+	}
+}
+
+// TestTrieClone 测试前缀树的深拷贝
+func TestTrieClone(t *testing.T) {
+	tr := New[[]int]()
+	tr.Insert("apple", []int{1})
+	tr.Insert("app", []int{2})
+	clone := tr.Clone(func(v []int) []int { return append([]int(nil), v...) })
+
+	v, _ := tr.Get("apple")
+	v[0] = 100
+	tr.Delete("app")
+	clone.Insert("banana", nil)
+
+	if v, ok := clone.Get("apple"); !ok || v[0] != 1 {
+		t.Errorf("深拷贝不应受原值修改的影响，实际为%v", v)
+	}
+	if !clone.Contains("app") || tr.Contains("banana") || clone.Size() != 3 {
+		t.Error("拷贝与原前缀树应互不影响")
+	}
+}
@@ -0,0 +1,96 @@
+// Package tuple 提供泛型的二元组、三元组及其比较函数，以及在迭代器上组合和拆分元组的工具
+// 键值对容器可以通过 Entries 把 iter.Seq2 转换为元素为 Pair 的 iter.Seq，
+// 从而与只接受 iter.Seq 的 stream、algo 等包配合使用
+package tuple
+
+import "fmt"
+
+// Pair 二元组
+type Pair[A, B any] struct {
+	First  A // 第一个元素
+	Second B // 第二个元素
+}
+
+// NewPair 创建二元组
+// 时间复杂度: O(1)
+func NewPair[A, B any](first A, second B) Pair[A, B] {
+	return Pair[A, B]{First: first, Second: second}
+}
+
+// Unpack 返回二元组的两个元素，便于多重赋值
+func (p Pair[A, B]) Unpack() (A, B) {
+	return p.First, p.Second
+}
+
+// Swap 返回交换两个元素后的二元组
+func (p Pair[A, B]) Swap() Pair[B, A] {
+	return Pair[B, A]{First: p.Second, Second: p.First}
+}
+
+// String 返回形如 (a, b) 的字符串表示
+// 实现 fmt.Stringer 接口
+func (p Pair[A, B]) String() string {
+	return fmt.Sprintf("(%v, %v)", p.First, p.Second)
+}
+
+// Triple 三元组
+type Triple[A, B, C any] struct {
+	First  A // 第一个元素
+	Second B // 第二个元素
+	Third  C // 第三个元素
+}
+
+// NewTriple 创建三元组
+// 时间复杂度: O(1)
+func NewTriple[A, B, C any](first A, second B, third C) Triple[A, B, C] {
+	return Triple[A, B, C]{First: first, Second: second, Third: third}
+}
+
+// Unpack 返回三元组的三个元素，便于多重赋值
+func (t Triple[A, B, C]) Unpack() (A, B, C) {
+	return t.First, t.Second, t.Third
+}
+
+// String 返回形如 (a, b, c) 的字符串表示
+// 实现 fmt.Stringer 接口
+func (t Triple[A, B, C]) String() string {
+	return fmt.Sprintf("(%v, %v, %v)", t.First, t.Second, t.Third)
+}
+
+// ComparePair 返回按字典序比较二元组的函数：先用 cmpA 比较第一个元素，相等时再用 cmpB 比较第二个元素
+// 例如 tuple.ComparePair(cmp.Compare[int], strings.Compare) 可以直接传给有序容器或 slices.SortFunc
+func ComparePair[A, B any](cmpA func(a, b A) int, cmpB func(a, b B) int) func(x, y Pair[A, B]) int {
+	return func(x, y Pair[A, B]) int {
+		if c := cmpA(x.First, y.First); c != 0 {
+			return c
+		}
+		return cmpB(x.Second, y.Second)
+	}
+}
+
+// CompareFirst 返回只比较二元组第一个元素的函数，常用于按键排序键值对
+func CompareFirst[A, B any](cmpA func(a, b A) int) func(x, y Pair[A, B]) int {
+	return func(x, y Pair[A, B]) int {
+		return cmpA(x.First, y.First)
+	}
+}
+
+// CompareSecond 返回只比较二元组第二个元素的函数，常用于按值排序键值对
+func CompareSecond[A, B any](cmpB func(a, b B) int) func(x, y Pair[A, B]) int {
+	return func(x, y Pair[A, B]) int {
+		return cmpB(x.Second, y.Second)
+	}
+}
+
+// CompareTriple 返回按字典序依次比较三元组三个元素的函数
+func CompareTriple[A, B, C any](cmpA func(a, b A) int, cmpB func(a, b B) int, cmpC func(a, b C) int) func(x, y Triple[A, B, C]) int {
+	return func(x, y Triple[A, B, C]) int {
+		if c := cmpA(x.First, y.First); c != 0 {
+			return c
+		}
+		if c := cmpB(x.Second, y.Second); c != 0 {
+			return c
+		}
+		return cmpC(x.Third, y.Third)
+	}
+}
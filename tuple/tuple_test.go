@@ -0,0 +1,78 @@
+package tuple
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// TestPair 测试二元组的构造与访问
+func TestPair(t *testing.T) {
+	p := NewPair("a", 1)
+	if p.First != "a" || p.Second != 1 {
+		t.Errorf("NewPair = %v", p)
+	}
+	k, v := p.Unpack()
+	if k != "a" || v != 1 {
+		t.Errorf("Unpack() = %v, %v", k, v)
+	}
+	if s := p.Swap(); s.First != 1 || s.Second != "a" {
+		t.Errorf("Swap() = %v", s)
+	}
+	if s := p.String(); s != "(a, 1)" {
+		t.Errorf("String() = %q", s)
+	}
+}
+
+// TestTriple 测试三元组的构造与访问
+func TestTriple(t *testing.T) {
+	tr := NewTriple(1, "b", 2.5)
+	a, b, c := tr.Unpack()
+	if a != 1 || b != "b" || c != 2.5 {
+		t.Errorf("Unpack() = %v, %v, %v", a, b, c)
+	}
+	if s := tr.String(); s != "(1, b, 2.5)" {
+		t.Errorf("String() = %q", s)
+	}
+}
+
+// TestCompare 测试二元组与三元组的比较函数
+func TestCompare(t *testing.T) {
+	t.Run("ComparePair", func(t *testing.T) {
+		pairs := []Pair[int, string]{{2, "a"}, {1, "b"}, {2, "0"}, {1, "a"}}
+		slices.SortFunc(pairs, ComparePair(cmp.Compare[int], strings.Compare))
+		want := []Pair[int, string]{{1, "a"}, {1, "b"}, {2, "0"}, {2, "a"}}
+		if !slices.Equal(pairs, want) {
+			t.Errorf("排序结果 %v，期望 %v", pairs, want)
+		}
+	})
+
+	t.Run("CompareFirst和CompareSecond", func(t *testing.T) {
+		x, y := NewPair(1, 9), NewPair(1, 3)
+		if CompareFirst[int, int](cmp.Compare[int])(x, y) != 0 {
+			t.Error("CompareFirst 只应比较第一个元素")
+		}
+		if CompareSecond[int](cmp.Compare[int])(x, y) <= 0 {
+			t.Error("CompareSecond 应比较第二个元素")
+		}
+	})
+
+	t.Run("CompareTriple", func(t *testing.T) {
+		c := CompareTriple(cmp.Compare[int], cmp.Compare[int], cmp.Compare[int])
+		tests := []struct {
+			x, y Triple[int, int, int]
+			want int
+		}{
+			{NewTriple(1, 2, 3), NewTriple(1, 2, 3), 0},
+			{NewTriple(1, 2, 3), NewTriple(1, 2, 4), -1},
+			{NewTriple(1, 3, 0), NewTriple(1, 2, 9), 1},
+			{NewTriple(0, 9, 9), NewTriple(1, 0, 0), -1},
+		}
+		for _, tt := range tests {
+			if got := c(tt.x, tt.y); got != tt.want {
+				t.Errorf("CompareTriple(%v, %v) = %d，期望 %d", tt.x, tt.y, got, tt.want)
+			}
+		}
+	})
+}
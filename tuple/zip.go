@@ -0,0 +1,110 @@
+package tuple
+
+import "iter"
+
+// Zip 把两个序列按位置组合为二元组序列，较短的序列结束时停止
+// 时间复杂度: O(min(n, m))
+func Zip[A, B any](a iter.Seq[A], b iter.Seq[B]) iter.Seq[Pair[A, B]] {
+	return func(yield func(Pair[A, B]) bool) {
+		nextB, stop := iter.Pull(b)
+		defer stop()
+		for va := range a {
+			vb, ok := nextB()
+			if !ok || !yield(Pair[A, B]{First: va, Second: vb}) {
+				return
+			}
+		}
+	}
+}
+
+// Zip3 把三个序列按位置组合为三元组序列，最短的序列结束时停止
+// 时间复杂度: O(min(n, m, k))
+func Zip3[A, B, C any](a iter.Seq[A], b iter.Seq[B], c iter.Seq[C]) iter.Seq[Triple[A, B, C]] {
+	return func(yield func(Triple[A, B, C]) bool) {
+		nextB, stopB := iter.Pull(b)
+		defer stopB()
+		nextC, stopC := iter.Pull(c)
+		defer stopC()
+		for va := range a {
+			vb, okB := nextB()
+			if !okB {
+				return
+			}
+			vc, okC := nextC()
+			if !okC || !yield(Triple[A, B, C]{First: va, Second: vb, Third: vc}) {
+				return
+			}
+		}
+	}
+}
+
+// Unzip 把二元组序列拆分为两个切片
+// 时间复杂度: O(n)
+func Unzip[A, B any](seq iter.Seq[Pair[A, B]]) ([]A, []B) {
+	as, bs := make([]A, 0), make([]B, 0)
+	for p := range seq {
+		as = append(as, p.First)
+		bs = append(bs, p.Second)
+	}
+	return as, bs
+}
+
+// Unzip3 把三元组序列拆分为三个切片
+// 时间复杂度: O(n)
+func Unzip3[A, B, C any](seq iter.Seq[Triple[A, B, C]]) ([]A, []B, []C) {
+	as, bs, cs := make([]A, 0), make([]B, 0), make([]C, 0)
+	for t := range seq {
+		as = append(as, t.First)
+		bs = append(bs, t.Second)
+		cs = append(cs, t.Third)
+	}
+	return as, bs, cs
+}
+
+// Entries 把键值对迭代器转换为二元组序列，例如把哈希表或 B 树的 All 转换为 iter.Seq
+// 时间复杂度: O(1)
+func Entries[K, V any](seq iter.Seq2[K, V]) iter.Seq[Pair[K, V]] {
+	return func(yield func(Pair[K, V]) bool) {
+		for k, v := range seq {
+			if !yield(Pair[K, V]{First: k, Second: v}) {
+				return
+			}
+		}
+	}
+}
+
+// Seq2 把二元组序列转换为键值对迭代器，是 Entries 的逆操作
+// 时间复杂度: O(1)
+func Seq2[K, V any](seq iter.Seq[Pair[K, V]]) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for p := range seq {
+			if !yield(p.First, p.Second) {
+				return
+			}
+		}
+	}
+}
+
+// Firsts 返回只产出二元组第一个元素的序列
+// 时间复杂度: O(1)
+func Firsts[A, B any](seq iter.Seq[Pair[A, B]]) iter.Seq[A] {
+	return func(yield func(A) bool) {
+		for p := range seq {
+			if !yield(p.First) {
+				return
+			}
+		}
+	}
+}
+
+// Seconds 返回只产出二元组第二个元素的序列
+// 时间复杂度: O(1)
+func Seconds[A, B any](seq iter.Seq[Pair[A, B]]) iter.Seq[B] {
+	return func(yield func(B) bool) {
+		for p := range seq {
+			if !yield(p.Second) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package tuple
+
+import (
+	"maps"
+	"slices"
+	"testing"
+
+	"godatastructure/btree"
+)
+
+// TestZip 测试按位置组合序列
+func TestZip(t *testing.T) {
+	t.Run("长度相同", func(t *testing.T) {
+		got := slices.Collect(Zip(slices.Values([]int{1, 2}), slices.Values([]string{"a", "b"})))
+		want := []Pair[int, string]{{1, "a"}, {2, "b"}}
+		if !slices.Equal(got, want) {
+			t.Errorf("Zip = %v", got)
+		}
+	})
+
+	t.Run("在较短的序列结束时停止", func(t *testing.T) {
+		got := slices.Collect(Zip(slices.Values([]int{1, 2, 3}), slices.Values([]int{4})))
+		if !slices.Equal(got, []Pair[int, int]{{1, 4}}) {
+			t.Errorf("Zip = %v", got)
+		}
+		got = slices.Collect(Zip(slices.Values([]int{}), slices.Values([]int{4})))
+		if len(got) != 0 {
+			t.Errorf("Zip = %v", got)
+		}
+	})
+
+	t.Run("Zip3", func(t *testing.T) {
+		got := slices.Collect(Zip3(slices.Values([]int{1, 2, 3}), slices.Values([]string{"a", "b"}), slices.Values([]bool{true, false, true})))
+		want := []Triple[int, string, bool]{{1, "a", true}, {2, "b", false}}
+		if !slices.Equal(got, want) {
+			t.Errorf("Zip3 = %v", got)
+		}
+	})
+
+	t.Run("提前停止", func(t *testing.T) {
+		n := 0
+		for range Zip(slices.Values([]int{1, 2, 3}), slices.Values([]int{4, 5, 6})) {
+			n++
+			break
+		}
+		if n != 1 {
+			t.Errorf("n = %d", n)
+		}
+	})
+}
+
+// TestUnzip 测试拆分元组序列
+func TestUnzip(t *testing.T) {
+	as, bs := Unzip(slices.Values([]Pair[int, string]{{1, "a"}, {2, "b"}}))
+	if !slices.Equal(as, []int{1, 2}) || !slices.Equal(bs, []string{"a", "b"}) {
+		t.Errorf("Unzip = %v, %v", as, bs)
+	}
+	as, bs = Unzip(slices.Values([]Pair[int, string]{}))
+	if as == nil || bs == nil || len(as) != 0 || len(bs) != 0 {
+		t.Errorf("空序列 Unzip = %#v, %#v", as, bs)
+	}
+	xs, ys, zs := Unzip3(Zip3(slices.Values([]int{1}), slices.Values([]int{2}), slices.Values([]int{3})))
+	if !slices.Equal(xs, []int{1}) || !slices.Equal(ys, []int{2}) || !slices.Equal(zs, []int{3}) {
+		t.Errorf("Unzip3 = %v, %v, %v", xs, ys, zs)
+	}
+}
+
+// TestEntries 测试键值对迭代器与二元组序列的互相转换
+func TestEntries(t *testing.T) {
+	tree := btree.NewBTree[int, string](2)
+	for i, s := range []string{"零", "一", "二"} {
+		tree.Put(i, s)
+	}
+	entries := slices.Collect(Entries(tree.All()))
+	want := []Pair[int, string]{{0, "零"}, {1, "一"}, {2, "二"}}
+	if !slices.Equal(entries, want) {
+		t.Errorf("Entries = %v", entries)
+	}
+	if keys := slices.Collect(Firsts(slices.Values(entries))); !slices.Equal(keys, []int{0, 1, 2}) {
+		t.Errorf("Firsts = %v", keys)
+	}
+	if values := slices.Collect(Seconds(slices.Values(entries))); !slices.Equal(values, []string{"零", "一", "二"}) {
+		t.Errorf("Seconds = %v", values)
+	}
+	m := maps.Collect(Seq2(slices.Values(entries)))
+	if len(m) != 3 || m[1] != "一" {
+		t.Errorf("Seq2 = %v", m)
+	}
+}
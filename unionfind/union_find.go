@@ -0,0 +1,177 @@
+package unionfind
+
+// DisjointSet 以整数 0..n-1 为元素的并查集
+// 使用按秩合并和路径压缩，单次操作的均摊时间复杂度为 O(α(n))
+type DisjointSet struct {
+	parent []int // 父节点，根节点的父节点是自身
+	rank   []int // 秩（树高的上界），仅对根节点有意义
+	count  int   // 集合数量
+}
+
+// New 创建包含 n 个单元素集合的并查集
+// 时间复杂度: O(n)
+func New(n int) *DisjointSet {
+	if n < 0 {
+		panic("元素数量不能为负数")
+	}
+	ds := &DisjointSet{
+		parent: make([]int, n),
+		rank:   make([]int, n),
+		count:  n,
+	}
+	for i := range ds.parent {
+		ds.parent[i] = i
+	}
+	return ds
+}
+
+// Add 添加一个新的单元素集合，返回新元素的编号
+// 时间复杂度: 均摊 O(1)
+func (ds *DisjointSet) Add() int {
+	x := len(ds.parent)
+	ds.parent = append(ds.parent, x)
+	ds.rank = append(ds.rank, 0)
+	ds.count++
+	return x
+}
+
+// Find 返回元素 x 所在集合的代表元素
+// 查找时进行路径压缩，将路径上的节点直接挂到根节点下
+// 时间复杂度: 均摊 O(α(n))
+func (ds *DisjointSet) Find(x int) int {
+	ds.checkIndex(x)
+	root := x
+	for ds.parent[root] != root {
+		root = ds.parent[root]
+	}
+	for ds.parent[x] != root {
+		next := ds.parent[x]
+		ds.parent[x] = root
+		x = next
+	}
+	return root
+}
+
+// Union 合并元素 x 和 y 所在的集合
+// 返回 true 表示发生了合并，false 表示两者原本就在同一集合中
+// 时间复杂度: 均摊 O(α(n))
+func (ds *DisjointSet) Union(x, y int) bool {
+	rootX, rootY := ds.Find(x), ds.Find(y)
+	if rootX == rootY {
+		return false
+	}
+	// 按秩合并：将秩较小的树挂到秩较大的树下
+	switch {
+	case ds.rank[rootX] < ds.rank[rootY]:
+		ds.parent[rootX] = rootY
+	case ds.rank[rootX] > ds.rank[rootY]:
+		ds.parent[rootY] = rootX
+	default:
+		ds.parent[rootY] = rootX
+		ds.rank[rootX]++
+	}
+	ds.count--
+	return true
+}
+
+// Connected 检查元素 x 和 y 是否在同一集合中
+// 时间复杂度: 均摊 O(α(n))
+func (ds *DisjointSet) Connected(x, y int) bool {
+	return ds.Find(x) == ds.Find(y)
+}
+
+// SetCount 返回集合数量
+// 时间复杂度: O(1)
+func (ds *DisjointSet) SetCount() int {
+	return ds.count
+}
+
+// Len 返回元素数量
+// 时间复杂度: O(1)
+func (ds *DisjointSet) Len() int {
+	return len(ds.parent)
+}
+
+// checkIndex 检查元素编号是否合法
+func (ds *DisjointSet) checkIndex(x int) {
+	if x < 0 || x >= len(ds.parent) {
+		panic("索引越界")
+	}
+}
+
+// KeyedDisjointSet 以任意可比较类型为元素的并查集
+// 内部将元素映射为整数编号，复用 DisjointSet 的实现
+type KeyedDisjointSet[T comparable] struct {
+	index map[T]int    // 元素到编号的映射
+	keys  []T          // 编号到元素的映射
+	ds    *DisjointSet // 底层的整数并查集
+}
+
+// NewKeyed 创建空的泛型并查集
+// 时间复杂度: O(1)
+func NewKeyed[T comparable]() *KeyedDisjointSet[T] {
+	return &KeyedDisjointSet[T]{
+		index: make(map[T]int),
+		ds:    New(0),
+	}
+}
+
+// Add 添加元素作为单元素集合，元素已存在时不做任何操作
+// 返回是否添加了新元素
+// 时间复杂度: 均摊 O(1)
+func (k *KeyedDisjointSet[T]) Add(x T) bool {
+	if _, ok := k.index[x]; ok {
+		return false
+	}
+	k.index[x] = k.ds.Add()
+	k.keys = append(k.keys, x)
+	return true
+}
+
+// Find 返回元素 x 所在集合的代表元素
+// 元素不存在时返回零值和 false
+// 时间复杂度: 均摊 O(α(n))
+func (k *KeyedDisjointSet[T]) Find(x T) (T, bool) {
+	i, ok := k.index[x]
+	if !ok {
+		var zero T
+		return zero, false
+	}
+	return k.keys[k.ds.Find(i)], true
+}
+
+// Union 合并元素 x 和 y 所在的集合，不存在的元素会被自动添加
+// 返回 true 表示发生了合并
+// 时间复杂度: 均摊 O(α(n))
+func (k *KeyedDisjointSet[T]) Union(x, y T) bool {
+	k.Add(x)
+	k.Add(y)
+	return k.ds.Union(k.index[x], k.index[y])
+}
+
+// Connected 检查元素 x 和 y 是否在同一集合中，任一元素不存在时返回 false
+// 时间复杂度: 均摊 O(α(n))
+func (k *KeyedDisjointSet[T]) Connected(x, y T) bool {
+	i, ok1 := k.index[x]
+	j, ok2 := k.index[y]
+	return ok1 && ok2 && k.ds.Connected(i, j)
+}
+
+// Contains 检查元素是否存在
+// 时间复杂度: O(1)
+func (k *KeyedDisjointSet[T]) Contains(x T) bool {
+	_, ok := k.index[x]
+	return ok
+}
+
+// SetCount 返回集合数量
+// 时间复杂度: O(1)
+func (k *KeyedDisjointSet[T]) SetCount() int {
+	return k.ds.SetCount()
+}
+
+// Len 返回元素数量
+// 时间复杂度: O(1)
+func (k *KeyedDisjointSet[T]) Len() int {
+	return len(k.keys)
+}
@@ -0,0 +1,107 @@
+package unionfind
+
+import (
+	"testing"
+)
+
+// TestDisjointSet 测试整数并查集
+func TestDisjointSet(t *testing.T) {
+	ds := New(10)
+	if ds.SetCount() != 10 || ds.Len() != 10 {
+		t.Fatalf("初始应有10个集合，实际为%d", ds.SetCount())
+	}
+
+	t.Run("合并", func(t *testing.T) {
+		if !ds.Union(0, 1) || !ds.Union(1, 2) || !ds.Union(3, 4) {
+			t.Error("合并不同集合应返回true")
+		}
+		if ds.Union(0, 2) {
+			t.Error("合并同一集合应返回false")
+		}
+		if ds.SetCount() != 7 {
+			t.Errorf("期望7个集合，实际为%d", ds.SetCount())
+		}
+	})
+
+	t.Run("连通性", func(t *testing.T) {
+		if !ds.Connected(0, 2) || !ds.Connected(3, 4) {
+			t.Error("已合并的元素应连通")
+		}
+		if ds.Connected(2, 3) || ds.Connected(5, 6) {
+			t.Error("未合并的元素不应连通")
+		}
+		if ds.Find(0) != ds.Find(2) {
+			t.Error("同一集合的代表元素应相同")
+		}
+	})
+
+	t.Run("添加元素", func(t *testing.T) {
+		x := ds.Add()
+		if x != 10 || ds.Len() != 11 || ds.SetCount() != 8 {
+			t.Errorf("添加元素后状态错误: x=%d, len=%d, count=%d", x, ds.Len(), ds.SetCount())
+		}
+		ds.Union(x, 0)
+		if !ds.Connected(10, 1) {
+			t.Error("新元素合并后应连通")
+		}
+	})
+
+	t.Run("越界访问", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Error("越界访问应该导致panic")
+			}
+		}()
+		ds.Find(100)
+	})
+}
+
+// TestDisjointSetPathCompression 测试路径压缩
+func TestDisjointSetPathCompression(t *testing.T) {
+	const n = 1000
+	ds := New(n)
+	for i := 1; i < n; i++ {
+		ds.Union(i-1, i)
+	}
+	root := ds.Find(n - 1)
+	for i := 0; i < n; i++ {
+		ds.Find(i)
+		if ds.parent[i] != root {
+			t.Fatalf("路径压缩后元素%d应直接指向根节点", i)
+		}
+	}
+	if ds.SetCount() != 1 {
+		t.Errorf("期望1个集合，实际为%d", ds.SetCount())
+	}
+}
+
+// TestKeyedDisjointSet 测试泛型并查集
+func TestKeyedDisjointSet(t *testing.T) {
+	ds := NewKeyed[string]()
+	ds.Add("a")
+	if ds.Add("a") {
+		t.Error("重复添加应返回false")
+	}
+	ds.Union("a", "b")
+	ds.Union("c", "d")
+	ds.Union("b", "d")
+	ds.Add("e")
+
+	if ds.Len() != 5 || ds.SetCount() != 2 {
+		t.Errorf("期望5个元素2个集合，实际为%d个元素%d个集合", ds.Len(), ds.SetCount())
+	}
+	if !ds.Connected("a", "c") || ds.Connected("a", "e") {
+		t.Error("连通性结果不正确")
+	}
+	if ds.Connected("a", "missing") || ds.Contains("missing") {
+		t.Error("不存在的元素不应连通")
+	}
+	ra, _ := ds.Find("a")
+	rd, _ := ds.Find("d")
+	if ra != rd {
+		t.Error("同一集合的代表元素应相同")
+	}
+	if _, ok := ds.Find("missing"); ok {
+		t.Error("不存在的元素Find应返回false")
+	}
+}